@@ -0,0 +1,1273 @@
+// Protocol buffer definition of the gorcb design and analysis services, for
+// typed integration into other structural tools. The messages mirror the
+// JSON output of the commands: lengths in mm, stresses in MPa, moments in
+// kN-m and forces in kN. gorcb serve --grpc serves them.
+//
+// The Go code of this package is generated from this file; regenerate it and
+// generate clients in other languages with protoc, e.g.
+//
+//   Go:     protoc -I api --go_out=api --go_opt=paths=source_relative --go-grpc_out=api --go-grpc_opt=paths=source_relative api/gorcb/v1/gorcb.proto
+//   C#:     the Grpc.Tools package, <Protobuf Include="gorcb.proto" />
+//   Python: python -m grpc_tools.protoc -I api --python_out=. --grpc_python_out=. api/gorcb/v1/gorcb.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: gorcb/v1/gorcb.proto
+
+package gorcbv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Member is a rectangular beam, the members of a batch file. Zero values
+// take the defaults of the batch file: cover 65 mm, f'c 28 MPa, fy 415 MPa.
+type Member struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // singly (default) or doubly
+	// Geometry (mm)
+	Width     float64 `protobuf:"fixed64,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height    float64 `protobuf:"fixed64,4,opt,name=height,proto3" json:"height,omitempty"`
+	Cover     float64 `protobuf:"fixed64,5,opt,name=cover,proto3" json:"cover,omitempty"`                          // To tension steel centroid
+	CoverComp float64 `protobuf:"fixed64,6,opt,name=cover_comp,json=coverComp,proto3" json:"cover_comp,omitempty"` // d'
+	// Materials (MPa)
+	Fc    float64 `protobuf:"fixed64,7,opt,name=fc,proto3" json:"fc,omitempty"`
+	Fy    float64 `protobuf:"fixed64,8,opt,name=fy,proto3" json:"fy,omitempty"`
+	Grade string  `protobuf:"bytes,9,opt,name=grade,proto3" json:"grade,omitempty"` // Steel grade in place of fy
+	// Factored actions (kN-m, kN)
+	Mu float64 `protobuf:"fixed64,10,opt,name=mu,proto3" json:"mu,omitempty"`
+	Vu float64 `protobuf:"fixed64,11,opt,name=vu,proto3" json:"vu,omitempty"`
+	// Provided reinforcement (mm²) for analysis
+	As  float64 `protobuf:"fixed64,12,opt,name=as,proto3" json:"as,omitempty"`
+	Asc float64 `protobuf:"fixed64,13,opt,name=asc,proto3" json:"asc,omitempty"`
+	// Stirrups for shear design
+	StirrupDia  float64 `protobuf:"fixed64,14,opt,name=stirrup_dia,json=stirrupDia,proto3" json:"stirrup_dia,omitempty"` // mm
+	StirrupLegs int32   `protobuf:"varint,15,opt,name=stirrup_legs,json=stirrupLegs,proto3" json:"stirrup_legs,omitempty"`
+	Fyt         float64 `protobuf:"fixed64,16,opt,name=fyt,proto3" json:"fyt,omitempty"` // MPa, fy when zero
+	// Lightweight concrete factor λ, 1 when zero
+	Lambda        float64 `protobuf:"fixed64,17,opt,name=lambda,proto3" json:"lambda,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Member) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Member) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Member) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Member) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Member) GetCover() float64 {
+	if x != nil {
+		return x.Cover
+	}
+	return 0
+}
+
+func (x *Member) GetCoverComp() float64 {
+	if x != nil {
+		return x.CoverComp
+	}
+	return 0
+}
+
+func (x *Member) GetFc() float64 {
+	if x != nil {
+		return x.Fc
+	}
+	return 0
+}
+
+func (x *Member) GetFy() float64 {
+	if x != nil {
+		return x.Fy
+	}
+	return 0
+}
+
+func (x *Member) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *Member) GetMu() float64 {
+	if x != nil {
+		return x.Mu
+	}
+	return 0
+}
+
+func (x *Member) GetVu() float64 {
+	if x != nil {
+		return x.Vu
+	}
+	return 0
+}
+
+func (x *Member) GetAs() float64 {
+	if x != nil {
+		return x.As
+	}
+	return 0
+}
+
+func (x *Member) GetAsc() float64 {
+	if x != nil {
+		return x.Asc
+	}
+	return 0
+}
+
+func (x *Member) GetStirrupDia() float64 {
+	if x != nil {
+		return x.StirrupDia
+	}
+	return 0
+}
+
+func (x *Member) GetStirrupLegs() int32 {
+	if x != nil {
+		return x.StirrupLegs
+	}
+	return 0
+}
+
+func (x *Member) GetFyt() float64 {
+	if x != nil {
+		return x.Fyt
+	}
+	return 0
+}
+
+func (x *Member) GetLambda() float64 {
+	if x != nil {
+		return x.Lambda
+	}
+	return 0
+}
+
+// Result is the design or analysis of a member
+type Result struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Member *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Mode   string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"` // design or analysis
+	// Resolved materials (MPa)
+	Fc float64 `protobuf:"fixed64,3,opt,name=fc,proto3" json:"fc,omitempty"`
+	Fy float64 `protobuf:"fixed64,4,opt,name=fy,proto3" json:"fy,omitempty"`
+	// Flexure
+	As            float64 `protobuf:"fixed64,5,opt,name=as,proto3" json:"as,omitempty"`   // Required (design) or provided (analysis) tension steel (mm²)
+	Asc           float64 `protobuf:"fixed64,6,opt,name=asc,proto3" json:"asc,omitempty"` // Required or provided compression steel (mm²)
+	EpsilonT      float64 `protobuf:"fixed64,7,opt,name=epsilon_t,json=epsilonT,proto3" json:"epsilon_t,omitempty"`
+	Phi           float64 `protobuf:"fixed64,8,opt,name=phi,proto3" json:"phi,omitempty"`
+	PhiMn         float64 `protobuf:"fixed64,9,opt,name=phi_mn,json=phiMn,proto3" json:"phi_mn,omitempty"`     // kN-m
+	Rho           float64 `protobuf:"fixed64,10,opt,name=rho,proto3" json:"rho,omitempty"`                     // Tension steel ratio As/(b·d)
+	RhoMax        float64 `protobuf:"fixed64,11,opt,name=rho_max,json=rhoMax,proto3" json:"rho_max,omitempty"` // Maximum ratio for a tension-controlled singly reinforced section
+	Adequate      bool    `protobuf:"varint,12,opt,name=adequate,proto3" json:"adequate,omitempty"`
+	Message       string  `protobuf:"bytes,13,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string  `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	NoConvergence bool    `protobuf:"varint,15,opt,name=no_convergence,json=noConvergence,proto3" json:"no_convergence,omitempty"` // The error is a solver that did not converge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Result) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *Result) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Result) GetFc() float64 {
+	if x != nil {
+		return x.Fc
+	}
+	return 0
+}
+
+func (x *Result) GetFy() float64 {
+	if x != nil {
+		return x.Fy
+	}
+	return 0
+}
+
+func (x *Result) GetAs() float64 {
+	if x != nil {
+		return x.As
+	}
+	return 0
+}
+
+func (x *Result) GetAsc() float64 {
+	if x != nil {
+		return x.Asc
+	}
+	return 0
+}
+
+func (x *Result) GetEpsilonT() float64 {
+	if x != nil {
+		return x.EpsilonT
+	}
+	return 0
+}
+
+func (x *Result) GetPhi() float64 {
+	if x != nil {
+		return x.Phi
+	}
+	return 0
+}
+
+func (x *Result) GetPhiMn() float64 {
+	if x != nil {
+		return x.PhiMn
+	}
+	return 0
+}
+
+func (x *Result) GetRho() float64 {
+	if x != nil {
+		return x.Rho
+	}
+	return 0
+}
+
+func (x *Result) GetRhoMax() float64 {
+	if x != nil {
+		return x.RhoMax
+	}
+	return 0
+}
+
+func (x *Result) GetAdequate() bool {
+	if x != nil {
+		return x.Adequate
+	}
+	return false
+}
+
+func (x *Result) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Result) GetNoConvergence() bool {
+	if x != nil {
+		return x.NoConvergence
+	}
+	return false
+}
+
+type BatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchRequest) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type BatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*Result              `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchResponse) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// SectionRequest is a section given either as a message or as the contents
+// of a section file, JSON or YAML, for the fields the message leaves out
+// (units, steel model, confinement)
+type SectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*SectionRequest_Section
+	//	*SectionRequest_File
+	Source        isSectionRequest_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectionRequest) Reset() {
+	*x = SectionRequest{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionRequest) ProtoMessage() {}
+
+func (x *SectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionRequest.ProtoReflect.Descriptor instead.
+func (*SectionRequest) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SectionRequest) GetSource() isSectionRequest_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *SectionRequest) GetSection() *Section {
+	if x != nil {
+		if x, ok := x.Source.(*SectionRequest_Section); ok {
+			return x.Section
+		}
+	}
+	return nil
+}
+
+func (x *SectionRequest) GetFile() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*SectionRequest_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+type isSectionRequest_Source interface {
+	isSectionRequest_Source()
+}
+
+type SectionRequest_Section struct {
+	Section *Section `protobuf:"bytes,1,opt,name=section,proto3,oneof"`
+}
+
+type SectionRequest_File struct {
+	File []byte `protobuf:"bytes,2,opt,name=file,proto3,oneof"`
+}
+
+func (*SectionRequest_Section) isSectionRequest_Source() {}
+
+func (*SectionRequest_File) isSectionRequest_Source() {}
+
+// Section is a polygon section in mm and MPa
+type Section struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Fc            float64                `protobuf:"fixed64,3,opt,name=fc,proto3" json:"fc,omitempty"`
+	Fy            float64                `protobuf:"fixed64,4,opt,name=fy,proto3" json:"fy,omitempty"`
+	Lambda        float64                `protobuf:"fixed64,5,opt,name=lambda,proto3" json:"lambda,omitempty"`   // 1.0 when zero
+	Vertices      []*Point               `protobuf:"bytes,6,rep,name=vertices,proto3" json:"vertices,omitempty"` // Counter-clockwise, y up from the bottom
+	Holes         []*Polygon             `protobuf:"bytes,7,rep,name=holes,proto3" json:"holes,omitempty"`
+	Reinforcement []*RebarLayer          `protobuf:"bytes,8,rep,name=reinforcement,proto3" json:"reinforcement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Section) Reset() {
+	*x = Section{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Section) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Section) ProtoMessage() {}
+
+func (x *Section) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Section.ProtoReflect.Descriptor instead.
+func (*Section) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Section) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Section) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Section) GetFc() float64 {
+	if x != nil {
+		return x.Fc
+	}
+	return 0
+}
+
+func (x *Section) GetFy() float64 {
+	if x != nil {
+		return x.Fy
+	}
+	return 0
+}
+
+func (x *Section) GetLambda() float64 {
+	if x != nil {
+		return x.Lambda
+	}
+	return 0
+}
+
+func (x *Section) GetVertices() []*Point {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+func (x *Section) GetHoles() []*Polygon {
+	if x != nil {
+		return x.Holes
+	}
+	return nil
+}
+
+func (x *Section) GetReinforcement() []*RebarLayer {
+	if x != nil {
+		return x.Reinforcement
+	}
+	return nil
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Polygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertices      []*Point               `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Polygon) GetVertices() []*Point {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type RebarLayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Y             float64                `protobuf:"fixed64,1,opt,name=y,proto3" json:"y,omitempty"`       // mm from the bottom of the section
+	Area          float64                `protobuf:"fixed64,2,opt,name=area,proto3" json:"area,omitempty"` // mm²
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // tension or compression, by position when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebarLayer) Reset() {
+	*x = RebarLayer{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebarLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebarLayer) ProtoMessage() {}
+
+func (x *RebarLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebarLayer.ProtoReflect.Descriptor instead.
+func (*RebarLayer) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RebarLayer) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RebarLayer) GetArea() float64 {
+	if x != nil {
+		return x.Area
+	}
+	return 0
+}
+
+func (x *RebarLayer) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RebarLayer) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// SectionResult is the flexural analysis of a section
+type SectionResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Neutral axis and compression block (mm)
+	C        float64 `protobuf:"fixed64,1,opt,name=c,proto3" json:"c,omitempty"` // Neutral axis depth from the top
+	A        float64 `protobuf:"fixed64,2,opt,name=a,proto3" json:"a,omitempty"`
+	Beta1    float64 `protobuf:"fixed64,3,opt,name=beta1,proto3" json:"beta1,omitempty"`
+	EpsilonT float64 `protobuf:"fixed64,4,opt,name=epsilon_t,json=epsilonT,proto3" json:"epsilon_t,omitempty"` // At the lowest tension steel
+	// Forces (kN)
+	Cc          float64             `protobuf:"fixed64,5,opt,name=cc,proto3" json:"cc,omitempty"`
+	Cs          float64             `protobuf:"fixed64,6,opt,name=cs,proto3" json:"cs,omitempty"`
+	T           float64             `protobuf:"fixed64,7,opt,name=t,proto3" json:"t,omitempty"`
+	SteelLayers []*SteelLayerResult `protobuf:"bytes,8,rep,name=steel_layers,json=steelLayers,proto3" json:"steel_layers,omitempty"`
+	// Capacity
+	Phi   float64 `protobuf:"fixed64,9,opt,name=phi,proto3" json:"phi,omitempty"`
+	Mn    float64 `protobuf:"fixed64,10,opt,name=mn,proto3" json:"mn,omitempty"`                    // kN-m
+	PhiMn float64 `protobuf:"fixed64,11,opt,name=phi_mn,json=phiMn,proto3" json:"phi_mn,omitempty"` // kN-m
+	// Cracking (gross section)
+	Fr                float64 `protobuf:"fixed64,12,opt,name=fr,proto3" json:"fr,omitempty"`   // MPa
+	Mcr               float64 `protobuf:"fixed64,13,opt,name=mcr,proto3" json:"mcr,omitempty"` // kN-m
+	TensionControlled bool    `protobuf:"varint,14,opt,name=tension_controlled,json=tensionControlled,proto3" json:"tension_controlled,omitempty"`
+	BarFracture       bool    `protobuf:"varint,15,opt,name=bar_fracture,json=barFracture,proto3" json:"bar_fracture,omitempty"`
+	Message           string  `protobuf:"bytes,16,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SectionResult) Reset() {
+	*x = SectionResult{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionResult) ProtoMessage() {}
+
+func (x *SectionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionResult.ProtoReflect.Descriptor instead.
+func (*SectionResult) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SectionResult) GetC() float64 {
+	if x != nil {
+		return x.C
+	}
+	return 0
+}
+
+func (x *SectionResult) GetA() float64 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *SectionResult) GetBeta1() float64 {
+	if x != nil {
+		return x.Beta1
+	}
+	return 0
+}
+
+func (x *SectionResult) GetEpsilonT() float64 {
+	if x != nil {
+		return x.EpsilonT
+	}
+	return 0
+}
+
+func (x *SectionResult) GetCc() float64 {
+	if x != nil {
+		return x.Cc
+	}
+	return 0
+}
+
+func (x *SectionResult) GetCs() float64 {
+	if x != nil {
+		return x.Cs
+	}
+	return 0
+}
+
+func (x *SectionResult) GetT() float64 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *SectionResult) GetSteelLayers() []*SteelLayerResult {
+	if x != nil {
+		return x.SteelLayers
+	}
+	return nil
+}
+
+func (x *SectionResult) GetPhi() float64 {
+	if x != nil {
+		return x.Phi
+	}
+	return 0
+}
+
+func (x *SectionResult) GetMn() float64 {
+	if x != nil {
+		return x.Mn
+	}
+	return 0
+}
+
+func (x *SectionResult) GetPhiMn() float64 {
+	if x != nil {
+		return x.PhiMn
+	}
+	return 0
+}
+
+func (x *SectionResult) GetFr() float64 {
+	if x != nil {
+		return x.Fr
+	}
+	return 0
+}
+
+func (x *SectionResult) GetMcr() float64 {
+	if x != nil {
+		return x.Mcr
+	}
+	return 0
+}
+
+func (x *SectionResult) GetTensionControlled() bool {
+	if x != nil {
+		return x.TensionControlled
+	}
+	return false
+}
+
+func (x *SectionResult) GetBarFracture() bool {
+	if x != nil {
+		return x.BarFracture
+	}
+	return false
+}
+
+func (x *SectionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SteelLayerResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Y             float64                `protobuf:"fixed64,1,opt,name=y,proto3" json:"y,omitempty"` // mm from the bottom of the section
+	Area          float64                `protobuf:"fixed64,2,opt,name=area,proto3" json:"area,omitempty"`
+	Strain        float64                `protobuf:"fixed64,3,opt,name=strain,proto3" json:"strain,omitempty"`
+	Stress        float64                `protobuf:"fixed64,4,opt,name=stress,proto3" json:"stress,omitempty"` // MPa
+	Force         float64                `protobuf:"fixed64,5,opt,name=force,proto3" json:"force,omitempty"`   // kN
+	Tension       bool                   `protobuf:"varint,6,opt,name=tension,proto3" json:"tension,omitempty"`
+	Yielded       bool                   `protobuf:"varint,7,opt,name=yielded,proto3" json:"yielded,omitempty"`
+	Fractured     bool                   `protobuf:"varint,8,opt,name=fractured,proto3" json:"fractured,omitempty"` // Strain beyond the fracture strain of the steel model
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SteelLayerResult) Reset() {
+	*x = SteelLayerResult{}
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SteelLayerResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SteelLayerResult) ProtoMessage() {}
+
+func (x *SteelLayerResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gorcb_v1_gorcb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SteelLayerResult.ProtoReflect.Descriptor instead.
+func (*SteelLayerResult) Descriptor() ([]byte, []int) {
+	return file_gorcb_v1_gorcb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SteelLayerResult) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *SteelLayerResult) GetArea() float64 {
+	if x != nil {
+		return x.Area
+	}
+	return 0
+}
+
+func (x *SteelLayerResult) GetStrain() float64 {
+	if x != nil {
+		return x.Strain
+	}
+	return 0
+}
+
+func (x *SteelLayerResult) GetStress() float64 {
+	if x != nil {
+		return x.Stress
+	}
+	return 0
+}
+
+func (x *SteelLayerResult) GetForce() float64 {
+	if x != nil {
+		return x.Force
+	}
+	return 0
+}
+
+func (x *SteelLayerResult) GetTension() bool {
+	if x != nil {
+		return x.Tension
+	}
+	return false
+}
+
+func (x *SteelLayerResult) GetYielded() bool {
+	if x != nil {
+		return x.Yielded
+	}
+	return false
+}
+
+func (x *SteelLayerResult) GetFractured() bool {
+	if x != nil {
+		return x.Fractured
+	}
+	return false
+}
+
+func (x *SteelLayerResult) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+var File_gorcb_v1_gorcb_proto protoreflect.FileDescriptor
+
+const file_gorcb_v1_gorcb_proto_rawDesc = "" +
+	"\n" +
+	"\x14gorcb/v1/gorcb.proto\x12\bgorcb.v1\"\xf5\x02\n" +
+	"\x06Member\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x01R\x06height\x12\x14\n" +
+	"\x05cover\x18\x05 \x01(\x01R\x05cover\x12\x1d\n" +
+	"\n" +
+	"cover_comp\x18\x06 \x01(\x01R\tcoverComp\x12\x0e\n" +
+	"\x02fc\x18\a \x01(\x01R\x02fc\x12\x0e\n" +
+	"\x02fy\x18\b \x01(\x01R\x02fy\x12\x14\n" +
+	"\x05grade\x18\t \x01(\tR\x05grade\x12\x0e\n" +
+	"\x02mu\x18\n" +
+	" \x01(\x01R\x02mu\x12\x0e\n" +
+	"\x02vu\x18\v \x01(\x01R\x02vu\x12\x0e\n" +
+	"\x02as\x18\f \x01(\x01R\x02as\x12\x10\n" +
+	"\x03asc\x18\r \x01(\x01R\x03asc\x12\x1f\n" +
+	"\vstirrup_dia\x18\x0e \x01(\x01R\n" +
+	"stirrupDia\x12!\n" +
+	"\fstirrup_legs\x18\x0f \x01(\x05R\vstirrupLegs\x12\x10\n" +
+	"\x03fyt\x18\x10 \x01(\x01R\x03fyt\x12\x16\n" +
+	"\x06lambda\x18\x11 \x01(\x01R\x06lambda\"\xec\x02\n" +
+	"\x06Result\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.gorcb.v1.MemberR\x06member\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x0e\n" +
+	"\x02fc\x18\x03 \x01(\x01R\x02fc\x12\x0e\n" +
+	"\x02fy\x18\x04 \x01(\x01R\x02fy\x12\x0e\n" +
+	"\x02as\x18\x05 \x01(\x01R\x02as\x12\x10\n" +
+	"\x03asc\x18\x06 \x01(\x01R\x03asc\x12\x1b\n" +
+	"\tepsilon_t\x18\a \x01(\x01R\bepsilonT\x12\x10\n" +
+	"\x03phi\x18\b \x01(\x01R\x03phi\x12\x15\n" +
+	"\x06phi_mn\x18\t \x01(\x01R\x05phiMn\x12\x10\n" +
+	"\x03rho\x18\n" +
+	" \x01(\x01R\x03rho\x12\x17\n" +
+	"\arho_max\x18\v \x01(\x01R\x06rhoMax\x12\x1a\n" +
+	"\badequate\x18\f \x01(\bR\badequate\x12\x18\n" +
+	"\amessage\x18\r \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x0e \x01(\tR\x05error\x12%\n" +
+	"\x0eno_convergence\x18\x0f \x01(\bR\rnoConvergence\":\n" +
+	"\fBatchRequest\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.gorcb.v1.MemberR\amembers\";\n" +
+	"\rBatchResponse\x12*\n" +
+	"\aresults\x18\x01 \x03(\v2\x10.gorcb.v1.ResultR\aresults\"_\n" +
+	"\x0eSectionRequest\x12-\n" +
+	"\asection\x18\x01 \x01(\v2\x11.gorcb.v1.SectionH\x00R\asection\x12\x14\n" +
+	"\x04file\x18\x02 \x01(\fH\x00R\x04fileB\b\n" +
+	"\x06source\"\x89\x02\n" +
+	"\aSection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x0e\n" +
+	"\x02fc\x18\x03 \x01(\x01R\x02fc\x12\x0e\n" +
+	"\x02fy\x18\x04 \x01(\x01R\x02fy\x12\x16\n" +
+	"\x06lambda\x18\x05 \x01(\x01R\x06lambda\x12+\n" +
+	"\bvertices\x18\x06 \x03(\v2\x0f.gorcb.v1.PointR\bvertices\x12'\n" +
+	"\x05holes\x18\a \x03(\v2\x11.gorcb.v1.PolygonR\x05holes\x12:\n" +
+	"\rreinforcement\x18\b \x03(\v2\x14.gorcb.v1.RebarLayerR\rreinforcement\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"6\n" +
+	"\aPolygon\x12+\n" +
+	"\bvertices\x18\x01 \x03(\v2\x0f.gorcb.v1.PointR\bvertices\"d\n" +
+	"\n" +
+	"RebarLayer\x12\f\n" +
+	"\x01y\x18\x01 \x01(\x01R\x01y\x12\x12\n" +
+	"\x04area\x18\x02 \x01(\x01R\x04area\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\"\x92\x03\n" +
+	"\rSectionResult\x12\f\n" +
+	"\x01c\x18\x01 \x01(\x01R\x01c\x12\f\n" +
+	"\x01a\x18\x02 \x01(\x01R\x01a\x12\x14\n" +
+	"\x05beta1\x18\x03 \x01(\x01R\x05beta1\x12\x1b\n" +
+	"\tepsilon_t\x18\x04 \x01(\x01R\bepsilonT\x12\x0e\n" +
+	"\x02cc\x18\x05 \x01(\x01R\x02cc\x12\x0e\n" +
+	"\x02cs\x18\x06 \x01(\x01R\x02cs\x12\f\n" +
+	"\x01t\x18\a \x01(\x01R\x01t\x12=\n" +
+	"\fsteel_layers\x18\b \x03(\v2\x1a.gorcb.v1.SteelLayerResultR\vsteelLayers\x12\x10\n" +
+	"\x03phi\x18\t \x01(\x01R\x03phi\x12\x0e\n" +
+	"\x02mn\x18\n" +
+	" \x01(\x01R\x02mn\x12\x15\n" +
+	"\x06phi_mn\x18\v \x01(\x01R\x05phiMn\x12\x0e\n" +
+	"\x02fr\x18\f \x01(\x01R\x02fr\x12\x10\n" +
+	"\x03mcr\x18\r \x01(\x01R\x03mcr\x12-\n" +
+	"\x12tension_controlled\x18\x0e \x01(\bR\x11tensionControlled\x12!\n" +
+	"\fbar_fracture\x18\x0f \x01(\bR\vbarFracture\x12\x18\n" +
+	"\amessage\x18\x10 \x01(\tR\amessage\"\xee\x01\n" +
+	"\x10SteelLayerResult\x12\f\n" +
+	"\x01y\x18\x01 \x01(\x01R\x01y\x12\x12\n" +
+	"\x04area\x18\x02 \x01(\x01R\x04area\x12\x16\n" +
+	"\x06strain\x18\x03 \x01(\x01R\x06strain\x12\x16\n" +
+	"\x06stress\x18\x04 \x01(\x01R\x06stress\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\x01R\x05force\x12\x18\n" +
+	"\atension\x18\x06 \x01(\bR\atension\x12\x18\n" +
+	"\ayielded\x18\a \x01(\bR\ayielded\x12\x1c\n" +
+	"\tfractured\x18\b \x01(\bR\tfractured\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription2\xa4\x01\n" +
+	"\vBeamService\x12,\n" +
+	"\x06Design\x12\x10.gorcb.v1.Member\x1a\x10.gorcb.v1.Result\x12-\n" +
+	"\aAnalyze\x12\x10.gorcb.v1.Member\x1a\x10.gorcb.v1.Result\x128\n" +
+	"\x05Batch\x12\x16.gorcb.v1.BatchRequest\x1a\x17.gorcb.v1.BatchResponse2N\n" +
+	"\x0eSectionService\x12<\n" +
+	"\aAnalyze\x12\x18.gorcb.v1.SectionRequest\x1a\x17.gorcb.v1.SectionResultBBZ5github.com/alexiusacademia/gorcb/api/gorcb/v1;gorcbv1\xaa\x02\bGorcb.V1b\x06proto3"
+
+var (
+	file_gorcb_v1_gorcb_proto_rawDescOnce sync.Once
+	file_gorcb_v1_gorcb_proto_rawDescData []byte
+)
+
+func file_gorcb_v1_gorcb_proto_rawDescGZIP() []byte {
+	file_gorcb_v1_gorcb_proto_rawDescOnce.Do(func() {
+		file_gorcb_v1_gorcb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gorcb_v1_gorcb_proto_rawDesc), len(file_gorcb_v1_gorcb_proto_rawDesc)))
+	})
+	return file_gorcb_v1_gorcb_proto_rawDescData
+}
+
+var file_gorcb_v1_gorcb_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_gorcb_v1_gorcb_proto_goTypes = []any{
+	(*Member)(nil),           // 0: gorcb.v1.Member
+	(*Result)(nil),           // 1: gorcb.v1.Result
+	(*BatchRequest)(nil),     // 2: gorcb.v1.BatchRequest
+	(*BatchResponse)(nil),    // 3: gorcb.v1.BatchResponse
+	(*SectionRequest)(nil),   // 4: gorcb.v1.SectionRequest
+	(*Section)(nil),          // 5: gorcb.v1.Section
+	(*Point)(nil),            // 6: gorcb.v1.Point
+	(*Polygon)(nil),          // 7: gorcb.v1.Polygon
+	(*RebarLayer)(nil),       // 8: gorcb.v1.RebarLayer
+	(*SectionResult)(nil),    // 9: gorcb.v1.SectionResult
+	(*SteelLayerResult)(nil), // 10: gorcb.v1.SteelLayerResult
+}
+var file_gorcb_v1_gorcb_proto_depIdxs = []int32{
+	0,  // 0: gorcb.v1.Result.member:type_name -> gorcb.v1.Member
+	0,  // 1: gorcb.v1.BatchRequest.members:type_name -> gorcb.v1.Member
+	1,  // 2: gorcb.v1.BatchResponse.results:type_name -> gorcb.v1.Result
+	5,  // 3: gorcb.v1.SectionRequest.section:type_name -> gorcb.v1.Section
+	6,  // 4: gorcb.v1.Section.vertices:type_name -> gorcb.v1.Point
+	7,  // 5: gorcb.v1.Section.holes:type_name -> gorcb.v1.Polygon
+	8,  // 6: gorcb.v1.Section.reinforcement:type_name -> gorcb.v1.RebarLayer
+	6,  // 7: gorcb.v1.Polygon.vertices:type_name -> gorcb.v1.Point
+	10, // 8: gorcb.v1.SectionResult.steel_layers:type_name -> gorcb.v1.SteelLayerResult
+	0,  // 9: gorcb.v1.BeamService.Design:input_type -> gorcb.v1.Member
+	0,  // 10: gorcb.v1.BeamService.Analyze:input_type -> gorcb.v1.Member
+	2,  // 11: gorcb.v1.BeamService.Batch:input_type -> gorcb.v1.BatchRequest
+	4,  // 12: gorcb.v1.SectionService.Analyze:input_type -> gorcb.v1.SectionRequest
+	1,  // 13: gorcb.v1.BeamService.Design:output_type -> gorcb.v1.Result
+	1,  // 14: gorcb.v1.BeamService.Analyze:output_type -> gorcb.v1.Result
+	3,  // 15: gorcb.v1.BeamService.Batch:output_type -> gorcb.v1.BatchResponse
+	9,  // 16: gorcb.v1.SectionService.Analyze:output_type -> gorcb.v1.SectionResult
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_gorcb_v1_gorcb_proto_init() }
+func file_gorcb_v1_gorcb_proto_init() {
+	if File_gorcb_v1_gorcb_proto != nil {
+		return
+	}
+	file_gorcb_v1_gorcb_proto_msgTypes[4].OneofWrappers = []any{
+		(*SectionRequest_Section)(nil),
+		(*SectionRequest_File)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gorcb_v1_gorcb_proto_rawDesc), len(file_gorcb_v1_gorcb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_gorcb_v1_gorcb_proto_goTypes,
+		DependencyIndexes: file_gorcb_v1_gorcb_proto_depIdxs,
+		MessageInfos:      file_gorcb_v1_gorcb_proto_msgTypes,
+	}.Build()
+	File_gorcb_v1_gorcb_proto = out.File
+	file_gorcb_v1_gorcb_proto_goTypes = nil
+	file_gorcb_v1_gorcb_proto_depIdxs = nil
+}
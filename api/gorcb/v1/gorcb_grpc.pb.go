@@ -0,0 +1,331 @@
+// Protocol buffer definition of the gorcb design and analysis services, for
+// typed integration into other structural tools. The messages mirror the
+// JSON output of the commands: lengths in mm, stresses in MPa, moments in
+// kN-m and forces in kN. gorcb serve --grpc serves them.
+//
+// The Go code of this package is generated from this file; regenerate it and
+// generate clients in other languages with protoc, e.g.
+//
+//   Go:     protoc -I api --go_out=api --go_opt=paths=source_relative --go-grpc_out=api --go-grpc_opt=paths=source_relative api/gorcb/v1/gorcb.proto
+//   C#:     the Grpc.Tools package, <Protobuf Include="gorcb.proto" />
+//   Python: python -m grpc_tools.protoc -I api --python_out=. --grpc_python_out=. api/gorcb/v1/gorcb.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: gorcb/v1/gorcb.proto
+
+package gorcbv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BeamService_Design_FullMethodName  = "/gorcb.v1.BeamService/Design"
+	BeamService_Analyze_FullMethodName = "/gorcb.v1.BeamService/Analyze"
+	BeamService_Batch_FullMethodName   = "/gorcb.v1.BeamService/Batch"
+)
+
+// BeamServiceClient is the client API for BeamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BeamService designs and analyzes rectangular beams, as gorcb beam and
+// gorcb batch do
+type BeamServiceClient interface {
+	// Design returns the steel required for the factored actions of a member
+	Design(ctx context.Context, in *Member, opts ...grpc.CallOption) (*Result, error)
+	// Analyze returns the capacity of a member with its provided steel
+	Analyze(ctx context.Context, in *Member, opts ...grpc.CallOption) (*Result, error)
+	// Batch designs or analyzes each member: members with as are analyzed,
+	// the others designed
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+}
+
+type beamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBeamServiceClient(cc grpc.ClientConnInterface) BeamServiceClient {
+	return &beamServiceClient{cc}
+}
+
+func (c *beamServiceClient) Design(ctx context.Context, in *Member, opts ...grpc.CallOption) (*Result, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Result)
+	err := c.cc.Invoke(ctx, BeamService_Design_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beamServiceClient) Analyze(ctx context.Context, in *Member, opts ...grpc.CallOption) (*Result, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Result)
+	err := c.cc.Invoke(ctx, BeamService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beamServiceClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, BeamService_Batch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BeamServiceServer is the server API for BeamService service.
+// All implementations must embed UnimplementedBeamServiceServer
+// for forward compatibility.
+//
+// BeamService designs and analyzes rectangular beams, as gorcb beam and
+// gorcb batch do
+type BeamServiceServer interface {
+	// Design returns the steel required for the factored actions of a member
+	Design(context.Context, *Member) (*Result, error)
+	// Analyze returns the capacity of a member with its provided steel
+	Analyze(context.Context, *Member) (*Result, error)
+	// Batch designs or analyzes each member: members with as are analyzed,
+	// the others designed
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+	mustEmbedUnimplementedBeamServiceServer()
+}
+
+// UnimplementedBeamServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBeamServiceServer struct{}
+
+func (UnimplementedBeamServiceServer) Design(context.Context, *Member) (*Result, error) {
+	return nil, status.Error(codes.Unimplemented, "method Design not implemented")
+}
+func (UnimplementedBeamServiceServer) Analyze(context.Context, *Member) (*Result, error) {
+	return nil, status.Error(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedBeamServiceServer) Batch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Batch not implemented")
+}
+func (UnimplementedBeamServiceServer) mustEmbedUnimplementedBeamServiceServer() {}
+func (UnimplementedBeamServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeBeamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BeamServiceServer will
+// result in compilation errors.
+type UnsafeBeamServiceServer interface {
+	mustEmbedUnimplementedBeamServiceServer()
+}
+
+func RegisterBeamServiceServer(s grpc.ServiceRegistrar, srv BeamServiceServer) {
+	// If the following call panics, it indicates UnimplementedBeamServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BeamService_ServiceDesc, srv)
+}
+
+func _BeamService_Design_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeamServiceServer).Design(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeamService_Design_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeamServiceServer).Design(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeamService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeamServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeamService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeamServiceServer).Analyze(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeamService_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeamServiceServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeamService_Batch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeamServiceServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BeamService_ServiceDesc is the grpc.ServiceDesc for BeamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BeamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gorcb.v1.BeamService",
+	HandlerType: (*BeamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Design",
+			Handler:    _BeamService_Design_Handler,
+		},
+		{
+			MethodName: "Analyze",
+			Handler:    _BeamService_Analyze_Handler,
+		},
+		{
+			MethodName: "Batch",
+			Handler:    _BeamService_Batch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gorcb/v1/gorcb.proto",
+}
+
+const (
+	SectionService_Analyze_FullMethodName = "/gorcb.v1.SectionService/Analyze"
+)
+
+// SectionServiceClient is the client API for SectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SectionService analyzes arbitrary polygon sections, as gorcb section
+// analyze does
+type SectionServiceClient interface {
+	Analyze(ctx context.Context, in *SectionRequest, opts ...grpc.CallOption) (*SectionResult, error)
+}
+
+type sectionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSectionServiceClient(cc grpc.ClientConnInterface) SectionServiceClient {
+	return &sectionServiceClient{cc}
+}
+
+func (c *sectionServiceClient) Analyze(ctx context.Context, in *SectionRequest, opts ...grpc.CallOption) (*SectionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SectionResult)
+	err := c.cc.Invoke(ctx, SectionService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SectionServiceServer is the server API for SectionService service.
+// All implementations must embed UnimplementedSectionServiceServer
+// for forward compatibility.
+//
+// SectionService analyzes arbitrary polygon sections, as gorcb section
+// analyze does
+type SectionServiceServer interface {
+	Analyze(context.Context, *SectionRequest) (*SectionResult, error)
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+// UnimplementedSectionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSectionServiceServer struct{}
+
+func (UnimplementedSectionServiceServer) Analyze(context.Context, *SectionRequest) (*SectionResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedSectionServiceServer) mustEmbedUnimplementedSectionServiceServer() {}
+func (UnimplementedSectionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeSectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SectionServiceServer will
+// result in compilation errors.
+type UnsafeSectionServiceServer interface {
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+func RegisterSectionServiceServer(s grpc.ServiceRegistrar, srv SectionServiceServer) {
+	// If the following call panics, it indicates UnimplementedSectionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SectionService_ServiceDesc, srv)
+}
+
+func _SectionService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).Analyze(ctx, req.(*SectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SectionService_ServiceDesc is the grpc.ServiceDesc for SectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gorcb.v1.SectionService",
+	HandlerType: (*SectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Analyze",
+			Handler:    _SectionService_Analyze_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gorcb/v1/gorcb.proto",
+}
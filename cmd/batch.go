@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Design or check many members from one input file",
+	Long: `Run the design and analysis of many members listed in one file.
+
+Subcommands:
+  run  - Design or check every member and summarize the results
+
+Use --quiet to print one name=value line per member, --xlsx results.xlsx to
+also write the results to an Excel workbook, and --log-file audit.jsonl to
+keep a JSON record of every member of the run. The exit code tells scripts
+and CI jobs whether every member is adequate (see 'gorcb help exit-codes').`,
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+}
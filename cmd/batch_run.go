@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/report"
+	"github.com/alexiusacademia/gorcb/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchFile    string
+	batchSummary bool
+)
+
+var batchRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Design or check every member of a batch file",
+	Long: `Design or check every rectangular beam listed in a CSV, JSON or YAML file
+and print a summary table followed by the results of each member.
+
+A member with As is analyzed and checked against Mu; one without As is
+designed for Mu. Members with Vu also get vertical stirrups designed for
+shear. Omitted covers default to 65 mm, f'c to 28 MPa and fy to 415 MPa.
+
+CSV columns (header row, any order, blank cells use the defaults):
+  id, type (singly or doubly), width, height, cover, cover_comp, fc, fy,
+  grade, mu, vu, as, asc, stirrup_dia, stirrup_legs, fyt, lambda
+
+JSON and YAML files list the same fields under "members". With -f - the
+members are read as JSON from standard input.
+
+Examples:
+  gorcb batch run -f beams.csv
+  gorcb batch run -f beams.json --summary
+
+  # Summary table for a spreadsheet
+  gorcb batch run -f beams.csv --format csv > results.csv`,
+	Run: runBatchRun,
+}
+
+func init() {
+	batchCmd.AddCommand(batchRunCmd)
+	tabular(batchRunCmd)
+	quiet(batchRunCmd)
+	reportable(batchRunCmd)
+	exportable(batchRunCmd)
+
+	batchRunCmd.Flags().StringVarP(&batchFile, "file", "f", "", "CSV, JSON or YAML file of members, or - for JSON on stdin [required]")
+	batchRunCmd.Flags().BoolVar(&batchSummary, "summary", false, "Print only the summary table")
+	batchRunCmd.MarkFlagRequired("file")
+}
+
+func runBatchRun(cmd *cobra.Command, args []string) {
+	members, err := batch.Load(batchFile)
+	if err != nil {
+		printError(err)
+		return
+	}
+	results := batch.Run(members, selectedCode)
+	for _, r := range results {
+		checkStatus(r.Status(), r.NoConvergence)
+	}
+	if reportFile != "" {
+		defer writeReport(batchReport(cmd, results))
+	}
+	var rows [][]string
+	for _, r := range results {
+		rows = append(rows, batchRow(r))
+	}
+	if workbookFile != "" {
+		book := xlsx.New()
+		book.AddSheet("Results").Table(batchHeader, rows)
+		defer writeWorkbook(book)
+	}
+
+	runPlugins(cmd, map[string]any{"file": batchFile, "members": members}, results)
+	defer printPluginResults()
+	for _, r := range results {
+		printQuiet(quietBatch(r))
+	}
+	if quietOutput {
+		return
+	}
+	if tabularOutput() {
+		printTable(batchHeader, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"file": batchFile, "members": members}, results)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     BATCH RESULTS - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Printf("SUMMARY (%s, %d members):\n", batchFile, len(results))
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	passed := printBatchSummary(results)
+	fmt.Printf("  %d of %d members adequate\n", passed, len(results))
+	fmt.Println()
+
+	if batchSummary {
+		return
+	}
+	for _, r := range results {
+		printBatchMember(r, "")
+	}
+}
+
+// batchRow returns the summary table row of a member result
+func batchRow(r batch.Result) []string {
+	row := []string{r.Member.ID, batchType(r.Member), r.Mode, cell(r.Member.Width), cell(r.Member.Height),
+		cell(r.Member.Mu), cell(r.As), cell(r.Asc), cell(r.PhiMn), cell(r.Member.Vu), "", "", r.Status(), r.Message}
+	if r.Error != "" {
+		row[len(row)-1] = r.Error
+	}
+	if r.Shear != nil {
+		row[10], row[11] = cell(r.Shear.PhiVn), cell(r.Shear.Spacing)
+	}
+	return row
+}
+
+// batchHeader is the header of the summary table rows of batchRow
+var batchHeader = []string{"Member", "Type", "Mode", "b (mm)", "h (mm)", "Mu (kN-m)", "As (mm²)", "As' (mm²)",
+	"φMn (kN-m)", "Vu (kN)", "φVn (kN)", "s (mm)", "Status", "Message"}
+
+// printBatchSummary prints the summary table of the member results and
+// returns the number of adequate members
+func printBatchSummary(results []batch.Result) int {
+	passed := 0
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Member\tb×h (mm)\tMode\tMu (kN-m)\tAs (mm²)\tAs' (mm²)\tφMn (kN-m)\tVu (kN)\tStirrups\tStatus")
+	fmt.Fprintln(w, "  ──────\t────────\t────\t─────────\t────────\t─────────\t──────────\t───────\t────────\t──────")
+	for _, r := range results {
+		m := r.Member
+		if r.Error != "" {
+			fmt.Fprintf(w, "  %s\t%.0f×%.0f\t-\t%.2f\t-\t-\t-\t%.2f\t-\t%s\n", m.ID, m.Width, m.Height, m.Mu, m.Vu, r.Status())
+			continue
+		}
+		if r.IsAdequate {
+			passed++
+		}
+		asc := "-"
+		if m.IsDoubly() {
+			asc = fmt.Sprintf("%.2f", r.Asc)
+		}
+		fmt.Fprintf(w, "  %s\t%.0f×%.0f\t%s\t%.2f\t%.2f\t%s\t%.2f\t%.2f\t%s\t%s\n",
+			m.ID, m.Width, m.Height, r.Mode, m.Mu, r.As, asc, r.PhiMn, m.Vu, batchStirrups(r), r.Status())
+	}
+	w.Flush()
+	return passed
+}
+
+// printBatchMember prints the results of one member, with the combinations
+// governing its actions when given
+func printBatchMember(r batch.Result, governing string) {
+	m := r.Member
+	if r.Mode == "" {
+		fmt.Printf("MEMBER %s (%s):\n", m.ID, batchType(m))
+	} else {
+		fmt.Printf("MEMBER %s (%s, %s):\n", m.ID, batchType(m), r.Mode)
+	}
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	if r.Error != "" {
+		fmt.Printf("  Error: %s\n", r.Error)
+		fmt.Println()
+		return
+	}
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Section (b × h):\t%.0f × %.0f mm\n", m.Width, m.Height)
+	fmt.Fprintf(w, "  f'c / fy:\t%.1f / %.1f MPa\n", r.Fc, r.Fy)
+	if governing != "" {
+		fmt.Fprintf(w, "  Governing combinations:\t%s\n", governing)
+	}
+	if r.Mode == batch.ModeDesign {
+		fmt.Fprintf(w, "  Required As:\t%s\n", fmtArea(r.As, 2))
+		if m.IsDoubly() {
+			fmt.Fprintf(w, "  Required As':\t%s\n", fmtArea(r.Asc, 2))
+		}
+	} else {
+		fmt.Fprintf(w, "  Provided As:\t%s\n", fmtArea(r.As, 2))
+		if m.IsDoubly() {
+			fmt.Fprintf(w, "  Provided As':\t%s\n", fmtArea(r.Asc, 2))
+		}
+	}
+	fmt.Fprintf(w, "  Tensile strain (εt):\t%.6f\n", r.EpsilonT)
+	fmt.Fprintf(w, "  φ:\t%.2f\n", r.Phi)
+	check := "✓"
+	if r.PhiMn < m.Mu {
+		check = "✗"
+	}
+	fmt.Fprintf(w, "  φMn:\t%s vs Mu = %s %s\n", fmtMoment(r.PhiMn, 2), fmtMoment(m.Mu, 2), check)
+	if s := r.Shear; s != nil {
+		fmt.Fprintf(w, "  φVc:\t%s\n", fmtForce(s.PhiVc, 2))
+		fmt.Fprintf(w, "  Vs required:\t%s (Vs,max = %s)\n", fmtForce(s.VsReq, 2), fmtForce(s.VsMax, 2))
+		if s.Spacing > 0 {
+			fmt.Fprintf(w, "  φVn:\t%s vs Vu = %s\n", fmtForce(s.PhiVn, 2), fmtForce(s.Vu, 2))
+		}
+		fmt.Fprintf(w, "  Shear:\t%s\n", i18n.T(s.Message))
+	}
+	fmt.Fprintf(w, "  Status:\t%s - %s\n", r.Status(), i18n.T(r.Message))
+	w.Flush()
+	if r.Mode == batch.ModeDesign && r.IsAdequate {
+		fmt.Println()
+		printBarSuggestionsFor(r.As, "  ")
+	}
+	fmt.Println()
+}
+
+// batchType returns the member type, singly when omitted
+func batchType(m batch.Member) string {
+	if m.IsDoubly() {
+		return batch.TypeDoubly
+	}
+	return batch.TypeSingly
+}
+
+// batchStirrups summarizes the stirrup design of a member result
+func batchStirrups(r batch.Result) string {
+	switch {
+	case r.Shear == nil:
+		return "-"
+	case r.Shear.Spacing > 0:
+		return "@ " + fmtSpacing(r.Shear.Spacing, 0)
+	case r.Shear.IsAdequate:
+		return "not req'd"
+	}
+	return "NG"
+}
+
+// batchReport returns the report of the members of a batch file
+func batchReport(cmd *cobra.Command, results []batch.Result) *report.Document {
+	doc := newReport(cmd, "Batch Design Results")
+	doc.Section("Summary")
+	passed := reportBatchSummary(doc, results)
+	doc.Paragraph(fmt.Sprintf("%d of %d members of %s adequate.", passed, len(results), batchFile))
+	doc.Conclude(passed == len(results), fmt.Sprintf("%d of %d members adequate", passed, len(results)))
+
+	doc.Section("Members")
+	for _, r := range results {
+		reportBatchMember(doc, r, "")
+	}
+	return doc
+}
+
+// reportBatchSummary adds the summary table of the member results and
+// returns the number of adequate members
+func reportBatchSummary(doc *report.Document, results []batch.Result) int {
+	passed := 0
+	var rows [][]string
+	for _, r := range results {
+		m := r.Member
+		row := []string{m.ID, fmt.Sprintf("%.0f×%.0f", m.Width, m.Height), r.Mode, fmtMoment(m.Mu, 2), "-", "-", "-", batchStirrups(r), r.Status()}
+		if r.Error == "" {
+			row[4], row[6] = fmtArea(r.As, 2), fmtMoment(r.PhiMn, 2)
+			if m.IsDoubly() {
+				row[5] = fmtArea(r.Asc, 2)
+			}
+		}
+		if r.IsAdequate {
+			passed++
+		}
+		rows = append(rows, row)
+	}
+	doc.Table([]string{"Member", "b×h (mm)", "Mode", "Mu", "As", "As'", "φMn", "Stirrups", "Status"}, rows)
+	return passed
+}
+
+// reportBatchMember adds the results of one member, with the combinations
+// governing its actions when given and the section diagram of singly
+// reinforced members
+func reportBatchMember(doc *report.Document, r batch.Result, governing string) {
+	m := r.Member
+	doc.Subsection(fmt.Sprintf("Member %s (%s)", m.ID, batchType(m)))
+	if r.Error != "" {
+		doc.Paragraph("**Error:** " + r.Error)
+		return
+	}
+
+	steel := "Provided"
+	if r.Mode == batch.ModeDesign {
+		steel = "Required"
+	}
+	var asc, combinations []string
+	if m.IsDoubly() {
+		asc = []string{steel + " As'", fmtArea(r.Asc, 2)}
+	}
+	if governing != "" {
+		combinations = []string{"Governing combinations", governing}
+	}
+	doc.Fields(
+		[]string{"Section (b × h)", fmt.Sprintf("%s × %s", fmtLength(m.Width, 0), fmtLength(m.Height, 0))},
+		[]string{"f'c / fy", fmt.Sprintf("%s / %s", fmtStress(r.Fc, 1), fmtStress(r.Fy, 1))},
+		combinations,
+		[]string{"Mode", r.Mode},
+		[]string{steel + " As", fmtArea(r.As, 2)},
+		asc,
+		[]string{"Tensile strain (εt)", fmt.Sprintf("%.6f", r.EpsilonT)},
+		[]string{"φ", fmt.Sprintf("%.2f", r.Phi)},
+	)
+
+	var rows [][]string
+	if rhoMin := batchRhoMin(r); rhoMin > 0 {
+		rows = append(rows, minSteelCheck(r.Rho, rhoMin))
+	}
+	rows = append(rows, tensionControlledCheck(r.Fc, r.Fy, r.EpsilonT))
+	if m.Mu > 0 {
+		rows = append(rows, strengthCheck(r.PhiMn, m.Mu))
+	}
+	if s := r.Shear; s != nil {
+		rows = append(rows, reportCheck("Shear strength (φVn ≥ Vu)", "", fmtForce(s.PhiVn, 2), fmtForce(s.Vu, 2),
+			quietStatus(s.IsAdequate)))
+	}
+	reportChecks(doc, rows...)
+	doc.Paragraph(fmt.Sprintf("**%s** - %s", r.Status(), r.Message))
+	if s := r.Shear; s != nil {
+		doc.Paragraph("Shear: " + s.Message)
+	}
+
+	if r.Mode == batch.ModeDesign && r.IsAdequate {
+		reportBars(doc, "Suggested Bars for "+m.ID, r.As)
+	}
+	if a, c, ok := batchStressBlock(r); ok {
+		cover := m.Cover
+		if cover == 0 {
+			cover = batch.DefaultCover
+		}
+		b := beam.NewSinglyReinforced(m.Width, m.Height, cover, r.Fc, r.Fy)
+		reportDiagram(doc, singlyDiagramData(b, r.As, a, c, r.EpsilonT), "", m.ID)
+	}
+}
+
+// batchRhoMin returns the minimum steel ratio of a member result, or zero
+func batchRhoMin(r batch.Result) float64 {
+	switch {
+	case r.Design != nil:
+		return r.Design.RhoMin
+	case r.Analysis != nil:
+		return r.Analysis.RhoMin
+	case r.DoublyDesign != nil:
+		return r.DoublyDesign.RhoMin
+	case r.DoublyAnalysis != nil:
+		return r.DoublyAnalysis.RhoMin
+	}
+	return 0
+}
+
+// batchStressBlock returns the stress block and neutral axis depths of a
+// singly reinforced member with steel, reporting whether it has them
+func batchStressBlock(r batch.Result) (a, c float64, ok bool) {
+	switch {
+	case r.Design != nil && r.Design.IsAdequate:
+		return r.Design.A, r.Design.C, true
+	case r.Analysis != nil:
+		return r.Analysis.A, r.Analysis.C, true
+	}
+	return 0, 0, false
+}
@@ -13,8 +13,27 @@ based on NSCP 2015 provisions.
 Subcommands:
   design   - Calculate required reinforcement for a given moment
   analyze  - Calculate moment capacity for a given reinforcement
+  continuous - Moment and shear diagrams of a continuous beam
 
-All calculations follow NSCP 2015 strength design method.`,
+All calculations follow NSCP 2015 strength design method by default.
+Use --code to select another design code (e.g. --code aci318-19, --code ec2,
+or --code nscp2010 for checking designs made under the 2010 edition).
+Project-specific φ factors and tension-controlled strain limits can be
+applied with --code-overrides; the overrides are echoed in every report header.
+Under Eurocode 2 the partial factors γc and γs are applied to the material
+strengths, φ is reported as 1.0 and φMn is the design resistance MRd.
+
+The beam and moment commands accept --units us to take inputs and print
+results in in, in², ksi, kip and kip-ft, with diagrams labeled and scaled in
+the same units and spans in ft; calculations still run in SI units and json,
+yaml, csv and tsv results are always written in SI units.
+
+Use --trace with design and analyze to show every formula with its
+substituted values and the governing clause of the selected code, step by
+step from Rn and ρ through a, c, εt and φ to φMn.
+
+Use --quiet to print only the steel area, design strength and adequacy as
+one line per member for scripts, e.g. "id=B1 as=1256.64 phi_mn=187.42 status=OK".`,
 }
 
 func init() {
@@ -2,12 +2,12 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/alexiusacademia/gorcb/internal/beam"
 	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -18,11 +18,25 @@ var (
 	analyzeCover  float64
 	analyzeFc     float64
 	analyzeFy     float64
+	analyzeGrade  gradeInput
 	analyzeAs     float64
 
+	analyzeConcreteType string
+
+	// Serviceability inputs
+	analyzeService serviceInputs
+
 	// Diagram options
 	analyzeShowDiagram bool
 	analyzeExportFile  string
+	analyzeCombined    string
+	analyzeDXF         string
+
+	// Exposure cover check
+	analyzeCoverCheck coverInputs
+
+	// Ductility and over-strength metrics
+	analyzeDuctility bool
 )
 
 var beamAnalyzeCmd = &cobra.Command{
@@ -41,12 +55,32 @@ Examples:
   gorcb beam analyze --width 300 --height 500 --cover 65 --fc 28 --fy 415 --as 942
 
   # Using short flags
-  gorcb beam analyze -b 300 -h 500 -c 65 --fc 28 --fy 415 -a 942`,
+  gorcb beam analyze -b 300 -h 500 -c 65 --fc 28 --fy 415 -a 942
+
+  # Include service-level checks (cracked section, service stresses, crack control)
+  gorcb beam analyze -b 300 --height 500 --as 942 --service-dead 40 --service-live 25 --sustained-live 0.3
+
+  # Section image with the bar spacing at the tension face against the crack
+  # control maximum, the gaps too wide shaded
+  gorcb beam analyze -b 600 --height 500 --as 1900 --bar-dia 28 --service-dead 90 --service-live 60 -o crack.png
+
+  # Include ductility and over-strength metrics for capacity design
+  gorcb beam analyze -b 300 --height 500 --as 942 --ductility
+
+  # Section with its bars, strain profile and stress block with Cc and T in one figure
+  gorcb beam analyze -b 300 --height 500 --as 942 --combined beam-figure.png
+
+  # Detailing drawing of the section for the structural sheets
+  gorcb beam analyze -b 300 --height 500 --as 942 --bar-dia 20 --stirrup-dia 10 --dxf B1.dxf`,
 	Run: runBeamAnalyze,
 }
 
 func init() {
 	beamCmd.AddCommand(beamAnalyzeCmd)
+	unitAware(beamAnalyzeCmd)
+	quiet(beamAnalyzeCmd)
+	traceable(beamAnalyzeCmd)
+	reportable(beamAnalyzeCmd)
 
 	// Geometry flags
 	beamAnalyzeCmd.Flags().Float64VarP(&analyzeWidth, "width", "b", 0, "Beam width (mm) [required]")
@@ -56,6 +90,8 @@ func init() {
 	// Material flags
 	beamAnalyzeCmd.Flags().Float64Var(&analyzeFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
 	beamAnalyzeCmd.Flags().Float64Var(&analyzeFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(beamAnalyzeCmd, &analyzeGrade)
+	beamAnalyzeCmd.Flags().StringVar(&analyzeConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
 
 	// Reinforcement flag
 	beamAnalyzeCmd.Flags().Float64VarP(&analyzeAs, "as", "a", 0, "Tension reinforcement area As (mm²) [required]")
@@ -65,47 +101,127 @@ func init() {
 	beamAnalyzeCmd.MarkFlagRequired("height")
 	beamAnalyzeCmd.MarkFlagRequired("as")
 
+	// Cover check flags
+	addCoverFlags(beamAnalyzeCmd, &analyzeCoverCheck)
+
+	// Serviceability flags
+	addServiceFlags(beamAnalyzeCmd, &analyzeService)
+
+	beamAnalyzeCmd.Flags().BoolVar(&analyzeDuctility, "ductility", false, "Show c/d, curvature ductility and probable moment Mpr")
+
 	// Diagram options
 	beamAnalyzeCmd.Flags().BoolVar(&analyzeShowDiagram, "diagram", false, "Show ASCII stress-strain diagram")
 	beamAnalyzeCmd.Flags().StringVarP(&analyzeExportFile, "output", "o", "", "Export diagram to file (png, svg, pdf)")
+	beamAnalyzeCmd.Flags().StringVar(&analyzeCombined, "combined", "", "Export the section, strain and stress diagrams side by side to file (png, svg, pdf)")
+	beamAnalyzeCmd.Flags().StringVar(&analyzeDXF, "dxf", "", "Export a detailing drawing of the section with bars, stirrup and dimensions to a DXF file")
 }
 
 func runBeamAnalyze(cmd *cobra.Command, args []string) {
+	if err := analyzeGrade.resolve(&analyzeFy); err != nil {
+		printError(err)
+		return
+	}
+	if err := analyzeCoverCheck.validate(); err != nil {
+		printError(err)
+		return
+	}
+
 	// Create beam
 	b := beam.NewSinglyReinforced(analyzeWidth, analyzeHeight, analyzeCover, analyzeFc, analyzeFy)
+	b.Code = selectedCode
+
+	lambda, err := concreteLambda(analyzeConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+	b.Lambda = lambda
+	b.Density = concreteDensity(analyzeConcreteType)
 
 	// Run analysis
 	result, err := b.Analyze(analyzeAs)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		printError(err)
+		return
+	}
+
+	// Ductility and over-strength metrics
+	var ductility *nscp.DuctilityResult
+	if analyzeDuctility {
+		ductility, err = b.Ductility(analyzeAs)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Serviceability checks under the unfactored service combinations
+	var sm nscp.ServiceMoments
+	var service *beam.ServiceResult
+	if analyzeService.requested() {
+		sm, err = analyzeService.serviceMoments()
+		if err != nil {
+			printError(err)
+			return
+		}
+		opts, err := analyzeService.options()
+		if err != nil {
+			printError(err)
+			return
+		}
+		service, err = b.ServiceCheck(sm, opts)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	checkAdequacy(result.MeetsMinReinf && result.MeetsMaxReinf)
+	if reportFile != "" {
+		defer writeReport(beamAnalysisReport(cmd, b, result, service))
+	}
+	runPlugins(cmd, b, analysisReport{Analysis: result, Ductility: ductility, Service: service})
+	defer printPluginResults()
+	printQuiet(quietResult{As: analyzeAs, PhiMn: result.PhiMn, Status: quietStatus(result.MeetsMinReinf && result.MeetsMaxReinf)})
+	if quietOutput {
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, b, analysisReport{Analysis: result, Ductility: ductility, Service: service})
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     SINGLY REINFORCED BEAM ANALYSIS - NSCP 2015")
+	fmt.Printf("     SINGLY REINFORCED BEAM ANALYSIS - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Input summary
 	fmt.Println("INPUT DATA:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Beam Width (b):\t%.0f mm\n", b.Width)
-	fmt.Fprintf(w, "  Beam Depth (h):\t%.0f mm\n", b.Height)
-	fmt.Fprintf(w, "  Effective Depth (d):\t%.0f mm\n", b.EffectiveDepth)
-	fmt.Fprintf(w, "  Concrete Cover:\t%.0f mm\n", b.Cover)
-	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", b.Fc)
-	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", b.Fy)
-	fmt.Fprintf(w, "  Reinforcement (As):\t%.2f mm²\n", analyzeAs)
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Beam Width (b):\t%s\n", fmtLength(b.Width, 0))
+	fmt.Fprintf(w, "  Beam Depth (h):\t%s\n", fmtLength(b.Height, 0))
+	fmt.Fprintf(w, "  Effective Depth (d):\t%s\n", fmtLength(b.EffectiveDepth, 0))
+	fmt.Fprintf(w, "  Concrete Cover:\t%s\n", fmtLength(b.Cover, 0))
+	fmt.Fprintf(w, "  f'c:\t%s\n", fmtStress(b.Fc, 1))
+	fmt.Fprintf(w, "  fy:\t%s\n", fmtStress(b.Fy, 1))
+	printGrade(w, analyzeGrade)
+	fmt.Fprintf(w, "  λ (concrete type):\t%.2f\n", result.Lambda)
+	fmt.Fprintf(w, "  Reinforcement (As):\t%s\n", fmtArea(analyzeAs, 2))
 	w.Flush()
 	fmt.Println()
 
+	if traceOutput {
+		analysisTrace(b, analyzeAs, result).print()
+	}
+
 	// Reinforcement ratios
 	fmt.Println("REINFORCEMENT RATIOS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  ρ_min:\t%.6f\n", result.RhoMin)
 	fmt.Fprintf(w, "  ρ_max (tension-controlled):\t%.6f\n", result.RhoMax)
 	fmt.Fprintf(w, "  ρ_bal:\t%.6f\n", result.RhoBalanced)
@@ -126,22 +242,22 @@ func runBeamAnalyze(cmd *cobra.Command, args []string) {
 	// Steel area limits
 	fmt.Println("STEEL AREA LIMITS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	asMin := result.RhoMin * analyzeWidth * (analyzeHeight - analyzeCover)
 	asMax := result.RhoMax * analyzeWidth * (analyzeHeight - analyzeCover)
-	fmt.Fprintf(w, "  As,min:\t%.2f mm²\n", asMin)
-	fmt.Fprintf(w, "  As,max:\t%.2f mm²\n", asMax)
-	fmt.Fprintf(w, "  As,provided:\t%.2f mm²\n", analyzeAs)
+	fmt.Fprintf(w, "  As,min:\t%s\n", fmtArea(asMin, 2))
+	fmt.Fprintf(w, "  As,max:\t%s\n", fmtArea(asMax, 2))
+	fmt.Fprintf(w, "  As,provided:\t%s\n", fmtArea(analyzeAs, 2))
 	w.Flush()
 	fmt.Println()
 
 	// Section analysis
 	fmt.Println("SECTION PROPERTIES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  β₁:\t%.4f\n", result.Beta1)
-	fmt.Fprintf(w, "  Compression block depth (a):\t%.2f mm\n", result.A)
-	fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", result.C)
+	fmt.Fprintf(w, "  Compression block depth (a):\t%s\n", fmtLength(result.A, 2))
+	fmt.Fprintf(w, "  Neutral axis depth (c):\t%s\n", fmtLength(result.C, 2))
 	fmt.Fprintf(w, "  c/d ratio:\t%.4f\n", result.C/(analyzeHeight-analyzeCover))
 	fmt.Fprintf(w, "  Tensile strain (εt):\t%.6f\n", result.EpsilonT)
 	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
@@ -151,83 +267,142 @@ func runBeamAnalyze(cmd *cobra.Command, args []string) {
 	// Moment capacity
 	fmt.Println("MOMENT CAPACITY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Nominal Moment (Mn):\t%.2f kN-m\n", result.Mn)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Nominal Moment (Mn):\t%s\n", fmtMoment(result.Mn, 2))
+	fmt.Fprintf(w, "  Modulus of Rupture (fr):\t%s\n", fmtStress(result.Fr, 2))
+	fmt.Fprintf(w, "  Cracking Moment (Mcr):\t%s\n", fmtMoment(result.Mcr, 2))
 	w.Flush()
 	fmt.Println()
 
 	fmt.Printf("  ╔═════════════════════════════════════════╗\n")
-	fmt.Printf("  ║  DESIGN CAPACITY φMn = %.2f kN-m     \n", result.PhiMn)
+	fmt.Printf("  ║  DESIGN CAPACITY φMn = %s     \n", fmtMoment(result.PhiMn, 2))
 	fmt.Printf("  ╚═════════════════════════════════════════╝\n")
 	fmt.Println()
 
 	// Status
 	fmt.Println("STATUS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	controlStatus := "Tension-controlled (φ = 0.90)"
+	controlStatus := fmt.Sprintf("Tension-controlled (φ = %.2f)", result.Phi)
 	if !result.IsTensionControlled {
-		if result.EpsilonT >= analyzeFy/200000 {
+		if result.EpsilonT >= selectedCode.DesignYieldStrength(analyzeFy)/200000 {
 			controlStatus = fmt.Sprintf("Transition zone (φ = %.2f)", result.Phi)
 		} else {
-			controlStatus = "Compression-controlled (φ = 0.65)"
+			controlStatus = fmt.Sprintf("Compression-controlled (φ = %.2f)", result.Phi)
 		}
 	}
 	fmt.Printf("  Section: %s\n", controlStatus)
-	fmt.Printf("  %s\n", result.Message)
+	fmt.Printf("  %s\n", i18n.T(result.Message))
 	fmt.Println()
 
+	if ductility != nil {
+		printDuctility(ductility)
+	}
+
+	printCoverCheck(analyzeCoverCheck, coverFace{"Bottom", analyzeCover})
+
+	if service != nil {
+		printServiceCheck(sm, service)
+	}
+
 	// Show diagram if requested
 	if analyzeShowDiagram {
-		epsilonY := analyzeFy / nscp.Es
-		tensionYields := result.EpsilonT >= epsilonY
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            analyzeWidth,
-			Height:           analyzeHeight,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    analyzeCover,
-			TensionSteelArea: analyzeAs,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * analyzeFc,
-			FsTension:        analyzeFy,
-			TensionYields:    tensionYields,
-			IsDoubly:         false,
-		}
+		diagramData := detailBars(singlyDiagramData(b, analyzeAs, result.A, result.C, result.EpsilonT), analyzeCoverCheck)
 
 		fmt.Println(diagram.DrawASCIISectionDiagram(diagramData))
 		fmt.Println(diagram.DrawStrainDiagram(diagramData))
+		fmt.Println(diagram.DrawStressBlock(diagramData))
 	}
 
 	// Export diagram if requested
 	if analyzeExportFile != "" {
-		epsilonY := analyzeFy / nscp.Es
-		tensionYields := result.EpsilonT >= epsilonY
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            analyzeWidth,
-			Height:           analyzeHeight,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    analyzeCover,
-			TensionSteelArea: analyzeAs,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * analyzeFc,
-			FsTension:        analyzeFy,
-			TensionYields:    tensionYields,
-			IsDoubly:         false,
-		}
+		diagramData := crackControl(detailBars(singlyDiagramData(b, analyzeAs, result.A, result.C, result.EpsilonT), analyzeCoverCheck), service)
 
 		err := diagram.ExportSectionDiagram(diagramData, analyzeExportFile)
 		if err != nil {
 			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
 		} else {
 			fmt.Printf("Diagram exported to: %s\n", analyzeExportFile)
+			printPlotData(analyzeExportFile)
+		}
+	}
+
+	if analyzeCombined != "" {
+		diagramData := crackControl(detailBars(singlyDiagramData(b, analyzeAs, result.A, result.C, result.EpsilonT), analyzeCoverCheck), service)
+		err := diagram.ExportCombinedDiagram(diagramData, analyzeCombined)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Combined diagram exported to: %s\n", analyzeCombined)
+			printPlotData(analyzeCombined)
+		}
+	}
+
+	if analyzeDXF != "" {
+		diagramData := detailBars(singlyDiagramData(b, analyzeAs, result.A, result.C, result.EpsilonT), analyzeCoverCheck)
+		if err := diagram.ExportSectionDXF(diagramData, analyzeDXF); err != nil {
+			fmt.Printf("Error exporting drawing: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Detailing drawing exported to: %s\n", analyzeDXF)
 		}
 	}
 }
 
+
+// printDuctility prints the ductility and over-strength metrics for capacity design
+func printDuctility(r *nscp.DuctilityResult) {
+	fmt.Println("DUCTILITY & OVER-STRENGTH:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  c/d:\t%.4f\n", r.CdRatio)
+	fmt.Fprintf(w, "  cb/d (balanced):\t%.4f\n", r.CdBalanced)
+	fmt.Fprintf(w, "  (c/d)/(cb/d):\t%.2f\n", r.CdRelative)
+	fmt.Fprintf(w, "  Yield curvature (φy, k = %.3f):\t%.5f 1/m\n", r.ElasticK, r.YieldCurvature)
+	fmt.Fprintf(w, "  Ultimate curvature (φu = εcu/c):\t%.5f 1/m\n", r.UltimateCurvature)
+	fmt.Fprintf(w, "  Curvature ductility (μφ = φu/φy):\t%.2f\n", r.CurvatureDuctility)
+	fmt.Fprintf(w, "  Probable Moment (Mpr, 1.25fy, φ = 1.0):\t%s\n", fmtMoment(r.ProbableMoment, 2))
+	fmt.Fprintf(w, "  Over-strength (Mpr/φMn):\t%.2f\n", r.OverStrength)
+	w.Flush()
+	fmt.Println()
+}
+
+// beamAnalysisReport returns the analysis report of a singly reinforced beam
+func beamAnalysisReport(cmd *cobra.Command, b *beam.SinglyReinforced, r *beam.AnalysisResult, service *beam.ServiceResult) *report.Document {
+	doc := newReport(cmd, "Singly Reinforced Beam Analysis")
+	doc.Conclude(r.MeetsMinReinf && r.MeetsMaxReinf, r.Message)
+
+	doc.Section("Input Data")
+	doc.Fields(
+		[]string{"Beam width (b)", fmtLength(b.Width, 0)},
+		[]string{"Beam depth (h)", fmtLength(b.Height, 0)},
+		[]string{"Effective depth (d)", fmtLength(b.EffectiveDepth, 0)},
+		[]string{"Concrete cover", fmtLength(b.Cover, 0)},
+		[]string{"f'c", fmtStress(b.Fc, 1)},
+		[]string{"fy", fmtStress(b.Fy, 1)},
+		reportGrade(analyzeGrade),
+		[]string{"λ (concrete type)", fmt.Sprintf("%.2f", r.Lambda)},
+		[]string{"Reinforcement (As)", fmtArea(analyzeAs, 2)},
+	)
+
+	doc.Section("Code Checks")
+	reportChecks(doc, singlyChecks(b.Fc, b.Fy, r.Rho, r.RhoMin, r.RhoMax, r.EpsilonT, r.PhiMn, 0)...)
+	doc.Paragraph(r.Message)
+	doc.Steps("Calculation steps (N, mm, MPa)", analysisTrace(b, analyzeAs, r).steps)
+
+	doc.Section("Section Analysis")
+	doc.Fields(
+		[]string{"ρbal", fmt.Sprintf("%.6f", r.RhoBalanced)},
+		[]string{"Compression block depth (a)", fmtLength(r.A, 2)},
+		[]string{"Neutral axis depth (c)", fmtLength(r.C, 2)},
+		[]string{"Tensile strain (εt)", fmt.Sprintf("%.6f", r.EpsilonT)},
+		[]string{"Strength reduction factor (φ)", fmt.Sprintf("%.2f", r.Phi)},
+		[]string{"Cracking moment (Mcr)", fmtMoment(r.Mcr, 2)},
+	)
+
+	doc.Section("Moment Capacity")
+	doc.Paragraph(fmt.Sprintf("Mn = %s, **φMn = %s**.", fmtMoment(r.Mn, 2), fmtMoment(r.PhiMn, 2)))
+	reportDiagram(doc, crackControl(detailBars(singlyDiagramData(b, analyzeAs, r.A, r.C, r.EpsilonT), analyzeCoverCheck), service), analyzeExportFile, "")
+	return doc
+}
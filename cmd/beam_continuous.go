@@ -0,0 +1,748 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/continuous"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	continuousFile         string
+	continuousCombination  string
+	continuousShowDiagram  bool
+	continuousBMDFile      string
+	continuousSFDFile      string
+	continuousEnvelopeFile string
+	continuousElevation    string
+
+	// Stirrup zones of a rectangular section
+	continuousShowStirrups bool
+	continuousStirrupFile  string
+	continuousShearFile    string
+	continuousWidth        float64
+	continuousHeight       float64
+	continuousCover        float64
+	continuousFc           float64
+	continuousFy           float64
+	continuousStirrupDia   float64
+	continuousLegs         int
+	continuousFyt          float64
+
+	// Bar cutoffs of the flexural steel
+	continuousShowCutoffs bool
+	continuousCutoffFile  string
+	continuousBarDia      float64
+
+	// Load combination selection
+	continuousCombinations combinationInputs
+)
+
+var beamContinuousCmd = &cobra.Command{
+	Use:   "continuous",
+	Short: "Bending moment and shear force diagrams of a continuous beam",
+	Long: `Analyze a continuous beam over one or more spans for the unfactored loads of
+each load type and combine them into the factored bending moment (BMD) and
+shear force (SFD) diagrams of every load combination and their envelope.
+
+The beam is read from a JSON or YAML file with its spans (m), its end
+supports (pinned, fixed or free; interior supports are pinned) and its
+loads: uniform loads w (kN/m) on every span or one span, and point loads p
+(kN) at x (m) from the start of their span. Loads act downward, and every
+span has the same stiffness.
+
+  {
+    "name": "B1",
+    "spans": [6, 7.5, 6],
+    "right": "fixed",
+    "loads": [
+      {"type": "D", "w": 18},
+      {"type": "L", "w": 12},
+      {"type": "L", "p": 40, "x": 3, "span": 2}
+    ]
+  }
+
+Each load type loads the spans as given: to find the worst pattern of live
+load, give it on alternate spans as another load type or another file.
+Moments are positive in sagging and shears are positive upward on the left
+of a section. A combination with "or" loads or reversible wind and
+earthquake is drawn as the range of its branches.
+
+The same combination sets, seismic and ASD options as 'gorcb loads' apply.
+The diagrams show the envelope of all combinations, or one combination
+with --combination; --diagram prints them in text and --bmd and --sfd
+export them to png, svg or pdf files. --envelope exports the moments of
+every combination, each in its own color, under the envelope with the
+design moments annotated: the hogging moment at each support and the
+largest sagging moment of each span. --elevation exports the load model
+to document it: the beam on its supports with the spans and the loads of
+each type with their magnitudes, above the bending moment diagram.
+
+Given the width and height of a rectangular section, --stirrups designs
+the stirrup zones of each span for the drawn shear: from each support, the
+spacing required for the shear at d from the support, then minimum
+stirrups where φVc/2 < Vu ≤ φVc, and none where Vu ≤ φVc/2. It prints them
+with an elevation of the beam, which --stirrup-file exports as an image.
+--shear-capacity exports the drawn shear against φVc, φVc/2 and the
+strength φ(Vc + Vs) of each zone, shading where minimum stirrups govern and
+where stirrups may be omitted.
+
+Likewise --cutoffs lays out bars of --bar-dia for the drawn moments: a
+third of the bars of the largest moment of each face, and at least two,
+run the length of the beam, lapped over the interior supports at the
+bottom and at midspan at the top. The other bars are cut off max(d, 12db)
+past where the continuous bars suffice, and at least a development length
+from the largest moment. --cutoff-file exports their elevation with the
+cutoff points and laps dimensioned.
+
+Examples:
+  gorcb beam continuous -f b1.yaml
+  gorcb beam continuous -f b1.yaml --diagram
+  gorcb beam continuous -f b1.yaml --bmd b1-bmd.png --sfd b1-sfd.png
+
+  # Moment envelope of all combinations with the design values
+  gorcb beam continuous -f b1.yaml --envelope b1-envelope.png
+
+  # Supports, spans and loads over the bending moment diagram
+  gorcb beam continuous -f b1.yaml --elevation b1-loads.png
+
+  # Stirrup zones of a 300×500 beam, and their elevation
+  gorcb beam continuous -f b1.yaml -b 300 --height 500 --stirrups
+  gorcb beam continuous -f b1.yaml -b 300 --height 500 --stirrup-file b1-stirrups.png
+  gorcb beam continuous -f b1.yaml -b 300 --height 500 --shear-capacity b1-shear.png
+
+  # Bar cutoffs of 20 mm bars, and their elevation
+  gorcb beam continuous -f b1.yaml -b 300 --height 500 --cutoffs --cutoff-file b1-bars.png
+
+  # Diagrams of combination 2 only
+  gorcb beam continuous -f b1.yaml --combination 2 --diagram
+
+  # Moment and shear at every station of the envelope
+  gorcb beam continuous -f b1.yaml --format csv > b1.csv`,
+	Run: runBeamContinuous,
+}
+
+func init() {
+	beamCmd.AddCommand(beamContinuousCmd)
+	tabular(beamContinuousCmd)
+
+	beamContinuousCmd.Flags().StringVarP(&continuousFile, "file", "f", "", "Beam JSON or YAML file, or - for JSON on stdin [required]")
+	beamContinuousCmd.MarkFlagRequired("file")
+	beamContinuousCmd.Flags().StringVar(&continuousCombination, "combination", "", "ID of the combination to draw (default: the envelope of all combinations)")
+	beamContinuousCmd.Flags().BoolVar(&continuousShowDiagram, "diagram", false, "Show ASCII bending moment and shear force diagrams")
+	beamContinuousCmd.Flags().StringVar(&continuousBMDFile, "bmd", "", "Export the bending moment diagram to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().StringVar(&continuousSFDFile, "sfd", "", "Export the shear force diagram to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().StringVar(&continuousEnvelopeFile, "envelope", "", "Export the moment envelope over every combination with the design values at the supports and in the spans to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().StringVar(&continuousElevation, "elevation", "", "Export the elevation with the supports, spans and loads over the bending moment diagram to file (png, svg, pdf)")
+
+	beamContinuousCmd.Flags().BoolVar(&continuousShowStirrups, "stirrups", false, "Design and show the stirrup zones of each span (needs --width and --height)")
+	beamContinuousCmd.Flags().StringVar(&continuousStirrupFile, "stirrup-file", "", "Export the elevation of the stirrup zones to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().StringVar(&continuousShearFile, "shear-capacity", "", "Export the shear along the beam against the strength of the stirrup zones to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().BoolVar(&continuousShowCutoffs, "cutoffs", false, "Lay out and show the bars and their cutoff points (needs --width and --height)")
+	beamContinuousCmd.Flags().StringVar(&continuousCutoffFile, "cutoff-file", "", "Export the elevation of the bars with their cutoffs and laps to file (png, svg, pdf)")
+	beamContinuousCmd.Flags().Float64Var(&continuousBarDia, "bar-dia", 20, "Diameter of the flexural bars for the cutoffs (mm)")
+	beamContinuousCmd.Flags().Float64VarP(&continuousWidth, "width", "b", 0, "Beam width for the stirrup zones and bar cutoffs (mm)")
+	beamContinuousCmd.Flags().Float64Var(&continuousHeight, "height", 0, "Beam total depth for the stirrup zones and bar cutoffs (mm)")
+	beamContinuousCmd.Flags().Float64VarP(&continuousCover, "cover", "c", 65, "Effective cover to steel centroid (mm)")
+	beamContinuousCmd.Flags().Float64Var(&continuousFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
+	beamContinuousCmd.Flags().Float64Var(&continuousFy, "fy", 415, "Steel yield strength fy (MPa)")
+	beamContinuousCmd.Flags().Float64Var(&continuousStirrupDia, "stirrup-dia", beam.DefaultStirrupDiameter, "Stirrup bar diameter (mm)")
+	beamContinuousCmd.Flags().IntVar(&continuousLegs, "legs", beam.DefaultStirrupLegs, "Number of stirrup legs")
+	beamContinuousCmd.Flags().Float64Var(&continuousFyt, "fyt", 0, "Stirrup yield strength fyt (MPa), fy when zero")
+
+	addCombinationFlags(beamContinuousCmd, &continuousCombinations)
+}
+
+// continuousReport is the JSON result of the continuous beam command
+type continuousReport struct {
+	Reactions    []continuous.Reaction   `json:"reactions"`
+	Combinations []continuous.Diagram    `json:"combinations"`
+	Envelope     continuous.Diagram      `json:"envelope"`
+	Stirrups     []continuousStirrups    `json:"stirrups,omitempty"`
+	Cutoffs      *continuous.Curtailment `json:"cutoffs,omitempty"`
+}
+
+// continuousStirrups are the stirrup zones of a span, counted from 1
+type continuousStirrups struct {
+	Span  int                `json:"span"`
+	Zones []beam.StirrupZone `json:"zones"`
+}
+
+func runBeamContinuous(cmd *cobra.Command, args []string) {
+	b, err := continuous.LoadFile(continuousFile)
+	if err != nil {
+		printError(err)
+		return
+	}
+	combinations, err := continuousCombinations.resolve(cmd)
+	if err != nil {
+		printError(err)
+		return
+	}
+	isASD := continuousCombinations.ASD
+
+	analysis, err := b.Analyze()
+	if err != nil {
+		printError(err)
+		return
+	}
+	diagrams := analysis.Combine(combinations)
+	if len(diagrams) == 0 {
+		fmt.Println("Error: no load combination includes the load types of the beam")
+		setExit(exitInvalidInput)
+		return
+	}
+	envelope := continuous.Envelope(diagrams)
+
+	// The diagram drawn: the envelope or the selected combination
+	drawn := envelope
+	if continuousCombination != "" {
+		found := false
+		for _, d := range diagrams {
+			if d.ID == continuousCombination {
+				drawn, found = d, true
+			}
+		}
+		if !found {
+			fmt.Printf("Error: combination %q is not among the combinations with loads on the beam\n", continuousCombination)
+			setExit(exitInvalidInput)
+			return
+		}
+	}
+
+	// Stirrup zones of the drawn shear
+	var stirrups []continuousStirrups
+	if continuousShowStirrups || continuousStirrupFile != "" || continuousShearFile != "" {
+		stirrups, err = continuousStirrupZones(b, drawn, isASD)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Bars and their cutoffs for the drawn moments
+	var cutoffs *continuous.Curtailment
+	if continuousShowCutoffs || continuousCutoffFile != "" {
+		section, err := continuousSection(isASD)
+		if err == nil {
+			cutoffs, err = b.Curtail(drawn, section, continuousBarDia)
+		}
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, p := range drawn.Points {
+			rows = append(rows, []string{strconv.Itoa(p.Span), cell(p.X),
+				cell(p.MaxMoment), cell(p.MinMoment), cell(p.MaxShear), cell(p.MinShear)})
+		}
+		m, v := actionSymbol(nscp.ActionMoment, isASD), actionSymbol(nscp.ActionShear, isASD)
+		printTable([]string{"Span", "x (m)", m + " max (kN-m)", m + " min (kN-m)", v + " max (kN)", v + " min (kN)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, b, continuousReport{Reactions: analysis.Reactions, Combinations: diagrams, Envelope: envelope, Stirrups: stirrups, Cutoffs: cutoffs})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("          CONTINUOUS BEAM ANALYSIS - %s\n", continuousCombinations.Title)
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	if b.Name != "" {
+		fmt.Printf("  Beam: %s\n\n", b.Name)
+	}
+
+	fmt.Println("SPANS AND LOADS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Spans:\t%s\n", continuousSpans(b))
+	fmt.Fprintf(w, "  Supports:\t%s at the left end, %s at the right end\n", continuousSupport(b.Left), continuousSupport(b.Right))
+	w.Flush()
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Load\tSpan\tw (kN/m)\tP (kN)\tx (m)\n")
+	fmt.Fprintf(w, "  ────\t────\t────────\t──────\t─────\n")
+	for _, l := range b.Loads {
+		span := "all"
+		if l.Span > 0 {
+			span = strconv.Itoa(l.Span)
+		}
+		if l.P != 0 {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%.2f\t%.2f\n", l.Type, span, continuousValue(l.W), l.P, l.X)
+		} else {
+			fmt.Fprintf(w, "  %s\t%s\t%.2f\t-\t-\n", l.Type, span, l.W)
+		}
+	}
+	w.Flush()
+	fmt.Println()
+
+	continuousCombinations.printSeismicEffect(0, "")
+
+	fmt.Println("UNFACTORED REACTIONS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  x (m)")
+	for _, t := range b.LoadTypes() {
+		fmt.Fprintf(w, "\t%s (kN)", t)
+	}
+	fmt.Fprintln(w)
+	for _, r := range analysis.Reactions {
+		fmt.Fprintf(w, "  %.2f", r.X)
+		for _, t := range b.LoadTypes() {
+			fmt.Fprintf(w, "\t%.2f", r.Force.Moment(t))
+			if m := r.Moment.Moment(t); m != 0 {
+				fmt.Fprintf(w, " (M = %.2f kN-m)", m)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Println()
+
+	m, v := actionSymbol(nscp.ActionMoment, isASD), actionSymbol(nscp.ActionShear, isASD)
+	fmt.Printf("LOAD COMBINATIONS (%s):\n", continuousCombinations.Title)
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  #\tCombination\t+%s (kN-m)\t−%s (kN-m)\t%s (kN)\n", m, m, v)
+	fmt.Fprintf(w, "  ─\t───────────\t──────────\t──────────\t──────\n")
+	for _, d := range append(diagrams, envelope) {
+		sagging, hogging, shear, xs, xh, xv := d.Extremes()
+		fmt.Fprintf(w, "  %s\t%s\t%.2f @ %.2f m\t%.2f @ %.2f m\t%.2f @ %.2f m\n",
+			d.ID, d.Description, sagging, xs, hogging, xh, math.Abs(shear), xv)
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("DESIGN VALUES PER SPAN (ENVELOPE):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Span\tL (m)\t−%s left (kN-m)\t+%s (kN-m)\t−%s right (kN-m)\t%s max (kN)\n", m, m, m, v)
+	fmt.Fprintf(w, "  ────\t─────\t──────────────\t─────────\t───────────────\t──────────\n")
+	for i, d := range continuousDesignValues(b, envelope) {
+		fmt.Fprintf(w, "  %d\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n", i+1, b.Spans[i], d.Left, d.Sagging, d.Right, d.Shear)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if continuousShowStirrups {
+		fmt.Printf("STIRRUP ZONES (%s, %s):\n", strings.ToUpper(drawn.ID), continuousStirrupName())
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		w = newTextWriter()
+		fmt.Fprintf(w, "  Span\tFrom (m)\tTo (m)\tStirrups\t%s (kN)\n", v)
+		fmt.Fprintf(w, "  ────\t────────\t──────\t────────\t──────\n")
+		for _, s := range stirrups {
+			for _, z := range s.Zones {
+				zone := "not required"
+				if z.Spacing > 0 {
+					zone = fmt.Sprintf("%d @ %s", z.Count, fmtSpacing(z.Spacing, 0))
+				}
+				fmt.Fprintf(w, "  %d\t%.2f\t%.2f\t%s\t%.2f\n", s.Span, z.Start, z.End, zone, z.Vu)
+			}
+		}
+		w.Flush()
+		fmt.Println(diagram.DrawASCIIStirrupDiagram(continuousStirrupData(b, stirrups)))
+	}
+
+	if continuousShowCutoffs {
+		fmt.Printf("BAR CUTOFFS (%s, φ%.0fmm BARS):\n", strings.ToUpper(drawn.ID), continuousBarDia)
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		w = newTextWriter()
+		fmt.Fprintf(w, "  Face\tBars\tFrom (m)\tTo (m)\tLength (m)\tNeeded (m)\tFor\n")
+		fmt.Fprintf(w, "  ────\t────\t────────\t──────\t──────────\t──────────\t───\n")
+		for _, r := range cutoffs.Runs {
+			face, needed, what := "Bottom", "-", "continuous"
+			if r.Top {
+				face = "Top"
+			}
+			if !r.Continuous {
+				needed = fmt.Sprintf("%.2f - %.2f", r.TheoreticalStart, r.TheoreticalEnd)
+				what = fmt.Sprintf("+%s in span %d", m, r.Span)
+				if r.Top {
+					what = fmt.Sprintf("−%s at %.2f m", m, r.Support)
+				}
+			}
+			fmt.Fprintf(w, "  %s\t%d-φ%.0f\t%.2f\t%.2f\t%.2f\t%s\t%s\n", face, r.Count, r.Diameter, r.Start, r.End, r.End-r.Start, needed, what)
+		}
+		w.Flush()
+		w = newTextWriter()
+		fmt.Fprintf(w, "  Continuous bars:\t%d top (φMn = %.2f kN-m), %d bottom (φMn = %.2f kN-m)\n",
+			cutoffs.ContinuousTop, cutoffs.CapacityTop, cutoffs.ContinuousBottom, cutoffs.CapacityBottom)
+		fmt.Fprintf(w, "  Development length ld:\t%s top, %s bottom\n", fmtLength(cutoffs.LdTop, 0), fmtLength(cutoffs.LdBottom, 0))
+		fmt.Fprintf(w, "  Extension past cutoff:\t%s = max(d, 12db)\n", fmtLength(cutoffs.Extension, 0))
+		for _, l := range cutoffs.Laps {
+			face := "bottom"
+			if l.Top {
+				face = "top"
+			}
+			fmt.Fprintf(w, "  Lap of %s bars:\t%.2f - %.2f m (%s, %s)\n", face, l.Start, l.End, l.Class, fmtLength((l.End-l.Start)*1000, 0))
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	if continuousShowDiagram {
+		fmt.Println(diagram.DrawASCIIForceDiagram(continuousDiagramData(b, drawn, diagrams, diagram.MomentDiagram)))
+		fmt.Println(diagram.DrawASCIIForceDiagram(continuousDiagramData(b, drawn, diagrams, diagram.ShearDiagram)))
+	}
+
+	for _, export := range []struct {
+		file string
+		kind diagram.ForceDiagramKind
+		name string
+	}{{continuousBMDFile, diagram.MomentDiagram, "Bending moment diagram"}, {continuousSFDFile, diagram.ShearDiagram, "Shear force diagram"}} {
+		if export.file == "" {
+			continue
+		}
+		err := diagram.ExportForceDiagram(continuousDiagramData(b, drawn, diagrams, export.kind), export.file)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("%s exported to: %s\n", export.name, export.file)
+			printPlotData(export.file)
+		}
+	}
+	if continuousEnvelopeFile != "" {
+		err := diagram.ExportEnvelopeDiagram(continuousEnvelopeData(b, envelope, diagrams, isASD), continuousEnvelopeFile)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Moment envelope exported to: %s\n", continuousEnvelopeFile)
+			printPlotData(continuousEnvelopeFile)
+		}
+	}
+	if continuousElevation != "" {
+		err := diagram.ExportElevationDiagram(continuousElevationData(b, drawn, diagrams), continuousElevation)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Beam elevation exported to: %s\n", continuousElevation)
+			printPlotData(continuousElevation)
+		}
+	}
+	if continuousStirrupFile != "" {
+		err := diagram.ExportStirrupDiagram(continuousStirrupData(b, stirrups), continuousStirrupFile)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Stirrup elevation exported to: %s\n", continuousStirrupFile)
+			printPlotData(continuousStirrupFile)
+		}
+	}
+	if continuousShearFile != "" {
+		data, err := continuousShearCapacityData(b, drawn, stirrups)
+		if err == nil {
+			err = diagram.ExportShearCapacityDiagram(data, continuousShearFile)
+		}
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Shear demand and capacity exported to: %s\n", continuousShearFile)
+			printPlotData(continuousShearFile)
+		}
+	}
+	if continuousCutoffFile != "" {
+		err := diagram.ExportCutoffDiagram(continuousCutoffData(b, cutoffs), continuousCutoffFile)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Bar cutoff elevation exported to: %s\n", continuousCutoffFile)
+			printPlotData(continuousCutoffFile)
+		}
+	}
+}
+
+// continuousSection returns the rectangular section of the beam for the
+// stirrup zones and bar cutoffs
+func continuousSection(isASD bool) (*beam.SinglyReinforced, error) {
+	if isASD {
+		return nil, fmt.Errorf("stirrup zones and bar cutoffs are designed for the factored actions of strength design (--method lrfd)")
+	}
+	if continuousWidth <= 0 || continuousHeight <= 0 || continuousCover >= continuousHeight {
+		return nil, fmt.Errorf("give the width and height of the section: width=%.2f, height=%.2f, cover=%.2f",
+			continuousWidth, continuousHeight, continuousCover)
+	}
+	section := beam.NewSinglyReinforced(continuousWidth, continuousHeight, continuousCover, continuousFc, continuousFy)
+	section.Code = selectedCode
+	return section, nil
+}
+
+// continuousCutoffData returns the elevation of the bars along the beam
+func continuousCutoffData(b *continuous.Beam, c *continuous.Curtailment) diagram.CutoffDiagramData {
+	data := diagram.CutoffDiagramData{
+		Name:     b.Name,
+		Spans:    b.Spans,
+		Supports: continuousDiagramData(b, continuous.Diagram{}, nil, diagram.MomentDiagram).Supports,
+		Height:   continuousHeight,
+		Cover:    continuousCover,
+		LdTop:    c.LdTop,
+		LdBottom: c.LdBottom,
+		Units:    selectedUnits,
+	}
+	for _, r := range c.Runs {
+		data.Bars = append(data.Bars, diagram.BarExtent{Top: r.Top, Label: fmt.Sprintf("%d-Ø%.0f", r.Count, r.Diameter),
+			Start: r.Start, End: r.End, Continuous: r.Continuous})
+	}
+	for _, l := range c.Laps {
+		data.Laps = append(data.Laps, diagram.BarLap{Top: l.Top, Start: l.Start, End: l.End})
+	}
+	return data
+}
+
+// continuousStirrupZones designs the stirrup zones of each span of the
+// beam for the shear of a diagram
+func continuousStirrupZones(b *continuous.Beam, d continuous.Diagram, isASD bool) ([]continuousStirrups, error) {
+	section, err := continuousSection(isASD)
+	if err != nil {
+		return nil, err
+	}
+	opts := beam.StirrupOptions{Diameter: continuousStirrupDia, Legs: continuousLegs, Fyt: continuousFyt}
+
+	var stirrups []continuousStirrups
+	for i := range b.Spans {
+		var x, vu []float64
+		for _, p := range d.Points {
+			if p.Span == i+1 {
+				x = append(x, p.X-b.Start(i))
+				vu = append(vu, math.Max(math.Abs(p.MaxShear), math.Abs(p.MinShear)))
+			}
+		}
+		zones, err := section.DesignStirrupZones(x, vu, opts)
+		if err != nil {
+			return nil, fmt.Errorf("span %d: %w", i+1, err)
+		}
+		stirrups = append(stirrups, continuousStirrups{Span: i + 1, Zones: zones})
+	}
+	return stirrups, nil
+}
+
+// continuousStirrupName describes the stirrups, e.g. "2-leg φ10mm"
+func continuousStirrupName() string {
+	return fmt.Sprintf("%d-leg φ%.0fmm", continuousLegs, continuousStirrupDia)
+}
+
+// continuousStirrupData returns the elevation of the stirrup zones along
+// the beam
+func continuousStirrupData(b *continuous.Beam, stirrups []continuousStirrups) diagram.StirrupDiagramData {
+	data := diagram.StirrupDiagramData{
+		Name:       b.Name,
+		Stirrup:    continuousStirrupName(),
+		Spans:      b.Spans,
+		Height:     continuousHeight,
+		ClearCover: beam.DefaultClearCover,
+		Units:      selectedUnits,
+	}
+	for _, s := range stirrups {
+		for _, z := range s.Zones {
+			start := b.Start(s.Span - 1)
+			data.Zones = append(data.Zones, diagram.StirrupZone{Start: start + z.Start, End: start + z.End, Spacing: z.Spacing, Count: z.Count})
+		}
+	}
+	data.Supports = continuousDiagramData(b, continuous.Diagram{}, nil, diagram.ShearDiagram).Supports
+	return data
+}
+
+// continuousShearCapacityData returns the shear of a diagram along the beam
+// against the strength of the concrete and of the stirrup zones
+func continuousShearCapacityData(b *continuous.Beam, d continuous.Diagram, stirrups []continuousStirrups) (diagram.ShearCapacityData, error) {
+	data := diagram.ShearCapacityData{
+		Name:   b.Name,
+		Symbol: actionSymbol(nscp.ActionShear, false),
+		Units:  selectedUnits,
+	}
+	section, err := continuousSection(false)
+	if err != nil {
+		return data, err
+	}
+	concrete, err := section.DesignShear(0, beam.StirrupOptions{Diameter: continuousStirrupDia, Legs: continuousLegs, Fyt: continuousFyt})
+	if err != nil {
+		return data, err
+	}
+	data.PhiVc = concrete.PhiVc
+
+	for _, p := range d.Points {
+		data.Demand = append(data.Demand, diagram.Point{X: p.X, Y: math.Max(math.Abs(p.MaxShear), math.Abs(p.MinShear))})
+	}
+	for _, s := range stirrups {
+		start := b.Start(s.Span - 1)
+		for _, z := range s.Zones {
+			data.Zones = append(data.Zones, diagram.ShearZone{Start: start + z.Start, End: start + z.End, Spacing: z.Spacing, PhiVn: z.PhiVn})
+		}
+	}
+	data.Supports = continuousDiagramData(b, continuous.Diagram{}, nil, diagram.ShearDiagram).Supports
+	return data, nil
+}
+
+// continuousElevationData returns the elevation of the beam with its loads
+// on the spans they load, over the moment diagram of the drawn combination
+// or envelope
+func continuousElevationData(b *continuous.Beam, drawn continuous.Diagram, diagrams []continuous.Diagram) diagram.ElevationDiagramData {
+	moments := continuousDiagramData(b, drawn, diagrams, diagram.MomentDiagram)
+	data := diagram.ElevationDiagramData{
+		Name:    b.Name,
+		Spans:   b.Spans,
+		Left:    continuousSupport(b.Left),
+		Right:   continuousSupport(b.Right),
+		Moments: &moments,
+		Units:   selectedUnits,
+	}
+	for _, l := range b.Loads {
+		if l.W != 0 {
+			start, end := 0.0, b.Length()
+			if l.Span > 0 {
+				start = b.Start(l.Span - 1)
+				end = start + b.Spans[l.Span-1]
+			}
+			data.Loads = append(data.Loads, diagram.ElevationLoad{Type: string(l.Type), W: l.W, Start: start, End: end})
+		}
+		if l.P != 0 {
+			data.Loads = append(data.Loads, diagram.ElevationLoad{Type: string(l.Type), P: l.P, X: b.Start(max(l.Span, 1)-1) + l.X})
+		}
+	}
+	return data
+}
+
+// continuousSpanDesign is the design moments and shear of a span from the
+// envelope: the hogging moments at its ends and the largest sagging moment
+// in it with its position
+type continuousSpanDesign struct {
+	Left, Right, Sagging float64 // kN-m
+	XSagging             float64 // m
+	Shear                float64 // kN
+}
+
+// continuousDesignValues returns the design values of each span from the
+// stations of the envelope in it
+func continuousDesignValues(b *continuous.Beam, envelope continuous.Diagram) []continuousSpanDesign {
+	values := make([]continuousSpanDesign, len(b.Spans))
+	for i := range b.Spans {
+		v := &values[i]
+		first := true
+		for _, p := range envelope.Points {
+			if p.Span != i+1 {
+				continue
+			}
+			if first {
+				v.Left, first = p.MinMoment, false
+			}
+			v.Right = p.MinMoment
+			if p.MaxMoment > v.Sagging {
+				v.Sagging, v.XSagging = p.MaxMoment, p.X
+			}
+			v.Shear = math.Max(v.Shear, math.Max(math.Abs(p.MaxShear), math.Abs(p.MinShear)))
+		}
+	}
+	return values
+}
+
+// continuousEnvelopeData returns the moment envelope over the moments of
+// every combination, with the hogging moment at each support and the
+// largest sagging moment of each span annotated
+func continuousEnvelopeData(b *continuous.Beam, envelope continuous.Diagram, diagrams []continuous.Diagram, isASD bool) diagram.EnvelopeDiagramData {
+	data := diagram.EnvelopeDiagramData{
+		Moments: continuousDiagramData(b, envelope, diagrams, diagram.MomentDiagram),
+		Symbol:  actionSymbol(nscp.ActionMoment, isASD),
+	}
+	hogging := func(x, m float64) {
+		if m < 0 {
+			data.Values = append(data.Values, diagram.EnvelopeValue{X: x, Moment: m})
+		}
+	}
+	values := continuousDesignValues(b, envelope)
+	for i, v := range values {
+		if i == 0 {
+			hogging(b.Start(0), v.Left)
+		} else {
+			hogging(b.Start(i), math.Min(values[i-1].Right, v.Left))
+		}
+		if v.Sagging > 0 {
+			data.Values = append(data.Values, diagram.EnvelopeValue{X: v.XSagging, Moment: v.Sagging})
+		}
+	}
+	hogging(b.Length(), values[len(values)-1].Right)
+	return data
+}
+
+// continuousDiagramData returns the moment or shear diagram of the drawn
+// combination or envelope, with the other combinations behind the envelope
+func continuousDiagramData(b *continuous.Beam, drawn continuous.Diagram, diagrams []continuous.Diagram, kind diagram.ForceDiagramKind) diagram.ForceDiagramData {
+	curve := func(d continuous.Diagram) diagram.ForceCurve {
+		c := diagram.ForceCurve{Label: d.ID}
+		if d.ID != continuous.EnvelopeID {
+			c.Label = "Combination " + d.ID
+		}
+		for _, p := range d.Points {
+			if kind == diagram.ShearDiagram {
+				c.Max = append(c.Max, diagram.Point{X: p.X, Y: p.MaxShear})
+				c.Min = append(c.Min, diagram.Point{X: p.X, Y: p.MinShear})
+			} else {
+				c.Max = append(c.Max, diagram.Point{X: p.X, Y: p.MaxMoment})
+				c.Min = append(c.Min, diagram.Point{X: p.X, Y: p.MinMoment})
+			}
+		}
+		return c
+	}
+
+	data := diagram.ForceDiagramData{Name: b.Name, Kind: kind, Main: curve(drawn), Units: selectedUnits}
+	if drawn.ID == continuous.EnvelopeID {
+		for _, d := range diagrams {
+			data.Others = append(data.Others, curve(d))
+		}
+	}
+	for i := range b.Spans {
+		if i > 0 || b.Left != continuous.SupportFree {
+			data.Supports = append(data.Supports, b.Start(i))
+		}
+	}
+	if b.Right != continuous.SupportFree {
+		data.Supports = append(data.Supports, b.Length())
+	}
+	return data
+}
+
+// continuousSpans lists the spans of a beam, e.g. "6.00 + 7.50 = 13.50 m"
+func continuousSpans(b *continuous.Beam) string {
+	s := ""
+	for i, l := range b.Spans {
+		if i > 0 {
+			s += " + "
+		}
+		s += fmt.Sprintf("%.2f", l)
+	}
+	if len(b.Spans) > 1 {
+		s += fmt.Sprintf(" = %.2f", b.Length())
+	}
+	return s + " m"
+}
+
+// continuousSupport names an end support, pinned when unset
+func continuousSupport(s string) string {
+	if s == "" {
+		return continuous.SupportPinned
+	}
+	return s
+}
+
+// continuousValue prints a load value, or a dash when it is zero
+func continuousValue(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
 	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/check"
 	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -18,11 +20,25 @@ var (
 	designCover  float64
 	designFc     float64
 	designFy     float64
+	designGrade  gradeInput
 	designMu     float64
 
+	// Concrete type for λ
+	designConcreteType string
+
+	// Number of ranked design alternatives
+	designTop int
+
 	// Diagram options
 	designShowDiagram bool
 	designExportFile  string
+	designDXF         string
+
+	// Exposure cover check
+	designCoverCheck coverInputs
+
+	// Lap splices
+	designSplices spliceInputs
 )
 
 var beamDesignCmd = &cobra.Command{
@@ -36,17 +52,43 @@ The design follows NSCP 2015 provisions:
   - Section 409.6.1.2: Minimum reinforcement
   - Section 410.2.7.3: Equivalent rectangular stress block
 
+The required steel is then laid out with each flexural bar size in up to two
+layers inside the stirrups (--stirrup-dia) at the minimum clear spacing, the
+clear cover taken as the cover less the stirrup and half the bar (--bar-dia).
+φMn of each layout is verified again with the d of its bars, adding bars
+while it falls short of Mu. When the section designed as doubly reinforced
+needs compression steel, it is also laid out with compression bars. The
+feasible alternatives (bar sizes, singly or doubly, one or two layers) are
+ranked by steel weight, the better utilized first at equal weight; --top sets
+how many are listed.
+
 Examples:
   # Design a 300x500mm beam with Mu=150 kN-m
   gorcb beam design --width 300 --height 500 --cover 65 --fc 28 --fy 415 --mu 150
 
   # Using short flags
-  gorcb beam design -b 300 -h 500 -c 65 --fc 28 --fy 415 -m 150`,
+  gorcb beam design -b 300 -h 500 -c 65 --fc 28 --fy 415 -m 150
+
+  # Verify the cover for an exterior beam with 25mm bars and 10mm stirrups
+  gorcb beam design -b 300 --height 500 -c 75 -m 150 --exposure exterior --bar-dia 25
+
+  # The three lightest bar layouts
+  gorcb beam design -b 300 --height 500 -m 150 --top 3
+
+  # Lap splice classes and lengths with half of the bars spliced at one location
+  gorcb beam design -b 300 --height 500 -m 150 --splices --spliced-fraction 0.5
+
+  # Detailing drawing of the designed section for the structural sheets
+  gorcb beam design -b 300 --height 500 -m 150 --dxf B1.dxf`,
 	Run: runBeamDesign,
 }
 
 func init() {
 	beamCmd.AddCommand(beamDesignCmd)
+	unitAware(beamDesignCmd)
+	quiet(beamDesignCmd)
+	traceable(beamDesignCmd)
+	reportable(beamDesignCmd)
 
 	// Geometry flags
 	beamDesignCmd.Flags().Float64VarP(&designWidth, "width", "b", 0, "Beam width (mm) [required]")
@@ -56,6 +98,8 @@ func init() {
 	// Material flags
 	beamDesignCmd.Flags().Float64Var(&designFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
 	beamDesignCmd.Flags().Float64Var(&designFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(beamDesignCmd, &designGrade)
+	beamDesignCmd.Flags().StringVar(&designConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
 
 	// Loading flag
 	beamDesignCmd.Flags().Float64VarP(&designMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
@@ -65,47 +109,103 @@ func init() {
 	beamDesignCmd.MarkFlagRequired("height")
 	beamDesignCmd.MarkFlagRequired("mu")
 
+	// Cover check flags
+	addCoverFlags(beamDesignCmd, &designCoverCheck)
+	addSpliceFlags(beamDesignCmd, &designSplices)
+
+	beamDesignCmd.Flags().IntVar(&designTop, "top", 10, "Number of ranked design alternatives to list")
+
 	// Diagram options
 	beamDesignCmd.Flags().BoolVar(&designShowDiagram, "diagram", false, "Show ASCII stress-strain diagram")
 	beamDesignCmd.Flags().StringVarP(&designExportFile, "output", "o", "", "Export diagram to file (png, svg, pdf)")
+	beamDesignCmd.Flags().StringVar(&designDXF, "dxf", "", "Export a detailing drawing of the section with bars, stirrup and dimensions to a DXF file")
 }
 
 func runBeamDesign(cmd *cobra.Command, args []string) {
+	if err := designGrade.resolve(&designFy); err != nil {
+		printError(err)
+		return
+	}
+	if err := designCoverCheck.validate(); err != nil {
+		printError(err)
+		return
+	}
+	if err := designSplices.validate(); err != nil {
+		printError(err)
+		return
+	}
+	if designTop < 1 {
+		fmt.Println("Error: --top must be at least 1")
+		setExit(exitInvalidInput)
+		return
+	}
+
 	// Create beam
 	b := beam.NewSinglyReinforced(designWidth, designHeight, designCover, designFc, designFy)
+	b.Code = selectedCode
+
+	lambda, err := concreteLambda(designConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+	b.Lambda, designSplices.Lambda = lambda, lambda
+	b.Density = concreteDensity(designConcreteType)
 
 	// Run design
 	result, err := b.Design(designMu)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		printError(err)
+		return
+	}
+	checkAdequacy(result.IsAdequate)
+	if reportFile != "" {
+		defer writeReport(beamDesignReport(cmd, b, result))
+	}
+	runPlugins(cmd, b, result)
+	defer printPluginResults()
+	printQuiet(quietResult{As: result.AsRequired, PhiMn: result.PhiMn, Status: quietStatus(result.IsAdequate)})
+	if quietOutput {
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, b, result)
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     SINGLY REINFORCED BEAM DESIGN - NSCP 2015")
+	fmt.Printf("     SINGLY REINFORCED BEAM DESIGN - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Input summary
 	fmt.Println("INPUT DATA:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Beam Width (b):\t%.0f mm\n", b.Width)
-	fmt.Fprintf(w, "  Beam Depth (h):\t%.0f mm\n", b.Height)
-	fmt.Fprintf(w, "  Effective Depth (d):\t%.0f mm\n", b.EffectiveDepth)
-	fmt.Fprintf(w, "  Concrete Cover:\t%.0f mm\n", b.Cover)
-	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", b.Fc)
-	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", b.Fy)
-	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", designMu)
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Beam Width (b):\t%s\n", fmtLength(b.Width, 0))
+	fmt.Fprintf(w, "  Beam Depth (h):\t%s\n", fmtLength(b.Height, 0))
+	fmt.Fprintf(w, "  Effective Depth (d):\t%s\n", fmtLength(b.EffectiveDepth, 0))
+	fmt.Fprintf(w, "  Concrete Cover:\t%s\n", fmtLength(b.Cover, 0))
+	fmt.Fprintf(w, "  f'c:\t%s\n", fmtStress(b.Fc, 1))
+	fmt.Fprintf(w, "  fy:\t%s\n", fmtStress(b.Fy, 1))
+	printGrade(w, designGrade)
+	if lambda < 1 {
+		fmt.Fprintf(w, "  λ (concrete type):\t%.2f\n", lambda)
+	}
+	fmt.Fprintf(w, "  Factored Moment (Mu):\t%s\n", fmtMoment(designMu, 2))
 	w.Flush()
 	fmt.Println()
 
+	if traceOutput {
+		designTrace(b, designMu, result).print()
+	}
+
 	// Reinforcement ratios
 	fmt.Println("REINFORCEMENT RATIOS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  ρ_min:\t%.6f\n", result.RhoMin)
 	fmt.Fprintf(w, "  ρ_max (tension-controlled):\t%.6f\n", result.RhoMax)
 	fmt.Fprintf(w, "  ρ_bal:\t%.6f\n", result.RhoBalanced)
@@ -116,18 +216,19 @@ func runBeamDesign(cmd *cobra.Command, args []string) {
 	// Steel area limits
 	fmt.Println("STEEL AREA LIMITS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  As,min:\t%.2f mm²\n", result.AsMin)
-	fmt.Fprintf(w, "  As,max:\t%.2f mm²\n", result.AsMax)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  As,min:\t%s\n", fmtArea(result.AsMin, 2))
+	fmt.Fprintf(w, "  As,max:\t%s\n", fmtArea(result.AsMax, 2))
+	printMinSteelAlternative(w, result.AsStrength, result.AsAlternative)
 	w.Flush()
 	fmt.Println()
 
 	// Section analysis
 	fmt.Println("SECTION ANALYSIS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Compression block depth (a):\t%.2f mm\n", result.A)
-	fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", result.C)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Compression block depth (a):\t%s\n", fmtLength(result.A, 2))
+	fmt.Fprintf(w, "  Neutral axis depth (c):\t%s\n", fmtLength(result.C, 2))
 	fmt.Fprintf(w, "  Tensile strain (εt):\t%.6f\n", result.EpsilonT)
 	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
 	controlStatus := "Tension-controlled"
@@ -144,128 +245,187 @@ func runBeamDesign(cmd *cobra.Command, args []string) {
 
 	if result.IsAdequate {
 		fmt.Printf("  ╔═════════════════════════════════════════╗\n")
-		fmt.Printf("  ║  REQUIRED As = %.2f mm²              \n", result.AsRequired)
+		fmt.Printf("  ║  REQUIRED As = %s              \n", fmtArea(result.AsRequired, 2))
 		fmt.Printf("  ╚═════════════════════════════════════════╝\n")
 		fmt.Println()
-		fmt.Printf("  φMn = %.2f kN-m ≥ Mu = %.2f kN-m ✓\n", result.PhiMn, designMu)
+		fmt.Printf("  φMn = %s ≥ Mu = %s ✓\n", fmtMoment(result.PhiMn, 2), fmtMoment(designMu, 2))
 		fmt.Println()
-		fmt.Printf("  Status: %s\n", result.Message)
+		fmt.Printf("  Status: %s\n", i18n.T(result.Message))
 	} else {
 		fmt.Println("  ╔═════════════════════════════════════════╗")
 		fmt.Println("  ║  DESIGN NOT ADEQUATE                    ║")
 		fmt.Println("  ╚═════════════════════════════════════════╝")
 		fmt.Println()
-		fmt.Printf("  %s\n", result.Message)
+		fmt.Printf("  %s\n", i18n.T(result.Message))
 	}
 	fmt.Println()
 
-	// Suggested bar combinations
-	if result.IsAdequate {
-		printBarSuggestions(result.AsRequired)
+	printCoverCheck(designCoverCheck, coverFace{"Bottom", designCover})
+
+	// Ranked bar layouts, singly and doubly reinforced
+	if alternatives := designAlternatives(b, result); result.IsAdequate || len(alternatives) > 0 {
+		printBarLayouts("DESIGN ALTERNATIVES", alternatives, true, designCoverCheck, designCover, b.EffectiveDepth)
+	}
+
+	// Lap splices of the suggested bars
+	if designSplices.Show && result.IsAdequate {
+		fmt.Printf("LAP SPLICES (%s, %.0f%% of bars spliced):\n", selectedCode.Name(), designSplices.FractionSpliced*100)
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		printLapSplicesFor(designSplices, result.AsRequired, designFc, designFy, false, "  ")
+		fmt.Println()
 	}
 
 	// Show diagram if requested
 	if designShowDiagram && result.IsAdequate {
-		epsilonY := designFy / nscp.Es
-		tensionYields := result.EpsilonT >= epsilonY
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            designWidth,
-			Height:           designHeight,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    designCover,
-			TensionSteelArea: result.AsRequired,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * designFc,
-			FsTension:        designFy,
-			TensionYields:    tensionYields,
-			IsDoubly:         false,
-		}
+		diagramData := detailBars(singlyDiagramData(b, result.AsRequired, result.A, result.C, result.EpsilonT), designCoverCheck)
 
 		fmt.Println(diagram.DrawASCIISectionDiagram(diagramData))
 		fmt.Println(diagram.DrawStrainDiagram(diagramData))
+		fmt.Println(diagram.DrawStressBlock(diagramData))
 	}
 
 	// Export diagram if requested
 	if designExportFile != "" && result.IsAdequate {
-		epsilonY := designFy / nscp.Es
-		tensionYields := result.EpsilonT >= epsilonY
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            designWidth,
-			Height:           designHeight,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    designCover,
-			TensionSteelArea: result.AsRequired,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * designFc,
-			FsTension:        designFy,
-			TensionYields:    tensionYields,
-			IsDoubly:         false,
-		}
+		diagramData := detailBars(singlyDiagramData(b, result.AsRequired, result.A, result.C, result.EpsilonT), designCoverCheck)
 
 		err := diagram.ExportSectionDiagram(diagramData, designExportFile)
 		if err != nil {
 			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
 		} else {
 			fmt.Printf("Diagram exported to: %s\n", designExportFile)
+			printPlotData(designExportFile)
 		}
 	}
-}
 
-// Common rebar areas in mm²
-var rebarAreas = map[int]float64{
-	10: 78.54,   // 10mm diameter
-	12: 113.10,  // 12mm diameter
-	16: 201.06,  // 16mm diameter
-	20: 314.16,  // 20mm diameter
-	25: 490.87,  // 25mm diameter
-	28: 615.75,  // 28mm diameter
-	32: 804.25,  // 32mm diameter
-	36: 1017.88, // 36mm diameter
+	if designDXF != "" && result.IsAdequate {
+		diagramData := detailBars(singlyDiagramData(b, result.AsRequired, result.A, result.C, result.EpsilonT), designCoverCheck)
+		if err := diagram.ExportSectionDXF(diagramData, designDXF); err != nil {
+			fmt.Printf("Error exporting drawing: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Detailing drawing exported to: %s\n", designDXF)
+		}
+	}
 }
 
-func printBarSuggestions(asRequired float64) {
-	fmt.Println("SUGGESTED BAR COMBINATIONS:")
-	fmt.Println("───────────────────────────────────────────────────────────────")
+// Bar counts offered in bar suggestions and layouts
+const (
+	suggestMinBars = 2
+	suggestMaxBars = 8
+)
 
-	suggestions := []struct {
-		dia   int
-		count int
-		area  float64
-	}{}
-
-	// Find suitable combinations
-	for _, dia := range []int{16, 20, 25, 28, 32} {
-		area := rebarAreas[dia]
-		count := int(asRequired/area) + 1
-		if count >= 2 && count <= 8 {
-			totalArea := float64(count) * area
-			if totalArea >= asRequired {
-				suggestions = append(suggestions, struct {
-					dia   int
-					count int
-					area  float64
-				}{dia, count, totalArea})
-			}
-		}
+// printMinSteelAlternative prints the steel required by analysis and the
+// 4/3 alternative that may be provided in lieu of As,min when the minimum governs
+func printMinSteelAlternative(w io.Writer, asStrength, asAlternative float64) {
+	if asAlternative <= 0 {
+		return
 	}
+	fmt.Fprintf(w, "  As by analysis:\t%s\n", fmtArea(asStrength, 2))
+	fmt.Fprintf(w, "  Alternative to As,min (4/3·As):\t%s (permitted in lieu of As,min)\n", fmtArea(asAlternative, 2))
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Bars\tAs Provided\tRatio\n")
-	fmt.Fprintf(w, "  ────\t───────────\t─────\n")
+// designAlternatives lays out the steel of a singly reinforced design, and
+// of the same section designed as doubly reinforced when it needs compression
+// steel, in one and two layers, and returns the --top lightest layouts
+func designAlternatives(b *beam.SinglyReinforced, r *beam.DesignResult) []check.Layout {
+	opts, ok := layoutOptions(designCoverCheck, b.Width, b.Height, b.Cover, b.Fc, b.Fy)
+	if !ok {
+		return nil
+	}
+	as2, asc := r.AsRequired, 0.0
+	doubly := beam.NewDoublyReinforced(b.Width, b.Height, b.Cover, b.Cover, b.Fc, b.Fy)
+	doubly.Code = selectedCode
+	if dr, err := doubly.Design(designMu); err == nil && dr.IsAdequate {
+		as2 = dr.AsTotal
+		if dr.RequiresCompSteel {
+			asc = dr.AscRequired
+		}
+	}
+	alternatives := check.Alternatives(r.AsRequired, as2, asc, designMu, opts, selectedCatalog, selectedCode)
+	return alternatives[:min(designTop, len(alternatives))]
+}
 
-	for _, s := range suggestions {
-		ratio := s.area / asRequired
-		fmt.Fprintf(w, "  %d - φ%dmm\t%.2f mm²\t%.2f\n", s.count, s.dia, s.area, ratio)
+// singlyDiagramData returns the section diagram of a singly reinforced beam
+// with tension steel as and the stress block of its analysis
+func singlyDiagramData(b *beam.SinglyReinforced, as, a, c, epsilonT float64) diagram.SectionDiagramData {
+	epsilonY := selectedCode.DesignYieldStrength(b.Fy) / nscp.Es
+	return diagram.SectionDiagramData{
+		Width:            b.Width,
+		Height:           b.Height,
+		NeutralAxisDepth: c,
+		StressBlockDepth: a,
+		TensionSteelY:    b.Cover,
+		TensionSteelArea: as,
+		EpsilonCU:        selectedCode.EpsilonCU(b.Fc),
+		EpsilonT:         epsilonT,
+		EpsilonY:         epsilonY,
+		Fc:               selectedCode.Alpha1(b.Fc) * b.Fc,
+		FsTension:        selectedCode.DesignYieldStrength(b.Fy),
+		TensionYields:    epsilonT >= epsilonY,
+		IsDoubly:         false,
+		Units:            selectedUnits,
 	}
-	w.Flush()
-	fmt.Println()
 }
 
+// beamDesignReport returns the design report of a singly reinforced beam
+func beamDesignReport(cmd *cobra.Command, b *beam.SinglyReinforced, r *beam.DesignResult) *report.Document {
+	doc := newReport(cmd, "Singly Reinforced Beam Design")
+	doc.Conclude(r.IsAdequate, r.Message)
+
+	doc.Section("Input Data")
+	doc.Fields(
+		[]string{"Beam width (b)", fmtLength(b.Width, 0)},
+		[]string{"Beam depth (h)", fmtLength(b.Height, 0)},
+		[]string{"Effective depth (d)", fmtLength(b.EffectiveDepth, 0)},
+		[]string{"Concrete cover", fmtLength(b.Cover, 0)},
+		[]string{"f'c", fmtStress(b.Fc, 1)},
+		[]string{"fy", fmtStress(b.Fy, 1)},
+		reportGrade(designGrade),
+		[]string{"Factored moment (Mu)", fmtMoment(designMu, 2)},
+	)
+
+	doc.Section("Reinforcement Limits")
+	doc.Fields(
+		[]string{"ρmin", fmt.Sprintf("%.6f", r.RhoMin)},
+		[]string{"ρmax (tension-controlled)", fmt.Sprintf("%.6f", r.RhoMax)},
+		[]string{"ρbal", fmt.Sprintf("%.6f", r.RhoBalanced)},
+		[]string{"ρrequired", fmt.Sprintf("%.6f", r.RhoRequired)},
+		[]string{"As,min", fmtArea(r.AsMin, 2)},
+		[]string{"As,max", fmtArea(r.AsMax, 2)},
+	)
+	if r.AsAlternative > 0 {
+		doc.Paragraph(fmt.Sprintf("As by analysis is %s; 4/3·As = %s may be provided in lieu of As,min.",
+			fmtArea(r.AsStrength, 2), fmtArea(r.AsAlternative, 2)))
+	}
+
+	doc.Section("Code Checks")
+	if r.AsRequired > 0 {
+		rho := r.AsRequired / (b.Width * b.EffectiveDepth)
+		reportChecks(doc, singlyChecks(b.Fc, b.Fy, rho, r.RhoMin, r.RhoMax, r.EpsilonT, r.PhiMn, designMu)...)
+	}
+	doc.Paragraph(r.Message)
+	doc.Steps("Calculation steps (N, mm, MPa)", designTrace(b, designMu, r).steps)
+
+	if !r.IsAdequate {
+		doc.Section("Design Result")
+		doc.Paragraph("**Design not adequate.**")
+		reportLayouts(doc, "Design Alternatives", designAlternatives(b, r), true)
+		return doc
+	}
+
+	doc.Section("Section Analysis")
+	doc.Fields(
+		[]string{"Compression block depth (a)", fmtLength(r.A, 2)},
+		[]string{"Neutral axis depth (c)", fmtLength(r.C, 2)},
+		[]string{"Tensile strain (εt)", fmt.Sprintf("%.6f", r.EpsilonT)},
+		[]string{"Strength reduction factor (φ)", fmt.Sprintf("%.2f", r.Phi)},
+	)
+
+	doc.Section("Design Result")
+	doc.Paragraph(fmt.Sprintf("**Required As = %s**, φMn = %s ≥ Mu = %s.",
+		fmtArea(r.AsRequired, 2), fmtMoment(r.PhiMn, 2), fmtMoment(designMu, 2)))
+	reportLayouts(doc, "Design Alternatives", designAlternatives(b, r), true)
+	reportDiagram(doc, detailBars(singlyDiagramData(b, r.AsRequired, r.A, r.C, r.EpsilonT), designCoverCheck), designExportFile, "")
+	return doc
+}
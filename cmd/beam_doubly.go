@@ -15,7 +15,13 @@ Use when the required moment exceeds the capacity of a singly reinforced section
 
 Subcommands:
   design   - Calculate required tension and compression reinforcement
-  analyze  - Calculate moment capacity for given reinforcement`,
+  analyze  - Calculate moment capacity for given reinforcement
+
+The neutral axis solver iterates to force equilibrium; --max-iter and
+--tolerance (the force imbalance accepted, in kN) tighten or relax it, e.g.
+to converge a heavily reinforced section (exit code 4 when it does not), as
+for the sections of 'gorcb section'. The residual T − ΣC and the iterations
+taken are printed with the internal forces.`,
 }
 
 func init() {
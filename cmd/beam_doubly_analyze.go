@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +18,20 @@ var (
 	doublyAnalyzeCoverComp float64
 	doublyAnalyzeFc        float64
 	doublyAnalyzeFy        float64
+	doublyAnalyzeGrade     gradeInput
 	doublyAnalyzeAs        float64
 	doublyAnalyzeAsc       float64
+
+	doublyAnalyzeConcreteType string
+
+	// Serviceability inputs
+	doublyAnalyzeService serviceInputs
+
+	// Exposure cover check
+	doublyAnalyzeCoverCheck coverInputs
+
+	// Ductility and over-strength metrics
+	doublyAnalyzeDuctility bool
 )
 
 var beamDoublyAnalyzeCmd = &cobra.Command{
@@ -34,12 +47,18 @@ The analysis follows NSCP 2015 provisions and accounts for:
 
 Examples:
   # Analyze a 300x500mm beam with As=1500 mm² and A'sc=600 mm²
-  gorcb beam doubly analyze -b 300 --height 500 -c 65 -d 65 --fc 28 --fy 415 --as 1500 --asc 600`,
+  gorcb beam doubly analyze -b 300 --height 500 -c 65 -d 65 --fc 28 --fy 415 --as 1500 --asc 600
+
+  # Include service-level checks (cracked section, service stresses, crack control)
+  gorcb beam doubly analyze -b 300 --height 500 --as 1500 --asc 600 --service-dead 80 --service-live 50`,
 	Run: runDoublyAnalyze,
 }
 
 func init() {
 	beamDoublyCmd.AddCommand(beamDoublyAnalyzeCmd)
+	unitAware(beamDoublyAnalyzeCmd)
+	quiet(beamDoublyAnalyzeCmd)
+	reportable(beamDoublyAnalyzeCmd)
 
 	// Geometry flags
 	beamDoublyAnalyzeCmd.Flags().Float64VarP(&doublyAnalyzeWidth, "width", "b", 0, "Beam width (mm) [required]")
@@ -50,6 +69,8 @@ func init() {
 	// Material flags
 	beamDoublyAnalyzeCmd.Flags().Float64Var(&doublyAnalyzeFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
 	beamDoublyAnalyzeCmd.Flags().Float64Var(&doublyAnalyzeFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(beamDoublyAnalyzeCmd, &doublyAnalyzeGrade)
+	beamDoublyAnalyzeCmd.Flags().StringVar(&doublyAnalyzeConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
 
 	// Reinforcement flags
 	beamDoublyAnalyzeCmd.Flags().Float64Var(&doublyAnalyzeAs, "as", 0, "Tension reinforcement area As (mm²) [required]")
@@ -60,9 +81,26 @@ func init() {
 	beamDoublyAnalyzeCmd.MarkFlagRequired("height")
 	beamDoublyAnalyzeCmd.MarkFlagRequired("as")
 	beamDoublyAnalyzeCmd.MarkFlagRequired("asc")
+
+	// Cover check flags
+	addCoverFlags(beamDoublyAnalyzeCmd, &doublyAnalyzeCoverCheck)
+
+	// Serviceability flags
+	addServiceFlags(beamDoublyAnalyzeCmd, &doublyAnalyzeService)
+
+	beamDoublyAnalyzeCmd.Flags().BoolVar(&doublyAnalyzeDuctility, "ductility", false, "Show c/d, curvature ductility and probable moment Mpr")
 }
 
 func runDoublyAnalyze(cmd *cobra.Command, args []string) {
+	if err := doublyAnalyzeGrade.resolve(&doublyAnalyzeFy); err != nil {
+		printError(err)
+		return
+	}
+	if err := doublyAnalyzeCoverCheck.validate(); err != nil {
+		printError(err)
+		return
+	}
+
 	// Create beam
 	b := beam.NewDoublyReinforced(
 		doublyAnalyzeWidth,
@@ -72,41 +110,98 @@ func runDoublyAnalyze(cmd *cobra.Command, args []string) {
 		doublyAnalyzeFc,
 		doublyAnalyzeFy,
 	)
+	b.Code = selectedCode
+
+	lambda, err := concreteLambda(doublyAnalyzeConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+	b.Lambda = lambda
+	b.Density = concreteDensity(doublyAnalyzeConcreteType)
 
 	// Run analysis
 	result, err := b.Analyze(doublyAnalyzeAs, doublyAnalyzeAsc)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		printError(err)
+		return
+	}
+
+	// Ductility and over-strength metrics
+	var ductility *nscp.DuctilityResult
+	if doublyAnalyzeDuctility {
+		ductility, err = b.Ductility(doublyAnalyzeAs, doublyAnalyzeAsc)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Serviceability checks under the unfactored service combinations
+	var sm nscp.ServiceMoments
+	var service *beam.ServiceResult
+	if doublyAnalyzeService.requested() {
+		sm, err = doublyAnalyzeService.serviceMoments()
+		if err != nil {
+			printError(err)
+			return
+		}
+		opts, err := doublyAnalyzeService.options()
+		if err != nil {
+			printError(err)
+			return
+		}
+		service, err = b.ServiceCheck(sm, opts)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	checkAdequacy(result.MeetsMinReinf)
+	if reportFile != "" {
+		defer writeReport(doublyAnalysisReport(cmd, b, result))
+	}
+	runPlugins(cmd, b, analysisReport{Analysis: result, Ductility: ductility, Service: service})
+	defer printPluginResults()
+	printQuiet(quietResult{As: b.As, Asc: b.Asc, PhiMn: result.PhiMn, Status: quietStatus(result.MeetsMinReinf)})
+	if quietOutput {
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, b, analysisReport{Analysis: result, Ductility: ductility, Service: service})
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     DOUBLY REINFORCED BEAM ANALYSIS - NSCP 2015")
+	fmt.Printf("     DOUBLY REINFORCED BEAM ANALYSIS - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Input summary
 	fmt.Println("INPUT DATA:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Beam Width (b):\t%.0f mm\n", b.Width)
-	fmt.Fprintf(w, "  Beam Depth (h):\t%.0f mm\n", b.Height)
-	fmt.Fprintf(w, "  Effective Depth (d):\t%.0f mm\n", b.EffectiveDepth)
-	fmt.Fprintf(w, "  Tension Cover:\t%.0f mm\n", b.Cover)
-	fmt.Fprintf(w, "  Compression Cover (d'):\t%.0f mm\n", b.CoverComp)
-	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", b.Fc)
-	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", b.Fy)
-	fmt.Fprintf(w, "  Tension Steel (As):\t%.2f mm²\n", doublyAnalyzeAs)
-	fmt.Fprintf(w, "  Compression Steel (A'sc):\t%.2f mm²\n", doublyAnalyzeAsc)
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Beam Width (b):\t%s\n", fmtLength(b.Width, 0))
+	fmt.Fprintf(w, "  Beam Depth (h):\t%s\n", fmtLength(b.Height, 0))
+	fmt.Fprintf(w, "  Effective Depth (d):\t%s\n", fmtLength(b.EffectiveDepth, 0))
+	fmt.Fprintf(w, "  Tension Cover:\t%s\n", fmtLength(b.Cover, 0))
+	fmt.Fprintf(w, "  Compression Cover (d'):\t%s\n", fmtLength(b.CoverComp, 0))
+	fmt.Fprintf(w, "  f'c:\t%s\n", fmtStress(b.Fc, 1))
+	fmt.Fprintf(w, "  fy:\t%s\n", fmtStress(b.Fy, 1))
+	printGrade(w, doublyAnalyzeGrade)
+	fmt.Fprintf(w, "  λ (concrete type):\t%.2f\n", result.Lambda)
+	fmt.Fprintf(w, "  Tension Steel (As):\t%s\n", fmtArea(doublyAnalyzeAs, 2))
+	fmt.Fprintf(w, "  Compression Steel (A'sc):\t%s\n", fmtArea(doublyAnalyzeAsc, 2))
 	w.Flush()
 	fmt.Println()
 
 	// Reinforcement ratios
 	fmt.Println("REINFORCEMENT RATIOS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  ρ_min:\t%.6f\n", result.RhoMin)
 	fmt.Fprintf(w, "  ρ_max (tension-controlled):\t%.6f\n", result.RhoMax)
 	fmt.Fprintf(w, "  ρ_bal:\t%.6f\n", result.RhoBalanced)
@@ -124,10 +219,10 @@ func runDoublyAnalyze(cmd *cobra.Command, args []string) {
 	// Section properties
 	fmt.Println("SECTION PROPERTIES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  β₁:\t%.4f\n", result.Beta1)
-	fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", result.C)
-	fmt.Fprintf(w, "  Compression block depth (a):\t%.2f mm\n", result.A)
+	fmt.Fprintf(w, "  Neutral axis depth (c):\t%s\n", fmtLength(result.C, 2))
+	fmt.Fprintf(w, "  Compression block depth (a):\t%s\n", fmtLength(result.A, 2))
 	fmt.Fprintf(w, "  c/d ratio:\t%.4f\n", result.C/b.EffectiveDepth)
 	w.Flush()
 	fmt.Println()
@@ -135,9 +230,9 @@ func runDoublyAnalyze(cmd *cobra.Command, args []string) {
 	// Strain analysis
 	fmt.Println("STRAIN ANALYSIS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  εcu (concrete):\t0.003000\n")
-	fmt.Fprintf(w, "  εy (steel yield):\t%.6f\n", doublyAnalyzeFy/200000)
+	fmt.Fprintf(w, "  εy (steel yield):\t%.6f\n", selectedCode.DesignYieldStrength(doublyAnalyzeFy)/200000)
 	fmt.Fprintf(w, "  εt (tension steel):\t%.6f", result.EpsilonT)
 	if result.TensionYielded {
 		fmt.Fprintf(w, " → YIELDS")
@@ -154,56 +249,69 @@ func runDoublyAnalyze(cmd *cobra.Command, args []string) {
 	// Steel stresses
 	fmt.Println("STEEL STRESSES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  fs (tension):\t%.2f MPa\n", result.FsStress)
-	fmt.Fprintf(w, "  f'sc (compression):\t%.2f MPa\n", result.FscStress)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  fs (tension):\t%s\n", fmtStress(result.FsStress, 2))
+	fmt.Fprintf(w, "  f'sc (compression):\t%s\n", fmtStress(result.FscStress, 2))
 	w.Flush()
 	fmt.Println()
 
 	// Internal forces
 	fmt.Println("INTERNAL FORCES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Cc (concrete compression):\t%.2f kN\n", result.Cc)
-	fmt.Fprintf(w, "  Cs (compression steel):\t%.2f kN\n", result.Cs)
-	fmt.Fprintf(w, "  T (tension steel):\t%.2f kN\n", result.T)
-	fmt.Fprintf(w, "  ΣC = Cc + Cs:\t%.2f kN\n", result.Cc+result.Cs)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Cc (concrete compression):\t%s\n", fmtForce(result.Cc, 2))
+	fmt.Fprintf(w, "  Cs (compression steel):\t%s\n", fmtForce(result.Cs, 2))
+	fmt.Fprintf(w, "  T (tension steel):\t%s\n", fmtForce(result.T, 2))
+	fmt.Fprintf(w, "  ΣC = Cc + Cs:\t%s\n", fmtForce(result.Cc+result.Cs, 2))
 	equilibrium := "✓"
 	if abs(result.T-(result.Cc+result.Cs)) > 1 {
 		equilibrium = "⚠"
 	}
 	fmt.Fprintf(w, "  Force equilibrium:\t%s\n", equilibrium)
+	fmt.Fprintf(w, "  Residual T − ΣC:\t%s\n", residualLabel(result.Residual, result.Iterations))
 	w.Flush()
 	fmt.Println()
 
 	// Moment capacity
 	fmt.Println("MOMENT CAPACITY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Nominal Moment (Mn):\t%.2f kN-m\n", result.Mn)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Nominal Moment (Mn):\t%s\n", fmtMoment(result.Mn, 2))
+	fmt.Fprintf(w, "  Modulus of Rupture (fr):\t%s\n", fmtStress(result.Fr, 2))
+	fmt.Fprintf(w, "  Cracking Moment (Mcr):\t%s\n", fmtMoment(result.Mcr, 2))
 	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
 	w.Flush()
 	fmt.Println()
 
 	fmt.Printf("  ╔═════════════════════════════════════════════════╗\n")
-	fmt.Printf("  ║  DESIGN CAPACITY φMn = %.2f kN-m            \n", result.PhiMn)
+	fmt.Printf("  ║  DESIGN CAPACITY φMn = %s            \n", fmtMoment(result.PhiMn, 2))
 	fmt.Printf("  ╚═════════════════════════════════════════════════╝\n")
 	fmt.Println()
 
 	// Status
 	fmt.Println("STATUS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	controlStatus := "Tension-controlled (φ = 0.90)"
+	controlStatus := fmt.Sprintf("Tension-controlled (φ = %.2f)", result.Phi)
 	if !result.IsTensionControlled {
-		if result.EpsilonT >= doublyAnalyzeFy/200000 {
+		if result.EpsilonT >= selectedCode.DesignYieldStrength(doublyAnalyzeFy)/200000 {
 			controlStatus = fmt.Sprintf("Transition zone (φ = %.2f)", result.Phi)
 		} else {
-			controlStatus = "Compression-controlled (φ = 0.65)"
+			controlStatus = fmt.Sprintf("Compression-controlled (φ = %.2f)", result.Phi)
 		}
 	}
 	fmt.Printf("  Section: %s\n", controlStatus)
-	fmt.Printf("  %s\n", result.Message)
+	fmt.Printf("  %s\n", i18n.T(result.Message))
 	fmt.Println()
+
+	if ductility != nil {
+		printDuctility(ductility)
+	}
+
+	printCoverCheck(doublyAnalyzeCoverCheck, coverFace{"Bottom", doublyAnalyzeCover}, coverFace{"Top", doublyAnalyzeCoverComp})
+
+	if service != nil {
+		printServiceCheck(sm, service)
+	}
 }
 
 func abs(x float64) float64 {
@@ -213,3 +321,48 @@ func abs(x float64) float64 {
 	return x
 }
 
+// doublyAnalysisReport returns the analysis report of a doubly reinforced beam
+func doublyAnalysisReport(cmd *cobra.Command, b *beam.DoublyReinforced, r *beam.DoublyAnalysisResult) *report.Document {
+	doc := newReport(cmd, "Doubly Reinforced Beam Analysis")
+	doc.Conclude(r.MeetsMinReinf, r.Message)
+
+	doc.Section("Input Data")
+	doc.Fields(
+		[]string{"Beam width (b)", fmtLength(b.Width, 0)},
+		[]string{"Beam depth (h)", fmtLength(b.Height, 0)},
+		[]string{"Effective depth (d)", fmtLength(b.EffectiveDepth, 0)},
+		[]string{"Tension cover", fmtLength(b.Cover, 0)},
+		[]string{"Compression cover (d')", fmtLength(b.CoverComp, 0)},
+		[]string{"f'c", fmtStress(b.Fc, 1)},
+		[]string{"fy", fmtStress(b.Fy, 1)},
+		reportGrade(doublyAnalyzeGrade),
+		[]string{"λ (concrete type)", fmt.Sprintf("%.2f", r.Lambda)},
+		[]string{"Tension steel (As)", fmtArea(b.As, 2)},
+		[]string{"Compression steel (A'sc)", fmtArea(b.Asc, 2)},
+	)
+
+	doc.Section("Code Checks")
+	reportChecks(doc, minSteelCheck(r.Rho, r.RhoMin), tensionControlledCheck(b.Fc, b.Fy, r.EpsilonT))
+	doc.Paragraph(r.Message)
+
+	doc.Section("Section Analysis")
+	doc.Fields(
+		[]string{"ρ' (A'sc/bd)", fmt.Sprintf("%.6f", r.RhoComp)},
+		[]string{"Compression block depth (a)", fmtLength(r.A, 2)},
+		[]string{"Neutral axis depth (c)", fmtLength(r.C, 2)},
+		[]string{"εt (tension steel)", fmt.Sprintf("%.6f", r.EpsilonT)},
+		[]string{"ε'sc (compression steel)", fmt.Sprintf("%.6f", r.EpsilonSc)},
+		[]string{"fs (tension)", fmtStress(r.FsStress, 2)},
+		[]string{"f'sc (compression)", fmtStress(r.FscStress, 2)},
+		[]string{"Cc (concrete compression)", fmtForce(r.Cc, 2)},
+		[]string{"Cs (compression steel)", fmtForce(r.Cs, 2)},
+		[]string{"T (tension steel)", fmtForce(r.T, 2)},
+		[]string{"Residual T − ΣC", residualLabel(r.Residual, r.Iterations)},
+		[]string{"Strength reduction factor (φ)", fmt.Sprintf("%.2f", r.Phi)},
+		[]string{"Cracking moment (Mcr)", fmtMoment(r.Mcr, 2)},
+	)
+
+	doc.Section("Moment Capacity")
+	doc.Paragraph(fmt.Sprintf("Mn = %s, **φMn = %s**.", fmtMoment(r.Mn, 2), fmtMoment(r.PhiMn, 2)))
+	return doc
+}
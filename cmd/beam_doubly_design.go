@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/check"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +19,17 @@ var (
 	doublyDesignCoverComp float64
 	doublyDesignFc        float64
 	doublyDesignFy        float64
+	doublyDesignGrade     gradeInput
 	doublyDesignMu        float64
+
+	// Concrete type for λ
+	doublyDesignConcreteType string
+
+	// Exposure cover check
+	doublyDesignCoverCheck coverInputs
+
+	// Lap splices
+	doublyDesignSplices spliceInputs
 )
 
 var beamDoublyDesignCmd = &cobra.Command{
@@ -29,6 +41,12 @@ for a doubly reinforced rectangular beam given the factored moment (Mu).
 The design follows NSCP 2015 provisions. If the moment can be resisted by
 a singly reinforced section, no compression steel will be required.
 
+The required steel is then laid out with each flexural bar size in up to two
+layers inside the stirrups (--stirrup-dia) at the minimum clear spacing, the
+clear cover taken as the cover less the stirrup and half the bar (--bar-dia).
+φMn of each layout is verified again with the d and d' of its bars, adding
+tension and compression bars while it falls short of Mu.
+
 Examples:
   # Design a 300x500mm beam with Mu=250 kN-m
   gorcb beam doubly design -b 300 --height 500 -c 65 --cover-comp 65 --fc 28 --fy 415 -m 250
@@ -40,6 +58,9 @@ Examples:
 
 func init() {
 	beamDoublyCmd.AddCommand(beamDoublyDesignCmd)
+	unitAware(beamDoublyDesignCmd)
+	quiet(beamDoublyDesignCmd)
+	reportable(beamDoublyDesignCmd)
 
 	// Geometry flags
 	beamDoublyDesignCmd.Flags().Float64VarP(&doublyDesignWidth, "width", "b", 0, "Beam width (mm) [required]")
@@ -50,6 +71,8 @@ func init() {
 	// Material flags
 	beamDoublyDesignCmd.Flags().Float64Var(&doublyDesignFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
 	beamDoublyDesignCmd.Flags().Float64Var(&doublyDesignFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(beamDoublyDesignCmd, &doublyDesignGrade)
+	beamDoublyDesignCmd.Flags().StringVar(&doublyDesignConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
 
 	// Loading flag
 	beamDoublyDesignCmd.Flags().Float64VarP(&doublyDesignMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
@@ -58,9 +81,26 @@ func init() {
 	beamDoublyDesignCmd.MarkFlagRequired("width")
 	beamDoublyDesignCmd.MarkFlagRequired("height")
 	beamDoublyDesignCmd.MarkFlagRequired("mu")
+
+	// Cover check flags
+	addCoverFlags(beamDoublyDesignCmd, &doublyDesignCoverCheck)
+	addSpliceFlags(beamDoublyDesignCmd, &doublyDesignSplices)
 }
 
 func runDoublyDesign(cmd *cobra.Command, args []string) {
+	if err := doublyDesignGrade.resolve(&doublyDesignFy); err != nil {
+		printError(err)
+		return
+	}
+	if err := doublyDesignCoverCheck.validate(); err != nil {
+		printError(err)
+		return
+	}
+	if err := doublyDesignSplices.validate(); err != nil {
+		printError(err)
+		return
+	}
+
 	// Create beam
 	b := beam.NewDoublyReinforced(
 		doublyDesignWidth,
@@ -70,62 +110,90 @@ func runDoublyDesign(cmd *cobra.Command, args []string) {
 		doublyDesignFc,
 		doublyDesignFy,
 	)
+	b.Code = selectedCode
+
+	lambda, err := concreteLambda(doublyDesignConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+	b.Lambda, doublyDesignSplices.Lambda = lambda, lambda
+	b.Density = concreteDensity(doublyDesignConcreteType)
 
 	// Run design
 	result, err := b.Design(doublyDesignMu)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		printError(err)
+		return
+	}
+	checkAdequacy(result.IsAdequate)
+	if reportFile != "" {
+		defer writeReport(doublyDesignReport(cmd, b, result))
+	}
+	runPlugins(cmd, b, result)
+	defer printPluginResults()
+	printQuiet(quietResult{As: result.AsTotal, Asc: result.AscRequired, PhiMn: result.PhiMn, Status: quietStatus(result.IsAdequate)})
+	if quietOutput {
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, b, result)
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     DOUBLY REINFORCED BEAM DESIGN - NSCP 2015")
+	fmt.Printf("     DOUBLY REINFORCED BEAM DESIGN - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Input summary
 	fmt.Println("INPUT DATA:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "  Beam Width (b):\t%.0f mm\n", b.Width)
-	fmt.Fprintf(w, "  Beam Depth (h):\t%.0f mm\n", b.Height)
-	fmt.Fprintf(w, "  Effective Depth (d):\t%.0f mm\n", b.EffectiveDepth)
-	fmt.Fprintf(w, "  Tension Cover:\t%.0f mm\n", b.Cover)
-	fmt.Fprintf(w, "  Compression Cover (d'):\t%.0f mm\n", b.CoverComp)
-	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", b.Fc)
-	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", b.Fy)
-	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", doublyDesignMu)
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Beam Width (b):\t%s\n", fmtLength(b.Width, 0))
+	fmt.Fprintf(w, "  Beam Depth (h):\t%s\n", fmtLength(b.Height, 0))
+	fmt.Fprintf(w, "  Effective Depth (d):\t%s\n", fmtLength(b.EffectiveDepth, 0))
+	fmt.Fprintf(w, "  Tension Cover:\t%s\n", fmtLength(b.Cover, 0))
+	fmt.Fprintf(w, "  Compression Cover (d'):\t%s\n", fmtLength(b.CoverComp, 0))
+	fmt.Fprintf(w, "  f'c:\t%s\n", fmtStress(b.Fc, 1))
+	fmt.Fprintf(w, "  fy:\t%s\n", fmtStress(b.Fy, 1))
+	printGrade(w, doublyDesignGrade)
+	if lambda < 1 {
+		fmt.Fprintf(w, "  λ (concrete type):\t%.2f\n", lambda)
+	}
+	fmt.Fprintf(w, "  Factored Moment (Mu):\t%s\n", fmtMoment(doublyDesignMu, 2))
 	w.Flush()
 	fmt.Println()
 
 	// Reinforcement ratios
 	fmt.Println("REINFORCEMENT LIMITS (Singly Reinforced):")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  ρ_min:\t%.6f\n", result.RhoMin)
 	fmt.Fprintf(w, "  ρ_max (tension-controlled):\t%.6f\n", result.RhoMax)
 	fmt.Fprintf(w, "  ρ_bal:\t%.6f\n", result.RhoBalanced)
-	fmt.Fprintf(w, "  As,min:\t%.2f mm²\n", result.AsMin)
-	fmt.Fprintf(w, "  As,max (singly):\t%.2f mm²\n", result.AsMax)
+	fmt.Fprintf(w, "  As,min:\t%s\n", fmtArea(result.AsMin, 2))
+	fmt.Fprintf(w, "  As,max (singly):\t%s\n", fmtArea(result.AsMax, 2))
+	printMinSteelAlternative(w, result.AsStrength, result.AsAlternative)
 	w.Flush()
 	fmt.Println()
 
 	// Design type determination
 	fmt.Println("DESIGN DETERMINATION:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	Mu1Max := result.Mu1
 	if result.RequiresCompSteel {
 		// Mu1Max is already set correctly
 	} else {
 		// For singly reinforced, calculate what Mu1Max would be
-		phi := 0.90
-		Mu1Max = phi * 0.85 * doublyDesignFc * doublyDesignWidth * result.AMax * (b.EffectiveDepth - result.AMax/2) / 1e6
+		phi := selectedCode.PhiFlexure()
+		Mu1Max = phi * selectedCode.Alpha1(doublyDesignFc) * doublyDesignFc * doublyDesignWidth * result.AMax * (b.EffectiveDepth - result.AMax/2) / 1e6
 	}
-	fmt.Fprintf(w, "  Max φMn (singly reinforced):\t%.2f kN-m\n", Mu1Max)
-	fmt.Fprintf(w, "  Required Mu:\t%.2f kN-m\n", doublyDesignMu)
+	fmt.Fprintf(w, "  Max φMn (singly reinforced):\t%s\n", fmtMoment(Mu1Max, 2))
+	fmt.Fprintf(w, "  Required Mu:\t%s\n", fmtMoment(doublyDesignMu, 2))
 	if result.RequiresCompSteel {
 		fmt.Fprintf(w, "  Design Type:\tDOUBLY REINFORCED REQUIRED\n")
 	} else {
@@ -138,33 +206,33 @@ func runDoublyDesign(cmd *cobra.Command, args []string) {
 		// Doubly reinforced details
 		fmt.Println("MOMENT DISTRIBUTION:")
 		fmt.Println("───────────────────────────────────────────────────────────────")
-		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "  Mu1 (concrete couple):\t%.2f kN-m\n", result.Mu1)
-		fmt.Fprintf(w, "  Mu2 (steel couple):\t%.2f kN-m\n", result.Mu2)
-		fmt.Fprintf(w, "  Total Mu:\t%.2f kN-m\n", result.Mu1+result.Mu2)
+		w = newTextWriter()
+		fmt.Fprintf(w, "  Mu1 (concrete couple):\t%s\n", fmtMoment(result.Mu1, 2))
+		fmt.Fprintf(w, "  Mu2 (steel couple):\t%s\n", fmtMoment(result.Mu2, 2))
+		fmt.Fprintf(w, "  Total Mu:\t%s\n", fmtMoment(result.Mu1+result.Mu2, 2))
 		w.Flush()
 		fmt.Println()
 
 		fmt.Println("COMPRESSION STEEL CHECK:")
 		fmt.Println("───────────────────────────────────────────────────────────────")
-		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "  c (at ρmax):\t%.2f mm\n", result.CMax)
-		fmt.Fprintf(w, "  d':\t%.2f mm\n", b.CoverComp)
+		w = newTextWriter()
+		fmt.Fprintf(w, "  c (at ρmax):\t%s\n", fmtLength(result.CMax, 2))
+		fmt.Fprintf(w, "  d':\t%s\n", fmtLength(b.CoverComp, 2))
 		fmt.Fprintf(w, "  ε'sc:\t%.6f\n", result.EpsilonSc)
-		fmt.Fprintf(w, "  εy:\t%.6f\n", doublyDesignFy/200000)
+		fmt.Fprintf(w, "  εy:\t%.6f\n", selectedCode.DesignYieldStrength(doublyDesignFy)/200000)
 		if result.CompYielded {
-			fmt.Fprintf(w, "  Compression steel:\tYIELDS (f'sc = fy = %.1f MPa)\n", doublyDesignFy)
+			fmt.Fprintf(w, "  Compression steel:\tYIELDS (f'sc = fy = %s)\n", fmtStress(doublyDesignFy, 1))
 		} else {
-			fmt.Fprintf(w, "  Compression steel:\tDOES NOT YIELD (f'sc = %.1f MPa)\n", result.FscStress)
+			fmt.Fprintf(w, "  Compression steel:\tDOES NOT YIELD (f'sc = %s)\n", fmtStress(result.FscStress, 1))
 		}
 		w.Flush()
 		fmt.Println()
 
 		fmt.Println("TENSION STEEL CALCULATION:")
 		fmt.Println("───────────────────────────────────────────────────────────────")
-		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "  As1 (for Mu1):\t%.2f mm²\n", result.As1)
-		fmt.Fprintf(w, "  As2 (for Mu2):\t%.2f mm²\n", result.As2)
+		w = newTextWriter()
+		fmt.Fprintf(w, "  As1 (for Mu1):\t%s\n", fmtArea(result.As1, 2))
+		fmt.Fprintf(w, "  As2 (for Mu2):\t%s\n", fmtArea(result.As2, 2))
 		w.Flush()
 		fmt.Println()
 	}
@@ -172,7 +240,7 @@ func runDoublyDesign(cmd *cobra.Command, args []string) {
 	// Section analysis
 	fmt.Println("SECTION STATUS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Tensile strain (εt):\t%.6f\n", result.EpsilonT)
 	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
 	controlStatus := "Tension-controlled"
@@ -189,69 +257,135 @@ func runDoublyDesign(cmd *cobra.Command, args []string) {
 
 	if result.IsAdequate {
 		fmt.Printf("  ╔═════════════════════════════════════════════════╗\n")
-		fmt.Printf("  ║  TENSION STEEL     As  = %.2f mm²           \n", result.AsTotal)
+		fmt.Printf("  ║  TENSION STEEL     As  = %s           \n", fmtArea(result.AsTotal, 2))
 		if result.RequiresCompSteel {
-			fmt.Printf("  ║  COMPRESSION STEEL A'sc = %.2f mm²           \n", result.AscRequired)
+			fmt.Printf("  ║  COMPRESSION STEEL A'sc = %s           \n", fmtArea(result.AscRequired, 2))
 		}
 		fmt.Printf("  ╚═════════════════════════════════════════════════╝\n")
 		fmt.Println()
-		fmt.Printf("  φMn = %.2f kN-m ≥ Mu = %.2f kN-m ✓\n", result.PhiMn, doublyDesignMu)
+		fmt.Printf("  φMn = %s ≥ Mu = %s ✓\n", fmtMoment(result.PhiMn, 2), fmtMoment(doublyDesignMu, 2))
 		fmt.Println()
-		fmt.Printf("  Status: %s\n", result.Message)
+		fmt.Printf("  Status: %s\n", i18n.T(result.Message))
 	} else {
 		fmt.Println("  ╔═════════════════════════════════════════════════╗")
 		fmt.Println("  ║  DESIGN NOT ADEQUATE                            ║")
 		fmt.Println("  ╚═════════════════════════════════════════════════╝")
 		fmt.Println()
-		fmt.Printf("  %s\n", result.Message)
+		fmt.Printf("  %s\n", i18n.T(result.Message))
 	}
 	fmt.Println()
 
-	// Suggested bar combinations
+	printCoverCheck(doublyDesignCoverCheck, coverFace{"Bottom", doublyDesignCover}, coverFace{"Top", doublyDesignCoverComp})
+
+	// Bar layouts of the required steel
 	if result.IsAdequate {
-		fmt.Println("SUGGESTED BAR COMBINATIONS:")
+		printBarLayouts("BAR LAYOUTS", doublyLayouts(b, result), false, doublyDesignCoverCheck, doublyDesignCover, b.EffectiveDepth)
+	}
+
+	// Lap splices of the suggested bars (compression bars are top bars)
+	if doublyDesignSplices.Show && result.IsAdequate {
+		fmt.Printf("LAP SPLICES (%s, %.0f%% of bars spliced):\n", selectedCode.Name(), doublyDesignSplices.FractionSpliced*100)
 		fmt.Println("───────────────────────────────────────────────────────────────")
 		fmt.Println("  Tension Steel:")
-		printBarSuggestionsFor(result.AsTotal, "    ")
+		printLapSplicesFor(doublyDesignSplices, result.AsTotal, doublyDesignFc, doublyDesignFy, false, "    ")
 		if result.RequiresCompSteel && result.AscRequired > 0 {
 			fmt.Println()
 			fmt.Println("  Compression Steel:")
-			printBarSuggestionsFor(result.AscRequired, "    ")
+			printLapSplicesFor(doublyDesignSplices, result.AscRequired, doublyDesignFc, doublyDesignFy, true, "    ")
 		}
 	}
 }
 
-func printBarSuggestionsFor(asRequired float64, indent string) {
-	suggestions := []struct {
-		dia   int
-		count int
-		area  float64
-	}{}
-
-	// Find suitable combinations
-	for _, dia := range []int{16, 20, 25, 28, 32} {
-		area := rebarAreas[dia]
-		count := int(asRequired/area) + 1
-		if count >= 2 && count <= 8 {
-			totalArea := float64(count) * area
-			if totalArea >= asRequired {
-				suggestions = append(suggestions, struct {
-					dia   int
-					count int
-					area  float64
-				}{dia, count, totalArea})
-			}
-		}
+// doublyLayouts lays out the required tension and compression steel of a
+// doubly reinforced design
+func doublyLayouts(b *beam.DoublyReinforced, r *beam.DoublyDesignResult) []check.Layout {
+	asc := 0.0
+	if r.RequiresCompSteel {
+		asc = r.AscRequired
 	}
+	return barLayouts(doublyDesignCoverCheck, b.Width, b.Height, b.Cover, b.Fc, b.Fy, r.AsTotal, asc, doublyDesignMu)
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "%sBars\tAs Provided\tRatio\n", indent)
-	fmt.Fprintf(w, "%s────\t───────────\t─────\n", indent)
+func printBarSuggestionsFor(asRequired float64, indent string) {
+	suggestions := selectedCatalog.Suggest(asRequired, suggestMinBars, suggestMaxBars)
+
+	w := newTextWriter()
+	fmt.Fprintf(w, "%sBars\tAs Provided\tRatio\tMass\n", indent)
+	fmt.Fprintf(w, "%s────\t───────────\t─────\t────\n", indent)
 
 	for _, s := range suggestions {
-		ratio := s.area / asRequired
-		fmt.Fprintf(w, "%s%d - φ%dmm\t%.2f mm²\t%.2f\n", indent, s.count, s.dia, s.area, ratio)
+		ratio := s.Area / asRequired
+		fmt.Fprintf(w, "%s%d - %s\t%s\t%.2f\t%.2f kg/m\n", indent, s.Count, s.Bar.Label(), fmtArea(s.Area, 2), ratio, s.Mass)
 	}
 	w.Flush()
 }
 
+// doublyDesignReport returns the design report of a doubly reinforced beam
+func doublyDesignReport(cmd *cobra.Command, b *beam.DoublyReinforced, r *beam.DoublyDesignResult) *report.Document {
+	doc := newReport(cmd, "Doubly Reinforced Beam Design")
+	doc.Conclude(r.IsAdequate, r.Message)
+
+	doc.Section("Input Data")
+	doc.Fields(
+		[]string{"Beam width (b)", fmtLength(b.Width, 0)},
+		[]string{"Beam depth (h)", fmtLength(b.Height, 0)},
+		[]string{"Effective depth (d)", fmtLength(b.EffectiveDepth, 0)},
+		[]string{"Tension cover", fmtLength(b.Cover, 0)},
+		[]string{"Compression cover (d')", fmtLength(b.CoverComp, 0)},
+		[]string{"f'c", fmtStress(b.Fc, 1)},
+		[]string{"fy", fmtStress(b.Fy, 1)},
+		reportGrade(doublyDesignGrade),
+		[]string{"Factored moment (Mu)", fmtMoment(doublyDesignMu, 2)},
+	)
+
+	doc.Section("Reinforcement Limits (Singly Reinforced)")
+	doc.Fields(
+		[]string{"ρmin", fmt.Sprintf("%.6f", r.RhoMin)},
+		[]string{"ρmax (tension-controlled)", fmt.Sprintf("%.6f", r.RhoMax)},
+		[]string{"ρbal", fmt.Sprintf("%.6f", r.RhoBalanced)},
+		[]string{"As,min", fmtArea(r.AsMin, 2)},
+		[]string{"As,max (singly)", fmtArea(r.AsMax, 2)},
+	)
+
+	if r.RequiresCompSteel {
+		doc.Section("Doubly Reinforced Design")
+		yield := fmt.Sprintf("yields (f'sc = fy = %s)", fmtStress(b.Fy, 1))
+		if !r.CompYielded {
+			yield = fmt.Sprintf("does not yield (f'sc = %s)", fmtStress(r.FscStress, 1))
+		}
+		doc.Fields(
+			[]string{"Mu1 (concrete couple)", fmtMoment(r.Mu1, 2)},
+			[]string{"Mu2 (steel couple)", fmtMoment(r.Mu2, 2)},
+			[]string{"c (at ρmax)", fmtLength(r.CMax, 2)},
+			[]string{"ε'sc", fmt.Sprintf("%.6f", r.EpsilonSc)},
+			[]string{"Compression steel", yield},
+			[]string{"As1 (for Mu1)", fmtArea(r.As1, 2)},
+			[]string{"As2 (for Mu2)", fmtArea(r.As2, 2)},
+		)
+	} else {
+		doc.Paragraph("Singly reinforced section is adequate; no compression steel is required.")
+	}
+
+	doc.Section("Code Checks")
+	if r.AsTotal > 0 {
+		reportChecks(doc,
+			minSteelCheck(r.AsTotal/(b.Width*b.EffectiveDepth), r.RhoMin),
+			tensionControlledCheck(b.Fc, b.Fy, r.EpsilonT),
+			strengthCheck(r.PhiMn, doublyDesignMu))
+	}
+	doc.Paragraph(r.Message)
+
+	doc.Section("Design Result")
+	if !r.IsAdequate {
+		doc.Paragraph("**Design not adequate.**")
+		return doc
+	}
+	result := fmt.Sprintf("**Tension steel As = %s**", fmtArea(r.AsTotal, 2))
+	if r.RequiresCompSteel {
+		result += fmt.Sprintf(", **compression steel A'sc = %s**", fmtArea(r.AscRequired, 2))
+	}
+	doc.Paragraph(fmt.Sprintf("%s; φMn = %s ≥ Mu = %s (φ = %.2f).", result,
+		fmtMoment(r.PhiMn, 2), fmtMoment(doublyDesignMu, 2), r.Phi))
+	reportLayouts(doc, "Bar Layouts", doublyLayouts(b, r), false)
+	return doc
+}
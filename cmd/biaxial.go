@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// biaxialSurfaceLevels is the number of contours of the biaxial interaction
+// surface written to a mesh, from pure tension to φPn,max
+const biaxialSurfaceLevels = 21
+
+// biaxialBarDiameter is the diameter of the bars a steel layer is taken as
+// when its description gives none (mm)
+const biaxialBarDiameter = 20
+
+// biaxialBars places the bars of the steel layers of a section as in its
+// diagram, across the width at the level of each layer: the bars of layers
+// described as count-bar, e.g. "4-20mm", or else the area of the layer as
+// bars of biaxialBarDiameter
+func biaxialBars(sec *section.Section) []section.BiaxialBar {
+	props := sec.CalculateProperties()
+	var vertices []diagram.Point
+	for _, v := range sec.Vertices {
+		vertices = append(vertices, diagram.Point{X: v.X, Y: v.Y})
+	}
+
+	var bars []section.BiaxialBar
+	for _, layer := range sec.Reinforcement {
+		count, diameter := 0, float64(biaxialBarDiameter)
+		if fields := strings.Fields(layer.Description); len(fields) > 0 {
+			if g, err := selectedCatalog.ParseGroup(fields[0]); err == nil {
+				count, diameter = g.Count, g.Bar.Diameter
+			}
+		}
+		if count < 1 {
+			count = int(math.Max(math.Ceil(layer.Area/(math.Pi*diameter*diameter/4)), 2))
+		}
+		for _, b := range diagram.OutlineLayerBars(vertices, props.Width, props.Height, count, diameter, layer.Y, false) {
+			bars = append(bars, section.BiaxialBar{X: b.X, Y: b.Y, Area: layer.Area / float64(count)})
+		}
+	}
+	return bars
+}
+
+// sectionBiaxial calculates the biaxial interaction contours of a section at
+// the design axial strengths, by default at 0, 20, 40, 60 and 80% of φPn,max
+func sectionBiaxial(sec *section.Section, bars []section.BiaxialBar, loads []float64) (*section.Biaxial, error) {
+	if len(loads) == 0 {
+		limits, err := sec.Biaxial(bars, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range []float64{0, 0.2, 0.4, 0.6, 0.8} {
+			loads = append(loads, f*limits.PhiPnMax)
+		}
+	}
+	return sec.Biaxial(bars, loads)
+}
+
+// biaxialSurface calculates the contours of the biaxial interaction surface
+// of a section evenly from pure tension to φPn,max
+func biaxialSurface(sec *section.Section, bars []section.BiaxialBar) (*section.Biaxial, error) {
+	limits, err := sec.Biaxial(bars, nil)
+	if err != nil {
+		return nil, err
+	}
+	var loads []float64
+	for i := 0; i < biaxialSurfaceLevels; i++ {
+		t := float64(i) / (biaxialSurfaceLevels - 1)
+		loads = append(loads, limits.PhiPnt+t*(limits.PhiPnMax-limits.PhiPnt))
+	}
+	return sec.Biaxial(bars, loads)
+}
+
+// printBiaxial prints the design moment strengths about each axis of the
+// contours of the biaxial interaction surface, with the top, bottom, right
+// and left face in compression
+func printBiaxial(b *section.Biaxial) {
+	fmt.Println("BIAXIAL INTERACTION (φMnx–φMny CONTOURS):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Maximum design axial strength (φPn,max):\t%s\n", fmtForce(b.PhiPnMax, 2))
+	fmt.Fprintf(w, "  Pure tension (φPnt):\t%s\n", fmtForce(b.PhiPnt, 2))
+	w.Flush()
+	fmt.Println()
+
+	w = newTextWriter()
+	fmt.Fprintln(w, "  φPn\tφMnx, top\tφMnx, bottom\tφMny, right\tφMny, left")
+	for _, c := range b.Contours {
+		at := func(quarter int) section.BiaxialPoint {
+			return c.Points[quarter*len(c.Points)/4]
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", fmtForce(c.PhiPn, 2),
+			fmtMoment(at(1).PhiMnx, 2), fmtMoment(at(3).PhiMnx, 2), fmtMoment(at(0).PhiMny, 2), fmtMoment(at(2).PhiMny, 2))
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// biaxialDiagramData returns the plot of the contours of the biaxial
+// interaction surface
+func biaxialDiagramData(sec *section.Section, b *section.Biaxial) diagram.BiaxialDiagramData {
+	data := diagram.BiaxialDiagramData{Name: sec.Name, Units: selectedUnits}
+	for _, c := range b.Contours {
+		contour := diagram.BiaxialContour{PhiPn: c.PhiPn}
+		for _, p := range c.Points {
+			contour.Points = append(contour.Points, diagram.Point{X: p.PhiMny, Y: p.PhiMnx})
+		}
+		data.Contours = append(data.Contours, contour)
+	}
+	return data
+}
+
+// biaxialLoad is a factored axial force with moments about both axes,
+// checked against the point of the biaxial interaction surface at the axial
+// force whose moment acts in the direction of the load
+type biaxialLoad struct {
+	Pu     float64               `json:"pu"`     // Compression positive (kN)
+	Mux    float64               `json:"mux"`    // Positive with the top in compression (kN-m)
+	Muy    float64               `json:"muy"`    // Positive with the right face in compression (kN-m)
+	Point  *section.BiaxialPoint `json:"point"`  // Neutral axis resisting the load
+	Mu     float64               `json:"mu"`     // Resultant of Mux and Muy (kN-m)
+	PhiMn  float64               `json:"phi_mn"` // Resultant design moment strength at Pu (kN-m)
+	Inside bool                  `json:"inside"` // The load is inside the biaxial interaction surface
+}
+
+// checkBiaxialLoad checks a factored load, given as Pu, Mux and Muy, against
+// the biaxial interaction surface of a section
+func checkBiaxialLoad(sec *section.Section, bars []section.BiaxialBar, load []float64) (*biaxialLoad, error) {
+	if len(load) != 3 {
+		return nil, fmt.Errorf("--biaxial-load takes Pu, Mux and Muy, got %d values", len(load))
+	}
+	pu, mux, muy := load[0], load[1], load[2]
+	point, err := sec.BiaxialAt(bars, pu, mux, muy)
+	if err != nil {
+		return nil, err
+	}
+	mu, phiMn := math.Hypot(mux, muy), math.Hypot(point.PhiMnx, point.PhiMny)
+	return &biaxialLoad{Pu: pu, Mux: mux, Muy: muy, Point: point, Mu: mu, PhiMn: phiMn, Inside: mu <= phiMn}, nil
+}
+
+// printBiaxialLoad prints the inclined neutral axis resisting a factored
+// load bent about both axes and the check of the load
+func printBiaxialLoad(load *biaxialLoad) {
+	p := load.Point
+	fmt.Println("BIAXIAL BENDING (INCLINED NEUTRAL AXIS):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Factored load (Pu, Mux, Muy):\t%s, %s, %s\n", fmtForce(load.Pu, 2), fmtMoment(load.Mux, 2), fmtMoment(load.Muy, 2))
+	fmt.Fprintf(w, "  Compression face, from +X:\t%.1f°\n", p.Angle)
+	fmt.Fprintf(w, "  Neutral axis, from the X axis (θ):\t%.1f°\n", diagram.InclinedAxisData{Angle: p.Angle}.Inclination())
+	fmt.Fprintf(w, "  Neutral axis depth (c):\t%s\n", fmtLength(p.C, 2))
+	fmt.Fprintf(w, "  Net tensile strain (εt):\t%.5f\n", p.EpsilonT)
+	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.3f\n", p.Phi)
+	fmt.Fprintf(w, "  Design moments (φMnx, φMny):\t%s, %s\n", fmtMoment(p.PhiMnx, 2), fmtMoment(p.PhiMny, 2))
+	if load.Inside {
+		fmt.Fprintf(w, "  Mu vs φMn at Pu:\t%s ≤ %s ✓\n", fmtMoment(load.Mu, 2), fmtMoment(load.PhiMn, 2))
+	} else {
+		fmt.Fprintf(w, "  Mu vs φMn at Pu:\t%s > %s ✗\n", fmtMoment(load.Mu, 2), fmtMoment(load.PhiMn, 2))
+	}
+	w.Flush()
+	fmt.Println()
+	if load.Inside {
+		fmt.Println("  ✓ The factored load is inside the biaxial interaction surface")
+	} else {
+		fmt.Println("  ✗ The factored load is outside the biaxial interaction surface")
+	}
+	fmt.Println()
+}
+
+// inclinedAxisData returns the plot of a section with the inclined neutral
+// axis and compression zone resisting a factored load bent about both axes,
+// the bars beyond the neutral axis in tension
+func inclinedAxisData(sec *section.Section, result *section.AnalysisResult, bars []section.BiaxialBar, load *biaxialLoad) diagram.InclinedAxisData {
+	p := load.Point
+	nx, ny := math.Cos(p.Angle*math.Pi/180), math.Sin(p.Angle*math.Pi/180)
+	top, bottom := math.Inf(-1), math.Inf(1)
+	for _, v := range sec.Vertices {
+		top, bottom = math.Max(top, nx*v.X+ny*v.Y), math.Min(bottom, nx*v.X+ny*v.Y)
+	}
+
+	data := sectionAnalysisDiagramData(sec, result)
+	data.Bars = nil
+	for _, b := range bars {
+		data.Bars = append(data.Bars, diagram.Bar{
+			X: b.X, Y: b.Y,
+			Diameter: math.Sqrt(4 * b.Area / math.Pi),
+			Tension:  top-(nx*b.X+ny*b.Y) > p.C,
+		})
+	}
+	return diagram.InclinedAxisData{
+		Name:    sec.Name,
+		Section: data,
+		Angle:   p.Angle,
+		C:       p.C,
+		A:       math.Min(selectedCode.Beta1(sec.Fc)*p.C, top-bottom),
+		PhiPn:   load.Pu,
+		PhiMnx:  p.PhiMnx,
+		PhiMny:  p.PhiMny,
+	}
+}
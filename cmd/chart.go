@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/spf13/cobra"
+)
+
+var chartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Design aid charts for teaching and manual design",
+	Long: `Generate the classical design aid charts of rectangular sections with tension
+steel only, for the selected concrete and steel strengths under the
+selected design code, as png, svg or pdf plots.
+
+Subcommands:
+  rn-rho     - Flexural resistance factor Rn = Mn/bd² against ρ
+  phirn-rho  - Design strength φMn/bd² against ρ, with φ from εt
+  phi-strain - Strength reduction factor φ against the net tensile strain εt`,
+}
+
+func init() {
+	rootCmd.AddCommand(chartCmd)
+}
+
+// Default strengths charted (MPa)
+var (
+	chartDefaultFc = []float64{21, 28, 35}
+	chartDefaultFy = []float64{415}
+)
+
+// chartPoints is the number of points of each curve
+const chartPoints = 100
+
+// chartInputs are the strengths charted and the file the chart is
+// exported to
+type chartInputs struct {
+	Fc     []float64
+	Fy     []float64
+	Output string
+}
+
+// addChartFlags adds the strength and export flags of a chart command
+func addChartFlags(cmd *cobra.Command, in *chartInputs) {
+	cmd.Flags().Float64SliceVar(&in.Fc, "fc", chartDefaultFc, "Concrete strengths f'c to chart, e.g. 21,28,35 (MPa)")
+	cmd.Flags().Float64SliceVar(&in.Fy, "fy", chartDefaultFy, "Steel yield strengths fy to chart, e.g. 275,415 (MPa)")
+	cmd.Flags().StringVarP(&in.Output, "output", "o", "", "Export the chart to file (png, svg, pdf)")
+}
+
+// chartCurve is a charted curve of one f'c and fy with its limiting ratios
+type chartCurve struct {
+	Fc     float64               `json:"fc"`
+	Fy     float64               `json:"fy"`
+	RhoMin float64               `json:"rho_min"`
+	RhoT   float64               `json:"rho_t"` // Tension-controlled limit
+	RhoMax float64               `json:"rho_max"`
+	RhoB   float64               `json:"rho_b"`
+	Points []beam.DesignAidPoint `json:"points"`
+}
+
+// curves returns the curves of every f'c and fy
+func (in chartInputs) curves() ([]chartCurve, error) {
+	var curves []chartCurve
+	for _, fy := range in.Fy {
+		for _, fc := range in.Fc {
+			if fc <= 0 || fy <= 0 {
+				return nil, fmt.Errorf("invalid strengths: f'c=%.2f, fy=%.2f", fc, fy)
+			}
+			curves = append(curves, chartCurve{
+				Fc:     fc,
+				Fy:     fy,
+				RhoMin: selectedCode.RhoMin(fc, fy),
+				RhoT:   beam.TensionControlledRatio(selectedCode, fc, fy),
+				RhoMax: selectedCode.RhoMax(fc, fy),
+				RhoB:   selectedCode.RhoBalanced(fc, fy),
+				Points: beam.DesignAid(selectedCode, fc, fy, chartPoints),
+			})
+		}
+	}
+	return curves, nil
+}
+
+// label names the curve in a legend
+func (c chartCurve) label() string {
+	return fmt.Sprintf("f'c = %g MPa, fy = %g MPa", c.Fc, c.Fy)
+}
+
+// runChart prints the limiting ratios of the curves of a chart of Rn, or
+// φRn when phi, and exports the chart
+func runChart(cmd *cobra.Command, in chartInputs, phi bool) {
+	curves, err := in.curves()
+	if err != nil {
+		printError(err)
+		return
+	}
+	value := func(p beam.DesignAidPoint) float64 {
+		if phi {
+			return p.PhiRn
+		}
+		return p.Rn
+	}
+	name, symbol := "Rn = Mn/bd²", "Rn"
+	if phi {
+		name, symbol = "φRn = φMn/bd²", "φRn"
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, c := range curves {
+			for _, p := range c.Points {
+				rows = append(rows, []string{cell(c.Fc), cell(c.Fy), cell(p.Rho), cell(p.Rn), cell(p.PhiRn), cell(p.EpsilonT), cell(p.Phi)})
+			}
+		}
+		printTable([]string{"f'c (MPa)", "fy (MPa)", "ρ", "Rn (MPa)", "φRn (MPa)", "εt", "φ"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, in, curves)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     DESIGN AID - %s vs ρ - %s\n", symbol, selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  %s of a rectangular section with tension steel only\n", name)
+	fmt.Println("  ρt is the largest ratio of a tension-controlled section")
+	fmt.Println()
+
+	w := newTextWriter()
+	fmt.Fprintf(w, "  f'c (MPa)\tfy (MPa)\tρmin\tρt\tρmax\tρb\t%s at ρt (MPa)\t%s at ρmax (MPa)\n", symbol, symbol)
+	fmt.Fprintf(w, "  ─────────\t────────\t────\t──\t────\t──\t─────────────\t───────────────\n")
+	for _, c := range curves {
+		fmt.Fprintf(w, "  %.0f\t%.0f\t%.5f\t%.5f\t%.5f\t%.5f\t%.3f\t%.3f\n", c.Fc, c.Fy, c.RhoMin, c.RhoT, c.RhoMax, c.RhoB,
+			value(beam.DesignAidAt(selectedCode, c.Fc, c.Fy, c.RhoT)), value(beam.DesignAidAt(selectedCode, c.Fc, c.Fy, c.RhoMax)))
+	}
+	w.Flush()
+	fmt.Println()
+
+	if in.Output != "" {
+		data := diagram.ChartData{
+			Title:  fmt.Sprintf("%s vs ρ - %s", name, selectedCode.Name()),
+			XLabel: "Steel ratio ρ = As/bd",
+			YLabel: name + " (MPa)",
+		}
+		for _, c := range curves {
+			curve := diagram.ChartCurve{Label: c.label()}
+			for _, p := range c.Points {
+				curve.Points = append(curve.Points, diagram.Point{X: p.Rho, Y: value(p)})
+			}
+			for _, m := range []struct {
+				kind string
+				rho  float64
+			}{{"ρmin", c.RhoMin}, {"ρt (tension-controlled)", c.RhoT}, {"ρmax", c.RhoMax}} {
+				if m.rho > 0 && m.rho <= c.RhoB {
+					curve.Marks = append(curve.Marks, diagram.ChartMark{Kind: m.kind, X: m.rho, Y: value(beam.DesignAidAt(selectedCode, c.Fc, c.Fy, m.rho))})
+				}
+			}
+			data.Curves = append(data.Curves, curve)
+		}
+		err := diagram.ExportChart(data, in.Output)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Chart exported to: %s\n", in.Output)
+			printPlotData(in.Output)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	chartPhiFy         float64
+	chartPhiGrade      gradeInput
+	chartPhiTransverse string
+	chartPhiOutput     string
+
+	// Section marked on the chart
+	chartPhiWidth  float64
+	chartPhiHeight float64
+	chartPhiCover  float64
+	chartPhiFc     float64
+	chartPhiAs     float64
+)
+
+var chartPhiStrainCmd = &cobra.Command{
+	Use:   "phi-strain",
+	Short: "Chart the strength reduction factor φ against the net tensile strain εt",
+	Long: `Chart the flexural strength reduction factor φ against the net tensile
+strain εt for the chosen steel grade, from the compression-controlled φ at
+the yield strain εty through the transition zone to the tension-controlled
+φ, for tied and spiral transverse reinforcement. The compression-controlled,
+transition and tension-controlled regions are marked.
+
+Give a section with --width, --height and --as to analyze it and mark its
+εt and φ on the curve of --transverse.
+
+Examples:
+  gorcb chart phi-strain --grade 415 -o phi-strain.png
+
+  # Mark a 300x500 mm beam with 4-25 mm bars
+  gorcb chart phi-strain --fy 415 -b 300 --height 500 --as 1963 -o phi-strain.svg
+
+  # Points of the curves for a spreadsheet
+  gorcb chart phi-strain --fy 275 --format csv > phi-strain.csv`,
+	Run: runChartPhiStrain,
+}
+
+func init() {
+	chartCmd.AddCommand(chartPhiStrainCmd)
+	tabular(chartPhiStrainCmd)
+
+	chartPhiStrainCmd.Flags().Float64Var(&chartPhiFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(chartPhiStrainCmd, &chartPhiGrade)
+	chartPhiStrainCmd.Flags().StringVar(&chartPhiTransverse, "transverse", "", "Transverse reinforcement of the section: tied or spiral (default tied)")
+	chartPhiStrainCmd.Flags().StringVarP(&chartPhiOutput, "output", "o", "", "Export the chart to file (png, svg, pdf)")
+
+	chartPhiStrainCmd.Flags().Float64VarP(&chartPhiWidth, "width", "b", 0, "Width of the section to mark (mm)")
+	chartPhiStrainCmd.Flags().Float64Var(&chartPhiHeight, "height", 0, "Total depth of the section to mark (mm)")
+	chartPhiStrainCmd.Flags().Float64VarP(&chartPhiCover, "cover", "c", 65, "Effective cover to steel centroid (mm)")
+	chartPhiStrainCmd.Flags().Float64Var(&chartPhiFc, "fc", 28, "Concrete compressive strength f'c of the section (MPa)")
+	chartPhiStrainCmd.Flags().Float64VarP(&chartPhiAs, "as", "a", 0, "Tension reinforcement area As of the section (mm²)")
+}
+
+// phiStrainPoint is a point of the φ-εt curves
+type phiStrainPoint struct {
+	EpsilonT  float64 `json:"epsilon_t"`
+	PhiTied   float64 `json:"phi_tied"`
+	PhiSpiral float64 `json:"phi_spiral"`
+}
+
+// phiStrainSection is the analyzed section marked on the chart
+type phiStrainSection struct {
+	C        float64 `json:"c"`
+	D        float64 `json:"d"`
+	EpsilonT float64 `json:"epsilon_t"`
+	Phi      float64 `json:"phi"`
+	Zone     string  `json:"zone"`
+}
+
+// phiStrainReport is the structured output of chart phi-strain
+type phiStrainReport struct {
+	Fy                float64           `json:"fy"`
+	EpsilonTY         float64           `json:"epsilon_ty"`
+	TensionControlled float64           `json:"epsilon_tension_controlled"`
+	PhiTied           float64           `json:"phi_compression_tied"`
+	PhiSpiral         float64           `json:"phi_compression_spiral"`
+	PhiTension        float64           `json:"phi_tension_controlled"`
+	Section           *phiStrainSection `json:"section,omitempty"`
+	Points            []phiStrainPoint  `json:"points"`
+}
+
+// phiZone names the region of a net tensile strain
+func phiZone(epsilonT, epsilonTY, tension float64) string {
+	switch {
+	case epsilonT <= epsilonTY:
+		return "Compression-controlled"
+	case epsilonT < tension:
+		return "Transition"
+	}
+	return "Tension-controlled"
+}
+
+func runChartPhiStrain(cmd *cobra.Command, args []string) {
+	if err := chartPhiGrade.resolve(&chartPhiFy); err != nil {
+		printError(err)
+		return
+	}
+	if chartPhiFy <= 0 {
+		printError(fmt.Errorf("invalid steel yield strength: fy=%.2f", chartPhiFy))
+		return
+	}
+	transverse, err := nscp.ParseTransverse(chartPhiTransverse)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	code := selectedCode
+	fy := chartPhiFy
+	r := phiStrainReport{
+		Fy:                fy,
+		EpsilonTY:         code.DesignYieldStrength(fy) / nscp.Es,
+		TensionControlled: code.TensionControlledStrain(chartPhiFc, fy),
+		PhiTied:           code.Phi(0, fy, nscp.TransverseTied),
+		PhiSpiral:         code.Phi(0, fy, nscp.TransverseSpiral),
+		PhiTension:        code.PhiFlexure(),
+	}
+
+	if chartPhiAs > 0 || chartPhiWidth > 0 || chartPhiHeight > 0 {
+		b := beam.NewSinglyReinforced(chartPhiWidth, chartPhiHeight, chartPhiCover, chartPhiFc, fy)
+		b.Code = code
+		result, err := b.Analyze(chartPhiAs)
+		if err != nil {
+			printError(err)
+			return
+		}
+		r.Section = &phiStrainSection{
+			C:        result.C,
+			D:        b.EffectiveDepth,
+			EpsilonT: result.EpsilonT,
+			Phi:      code.Phi(result.EpsilonT, fy, transverse),
+			Zone:     phiZone(result.EpsilonT, r.EpsilonTY, r.TensionControlled),
+		}
+	}
+
+	// Strains charted, past the tension-controlled limit and the section
+	top := 2 * r.TensionControlled
+	if r.Section != nil {
+		top = math.Max(top, 1.1*r.Section.EpsilonT)
+	}
+	const n = 200
+	for i := 0; i <= n; i++ {
+		et := top * float64(i) / n
+		r.Points = append(r.Points, phiStrainPoint{
+			EpsilonT:  et,
+			PhiTied:   code.Phi(et, fy, nscp.TransverseTied),
+			PhiSpiral: code.Phi(et, fy, nscp.TransverseSpiral),
+		})
+	}
+
+	if tabularOutput() {
+		rows := make([][]string, len(r.Points))
+		for i, p := range r.Points {
+			rows[i] = []string{cell(p.EpsilonT), cell(p.PhiTied), cell(p.PhiSpiral)}
+		}
+		printTable([]string{"εt", "φ (tied)", "φ (spiral)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"fy": fy, "transverse": transverse}, r)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     φ vs NET TENSILE STRAIN εt - %s\n", code.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  fy:                          %.0f MPa\n", fy)
+	fmt.Printf("  Yield strain εty:            %.5f\n", r.EpsilonTY)
+	fmt.Printf("  Tension-controlled εt ≥      %.5f\n", r.TensionControlled)
+	fmt.Println()
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Region\tεt\tφ (tied)\tφ (spiral)\n")
+	fmt.Fprintf(w, "  ──────\t──\t────────\t──────────\n")
+	fmt.Fprintf(w, "  Compression-controlled\t≤ %.5f\t%.2f\t%.2f\n", r.EpsilonTY, r.PhiTied, r.PhiSpiral)
+	fmt.Fprintf(w, "  Transition\t%.5f - %.5f\tlinear\tlinear\n", r.EpsilonTY, r.TensionControlled)
+	fmt.Fprintf(w, "  Tension-controlled\t≥ %.5f\t%.2f\t%.2f\n", r.TensionControlled, r.PhiTension, r.PhiTension)
+	w.Flush()
+
+	if s := r.Section; s != nil {
+		fmt.Println()
+		fmt.Println("SECTION:")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		fmt.Printf("  Neutral axis c:    %.1f mm (d = %.1f mm)\n", s.C, s.D)
+		fmt.Printf("  εt:                %.5f\n", s.EpsilonT)
+		fmt.Printf("  %-19s%.3f\n", fmt.Sprintf("φ (%s):", transverse), s.Phi)
+		fmt.Printf("  Region:            %s\n", s.Zone)
+	}
+	fmt.Println()
+
+	if chartPhiOutput != "" {
+		data := diagram.ChartData{
+			Title:  fmt.Sprintf("φ vs εt, fy = %g MPa - %s", fy, code.Name()),
+			XLabel: "Net tensile strain εt",
+			YLabel: "Strength reduction factor φ",
+			Regions: []diagram.ChartRegion{
+				{Label: "Compression-\ncontrolled", From: 0, To: r.EpsilonTY},
+				{Label: "Transition", From: r.EpsilonTY, To: r.TensionControlled},
+				{Label: "Tension-controlled", From: r.TensionControlled, To: top},
+			},
+		}
+		tied := diagram.ChartCurve{Label: "Tied"}
+		spiral := diagram.ChartCurve{Label: "Spiral"}
+		for _, p := range r.Points {
+			tied.Points = append(tied.Points, diagram.Point{X: p.EpsilonT, Y: p.PhiTied})
+			spiral.Points = append(spiral.Points, diagram.Point{X: p.EpsilonT, Y: p.PhiSpiral})
+		}
+		if s := r.Section; s != nil {
+			mark := diagram.ChartMark{Kind: fmt.Sprintf("Section: εt = %.4f, φ = %.3f", s.EpsilonT, s.Phi), X: s.EpsilonT, Y: s.Phi}
+			if transverse == nscp.TransverseSpiral {
+				spiral.Marks = append(spiral.Marks, mark)
+			} else {
+				tied.Marks = append(tied.Marks, mark)
+			}
+		}
+		data.Curves = []diagram.ChartCurve{tied, spiral}
+		err := diagram.ExportChart(data, chartPhiOutput)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Chart exported to: %s\n", chartPhiOutput)
+			printPlotData(chartPhiOutput)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var chartPhiRnRho chartInputs
+
+var chartPhiRnRhoCmd = &cobra.Command{
+	Use:   "phirn-rho",
+	Short: "Chart the design strength φMn/bd² against ρ",
+	Long: `Chart the design strength of a rectangular section with tension steel only
+per unit bd², φRn = φMn/bd², against the steel ratio ρ up to the balanced
+ratio, with φ from the net tensile strain εt of each ratio. Past the
+tension-controlled limit ρt, φ falls through the transition zone and the
+curves flatten or drop, which shows why more steel gains little there.
+One curve is drawn for each f'c and fy, with ρmin, ρt and ρmax marked.
+For manual design, read ρ at φRn = Mu/bd².
+
+Examples:
+  gorcb chart phirn-rho -o phirn-rho.png
+  gorcb chart phirn-rho --fc 28 --fy 275,415,520 -o phirn-rho.svg --code aci318-19`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runChart(cmd, chartPhiRnRho, true)
+	},
+}
+
+func init() {
+	chartCmd.AddCommand(chartPhiRnRhoCmd)
+	tabular(chartPhiRnRhoCmd)
+	addChartFlags(chartPhiRnRhoCmd, &chartPhiRnRho)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var chartRnRho chartInputs
+
+var chartRnRhoCmd = &cobra.Command{
+	Use:   "rn-rho",
+	Short: "Chart the flexural resistance factor Rn = Mn/bd² against ρ",
+	Long: `Chart the flexural resistance factor of a rectangular section with tension
+steel only,
+
+  Rn = Mn/bd² = ρ·fy·(1 − ρ·fy/(2·0.85f'c))
+
+against the steel ratio ρ up to the balanced ratio, one curve for each
+f'c and fy, with ρmin, the tension-controlled limit ρt and ρmax marked.
+For manual design, read ρ at Rn = Mu/(φbd²). The limiting ratios are
+printed, the points of the curves are written with --format csv, and -o
+exports the chart.
+
+Examples:
+  gorcb chart rn-rho -o rn-rho.png
+  gorcb chart rn-rho --fc 21,28,35,42 --fy 275,415 -o rn-rho.pdf
+
+  # Points of the curves for a spreadsheet
+  gorcb chart rn-rho --fc 28 --format csv > rn-rho.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runChart(cmd, chartRnRho, false)
+	},
+}
+
+func init() {
+	chartCmd.AddCommand(chartRnRhoCmd)
+	tabular(chartRnRhoCmd)
+	addChartFlags(chartRnRhoCmd, &chartRnRho)
+}
@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/check"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/report"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"github.com/alexiusacademia/gorcb/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkFile           string
+	checkDeflectionFile string
+)
+
+// deflectionStations is the number of segments the deflected shapes of
+// --deflection are plotted with
+const deflectionStations = 40
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Verify a complete beam design against every limit state",
+	Long: `Review an existing drawing: read a rectangular beam as detailed, with the
+position of every layer of bars, its stirrups and its loads, and verify it
+against every limit state in one pass/fail matrix:
+
+  Flexure       φMn ≥ Mu, As ≥ As,min and ρ ≤ ρmax (less the compression
+                steel it balances)
+  Shear         φVn ≥ Vu with the provided stirrups, Vs ≤ Vs,max, stirrup
+                spacing and minimum shear steel
+  Spacing       clear spacing of the bars of each layer and between layers,
+                and bar spacing for crack control
+  Development   embedment of each tension layer beyond the critical section
+                against ℓd
+  Deflection    immediate live load deflection ≤ L/360 and long-term
+                deflection ≤ L/240 (L/480 with sensitive: true)
+
+Layers are listed from the face outward; a layer without a depth is placed
+inside the clear cover and stirrups, 25 mm clear of the layer before it.
+Factored mu and vu are given directly, or loads as M[,V] per load type are
+combined like the members of a project, which also gives the service
+moments for crack control and deflection. Checks without the data they need
+(no vu, embedment, loads or span) are reported as N/A.
+
+  {
+    "id": "B1",
+    "width": 300, "height": 500, "fc": 28, "fy": 415,
+    "clear_cover": 40,
+    "tension": [{"bars": "4-20", "embedment": 900}],
+    "compression": [{"bars": "2-16"}],
+    "stirrups": {"dia": 10, "legs": 2, "spacing": 150},
+    "loads": {"D": [90, 80], "L": [45, 40]},
+    "sustained_live": 0.25,
+    "span": 6, "support": "simple"
+  }
+
+--deflection exports the deflected shapes along the span, the immediate
+deflection under Ma, the immediate live load deflection and the long-term
+deflection, with their limits L/360 and L/240 (or L/480) across the span.
+
+Plugins registered under plugins in the configuration add office-specific
+checks or export steps to the beam, section, check, batch and project
+commands: each is an external program given the --format json document of
+the run on stdin, answering on stdout with {"checks": [...], "messages": [...]}
+where a check has the fields of a verification row. Their checks are printed
+after the results and an NG check sets exit code 3, e.g.
+
+  plugins:
+    - name: office-checks
+      exec: ./tools/office-checks.py   # relative to the configuration file
+      args: [--strict]
+      on: [beam design, check]         # default: every analysis
+      timeout: 30                      # seconds
+
+Plugins are only registered in ~/.gorcb.yaml or the file of --config (or
+GORCB_CONFIG); those of ./gorcb.yaml are not run, so that opening a project
+directory never runs programs it brings along. Use --no-plugins to skip them.
+
+Examples:
+  gorcb check -f design.json
+  gorcb check -f design.yaml --report check.pdf
+  gorcb check -f design.json --deflection b1-deflection.png
+  gorcb check -f design.json --format csv > checks.csv`,
+	Run: runCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	tabular(checkCmd)
+	reportable(checkCmd)
+	exportable(checkCmd)
+	unitAware(checkCmd)
+
+	checkCmd.Flags().StringVarP(&checkFile, "file", "f", "", "Design JSON or YAML file, or - for JSON on stdin [required]")
+	checkCmd.Flags().StringVar(&checkDeflectionFile, "deflection", "", "Export the deflected shapes along the span with their limits to file (png, svg, pdf)")
+	checkCmd.MarkFlagRequired("file")
+}
+
+// checkHeader is the header of the verification matrix
+var checkHeader = []string{"Limit State", "Check", "Clause", "Value", "Limit", "Status", "Note"}
+
+func runCheck(cmd *cobra.Command, args []string) {
+	d, err := check.Load(checkFile)
+	if err != nil {
+		printError(err)
+		return
+	}
+	result, err := check.Run(d, selectedCode, selectedCatalog)
+	if err != nil {
+		printError(err)
+		return
+	}
+	checkAdequacy(result.IsAdequate)
+
+	var rows [][]string
+	for _, c := range result.Checks {
+		rows = append(rows, checkRow(c))
+	}
+	if reportFile != "" {
+		defer writeReport(checkReport(cmd, result, rows))
+	}
+	if workbookFile != "" {
+		book := xlsx.New()
+		book.AddSheet("Checks").Table(checkHeader, rows)
+		defer writeWorkbook(book)
+	}
+	runPlugins(cmd, d, result)
+	defer printPluginResults()
+	if tabularOutput() {
+		printTable(checkHeader, rows)
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, d, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     DESIGN CHECK - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("DESIGN:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	for _, f := range checkFields(result) {
+		fmt.Fprintf(w, "  %s:\t%s\n", i18n.T(f[0]), f[1])
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("VERIFICATION MATRIX:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintln(w, "  Limit State\tCheck\tValue\tLimit\tStatus\tClause")
+	fmt.Fprintln(w, "  ───────────\t─────\t─────\t─────\t──────\t──────")
+	for _, row := range rows {
+		note := strings.TrimSpace(strings.TrimPrefix(row[2], "-") + "  " + row[6])
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", row[0], row[1], row[3], row[4], row[5], note)
+	}
+	w.Flush()
+	fmt.Println()
+
+	checked, passed := result.Counts()
+	fmt.Printf("  %d of %d checks OK, %d not checked\n", passed, checked, len(result.Checks)-checked)
+	if result.IsAdequate {
+		fmt.Println("  ✓ " + i18n.T("Design is adequate for every limit state checked"))
+	} else {
+		fmt.Println("  ✗ " + i18n.T("Design is NOT adequate"))
+	}
+	fmt.Println()
+
+	if checkDeflectionFile != "" {
+		err := fmt.Errorf("no deflection to plot (give loads and span)")
+		if result.Deflection > 0 {
+			err = diagram.ExportDeflectionDiagram(checkDeflectionData(result), checkDeflectionFile)
+		}
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Deflection diagram exported to: %s\n", checkDeflectionFile)
+			printPlotData(checkDeflectionFile)
+		}
+	}
+}
+
+// checkDeflectionData returns the deflected shapes of the span of a checked
+// design with the limits of the live load and long-term deflections
+func checkDeflectionData(r *check.Result) diagram.DeflectionDiagramData {
+	d := r.Design
+	data := diagram.DeflectionDiagramData{
+		Name:       d.ID,
+		Span:       d.Span,
+		Cantilever: d.Support == check.SupportCantilever,
+		Units:      selectedUnits,
+	}
+	for _, c := range []struct {
+		label string
+		max   float64
+	}{{"Immediate (Ma)", r.Deflection}, {"Immediate live load", r.Live}, {"Long-term", r.LongTerm}} {
+		curve := diagram.DeflectionCurve{Label: c.label}
+		for i := 0; i <= deflectionStations; i++ {
+			x := d.Span * float64(i) / deflectionStations
+			curve.Points = append(curve.Points, diagram.Point{X: x, Y: c.max * d.DeflectionShape(x)})
+		}
+		data.Curves = append(data.Curves, curve)
+	}
+	long := check.LongTermDeflectionLimit(d.Sensitive)
+	data.Limits = []diagram.DeflectionLimit{
+		{Label: fmt.Sprintf("L/%d", check.LiveDeflectionLimit), Value: d.Span * 1000 / check.LiveDeflectionLimit, Of: "Immediate live load"},
+		{Label: fmt.Sprintf("L/%d", long), Value: d.Span * 1000 / float64(long), Of: "Long-term"},
+	}
+	return data
+}
+
+// checkFields returns the member, materials, reinforcement and actions of
+// a checked design as label, value pairs
+func checkFields(r *check.Result) [][]string {
+	d := r.Design
+	fields := [][]string{}
+	if d.ID != "" {
+		fields = append(fields, []string{"Member", strings.TrimSpace(d.ID + " " + d.Description)})
+	}
+	fields = append(fields,
+		[]string{"Section", fmt.Sprintf("%s × %s", fmtLength(d.Width, 0), fmtLength(d.Height, 0))},
+		[]string{"f'c / fy / fyt", fmt.Sprintf("%s / %s / %s", fmtStress(r.Fc, 0), fmtStress(r.Fy, 0), fmtStress(r.Fyt, 0))},
+		[]string{"Clear cover", fmtLength(r.ClearCover, 0)},
+	)
+	for i, l := range r.Tension {
+		fields = append(fields, []string{fmt.Sprintf("Tension layer %d", i+1),
+			fmt.Sprintf("%s at %s", l.Group, fmtLength(l.Depth, 0))})
+	}
+	fields = append(fields, []string{"Tension steel (As, d)", fmt.Sprintf("%s at %s", fmtArea(r.As, 2), fmtLength(r.D, 1))})
+	for i, l := range r.Compression {
+		fields = append(fields, []string{fmt.Sprintf("Compression layer %d", i+1),
+			fmt.Sprintf("%s at %s", l.Group, fmtLength(l.Depth, 0))})
+	}
+	if r.Asc > 0 {
+		fields = append(fields, []string{"Compression steel (A's, d')", fmt.Sprintf("%s at %s", fmtArea(r.Asc, 2), fmtLength(r.DPrime, 1))})
+	}
+	if s := d.Stirrups; s != nil {
+		legs := s.Legs
+		if legs == 0 {
+			legs = 2
+		}
+		stirrups := fmt.Sprintf("%d-leg φ%.0fmm", legs, s.Diameter)
+		if s.Spacing > 0 {
+			stirrups += " @ " + fmtSpacing(s.Spacing, 0)
+		}
+		fields = append(fields, []string{"Stirrups", stirrups})
+	}
+	fields = append(fields, []string{"Factored moment (Mu)", fmtMoment(r.Mu, 2) + combinationNote(r.MomentCombination)})
+	if r.Vu > 0 {
+		fields = append(fields, []string{"Factored shear (Vu)", fmtForce(r.Vu, 2) + combinationNote(r.ShearCombination)})
+	}
+	if d.Span > 0 {
+		support := d.Support
+		if support == "" {
+			support = check.SupportSimple
+		}
+		fields = append(fields, []string{"Span", fmt.Sprintf("%g m (%s)", d.Span, support)})
+	}
+	return fields
+}
+
+// combinationNote returns the governing combination in brackets, or ""
+func combinationNote(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (combination " + id + ")"
+}
+
+// checkValue prints a value of the verification matrix in the selected
+// units, rounded as a spacing for the spacing checks, or as a plain ratio
+// when it has no unit
+func checkValue(c check.Item, v float64) string {
+	u, ok := selectedUnits.Unit(c.Unit)
+	if !ok {
+		return fmt.Sprintf("%.6f", v)
+	}
+	quantity, _ := units.Quantity(c.Unit)
+	prec := 2
+	if c.Unit == "mm" {
+		prec = 1
+		if c.LimitState == check.LimitSpacing || strings.Contains(strings.ToLower(c.Check), "spacing") {
+			quantity = units.QuantitySpacing
+		}
+	}
+	return fmtQuantity(u, quantity, v, prec)
+}
+
+// checkRow returns a row of the verification matrix for tables and reports
+func checkRow(c check.Item) []string {
+	value, limit := checkValue(c, c.Value), checkValue(c, c.Limit)
+	if c.Status == check.StatusNotChecked && c.Value == 0 {
+		value = "-"
+	}
+	if c.Limit == 0 {
+		limit = "-"
+	}
+	clause := c.Clause
+	if clause == "" {
+		clause = "-"
+	}
+	return []string{c.LimitState, c.Check, clause, value, limit, c.Status, c.Note}
+}
+
+// checkReport returns the report of a checked design
+func checkReport(cmd *cobra.Command, r *check.Result, rows [][]string) *report.Document {
+	doc := newReport(cmd, "Design Check Report")
+	checked, passed := r.Counts()
+	doc.Conclude(r.IsAdequate, fmt.Sprintf("%d of %d checks OK, %d not checked", passed, checked, len(r.Checks)-checked))
+
+	doc.Section("Input Data")
+	doc.Fields(checkFields(r)...)
+
+	doc.Section("Verification Matrix")
+	doc.Table(checkHeader, rows)
+	return doc
+}
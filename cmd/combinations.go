@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+// combinationInputs holds the options that select the load combinations of
+// the moment and loads commands
+type combinationInputs struct {
+	Method     string
+	Simplified bool
+
+	// User-defined load combinations
+	File string
+	Set  string
+
+	// Seismic load effect (E = ρEh ± 0.2SDS·D)
+	Seismic      nscp.SeismicParameters
+	Overstrength bool
+
+	// Resolved by resolve
+	ASD           bool
+	ExpandSeismic bool
+	Title         string
+}
+
+// addCombinationFlags registers the load combination selection flags
+func addCombinationFlags(cmd *cobra.Command, in *combinationInputs) {
+	cmd.Flags().BoolVarP(&in.Simplified, "simplified", "s", false, "Use simplified combinations (gravity only: 1.4D and 1.2D+1.6L)")
+
+	// Seismic flags
+	cmd.Flags().Float64Var(&in.Seismic.Rho, "rho", 1.0, "Redundancy factor ρ applied to Eh")
+	cmd.Flags().Float64Var(&in.Seismic.SDS, "sds", 0, "Design spectral acceleration SDS for Ev = 0.2SDS·D")
+	cmd.Flags().Float64Var(&in.Seismic.Omega0, "omega0", 0, "Overstrength factor Ω0 for Em = Ω0Eh ± Ev")
+	cmd.Flags().BoolVar(&in.Overstrength, "overstrength", false, "Add overstrength (Em) seismic combinations (collectors, supports of discontinuous elements)")
+
+	cmd.Flags().StringVar(&in.Method, "method", "lrfd", "Design method: lrfd (strength design) or asd (allowable stress design)")
+	cmd.Flags().StringVar(&in.File, "combinations", "", "JSON/YAML file of user-defined load combination sets")
+	cmd.Flags().StringVar(&in.Set, "set", "", "Name of the combination set to use (default: first set in the file)")
+}
+
+// resolve returns the load combinations selected by the flags: the strength
+// or ASD combinations of the selected code, or a user-defined set, expanded
+// into the seismic branches when any seismic flag was given
+func (in *combinationInputs) resolve(cmd *cobra.Command) ([]nscp.LoadCombination, error) {
+	// Strength (LRFD) or allowable stress (ASD) combinations
+	switch strings.ToLower(in.Method) {
+	case "lrfd", "strength", "usd":
+		in.ASD = false
+	case "asd":
+		in.ASD = true
+	default:
+		return nil, fmt.Errorf("unknown design method %q (use lrfd or asd)", in.Method)
+	}
+
+	combinations := selectedCode.LoadCombinations()
+	in.Title = selectedCode.Name()
+	if in.ASD {
+		combinations = selectedCode.ASDCombinations()
+		if combinations == nil {
+			return nil, fmt.Errorf("%s does not define allowable stress design combinations", selectedCode.Name())
+		}
+		if in.Simplified {
+			return nil, fmt.Errorf("--simplified applies to strength design combinations only")
+		}
+		in.Title += " ASD"
+	}
+	if in.Simplified {
+		combinations = nscp.SimplifiedCombinations
+	}
+
+	if in.File != "" {
+		sets, err := nscp.LoadCombinationSets(in.File)
+		if err != nil {
+			return nil, err
+		}
+		set, err := nscp.FindCombinationSet(sets, in.Set)
+		if err != nil {
+			return nil, err
+		}
+		combinations = set.Resolve(combinations)
+		in.Title = set.Name
+		if set.Append {
+			in.Title += " + " + set.Name
+		}
+	} else if in.Set != "" {
+		return nil, fmt.Errorf("--set requires a --combinations file")
+	}
+
+	// Treat the earthquake effect as Eh and expand E = ρEh ± Ev (and Em)
+	in.ExpandSeismic = cmd.Flags().Changed("rho") || cmd.Flags().Changed("sds") || in.Overstrength
+	if in.ExpandSeismic {
+		if err := in.Seismic.Validate(in.Overstrength); err != nil {
+			return nil, err
+		}
+		combinations = nscp.SeismicCombinations(combinations, in.Seismic, in.Overstrength)
+	}
+	return combinations, nil
+}
+
+// printSeismicEffect prints the seismic load effect parameters, with the
+// vertical effect Ev evaluated for the unfactored dead load effect when the
+// unit is given
+func (in *combinationInputs) printSeismicEffect(dead float64, unit string) {
+	if !in.ExpandSeismic {
+		return
+	}
+	fmt.Println("SEISMIC LOAD EFFECT:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Redundancy factor (ρ):\t%.2f\n", in.Seismic.Rho)
+	fmt.Fprintf(w, "  SDS:\t%.3f\n", in.Seismic.SDS)
+	if unit != "" {
+		fmt.Fprintf(w, "  Vertical effect (Ev):\t%.3f·D = %.2f %s\n", 0.2*in.Seismic.SDS, 0.2*in.Seismic.SDS*dead, unit)
+	} else {
+		fmt.Fprintf(w, "  Vertical effect (Ev):\t%.3f·D\n", 0.2*in.Seismic.SDS)
+	}
+	fmt.Fprintf(w, "  E:\tρEh ± Ev\n")
+	if in.Overstrength {
+		fmt.Fprintf(w, "  Overstrength factor (Ω0):\t%.2f\n", in.Seismic.Omega0)
+		fmt.Fprintf(w, "  Em:\tΩ0Eh ± Ev\n")
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compareMu    float64
+	compareVu    float64
+	compareSpecs [2]string
+	compareCosts costInputs
+
+	compareConcreteType string
+
+	compareExportFile string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare two beam designs for the same demand side by side",
+	Long: `Design two rectangular beams for the same factored moment (and shear) and
+compare their steel areas, capacities, utilization, concrete volume and
+estimated cost side by side.
+
+Each design is given as comma-separated name=value pairs of the fields of
+'gorcb batch run' (width, height, cover, cover_comp, fc, fy, grade, type,
+stirrup_dia, ...) plus code to design it under another code than --code.
+Omitted covers default to 65 mm, f'c to 28 MPa and fy to 415 MPa.
+
+Quantities and cost are per --length of beam (1 m by default). Formwork
+covers the soffit and both sides; stirrups are closed hoops with 135° hooks.
+
+With --output, both sections are drawn side by side to the same scale with
+their stress blocks and key results, for an option study.
+
+Examples:
+  # Deeper section against the baseline
+  gorcb compare --mu 250 --vu 180 --a width=300,height=500 --b width=300,height=600
+
+  # Higher concrete strength, or the same section under ACI 318-19
+  gorcb compare --mu 250 --a width=300,height=500 --b width=300,height=500,fc=35
+  gorcb compare --mu 250 --a width=300,height=500 --b width=300,height=500,code=aci318-19
+
+  # Both sections to scale for the option study
+  gorcb compare --mu 250 --a width=300,height=500 --b width=250,height=600 -o options.png`,
+	Run: runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().Float64VarP(&compareMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
+	compareCmd.Flags().Float64Var(&compareVu, "vu", 0, "Factored shear Vu for stirrup design (kN)")
+	compareCmd.Flags().StringVar(&compareSpecs[0], "a", "", "First design as name=value pairs, e.g. width=300,height=500 [required]")
+	compareCmd.Flags().StringVar(&compareSpecs[1], "b", "", "Second design as name=value pairs [required]")
+	compareCmd.Flags().StringVar(&compareConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
+	addCostFlags(compareCmd, &compareCosts)
+	compareCmd.Flags().StringVarP(&compareExportFile, "output", "o", "", "Export both sections side by side to the same scale to file (png, svg, pdf)")
+
+	compareCmd.MarkFlagRequired("mu")
+	compareCmd.MarkFlagRequired("a")
+	compareCmd.MarkFlagRequired("b")
+}
+
+// comparedDesign is one design of the compare command
+type comparedDesign struct {
+	Label      string          `json:"label"`
+	Code       string          `json:"code"`
+	Result     batch.Result    `json:"result"`
+	Quantities cost.Quantities `json:"quantities"`
+}
+
+// parseDesignSpec reads a design of name=value pairs, with code selecting
+// the design code
+func parseDesignSpec(spec string) (batch.Member, codes.DesignCode, error) {
+	code := selectedCode
+	var fields []string
+	for _, pair := range strings.Split(spec, ",") {
+		name, value, _ := strings.Cut(pair, "=")
+		if strings.ToLower(strings.TrimSpace(name)) != "code" {
+			fields = append(fields, pair)
+			continue
+		}
+		c, err := codes.Get(strings.TrimSpace(value))
+		if err != nil {
+			return batch.Member{}, nil, err
+		}
+		code = c
+	}
+	m, err := batch.ParseMember(strings.Join(fields, ","))
+	return m, code, err
+}
+
+func runCompare(cmd *cobra.Command, args []string) {
+	if err := compareCosts.validate(); err != nil {
+		printError(err)
+		return
+	}
+	lambda, err := concreteLambda(compareConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	var designs [2]comparedDesign
+	for i, spec := range compareSpecs {
+		label := string(rune('A' + i))
+		m, code, err := parseDesignSpec(spec)
+		if err != nil {
+			printError(fmt.Errorf("design %s: %w", label, err))
+			return
+		}
+		if m.ID == "" {
+			m.ID = label
+		}
+		m.Mu, m.Vu = compareMu, compareVu
+		if m.Lambda == 0 {
+			m.Lambda = lambda
+		}
+		r, err := batch.RunMember(m, code)
+		if err != nil {
+			printError(fmt.Errorf("design %s: %w", label, err))
+			return
+		}
+		designs[i] = comparedDesign{Label: m.ID, Code: code.Name(), Result: *r, Quantities: r.Quantities(compareCosts.Length, compareCosts.Costs)}
+		checkStatus(r.Status(), r.NoConvergence)
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"mu": compareMu, "vu": compareVu, "a": compareSpecs[0], "b": compareSpecs[1],
+			"length": compareCosts.Length, "unit_costs": compareCosts.Costs}, designs)
+		return
+	}
+
+	a, b := designs[0], designs[1]
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     DESIGN COMPARISON")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  Mu = %.2f kN-m", compareMu)
+	if compareVu > 0 {
+		fmt.Printf(", Vu = %.2f kN", compareVu)
+	}
+	fmt.Println()
+	fmt.Println()
+
+	// row prints a property of both designs, with the difference B - A when
+	// the format is numeric
+	w := newTextWriter()
+	row := func(label, format string, va, vb float64) {
+		fmt.Fprintf(w, "  %s\t"+format+"\t"+format+"\t%+.1f%%\n", label, va, vb, percentChange(va, vb))
+	}
+	text := func(label, va, vb string) {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t\n", label, va, vb)
+	}
+
+	fmt.Println("DESIGNS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	fmt.Fprintf(w, "  \t%s\t%s\tB vs A\n", a.Label, b.Label)
+	fmt.Fprintf(w, "  \t%s\t%s\t──────\n", strings.Repeat("─", len(a.Label)), strings.Repeat("─", len(b.Label)))
+	text("Design code", a.Code, b.Code)
+	text("Type", batchType(a.Result.Member), batchType(b.Result.Member))
+	text("b × h (mm)", fmt.Sprintf("%.0f × %.0f", a.Result.Member.Width, a.Result.Member.Height),
+		fmt.Sprintf("%.0f × %.0f", b.Result.Member.Width, b.Result.Member.Height))
+	text("f'c / fy (MPa)", fmt.Sprintf("%.0f / %.0f", a.Result.Fc, a.Result.Fy), fmt.Sprintf("%.0f / %.0f", b.Result.Fc, b.Result.Fy))
+	fmt.Fprintln(w, "  \t\t\t")
+
+	row("As (mm²)", "%.2f", a.Result.As, b.Result.As)
+	if a.Result.Member.IsDoubly() || b.Result.Member.IsDoubly() {
+		row("As' (mm²)", "%.2f", a.Result.Asc, b.Result.Asc)
+	}
+	row("φMn (kN-m)", "%.2f", a.Result.PhiMn, b.Result.PhiMn)
+	row("Utilization Mu/φMn", "%.3f", a.Result.Utilization(), b.Result.Utilization())
+	row("Tensile strain εt", "%.5f", a.Result.EpsilonT, b.Result.EpsilonT)
+	if compareVu > 0 {
+		text("Stirrups", batchStirrups(a.Result), batchStirrups(b.Result))
+		if a.Result.Shear != nil && b.Result.Shear != nil {
+			row("φVn (kN)", "%.2f", a.Result.Shear.PhiVn, b.Result.Shear.PhiVn)
+		}
+	}
+	text("Status", a.Result.Status(), b.Result.Status())
+	fmt.Fprintln(w, "  \t\t\t")
+
+	fmt.Fprintf(w, "  Quantities (per %.2f m)\t\t\t\n", compareCosts.Length)
+	row("Concrete (m³)", "%.3f", a.Quantities.Concrete, b.Quantities.Concrete)
+	row("Steel (kg)", "%.2f", a.Quantities.Steel, b.Quantities.Steel)
+	row("Formwork (m²)", "%.2f", a.Quantities.Formwork, b.Quantities.Formwork)
+	row("Cost", "%.2f", a.Quantities.Total, b.Quantities.Total)
+	w.Flush()
+	fmt.Println()
+
+	for _, d := range designs {
+		if d.Result.Status() != "OK" {
+			fmt.Printf("  %s: %s\n", d.Label, i18n.T(d.Result.Message))
+		}
+	}
+	cheaper, saving := a, b.Quantities.Total-a.Quantities.Total
+	if saving < 0 {
+		cheaper, saving = b, -saving
+	}
+	fmt.Printf("  %s costs %.2f less per %.2f m (concrete %.2f/m³, steel %.2f/kg, formwork %.2f/m²)\n",
+		cheaper.Label, saving, compareCosts.Length, compareCosts.Costs.Concrete, compareCosts.Costs.Steel, compareCosts.Costs.Formwork)
+	fmt.Println()
+
+	if compareExportFile != "" {
+		data := diagram.ComparisonData{A: comparedSectionData(a), B: comparedSectionData(b)}
+		if err := diagram.ExportComparisonDiagram(data, compareExportFile); err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Comparison exported to: %s\n", compareExportFile)
+			printPlotData(compareExportFile)
+		}
+	}
+}
+
+// comparedSectionData returns the section of a compared design with its
+// steel and stress block, and its key results listed under it. The neutral
+// axis of a doubly reinforced design is found from its tensile strain; a
+// design without steel is drawn without a stress block.
+func comparedSectionData(d comparedDesign) diagram.ComparedSection {
+	r, m := d.Result, d.Result.Member
+	cover := m.Cover
+	if cover == 0 {
+		cover = batch.DefaultCover
+	}
+	b := beam.NewSinglyReinforced(m.Width, m.Height, cover, r.Fc, r.Fy)
+	a, c, ok := batchStressBlock(r)
+	if !ok && m.IsDoubly() && r.As > 0 {
+		epsilonCU := selectedCode.EpsilonCU(r.Fc)
+		c = epsilonCU * b.EffectiveDepth / (epsilonCU + r.EpsilonT)
+		a = selectedCode.Beta1(r.Fc) * c
+	}
+	data := singlyDiagramData(b, r.As, a, c, r.EpsilonT)
+	if m.IsDoubly() && r.Asc > 0 {
+		coverComp := m.CoverComp
+		if coverComp == 0 {
+			coverComp = batch.DefaultCover
+		}
+		data.CompSteelY, data.CompSteelArea, data.IsDoubly = coverComp, r.Asc, true
+	}
+
+	results := []diagram.ComparisonResult{
+		{Name: "b × h", Value: fmt.Sprintf("%s × %s", fmtLength(m.Width, 0), fmtLength(m.Height, 0))},
+		{Name: "As", Value: fmtArea(r.As, 0)},
+	}
+	if r.Asc > 0 {
+		results = append(results, diagram.ComparisonResult{Name: "As'", Value: fmtArea(r.Asc, 0)})
+	}
+	results = append(results,
+		diagram.ComparisonResult{Name: "φMn", Value: fmtMoment(r.PhiMn, 2)},
+		diagram.ComparisonResult{Name: "Mu/φMn", Value: fmt.Sprintf("%.3f", r.Utilization())},
+		diagram.ComparisonResult{Name: "εt", Value: fmt.Sprintf("%.5f", r.EpsilonT)},
+	)
+	if r.Shear != nil {
+		results = append(results, diagram.ComparisonResult{Name: "Stirrups", Value: batchStirrups(r)})
+	}
+	results = append(results,
+		diagram.ComparisonResult{Name: "Cost", Value: fmt.Sprintf("%.2f per %.2f m", d.Quantities.Total, compareCosts.Length)},
+		diagram.ComparisonResult{Name: "Status", Value: r.Status()},
+	)
+	return diagram.ComparedSection{Label: d.Label + " (" + d.Code + ")", Section: data, Results: results}
+}
+
+// percentChange returns the change from a to b in percent, zero below the
+// printed precision
+func percentChange(a, b float64) float64 {
+	if a == 0 {
+		return 0
+	}
+	p := (b - a) / a * 100
+	if math.Abs(p) < 0.05 {
+		return 0
+	}
+	return p
+}
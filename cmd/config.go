@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/config"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	// Configuration file given with --config in place of ~/.gorcb.yaml and ./gorcb.yaml
+	configFile string
+
+	// Flags of the command being run that were set from the environment or
+	// the configuration
+	configuredFlags = make(map[string]bool)
+
+	// Flags of the command being run that were set from the environment
+	envFlags = make(map[string]bool)
+
+	// Units of the values set from the environment (GORCB_UNITS) and from
+	// the configuration files (a plain units value), SI when not set
+	envUnitsName, fileUnitsName string
+)
+
+// mutuallyExclusiveAnnotation is the flag annotation cobra sets in
+// MarkFlagsMutuallyExclusive
+const mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+
+// applyConfig sets the flags of the command that were not given on the
+// command line to the values of the GORCB_* environment variables, then of
+// the configuration files
+func applyConfig(cmd *cobra.Command) error {
+	env := config.Env(os.Environ())
+	file := configFile
+	if file == "" {
+		file = env["config"]
+	}
+	paths, required := config.Paths(), false
+	if file != "" {
+		paths, required = []string{file}, true
+	}
+	cfg, err := config.Load(paths, required)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	collectFlagNames(cmd.Root(), known)
+	for _, key := range config.EnvKeys(env) {
+		f := cmd.Flags().Lookup(key)
+		if key == "config" || f == nil || f.Changed || exclusiveFlagChanged(cmd.Flags(), f) {
+			continue
+		}
+		if err := f.Value.Set(env[key]); err != nil {
+			return fmt.Errorf("setting %s: %w", config.EnvName(key), err)
+		}
+		configuredFlags[key] = true
+		envFlags[key] = true
+	}
+	for _, key := range cfg.Keys() {
+		if !known[key] || key == "config" || key == "help" {
+			return fmt.Errorf("unknown setting %q in %s", key, strings.Join(cfg.Files, ", "))
+		}
+		f := cmd.Flags().Lookup(key)
+		if f == nil || f.Changed || envFlags[key] || exclusiveFlagChanged(cmd.Flags(), f) {
+			continue
+		}
+		if err := f.Value.Set(cfg.Defaults[key]); err != nil {
+			return fmt.Errorf("setting %q in %s: %w", key, strings.Join(cfg.Files, ", "), err)
+		}
+		configuredFlags[key] = true
+	}
+
+	envUnitsName, fileUnitsName = env["units"], cfg.Defaults["units"]
+	section.DefaultUnits = cfg.Units
+	configuredPlugins = cfg.Plugins
+	if len(cfg.SkippedPlugins) > 0 && !noPlugins {
+		fmt.Fprintf(os.Stderr, "Warning: plugins of ./%s are not run (%s); register them in ~/%s or give the file with --config\n",
+			config.ProjectFile, strings.Join(cfg.SkippedPlugins, ", "), config.HomeFile)
+	}
+	return nil
+}
+
+// collectFlagNames adds the names of the flags of a command and its
+// subcommands to names
+func collectFlagNames(cmd *cobra.Command, names map[string]bool) {
+	add := func(f *pflag.Flag) { names[f.Name] = true }
+	cmd.Flags().VisitAll(add)
+	cmd.PersistentFlags().VisitAll(add)
+	for _, sub := range cmd.Commands() {
+		collectFlagNames(sub, names)
+	}
+}
+
+// exclusiveFlagChanged reports whether a flag mutually exclusive with f was
+// given on the command line or in the environment, e.g. --fy when the
+// configuration sets grade
+func exclusiveFlagChanged(flags *pflag.FlagSet, f *pflag.Flag) bool {
+	for _, group := range f.Annotations[mutuallyExclusiveAnnotation] {
+		for _, name := range strings.Fields(group) {
+			if other := flags.Lookup(name); other != nil && other != f && (other.Changed || envFlags[name]) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert section files between formats and units",
+	Long: `Convert section files between JSON and YAML and between units, or write
+the section file of a rectangular beam given by the beam command flags, so
+that it can be analyzed with the section commands.
+
+Subcommands:
+  section  - Convert a section file to JSON or YAML in other units
+  beam     - Write the section file of a rectangular beam
+
+Section files have a single schema, so there is no schema version to
+convert between.`,
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+}
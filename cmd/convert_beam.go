@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertBeamWidth     float64
+	convertBeamHeight    float64
+	convertBeamCover     float64
+	convertBeamCoverComp float64
+	convertBeamAs        float64
+	convertBeamAsc       float64
+	convertBeamFc        float64
+	convertBeamFy        float64
+	convertBeamGrade     gradeInput
+	convertBeamConcrete  string
+	convertBeamName      string
+	convertBeamOutput    string
+	convertBeamTo        string
+	convertBeamForce     bool
+)
+
+var convertBeamCmd = &cobra.Command{
+	Use:   "beam",
+	Short: "Write the section file of a rectangular beam",
+	Long: `Write the section file of a rectangular beam given with the flags of the
+beam commands, so that the polygon engine of the section commands can check
+it or take it as the starting point of a non-rectangular section.
+
+The tension steel is placed at --cover from the bottom and the compression
+steel, when --asc is given, at --cover-comp from the top. The file is in mm
+and MPa, or in inches and ksi with --units us.
+
+Examples:
+  gorcb convert beam -b 300 --height 500 --as 942 -o b1.json
+  gorcb convert beam -b 300 --height 500 --as 1473 --asc 402 --fc 28 --grade 60 --to yaml
+  gorcb convert beam -b 300 --height 500 --as 942 | gorcb section analyze -f -`,
+	Run: runConvertBeam,
+}
+
+func init() {
+	convertCmd.AddCommand(convertBeamCmd)
+	unitAware(convertBeamCmd)
+
+	convertBeamCmd.Flags().Float64VarP(&convertBeamWidth, "width", "b", 0, "Beam width (mm) [required]")
+	convertBeamCmd.Flags().Float64Var(&convertBeamHeight, "height", 0, "Beam total depth (mm) [required]")
+	convertBeamCmd.Flags().Float64VarP(&convertBeamCover, "cover", "c", 65, "Effective cover to tension steel centroid (mm)")
+	convertBeamCmd.Flags().Float64VarP(&convertBeamCoverComp, "cover-comp", "d", 65, "Cover to compression steel centroid d' (mm)")
+	convertBeamCmd.Flags().Float64VarP(&convertBeamAs, "as", "a", 0, "Tension reinforcement area As (mm²) [required]")
+	convertBeamCmd.Flags().Float64Var(&convertBeamAsc, "asc", 0, "Compression reinforcement area A'sc (mm²)")
+
+	convertBeamCmd.Flags().Float64Var(&convertBeamFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
+	convertBeamCmd.Flags().Float64Var(&convertBeamFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(convertBeamCmd, &convertBeamGrade)
+	convertBeamCmd.Flags().StringVar(&convertBeamConcrete, "concrete-type", "", concreteTypeUsage)
+
+	convertBeamCmd.Flags().StringVar(&convertBeamName, "name", "", "Name of the section (default: from the dimensions)")
+	convertBeamCmd.Flags().StringVarP(&convertBeamOutput, "output", "o", "", "Section file to write (default: print to stdout)")
+	convertBeamCmd.Flags().StringVar(&convertBeamTo, "to", "", "Output format: json or yaml (default: from the --output extension, else json)")
+	convertBeamCmd.Flags().BoolVar(&convertBeamForce, "force", false, "Overwrite an existing section file")
+
+	convertBeamCmd.MarkFlagRequired("width")
+	convertBeamCmd.MarkFlagRequired("height")
+	convertBeamCmd.MarkFlagRequired("as")
+}
+
+func runConvertBeam(cmd *cobra.Command, args []string) {
+	if err := convertBeamGrade.resolve(&convertBeamFy); err != nil {
+		printError(err)
+		return
+	}
+	format, err := sectionFileFormat(convertBeamTo, convertBeamOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if convertBeamCover <= 0 || convertBeamCover >= convertBeamHeight {
+		printError(fmt.Errorf("--cover must be between 0 and the beam height"))
+		return
+	}
+	if convertBeamAsc > 0 && (convertBeamCoverComp <= 0 || convertBeamCoverComp >= convertBeamHeight) {
+		printError(fmt.Errorf("--cover-comp must be between 0 and the beam height"))
+		return
+	}
+
+	b, h := convertBeamWidth, convertBeamHeight
+	sec := &section.Section{
+		Name:     convertBeamName,
+		Fc:       convertBeamFc,
+		Fy:       convertBeamFy,
+		Vertices: []section.Point{{X: 0, Y: 0}, {X: b, Y: 0}, {X: b, Y: h}, {X: 0, Y: h}},
+		Reinforcement: []section.RebarLayer{
+			{Y: convertBeamCover, Area: convertBeamAs, Description: "Tension steel", Type: "tension"},
+		},
+	}
+	if sec.Name == "" {
+		sec.Name = fmt.Sprintf("Rectangular Beam %s x %s", fmtLength(b, 0), fmtLength(h, 0))
+	}
+	if convertBeamAsc > 0 {
+		sec.Reinforcement = append(sec.Reinforcement, section.RebarLayer{
+			Y: h - convertBeamCoverComp, Area: convertBeamAsc, Description: "Compression steel", Type: "compression",
+		})
+	}
+	if convertBeamConcrete != "" {
+		lambda, err := concreteLambda(convertBeamConcrete)
+		if err != nil {
+			printError(err)
+			return
+		}
+		if lambda < 1 {
+			sec.Lambda = lambda
+		}
+	}
+	if err := sec.Validate(); err != nil {
+		printError(err)
+		return
+	}
+
+	if !selectedUnits.IsSI() {
+		if err := sec.ConvertUnits(section.Units{Length: "in", Stress: "ksi"}); err != nil {
+			printError(err)
+			return
+		}
+	}
+	writeConvertedSection(sec, format, convertBeamOutput, convertBeamForce)
+}
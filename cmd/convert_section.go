@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertSectionFile   string
+	convertSectionOutput string
+	convertSectionTo     string
+	convertSectionLength string
+	convertSectionStress string
+	convertSectionForce  bool
+)
+
+var convertSectionCmd = &cobra.Command{
+	Use:   "section",
+	Short: "Convert a section file to JSON or YAML in other units",
+	Long: `Read a section file and write it as JSON or YAML, converting its values
+to the units given with --length and --stress. Values keep the units of the
+input file unless converted; converted values are rounded to 6 significant
+digits.
+
+The output format follows the extension of --output (.json, .yaml or .yml),
+or --to when printing to stdout.
+
+Examples:
+  gorcb convert section -f t-beam.json -o t-beam.yaml
+  gorcb convert section -f t-beam.json --length in --stress psi -o t-beam-us.json
+  gorcb convert section -f t-beam-us.yaml --length mm --stress MPa --to json`,
+	Run: runConvertSection,
+}
+
+func init() {
+	convertCmd.AddCommand(convertSectionCmd)
+
+	convertSectionCmd.Flags().StringVarP(&convertSectionFile, "file", "f", "", "Path to section JSON or YAML file, or - for stdin [required]")
+	convertSectionCmd.Flags().StringVarP(&convertSectionOutput, "output", "o", "", "Section file to write (default: print to stdout)")
+	convertSectionCmd.Flags().StringVar(&convertSectionTo, "to", "", "Output format: json or yaml (default: from the --output extension, else json)")
+	convertSectionCmd.Flags().StringVar(&convertSectionLength, "length", "", "Length unit of the output: mm, cm, m, in or ft (default: unit of the input)")
+	convertSectionCmd.Flags().StringVar(&convertSectionStress, "stress", "", "Stress unit of the output: MPa, psi or ksi (default: unit of the input)")
+	convertSectionCmd.Flags().BoolVar(&convertSectionForce, "force", false, "Overwrite an existing section file")
+
+	convertSectionCmd.MarkFlagRequired("file")
+}
+
+func runConvertSection(cmd *cobra.Command, args []string) {
+	format, err := sectionFileFormat(convertSectionTo, convertSectionOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	sec, err := section.ReadFile(convertSectionFile)
+	if err != nil {
+		fmt.Printf("Error loading section file: %v\n", err)
+		setExit(exitInvalidInput)
+		return
+	}
+	var target section.Units
+	if sec.Units != nil {
+		target = *sec.Units
+	} else if section.DefaultUnits != nil {
+		target = *section.DefaultUnits
+	}
+	if convertSectionLength != "" {
+		target.Length = convertSectionLength
+	}
+	if convertSectionStress != "" {
+		target.Stress = convertSectionStress
+	}
+
+	if err := sec.Prepare(); err != nil {
+		fmt.Printf("Error loading section file: %v\n", err)
+		setExit(exitInvalidInput)
+		return
+	}
+	if err := sec.ConvertUnits(target); err != nil {
+		printError(err)
+		return
+	}
+	writeConvertedSection(sec, format, convertSectionOutput, convertSectionForce)
+}
+
+// sectionFileFormat returns the format of a converted section file: to when
+// given, otherwise the one of the extension of output, JSON by default
+func sectionFileFormat(to, output string) (string, error) {
+	switch to {
+	case "":
+		if output != "" && section.IsYAML(output) {
+			return formatYAML, nil
+		}
+		return formatJSON, nil
+	case formatJSON, formatYAML:
+		return to, nil
+	}
+	return "", fmt.Errorf("unknown section file format %q (use %s or %s)", to, formatJSON, formatYAML)
+}
+
+// writeConvertedSection prints a converted section file, or writes it to
+// output when given
+func writeConvertedSection(sec *section.Section, format, output string, force bool) {
+	data, err := encodeSection(sec, format)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	writeSectionFile(output, data, force)
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	costSectionFile string
+	costProjectFile string
+
+	// Rectangular member
+	costWidth  float64
+	costHeight float64
+	costAs     float64
+	costAsc    float64
+	costBars   []string
+
+	// Stirrups
+	costStirrupDia float64
+	costSpacing    float64
+
+	costCosts costInputs
+)
+
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Estimate quantities and cost of a designed member or project",
+	Long: `Estimate the concrete volume, steel weight, formwork area and cost of a
+completed design from unit prices: a rectangular member given by its size,
+bars and stirrups, a member of a section file, or every member of a project.
+
+Rectangular members take the longitudinal steel as --as and --asc or as
+groups of bars of the rebar catalog with --bars. Sections take the steel
+of their reinforcement layers, and stirrups follow the stirrup centerline of
+the section. Projects design their beams as in 'gorcb project run', with
+the stirrups of the shear design, over the length of each member or
+--length; sections give their stirrups as stirrup_dia and stirrup_spacing.
+
+Formwork covers the soffit and sides of a member, and the voids of a
+section; the top face is taken as open or cast against a slab. Stirrups are
+closed hoops with 135° hooks.
+
+Examples:
+  gorcb cost -b 300 --height 500 --bars 4-20,2-16 --spacing 150 --length 6
+  gorcb cost -f t-beam.json --stirrup-dia 10 --spacing 200 --length 7.5
+  gorcb cost -p project.yaml --concrete-price 7000 --steel-price 80`,
+	Run: runCost,
+}
+
+func init() {
+	rootCmd.AddCommand(costCmd)
+	tabular(costCmd)
+
+	costCmd.Flags().StringVarP(&costSectionFile, "file", "f", "", "Section JSON or YAML file of the member, or - for stdin")
+	costCmd.Flags().StringVarP(&costProjectFile, "project", "p", "", "Project JSON or YAML file to estimate every member of")
+
+	costCmd.Flags().Float64VarP(&costWidth, "width", "b", 0, "Beam width (mm)")
+	costCmd.Flags().Float64Var(&costHeight, "height", 0, "Beam total depth (mm)")
+	costCmd.Flags().Float64Var(&costAs, "as", 0, "Tension reinforcement area As (mm²)")
+	costCmd.Flags().Float64Var(&costAsc, "asc", 0, "Compression reinforcement area A'sc (mm²)")
+	costCmd.Flags().StringSliceVar(&costBars, "bars", nil, "Longitudinal bars as count-bar groups, e.g. 4-20,2-16")
+
+	costCmd.Flags().Float64Var(&costStirrupDia, "stirrup-dia", beam.DefaultStirrupDiameter, "Stirrup bar diameter (mm)")
+	costCmd.Flags().Float64Var(&costSpacing, "spacing", 0, "Stirrup spacing (mm), no stirrups when zero")
+	addCostFlags(costCmd, &costCosts)
+
+	costCmd.MarkFlagsMutuallyExclusive("file", "project", "width")
+	costCmd.MarkFlagsMutuallyExclusive("project", "spacing")
+	costCmd.MarkFlagsRequiredTogether("width", "height")
+	costCmd.MarkFlagsOneRequired("file", "project", "width")
+}
+
+func runCost(cmd *cobra.Command, args []string) {
+	if err := costCosts.validate(); err != nil {
+		printError(err)
+		return
+	}
+	if costStirrupDia <= 0 || costSpacing < 0 {
+		printError(fmt.Errorf("stirrup diameter must be positive and spacing must not be negative"))
+		return
+	}
+	if costProjectFile != "" {
+		runProjectCost(cmd)
+		return
+	}
+
+	var label string
+	var q cost.Quantities
+	if costSectionFile != "" {
+		sec, err := section.LoadFromFile(costSectionFile)
+		if err != nil {
+			fmt.Printf("Error loading section file: %v\n", err)
+			setExit(exitInvalidInput)
+			return
+		}
+		label = sec.Name
+		q = project.SectionQuantities(sec, costCosts.Length, costStirrupDia, costSpacing, costCosts.Costs)
+	} else {
+		steel, err := costSteelArea()
+		if err != nil {
+			printError(err)
+			return
+		}
+		label = fmt.Sprintf("%.0f × %.0f mm", costWidth, costHeight)
+		var barArea, barLength float64
+		if costSpacing > 0 {
+			barArea = math.Pi * costStirrupDia * costStirrupDia / 4
+			barLength = cost.StirrupLength(costWidth, costHeight, beam.DefaultClearCover, costStirrupDia)
+		}
+		q = cost.RectangularBeam(costWidth, costHeight, costCosts.Length, steel, barArea, barLength, costSpacing, costCosts.Costs)
+	}
+
+	if tabularOutput() {
+		printTable(costHeader, [][]string{costRow(label, "", q)})
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, costInputMap(), q)
+		return
+	}
+
+	printCostHeader()
+	fmt.Println("MEMBER:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Section:\t%s\n", label)
+	fmt.Fprintf(w, "  Length:\t%.2f m\n", q.Length)
+	if costSpacing > 0 {
+		fmt.Fprintf(w, "  Stirrups:\tφ%.0fmm @ %.0f mm\n", costStirrupDia, costSpacing)
+	}
+	printUnitCosts(w)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("QUANTITIES AND COST:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	printQuantities(q)
+}
+
+// costSteelArea returns the longitudinal steel area of a rectangular member
+// from --as, --asc and --bars
+func costSteelArea() (float64, error) {
+	if costWidth <= 0 || costHeight <= 0 {
+		return 0, fmt.Errorf("width and height must be positive")
+	}
+	if costAs < 0 || costAsc < 0 {
+		return 0, fmt.Errorf("steel areas must not be negative")
+	}
+	groups, err := parseGroups(selectedCatalog, costBars)
+	if err != nil {
+		return 0, err
+	}
+	steel := costAs + costAsc
+	for _, g := range groups {
+		steel += g.Area()
+	}
+	if steel <= 0 {
+		return 0, fmt.Errorf("give the longitudinal steel with --as or --bars")
+	}
+	return steel, nil
+}
+
+func runProjectCost(cmd *cobra.Command) {
+	p, result, err := loadProject(costProjectFile, false)
+	if err != nil {
+		printError(err)
+		return
+	}
+	members, total := p.Quantities(result, costCosts.Length, costCosts.Costs)
+	for _, m := range members {
+		if m.Error != "" {
+			setExit(exitInvalidInput)
+		}
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, m := range members {
+			rows = append(rows, costRow(m.ID, m.Kind, m.Quantities))
+		}
+		rows = append(rows, costRow("Total", "", total))
+		printTable(costHeader, rows)
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, costInputMap(), map[string]any{"members": members, "total": total})
+		return
+	}
+
+	printCostHeader()
+	fmt.Printf("  Project: %s\n", p.Name)
+	w := newTextWriter()
+	printUnitCosts(w)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("MEMBERS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintln(w, "  Member\tLength (m)\tConcrete (m³)\tSteel (kg)\tFormwork (m²)\tCost")
+	fmt.Fprintln(w, "  ──────\t──────────\t─────────────\t──────────\t─────────────\t────")
+	for _, m := range members {
+		if m.Error != "" {
+			fmt.Fprintf(w, "  %s\tERROR: %s\t\t\t\t\n", m.ID, m.Error)
+			continue
+		}
+		fmt.Fprintf(w, "  %s\t%.2f\t%.3f\t%.2f\t%.2f\t%.2f\n", m.ID, m.Length, m.Concrete, m.Steel, m.Formwork, m.Total)
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("PROJECT TOTAL:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	printQuantities(total)
+}
+
+// costHeader is the header of the tabular output of cost
+var costHeader = []string{"Member", "Kind", "Length (m)", "Concrete (m³)", "Steel (kg)", "Formwork (m²)",
+	"Concrete Cost", "Steel Cost", "Formwork Cost", "Total Cost"}
+
+// costRow returns a row of the tabular output of cost
+func costRow(id, kind string, q cost.Quantities) []string {
+	return []string{id, kind, cell(q.Length), cell(q.Concrete), cell(q.Steel), cell(q.Formwork),
+		cell(q.ConcreteCost), cell(q.SteelCost), cell(q.FormworkCost), cell(q.Total)}
+}
+
+// costInputMap returns the inputs of cost for structured output
+func costInputMap() map[string]any {
+	return map[string]any{"length": costCosts.Length, "unit_costs": costCosts.Costs}
+}
+
+func printCostHeader() {
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     QUANTITY AND COST ESTIMATE")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+}
+
+// printUnitCosts prints the unit prices of the estimate
+func printUnitCosts(w io.Writer) {
+	c := costCosts.Costs
+	fmt.Fprintf(w, "  Unit costs:\tconcrete %.2f/m³, steel %.2f/kg, formwork %.2f/m²\n", c.Concrete, c.Steel, c.Formwork)
+}
+
+// printQuantities prints the quantities and cost breakdown of an estimate
+func printQuantities(q cost.Quantities) {
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Concrete:\t%.3f m³\t%.2f\n", q.Concrete, q.ConcreteCost)
+	fmt.Fprintf(w, "  Steel:\t%.2f kg (%.3f t)\t%.2f\n", q.Steel, q.Steel/1000, q.SteelCost)
+	fmt.Fprintf(w, "  Formwork:\t%.2f m²\t%.2f\n", q.Formwork, q.FormworkCost)
+	fmt.Fprintf(w, "  Total:\t\t%.2f\n", q.Total)
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/spf13/cobra"
+)
+
+// costInputs holds the unit costs and member length of quantity estimates
+type costInputs struct {
+	Costs  cost.UnitCosts
+	Length float64
+}
+
+// addCostFlags registers the unit cost and member length flags
+func addCostFlags(cmd *cobra.Command, in *costInputs) {
+	cmd.Flags().Float64Var(&in.Costs.Concrete, "concrete-price", cost.DefaultConcretePrice, "Unit cost of concrete (per m³)")
+	cmd.Flags().Float64Var(&in.Costs.Steel, "steel-price", cost.DefaultSteelPrice, "Unit cost of reinforcing steel (per kg)")
+	cmd.Flags().Float64Var(&in.Costs.Formwork, "formwork-price", cost.DefaultFormworkPrice, "Unit cost of formwork (per m² of contact area)")
+	cmd.Flags().Float64Var(&in.Length, "length", 1, "Member length for quantities and cost (m)")
+}
+
+// validate checks the unit costs and length before any output is printed
+func (in costInputs) validate() error {
+	if in.Length <= 0 {
+		return fmt.Errorf("member length must be positive, got %.2f", in.Length)
+	}
+	return in.Costs.Validate()
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+// coverInputs holds the exposure and bar sizes used to verify the concrete cover
+type coverInputs struct {
+	Exposure   string
+	Member     string
+	BarDia     float64
+	StirrupDia float64
+}
+
+// coverFace is an effective cover (face to bar centroid) to be verified
+type coverFace struct {
+	Label string
+	Cover float64
+}
+
+// addCoverFlags registers the exposure and bar size flags on a beam command
+func addCoverFlags(cmd *cobra.Command, in *coverInputs) {
+	cmd.Flags().StringVar(&in.Exposure, "exposure", "", "Exposure for the cover check: interior, exterior, soil, cast-against-earth, marine")
+	cmd.Flags().StringVar(&in.Member, "member", string(nscp.MemberBeam), "Member type for the cover check: beam, column, slab, wall, joist")
+	cmd.Flags().Float64Var(&in.BarDia, "bar-dia", 20, "Main bar diameter for the cover check and bar layouts (mm)")
+	cmd.Flags().Float64Var(&in.StirrupDia, "stirrup-dia", 10, "Stirrup diameter for the cover check and bar layouts (mm)")
+}
+
+// validate checks the exposure and member names before any output is printed
+func (in coverInputs) validate() error {
+	if in.Exposure == "" {
+		return nil
+	}
+	if _, err := nscp.ParseExposure(in.Exposure); err != nil {
+		return err
+	}
+	if _, err := nscp.ParseMemberType(in.Member); err != nil {
+		return err
+	}
+	if in.BarDia <= 0 || in.StirrupDia < 0 {
+		return fmt.Errorf("invalid bar sizes for the cover check: bar=%.1f, stirrup=%.1f", in.BarDia, in.StirrupDia)
+	}
+	return nil
+}
+
+// clearCover returns the clear cover to the stirrups of a face with an
+// effective cover to the bar centroid, from the bar and stirrup sizes
+func (in coverInputs) clearCover(cover float64) float64 {
+	return cover - in.StirrupDia - in.BarDia/2
+}
+
+// printCoverCheck verifies the clear cover of each face against the minimum
+// cover of the exposure class. It prints nothing when no exposure was given.
+func printCoverCheck(in coverInputs, faces ...coverFace) {
+	if in.Exposure == "" {
+		return
+	}
+	exposure, _ := nscp.ParseExposure(in.Exposure)
+	member, _ := nscp.ParseMemberType(in.Member)
+	required := nscp.MinimumCover(exposure, member, in.BarDia)
+
+	fmt.Println("COVER CHECK (NSCP 2015 Section 420.6.1):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Exposure:\t%s\n", exposure)
+	fmt.Fprintf(w, "  Member:\t%s\n", member)
+	fmt.Fprintf(w, "  Required clear cover:\t%s\n", fmtLength(required, 0))
+
+	var warnings []string
+	for _, f := range faces {
+		// Clear cover to the outermost reinforcement (stirrups)
+		clear := f.Cover - in.BarDia/2 - in.StirrupDia
+		ok := clear >= required
+		fmt.Fprintf(w, "  %s clear cover:\t%s\t%s\n", f.Label, fmtLength(clear, 0), checkMark(ok))
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s clear cover %s is less than the %s required for %s exposure",
+				f.Label, fmtLength(clear, 0), fmtLength(required, 0), exposure))
+		}
+	}
+	w.Flush()
+	for _, msg := range warnings {
+		fmt.Printf("  ⚠ WARNING: %s\n", i18n.T(msg))
+		slog.Warn(msg)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/alexiusacademia/gorcb/examples"
+	"github.com/alexiusacademia/gorcb/internal/check"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/alexiusacademia/gorcb/internal/schedule"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	examplesOutput string
+	examplesForce  bool
+	examplesVerify bool
+)
+
+// examplesTolerance is the relative difference from an expected result that
+// still verifies
+const examplesTolerance = 0.001
+
+var examplesCmd = &cobra.Command{
+	Use:   "examples [name...]",
+	Short: "List, write out and verify the bundled example inputs",
+	Long: `List the example inputs bundled with gorcb: section files of a rectangular
+beam, a T-beam, an L-beam, a hollow box girder and a doubly reinforced beam,
+a detailed design for 'gorcb check', batch and project files, and supporting
+files for --combinations, --code-overrides, --bar-catalog and --config.
+
+With --output the named examples (all when none are named) are written to a
+directory with the command that runs each one and the results it should
+give. --verify runs the case studies with NSCP 2015 and the PNS bars and
+compares their results with the expected ones, to sanity-check an install;
+it exits with code 3 when a result differs by more than 0.1%. The project is
+both checked and scheduled, as by 'gorcb project check' and 'gorcb schedule'.
+
+Examples:
+  gorcb examples
+  gorcb examples -o examples
+  gorcb examples t-beam box-girder -o sections
+  gorcb examples --verify`,
+	Run: runExamples,
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+	unrecorded(examplesCmd)
+	tabular(examplesCmd)
+
+	examplesCmd.Flags().StringVarP(&examplesOutput, "output", "o", "", "Directory to write the example files to")
+	examplesCmd.Flags().BoolVar(&examplesForce, "force", false, "Overwrite existing example files")
+	examplesCmd.Flags().BoolVar(&examplesVerify, "verify", false, "Run the case studies and compare with the expected results")
+}
+
+func runExamples(cmd *cobra.Command, args []string) {
+	selected := examples.All
+	if len(args) > 0 {
+		selected = nil
+		for _, name := range args {
+			e, err := examples.Find(name)
+			if err != nil {
+				printError(err)
+				return
+			}
+			selected = append(selected, e)
+		}
+	}
+
+	switch {
+	case examplesVerify:
+		verifyExamples(cmd, selected)
+	case examplesOutput != "":
+		writeExamples(selected)
+	default:
+		listExamples(cmd, selected)
+	}
+}
+
+// listExamples prints the examples
+func listExamples(cmd *cobra.Command, list []examples.Example) {
+	if tabularOutput() {
+		var rows [][]string
+		for _, e := range list {
+			rows = append(rows, []string{e.Name, e.Kind, e.File, e.Description, e.Command})
+		}
+		printTable([]string{"Name", "Kind", "File", "Description", "Command"}, rows)
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, nil, list)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     BUNDLED EXAMPLES")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Name\tKind\tFile\tDescription")
+	fmt.Fprintln(w, "  ────\t────\t────\t───────────")
+	for _, e := range list {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", e.Name, e.Kind, e.File, e.Description)
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Println("  Write them with: gorcb examples -o <dir> [name...]")
+	fmt.Println("  Check the install with: gorcb examples --verify")
+	fmt.Println()
+}
+
+// writeExamples writes the files of the examples to --output and prints how
+// to run each one
+func writeExamples(list []examples.Example) {
+	if err := os.MkdirAll(examplesOutput, 0755); err != nil {
+		printError(err)
+		return
+	}
+	for _, e := range list {
+		path := filepath.Join(examplesOutput, e.File)
+		if _, err := os.Stat(path); err == nil && !examplesForce {
+			fmt.Printf("Error: %s already exists (use --force to overwrite)\n", path)
+			setExit(exitInvalidInput)
+			continue
+		}
+		data, err := e.Data()
+		if err == nil {
+			err = os.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			fmt.Printf("Error writing %s: %v\n", path, err)
+			setExit(exitFailure)
+			continue
+		}
+		fmt.Printf("%s\n  run: %s\n", path, e.Command)
+		if len(e.Expected) > 0 {
+			fmt.Printf("  expected (NSCP 2015): %s\n", expectedLabel(e.Expected))
+		}
+	}
+}
+
+// expectedLabel prints expected results in key order, e.g.
+// "c = 86.37, epsilon_t = 0.012109, phi_mn = 186.88"
+func expectedLabel(expected map[string]float64) string {
+	label := ""
+	for i, k := range slices.Sorted(maps.Keys(expected)) {
+		if i > 0 {
+			label += ", "
+		}
+		label += fmt.Sprintf("%s = %g", k, expected[k])
+	}
+	return label
+}
+
+// exampleResult is an expected result of an example compared with the
+// result of this install
+type exampleResult struct {
+	Example  string  `json:"example"`
+	Result   string  `json:"result"`
+	Expected float64 `json:"expected"`
+	Actual   float64 `json:"actual"`
+	OK       bool    `json:"ok"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// verifyExamples runs the examples with expected results and compares them
+func verifyExamples(cmd *cobra.Command, list []examples.Example) {
+	var results []exampleResult
+	for _, e := range list {
+		if len(e.Expected) == 0 {
+			continue
+		}
+		actual, err := runExample(e)
+		for _, k := range slices.Sorted(maps.Keys(e.Expected)) {
+			r := exampleResult{Example: e.Name, Result: k, Expected: e.Expected[k]}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Actual = actual[k]
+				r.OK = math.Abs(r.Actual-r.Expected) <= examplesTolerance*math.Max(math.Abs(r.Expected), 1e-3)
+			}
+			results = append(results, r)
+		}
+	}
+
+	passed := 0
+	for _, r := range results {
+		if r.OK {
+			passed++
+		}
+	}
+	checkAdequacy(passed == len(results))
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, r := range results {
+			rows = append(rows, []string{r.Example, r.Result, cell(r.Expected), cell(r.Actual), quietStatus(r.OK)})
+		}
+		printTable([]string{"Example", "Result", "Expected", "Actual", "Status"}, rows)
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, nil, results)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     EXAMPLE VERIFICATION - NSCP 2015")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Example\tResult\tExpected\tActual\tStatus")
+	fmt.Fprintln(w, "  ───────\t──────\t────────\t──────\t──────")
+	for _, r := range results {
+		actual := fmt.Sprintf("%.6g", r.Actual)
+		if r.Error != "" {
+			actual = r.Error
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%g\t%s\t%s\n", r.Example, r.Result, r.Expected, actual, quietStatus(r.OK))
+	}
+	w.Flush()
+	fmt.Println()
+	if passed == len(results) {
+		fmt.Printf("  ✓ All %d results match\n", len(results))
+	} else {
+		fmt.Printf("  ✗ %d of %d results differ from the expected ones\n", len(results)-passed, len(results))
+	}
+	fmt.Println()
+}
+
+// runExample runs an example as its command would with the default flags,
+// from a copy of its file, and returns its results by key
+func runExample(e examples.Example) (map[string]float64, error) {
+	data, err := e.Data()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "gorcb-example-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, e.File)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+
+	code, _ := codes.Get(codes.DefaultName)
+	switch e.Kind {
+	case examples.KindSection:
+		// The example files are in mm and MPa whatever the configuration assumes
+		defaults := section.DefaultUnits
+		section.DefaultUnits = nil
+		defer func() { section.DefaultUnits = defaults }()
+
+		sec, err := section.LoadFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sec.Code = code
+		r, err := sec.Analyze()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{examples.ResultPhiMn: r.PhiMn, examples.ResultC: r.C, examples.ResultEpsilonT: r.EpsilonT}, nil
+	case examples.KindCheck:
+		d, err := check.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r, err := check.Run(d, code, rebar.Default())
+		if err != nil {
+			return nil, err
+		}
+		_, passed := r.Counts()
+		return map[string]float64{examples.ResultPhiMn: r.PhiMn, examples.ResultMu: r.Mu, examples.ResultChecksOK: float64(passed)}, nil
+	case examples.KindProject:
+		defaults := section.DefaultUnits
+		section.DefaultUnits = nil
+		defer func() { section.DefaultUnits = defaults }()
+
+		p, err := project.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		// The section files of the project are bundled examples too
+		for _, s := range p.Sections {
+			se, err := examples.Find(s.File)
+			if err != nil {
+				return nil, err
+			}
+			data, err := se.Data()
+			if err == nil {
+				err = os.WriteFile(p.SectionPath(s), data, 0644)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, adequate := project.Run(p, code, true).Counts()
+		// The beams are scheduled as by gorcb schedule, from the project run
+		scheduled := 0
+		for i, r := range project.Run(p, code, false).Beams {
+			if _, err := schedule.Beam(r.Result, p.Beams[i].Length, rebar.Default(), code); err == nil {
+				scheduled++
+			}
+		}
+		return map[string]float64{examples.ResultAdequate: float64(adequate), examples.ResultScheduled: float64(scheduled)}, nil
+	}
+	return nil, fmt.Errorf("%s examples are not verified", e.Kind)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// Exit codes of gorcb, for scripts and CI pipelines that branch on the result
+const (
+	exitOK            = 0
+	exitFailure       = 1 // Writing a diagram, report or output failed
+	exitInvalidInput  = 2 // Invalid flags, files or input values
+	exitInadequate    = 3 // A design or check is not adequate
+	exitNoConvergence = 4 // An iterative solver did not converge
+)
+
+// Exit code of the command being run
+var exitCode = exitOK
+
+// setExit records the exit code of an outcome. Errors take precedence over
+// inadequate designs, and the first error is kept.
+func setExit(code int) {
+	if exitCode == exitOK || exitCode == exitInadequate {
+		exitCode = code
+	}
+}
+
+// exitFor returns the exit code for an error of the design engines
+func exitFor(err error) int {
+	if errors.Is(err, beam.ErrNoConvergence) || errors.Is(err, section.ErrNoConvergence) {
+		return exitNoConvergence
+	}
+	return exitInvalidInput
+}
+
+// printError prints an error of the command and sets the exit code for its cause
+func printError(err error) {
+	fmt.Printf("Error: %v\n", err)
+	slog.Error(err.Error())
+	setExit(exitFor(err))
+}
+
+// checkAdequacy sets the exit code of an inadequate design or check
+func checkAdequacy(adequate bool) {
+	if !adequate {
+		setExit(exitInadequate)
+	}
+}
+
+// checkStatus sets the exit code for the OK, NG or ERROR status of a batch or
+// project member
+func checkStatus(status string, noConvergence bool) {
+	switch {
+	case status == "ERROR" && noConvergence:
+		setExit(exitNoConvergence)
+	case status == "ERROR":
+		setExit(exitInvalidInput)
+	case status != "OK":
+		setExit(exitInadequate)
+	}
+}
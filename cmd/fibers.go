@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// fiberField returns the quantity a fiber diagram is colored by from its
+// name, stress or strain
+func fiberField(name string) (diagram.FiberField, error) {
+	switch name {
+	case "stress":
+		return diagram.FiberStress, nil
+	case "strain":
+		return diagram.FiberStrain, nil
+	}
+	return 0, fmt.Errorf("unknown fiber field %q (use stress or strain)", name)
+}
+
+// fiberPoint returns the point of a confined moment-curvature response
+// nearest to a curvature (1/m), or the ultimate point when it is zero
+func fiberPoint(r *section.ConfinedResult, curvature float64) (section.MomentCurvaturePoint, error) {
+	if len(r.Points) == 0 {
+		return section.MomentCurvaturePoint{}, fmt.Errorf("the confined analysis has no moment-curvature points")
+	}
+	if curvature <= 0 {
+		return r.Points[len(r.Points)-1], nil
+	}
+	nearest := r.Points[0]
+	for _, p := range r.Points {
+		if math.Abs(p.Curvature-curvature) < math.Abs(nearest.Curvature-curvature) {
+			nearest = p
+		}
+	}
+	return nearest, nil
+}
+
+// fiberDiagramData returns the plot of the fibers of a section colored by
+// their stress or strain at a point of its confined analysis
+func fiberDiagramData(sec *section.Section, result *section.AnalysisResult, state *section.FiberState, field diagram.FiberField) diagram.FiberDiagramData {
+	data := diagram.FiberDiagramData{
+		Name:      sec.Name,
+		Section:   sectionAnalysisDiagramData(sec, result),
+		Field:     field,
+		TopStrain: state.TopStrain,
+		Curvature: state.Curvature,
+		Moment:    state.Moment,
+	}
+	data.Section.NeutralAxisDepth = state.C
+	data.Section.StressBlockDepth = 0
+	for _, v := range state.Core {
+		data.Core = append(data.Core, diagram.Point{X: v.X, Y: v.Y})
+	}
+	for _, s := range state.Strips {
+		strip := diagram.FiberStrip{Y: s.Y, Height: s.Height, Strain: s.Strain}
+		for _, seg := range s.Segments {
+			strip.Cells = append(strip.Cells, diagram.FiberCell{XMin: seg.XMin, XMax: seg.XMax, Stress: seg.Stress})
+		}
+		data.Strips = append(data.Strips, strip)
+	}
+	for _, l := range state.Layers {
+		data.Layers = append(data.Layers, diagram.FiberLayer{Y: l.Y, Strain: l.Strain, Stress: l.Stress})
+	}
+	return data
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"gonum.org/v1/plot/vg"
+)
+
+var (
+	// plotSize is the size of every exported diagram, e.g. 180x120mm, set
+	// with --plot-size
+	plotSize string
+
+	// plotDPI is the resolution of png, jpeg and tiff diagrams, set with --dpi
+	plotDPI int
+)
+
+// plotSizeUnits are the units of --plot-size by suffix
+var plotSizeUnits = map[string]vg.Length{
+	"in": vg.Inch,
+	"mm": vg.Millimeter,
+	"cm": vg.Centimeter,
+	"pt": vg.Points(1),
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&plotSize, "plot-size", "",
+		"Size of exported diagrams as WIDTHxHEIGHT in in, mm, cm or pt, e.g. 7x5in or 180x120mm (default: the size of each diagram)")
+	rootCmd.PersistentFlags().IntVar(&plotDPI, "dpi", 0, "Resolution of png, jpeg and tiff diagrams (default 96)")
+}
+
+// applyFigure validates --plot-size and --dpi and passes them to the
+// diagram exports
+func applyFigure() error {
+	if plotDPI < 0 {
+		return fmt.Errorf("--dpi must be positive, got %d", plotDPI)
+	}
+	diagram.Figure = diagram.FigureOptions{DPI: plotDPI}
+	if plotSize == "" {
+		return nil
+	}
+	width, height, err := parsePlotSize(plotSize)
+	if err != nil {
+		return err
+	}
+	diagram.Figure.Width, diagram.Figure.Height = width, height
+	return nil
+}
+
+// parsePlotSize parses a size given as WIDTHxHEIGHT followed by its unit,
+// inches when none, e.g. 7x5in or 180x120mm
+func parsePlotSize(s string) (vg.Length, vg.Length, error) {
+	size, unit := strings.ToLower(strings.TrimSpace(s)), vg.Inch
+	for suffix, u := range plotSizeUnits {
+		if strings.HasSuffix(size, suffix) {
+			size, unit = strings.TrimSpace(strings.TrimSuffix(size, suffix)), u
+			break
+		}
+	}
+	w, h, ok := strings.Cut(size, "x")
+	width, errW := strconv.ParseFloat(strings.TrimSpace(w), 64)
+	height, errH := strconv.ParseFloat(strings.TrimSpace(h), 64)
+	if !ok || errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid --plot-size %q (use WIDTHxHEIGHT in in, mm, cm or pt, e.g. 7x5in or 180x120mm)", s)
+	}
+	return vg.Length(width) * unit, vg.Length(height) * unit, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/spf13/cobra"
+)
+
+// gradeInput holds the named steel grade given in place of a raw fy
+type gradeInput struct {
+	Name  string
+	Grade *rebar.Grade
+}
+
+// addGradeFlag registers --grade on a command that also has --fy
+func addGradeFlag(cmd *cobra.Command, in *gradeInput) {
+	cmd.Flags().StringVar(&in.Name, "grade", "", "Steel grade in place of --fy (40, 60, 75, 80 or PNS 230, 275, 415, 520)")
+	cmd.MarkFlagsMutuallyExclusive("fy", "grade")
+}
+
+// resolve looks up the grade and sets fy from it when --grade is given
+func (in *gradeInput) resolve(fy *float64) error {
+	if in.Name == "" {
+		return nil
+	}
+	g, err := rebar.FindGrade(in.Name)
+	if err != nil {
+		return err
+	}
+	in.Grade = &g
+	*fy = g.Fy
+	return nil
+}
+
+// printGrade prints the resolved steel grade as an input data row
+func printGrade(w io.Writer, in gradeInput) {
+	if in.Grade == nil {
+		return
+	}
+	fmt.Fprintf(w, "  Steel Grade:\t%s (fu = %s, εy = %.5f)\n", in.Grade.Name, fmtStress(in.Grade.Fu, 0), in.Grade.YieldStrain())
+}
+
+// reportGrade returns the resolved steel grade as an input data row of a report, or nil
+func reportGrade(in gradeInput) []string {
+	if in.Grade == nil {
+		return nil
+	}
+	return []string{"Steel grade", fmt.Sprintf("%s (fu = %s, εy = %.5f)", in.Grade.Name, fmtStress(in.Grade.Fu, 0), in.Grade.YieldStrain())}
+}
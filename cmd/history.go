@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/history"
+	"github.com/alexiusacademia/gorcb/internal/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// unrecordedAnnotation marks commands whose runs are not kept in the history
+const unrecordedAnnotation = "gorcb/unrecorded"
+
+// historyInputFlags are the flags naming input files that are copied into
+// the history with a run
+var historyInputFlags = []string{"file", "config", "code-overrides", "bar-catalog", "combinations"}
+
+var (
+	// Recording of the run turned off with --no-history, and of its output
+	// turned on with --record-output
+	noHistory    bool
+	recordOutput bool
+
+	// historyRun is the run being recorded, historyStdout the stdout of the
+	// command and historyDone closed when its output was all copied
+	historyRun    *history.Run
+	historyStdout *os.File
+	historyDone   chan struct{}
+	historyOutput historyBuffer
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List, show and rerun earlier calculations",
+	Long: `Every run of a design, analysis, check, batch or project command is recorded
+with its command line, the flags set from the environment or configuration
+files, a copy of its input files, its exit code and the one-line results of
+its members as printed with --quiet, so earlier calculations can be retrieved
+and reproduced in design reviews. With --record-output (or record-output:
+true in the configuration) everything it printed is kept too, for 'gorcb
+history rerun' to compare against.
+
+The runs are kept in the SQLite database ~/.config/gorcb/history.db (in the
+user configuration directory of the platform), numbered from 1 in the order
+they were made. Use --no-history, or GORCB_NO_HISTORY=true in scripts and CI
+jobs, to leave a run out. The history, serve, web and examples commands,
+--watch and --help are never recorded.
+
+Subcommands:
+  list   - Recent runs with their status
+  show   - The command line, inputs and results of a run
+  rerun  - Run a recorded command again and compare its results`,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	unrecorded(historyCmd)
+
+	rootCmd.PersistentFlags().BoolVar(&noHistory, "no-history", false,
+		"Do not record the run in the local history of calculations")
+	rootCmd.PersistentFlags().BoolVar(&recordOutput, "record-output", false,
+		"Also record everything the run prints in the history, not only its results")
+}
+
+// unrecorded marks a command and its subcommands as left out of the history
+func unrecorded(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[unrecordedAnnotation] = "true"
+}
+
+// recorded reports whether runs of a command are kept in the history
+func recorded(cmd *cobra.Command) bool {
+	if !cmd.HasParent() {
+		return false
+	}
+	if f := cmd.Flags().Lookup("watch"); f != nil && f.Value.String() == "true" {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[unrecordedAnnotation] != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// historyStore returns the history database of the user
+func historyStore() (history.Store, error) {
+	path, err := history.DefaultPath()
+	return history.Store{Path: path}, err
+}
+
+// setupHistory starts recording the run of a command: its command line,
+// configured flags and input files now and, with --record-output, everything
+// it prints to stdout until closeHistory
+func setupHistory(cmd *cobra.Command) error {
+	if noHistory || !recorded(cmd) {
+		return nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	run := &history.Run{
+		Time:    time.Now(),
+		Version: version.Version,
+		Command: cmd.CommandPath(),
+		Args:    os.Args[1:],
+		Dir:     dir,
+	}
+	for name := range configuredFlags {
+		if f := cmd.Flags().Lookup(name); f != nil {
+			if run.Configured == nil {
+				run.Configured = make(map[string]string)
+			}
+			// In the units of the run, as rerunArgs gives it with its --units
+			value := flagValue(f)
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				value = strings.Join(sv.GetSlice(), ",")
+			}
+			run.Configured[name] = value
+		}
+	}
+	for _, name := range historyInputFlags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Value.String() == "" {
+			continue
+		}
+		if f.Value.String() == "-" {
+			run.Stdin = true
+			continue
+		}
+		// Missing files are reported by the command itself
+		if in, err := history.ReadInput(name, f.Value.String()); err == nil {
+			run.Inputs = append(run.Inputs, in)
+		}
+	}
+
+	historyRun = run
+	if !recordOutput {
+		return nil
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	run.OutputRecorded = true
+	historyStdout, historyDone = os.Stdout, make(chan struct{})
+	historyOutput = historyBuffer{}
+	os.Stdout = w
+	go func() {
+		defer close(historyDone)
+		io.Copy(io.MultiWriter(historyStdout, &historyOutput), r)
+	}()
+	return nil
+}
+
+// closeHistory restores stdout and appends the run with its exit code and
+// any recorded output to the history. A history that cannot be written is
+// reported without changing the exit code of the command.
+func closeHistory() {
+	if historyRun == nil {
+		return
+	}
+	if historyStdout != nil {
+		os.Stdout.Close()
+		<-historyDone
+		os.Stdout, historyStdout = historyStdout, nil
+		historyRun.Output = historyOutput.String()
+		historyRun.Truncated = historyOutput.truncated
+	}
+
+	historyRun.Exit = exitCode
+	historyRun.Results = quietLines
+	store, err := historyStore()
+	if err == nil {
+		_, err = store.Append(*historyRun)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the run was not recorded in the history: %v\n", err)
+	}
+	historyRun = nil
+}
+
+// historyBuffer keeps the first history.MaxOutput bytes of the output of a
+// run
+type historyBuffer struct {
+	strings.Builder
+	truncated bool
+}
+
+func (b *historyBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := history.MaxOutput - b.Len(); n > room {
+		b.truncated = true
+		p = p[:max(room, 0)]
+	}
+	b.Builder.Write(p)
+	return n, nil
+}
+
+// rerunArgs returns the command line of a recorded run with the flags it
+// took from the environment and configuration files given explicitly, so
+// that it runs with the same values
+func rerunArgs(run history.Run) []string {
+	args := slices.Clone(run.Args)
+	for _, name := range slices.Sorted(maps.Keys(run.Configured)) {
+		args = append(args, "--"+name+"="+run.Configured[name])
+	}
+	return args
+}
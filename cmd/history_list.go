@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// historyLast is the number of recent runs listed with --last
+var historyLast int
+
+// historyTimeLayout prints the time of a run in text output
+const historyTimeLayout = "2006-01-02 15:04"
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recent runs with their status",
+	Long: `List the most recent runs of the history, oldest first, with the time they
+were made, their status (OK, NG for an inadequate design or check, ERROR for
+invalid input or a failure) and their command line. Use the number of a run
+with 'gorcb history show' and 'gorcb history rerun'.
+
+Examples:
+  gorcb history list
+  gorcb history list --last 0
+  gorcb history list --format csv`,
+	Args: cobra.NoArgs,
+	Run:  runHistoryList,
+}
+
+func init() {
+	historyCmd.AddCommand(historyListCmd)
+	tabular(historyListCmd)
+
+	historyListCmd.Flags().IntVarP(&historyLast, "last", "n", 20, "Number of recent runs to list, 0 for all")
+}
+
+// historyEntry is a run as listed, without its inputs and output
+type historyEntry struct {
+	ID      int       `json:"id"`
+	Time    time.Time `json:"time"`
+	Status  string    `json:"status"`
+	Exit    int       `json:"exit"`
+	Command string    `json:"command"`
+	Dir     string    `json:"dir"`
+}
+
+func runHistoryList(cmd *cobra.Command, args []string) {
+	if historyLast < 0 {
+		fmt.Printf("Error: --last must be 0 or more, got %d\n", historyLast)
+		setExit(exitInvalidInput)
+		return
+	}
+	store, err := historyStore()
+	if err != nil {
+		printError(err)
+		return
+	}
+	runs, err := store.List(historyLast)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	var entries []historyEntry
+	for _, r := range runs {
+		entries = append(entries, historyEntry{ID: r.ID, Time: r.Time, Status: r.Status(), Exit: r.Exit,
+			Command: r.CommandLine(), Dir: r.Dir})
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, e := range entries {
+			rows = append(rows, []string{strconv.Itoa(e.ID), e.Time.Format(time.RFC3339), e.Status,
+				strconv.Itoa(e.Exit), e.Command, e.Dir})
+		}
+		printTable([]string{"ID", "Time", "Status", "Exit", "Command", "Directory"}, rows)
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, nil, entries)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     CALCULATION HISTORY")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	if len(entries) == 0 {
+		fmt.Printf("  No runs recorded in %s\n", store.Path)
+		fmt.Println()
+		return
+	}
+	w := newTextWriter()
+	fmt.Fprintln(w, "  ID\tTime\tStatus\tCommand")
+	fmt.Fprintln(w, "  ──\t────\t──────\t───────")
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %d\t%s\t%s\t%s\n", e.ID, e.Time.Local().Format(historyTimeLayout), e.Status, e.Command)
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Println("  Show a run with: gorcb history show <id>")
+	fmt.Println()
+}
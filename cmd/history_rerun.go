@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/ascii"
+	"github.com/alexiusacademia/gorcb/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var historyRerunCmd = &cobra.Command{
+	Use:   "rerun <id>",
+	Short: "Run a recorded command again and compare its results",
+	Long: `Run the command of a recorded run again in the directory it was made in,
+with the flags it took from the environment or configuration files given
+explicitly and the GORCB_* environment variables of this shell ignored, so it
+runs with the same values. Its output is printed as the original run printed
+it, then compared with the recorded output, or with the recorded results of
+the members when the run was made without --record-output, and its exit code
+with that of the run.
+
+A note on stderr tells whether the results are unchanged and warns of input
+files that changed since the run. The exit code is that of the rerun, or 3
+when it succeeds but its output differs from the recorded one. Runs that read
+their input from stdin cannot be rerun. The rerun is recorded as a new run.
+
+Examples:
+  gorcb history rerun 12
+  gorcb history rerun 12 > rerun.txt`,
+	Args: cobra.ExactArgs(1),
+	Run:  runHistoryRerun,
+}
+
+func init() {
+	historyCmd.AddCommand(historyRerunCmd)
+}
+
+func runHistoryRerun(cmd *cobra.Command, args []string) {
+	run, err := historyRunOf(args[0])
+	if err != nil {
+		printError(err)
+		return
+	}
+	if run.Stdin {
+		fmt.Printf("Error: run %d read its input from stdin, which was not recorded\n", run.ID)
+		setExit(exitInvalidInput)
+		return
+	}
+	if info, err := os.Stat(run.Dir); err != nil || !info.IsDir() {
+		fmt.Printf("Error: the directory of run %d no longer exists: %s\n", run.ID, run.Dir)
+		setExit(exitInvalidInput)
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		printError(err)
+		return
+	}
+	for _, in := range run.Inputs {
+		if in.Changed(run.Dir) {
+			historyNote("Warning: %s changed since run %d (see gorcb history show %d --inputs)\n", in.Path, run.ID, run.ID)
+		}
+	}
+
+	rerunArgs := rerunArgs(run)
+	if noHistory {
+		rerunArgs = append(rerunArgs, "--no-history")
+	}
+	results, err := os.CreateTemp("", "gorcb-rerun-")
+	if err != nil {
+		printError(err)
+		return
+	}
+	results.Close()
+	defer os.Remove(results.Name())
+
+	var output bytes.Buffer
+	rerun := exec.Command(exe, rerunArgs...)
+	rerun.Dir = run.Dir
+	rerun.Env = append(rerunEnv(os.Environ()), rerunResultsEnv+"="+results.Name())
+	rerun.Stdout = io.MultiWriter(os.Stdout, &output)
+	rerun.Stderr = os.Stderr
+	err = rerun.Run()
+	code := exitOK
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.ExitCode()
+	case err != nil:
+		printError(err)
+		return
+	}
+
+	// Without the output of the run its results are compared, when it has any
+	compared := run.OutputRecorded || len(run.Results) > 0
+	same := output.String() == run.Output
+	switch {
+	case run.OutputRecorded && run.Truncated:
+		same = strings.HasPrefix(output.String(), run.Output)
+	case !run.OutputRecorded:
+		data, _ := os.ReadFile(results.Name())
+		same = !compared || string(data) == resultsText(run.Results)
+	}
+	when := run.Time.Local().Format(historyTimeLayout)
+	switch {
+	case !compared && code == run.Exit:
+		historyNote("✓ Same exit code as run %d of %s, whose results were not recorded to compare\n", run.ID, when)
+	case !compared:
+		historyNote("✗ Exit code %d instead of %d of run %d of %s, whose results were not recorded to compare\n", code, run.Exit, run.ID, when)
+	case same && code == run.Exit:
+		historyNote("✓ Same results as run %d of %s\n", run.ID, when)
+	case same:
+		historyNote("✗ Same results as run %d of %s, but exit code %d instead of %d\n", run.ID, when, code, run.Exit)
+	default:
+		historyNote("✗ Results differ from run %d of %s (see gorcb history show %d)\n", run.ID, when, run.ID)
+	}
+	if code != exitOK {
+		setExit(code)
+	} else if !same {
+		setExit(exitInadequate)
+	}
+}
+
+// rerunResultsEnv names the file a rerun writes its one-line results to, for
+// comparing them with those of the recorded run
+const rerunResultsEnv = "GORCB_RERUN_RESULTS"
+
+// writeRerunResults writes the one-line results of the run to the file of
+// rerunResultsEnv when it is run by 'gorcb history rerun'
+func writeRerunResults() {
+	if path := os.Getenv(rerunResultsEnv); path != "" {
+		os.WriteFile(path, []byte(resultsText(quietLines)), 0644)
+	}
+}
+
+// resultsText returns one-line results as lines of text
+func resultsText(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// rerunEnv returns the environment of a rerun without the GORCB_* flag
+// defaults, which the recorded run gives explicitly
+func rerunEnv(environ []string) []string {
+	var env []string
+	for _, entry := range environ {
+		if !strings.HasPrefix(entry, config.EnvPrefix) {
+			env = append(env, entry)
+		}
+	}
+	return env
+}
+
+// historyNote prints a note of a rerun on stderr, leaving stdout as the
+// recorded run printed it
+func historyNote(format string, a ...any) {
+	note := fmt.Sprintf(format, a...)
+	if plainOutput {
+		note = ascii.Spell(note)
+	}
+	fmt.Fprint(os.Stderr, note)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// historyInputs prints the recorded contents of the input files
+var historyInputs bool
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the command line, inputs and results of a run",
+	Long: `Show a run of the history: when and where it was made, its command line and
+the flags it took from the environment or configuration files, its input
+files and whether they changed since, the one-line results of its members
+and everything it printed when that was recorded with --record-output. Use
+--inputs to also print the input files as they were at the time of the run.
+
+Examples:
+  gorcb history show 12
+  gorcb history show 12 --inputs
+  gorcb history show 12 --format json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runHistoryShow,
+}
+
+func init() {
+	historyCmd.AddCommand(historyShowCmd)
+
+	historyShowCmd.Flags().BoolVar(&historyInputs, "inputs", false, "Print the recorded contents of the input files")
+}
+
+// historyRunOf returns the run of an ID given on the command line
+func historyRunOf(arg string) (history.Run, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return history.Run{}, fmt.Errorf("run ID must be a number, got %q", arg)
+	}
+	store, err := historyStore()
+	if err != nil {
+		return history.Run{}, err
+	}
+	return store.Get(id)
+}
+
+func runHistoryShow(cmd *cobra.Command, args []string) {
+	run, err := historyRunOf(args[0])
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, run)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     RUN %d\n", run.ID)
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Time:\t%s\n", run.Time.Local().Format("2006-01-02 15:04:05 MST"))
+	fmt.Fprintf(w, "  Command:\t%s\n", run.CommandLine())
+	fmt.Fprintf(w, "  Directory:\t%s\n", run.Dir)
+	fmt.Fprintf(w, "  Version:\tgorcb v%s\n", run.Version)
+	fmt.Fprintf(w, "  Status:\t%s (exit code %d)\n", run.Status(), run.Exit)
+	if len(run.Configured) > 0 {
+		var flags []string
+		for _, name := range slices.Sorted(maps.Keys(run.Configured)) {
+			flags = append(flags, name+"="+run.Configured[name])
+		}
+		fmt.Fprintf(w, "  Configured:\t%s\n", strings.Join(flags, ", "))
+	}
+	w.Flush()
+
+	if len(run.Inputs) > 0 || run.Stdin {
+		fmt.Println()
+		fmt.Println("INPUTS")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		w = newTextWriter()
+		for _, in := range run.Inputs {
+			note := ""
+			if in.Changed(run.Dir) {
+				note = "changed since the run"
+			}
+			fmt.Fprintf(w, "  --%s\t%s\t%d bytes\t%s\n", in.Flag, in.Path, in.Size, note)
+		}
+		if run.Stdin {
+			fmt.Fprintln(w, "  -\tstdin\t\tnot recorded")
+		}
+		w.Flush()
+		if historyInputs {
+			for _, in := range run.Inputs {
+				fmt.Println()
+				fmt.Printf("  %s:\n", in.Path)
+				if in.Omitted {
+					fmt.Printf("  (larger than %d bytes, not recorded)\n", history.MaxInput)
+					continue
+				}
+				fmt.Println(strings.TrimRight(in.Content, "\n"))
+			}
+		}
+	}
+
+	if len(run.Results) > 0 {
+		fmt.Println()
+		fmt.Println("RESULTS")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		for _, line := range run.Results {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("OUTPUT")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	if !run.OutputRecorded {
+		fmt.Println("  Not recorded (run with --record-output to keep everything it printed)")
+	}
+	fmt.Print(run.Output)
+	if run.Truncated {
+		fmt.Printf("\n  ... (only the first %d bytes were recorded)\n", history.MaxOutput)
+	}
+	fmt.Println()
+}
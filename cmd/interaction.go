@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// interactionLoad is a factored load checked on the interaction diagram
+type interactionLoad struct {
+	Pu       float64 `json:"pu"`         // Compression positive (kN)
+	Mu       float64 `json:"mu"`         // Negative with the bottom in compression (kN-m)
+	PhiMn    float64 `json:"phi_mn"`     // Design moment strength at Pu (kN-m)
+	PhiPnMax float64 `json:"phi_pn_max"` // Maximum design axial strength (kN)
+	Inside   bool    `json:"inside"`     // The load is inside the φPn–φMn curve
+}
+
+// checkInteractionLoad checks a factored load against the design curve of
+// the interaction diagram
+func checkInteractionLoad(d *section.Interaction, pu, mu float64) *interactionLoad {
+	capacity, ok := d.MomentCapacity(pu, mu < 0)
+	return &interactionLoad{
+		Pu:       pu,
+		Mu:       mu,
+		PhiMn:    capacity,
+		PhiPnMax: d.PhiPnMax,
+		Inside:   ok && math.Abs(mu) <= capacity,
+	}
+}
+
+// printInteraction prints the key points of the interaction diagram and the
+// check of the factored load
+func printInteraction(sec *section.Section, d *section.Interaction, load *interactionLoad) {
+	fmt.Println("AXIAL-FLEXURAL INTERACTION:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Pure compression (P0):\t%s\n", fmtForce(d.P0, 2))
+	fmt.Fprintf(w, "  Maximum design axial strength (φPn,max):\t%s\n", fmtForce(d.PhiPnMax, 2))
+	tension := d.Positive[0]
+	fmt.Fprintf(w, "  Pure tension (φPnt):\t%s\n", fmtForce(tension.PhiPn, 2))
+	if b, ok := balancedPoint(sec, d.Positive); ok {
+		fmt.Fprintf(w, "  Balanced point, top in compression (φPb, φMb):\t%s, %s\n", fmtForce(b.PhiPn, 2), fmtMoment(b.PhiMn, 2))
+	}
+	if b, ok := balancedPoint(sec, d.Negative); ok {
+		fmt.Fprintf(w, "  Balanced point, bottom in compression (φPb, φMb):\t%s, %s\n", fmtForce(b.PhiPn, 2), fmtMoment(b.PhiMn, 2))
+	}
+	w.Flush()
+	fmt.Println()
+
+	if load == nil {
+		return
+	}
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Factored load (Pu, Mu):\t%s, %s\n", fmtForce(load.Pu, 2), fmtMoment(load.Mu, 2))
+	switch {
+	case load.Pu > load.PhiPnMax:
+		fmt.Fprintf(w, "  Pu vs φPn,max:\t%s > %s ✗\n", fmtForce(load.Pu, 2), fmtForce(load.PhiPnMax, 2))
+	case load.Inside:
+		fmt.Fprintf(w, "  |Mu| vs φMn at Pu:\t%s ≤ %s ✓\n", fmtMoment(math.Abs(load.Mu), 2), fmtMoment(load.PhiMn, 2))
+	default:
+		fmt.Fprintf(w, "  |Mu| vs φMn at Pu:\t%s > %s ✗\n", fmtMoment(math.Abs(load.Mu), 2), fmtMoment(load.PhiMn, 2))
+	}
+	w.Flush()
+	if load.Inside {
+		fmt.Println("  ✓ The factored load is inside the φPn–φMn interaction diagram")
+	} else {
+		fmt.Println("  ✗ The factored load is outside the φPn–φMn interaction diagram")
+	}
+	fmt.Println()
+}
+
+// balancedPoint interpolates the point of a branch at the balanced
+// condition, with the extreme tension steel just yielding
+func balancedPoint(sec *section.Section, branch []section.InteractionPoint) (section.InteractionPoint, bool) {
+	epsilonY := selectedCode.DesignYieldStrength(sec.Fy) / nscp.Es
+	for i := 1; i < len(branch); i++ {
+		p1, p2 := branch[i-1], branch[i]
+		if p1.C == 0 || p2.C == 0 || p1.EpsilonT < epsilonY || p2.EpsilonT > epsilonY {
+			continue
+		}
+		t := (p1.EpsilonT - epsilonY) / (p1.EpsilonT - p2.EpsilonT)
+		lerp := func(a, b float64) float64 { return a + t*(b-a) }
+		return section.InteractionPoint{
+			C: lerp(p1.C, p2.C), EpsilonT: epsilonY,
+			Pn: lerp(p1.Pn, p2.Pn), Mn: lerp(p1.Mn, p2.Mn), Phi: lerp(p1.Phi, p2.Phi),
+			PhiPn: lerp(p1.PhiPn, p2.PhiPn), PhiMn: lerp(p1.PhiMn, p2.PhiMn),
+		}, true
+	}
+	return section.InteractionPoint{}, false
+}
+
+// interactionDiagramData returns the plot of the interaction diagram as
+// closed curves, from pure tension up the positive branch and back down the
+// negative branch
+func interactionDiagramData(sec *section.Section, d *section.Interaction, load *interactionLoad) diagram.InteractionDiagramData {
+	data := diagram.InteractionDiagramData{Name: sec.Name, Units: selectedUnits}
+	points := append([]section.InteractionPoint{}, d.Positive...)
+	for i := len(d.Negative) - 1; i >= 0; i-- {
+		points = append(points, d.Negative[i])
+	}
+	for _, p := range points {
+		data.Nominal = append(data.Nominal, diagram.Point{X: p.Mn, Y: p.Pn})
+		data.Design = append(data.Design, diagram.Point{X: p.PhiMn, Y: p.PhiPn})
+	}
+	if load != nil {
+		data.Load = &diagram.Point{X: load.Mu, Y: load.Pu}
+		data.Inside = load.Inside
+	}
+	return data
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/check"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/report"
+)
+
+// layoutOptions returns the layout options of a rectangular beam inside the
+// stirrups of the cover check flags, the clear cover taken from the
+// effective cover of the tension face, or false when no clear cover is left
+func layoutOptions(in coverInputs, width, height, cover, fc, fy float64) (check.LayoutOptions, bool) {
+	opts := check.LayoutOptions{
+		Width:      width,
+		Height:     height,
+		ClearCover: in.clearCover(cover),
+		Stirrup:    in.StirrupDia,
+		Fc:         fc,
+		Fy:         fy,
+		MinBars:    suggestMinBars,
+		MaxBars:    suggestMaxBars,
+	}
+	return opts, opts.ClearCover > 0
+}
+
+// barLayouts lays out the tension steel as and compression steel asc of a
+// rectangular beam
+func barLayouts(in coverInputs, width, height, cover, fc, fy, as, asc, mu float64) []check.Layout {
+	opts, ok := layoutOptions(in, width, height, cover, fc, fy)
+	if !ok {
+		return nil
+	}
+	return check.Layouts(as, asc, mu, opts, selectedCatalog, selectedCode)
+}
+
+// layoutTable returns the header and rows of a table of bar layouts, with
+// the compression bars when a layout has any, and the rank and type of each
+// layout when ranked
+func layoutTable(layouts []check.Layout, ranked bool) ([]string, [][]string) {
+	doubly := false
+	for _, l := range layouts {
+		doubly = doubly || l.Compression.Count > 0
+	}
+	header := []string{"Bars", "Layers", "As", "d"}
+	if ranked {
+		header = append([]string{"Rank", "Type"}, header...)
+	}
+	if doubly {
+		header = append(header, "Top Bars", "d'")
+	}
+	header = append(header, "φMn", "Mu/φMn", "Mass")
+
+	var rows [][]string
+	for i, l := range layouts {
+		r := l.Result
+		var row []string
+		if ranked {
+			row = []string{fmt.Sprint(i + 1), l.Type()}
+		}
+		row = append(row, fmt.Sprintf("%d - %s", l.Tension.Count, l.Tension.Bar.Label()), l.LayersLabel(),
+			fmtArea(r.As, 2), fmtLength(r.D, 1))
+		if doubly && l.Compression.Count > 0 {
+			row = append(row, fmt.Sprintf("%d - %s", l.Compression.Count, l.Compression.Bar.Label()), fmtLength(r.DPrime, 1))
+		} else if doubly {
+			row = append(row, "-", "-")
+		}
+		rows = append(rows, append(row, fmtMoment(r.PhiMn, 2), fmt.Sprintf("%.2f", l.Utilization()),
+			fmtMass(l.Mass, 2)))
+	}
+	return header, rows
+}
+
+// printBarLayouts prints the bar layouts of a design whose steel was found
+// with an assumed effective depth d under a title, ranked when more than one
+// type of reinforcement was laid out
+func printBarLayouts(title string, layouts []check.Layout, ranked bool, in coverInputs, cover, d float64) {
+	fmt.Println(title + ":")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	if len(layouts) == 0 {
+		fmt.Printf("  No layout of %d to %d bars in up to %d layers inside φ%.0fmm stirrups with %s\n",
+			suggestMinBars, suggestMaxBars, check.DefaultMaxLayers, in.StirrupDia, fmtLength(in.clearCover(cover), 1))
+		fmt.Println("  clear cover passes the flexure and spacing checks with the d of its bars.")
+		fmt.Println()
+		return
+	}
+	header, rows := layoutTable(layouts, ranked)
+	w := newTextWriter()
+	for i, row := range append([][]string{header}, rows...) {
+		fmt.Fprint(w, " ")
+		for _, cell := range row {
+			fmt.Fprintf(w, " %s\t", cell)
+		}
+		fmt.Fprintln(w)
+		if i == 0 {
+			fmt.Fprint(w, " ")
+			for _, cell := range row {
+				fmt.Fprintf(w, " %s\t", underline(cell))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Printf("  Bars at least %s or one diameter apart inside φ%.0fmm stirrups, %s clear cover;\n",
+		fmtSpacing(batch.MinClearBarSpacing, 0), in.StirrupDia, fmtLength(in.clearCover(cover), 1))
+	fmt.Printf("  φMn is verified with d of each layout (design assumed d = %s).\n", fmtLength(d, 1))
+	fmt.Println()
+}
+
+// reportLayouts adds the bar layouts of a design under a subsection title
+func reportLayouts(doc *report.Document, title string, layouts []check.Layout, ranked bool) {
+	if len(layouts) == 0 {
+		return
+	}
+	doc.Subsection(title)
+	doc.Table(layoutTable(layouts, ranked))
+}
+
+// detailBars draws the tension steel of a rectangular beam diagram as bars
+// of the cover check flags inside the stirrups, as many to a layer as fit
+// at the least clear spacing, the layers centered on the steel centroid.
+// The diagram is returned unchanged when no clear cover is left.
+func detailBars(data diagram.SectionDiagramData, in coverInputs) diagram.SectionDiagramData {
+	dia := in.BarDia
+	count := int(math.Ceil(data.TensionSteelArea / (math.Pi * dia * dia / 4)))
+	inner := data.Width - 2*(in.clearCover(data.TensionSteelY)+in.StirrupDia)
+	gap := math.Max(batch.MinClearBarSpacing, dia)
+	perLayer := max(int((inner+gap)/(dia+gap)), 1)
+	layers := (count + perLayer - 1) / perLayer
+	pitch := dia + check.MinClearLayerSpacing
+	first := data.TensionSteelY - pitch*float64(layers-1)/2
+	clear := first - dia/2 - in.StirrupDia
+	if count < 1 || clear <= 0 {
+		return data
+	}
+
+	data.ClearCover, data.StirrupDiameter = clear, in.StirrupDia
+	xMin, xMax := clear+in.StirrupDia, data.Width-clear-in.StirrupDia
+	for i := 0; i < layers; i++ {
+		n := min(perLayer, count-i*perLayer)
+		data.Bars = append(data.Bars, diagram.LayerBars(n, dia, first+pitch*float64(i), xMin, xMax, true)...)
+	}
+	return data
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+// loadInput is the unfactored M[,V[,P]] of one load type
+type loadInput struct {
+	Type      nscp.LoadType
+	Name      string
+	Shorthand string
+	Label     string
+	Values    []float64
+}
+
+var (
+	// Unfactored load effects per load type
+	loadsInputs = []loadInput{
+		{Type: nscp.LoadDead, Name: "dead", Shorthand: "d", Label: "Dead Load (D)"},
+		{Type: nscp.LoadLive, Name: "live", Shorthand: "l", Label: "Live Load (L)"},
+		{Type: nscp.LoadRoof, Name: "roof", Shorthand: "r", Label: "Roof Live Load (Lr)"},
+		{Type: nscp.LoadWind, Name: "wind", Shorthand: "w", Label: "Wind Load (W)"},
+		{Type: nscp.LoadEarthquake, Name: "earthquake", Shorthand: "e", Label: "Earthquake Load (E)"},
+		{Type: nscp.LoadRain, Name: "rain", Shorthand: "R", Label: "Rain Load (R)"},
+		{Type: nscp.LoadSnow, Name: "snow", Shorthand: "S", Label: "Snow Load (S)"},
+		{Type: nscp.LoadFluid, Name: "fluid", Shorthand: "F", Label: "Fluid Pressure (F)"},
+		{Type: nscp.LoadEarth, Name: "earth", Shorthand: "H", Label: "Lateral Earth Pressure (H)"},
+		{Type: nscp.LoadTemperature, Name: "temperature", Shorthand: "T", Label: "Self-straining (T)"},
+	}
+
+	// Load combination selection
+	loadsCombinations combinationInputs
+)
+
+var loadsCmd = &cobra.Command{
+	Use:   "loads",
+	Short: "Tabulate factored moment, shear and axial force for every load combination",
+	Long: `Calculate the factored moment (Mu), shear (Vu) and axial force (Pu) of every
+load combination of the selected design code and flag the combination that
+governs each action.
+
+Give the unfactored effects of each load type as M[,V[,P]] in kN-m and kN,
+with axial compression positive. Omitted values are zero. The same load
+types, combination sets, seismic and ASD options as 'gorcb moment' apply.
+
+Each factored value is taken from the branch of the combination ("or" loads
+and ± wind or earthquake) of largest magnitude for that action, so M, V and P
+of one row may come from different branches.
+
+Examples:
+  # Beam end: moment and shear from dead and live load
+  gorcb loads --dead 50,80 --live 30,45
+
+  # Column: moment, shear and axial force with earthquake
+  gorcb loads --dead 40,15,900 --live 20,8,350 --earthquake 60,35,120
+
+  # Allowable stress design combinations
+  gorcb loads --dead 50,80 --live 30,45 --method asd`,
+	Run: runLoads,
+}
+
+func init() {
+	rootCmd.AddCommand(loadsCmd)
+	tabular(loadsCmd)
+	exportable(loadsCmd)
+
+	for i := range loadsInputs {
+		in := &loadsInputs[i]
+		usage := fmt.Sprintf("Unfactored M,V,P of %s (kN-m, kN, kN)", in.Label)
+		loadsCmd.Flags().Float64SliceVarP(&in.Values, in.Name, in.Shorthand, nil, usage)
+	}
+
+	addCombinationFlags(loadsCmd, &loadsCombinations)
+}
+
+// loadsReport is the JSON result of the loads command
+type loadsReport struct {
+	Demands   []nscp.CombinationDemand `json:"demands"`
+	Governing map[nscp.Action]string   `json:"governing"` // Governing combination ID per action
+}
+
+func runLoads(cmd *cobra.Command, args []string) {
+	var effects nscp.LoadEffects
+	given := false
+	for _, in := range loadsInputs {
+		if len(in.Values) > len(nscp.Actions) {
+			fmt.Printf("Error: --%s takes at most M,V,P, got %d values\n", in.Name, len(in.Values))
+			setExit(exitInvalidInput)
+			return
+		}
+		for i, v := range in.Values {
+			switch nscp.Actions[i] {
+			case nscp.ActionMoment:
+				effects.Moment.Set(in.Type, v)
+			case nscp.ActionShear:
+				effects.Shear.Set(in.Type, v)
+			case nscp.ActionAxial:
+				effects.Axial.Set(in.Type, v)
+			}
+			if v != 0 {
+				given = true
+			}
+		}
+	}
+	if !given {
+		fmt.Println("Error: Please provide at least one unfactored load effect.")
+		fmt.Println("Use 'gorcb loads --help' for usage information.")
+		setExit(exitInvalidInput)
+		return
+	}
+
+	combinations, err := loadsCombinations.resolve(cmd)
+	if err != nil {
+		printError(err)
+		return
+	}
+	isASD := loadsCombinations.ASD
+
+	demands := nscp.CalculateDemands(effects, combinations)
+	governing := make(map[nscp.Action]int)
+	for _, a := range nscp.Actions {
+		governing[a] = nscp.GoverningDemand(demands, a)
+	}
+
+	if workbookFile != "" {
+		book := xlsx.New()
+		book.AddSheet("Load Combinations").Table(combinationTable(demands, isASD))
+		defer writeWorkbook(book)
+	}
+	if tabularOutput() {
+		printTable(combinationTable(demands, isASD))
+		return
+	}
+
+	if structuredOutput() {
+		report := loadsReport{Demands: demands, Governing: make(map[nscp.Action]string)}
+		for a, i := range governing {
+			if i >= 0 {
+				report.Governing[a] = demands[i].Combination.ID
+			}
+		}
+		printReport(cmd, effects, report)
+		return
+	}
+
+	// Print header
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	if isASD {
+		fmt.Printf("          %s ASD LOAD EFFECTS\n", selectedCode.Name())
+	} else {
+		fmt.Printf("          %s FACTORED LOAD EFFECTS\n", selectedCode.Name())
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	// Print input effects
+	fmt.Println("UNFACTORED LOAD EFFECTS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Load\tM (kN-m)\tV (kN)\tP (kN)\n")
+	fmt.Fprintf(w, "  ────\t────────\t──────\t──────\n")
+	for _, in := range loadsInputs {
+		m, v, p := effects.Moment.Moment(in.Type), effects.Shear.Moment(in.Type), effects.Axial.Moment(in.Type)
+		if m == 0 && v == 0 && p == 0 {
+			continue
+		}
+		label := in.Label
+		if in.Type == nscp.LoadEarthquake && loadsCombinations.ExpandSeismic {
+			label = "Horizontal Earthquake (Eh)"
+		}
+		fmt.Fprintf(w, "  %s\t%.2f\t%.2f\t%.2f\n", label, m, v, p)
+	}
+	w.Flush()
+	fmt.Println()
+
+	loadsCombinations.printSeismicEffect(0, "")
+
+	// Every combination
+	fmt.Printf("LOAD COMBINATIONS (%s):\n", loadsCombinations.Title)
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  #\tCombination\t%s (kN-m)\t%s (kN)\t%s (kN)\n",
+		actionSymbol(nscp.ActionMoment, isASD), actionSymbol(nscp.ActionShear, isASD), actionSymbol(nscp.ActionAxial, isASD))
+	fmt.Fprintf(w, "  ─\t───────────\t─────────\t───────\t───────\n")
+	for i, d := range demands {
+		fmt.Fprintf(w, "  %s\t%s", d.Combination.ID, d.Combination.Description)
+		for _, a := range nscp.Actions {
+			marker := ""
+			if governing[a] == i {
+				marker = " *"
+			}
+			fmt.Fprintf(w, "\t%.2f%s", d.Branch(a).Moment, marker)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Println("  * governs the action")
+	fmt.Println()
+
+	// Governing combination per action
+	fmt.Println("GOVERNING COMBINATIONS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	for _, a := range nscp.Actions {
+		unit := actionUnit(a)
+		i := governing[a]
+		if i < 0 {
+			fmt.Fprintf(w, "  %s:\t-\t(no %s given)\n", actionSymbol(a, isASD), actionName(a))
+			continue
+		}
+		d := demands[i]
+		branch := d.Branch(a)
+		fmt.Fprintf(w, "  %s:\t%.2f %s\t%s (%s), branch %s\n",
+			actionSymbol(a, isASD), branch.Moment, unit, d.Combination.ID, d.Combination.Description, branch.Describe())
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// actionSymbol returns the symbol of a factored action for the design method,
+// e.g. Vu for strength design and Va for ASD
+func actionSymbol(a nscp.Action, asd bool) string {
+	if asd {
+		return string(a) + "a"
+	}
+	return string(a) + "u"
+}
+
+// actionUnit returns the unit of an action
+func actionUnit(a nscp.Action) string {
+	if a == nscp.ActionMoment {
+		return "kN-m"
+	}
+	return "kN"
+}
+
+// actionName returns the name of an action
+func actionName(a nscp.Action) string {
+	switch a {
+	case nscp.ActionShear:
+		return "shear"
+	case nscp.ActionAxial:
+		return "axial force"
+	}
+	return "moment"
+}
+
+// combinationTable returns the header and rows of the factored effects of
+// every combination, marking the actions each combination governs
+func combinationTable(demands []nscp.CombinationDemand, isASD bool) ([]string, [][]string) {
+	header := []string{"Combination", "Description"}
+	for _, a := range nscp.Actions {
+		header = append(header, actionSymbol(a, isASD)+" ("+actionUnit(a)+")")
+	}
+	header = append(header, "Governs")
+	governing := make(map[nscp.Action]int)
+	for _, a := range nscp.Actions {
+		governing[a] = nscp.GoverningDemand(demands, a)
+	}
+	var rows [][]string
+	for i, d := range demands {
+		row := []string{d.Combination.ID, d.Combination.Description}
+		var governs []string
+		for _, a := range nscp.Actions {
+			row = append(row, cell(d.Branch(a).Moment))
+			if governing[a] == i {
+				governs = append(governs, actionSymbol(a, isASD))
+			}
+		}
+		rows = append(rows, append(row, strings.Join(governs, " ")))
+	}
+	return header, rows
+}
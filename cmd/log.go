@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Log levels of --log-level
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+var (
+	// Logging selected with --log-level and --log-file
+	logLevel    string
+	logFileName string
+	logFile     *os.File
+)
+
+// Nothing is logged until setupLogging runs, e.g. for --help
+func init() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// setupLogging sends the log of the engines to --log-file, or to stderr, at
+// --log-level, then records the command and its inputs. Without either flag
+// nothing is logged. Files named *.json or *.jsonl are written as JSON lines,
+// others as logfmt text.
+func setupLogging(cmd *cobra.Command, args []string) error {
+	if logLevel == "" && logFileName == "" {
+		return nil
+	}
+	level := slog.LevelInfo
+	if logLevel != "" {
+		l, ok := logLevels[strings.ToLower(logLevel)]
+		if !ok {
+			return fmt.Errorf("unknown log level %q (use debug, info, warn or error)", logLevel)
+		}
+		level = l
+	}
+
+	var w io.Writer = os.Stderr
+	if logFileName != "" {
+		if err := os.MkdirAll(filepath.Dir(logFileName), 0755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		logFile, w = f, f
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewTextHandler(w, opts)
+	if ext := strings.ToLower(filepath.Ext(logFileName)); ext == ".json" || ext == ".jsonl" {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	inputs := []any{"command", cmd.CommandPath(), "code", selectedCode.Name()}
+	if len(args) > 0 {
+		inputs = append(inputs, "args", args)
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		inputs = append(inputs, f.Name, f.Value.String())
+	})
+	slog.Info("run", inputs...)
+	return nil
+}
+
+// closeLog records the exit code of the command and closes the log file
+func closeLog() {
+	slog.Info("exit", "code", exitCode)
+	if logFile != nil {
+		logFile.Close()
+	}
+}
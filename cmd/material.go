@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var materialCmd = &cobra.Command{
+	Use:   "material",
+	Short: "Material property calculators",
+	Long: `Calculate material properties used throughout the design checks.
+
+Subcommands:
+  concrete  - Modulus of elasticity, modulus of rupture and cracking moment
+  creep     - Time-dependent creep and shrinkage factors at given ages
+  grades    - Reinforcing steel grades accepted by --grade`,
+}
+
+func init() {
+	rootCmd.AddCommand(materialCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	materialFc           float64
+	materialFy           float64
+	materialGrade        gradeInput
+	materialConcreteType string
+	materialWidth        float64
+	materialHeight       float64
+	materialSectionFile  string
+)
+
+var materialConcreteCmd = &cobra.Command{
+	Use:   "concrete",
+	Short: "Calculate Ec, fr and the cracking moment of concrete",
+	Long: `Calculate the modulus of elasticity Ec (normal-weight and density-based),
+the modulus of rupture fr and, for a given section, the cracking moment Mcr.
+
+Ec is used for the modular ratio of the cracked section in deflection and
+service stress checks; fr and Mcr govern the effective moment of inertia.
+
+The section is either a rectangle (--width and --height) or a section JSON
+file (--section), with tension at the bottom fiber.
+
+Examples:
+  # Normal-weight concrete
+  gorcb material concrete --fc 28
+
+  # Lightweight concrete of 1800 kg/m³ with the cracking moment of a 300x500 beam
+  gorcb material concrete --fc 28 --concrete-type 1800 --width 300 --height 500
+
+  # Cracking moment of a non-rectangular section
+  gorcb material concrete --section examples/t-beam.json`,
+	Run: runMaterialConcrete,
+}
+
+func init() {
+	materialCmd.AddCommand(materialConcreteCmd)
+
+	materialConcreteCmd.Flags().Float64Var(&materialFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
+	materialConcreteCmd.Flags().Float64Var(&materialFy, "fy", 415, "Steel yield strength fy (MPa) for the minimum steel ratio")
+	addGradeFlag(materialConcreteCmd, &materialGrade)
+	materialConcreteCmd.Flags().StringVar(&materialConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
+	materialConcreteCmd.Flags().Float64VarP(&materialWidth, "width", "b", 0, "Rectangular section width (mm)")
+	materialConcreteCmd.Flags().Float64Var(&materialHeight, "height", 0, "Rectangular section total depth (mm)")
+	materialConcreteCmd.Flags().StringVarP(&materialSectionFile, "section", "s", "", "Section JSON file (f'c and λ are taken from the file)")
+}
+
+// concreteInput is the JSON input of the material concrete command
+type concreteInput struct {
+	Fc           float64 `json:"fc"`
+	Fy           float64 `json:"fy"`
+	Density      float64 `json:"density,omitempty"`
+	ConcreteType string  `json:"concrete_type"`
+	Section      string  `json:"section,omitempty"`
+}
+
+// concreteReport is the JSON result of the material concrete command
+type concreteReport struct {
+	Lambda           float64 `json:"lambda"`
+	Ec               float64 `json:"ec"`
+	EcNormalWeight   float64 `json:"ec_normal_weight"`
+	ModularRatio     float64 `json:"modular_ratio"`
+	ModulusOfRupture float64 `json:"fr"`
+	RhoMin           float64 `json:"rho_min"`
+	Ig               float64 `json:"ig,omitempty"`
+	Yt               float64 `json:"yt,omitempty"`
+	Mcr              float64 `json:"mcr,omitempty"`
+}
+
+func runMaterialConcrete(cmd *cobra.Command, args []string) {
+	if err := materialGrade.resolve(&materialFy); err != nil {
+		printError(err)
+		return
+	}
+	fc := materialFc
+	lambda, err := concreteLambda(materialConcreteType)
+	if err != nil {
+		printError(err)
+		return
+	}
+	density := concreteDensity(materialConcreteType)
+
+	// Gross section properties for the cracking moment
+	var ig, yt float64
+	var sectionLabel string
+	switch {
+	case materialSectionFile != "":
+		sec, err := section.LoadFromFile(materialSectionFile)
+		if err != nil {
+			fmt.Printf("Error loading section: %v\n", err)
+			setExit(exitFor(err))
+			return
+		}
+		fc = sec.Fc
+		if sec.Lambda > 0 && !cmd.Flags().Changed("concrete-type") {
+			lambda = sec.Lambda
+		}
+		ig, yt = sec.CrackingProperties()
+		sectionLabel = sec.Name
+	case materialWidth > 0 || materialHeight > 0:
+		if materialWidth <= 0 || materialHeight <= 0 {
+			fmt.Printf("Error: both --width and --height are required for a rectangular section\n")
+			setExit(exitInvalidInput)
+			return
+		}
+		ig = materialWidth * math.Pow(materialHeight, 3) / 12
+		yt = materialHeight / 2
+		sectionLabel = fmt.Sprintf("%.0f x %.0f mm rectangle", materialWidth, materialHeight)
+	}
+
+	if fc <= 0 {
+		fmt.Printf("Error: f'c must be positive, got %.2f\n", fc)
+		setExit(exitInvalidInput)
+		return
+	}
+
+	ec := selectedCode.ModulusOfElasticity(fc, density)
+	ecNormal := selectedCode.ModulusOfElasticity(fc, 0)
+	fr := selectedCode.ModulusOfRupture(fc, lambda)
+
+	if structuredOutput() {
+		report := concreteReport{
+			Lambda:           lambda,
+			Ec:               ec,
+			EcNormalWeight:   ecNormal,
+			ModularRatio:     nscp.Es / ec,
+			ModulusOfRupture: fr,
+			RhoMin:           selectedCode.RhoMin(fc, materialFy),
+			Ig:               ig,
+			Yt:               yt,
+		}
+		if ig > 0 && yt > 0 {
+			report.Mcr = nscp.CrackingMoment(fr, ig, yt)
+		}
+		printReport(cmd, concreteInput{Fc: fc, Fy: materialFy, Density: density, ConcreteType: materialConcreteType, Section: sectionLabel}, report)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     CONCRETE MATERIAL PROPERTIES - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("INPUT DATA:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", fc)
+	if density > 0 {
+		fmt.Fprintf(w, "  Density (wc):\t%.0f kg/m³\n", density)
+	} else {
+		fmt.Fprintf(w, "  Concrete type:\t%s\n", materialConcreteType)
+	}
+	fmt.Fprintf(w, "  λ:\t%.2f\n", lambda)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("ELASTIC PROPERTIES:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Ec (normal-weight):\t%.0f MPa\n", ecNormal)
+	if density > 0 {
+		fmt.Fprintf(w, "  Ec (wc = %.0f kg/m³):\t%.0f MPa\n", density, ec)
+	}
+	fmt.Fprintf(w, "  Modular ratio (n = Es/Ec):\t%.2f\n", nscp.Es/ec)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("TENSILE PROPERTIES:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Modulus of Rupture (fr):\t%.2f MPa\n", fr)
+	fmt.Fprintf(w, "  ρ_min (fy = %.0f MPa):\t%.6f\n", materialFy, selectedCode.RhoMin(fc, materialFy))
+	w.Flush()
+	fmt.Println()
+
+	if ig > 0 && yt > 0 {
+		fmt.Println("CRACKING MOMENT:")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		w = newTextWriter()
+		if sectionLabel != "" {
+			fmt.Fprintf(w, "  Section:\t%s\n", sectionLabel)
+		}
+		fmt.Fprintf(w, "  Gross moment of inertia (Ig):\t%.4g mm⁴\n", ig)
+		fmt.Fprintf(w, "  Centroid to tension fiber (yt):\t%.2f mm\n", yt)
+		fmt.Fprintf(w, "  Cracking Moment (Mcr = fr·Ig/yt):\t%.2f kN-m\n", nscp.CrackingMoment(fr, ig, yt))
+		w.Flush()
+		fmt.Println()
+	}
+}
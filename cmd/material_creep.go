@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/aci"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	creepAges          []float64
+	creepLoadingAge    float64
+	creepCuringAge     float64
+	creepHumidity      float64
+	creepVolumeSurface float64
+	creepSteamCured    bool
+	creepFc            float64
+	creepFcgp          float64
+	creepEps           float64
+)
+
+var materialCreepCmd = &cobra.Command{
+	Use:   "creep",
+	Short: "Time-dependent creep and shrinkage factors at given ages",
+	Long: `Tabulate the time-dependent factors used for long-term deflection and
+prestress-loss estimates at user-specified ages:
+
+  - NSCP 2015 time-dependent factor ξ (Table 424.2.4.1.3)
+  - ACI 209R-92 creep coefficient νt and shrinkage strain εsh, corrected for
+    loading age, curing, relative humidity and volume-to-surface ratio
+
+Ages are counted in days from loading (creep and ξ) and from the end of
+curing (shrinkage). When the concrete stress at the prestressing steel
+centroid is given with --fcgp, the creep and shrinkage prestress losses are
+also estimated.
+
+Examples:
+  gorcb material creep --ages 30,90,365,1825
+  gorcb material creep --ages 365,3650 --humidity 80 --volume-surface 75 --loading-age 28
+  gorcb material creep --fc 35 --fcgp 8 --ages 1825`,
+	Run: runMaterialCreep,
+}
+
+func init() {
+	materialCmd.AddCommand(materialCreepCmd)
+	tabular(materialCreepCmd)
+
+	defaults := aci.DefaultCreepShrinkageConditions()
+	materialCreepCmd.Flags().Float64SliceVar(&creepAges, "ages", []float64{30, 90, 180, 365, 1825}, "Ages after loading to tabulate (days)")
+	materialCreepCmd.Flags().Float64Var(&creepLoadingAge, "loading-age", defaults.LoadingAge, "Age of concrete at loading (days)")
+	materialCreepCmd.Flags().Float64Var(&creepCuringAge, "curing-age", defaults.CuringAge, "Duration of initial moist curing (days)")
+	materialCreepCmd.Flags().Float64Var(&creepHumidity, "humidity", 70, "Ambient relative humidity (%)")
+	materialCreepCmd.Flags().Float64Var(&creepVolumeSurface, "volume-surface", defaults.VolumeSurface, "Volume-to-surface ratio (mm)")
+	materialCreepCmd.Flags().BoolVar(&creepSteamCured, "steam-cured", false, "Steam cured concrete (default moist cured)")
+	materialCreepCmd.Flags().Float64Var(&creepFc, "fc", 28, "Concrete compressive strength f'c (MPa) for Ec")
+	materialCreepCmd.Flags().Float64Var(&creepFcgp, "fcgp", 0, "Concrete stress at prestressing steel centroid for loss estimates (MPa)")
+	materialCreepCmd.Flags().Float64Var(&creepEps, "eps", 195000, "Modulus of elasticity of prestressing steel Eps (MPa)")
+}
+
+// creepRow is one age of the JSON result of the material creep command
+type creepRow struct {
+	Age           float64 `json:"age"`                      // days
+	Xi            float64 `json:"xi"`                       // NSCP time-dependent factor ξ
+	Nu            float64 `json:"nu"`                       // Creep coefficient νt
+	Esh           float64 `json:"esh"`                      // Shrinkage strain
+	CreepLoss     float64 `json:"creep_loss,omitempty"`     // ΔfpCR (MPa)
+	ShrinkageLoss float64 `json:"shrinkage_loss,omitempty"` // ΔfpSH (MPa)
+}
+
+func runMaterialCreep(cmd *cobra.Command, args []string) {
+	cond := aci.CreepShrinkageConditions{
+		LoadingAge:       creepLoadingAge,
+		CuringAge:        creepCuringAge,
+		RelativeHumidity: creepHumidity,
+		VolumeSurface:    creepVolumeSurface,
+		SteamCured:       creepSteamCured,
+	}
+	if err := cond.Validate(); err != nil {
+		printError(err)
+		return
+	}
+	if len(creepAges) == 0 {
+		fmt.Printf("Error: at least one age is required\n")
+		setExit(exitInvalidInput)
+		return
+	}
+	for _, t := range creepAges {
+		if t <= 0 {
+			fmt.Printf("Error: ages must be positive, got %g\n", t)
+			setExit(exitInvalidInput)
+			return
+		}
+	}
+	ec := selectedCode.ModulusOfElasticity(creepFc, 0)
+
+	if structuredOutput() || tabularOutput() {
+		var rows []creepRow
+		for _, t := range creepAges {
+			row := creepRow{
+				Age: t,
+				Xi:  nscp.TimeDependentFactor(t / 30.4),
+				Nu:  aci.CreepCoefficient(t, cond),
+				Esh: aci.ShrinkageStrain(t, cond),
+			}
+			if creepFcgp > 0 {
+				row.CreepLoss = aci.CreepLoss(creepEps, ec, row.Nu, creepFcgp)
+				row.ShrinkageLoss = aci.ShrinkageLoss(creepEps, row.Esh)
+			}
+			rows = append(rows, row)
+		}
+		if tabularOutput() {
+			var cells [][]string
+			for _, row := range rows {
+				cells = append(cells, []string{cell(row.Age), cell(row.Xi), cell(row.Nu), cell(row.Esh), cell(row.CreepLoss), cell(row.ShrinkageLoss)})
+			}
+			printTable([]string{"Age (days)", "ξ", "νt", "εsh", "ΔfpCR (MPa)", "ΔfpSH (MPa)"}, cells)
+			return
+		}
+		printReport(cmd, cond, rows)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     CREEP AND SHRINKAGE (NSCP ξ / ACI 209R-92)")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("CONDITIONS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Age at loading:\t%.0f days\n", cond.LoadingAge)
+	curing := "moist"
+	if cond.SteamCured {
+		curing = "steam"
+	}
+	fmt.Fprintf(w, "  Curing:\t%s, %.0f days\n", curing, cond.CuringAge)
+	fmt.Fprintf(w, "  Relative humidity:\t%.0f%%\n", cond.RelativeHumidity)
+	fmt.Fprintf(w, "  Volume-to-surface ratio:\t%.0f mm\n", cond.VolumeSurface)
+	if creepFcgp > 0 {
+		fmt.Fprintf(w, "  Ec (f'c = %.1f MPa):\t%.0f MPa\n", creepFc, ec)
+		fmt.Fprintf(w, "  Eps:\t%.0f MPa\n", creepEps)
+		fmt.Fprintf(w, "  fcgp:\t%.2f MPa\n", creepFcgp)
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("TIME-DEPENDENT FACTORS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	if creepFcgp > 0 {
+		fmt.Fprintf(w, "  Age (days)\tξ\tνt\tεsh (×10⁻⁶)\tΔfpCR (MPa)\tΔfpSH (MPa)\n")
+		fmt.Fprintf(w, "  ──────────\t─\t──\t───────────\t───────────\t───────────\n")
+	} else {
+		fmt.Fprintf(w, "  Age (days)\tξ\tνt\tεsh (×10⁻⁶)\n")
+		fmt.Fprintf(w, "  ──────────\t─\t──\t───────────\n")
+	}
+	for _, t := range creepAges {
+		xi := nscp.TimeDependentFactor(t / 30.4)
+		nu := aci.CreepCoefficient(t, cond)
+		esh := aci.ShrinkageStrain(t, cond)
+		if creepFcgp > 0 {
+			fmt.Fprintf(w, "  %.0f\t%.2f\t%.2f\t%.0f\t%.1f\t%.1f\n", t, xi, nu, esh*1e6,
+				aci.CreepLoss(creepEps, ec, nu, creepFcgp), aci.ShrinkageLoss(creepEps, esh))
+		} else {
+			fmt.Fprintf(w, "  %.0f\t%.2f\t%.2f\t%.0f\n", t, xi, nu, esh*1e6)
+		}
+	}
+	w.Flush()
+	fmt.Println()
+}
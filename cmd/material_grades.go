@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/spf13/cobra"
+)
+
+var materialGradesCmd = &cobra.Command{
+	Use:   "grades",
+	Short: "List the steel grades accepted by --grade",
+	Long: `List the built-in reinforcing steel grades with their yield and tensile
+strengths and yield strain. Any of the listed keys may be given to --grade in
+place of a raw --fy.
+
+Examples:
+  gorcb material grades
+  gorcb beam design -b 300 --height 500 -m 150 --grade 60`,
+	Run: runMaterialGrades,
+}
+
+func init() {
+	materialCmd.AddCommand(materialGradesCmd)
+	tabular(materialGradesCmd)
+}
+
+func runMaterialGrades(cmd *cobra.Command, args []string) {
+	if tabularOutput() {
+		var rows [][]string
+		for _, g := range rebar.Grades {
+			rows = append(rows, []string{g.Name, strings.Join(g.Aliases, " "), cell(g.Fy), cell(g.Fu), cell(g.Es), g.Description})
+		}
+		printTable([]string{"Grade", "Keys", "fy (MPa)", "fu (MPa)", "Es (MPa)", "Description"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, rebar.Grades)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     REINFORCING STEEL GRADES")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Grade\tKeys\tfy (MPa)\tfu (MPa)\tεy")
+	fmt.Fprintln(w, "  ─────\t────\t────────\t────────\t──")
+	for _, g := range rebar.Grades {
+		fmt.Fprintf(w, "  %s\t%s\t%.0f\t%.0f\t%.5f\n", g.Name, strings.Join(g.Aliases, ", "), g.Fy, g.Fu, g.YieldStrain())
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -2,8 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/alexiusacademia/gorcb/internal/nscp"
 	"github.com/spf13/cobra"
@@ -11,25 +9,39 @@ import (
 
 var (
 	// Unfactored moments (kN-m)
-	momentDead       float64
-	momentLive       float64
-	momentRoof       float64
-	momentWind       float64
-	momentEarthquake float64
-	momentRain       float64
+	momentDead        float64
+	momentLive        float64
+	momentRoof        float64
+	momentWind        float64
+	momentEarthquake  float64
+	momentRain        float64
+	momentSnow        float64
+	momentFluid       float64
+	momentEarth       float64
+	momentTemperature float64
 
 	// Options
-	showAll      bool
-	useSimplified bool
+	showAll bool
+
+	// Load combination selection
+	momentCombinations combinationInputs
+
+	// Service-level combinations for serviceability checks
+	momentService       bool
+	momentSustainedLive float64
 )
 
 var momentCmd = &cobra.Command{
 	Use:   "moment",
-	Short: "Calculate factored moment using NSCP load combinations",
-	Long: `Calculate the factored moment (Mu) based on NSCP 2015 load combinations.
+	Short: "Calculate factored moment using design code load combinations",
+	Long: `Calculate the factored moment (Mu) based on the load combinations of the
+selected design code (NSCP 2015 Section 203.3 by default, ACI 318-19 Table 5.3.1
+with --code aci318-19, EN 1990 Eq. 6.10 with --code ec2, NSCP 2010 Section 203.3
+with --code nscp2010).
 
 Provide unfactored moments from different load types and this command will
-compute the factored moments for all applicable NSCP load combinations.
+compute the factored moments for all applicable load combinations.
+Use 'gorcb loads' to factor shear and axial force together with the moment.
 
 Load Types:
   D  - Dead load
@@ -38,6 +50,10 @@ Load Types:
   W  - Wind load
   E  - Earthquake load
   R  - Rain load
+  S  - Snow load (ACI 318-19 and Eurocode only)
+  F  - Fluid pressure (tanks, reservoirs)
+  H  - Lateral earth pressure (basement and retaining walls)
+  T  - Self-straining effects (temperature, creep, shrinkage)
 
 Examples:
   # Simple gravity loads (dead + live)
@@ -47,12 +63,41 @@ Examples:
   gorcb moment --dead 50 --live 30 --wind 20
 
   # Show all combinations
-  gorcb moment --dead 50 --live 30 --all`,
+  gorcb moment --dead 50 --live 30 --all
+
+  # ACI 318-19 combinations with snow
+  gorcb moment --dead 50 --live 30 --snow 10 --code aci318-19
+
+  # Earthquake moment as Eh with redundancy, vertical component and overstrength
+  gorcb moment --dead 50 --live 30 --earthquake 40 --rho 1.3 --sds 1.0 --omega0 2.5 --overstrength
+
+  # Allowable stress design (service-level) combinations, e.g. for footings
+  gorcb moment --dead 50 --live 30 --method asd
+
+  # Unfactored service combinations and sustained moment for deflection/crack checks
+  gorcb moment --dead 50 --live 30 --wind 20 --service --sustained-live 0.3
+
+  # Project-specific combinations from a JSON or YAML file
+  gorcb moment --dead 50 --live 30 --combinations examples/combinations.yaml --set warehouse
+
+Combination files hold named sets. A set with "append: true" is added to the
+built-in combinations of the selected code instead of replacing them:
+
+  sets:
+    - name: warehouse
+      append: true
+      combinations:
+        - id: W1
+          description: 1.2D + 1.8L (heavy storage)
+          dead: 1.2
+          live: 1.8`,
 	Run: runMoment,
 }
 
 func init() {
 	rootCmd.AddCommand(momentCmd)
+	unitAware(momentCmd)
+	tabular(momentCmd)
 
 	// Load moment flags
 	momentCmd.Flags().Float64VarP(&momentDead, "dead", "d", 0, "Moment due to dead load (kN-m)")
@@ -61,86 +106,178 @@ func init() {
 	momentCmd.Flags().Float64VarP(&momentWind, "wind", "w", 0, "Moment due to wind load (kN-m)")
 	momentCmd.Flags().Float64VarP(&momentEarthquake, "earthquake", "e", 0, "Moment due to earthquake load (kN-m)")
 	momentCmd.Flags().Float64VarP(&momentRain, "rain", "R", 0, "Moment due to rain load (kN-m)")
+	momentCmd.Flags().Float64VarP(&momentSnow, "snow", "S", 0, "Moment due to snow load (kN-m)")
+	momentCmd.Flags().Float64VarP(&momentFluid, "fluid", "F", 0, "Moment due to fluid pressure (kN-m)")
+	momentCmd.Flags().Float64VarP(&momentEarth, "earth", "H", 0, "Moment due to lateral earth pressure (kN-m)")
+	momentCmd.Flags().Float64VarP(&momentTemperature, "temperature", "T", 0, "Moment due to self-straining effects (kN-m)")
 
 	// Options
 	momentCmd.Flags().BoolVarP(&showAll, "all", "a", false, "Show all load combination results")
-	momentCmd.Flags().BoolVarP(&useSimplified, "simplified", "s", false, "Use simplified combinations (gravity only: 1.4D and 1.2D+1.6L)")
+	addCombinationFlags(momentCmd, &momentCombinations)
+	momentCmd.Flags().BoolVar(&momentService, "service", false, "Also show the service-level combinations used for serviceability checks")
+	momentCmd.Flags().Float64Var(&momentSustainedLive, "sustained-live", 0, "Sustained fraction ψ of the live load for the sustained moment (0 to 1)")
+}
+
+// momentCombination is a load combination with its governing branch
+type momentCombination struct {
+	Combination nscp.LoadCombination   `json:"combination"`
+	Branch      nscp.CombinationBranch `json:"branch"`
+}
+
+// momentReport is the JSON result of the moment command
+type momentReport struct {
+	Combinations   []momentCombination  `json:"combinations"`
+	Moment         float64              `json:"governing_moment"`
+	Governing      string               `json:"governing_combination"`
+	ReversalMoment float64              `json:"reversal_moment,omitempty"`
+	Reversal       string               `json:"reversal_combination,omitempty"`
+	Service        *nscp.ServiceMoments `json:"service,omitempty"`
 }
 
 func runMoment(cmd *cobra.Command, args []string) {
 	moments := nscp.LoadMoments{
-		Dead:       momentDead,
-		Live:       momentLive,
-		Roof:       momentRoof,
-		Wind:       momentWind,
-		Earthquake: momentEarthquake,
-		Rain:       momentRain,
+		Dead:        momentDead,
+		Live:        momentLive,
+		Roof:        momentRoof,
+		Wind:        momentWind,
+		Earthquake:  momentEarthquake,
+		Rain:        momentRain,
+		Snow:        momentSnow,
+		Fluid:       momentFluid,
+		Earth:       momentEarth,
+		Temperature: momentTemperature,
 	}
 
 	// Check if any moment is provided
 	if moments.Dead == 0 && moments.Live == 0 && moments.Roof == 0 &&
-		moments.Wind == 0 && moments.Earthquake == 0 && moments.Rain == 0 {
+		moments.Wind == 0 && moments.Earthquake == 0 && moments.Rain == 0 &&
+		moments.Snow == 0 && moments.Fluid == 0 && moments.Earth == 0 &&
+		moments.Temperature == 0 {
 		fmt.Println("Error: Please provide at least one unfactored moment.")
 		fmt.Println("Use 'gorcb moment --help' for usage information.")
+		setExit(exitInvalidInput)
+		return
+	}
+
+	combinations, err := momentCombinations.resolve(cmd)
+	if err != nil {
+		printError(err)
 		return
 	}
+	isASD := momentCombinations.ASD
+	combinationsTitle := momentCombinations.Title
 
-	// Select which combinations to use
-	combinations := nscp.LoadCombinations
-	if useSimplified {
-		combinations = nscp.SimplifiedCombinations
+	// Calculate governing moment
+	maxMu, governingCombo := nscp.CalculateGoverningMoment(moments, combinations)
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, combo := range combinations {
+			branch, _ := combo.GoverningBranch(moments)
+			governs := ""
+			if combo.ID == governingCombo.ID {
+				governs = momentSymbol(isASD)
+			}
+			rows = append(rows, []string{combo.ID, combo.Description, branch.Describe(), cell(branch.Moment), governs})
+		}
+		printTable([]string{"Combination", "Description", "Branch", momentSymbol(isASD) + " (kN-m)", "Governs"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		report := momentReport{
+			Moment:    maxMu,
+			Governing: governingCombo.ID,
+		}
+		for _, combo := range combinations {
+			branch, _ := combo.GoverningBranch(moments)
+			report.Combinations = append(report.Combinations, momentCombination{Combination: combo, Branch: branch})
+		}
+		if minMu, reversalCombo, _ := nscp.CalculateReversalMoment(moments, combinations); minMu < 0 {
+			report.ReversalMoment = minMu
+			report.Reversal = reversalCombo.ID
+		}
+		if momentService {
+			if err := nscp.ValidateSustainedLive(momentSustainedLive); err != nil {
+				printError(err)
+				return
+			}
+			sm := nscp.CalculateServiceMoments(moments, nscp.ServiceCombinations, momentSustainedLive)
+			report.Service = &sm
+		}
+		printReport(cmd, moments, report)
+		return
 	}
 
 	// Print header
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("          NSCP 2015 FACTORED MOMENT CALCULATION")
+	if isASD {
+		fmt.Printf("          %s ASD MOMENT CALCULATION\n", selectedCode.Name())
+	} else {
+		fmt.Printf("          %s FACTORED MOMENT CALCULATION\n", selectedCode.Name())
+	}
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Print input moments
-	fmt.Println("UNFACTORED MOMENTS (kN-m):")
+	fmt.Printf("UNFACTORED MOMENTS (%s):\n", selectedUnits.Moment.Label)
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTextWriter()
 	if moments.Dead != 0 {
-		fmt.Fprintf(w, "  Dead Load (D):\t%.2f\n", moments.Dead)
+		fmt.Fprintf(w, "  Dead Load (D):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Dead))
 	}
 	if moments.Live != 0 {
-		fmt.Fprintf(w, "  Live Load (L):\t%.2f\n", moments.Live)
+		fmt.Fprintf(w, "  Live Load (L):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Live))
 	}
 	if moments.Roof != 0 {
-		fmt.Fprintf(w, "  Roof Live Load (Lr):\t%.2f\n", moments.Roof)
+		fmt.Fprintf(w, "  Roof Live Load (Lr):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Roof))
 	}
 	if moments.Wind != 0 {
-		fmt.Fprintf(w, "  Wind Load (W):\t%.2f\n", moments.Wind)
+		fmt.Fprintf(w, "  Wind Load (W):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Wind))
 	}
 	if moments.Earthquake != 0 {
-		fmt.Fprintf(w, "  Earthquake Load (E):\t%.2f\n", moments.Earthquake)
+		if momentCombinations.ExpandSeismic {
+			fmt.Fprintf(w, "  Horizontal Earthquake (Eh):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Earthquake))
+		} else {
+			fmt.Fprintf(w, "  Earthquake Load (E):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Earthquake))
+		}
 	}
 	if moments.Rain != 0 {
-		fmt.Fprintf(w, "  Rain Load (R):\t%.2f\n", moments.Rain)
+		fmt.Fprintf(w, "  Rain Load (R):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Rain))
+	}
+	if moments.Snow != 0 {
+		fmt.Fprintf(w, "  Snow Load (S):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Snow))
+	}
+	if moments.Fluid != 0 {
+		fmt.Fprintf(w, "  Fluid Pressure (F):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Fluid))
+	}
+	if moments.Earth != 0 {
+		fmt.Fprintf(w, "  Lateral Earth Pressure (H):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Earth))
+	}
+	if moments.Temperature != 0 {
+		fmt.Fprintf(w, "  Self-straining (T):\t%.2f\n", selectedUnits.Moment.FromSI(moments.Temperature))
 	}
 	w.Flush()
 	fmt.Println()
 
-	// Calculate governing moment
-	maxMu, governingCombo := nscp.CalculateGoverningMoment(moments, combinations)
+	momentCombinations.printSeismicEffect(selectedUnits.Moment.FromSI(moments.Dead), selectedUnits.Moment.Label)
 
 	if showAll {
 		// Show all combinations
-		fmt.Println("LOAD COMBINATIONS (NSCP 2015 Section 203.3):")
+		fmt.Printf("LOAD COMBINATIONS (%s):\n", combinationsTitle)
 		fmt.Println("───────────────────────────────────────────────────────────────")
-		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "  #\tCombination\tMu (kN-m)\n")
-		fmt.Fprintf(w, "  ─\t───────────\t─────────\n")
+		w = newTextWriter()
+		fmt.Fprintf(w, "  #\tCombination\tBranch\t%s (%s)\n", momentSymbol(isASD), selectedUnits.Moment.Label)
+		fmt.Fprintf(w, "  ─\t───────────\t──────\t─────────\n")
 
 		for _, combo := range combinations {
-			mu := combo.CalculateFactoredMoment(moments)
+			branch, _ := combo.GoverningBranch(moments)
 			marker := ""
 			if combo.ID == governingCombo.ID {
 				marker = " ← GOVERNS"
 			}
-			fmt.Fprintf(w, "  %s\t%s\t%.2f%s\n", combo.ID, combo.Description, mu, marker)
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%.2f%s\n", combo.ID, combo.Description, branch.Describe(), selectedUnits.Moment.FromSI(branch.Moment), marker)
 		}
 		w.Flush()
 		fmt.Println()
@@ -150,10 +287,70 @@ func runMoment(cmd *cobra.Command, args []string) {
 	fmt.Println("RESULT:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
 	fmt.Printf("  Governing Combination: %s (%s)\n", governingCombo.ID, governingCombo.Description)
+	governingBranch, _ := governingCombo.GoverningBranch(moments)
+	fmt.Printf("  Governing Branch:      %s\n", governingBranch.Describe())
 	fmt.Println()
 	fmt.Printf("  ╔═══════════════════════════════════╗\n")
-	fmt.Printf("  ║  FACTORED MOMENT (Mu) = %.2f kN-m  \n", maxMu)
+	if isASD {
+		fmt.Printf("  ║  SERVICE MOMENT (Ma) = %s  \n", fmtMoment(maxMu, 2))
+	} else {
+		fmt.Printf("  ║  FACTORED MOMENT (Mu) = %s  \n", fmtMoment(maxMu, 2))
+	}
 	fmt.Printf("  ╚═══════════════════════════════════╝\n")
+
+	// Reversed wind or earthquake can produce a moment of opposite sign
+	minMu, reversalCombo, reversalBranch := nscp.CalculateReversalMoment(moments, combinations)
+	if minMu < 0 {
+		fmt.Println()
+		fmt.Printf("  Moment Reversal: %s = %s\n", momentSymbol(isASD), fmtMoment(minMu, 2))
+		fmt.Printf("    Combination %s, branch %s\n", reversalCombo.ID, reversalBranch.Describe())
+	}
 	fmt.Println()
+
+	if momentService {
+		printServiceCombinations(moments)
+	}
 }
 
+// printServiceCombinations prints the unfactored service combinations and the
+// service moments fed to the deflection, crack control and service stress checks
+func printServiceCombinations(moments nscp.LoadMoments) {
+	if err := nscp.ValidateSustainedLive(momentSustainedLive); err != nil {
+		printError(err)
+		return
+	}
+	sm := nscp.CalculateServiceMoments(moments, nscp.ServiceCombinations, momentSustainedLive)
+
+	fmt.Println("SERVICE COMBINATIONS (serviceability):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  #\tCombination\tBranch\tMa (%s)\n", selectedUnits.Moment.Label)
+	fmt.Fprintf(w, "  ─\t───────────\t──────\t─────────\n")
+	for _, combo := range nscp.ServiceCombinations {
+		branch, _ := combo.GoverningBranch(moments)
+		marker := ""
+		if combo.ID == sm.TotalCombo.ID {
+			marker = " ← GOVERNS"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%.2f%s\n", combo.ID, combo.Description, branch.Describe(), selectedUnits.Moment.FromSI(branch.Moment), marker)
+	}
+	sustained := nscp.SustainedCombination(momentSustainedLive)
+	fmt.Fprintf(w, "  %s\t%s\t\t%.2f\n", sustained.ID, sustained.Description, selectedUnits.Moment.FromSI(sm.Sustained))
+	w.Flush()
+	fmt.Println()
+
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Total service moment (Ma):\t%s\n", fmtMoment(sm.Total, 2))
+	fmt.Fprintf(w, "  Sustained moment (Msus):\t%s\n", fmtMoment(sm.Sustained, 2))
+	fmt.Fprintf(w, "  Transient moment (Ma - MD):\t%s\n", fmtMoment(sm.Transient, 2))
+	w.Flush()
+	fmt.Println()
+}
+
+// momentSymbol returns the moment symbol for the design method (Ma for ASD, Mu for strength design)
+func momentSymbol(asd bool) string {
+	if asd {
+		return "Ma"
+	}
+	return "Mu"
+}
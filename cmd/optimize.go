@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	optimizeMu      float64
+	optimizeVu      float64
+	optimizeBase    string
+	optimizeWidths  string
+	optimizeHeights string
+	optimizeMinBars int
+	optimizeMaxBars int
+	optimizeTop     int
+	optimizeCosts   costInputs
+
+	optimizeConcreteType string
+)
+
+var optimizeCmd = &cobra.Command{
+	Use:   "optimize",
+	Short: "Find the minimum-cost beam section and bar layout for a demand",
+	Long: `Search rectangular beam sections and bar layouts for the least expensive
+adequate design under a factored moment (and shear).
+
+Every width and height of the search is designed as a singly reinforced
+section. The required steel is then provided with one layer of identical bars
+of each flexural size of the rebar catalog (see --catalog), from --min-bars to
+--max-bars bars with a clear spacing of at least 25 mm and one bar diameter
+inside the stirrups. Each layout is analyzed again and kept when φMn ≥ Mu and
+the stirrups are adequate. Candidates are ranked by the cost of concrete,
+steel (bars and stirrups) and formwork per --length of beam.
+
+Widths and heights are ranges start:stop:step or lists v1,v2,... in mm.
+The remaining properties (cover, fc, fy, grade, stirrup_dia, ...) are given
+with --base as in 'gorcb batch run'.
+
+Examples:
+  # Cheapest section from 250-400 mm wide and 400-800 mm deep
+  gorcb optimize --mu 250 --vu 180 --width 250:400:50 --height 400:800:50
+
+  # With local unit prices and f'c 28 MPa, grade 60 bars
+  gorcb optimize --mu 250 --width 250,300 --height 400:700:50 --base fc=28,grade=60 \
+    --concrete-price 7000 --steel-price 80 --formwork-price 700`,
+	Run: runOptimize,
+}
+
+func init() {
+	rootCmd.AddCommand(optimizeCmd)
+	tabular(optimizeCmd)
+
+	optimizeCmd.Flags().Float64VarP(&optimizeMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
+	optimizeCmd.Flags().Float64Var(&optimizeVu, "vu", 0, "Factored shear Vu for stirrup design (kN)")
+	optimizeCmd.Flags().StringVar(&optimizeWidths, "width", "", "Widths to search as start:stop:step or v1,v2,... (mm) [required]")
+	optimizeCmd.Flags().StringVar(&optimizeHeights, "height", "", "Heights to search as start:stop:step or v1,v2,... (mm) [required]")
+	optimizeCmd.Flags().StringVar(&optimizeBase, "base", "", "Other beam properties as name=value pairs, e.g. fc=28,grade=60")
+	optimizeCmd.Flags().StringVar(&optimizeConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
+	optimizeCmd.Flags().IntVar(&optimizeMinBars, "min-bars", suggestMinBars, "Fewest tension bars in a layout")
+	optimizeCmd.Flags().IntVar(&optimizeMaxBars, "max-bars", suggestMaxBars, "Most tension bars in a layout")
+	optimizeCmd.Flags().IntVar(&optimizeTop, "top", 10, "Number of ranked candidates to list")
+	addCostFlags(optimizeCmd, &optimizeCosts)
+
+	optimizeCmd.MarkFlagRequired("mu")
+	optimizeCmd.MarkFlagRequired("width")
+	optimizeCmd.MarkFlagRequired("height")
+}
+
+func runOptimize(cmd *cobra.Command, args []string) {
+	if err := optimizeCosts.validate(); err != nil {
+		printError(err)
+		return
+	}
+	if optimizeTop < 1 {
+		fmt.Println("Error: --top must be at least 1")
+		setExit(exitInvalidInput)
+		return
+	}
+	base, err := batch.ParseMember(optimizeBase)
+	if err != nil {
+		printError(fmt.Errorf("base: %w", err))
+		return
+	}
+	base.Mu, base.Vu = optimizeMu, optimizeVu
+	if base.Lambda == 0 {
+		if base.Lambda, err = concreteLambda(optimizeConcreteType); err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	widths, err := batch.ParseParameter("width=" + optimizeWidths)
+	if err != nil {
+		printError(err)
+		return
+	}
+	heights, err := batch.ParseParameter("height=" + optimizeHeights)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	opts := batch.OptimizeOptions{
+		Widths:  widths.Values,
+		Heights: heights.Values,
+		MinBars: optimizeMinBars,
+		MaxBars: optimizeMaxBars,
+		Length:  optimizeCosts.Length,
+		Costs:   optimizeCosts.Costs,
+	}
+	candidates, tried, err := batch.Optimize(base, selectedCatalog, opts, selectedCode)
+	if err != nil {
+		printError(err)
+		return
+	}
+	ranked := candidates[:min(optimizeTop, len(candidates))]
+	checkAdequacy(len(candidates) > 0)
+
+	if tabularOutput() {
+		var rows [][]string
+		for i, c := range ranked {
+			m := c.Result.Member
+			spacing := ""
+			if c.Result.Shear != nil {
+				spacing = cell(c.Result.Shear.Spacing)
+			}
+			rows = append(rows, []string{fmt.Sprint(i + 1), cell(m.Width), cell(m.Height), c.Layout.Label(), cell(c.Layout.Area),
+				cell(c.Result.PhiMn), cell(c.Result.Utilization()), spacing,
+				cell(c.Quantities.ConcreteCost), cell(c.Quantities.SteelCost), cell(c.Quantities.FormworkCost), cell(c.Quantities.Total)})
+		}
+		printTable([]string{"Rank", "b (mm)", "h (mm)", "Bars", "As (mm²)", "φMn (kN-m)", "Mu/φMn", "Stirrups (mm)",
+			"Concrete cost", "Steel cost", "Formwork cost", "Total cost"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"base": base, "widths": opts.Widths, "heights": opts.Heights,
+			"min_bars": opts.MinBars, "max_bars": opts.MaxBars, "catalog": selectedCatalog.Name,
+			"length": opts.Length, "unit_costs": opts.Costs},
+			map[string]any{"sections_tried": tried, "feasible": len(candidates), "candidates": ranked})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     COST OPTIMIZATION - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("SEARCH:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", optimizeMu)
+	if optimizeVu > 0 {
+		fmt.Fprintf(w, "  Factored Shear (Vu):\t%.2f kN\n", optimizeVu)
+	}
+	fmt.Fprintf(w, "  Widths:\t%.0f to %.0f mm (%d values)\n", opts.Widths[0], opts.Widths[len(opts.Widths)-1], len(opts.Widths))
+	fmt.Fprintf(w, "  Heights:\t%.0f to %.0f mm (%d values)\n", opts.Heights[0], opts.Heights[len(opts.Heights)-1], len(opts.Heights))
+	fmt.Fprintf(w, "  Bars:\t%d to %d bars from catalog %s\n", opts.MinBars, opts.MaxBars, selectedCatalog.Name)
+	fmt.Fprintf(w, "  Unit costs:\tconcrete %.2f/m³, steel %.2f/kg, formwork %.2f/m²\n",
+		opts.Costs.Concrete, opts.Costs.Steel, opts.Costs.Formwork)
+	fmt.Fprintf(w, "  Sections tried:\t%d (%d adequate layouts)\n", tried, len(candidates))
+	w.Flush()
+	fmt.Println()
+
+	if len(candidates) == 0 {
+		fmt.Println("  No adequate design in the search. Widen the ranges or allow more bars.")
+		fmt.Println()
+		return
+	}
+
+	best := candidates[0]
+	m, q := best.Result.Member, best.Quantities
+	fmt.Println("OPTIMUM DESIGN:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Section (b × h):\t%.0f × %.0f mm\n", m.Width, m.Height)
+	fmt.Fprintf(w, "  Tension Bars:\t%s (As = %.2f mm², clear spacing %.0f mm)\n", best.Layout.Label(), best.Layout.Area, best.Layout.ClearSpacing)
+	fmt.Fprintf(w, "  Design Strength (φMn):\t%.2f kN-m\n", best.Result.PhiMn)
+	fmt.Fprintf(w, "  Utilization (Mu/φMn):\t%.3f\n", best.Result.Utilization())
+	fmt.Fprintf(w, "  Tensile Strain (εt):\t%.5f (φ = %.2f)\n", best.Result.EpsilonT, best.Result.Phi)
+	if optimizeVu > 0 {
+		fmt.Fprintf(w, "  Stirrups:\t%s\n", batchStirrups(best.Result))
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Printf("COST BREAKDOWN (per %.2f m):\n", q.Length)
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Concrete:\t%.3f m³\t%.2f\n", q.Concrete, q.ConcreteCost)
+	fmt.Fprintf(w, "  Steel:\t%.2f kg\t%.2f\n", q.Steel, q.SteelCost)
+	fmt.Fprintf(w, "  Formwork:\t%.2f m²\t%.2f\n", q.Formwork, q.FormworkCost)
+	fmt.Fprintf(w, "  Total:\t\t%.2f\n", q.Total)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Printf("RANKED CANDIDATES (%d of %d):\n", len(ranked), len(candidates))
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintln(w, "  #\tb × h (mm)\tBars\tAs (mm²)\tφMn (kN-m)\tMu/φMn\tCost\tvs Optimum")
+	fmt.Fprintln(w, "  ─\t──────────\t────\t────────\t──────────\t──────\t────\t──────────")
+	for i, c := range ranked {
+		fmt.Fprintf(w, "  %d\t%.0f × %.0f\t%s\t%.2f\t%.2f\t%.3f\t%.2f\t%+.1f%%\n", i+1,
+			c.Result.Member.Width, c.Result.Member.Height, c.Layout.Label(), c.Layout.Area,
+			c.Result.PhiMn, c.Result.Utilization(), c.Quantities.Total, percentChange(q.Total, c.Quantities.Total))
+	}
+	w.Flush()
+	fmt.Println()
+}
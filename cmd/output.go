@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats selected with --format
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+)
+
+// tabularAnnotation marks commands whose results include a table that can be
+// written with --format csv or tsv
+const tabularAnnotation = "gorcb/tabular"
+
+// Output format selected with --format
+var outputFormat = formatText
+
+// outputReport is the document written by a command with --format json or yaml
+type outputReport struct {
+	Command string `json:"command"`
+	Code    string `json:"code"`
+	Input   any    `json:"input,omitempty"`
+	Result  any    `json:"result"`
+
+	// Checks and messages of the plugins run after the analysis
+	Plugins []pluginResult `json:"plugins,omitempty"`
+}
+
+// analysisReport is the JSON result of an analysis command with the results
+// of its optional checks
+type analysisReport struct {
+	Analysis  any                        `json:"analysis"`
+	Torsion   *section.TorsionProperties `json:"torsion,omitempty"`
+	Ductility *nscp.DuctilityResult      `json:"ductility,omitempty"`
+	Service   *beam.ServiceResult        `json:"service,omitempty"`
+	Confined  *section.ConfinedResult    `json:"confined,omitempty"`
+
+	Interaction *section.Interaction `json:"interaction,omitempty"`
+	Load        *interactionLoad     `json:"load,omitempty"`
+	Biaxial     *section.Biaxial     `json:"biaxial,omitempty"`
+	BiaxialLoad *biaxialLoad         `json:"biaxial_load,omitempty"`
+}
+
+// validateFormat checks the --format value for the command being run
+func validateFormat(cmd *cobra.Command) error {
+	switch outputFormat {
+	case formatText, formatJSON, formatYAML:
+		return nil
+	case formatCSV, formatTSV:
+		if cmd.Annotations[tabularAnnotation] == "" {
+			return fmt.Errorf("%s has no tabular output for --format %s (use %s, %s or %s)",
+				cmd.CommandPath(), outputFormat, formatText, formatJSON, formatYAML)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q (use %s, %s, %s, %s or %s)",
+		outputFormat, formatText, formatJSON, formatYAML, formatCSV, formatTSV)
+}
+
+// tabular marks a command as supporting --format csv and tsv
+func tabular(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[tabularAnnotation] = "true"
+}
+
+// tabularOutput reports whether results are written as CSV or TSV rows
+func tabularOutput() bool {
+	return outputFormat == formatCSV || outputFormat == formatTSV
+}
+
+// printTable writes a header and rows to stdout as CSV or TSV
+func printTable(header []string, rows [][]string) {
+	w := csv.NewWriter(os.Stdout)
+	if outputFormat == formatTSV {
+		w.Comma = '\t'
+	}
+	w.Write(header)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		setExit(exitFailure)
+	}
+}
+
+// cell formats a number for a CSV or TSV cell with the decimals of
+// --precision, or without rounding
+func cell(v float64) string {
+	if outputPrecision >= 0 {
+		return strconv.FormatFloat(v, 'f', outputPrecision, 64)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// structuredOutput reports whether results are written as JSON or YAML
+// instead of tables
+func structuredOutput() bool {
+	return outputFormat == formatJSON || outputFormat == formatYAML
+}
+
+// printReport writes the inputs and results of a command to stdout in the
+// selected structured format. YAML is converted from the JSON document so
+// both formats share the same keys and field order.
+func printReport(cmd *cobra.Command, input, result any) {
+	report := outputReport{
+		Command: cmd.CommandPath(),
+		Code:    selectedCode.Name(),
+		Input:   input,
+		Result:  result,
+		Plugins: pluginResults,
+	}
+	var data []byte
+	var err error
+	if outputFormat == formatYAML {
+		data, err = toYAML(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		setExit(exitFailure)
+		return
+	}
+	fmt.Println(strings.TrimRight(string(data), "\n"))
+}
+
+// toYAML encodes v as block-style YAML with the keys of its JSON encoding
+func toYAML(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles parsed from JSON
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
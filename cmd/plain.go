@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alexiusacademia/gorcb/internal/ascii"
+)
+
+// Plain ASCII output selected with --plain
+var plainOutput bool
+
+// textWriter aligns the tab-separated cells of text output on stdout. With
+// --plain the cells are spelled in ASCII before they are aligned, so the
+// columns stay aligned.
+type textWriter struct {
+	*tabwriter.Writer
+}
+
+// newTextWriter returns the writer of an aligned block of text output
+func newTextWriter() textWriter {
+	return textWriter{tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)}
+}
+
+func (w textWriter) Write(p []byte) (int, error) {
+	if !plainOutput {
+		return w.Writer.Write(p)
+	}
+	if _, err := w.Writer.Write([]byte(ascii.Spell(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// plainStdout is the stdout of the command, and plainDone is closed when
+// everything written to the pipe replacing it was spelled out
+var (
+	plainStdout *os.File
+	plainDone   chan struct{}
+)
+
+// setupPlain replaces stdout with a pipe spelling everything the command
+// prints in ASCII with --plain
+func setupPlain() error {
+	if !plainOutput {
+		return nil
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	plainStdout, plainDone = os.Stdout, make(chan struct{})
+	os.Stdout = w
+	go func() {
+		defer close(plainDone)
+		in, out := bufio.NewReader(r), bufio.NewWriter(plainStdout)
+		for {
+			c, _, err := in.ReadRune()
+			if err != nil {
+				break
+			}
+			out.WriteString(ascii.Rune(c))
+			// Pass lines on as they are printed, e.g. by watch and serve
+			if c == '\n' || in.Buffered() == 0 {
+				out.Flush()
+			}
+		}
+		out.Flush()
+	}()
+	return nil
+}
+
+// closePlain writes out the rest of the output printed with --plain
+func closePlain() {
+	if plainStdout == nil {
+		return
+	}
+	os.Stdout.Close()
+	<-plainDone
+	os.Stdout = plainStdout
+}
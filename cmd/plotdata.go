@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+)
+
+// plotData writes the data series of each exported diagram to a CSV file
+// beside it, set with --plot-data
+var plotData bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&plotData, "plot-data", false,
+		"Also write the data series of each exported diagram to a CSV file beside it, e.g. beam.csv for beam.png")
+}
+
+// printPlotData prints where the data series of a diagram exported to file
+// were written with --plot-data
+func printPlotData(file string) {
+	if plotData {
+		fmt.Printf("Plot data written to: %s\n", diagram.DataFile(file))
+	}
+}
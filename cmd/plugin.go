@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/plugin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Plugins registered in the configuration, skipped with --no-plugins
+	configuredPlugins []plugin.Plugin
+	noPlugins         bool
+
+	// Results of the plugins run after the analysis of the command
+	pluginResults []pluginResult
+)
+
+// pluginResult is the answer of a plugin, or the error it failed with
+type pluginResult struct {
+	Plugin string `json:"plugin"`
+	plugin.Output
+	Error string `json:"error,omitempty"`
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noPlugins, "no-plugins", false,
+		"Do not run the plugins registered in the configuration after the analysis")
+}
+
+// runPlugins runs the plugins registered for a command on its inputs and
+// results, as the JSON document of --format json. Their checks are added to
+// the adequacy of the command, and a plugin that fails sets exit code 1.
+func runPlugins(cmd *cobra.Command, input, result any) {
+	pluginResults = nil
+	if noPlugins || len(configuredPlugins) == 0 {
+		return
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	document, err := json.Marshal(outputReport{
+		Command: cmd.CommandPath(),
+		Code:    selectedCode.Name(),
+		Input:   input,
+		Result:  result,
+	})
+	if err != nil {
+		printError(err)
+		return
+	}
+	for _, p := range configuredPlugins {
+		if !p.Runs(command) {
+			continue
+		}
+		slog.Debug("plugin", "name", p.Name, "exec", p.Exec)
+		out, err := p.Run(document)
+		r := pluginResult{Plugin: p.Name, Output: out}
+		if err != nil {
+			r.Error = err.Error()
+			slog.Error("plugin failed", "name", p.Name, "error", err)
+			setExit(exitFailure)
+		}
+		checkAdequacy(out.Adequate())
+		pluginResults = append(pluginResults, r)
+	}
+}
+
+// printPluginResults prints the checks, messages and errors of the plugins
+// after the text output of the command
+func printPluginResults() {
+	if len(pluginResults) == 0 || tabularOutput() || structuredOutput() || quietOutput {
+		return
+	}
+	fmt.Println("PLUGIN CHECKS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	var rows [][]string
+	for _, r := range pluginResults {
+		for _, c := range r.Checks {
+			rows = append(rows, append([]string{r.Plugin}, checkRow(c)...))
+		}
+	}
+	if len(rows) > 0 {
+		w := newTextWriter()
+		fmt.Fprintln(w, "  Plugin\tLimit State\tCheck\tValue\tLimit\tStatus\tNote")
+		fmt.Fprintln(w, "  ──────\t───────────\t─────\t─────\t─────\t──────\t────")
+		for _, row := range rows {
+			note := strings.TrimSpace(strings.TrimPrefix(row[3], "-") + "  " + row[7])
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\n", row[0], row[1], row[2], row[4], row[5], row[6], note)
+		}
+		w.Flush()
+	}
+	for _, r := range pluginResults {
+		for _, m := range r.Messages {
+			fmt.Printf("  %s: %s\n", r.Plugin, m)
+		}
+		if r.Error != "" {
+			fmt.Printf("  ✗ Plugin %s failed: %s\n", r.Plugin, r.Error)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Design, check and report every member of a project file",
+	Long: `Work with a project file: one JSON or YAML file describing the beams and
+sections of a structure with their loads.
+
+Subcommands:
+  run     - Design or check every member and summarize the results
+  check   - Verify the provided reinforcement of every member
+  report  - Write one combined calculation report of the project
+
+A project file lists rectangular beams (the fields of 'gorcb batch run') and
+non-rectangular sections (a section file checked for Mu). Instead of mu and
+vu, a member may give its unfactored loads as M[,V] per load type; the
+strength combinations of the selected code then give the governing Mu and Vu.
+Defaults apply to the properties a beam omits, e.g.
+
+  name: Two-Storey Residence
+  defaults: {fc: 28, grade: 415, cover: 65}
+  beams:
+    - {id: B1, width: 300, height: 500, loads: {D: [50, 80], L: [30, 45]}}
+    - {id: B2, width: 300, height: 500, mu: 150, vu: 120, as: 1256.64}
+  sections:
+    - {id: TB1, file: t-beam.json, loads: {D: [80], L: [50]}}
+
+With --xlsx the results are also written to an Excel workbook with one sheet
+per member.`,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+}
+
+// projectRows returns the summary table rows of the beams and sections
+func projectRows(result *project.Result) [][]string {
+	var rows [][]string
+	for _, b := range result.Beams {
+		rows = append(rows, batchRow(b.Result))
+	}
+	for _, s := range result.Sections {
+		row := make([]string, len(batchHeader))
+		row[0], row[1], row[2] = s.ID, "section", "analysis"
+		row[5] = cell(s.Mu)
+		if s.Analysis != nil {
+			row[8] = cell(s.Analysis.PhiMn)
+		}
+		row[12], row[13] = s.Status(), s.Message
+		if s.Error != "" {
+			row[13] = s.Error
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// printProjectQuiet keeps the one-line results of the beams and sections
+// and prints them with --quiet
+func printProjectQuiet(result *project.Result) {
+	for _, b := range result.Beams {
+		printQuiet(quietBatch(b.Result))
+	}
+	for _, s := range result.Sections {
+		q := quietResult{ID: s.ID, Status: s.Status(), Error: s.Error}
+		if s.Analysis != nil {
+			q.PhiMn = s.Analysis.PhiMn
+		}
+		printQuiet(q)
+	}
+}
+
+// printProjectSummary prints the summary tables of the beams and sections
+func printProjectSummary(p *project.Project, result *project.Result) {
+	total, adequate := result.Counts()
+	if len(result.Beams) > 0 {
+		results := make([]batch.Result, len(result.Beams))
+		for i, b := range result.Beams {
+			results[i] = b.Result
+		}
+		fmt.Printf("BEAMS (%d):\n", len(result.Beams))
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		printBatchSummary(results)
+		fmt.Println()
+	}
+
+	if len(result.Sections) > 0 {
+		fmt.Printf("SECTIONS (%d):\n", len(result.Sections))
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		w := newTextWriter()
+		fmt.Fprintln(w, "  Member\tFile\tMu (kN-m)\tφMn (kN-m)\tεt\tStatus")
+		fmt.Fprintln(w, "  ──────\t────\t─────────\t──────────\t──\t──────")
+		for _, s := range result.Sections {
+			if s.Analysis == nil {
+				fmt.Fprintf(w, "  %s\t%s\t%.2f\t-\t-\t%s\n", s.ID, s.File, s.Mu, s.Status())
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%.2f\t%.2f\t%.6f\t%s\n", s.ID, s.File, s.Mu, s.Analysis.PhiMn, s.Analysis.EpsilonT, s.Status())
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	fmt.Printf("  %d of %d members of %s adequate\n", adequate, total, p.Name)
+	fmt.Println()
+}
+
+// printProjectDetails prints the results of every member
+func printProjectDetails(result *project.Result) {
+	for _, b := range result.Beams {
+		printBatchMember(b.Result, projectGoverning(b))
+	}
+	for _, s := range result.Sections {
+		fmt.Printf("SECTION %s (%s):\n", s.ID, s.File)
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		if s.Error != "" {
+			fmt.Printf("  Error: %s\n", s.Error)
+			fmt.Println()
+			continue
+		}
+		a := s.Analysis
+		w := newTextWriter()
+		if s.Name != "" {
+			fmt.Fprintf(w, "  Section:\t%s\n", s.Name)
+		}
+		if s.MomentCombination != "" {
+			fmt.Fprintf(w, "  Governing combination:\tMu from %s\n", s.MomentCombination)
+		}
+		fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", a.C)
+		fmt.Fprintf(w, "  Tensile strain (εt):\t%.6f\n", a.EpsilonT)
+		fmt.Fprintf(w, "  φ:\t%.2f\n", a.Phi)
+		check := "✓"
+		if !s.IsAdequate {
+			check = "✗"
+		}
+		fmt.Fprintf(w, "  φMn:\t%.2f kN-m vs Mu = %.2f kN-m %s\n", a.PhiMn, s.Mu, check)
+		fmt.Fprintf(w, "  Status:\t%s - %s\n", s.Status(), i18n.T(s.Message))
+		w.Flush()
+		fmt.Println()
+	}
+}
+
+// projectGoverning describes the combinations governing the actions of a
+// beam with loads
+func projectGoverning(b project.BeamResult) string {
+	var parts []string
+	if b.MomentCombination != "" {
+		parts = append(parts, "Mu from "+b.MomentCombination)
+	}
+	if b.ShearCombination != "" {
+		parts = append(parts, "Vu from "+b.ShearCombination)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// loadProject reads a project file and runs it with the selected code
+func loadProject(path string, check bool) (*project.Project, *project.Result, error) {
+	p, err := project.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := project.Run(p, selectedCode, check)
+	for _, b := range result.Beams {
+		checkStatus(b.Status(), b.NoConvergence)
+	}
+	for _, s := range result.Sections {
+		checkStatus(s.Status(), s.NoConvergence)
+	}
+	return p, result, nil
+}
+
+// projectReport returns the report of every member of a project
+func projectReport(cmd *cobra.Command, title string, p *project.Project, result *project.Result) *report.Document {
+	doc := newReport(cmd, title)
+	doc.Paragraph("Project: **" + p.Name + "**")
+
+	doc.Section("Summary")
+	total, adequate := result.Counts()
+	if len(result.Beams) > 0 {
+		doc.Subsection(fmt.Sprintf("Beams (%d)", len(result.Beams)))
+		results := make([]batch.Result, len(result.Beams))
+		for i, b := range result.Beams {
+			results[i] = b.Result
+		}
+		reportBatchSummary(doc, results)
+	}
+	if len(result.Sections) > 0 {
+		doc.Subsection(fmt.Sprintf("Sections (%d)", len(result.Sections)))
+		var rows [][]string
+		for _, s := range result.Sections {
+			row := []string{s.ID, s.File, fmtMoment(s.Mu, 2), "-", "-", s.Status()}
+			if s.Analysis != nil {
+				row[3], row[4] = fmtMoment(s.Analysis.PhiMn, 2), fmt.Sprintf("%.6f", s.Analysis.EpsilonT)
+			}
+			rows = append(rows, row)
+		}
+		doc.Table([]string{"Member", "File", "Mu", "φMn", "εt", "Status"}, rows)
+	}
+	doc.Paragraph(fmt.Sprintf("%d of %d members adequate.", adequate, total))
+	doc.Conclude(adequate == total, fmt.Sprintf("%d of %d members adequate", adequate, total))
+
+	doc.Section("Members")
+	for _, b := range result.Beams {
+		reportBatchMember(doc, b.Result, projectGoverning(b))
+	}
+	for _, s := range result.Sections {
+		doc.Subsection(fmt.Sprintf("Section %s (%s)", s.ID, s.File))
+		if s.Error != "" {
+			doc.Paragraph("**Error:** " + s.Error)
+			continue
+		}
+		a := s.Analysis
+		var name, combination []string
+		if s.Name != "" {
+			name = []string{"Section", s.Name}
+		}
+		if s.MomentCombination != "" {
+			combination = []string{"Governing combination", "Mu from " + s.MomentCombination}
+		}
+		doc.Fields(
+			name,
+			combination,
+			[]string{"Neutral axis depth (c)", fmtLength(a.C, 2)},
+			[]string{"Tensile strain (εt)", fmt.Sprintf("%.6f", a.EpsilonT)},
+			[]string{"φ", fmt.Sprintf("%.2f", a.Phi)},
+		)
+		reportChecks(doc, strengthCheck(a.PhiMn, s.Mu))
+		doc.Paragraph(fmt.Sprintf("**%s** - %s", s.Status(), s.Message))
+	}
+	return doc
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var projectCheckFile string
+
+var projectCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Verify the provided reinforcement of every member of a project",
+	Long: `Check every member of a project against its Mu and Vu without designing:
+beams must give their provided steel (as, and asc for doubly reinforced
+beams) and sections their reinforcement. Beams without provided steel are
+reported as errors. Only the members that are not adequate are detailed.
+
+Examples:
+  gorcb project check -f project.yaml
+  gorcb project check -f project.yaml --format csv > check.csv`,
+	Run: runProjectCheck,
+}
+
+func init() {
+	projectCmd.AddCommand(projectCheckCmd)
+	tabular(projectCheckCmd)
+	quiet(projectCheckCmd)
+	reportable(projectCheckCmd)
+	exportable(projectCheckCmd)
+
+	projectCheckCmd.Flags().StringVarP(&projectCheckFile, "file", "f", "", "Project JSON or YAML file [required]")
+	projectCheckCmd.MarkFlagRequired("file")
+}
+
+func runProjectCheck(cmd *cobra.Command, args []string) {
+	p, result, err := loadProject(projectCheckFile, true)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if reportFile != "" {
+		defer writeReport(projectReport(cmd, "Project Check Report", p, result))
+	}
+	if workbookFile != "" {
+		defer writeWorkbook(projectWorkbook(p, result))
+	}
+	runPlugins(cmd, p, result)
+	defer printPluginResults()
+	printProjectQuiet(result)
+	if quietOutput {
+		return
+	}
+	if tabularOutput() {
+		printTable(batchHeader, projectRows(result))
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, p, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     PROJECT CHECK - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  Project: %s\n", p.Name)
+	fmt.Println()
+
+	printProjectSummary(p, result)
+
+	// Detail only the members that fail
+	failed := *result
+	failed.Beams, failed.Sections = nil, nil
+	for _, b := range result.Beams {
+		if b.Status() != "OK" {
+			failed.Beams = append(failed.Beams, b)
+		}
+	}
+	for _, s := range result.Sections {
+		if s.Status() != "OK" {
+			failed.Sections = append(failed.Sections, s)
+		}
+	}
+	printProjectDetails(&failed)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectReportFile   string
+	projectReportOutput string
+)
+
+var projectReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write one combined calculation report of a project",
+	Long: `Design or check every member of a project and write one combined report:
+the project data, the unfactored loads of each member, the summary tables
+and the detailed results of every beam and section.
+
+Examples:
+  gorcb project report -f project.yaml
+  gorcb project report -f project.yaml -o calculations.txt`,
+	Run: runProjectReport,
+}
+
+func init() {
+	projectCmd.AddCommand(projectReportCmd)
+
+	projectReportCmd.Flags().StringVarP(&projectReportFile, "file", "f", "", "Project JSON or YAML file [required]")
+	projectReportCmd.Flags().StringVarP(&projectReportOutput, "output", "o", "", "Write the report to a file instead of the terminal")
+	projectReportCmd.MarkFlagRequired("file")
+}
+
+func runProjectReport(cmd *cobra.Command, args []string) {
+	p, result, err := loadProject(projectReportFile, false)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if projectReportOutput != "" {
+		f, err := os.Create(projectReportOutput)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			setExit(exitFailure)
+			return
+		}
+		stdout := os.Stdout
+		os.Stdout = f
+		defer func() {
+			os.Stdout = stdout
+			if err := f.Close(); err != nil {
+				fmt.Printf("Error writing report: %v\n", err)
+				setExit(exitFailure)
+				return
+			}
+			fmt.Printf("Report written to: %s\n", projectReportOutput)
+		}()
+	}
+
+	if structuredOutput() {
+		printReport(cmd, p, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     PROJECT CALCULATION REPORT")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("PROJECT:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Name:\t%s\n", p.Name)
+	if p.Description != "" {
+		fmt.Fprintf(w, "  Description:\t%s\n", p.Description)
+	}
+	if p.Engineer != "" {
+		fmt.Fprintf(w, "  Engineer:\t%s\n", p.Engineer)
+	}
+	fmt.Fprintf(w, "  Design code:\t%s\n", selectedCode.Name())
+	fmt.Fprintf(w, "  Project file:\t%s\n", projectReportFile)
+	fmt.Fprintf(w, "  Date:\t%s\n", time.Now().Format("2006-01-02"))
+	w.Flush()
+	fmt.Println()
+
+	printProjectLoads(p)
+	printProjectSummary(p, result)
+	printProjectDetails(result)
+}
+
+// printProjectLoads prints the unfactored loads of the members that give them
+func printProjectLoads(p *project.Project) {
+	type memberLoads struct {
+		id    string
+		loads project.Loads
+	}
+	var members []memberLoads
+	for _, b := range p.Beams {
+		if len(b.Loads) > 0 {
+			members = append(members, memberLoads{b.ID, b.Loads})
+		}
+	}
+	for _, s := range p.Sections {
+		if len(s.Loads) > 0 {
+			members = append(members, memberLoads{s.ID, s.Loads})
+		}
+	}
+	if len(members) == 0 {
+		return
+	}
+
+	fmt.Println("UNFACTORED LOADS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Member\tLoad\tM (kN-m)\tV (kN)")
+	fmt.Fprintln(w, "  ──────\t────\t────────\t──────")
+	for _, m := range members {
+		for _, t := range nscp.LoadTypes {
+			values, ok := m.loads[t]
+			if !ok {
+				continue
+			}
+			v := "-"
+			if len(values) > 1 {
+				v = fmt.Sprintf("%.2f", values[1])
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%.2f\t%s\n", m.id, t, values[0], v)
+		}
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectRunFile    string
+	projectRunSummary bool
+)
+
+var projectRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Design or check every member of a project",
+	Long: `Design every beam of a project for its Mu and Vu, check the beams that give
+their provided steel, check every section, and print a summary followed by
+the results of each member.
+
+Examples:
+  gorcb project run -f project.yaml
+  gorcb project run -f project.yaml --summary --code aci318-19`,
+	Run: runProjectRun,
+}
+
+func init() {
+	projectCmd.AddCommand(projectRunCmd)
+	tabular(projectRunCmd)
+	quiet(projectRunCmd)
+	reportable(projectRunCmd)
+	exportable(projectRunCmd)
+
+	projectRunCmd.Flags().StringVarP(&projectRunFile, "file", "f", "", "Project JSON or YAML file [required]")
+	projectRunCmd.Flags().BoolVar(&projectRunSummary, "summary", false, "Print only the summary tables")
+	projectRunCmd.MarkFlagRequired("file")
+}
+
+func runProjectRun(cmd *cobra.Command, args []string) {
+	p, result, err := loadProject(projectRunFile, false)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if reportFile != "" {
+		defer writeReport(projectReport(cmd, "Project Design Report", p, result))
+	}
+	if workbookFile != "" {
+		defer writeWorkbook(projectWorkbook(p, result))
+	}
+	runPlugins(cmd, p, result)
+	defer printPluginResults()
+	printProjectQuiet(result)
+	if quietOutput {
+		return
+	}
+	if tabularOutput() {
+		printTable(batchHeader, projectRows(result))
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, p, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     PROJECT RESULTS - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  Project: %s\n", p.Name)
+	fmt.Println()
+
+	printProjectSummary(p, result)
+	if !projectRunSummary {
+		printProjectDetails(result)
+	}
+}
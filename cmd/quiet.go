@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/spf13/cobra"
+)
+
+// quietAnnotation marks commands that print one result line per member with --quiet
+const quietAnnotation = "gorcb/quiet"
+
+// Essential results only, selected with --quiet
+var quietOutput bool
+
+// quietLines are the one-line results of the run, kept in the history with
+// or without --quiet
+var quietLines []string
+
+// quietResult is the one-line result of a member printed with --quiet
+type quietResult struct {
+	ID     string  // Member ID, omitted for single-member commands
+	As     float64 // Required or provided tension steel (mm²), omitted when zero
+	Asc    float64 // Compression steel (mm²), omitted when zero
+	PhiMn  float64 // Design strength (kN-m)
+	Status string  // OK, NG or ERROR
+	Error  string
+}
+
+// String returns the result as space-separated name=value pairs in the
+// selected units, e.g. "id=B1 as=1256.64 phi_mn=187.42 status=OK"
+func (q quietResult) String() string {
+	var pairs []string
+	if q.ID != "" {
+		pairs = append(pairs, "id="+quietValue(q.ID))
+	}
+	if q.Error == "" {
+		if q.As > 0 {
+			pairs = append(pairs, fmt.Sprintf("as=%.*f", 2+selectedUnits.Area.Digits, selectedUnits.Area.FromSI(q.As)))
+		}
+		if q.Asc > 0 {
+			pairs = append(pairs, fmt.Sprintf("asc=%.*f", 2+selectedUnits.Area.Digits, selectedUnits.Area.FromSI(q.Asc)))
+		}
+		pairs = append(pairs, fmt.Sprintf("phi_mn=%.2f", selectedUnits.Moment.FromSI(q.PhiMn)))
+	}
+	pairs = append(pairs, "status="+q.Status)
+	if q.Error != "" {
+		pairs = append(pairs, "error="+quietValue(q.Error))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// printQuiet keeps the one-line result of a member for the history and
+// prints it with --quiet
+func printQuiet(q quietResult) {
+	quietLines = append(quietLines, q.String())
+	if quietOutput {
+		fmt.Println(q)
+	}
+}
+
+// quietValue quotes a value that contains spaces or quotes
+func quietValue(s string) string {
+	if strings.ContainsAny(s, " \t\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// quietBatch returns the one-line result of a batch member
+func quietBatch(r batch.Result) quietResult {
+	return quietResult{ID: r.Member.ID, As: r.As, Asc: r.Asc, PhiMn: r.PhiMn, Status: r.Status(), Error: r.Error}
+}
+
+// quietStatus returns OK or NG for the adequacy of a member
+func quietStatus(adequate bool) string {
+	if adequate {
+		return "OK"
+	}
+	return "NG"
+}
+
+// quiet marks a command as supporting --quiet
+func quiet(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[quietAnnotation] = "true"
+}
+
+// validateQuiet checks --quiet against the command being run and --format
+func validateQuiet(cmd *cobra.Command) error {
+	if !quietOutput {
+		return nil
+	}
+	if cmd.Annotations[quietAnnotation] == "" {
+		return fmt.Errorf("%s has no one-line results for --quiet", cmd.CommandPath())
+	}
+	if outputFormat != formatText {
+		return fmt.Errorf("--quiet prints text lines and cannot be combined with --format %s", outputFormat)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Bar sizes the rebar commands are limited to
+	rebarAvailable   []string
+	rebarProjectFile string
+)
+
+var rebarCmd = &cobra.Command{
+	Use:   "rebar",
+	Short: "Reinforcing bar reference",
+	Long: `Look up the bars of the rebar catalog selected with --bars and
+--bar-catalog: areas, unit weights and perimeters, the area and weight of
+groups of bars, and the number of bars of each size needed for a steel area.
+
+Groups of bars are written as count-bar, e.g. 4-20, 4-20mm or 2-#8.
+
+Subcommands:
+  list    - Areas, perimeters and unit weights of the bars
+  area    - Area of groups of bars, or bars needed for a steel area
+  weight  - Weight of groups of bars over a length
+
+The bar sizes can be limited to those available on a project with --available
+or with the available_bars of a project file given with --project.`,
+}
+
+func init() {
+	rootCmd.AddCommand(rebarCmd)
+}
+
+// addAvailableFlags registers the flags limiting the bar sizes of a rebar command
+func addAvailableFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&rebarAvailable, "available", nil, "Bar sizes available, e.g. 16,20,25 (default: all bars of the catalog)")
+	cmd.Flags().StringVarP(&rebarProjectFile, "project", "p", "", "Project file whose available_bars limit the bar sizes")
+}
+
+// availableCatalog returns the selected rebar catalog limited to the bar
+// sizes of the project file and of --available
+func availableCatalog() (*rebar.Catalog, error) {
+	catalog := selectedCatalog
+	if rebarProjectFile != "" {
+		p, err := project.Load(rebarProjectFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(p.AvailableBars) > 0 {
+			if catalog, err = catalog.Only(p.AvailableBars); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(rebarAvailable) > 0 {
+		return catalog.Only(rebarAvailable)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/spf13/cobra"
+)
+
+var rebarAreaRequired float64
+
+var rebarAreaCmd = &cobra.Command{
+	Use:   "area [count-bar...]",
+	Short: "Area of groups of bars, or bars needed for a steel area",
+	Long: `Add up the area of groups of bars, or with --as list the number of bars of
+each size needed to provide a required steel area. Given both, the groups
+are checked against the required area.
+
+Examples:
+  gorcb rebar area 4-20 2-16
+  gorcb rebar area --as 1500
+  gorcb rebar area --as 1500 --available 16,20,25
+  gorcb rebar area 3-25 --as 1400`,
+	Run: runRebarArea,
+}
+
+func init() {
+	rebarCmd.AddCommand(rebarAreaCmd)
+	tabular(rebarAreaCmd)
+	addAvailableFlags(rebarAreaCmd)
+
+	rebarAreaCmd.Flags().Float64Var(&rebarAreaRequired, "as", 0, "Required steel area As (mm²)")
+}
+
+// rebarGroupRow is a group of bars in the results of the rebar commands
+type rebarGroupRow struct {
+	Bar    string  `json:"bar"`
+	Count  int     `json:"count"`
+	Area   float64 `json:"area"`             // mm²
+	Length float64 `json:"length,omitempty"` // m
+	Mass   float64 `json:"mass,omitempty"`   // kg
+}
+
+// rebarAreaResult is the result of rebar area
+type rebarAreaResult struct {
+	Groups   []rebarGroupRow `json:"groups,omitempty"`
+	Total    float64         `json:"total,omitempty"`    // mm²
+	Required float64         `json:"required,omitempty"` // mm²
+	Adequate *bool           `json:"adequate,omitempty"`
+	Needed   []rebarGroupRow `json:"needed,omitempty"`
+}
+
+// groupRows returns the rows of groups of bars
+func groupRows(groups []rebar.Group) []rebarGroupRow {
+	rows := make([]rebarGroupRow, len(groups))
+	for i, g := range groups {
+		rows[i] = rebarGroupRow{Bar: g.Bar.Designation, Count: g.Count, Area: math.Round(g.Area()*100) / 100}
+	}
+	return rows
+}
+
+// parseGroups parses the groups of bars given as arguments
+func parseGroups(catalog *rebar.Catalog, args []string) ([]rebar.Group, error) {
+	groups := make([]rebar.Group, len(args))
+	for i, arg := range args {
+		g, err := catalog.ParseGroup(arg)
+		if err != nil {
+			return nil, err
+		}
+		groups[i] = g
+	}
+	return groups, nil
+}
+
+func runRebarArea(cmd *cobra.Command, args []string) {
+	if len(args) == 0 && rebarAreaRequired <= 0 {
+		printError(fmt.Errorf("give groups of bars, e.g. 4-20, or a required area with --as"))
+		return
+	}
+	if rebarAreaRequired < 0 {
+		printError(fmt.Errorf("--as must be positive, got %g", rebarAreaRequired))
+		return
+	}
+	catalog, err := availableCatalog()
+	if err != nil {
+		printError(err)
+		return
+	}
+	groups, err := parseGroups(catalog, args)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	result := rebarAreaResult{Groups: groupRows(groups), Required: rebarAreaRequired}
+	for _, g := range groups {
+		result.Total += g.Area()
+	}
+	if len(groups) == 0 {
+		result.Needed = groupRows(catalog.Needed(rebarAreaRequired))
+	} else if rebarAreaRequired > 0 {
+		adequate := result.Total >= rebarAreaRequired
+		result.Adequate = &adequate
+		if !adequate {
+			setExit(exitInadequate)
+		}
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, r := range append(result.Groups, result.Needed...) {
+			rows = append(rows, []string{r.Bar, fmt.Sprint(r.Count), cell(r.Area)})
+		}
+		printTable([]string{"Bar", "Count", "Area (mm²)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	if len(groups) == 0 {
+		fmt.Printf("     BARS NEEDED FOR As = %.0f mm²\n", rebarAreaRequired)
+	} else {
+		fmt.Println("     REBAR AREA")
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	w := newTextWriter()
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "  Bars\tAs provided\tExcess")
+		fmt.Fprintln(w, "  ────\t───────────\t──────")
+		for _, g := range catalog.Needed(rebarAreaRequired) {
+			fmt.Fprintf(w, "  %s\t%.0f mm²\t%.1f%%\n", g, g.Area(), (g.Area()/rebarAreaRequired-1)*100)
+		}
+		w.Flush()
+		fmt.Println()
+		return
+	}
+
+	fmt.Fprintln(w, "  Bars\tBar Area\tArea")
+	fmt.Fprintln(w, "  ────\t────────\t────")
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %s\t%.0f mm²\t%.0f mm²\n", g, g.Bar.Area, g.Area())
+	}
+	fmt.Fprintf(w, "  Total\t\t%.0f mm²\n", result.Total)
+	w.Flush()
+
+	if result.Adequate != nil {
+		status := "OK"
+		if !*result.Adequate {
+			status = "NG"
+		}
+		fmt.Println()
+		fmt.Printf("  As provided = %.0f mm² vs As required = %.0f mm²: %s\n", result.Total, rebarAreaRequired, status)
+	}
+	fmt.Println()
+}
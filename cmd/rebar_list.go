@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var rebarListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the areas, perimeters and unit weights of the bars",
+	Long: `List the bars of the selected rebar catalog with their nominal diameter,
+area, perimeter, unit weight and available stock lengths.
+
+Examples:
+  gorcb rebar list
+  gorcb rebar list --bars astm
+  gorcb rebar list --available 12,16,20,25 --format csv`,
+	Args: cobra.NoArgs,
+	Run:  runRebarList,
+}
+
+func init() {
+	rebarCmd.AddCommand(rebarListCmd)
+	tabular(rebarListCmd)
+	addAvailableFlags(rebarListCmd)
+}
+
+func runRebarList(cmd *cobra.Command, args []string) {
+	catalog, err := availableCatalog()
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, b := range catalog.Bars {
+			rows = append(rows, []string{b.Designation, cell(b.Diameter), cell(b.Area), cell(b.Perimeter()),
+				cell(b.MassPerMeter), cell(b.MinLength), cell(b.MaxLength)})
+		}
+		printTable([]string{"Bar", "Diameter (mm)", "Area (mm²)", "Perimeter (mm)", "Mass (kg/m)", "Min Length (m)", "Max Length (m)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, catalog)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     REINFORCING BARS")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  Catalog: %s", catalog.Name)
+	if catalog.Description != "" {
+		fmt.Printf(" (%s)", catalog.Description)
+	}
+	fmt.Println()
+	fmt.Println()
+
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Bar\tDiameter\tArea\tPerimeter\tMass\tStock Lengths")
+	fmt.Fprintln(w, "  ───\t────────\t────\t─────────\t────\t─────────────")
+	for _, b := range catalog.Bars {
+		fmt.Fprintf(w, "  %s\t%.1f mm\t%.0f mm²\t%.1f mm\t%.3f kg/m\t%.1f - %.1f m\n",
+			b.Label(), b.Diameter, b.Area, b.Perimeter(), b.MassPerMeter, b.MinLength, b.MaxLength)
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+)
+
+var rebarWeightLength float64
+
+var rebarWeightCmd = &cobra.Command{
+	Use:   "weight count-bar...",
+	Short: "Weight of groups of bars over a length",
+	Long: `Calculate the mass of groups of bars over a length from the unit weights
+of the selected rebar catalog, e.g. to order the bars of a member.
+
+Examples:
+  gorcb rebar weight 4-20 2-16 --length 6
+  gorcb rebar weight 8-#8 --bars astm --length 12 --format csv`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runRebarWeight,
+}
+
+func init() {
+	rebarCmd.AddCommand(rebarWeightCmd)
+	tabular(rebarWeightCmd)
+	addAvailableFlags(rebarWeightCmd)
+
+	rebarWeightCmd.Flags().Float64VarP(&rebarWeightLength, "length", "l", 1, "Length of the bars (m)")
+}
+
+// rebarWeightResult is the result of rebar weight
+type rebarWeightResult struct {
+	Groups []rebarGroupRow `json:"groups"`
+	Total  float64         `json:"total"` // kg
+}
+
+func runRebarWeight(cmd *cobra.Command, args []string) {
+	if rebarWeightLength <= 0 {
+		printError(fmt.Errorf("--length must be positive, got %g", rebarWeightLength))
+		return
+	}
+	catalog, err := availableCatalog()
+	if err != nil {
+		printError(err)
+		return
+	}
+	groups, err := parseGroups(catalog, args)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	result := rebarWeightResult{Groups: groupRows(groups)}
+	for i, g := range groups {
+		result.Groups[i].Length = rebarWeightLength
+		result.Groups[i].Mass = math.Round(g.Mass(rebarWeightLength)*1000) / 1000
+		result.Total += g.Mass(rebarWeightLength)
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, r := range result.Groups {
+			rows = append(rows, []string{r.Bar, fmt.Sprint(r.Count), cell(r.Length), cell(r.Mass)})
+		}
+		printTable([]string{"Bar", "Count", "Length (m)", "Mass (kg)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     REBAR WEIGHT")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Bars\tUnit Weight\tLength\tMass")
+	fmt.Fprintln(w, "  ────\t───────────\t──────\t────")
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %s\t%.3f kg/m\t%.2f m\t%.2f kg\n", g, g.Bar.MassPerMeter, rebarWeightLength, g.Mass(rebarWeightLength))
+	}
+	fmt.Fprintf(w, "  Total\t\t\t%.2f kg\n", result.Total)
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/report"
+	"github.com/alexiusacademia/gorcb/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// reportAnnotation marks commands that write a design report with --report
+const reportAnnotation = "gorcb/report"
+
+var (
+	// Design report file written with --report
+	reportFile string
+
+	// Template file or directory overriding the report layout with --template
+	reportTemplate string
+)
+
+// reportable marks a command as supporting --report
+func reportable(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[reportAnnotation] = "true"
+}
+
+// validateReport checks --report against the command being run and the
+// extension of the report file, and --template against the report format.
+// A template set in the configuration is ignored by reports it cannot lay
+// out.
+func validateReport(cmd *cobra.Command) error {
+	templateGiven := cmd.Flags().Changed("template")
+	if reportFile == "" {
+		if templateGiven {
+			return fmt.Errorf("--template needs a --report file")
+		}
+		return nil
+	}
+	if cmd.Annotations[reportAnnotation] == "" {
+		return fmt.Errorf("%s has no design report for --report", cmd.CommandPath())
+	}
+	format, err := report.FormatOf(reportFile)
+	if err != nil || reportTemplate == "" {
+		return err
+	}
+	if !report.Templated(format) {
+		if templateGiven {
+			return fmt.Errorf("--template lays out .md and .html reports, not %q", reportFile)
+		}
+		return nil
+	}
+	_, err = report.TemplateFile(reportTemplate, format)
+	return err
+}
+
+// newReport starts the report of the command being run with the code, units
+// and version of the calculation
+func newReport(cmd *cobra.Command, title string) *report.Document {
+	doc := report.New(title)
+	doc.Template = reportTemplate
+	doc.Fields(
+		[]string{"Command", cmd.CommandPath()},
+		[]string{"Design code", selectedCode.Name()},
+		[]string{"Units", selectedUnits.Name},
+		[]string{"Date", time.Now().Format("2006-01-02")},
+		[]string{"Program", "gorcb " + version.Version},
+	)
+	return doc
+}
+
+// writeReport writes the report to the --report file
+func writeReport(doc *report.Document) {
+	if err := doc.WriteFile(reportFile); err != nil {
+		fmt.Printf("Error writing report: %v\n", err)
+		setExit(exitFailure)
+		return
+	}
+	if outputFormat == formatText && !quietOutput {
+		fmt.Printf("Report written to: %s\n", reportFile)
+	}
+}
+
+// reportCheck returns a row of the code checks table, citing the clause of
+// the provision checked
+func reportCheck(check string, p codes.Provision, value, limit, status string) []string {
+	clause := selectedCode.Clause(p)
+	if clause == "" {
+		clause = "-"
+	}
+	return []string{check, clause, value, limit, status}
+}
+
+// reportChecks adds the code checks table of a member
+func reportChecks(doc *report.Document, rows ...[]string) {
+	doc.Table([]string{"Check", "Clause", "Value", "Limit", "Status"}, rows)
+}
+
+// minSteelCheck returns the minimum reinforcement check of a steel ratio
+func minSteelCheck(rho, rhoMin float64) []string {
+	return reportCheck("Minimum steel (ρ ≥ ρmin)", codes.ProvisionMinSteel,
+		fmt.Sprintf("%.6f", rho), fmt.Sprintf("%.6f", rhoMin), quietStatus(rho >= rhoMin))
+}
+
+// tensionControlledCheck returns the net tensile strain check of a section,
+// which is in the transition zone with a reduced φ when it fails
+func tensionControlledCheck(fc, fy, epsilonT float64) []string {
+	tc := selectedCode.TensionControlledStrain(fc, fy)
+	status := "OK"
+	if epsilonT < tc {
+		status = "Transition zone"
+	}
+	return reportCheck("Tension-controlled (εt ≥ εt,tc)", codes.ProvisionStrengthReduction,
+		fmt.Sprintf("%.6f", epsilonT), fmt.Sprintf("%.4g", tc), status)
+}
+
+// strengthCheck returns the design strength check of a member
+func strengthCheck(phiMn, mu float64) []string {
+	return reportCheck("Design strength (φMn ≥ Mu)", codes.ProvisionDesignStrength,
+		fmtMoment(phiMn, 2), fmtMoment(mu, 2), quietStatus(phiMn >= mu*0.999))
+}
+
+// singlyChecks returns the steel ratio, strain and strength checks of a
+// singly reinforced section with tension steel ratio rho, skipping the
+// strength check when mu is zero
+func singlyChecks(fc, fy, rho, rhoMin, rhoMax, epsilonT, phiMn, mu float64) [][]string {
+	rows := [][]string{
+		minSteelCheck(rho, rhoMin),
+		reportCheck("Maximum steel (ρ ≤ ρmax)", codes.ProvisionTensionControlled,
+			fmt.Sprintf("%.6f", rho), fmt.Sprintf("%.6f", rhoMax), quietStatus(rho <= rhoMax)),
+		tensionControlledCheck(fc, fy, epsilonT),
+	}
+	if mu > 0 {
+		rows = append(rows, strengthCheck(phiMn, mu))
+	}
+	return rows
+}
+
+// reportBars adds the suggested bar combinations for a required steel area
+// under a subsection title
+func reportBars(doc *report.Document, title string, asRequired float64) {
+	var rows [][]string
+	for _, s := range selectedCatalog.Suggest(asRequired, suggestMinBars, suggestMaxBars) {
+		rows = append(rows, []string{fmt.Sprintf("%d - %s", s.Count, s.Bar.Label()), fmtArea(s.Area, 2),
+			fmt.Sprintf("%.2f", s.Area/asRequired), fmtMass(s.Mass, 2)})
+	}
+	if len(rows) == 0 {
+		return
+	}
+	doc.Subsection(title)
+	doc.Table([]string{"Bars", "As provided", "Ratio", "Mass"}, rows)
+}
+
+// reportDiagram adds the section diagram of a member to the report, or of
+// the only member of the command when member is empty. HTML and PDF reports
+// embed the section and strain diagrams, as SVG and PNG. Markdown reports
+// link the image exported with the -o flag of the command when given;
+// otherwise the diagram is exported as a PNG next to the report, named after
+// the report and the member.
+func reportDiagram(doc *report.Document, data diagram.SectionDiagramData, exported, member string) {
+	alt, strainAlt, suffix := "Section diagram", "Strain diagram", "diagram"
+	if member != "" {
+		alt, strainAlt, suffix = "Section diagram of "+member, "Strain diagram of "+member, reportSlug(member)
+	}
+	if format, _ := report.FormatOf(reportFile); format != report.FormatMarkdown {
+		section, strain, embed := diagram.SectionDiagramSVG, diagram.StrainDiagramSVG, doc.EmbedSVG
+		if format == report.FormatPDF {
+			section, strain, embed = diagram.SectionDiagramPNG, diagram.StrainDiagramPNG, doc.EmbedPNG
+		}
+		sectionImage, err := section(data)
+		var strainImage []byte
+		if err == nil {
+			strainImage, err = strain(data)
+		}
+		if err != nil {
+			fmt.Printf("Error drawing report diagram: %v\n", err)
+			setExit(exitFailure)
+			return
+		}
+		embed(alt, sectionImage)
+		embed(strainAlt, strainImage)
+		return
+	}
+	image := exported
+	if image == "" {
+		image = strings.TrimSuffix(reportFile, filepath.Ext(reportFile)) + "-" + suffix + ".png"
+		if err := diagram.ExportSectionDiagram(data, image); err != nil {
+			fmt.Printf("Error exporting report diagram: %v\n", err)
+			setExit(exitFailure)
+			return
+		}
+	}
+	doc.Image(alt, reportLink(image))
+}
+
+// reportLink returns the path of a file relative to the directory of the report
+func reportLink(path string) string {
+	dir, err := filepath.Abs(filepath.Dir(reportFile))
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// reportSlug returns a name usable in a file name, e.g. "b1" for "B1"
+func reportSlug(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name)
+}
@@ -3,11 +3,36 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+	"github.com/alexiusacademia/gorcb/internal/units"
 	"github.com/alexiusacademia/gorcb/internal/version"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// Design code selected with --code
+	designCodeName string
+	selectedCode   codes.DesignCode = codes.Default()
+
+	// Project-specific φ and strain limit overrides from --code-overrides
+	codeOverridesFile string
+
+	// Language of reports, messages, warnings and diagrams selected with --lang
+	language string
+
+	// Rebar catalog selected with --bars and --bar-catalog
+	rebarCatalogName string
+	rebarCatalogFile string
+	selectedCatalog  = rebar.Default()
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "gorcb",
 	Short: "Reinforced Concrete Beam Design Tool",
@@ -23,7 +48,75 @@ This tool helps structural engineers perform:
   - Reinforcement detailing
   - Non-rectangular section analysis
 
-All calculations follow NSCP 2015 (Volume 1) provisions.`,
+All calculations follow NSCP 2015 (Volume 1) provisions by default;
+use --code to select another design code (see 'gorcb beam --help').
+Run 'gorcb help <topic>' for the configuration, output, reports, diagrams
+and exit codes shared by the commands.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		if err := validateFormat(cmd); err != nil {
+			return err
+		}
+		if err := validateQuiet(cmd); err != nil {
+			return err
+		}
+		if err := validateTrace(cmd); err != nil {
+			return err
+		}
+		if err := validateReport(cmd); err != nil {
+			return err
+		}
+		if err := validateWorkbook(); err != nil {
+			return err
+		}
+		if err := applyUnits(cmd); err != nil {
+			return err
+		}
+		if err := applyRounding(); err != nil {
+			return err
+		}
+		if err := applySolver(); err != nil {
+			return err
+		}
+		if err := applyFigure(); err != nil {
+			return err
+		}
+		diagram.WriteData = plotData
+		applyText()
+		if err := i18n.Set(language); err != nil {
+			return err
+		}
+		code, err := codes.Get(designCodeName)
+		if err != nil {
+			return err
+		}
+		if codeOverridesFile != "" {
+			overrides, err := codes.LoadOverrides(codeOverridesFile)
+			if err != nil {
+				return err
+			}
+			code = codes.WithOverrides(code, overrides)
+		}
+		selectedCode = code
+		if err := applyTheme(); err != nil {
+			return err
+		}
+
+		catalog, err := rebar.Resolve(rebarCatalogName, rebarCatalogFile)
+		if err != nil {
+			return err
+		}
+		selectedCatalog = catalog
+		if err := setupHistory(cmd); err != nil {
+			return err
+		}
+		if err := setupPlain(); err != nil {
+			return err
+		}
+		return setupLogging(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		fmt.Println("  ╔═══════════════════════════════════════════════════════════╗")
@@ -51,15 +144,88 @@ All calculations follow NSCP 2015 (Volume 1) provisions.`,
 	},
 }
 
+// concreteTypeUsage is the help text of the --concrete-type flag
+const concreteTypeUsage = "Concrete type for λ: normal, sand-lightweight, all-lightweight, or density in kg/m³"
+
+// concreteLambda resolves a --concrete-type value to the lightweight concrete
+// modification factor λ. A number is taken as the concrete density in kg/m³
+// and converted with the selected design code.
+func concreteLambda(value string) (float64, error) {
+	if density, err := strconv.ParseFloat(value, 64); err == nil {
+		if density <= 0 {
+			return 0, fmt.Errorf("concrete density must be positive, got %g", density)
+		}
+		return selectedCode.Lambda(density), nil
+	}
+	return nscp.Lambda(value)
+}
+
+// concreteDensity returns the density given to --concrete-type in kg/m³,
+// or zero when a concrete type name was given
+func concreteDensity(value string) float64 {
+	density, err := strconv.ParseFloat(value, 64)
+	if err != nil || density <= 0 {
+		return 0
+	}
+	return density
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if err != nil {
+		exitCode = exitInvalidInput
+	}
+	closePlain()
+	closeHistory()
+	writeRerunResults()
+	closeLog()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
 	}
+	os.Exit(exitCode)
 }
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
+	rootCmd.PersistentFlags().StringVar(&designCodeName, "code", codes.DefaultName,
+		"Design code ("+strings.Join(codes.Names(), ", ")+")")
+	rootCmd.PersistentFlags().StringVar(&codeOverridesFile, "code-overrides", "",
+		"JSON/YAML file overriding φ factors and the tension-controlled strain limit")
+	rootCmd.PersistentFlags().StringVar(&rebarCatalogName, "bars", rebar.DefaultName,
+		"Rebar catalog for bar suggestions ("+strings.Join(rebar.Names(), ", ")+")")
+	rootCmd.PersistentFlags().StringVar(&rebarCatalogFile, "bar-catalog", "",
+		"JSON file overriding or replacing bars of the selected rebar catalog")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "",
+		"YAML file of flag defaults (default $GORCB_CONFIG, or ~/.gorcb.yaml and ./gorcb.yaml)")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false,
+		"Print only As, φMn and adequacy as one name=value line per member")
+	rootCmd.PersistentFlags().BoolVar(&traceOutput, "trace", false,
+		"Show each formula, substituted values and code clause step by step")
+	rootCmd.PersistentFlags().StringVar(&reportFile, "report", "",
+		"Also write a design report (.md, .html or .pdf) of the inputs, checks and results")
+	rootCmd.PersistentFlags().StringVar(&reportTemplate, "template", "",
+		"Template file or directory overriding the layout of .md and .html reports")
+	rootCmd.PersistentFlags().StringVar(&unitSystemName, "units", units.SystemSI,
+		"Units of inputs and printed results: si (mm, MPa, kN, kN-m) or us (in, ksi, kip, kip-ft)")
+	rootCmd.PersistentFlags().IntVar(&outputPrecision, "precision", -1,
+		"Decimals of printed values and csv/tsv cells, -1 for the default of each value")
+	rootCmd.PersistentFlags().StringVar(&roundingRules, "round", "",
+		"Round printed quantities to steps as quantity=step[:up|down|nearest], e.g. area=10,spacing=5")
+	rootCmd.PersistentFlags().IntVar(&solverMaxIter, "max-iter", 0,
+		"Neutral axis iterations before a solver gives up (default 50 for doubly reinforced beams, 100 for sections)")
+	rootCmd.PersistentFlags().Float64Var(&solverTolerance, "tolerance", 0,
+		"Force imbalance T − ΣC accepted at equilibrium in kN (default c within 0.01 mm for doubly reinforced beams, 0.1 kN for sections)")
+	rootCmd.PersistentFlags().StringVar(&language, "lang", i18n.English,
+		"Language of reports, messages, warnings and diagrams ("+strings.Join(i18n.Languages, ", ")+")")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "",
+		"Log inputs, solver iterations and warnings at this level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFileName, "log-file", "",
+		"Append the log to this file instead of stderr (.json or .jsonl for JSON lines)")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false,
+		"Print plain ASCII without box-drawing characters or symbols, e.g. for awk, grep and log collectors")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", formatText,
+		"Output format: text (formatted tables), json or yaml (result structs), csv or tsv (table rows for spreadsheets)")
 }
 
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+)
+
+// maxPrecision is the most decimals --precision prints
+const maxPrecision = 10
+
+var (
+	// Decimals of printed values selected with --precision, the default of
+	// each value when negative
+	outputPrecision = -1
+
+	// Rounding rules of printed quantities selected with --round
+	roundingRules  string
+	roundingPolicy = units.Policy{}
+)
+
+// applyRounding validates --precision and parses the --round policy
+func applyRounding() error {
+	if outputPrecision < -1 || outputPrecision > maxPrecision {
+		return fmt.Errorf("--precision must be from 0 to %d, got %d", maxPrecision, outputPrecision)
+	}
+	policy, err := units.ParsePolicy(roundingRules)
+	if err != nil {
+		return fmt.Errorf("--round: %w", err)
+	}
+	roundingPolicy = policy
+	return nil
+}
+
+// fmtQuantity prints a value in engine units in unit u with its label,
+// rounded by the rule of the quantity. The decimals are those of --precision,
+// else of the rounding step, else prec in SI units.
+func fmtQuantity(u units.Unit, quantity string, v float64, prec int) string {
+	x := u.FromSI(v)
+	decimals := prec + u.Digits
+	if rule, ok := roundingPolicy[quantity]; ok {
+		x = rule.Apply(x)
+		decimals = rule.Decimals()
+	}
+	if outputPrecision >= 0 {
+		decimals = outputPrecision
+	}
+	return fmt.Sprintf("%.*f %s", decimals, x, u.Label)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/schedule"
+	"github.com/alexiusacademia/gorcb/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleFile   string
+	scheduleLength float64
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Bar bending schedule of the beams of a project",
+	Long: `Design or check every beam of a project as in 'gorcb project run' and
+detail its bars into a bar bending schedule: bar marks, shape codes, counts,
+cut lengths and weights.
+
+Each beam gets its tension bars at the bottom and its compression bars, or
+two hanger bars of the smallest flexural size, at the top, in one layer of
+the bar size of the rebar catalog with the least excess area. Longitudinal
+bars run the length of the beam less the end covers with a 90° hook of 12db
+at both ends (shape 21), and are lap spliced with the tension lap of the
+design code into equal pieces when longer than the stock length of the bar.
+Closed stirrups with 135° hooks (shape 51) are spaced as in the shear
+design, starting 50 mm from the supports. Bend allowances are neglected and
+cut lengths are rounded up to 10 mm.
+
+Beams take their length from the project file, or --length. The sections of
+a project are not scheduled.
+
+Examples:
+  gorcb schedule -f project.yaml
+  gorcb schedule -f project.yaml --length 6 --format csv > bbs.csv
+  gorcb schedule -f project.yaml --xlsx bbs.xlsx`,
+	Run: runSchedule,
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	tabular(scheduleCmd)
+	exportable(scheduleCmd)
+
+	scheduleCmd.Flags().StringVarP(&scheduleFile, "file", "f", "", "Project JSON or YAML file [required]")
+	scheduleCmd.Flags().Float64Var(&scheduleLength, "length", 0, "Length of the beams that give none in the project (m)")
+	scheduleCmd.MarkFlagRequired("file")
+}
+
+// scheduleHeader is the header of the tabular output of schedule
+var scheduleHeader = []string{"Mark", "Member", "Location", "Bar", "Diameter (mm)", "Shape", "Count",
+	"Cut Length (mm)", "Pieces", "Lap (mm)", "Hook (mm)", "Mass (kg)", "Note"}
+
+func runSchedule(cmd *cobra.Command, args []string) {
+	if scheduleLength < 0 {
+		printError(fmt.Errorf("--length must be positive, got %g", scheduleLength))
+		return
+	}
+	p, result, err := loadProject(scheduleFile, false)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	var bbs schedule.Schedule
+	var skipped []string
+	for i, r := range result.Beams {
+		length := p.Beams[i].Length
+		if length <= 0 {
+			length = scheduleLength
+		}
+		if length <= 0 {
+			skipped = append(skipped, fmt.Sprintf("%s: no length (give length in the project or --length)", r.Member.ID))
+			setExit(exitInvalidInput)
+			continue
+		}
+		entries, err := schedule.Beam(r.Result, length, selectedCatalog, selectedCode)
+		if err != nil {
+			skipped = append(skipped, err.Error())
+			setExit(exitInvalidInput)
+			continue
+		}
+		bbs.Add(entries)
+	}
+
+	if workbookFile != "" {
+		defer writeWorkbook(scheduleWorkbook(p, bbs))
+	}
+	if tabularOutput() {
+		printTable(scheduleHeader, scheduleRows(bbs))
+		return
+	}
+	if structuredOutput() {
+		printReport(cmd, p, map[string]any{"schedule": bbs, "skipped": skipped})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println("     BAR BENDING SCHEDULE")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  Project: %s\n", p.Name)
+	fmt.Printf("  Catalog: %s\n", selectedCatalog.Name)
+	fmt.Println()
+
+	w := newTextWriter()
+	fmt.Fprintln(w, "  Mark\tLocation\tBar\tShape\tNo.\tCut Length\tLap\tMass")
+	fmt.Fprintln(w, "  ────\t────────\t───\t─────\t───\t──────────\t───\t────")
+	member := ""
+	for _, e := range bbs.Entries {
+		if e.Member != member && member != "" {
+			fmt.Fprintln(w, "  \t\t\t\t\t\t\t")
+		}
+		member = e.Member
+		lap := "-"
+		if e.Pieces > 1 {
+			lap = fmt.Sprintf("%.0f mm", e.Lap)
+		}
+		bar, _ := selectedCatalog.Find(e.Bar)
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\t%.0f mm\t%s\t%.2f kg\n",
+			e.Mark, e.Location, bar.Label(), e.Shape, e.Count, e.CutLength, lap, e.Mass)
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Printf("  Total mass: %.2f kg (%.3f t)\n", bbs.Mass, bbs.Mass/1000)
+	fmt.Println()
+	notes := false
+	for _, e := range bbs.Entries {
+		if e.Note != "" {
+			fmt.Printf("  %s: %s\n", e.Mark, e.Note)
+			notes = true
+		}
+	}
+	if notes {
+		fmt.Println()
+	}
+
+	if len(skipped) > 0 || len(p.Sections) > 0 {
+		fmt.Println("NOT SCHEDULED:")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		for _, s := range skipped {
+			fmt.Printf("  %s\n", s)
+		}
+		for _, s := range p.Sections {
+			fmt.Printf("  %s: sections are not scheduled\n", s.ID)
+		}
+		fmt.Println()
+	}
+}
+
+// scheduleRows returns the rows of the tabular output of a schedule
+func scheduleRows(bbs schedule.Schedule) [][]string {
+	var rows [][]string
+	for _, e := range bbs.Entries {
+		rows = append(rows, []string{e.Mark, e.Member, e.Location, e.Bar, cell(e.Diameter), e.Shape, fmt.Sprint(e.Count),
+			cell(e.CutLength), fmt.Sprint(e.Pieces), cell(e.Lap), cell(e.Hook), cell(e.Mass), e.Note})
+	}
+	return rows
+}
+
+// scheduleWorkbook returns the schedule on one sheet with the total mass
+func scheduleWorkbook(p *project.Project, bbs schedule.Schedule) *xlsx.Workbook {
+	book := xlsx.New()
+	sheet := book.AddSheet("Bar Bending Schedule")
+	sheet.Table([]string{"Item", "Value"}, [][]string{
+		{"Project", p.Name},
+		{"Catalog", selectedCatalog.Name},
+		{"Total mass (kg)", cell(bbs.Mass)},
+	})
+	sheet.Table(scheduleHeader, scheduleRows(bbs))
+	return book
+}
@@ -18,6 +18,7 @@ The section is defined in a JSON file with vertices and reinforcement.
 Subcommands:
   analyze  - Calculate moment capacity for a defined section
   design   - Calculate required reinforcement for a given moment
+  init     - Write a starter section file of a common shape
 
 Example JSON file structure:
 {
@@ -35,7 +36,15 @@ Example JSON file structure:
   "reinforcement": [
     {"y": 65, "area": 1256.64, "description": "4-20mm"}
   ]
-}`,
+}
+
+Section files with a .yaml or .yml extension are read as YAML with the
+same keys. Use gorcb convert section to change the format or units of a
+section file.
+
+Values are in mm and MPa unless the file declares other units, e.g.
+  "units": {"length": "in", "stress": "psi"}
+Supported length units: mm, cm, m, in, ft. Stress units: MPa, psi, ksi.`,
 }
 
 func init() {
@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"strconv"
+	"strings"
 
 	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/alexiusacademia/gorcb/internal/section"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +17,38 @@ var (
 	sectionAnalyzeFile       string
 	sectionAnalyzeShowDiagram bool
 	sectionAnalyzeExportFile string
+	sectionAnalyzeCombined   string
+	sectionAnalyzeDXF        string
+	sectionAnalyzeConvergence string
+
+	// Steel model overrides
+	sectionAnalyzeSteelModel string
+	sectionAnalyzeEsh        float64
+	sectionAnalyzeFu         float64
+	sectionAnalyzeEsu        float64
+
+	sectionAnalyzeConcreteType string
+	sectionAnalyzeTransverse   string
+
+	sectionAnalyzeDuctility bool
+	sectionAnalyzeWatch     bool
+
+	// Fiber diagram of the confined analysis
+	sectionAnalyzeFiberMap       string
+	sectionAnalyzeFiberField     string
+	sectionAnalyzeFiberCurvature float64
+
+	// Axial force-moment interaction
+	sectionAnalyzeInteraction string
+	sectionAnalyzePu          float64
+	sectionAnalyzeMu          float64
+
+	// Biaxial interaction
+	sectionAnalyzeBiaxial        string
+	sectionAnalyzeBiaxialPu      []float64
+	sectionAnalyzeBiaxialSurface string
+	sectionAnalyzeBiaxialLoad    []float64
+	sectionAnalyzeBiaxialAxis    string
 )
 
 var sectionAnalyzeCmd = &cobra.Command{
@@ -26,42 +60,325 @@ defined in a JSON file.
 The analysis uses strain compatibility and force equilibrium to find
 the neutral axis position and calculate the moment capacity.
 
+The steel is modeled as elastic-perfectly plastic by default. A bilinear
+strain-hardening model can be selected to estimate the probable moment
+strength Mpr for capacity design, optionally with an ultimate strength
+cap and a fracture strain.
+
+With --interaction the axial force-moment (φPn–φMn) interaction diagram
+is calculated by strain compatibility over the whole depth, both faces in
+compression, and plotted to a png, svg or pdf file. A factored load given
+with --pu and --mu (compression and top in compression positive) is
+marked on the plot and checked against the φPn–φMn curve.
+
+With --biaxial the neutral axis is also turned around the section to plot
+the φMnx–φMny contours of the biaxial interaction surface at the design
+axial strengths of --biaxial-pu, by default 0, 20, 40, 60 and 80% of
+φPn,max. The bars of each layer are placed across the width as in the
+section diagram. --biaxial-surface writes the whole surface, from pure
+tension to φPn,max, as a Wavefront OBJ mesh for 3D viewers.
+
+A factored load bent about both axes is given with --biaxial-load as Pu,
+Mux and Muy (compression, top and right face in compression positive).
+The neutral axis is turned until the moment it resists acts in the
+direction of the load, generally inclined to it, and the resultant
+moment is checked against the surface. --biaxial-axis draws the section
+with that inclined neutral axis, the compression zone turned toward the
+compression face, and the angle of the axis from the X axis.
+
+Use --quiet to print only the tension steel, design strength and adequacy
+against the factored loads as one line, e.g. "id=T1 as=1256.64 phi_mn=187.42 status=OK".
+
 Examples:
   gorcb section analyze --file t-beam.json
-  gorcb section analyze -f my-section.json`,
+  gorcb section analyze -f my-section.json
+
+  # Section JSON from another program on stdin
+  generate-section | gorcb section analyze -f - --format json | jq .result
+
+With --convergence each neutral axis depth tried by the equilibrium
+iteration is drawn as a frame, the section with its stress block beside
+the force imbalance T − (Cc + Cs) so far, for demonstrating strain
+compatibility. A gif file is an animation of the frames; png, svg and pdf
+frames are numbered after the file name.
+
+Defining "confinement" (rho_s, fyh) in the JSON file adds a fiber
+moment-curvature analysis with a confined core and spalling cover.
+--fiber-map draws the section with each fiber colored by its stress or
+strain (--fiber-field) at ultimate, or at the curvature of
+--fiber-curvature, beside the color scale, showing where the section is
+working hardest and where the cover has spalled.
+
+  # Probable moment strength with strain hardening
+  gorcb section analyze -f t-beam.json --steel-model bilinear --esh 2000 --fu 620 --esu 0.09
+
+  # Fiber stresses at ultimate and fiber strains at a curvature of 0.02 1/m
+  gorcb section analyze -f column.json --fiber-map column-fibers.png
+  gorcb section analyze -f column.json --fiber-map column-strains.svg --fiber-field strain --fiber-curvature 0.02
+
+  # c/d relative to balanced, curvature ductility and Mpr at 1.25fy
+  gorcb section analyze -f t-beam.json --ductility
+
+  # Section, strain profile and stress block with Cc, Cs and T in one figure
+  gorcb section analyze -f doubly.json --combined doubly-figure.png
+
+  # Neutral axis moving to equilibrium, as an animation and as numbered frames
+  gorcb section analyze -f t-beam.json --convergence t-beam.gif
+  gorcb section analyze -f t-beam.json --convergence frames/t-beam.png
+
+  # Detailing drawing with the bars of layers described as count-bar, e.g. "4-20mm"
+  gorcb section analyze -f t-beam.json --dxf t-beam.dxf
+
+  # Interaction diagram of a column section with the factored load marked
+  gorcb section analyze -f column.json --interaction column-pm.png --pu 1200 --mu 180
+
+  # φMnx–φMny contours at three axial loads and the 3D interaction surface
+  gorcb section analyze -f column.json --biaxial column-mxmy.png --biaxial-pu 0,500,1000 --biaxial-surface column.obj
+
+  # Inclined neutral axis and compression zone under a load bent about both axes
+  gorcb section analyze -f column.json --biaxial-load 500,120,80 --biaxial-axis column-na.png
+
+  # Re-analyze on every save of the file while editing it
+  gorcb section analyze -f t-beam.json --watch -o t-beam.svg`,
 	Run: runSectionAnalyze,
 }
 
 func init() {
 	sectionCmd.AddCommand(sectionAnalyzeCmd)
+	tabular(sectionAnalyzeCmd)
+	reportable(sectionAnalyzeCmd)
+	quiet(sectionAnalyzeCmd)
 
-	sectionAnalyzeCmd.Flags().StringVarP(&sectionAnalyzeFile, "file", "f", "", "Path to section JSON file [required]")
+	sectionAnalyzeCmd.Flags().StringVarP(&sectionAnalyzeFile, "file", "f", "", "Path to section JSON or YAML file, or - for stdin [required]")
 	sectionAnalyzeCmd.MarkFlagRequired("file")
 
 	// Diagram options
 	sectionAnalyzeCmd.Flags().BoolVar(&sectionAnalyzeShowDiagram, "diagram", false, "Show ASCII stress-strain diagram")
 	sectionAnalyzeCmd.Flags().StringVarP(&sectionAnalyzeExportFile, "output", "o", "", "Export diagram to file (png, svg, pdf)")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeCombined, "combined", "", "Export the section, strain and stress diagrams side by side to file (png, svg, pdf)")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeDXF, "dxf", "", "Export a detailing drawing of the section with bars and dimensions to a DXF file")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeConvergence, "convergence", "", "Export the steps of the neutral axis iteration as an animation (gif) or numbered frames (png, svg, pdf)")
+
+	// Steel model options (override the steel_model in the JSON file)
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeSteelModel, "steel-model", "", "Steel model: elastic-plastic or bilinear")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzeEsh, "esh", 0, "Post-yield modulus Esh for bilinear steel (MPa)")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzeFu, "fu", 0, "Ultimate steel strength cap fu (MPa)")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzeEsu, "esu", 0, "Steel fracture strain εsu (0 = no fracture check)")
+
+	// Concrete type (overrides "lambda" in the file)
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeConcreteType, "concrete-type", "", concreteTypeUsage)
+	sectionAnalyzeCmd.Flags().BoolVar(&sectionAnalyzeDuctility, "ductility", false, "Show c/d, curvature ductility and probable moment Mpr")
+	sectionAnalyzeCmd.Flags().BoolVar(&sectionAnalyzeWatch, "watch", false, "Re-run the analysis and re-export the diagram whenever the section file is saved")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeFiberMap, "fiber-map", "", "Export the fibers of the confined analysis colored by their stress or strain to file (png, svg, pdf)")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeFiberField, "fiber-field", "stress", "Quantity the fibers of --fiber-map are colored by: stress or strain")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzeFiberCurvature, "fiber-curvature", 0, "Curvature of the moment-curvature point drawn by --fiber-map (1/m); ultimate when not given")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeTransverse, "transverse", "", "Transverse reinforcement for compression-controlled φ: tied or spiral (overrides the file)")
+
+	// Interaction diagram options
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeInteraction, "interaction", "", "Export the φPn–φMn interaction diagram to file (png, svg, pdf)")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzePu, "pu", 0, "Factored axial force Pu checked on the interaction diagram, compression positive (kN)")
+	sectionAnalyzeCmd.Flags().Float64Var(&sectionAnalyzeMu, "mu", 0, "Factored moment Mu checked on the interaction diagram, negative with the bottom in compression (kN-m)")
+
+	// Biaxial interaction options
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeBiaxial, "biaxial", "", "Export the φMnx–φMny contours of the biaxial interaction surface to file (png, svg, pdf)")
+	sectionAnalyzeCmd.Flags().Float64SliceVar(&sectionAnalyzeBiaxialPu, "biaxial-pu", nil, "Design axial strengths of the biaxial contours, compression positive (kN); 0, 20, 40, 60 and 80% of φPn,max when not given")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeBiaxialSurface, "biaxial-surface", "", "Export the biaxial interaction surface to a Wavefront OBJ file")
+	sectionAnalyzeCmd.Flags().Float64SliceVar(&sectionAnalyzeBiaxialLoad, "biaxial-load", nil, "Factored load Pu,Mux,Muy checked on the biaxial interaction surface, compression and the top and right face in compression positive (kN, kN-m)")
+	sectionAnalyzeCmd.Flags().StringVar(&sectionAnalyzeBiaxialAxis, "biaxial-axis", "", "Export the section with the inclined neutral axis and compression zone under --biaxial-load to file (png, svg, pdf)")
+}
+
+// applySteelModelFlags overrides the section's steel model with any steel model flags that were set
+func applySteelModelFlags(cmd *cobra.Command, sec *section.Section) {
+	flags := cmd.Flags()
+	if !flags.Changed("steel-model") && !flags.Changed("esh") && !flags.Changed("fu") && !flags.Changed("esu") {
+		return
+	}
+	if sec.SteelModel == nil {
+		sec.SteelModel = &section.SteelModel{}
+	}
+	if flags.Changed("steel-model") {
+		sec.SteelModel.Type = sectionAnalyzeSteelModel
+	} else if sec.SteelModel.Type == "" && (flags.Changed("esh") || flags.Changed("fu")) {
+		sec.SteelModel.Type = section.SteelBilinear
+	}
+	if flags.Changed("esh") {
+		sec.SteelModel.Esh = sectionAnalyzeEsh
+	}
+	if flags.Changed("fu") {
+		sec.SteelModel.Fu = sectionAnalyzeFu
+	}
+	if flags.Changed("esu") {
+		sec.SteelModel.EpsilonU = sectionAnalyzeEsu
+	}
 }
 
 func runSectionAnalyze(cmd *cobra.Command, args []string) {
+	if sectionAnalyzeWatch {
+		watchFile(sectionAnalyzeFile, func() { analyzeSection(cmd) })
+		return
+	}
+	analyzeSection(cmd)
+}
+
+// analyzeSection loads, analyzes and prints the section of --file
+func analyzeSection(cmd *cobra.Command) {
 	// Load section from file
 	sec, err := section.LoadFromFile(sectionAnalyzeFile)
 	if err != nil {
 		fmt.Printf("Error loading section: %v\n", err)
+		setExit(exitFor(err))
 		return
 	}
+	sec.Code = selectedCode
+	applySteelModelFlags(cmd, sec)
+	if sectionAnalyzeTransverse != "" {
+		sec.Transverse = sectionAnalyzeTransverse
+	}
+	if sectionAnalyzeConcreteType != "" {
+		sec.Lambda, err = concreteLambda(sectionAnalyzeConcreteType)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
 
 	// Run analysis
 	result, err := sec.Analyze()
 	if err != nil {
 		fmt.Printf("Error analyzing section: %v\n", err)
+		setExit(exitFor(err))
+		return
+	}
+
+	// Ductility and over-strength metrics
+	var ductility *nscp.DuctilityResult
+	if sectionAnalyzeDuctility {
+		ductility, err = sec.Ductility()
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Confined core / unconfined cover analysis
+	var confined *section.ConfinedResult
+	var confinedErr error
+	if sec.Confinement != nil {
+		confined, confinedErr = sec.AnalyzeConfined()
+	}
+
+	// Fibers of the confined analysis at a point of its response
+	var fibers *section.FiberState
+	var fibersField diagram.FiberField
+	if sectionAnalyzeFiberMap != "" && confinedErr == nil {
+		fibersField, err = fiberField(sectionAnalyzeFiberField)
+		if err == nil && confined == nil {
+			err = fmt.Errorf("--fiber-map needs the confinement of the section (rho_s, fyh) for its fiber analysis")
+		}
+		if err == nil {
+			var point section.MomentCurvaturePoint
+			point, err = fiberPoint(confined, sectionAnalyzeFiberCurvature)
+			if err == nil {
+				fibers, err = sec.Fibers(confined, point)
+			}
+		}
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Axial force-moment interaction, with the factored load when given
+	var interaction *section.Interaction
+	var load *interactionLoad
+	if sectionAnalyzeInteraction != "" || cmd.Flags().Changed("pu") || cmd.Flags().Changed("mu") {
+		interaction, err = sec.Interaction()
+		if err != nil {
+			printError(err)
+			return
+		}
+		if cmd.Flags().Changed("pu") || cmd.Flags().Changed("mu") {
+			load = checkInteractionLoad(interaction, sectionAnalyzePu, sectionAnalyzeMu)
+			checkAdequacy(load.Inside)
+		}
+	}
+
+	// Biaxial interaction contours, and the whole surface for its mesh
+	var biaxial, surface *section.Biaxial
+	bars := biaxialBars(sec)
+	if sectionAnalyzeBiaxial != "" || sectionAnalyzeBiaxialSurface != "" || cmd.Flags().Changed("biaxial-pu") {
+		biaxial, err = sectionBiaxial(sec, bars, sectionAnalyzeBiaxialPu)
+		if err == nil && sectionAnalyzeBiaxialSurface != "" {
+			surface, err = biaxialSurface(sec, bars)
+		}
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	// Inclined neutral axis under a factored load bent about both axes
+	var biaxialCheck *biaxialLoad
+	if sectionAnalyzeBiaxialAxis != "" && !cmd.Flags().Changed("biaxial-load") {
+		printError(fmt.Errorf("--biaxial-axis needs the factored load of --biaxial-load"))
+		return
+	}
+	if cmd.Flags().Changed("biaxial-load") {
+		biaxialCheck, err = checkBiaxialLoad(sec, bars, sectionAnalyzeBiaxialLoad)
+		if err != nil {
+			printError(err)
+			return
+		}
+		checkAdequacy(biaxialCheck.Inside)
+	}
+
+	tp := sec.TorsionProperties()
+	if reportFile != "" {
+		defer writeReport(sectionAnalysisReport(cmd, sec, result))
+	}
+
+	runPlugins(cmd, sec, analysisReport{Analysis: result, Torsion: tp, Ductility: ductility, Confined: confined, Interaction: interaction, Load: load, Biaxial: biaxial, BiaxialLoad: biaxialCheck})
+	defer printPluginResults()
+	q := quietResult{ID: sec.Name, As: result.Properties.TotalTensionSteel, PhiMn: result.PhiMn,
+		Status: quietStatus((load == nil || load.Inside) && (biaxialCheck == nil || biaxialCheck.Inside))}
+	if confinedErr != nil {
+		q.Status, q.Error = "ERROR", confinedErr.Error()
+	}
+	printQuiet(q)
+	if quietOutput {
+		if confinedErr != nil {
+			setExit(exitFor(confinedErr))
+		}
+		return
+	}
+	if tabularOutput() {
+		var rows [][]string
+		for i, layer := range result.SteelLayers {
+			rows = append(rows, []string{
+				strconv.Itoa(i + 1), cell(layer.Y), cell(layer.Area), cell(layer.Strain), cell(layer.Stress), cell(layer.Force),
+				strconv.FormatBool(layer.IsTension), strconv.FormatBool(layer.HasYielded), strconv.FormatBool(layer.Fractured),
+				layer.Description,
+			})
+		}
+		printTable([]string{"Layer", "Y (mm)", "Area (mm²)", "Strain", "Stress (MPa)", "Force (kN)",
+			"Tension", "Yielded", "Fractured", "Description"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		if confinedErr != nil {
+			fmt.Printf("Error in confined analysis: %v\n", confinedErr)
+			setExit(exitFor(confinedErr))
+			return
+		}
+		printReport(cmd, sec, analysisReport{Analysis: result, Torsion: tp, Ductility: ductility, Confined: confined, Interaction: interaction, Load: load, Biaxial: biaxial, BiaxialLoad: biaxialCheck})
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     NON-RECTANGULAR SECTION ANALYSIS - NSCP 2015")
+	fmt.Printf("     NON-RECTANGULAR SECTION ANALYSIS - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
@@ -77,29 +394,56 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	// Material properties
 	fmt.Println("MATERIAL PROPERTIES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTextWriter()
 	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", sec.Fc)
 	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", sec.Fy)
 	fmt.Fprintf(w, "  β₁:\t%.4f\n", result.Beta1)
+	fmt.Fprintf(w, "  λ (concrete type):\t%.2f\n", result.Lambda)
+	if sec.SteelModel != nil {
+		fmt.Fprintf(w, "  Steel model:\t%s\n", sec.SteelModel.Describe())
+	}
 	w.Flush()
 	fmt.Println()
 
 	// Geometric properties
 	fmt.Println("SECTION GEOMETRY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Width (max):\t%.0f mm\n", result.Properties.Width)
 	fmt.Fprintf(w, "  Height:\t%.0f mm\n", result.Properties.Height)
 	fmt.Fprintf(w, "  Gross Area:\t%.0f mm²\n", result.Properties.Area)
 	fmt.Fprintf(w, "  Effective Depth (d):\t%.0f mm\n", result.Properties.EffectiveDepth)
 	fmt.Fprintf(w, "  Vertices:\t%d points\n", len(sec.Vertices))
+	if len(sec.Holes) > 0 {
+		fmt.Fprintf(w, "  Voids:\t%d\n", len(sec.Holes))
+	}
+	w.Flush()
+	fmt.Println()
+
+	// Torsion and shear properties
+	fmt.Println("TORSION & SHEAR PROPERTIES (NSCP 2015 Section 422.7):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Acp:\t%.0f mm²\n", tp.Acp)
+	fmt.Fprintf(w, "  pcp:\t%.0f mm\n", tp.Pcp)
+	fmt.Fprintf(w, "  Aoh (stirrup at %.0f mm):\t%.0f mm²\n", tp.StirrupCover, tp.Aoh)
+	fmt.Fprintf(w, "  ph:\t%.0f mm\n", tp.Ph)
+	fmt.Fprintf(w, "  Ao = 0.85Aoh:\t%.0f mm²\n", tp.Ao)
+	if tp.IsHollow {
+		fmt.Fprintf(w, "  Ag²/pcp (hollow):\t%.4g mm³\n", tp.ThresholdRatio)
+	} else {
+		fmt.Fprintf(w, "  Acp²/pcp:\t%.4g mm³\n", tp.ThresholdRatio)
+	}
+	fmt.Fprintf(w, "  Torsional constant (J):\t%.4g mm⁴\n", tp.J)
+	fmt.Fprintf(w, "  Web width (bw):\t%.0f mm\n", tp.Bw)
+	fmt.Fprintf(w, "  Shear area (bw·d):\t%.0f mm²\n", tp.ShearArea)
 	w.Flush()
 	fmt.Println()
 
 	// Reinforcement
 	fmt.Println("REINFORCEMENT:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Layer\tY (mm)\tArea (mm²)\tDescription\n")
 	fmt.Fprintf(w, "  ─────\t──────\t──────────\t───────────\n")
 	for i, layer := range sec.Reinforcement {
@@ -107,7 +451,7 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	}
 	w.Flush()
 	fmt.Println()
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Total Tension Steel:\t%.2f mm²\n", result.Properties.TotalTensionSteel)
 	if result.Properties.TotalCompressionSteel > 0 {
 		fmt.Fprintf(w, "  Total Compression Steel:\t%.2f mm²\n", result.Properties.TotalCompressionSteel)
@@ -118,7 +462,7 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	// Neutral axis analysis
 	fmt.Println("NEUTRAL AXIS ANALYSIS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", result.C)
 	fmt.Fprintf(w, "  Compression block depth (a):\t%.2f mm\n", result.A)
 	fmt.Fprintf(w, "  c/d ratio:\t%.4f\n", result.C/result.Properties.EffectiveDepth)
@@ -129,7 +473,7 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	// Steel layer results
 	fmt.Println("STEEL LAYER ANALYSIS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Layer\tStrain\tStress (MPa)\tForce (kN)\tStatus\n")
 	fmt.Fprintf(w, "  ─────\t──────\t────────────\t──────────\t──────\n")
 	for i, layer := range result.SteelLayers {
@@ -137,7 +481,9 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 		if !layer.IsTension {
 			status = "Compression"
 		}
-		if layer.HasYielded {
+		if layer.Fractured {
+			status += " (fractured)"
+		} else if layer.HasYielded {
 			status += " (yields)"
 		}
 		fmt.Fprintf(w, "  %d\t%.6f\t%.2f\t%.2f\t%s\n", 
@@ -149,7 +495,7 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	// Internal forces
 	fmt.Println("INTERNAL FORCES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Cc (concrete compression):\t%.2f kN\n", result.Cc)
 	if result.Cs != 0 {
 		fmt.Fprintf(w, "  Cs (compression steel):\t%.2f kN\n", result.Cs)
@@ -160,16 +506,26 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 		equilibrium = "⚠"
 	}
 	fmt.Fprintf(w, "  Force equilibrium:\t%s\n", equilibrium)
+	fmt.Fprintf(w, "  Residual T − ΣC:\t%s\n", residualLabel(result.Residual, result.Iterations))
 	w.Flush()
 	fmt.Println()
 
 	// Capacity
 	fmt.Println("MOMENT CAPACITY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Maximum tensile strain (εt):\t%.6f\n", result.EpsilonT)
-	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
+	if sec.Transverse != "" {
+		fmt.Fprintf(w, "  Strength reduction factor (φ, %s):\t%.2f\n", strings.ToLower(sec.Transverse), result.Phi)
+	} else {
+		fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
+	}
 	fmt.Fprintf(w, "  Nominal Moment (Mn):\t%.2f kN-m\n", result.Mn)
+	if sec.SteelModel.IsBilinear() {
+		fmt.Fprintf(w, "  Probable Moment (Mpr, φ = 1.0):\t%.2f kN-m\n", result.Mn)
+	}
+	fmt.Fprintf(w, "  Modulus of Rupture (fr):\t%.2f MPa\n", result.Fr)
+	fmt.Fprintf(w, "  Cracking Moment (Mcr):\t%.2f kN-m\n", result.Mcr)
 	w.Flush()
 	fmt.Println()
 
@@ -181,24 +537,201 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 	// Status
 	fmt.Println("STATUS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	fmt.Printf("  %s\n", result.Message)
+	fmt.Printf("  %s\n", i18n.T(result.Message))
+	fmt.Println()
+
+	if ductility != nil {
+		printDuctility(ductility)
+	}
+
+	if confinedErr != nil {
+		fmt.Printf("Error in confined analysis: %v\n", confinedErr)
+		setExit(exitFor(confinedErr))
+	} else if confined != nil {
+		printConfinedResult(confined, result.Mn)
+	}
+
+	if interaction != nil {
+		printInteraction(sec, interaction, load)
+	}
+	if biaxial != nil {
+		printBiaxial(biaxial)
+	}
+	if biaxialCheck != nil {
+		printBiaxialLoad(biaxialCheck)
+	}
+
+	// Show diagram if requested
+	if sectionAnalyzeShowDiagram {
+		diagramData := sectionAnalysisDiagramData(sec, result)
+
+		fmt.Println(diagram.DrawASCIISectionDiagram(diagramData))
+		fmt.Println(diagram.DrawStrainDiagram(diagramData))
+		fmt.Println(diagram.DrawStressBlock(diagramData))
+	}
+
+	// Export diagram if requested
+	if sectionAnalyzeExportFile != "" {
+		diagramData := sectionAnalysisDiagramData(sec, result)
+
+		err := diagram.ExportSectionDiagram(diagramData, sectionAnalyzeExportFile)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Diagram exported to: %s\n", sectionAnalyzeExportFile)
+			printPlotData(sectionAnalyzeExportFile)
+		}
+	}
+
+	if sectionAnalyzeCombined != "" {
+		err := diagram.ExportCombinedDiagram(sectionAnalysisDiagramData(sec, result), sectionAnalyzeCombined)
+		if err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Combined diagram exported to: %s\n", sectionAnalyzeCombined)
+			printPlotData(sectionAnalyzeCombined)
+		}
+	}
+
+	if sectionAnalyzeDXF != "" {
+		if err := diagram.ExportSectionDXF(sectionAnalysisDiagramData(sec, result), sectionAnalyzeDXF); err != nil {
+			fmt.Printf("Error exporting drawing: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Detailing drawing exported to: %s\n", sectionAnalyzeDXF)
+		}
+	}
+
+	if sectionAnalyzeConvergence != "" {
+		files, err := diagram.ExportConvergenceAnimation(sectionConvergenceData(sec, result), sectionAnalyzeConvergence)
+		switch {
+		case err != nil:
+			fmt.Printf("Error exporting convergence animation: %v\n", err)
+			setExit(exitFailure)
+		case len(files) == 1 && files[0] == sectionAnalyzeConvergence:
+			fmt.Printf("Convergence animation exported to: %s (%d frames)\n", sectionAnalyzeConvergence, len(result.Trials))
+		default:
+			fmt.Printf("Convergence frames exported to: %s … %s\n", files[0], files[len(files)-1])
+		}
+	}
+
+	// Export interaction diagram if requested
+	if sectionAnalyzeInteraction != "" {
+		err := diagram.ExportInteractionDiagram(interactionDiagramData(sec, interaction, load), sectionAnalyzeInteraction)
+		if err != nil {
+			fmt.Printf("Error exporting interaction diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Interaction diagram exported to: %s\n", sectionAnalyzeInteraction)
+			printPlotData(sectionAnalyzeInteraction)
+		}
+	}
+
+	if sectionAnalyzeBiaxial != "" {
+		err := diagram.ExportBiaxialDiagram(biaxialDiagramData(sec, biaxial), sectionAnalyzeBiaxial)
+		if err != nil {
+			fmt.Printf("Error exporting biaxial interaction diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Biaxial interaction diagram exported to: %s\n", sectionAnalyzeBiaxial)
+			printPlotData(sectionAnalyzeBiaxial)
+		}
+	}
+
+	if sectionAnalyzeBiaxialSurface != "" {
+		if err := diagram.ExportBiaxialSurface(biaxialDiagramData(sec, surface), sectionAnalyzeBiaxialSurface); err != nil {
+			fmt.Printf("Error exporting biaxial interaction surface: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Biaxial interaction surface exported to: %s\n", sectionAnalyzeBiaxialSurface)
+		}
+	}
+
+	if fibers != nil {
+		err := diagram.ExportFiberDiagram(fiberDiagramData(sec, result, fibers, fibersField), sectionAnalyzeFiberMap)
+		if err != nil {
+			fmt.Printf("Error exporting fiber diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Fiber diagram exported to: %s\n", sectionAnalyzeFiberMap)
+			printPlotData(sectionAnalyzeFiberMap)
+		}
+	}
+
+	if sectionAnalyzeBiaxialAxis != "" {
+		err := diagram.ExportInclinedAxisDiagram(inclinedAxisData(sec, result, bars, biaxialCheck), sectionAnalyzeBiaxialAxis)
+		if err != nil {
+			fmt.Printf("Error exporting neutral axis diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Neutral axis diagram exported to: %s\n", sectionAnalyzeBiaxialAxis)
+			printPlotData(sectionAnalyzeBiaxialAxis)
+		}
+	}
+}
+
+func printConfinedResult(r *section.ConfinedResult, whitneyMn float64) {
+	fmt.Println("CONFINED SECTION ANALYSIS (Mander model, fiber moment-curvature):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Core area (hoop centerline at %.0f mm):\t%.0f mm²\n", r.CoreCover, r.CoreArea)
+	fmt.Fprintf(w, "  Cover area:\t%.0f mm²\n", r.CoverArea)
+	fmt.Fprintf(w, "  Confined strength (f'cc):\t%.1f MPa (%.2f f'c)\n", r.Fcc, r.Fcc/r.Fco)
+	fmt.Fprintf(w, "  Strain at f'cc (εcc):\t%.4f\n", r.EpsilonCC)
+	fmt.Fprintf(w, "  Ultimate core strain (εcu):\t%.4f\n", r.EpsilonCU)
+	fmt.Fprintf(w, "  Cover spalling strain:\t%.4f\n", r.SpallingStrain)
+	w.Flush()
+	fmt.Println()
+
+	w = newTextWriter()
+	fmt.Fprintf(w, "  First yield:\tφy = %.5f 1/m\tMy = %.2f kN-m\n", r.YieldCurvature, r.YieldMoment)
+	fmt.Fprintf(w, "  Peak moment:\t\tMmax = %.2f kN-m\n", r.PeakMoment)
+	fmt.Fprintf(w, "  Ultimate:\tφu = %.5f 1/m\tMu = %.2f kN-m\n", r.UltimateCurvature, r.UltimateMoment)
+	w.Flush()
 	fmt.Println()
 
-	// Find tension steel info for diagram
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Curvature ductility (μφ):\t%.2f\n", r.CurvatureDuctility)
+	if whitneyMn > 0 {
+		fmt.Fprintf(w, "  Mmax / Mn (stress block):\t%.3f\n", r.PeakMoment/whitneyMn)
+	}
+	spalled := "No"
+	if r.CoverSpalled {
+		spalled = "Yes"
+	}
+	fmt.Fprintf(w, "  Cover spalled:\t%s\n", spalled)
+	fmt.Fprintf(w, "  Limit state:\t%s\n", r.Limit)
+	w.Flush()
+	fmt.Println()
+}
+
+func absFloat(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// sectionAnalysisDiagramData returns the section diagram of an analyzed
+// section from its tension and compression steel layers, each taken at the
+// centroid of its layers
+func sectionAnalysisDiagramData(sec *section.Section, result *section.AnalysisResult) diagram.SectionDiagramData {
 	var tensionSteelY, tensionSteelArea float64
 	var compSteelY, compSteelArea float64
 	var tensionYields, compYields bool
-	epsilonY := sec.Fy / nscp.Es
+	epsilonY := selectedCode.DesignYieldStrength(sec.Fy) / nscp.Es
 
 	for _, layer := range result.SteelLayers {
 		if layer.IsTension {
-			tensionSteelY = layer.Y
+			tensionSteelY += layer.Y * layer.Area
 			tensionSteelArea += layer.Area
 			if layer.HasYielded {
 				tensionYields = true
 			}
 		} else {
-			compSteelY = layer.Y
+			compSteelY += layer.Y * layer.Area
 			compSteelArea += layer.Area
 			if layer.HasYielded {
 				compYields = true
@@ -206,75 +739,108 @@ func runSectionAnalyze(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Convert section vertices to diagram points
-	var diagramVertices []diagram.Point
-	for _, v := range sec.Vertices {
-		diagramVertices = append(diagramVertices, diagram.Point{X: v.X, Y: v.Y})
+	if tensionSteelArea > 0 {
+		tensionSteelY /= tensionSteelArea
+	}
+	if compSteelArea > 0 {
+		compSteelY /= compSteelArea
 	}
 
-	// Show diagram if requested
-	if sectionAnalyzeShowDiagram {
-		diagramData := diagram.SectionDiagramData{
-			Width:            result.Properties.Width,
-			Height:           result.Properties.Height,
-			Vertices:         diagramVertices,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    tensionSteelY,
-			TensionSteelArea: tensionSteelArea,
-			CompSteelY:       result.Properties.Height - compSteelY,
-			CompSteelArea:    compSteelArea,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * sec.Fc,
-			FsTension:        sec.Fy,
-			FsComp:           sec.Fy,
-			TensionYields:    tensionYields,
-			CompYields:       compYields,
-			IsDoubly:         compSteelArea > 0,
-		}
+	var vertices []diagram.Point
+	for _, v := range sec.Vertices {
+		vertices = append(vertices, diagram.Point{X: v.X, Y: v.Y})
+	}
 
-		fmt.Println(diagram.DrawASCIISectionDiagram(diagramData))
-		fmt.Println(diagram.DrawStrainDiagram(diagramData))
+	data := diagram.SectionDiagramData{
+		Width:            result.Properties.Width,
+		Height:           result.Properties.Height,
+		Vertices:         vertices,
+		NeutralAxisDepth: result.C,
+		StressBlockDepth: result.A,
+		TensionSteelY:    tensionSteelY,
+		TensionSteelArea: tensionSteelArea,
+		CompSteelY:       result.Properties.Height - compSteelY,
+		CompSteelArea:    compSteelArea,
+		EpsilonCU:        selectedCode.EpsilonCU(sec.Fc),
+		EpsilonT:         result.EpsilonT,
+		EpsilonY:         epsilonY,
+		Fc:               selectedCode.Alpha1(sec.Fc) * sec.Fc,
+		FsTension:        selectedCode.DesignYieldStrength(sec.Fy),
+		FsComp:           selectedCode.DesignYieldStrength(sec.Fy),
+		TensionYields:    tensionYields,
+		CompYields:       compYields,
+		IsDoubly:         compSteelArea > 0,
+		Units:            selectedUnits,
 	}
 
-	// Export diagram if requested
-	if sectionAnalyzeExportFile != "" {
-		diagramData := diagram.SectionDiagramData{
-			Width:            result.Properties.Width,
-			Height:           result.Properties.Height,
-			Vertices:         diagramVertices,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    tensionSteelY,
-			TensionSteelArea: tensionSteelArea,
-			CompSteelY:       result.Properties.Height - compSteelY,
-			CompSteelArea:    compSteelArea,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         result.EpsilonT,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * sec.Fc,
-			FsTension:        sec.Fy,
-			FsComp:           sec.Fy,
-			TensionYields:    tensionYields,
-			CompYields:       compYields,
-			IsDoubly:         compSteelArea > 0,
+	// Bars of the layers described as count-bar, e.g. "4-20mm". Unless every
+	// layer is, the diagram estimates the bars from the steel areas.
+	var bars []diagram.Bar
+	for _, layer := range result.SteelLayers {
+		fields := strings.Fields(layer.Description)
+		if len(fields) == 0 {
+			return data
 		}
-
-		err := diagram.ExportSectionDiagram(diagramData, sectionAnalyzeExportFile)
+		g, err := selectedCatalog.ParseGroup(fields[0])
 		if err != nil {
-			fmt.Printf("Error exporting diagram: %v\n", err)
-		} else {
-			fmt.Printf("Diagram exported to: %s\n", sectionAnalyzeExportFile)
+			return data
 		}
+		bars = append(bars, diagram.OutlineLayerBars(vertices, data.Width, data.Height, g.Count, g.Bar.Diameter, layer.Y, layer.IsTension)...)
 	}
+	data.Bars = bars
+	return data
 }
 
-func absFloat(x float64) float64 {
-	if x < 0 {
-		return -x
+// sectionConvergenceData returns the section diagram of an analyzed section
+// with the neutral axis depths tried on the way to equilibrium
+func sectionConvergenceData(sec *section.Section, result *section.AnalysisResult) diagram.ConvergenceData {
+	data := diagram.ConvergenceData{Section: sectionAnalysisDiagramData(sec, result), Beta1: result.Beta1}
+	for _, t := range result.Trials {
+		data.Steps = append(data.Steps, diagram.ConvergenceStep{Method: t.Method, C: t.C, Imbalance: t.Imbalance})
 	}
-	return x
+	return data
 }
 
+// sectionAnalysisReport returns the analysis report of a non-rectangular section
+func sectionAnalysisReport(cmd *cobra.Command, sec *section.Section, r *section.AnalysisResult) *report.Document {
+	doc := newReport(cmd, "Non-Rectangular Section Analysis")
+	sectionReportInputs(doc, sec, r.Properties)
+
+	doc.Section("Steel Layers")
+	var rows [][]string
+	for i, layer := range r.SteelLayers {
+		status := "Tension"
+		if !layer.IsTension {
+			status = "Compression"
+		}
+		if layer.Fractured {
+			status += " (fractured)"
+		} else if layer.HasYielded {
+			status += " (yields)"
+		}
+		rows = append(rows, []string{strconv.Itoa(i + 1), fmtLength(layer.Y, 0), fmtArea(layer.Area, 2),
+			fmt.Sprintf("%.6f", layer.Strain), fmtStress(layer.Stress, 2), fmtForce(layer.Force, 2), status})
+	}
+	doc.Table([]string{"Layer", "Y", "Area", "Strain", "Stress", "Force", "Status"}, rows)
+
+	doc.Section("Code Checks")
+	reportChecks(doc, tensionControlledCheck(sec.Fc, sec.Fy, r.EpsilonT))
+	doc.Paragraph(r.Message)
+
+	doc.Section("Section Analysis")
+	doc.Fields(
+		[]string{"Neutral axis depth (c)", fmtLength(r.C, 2)},
+		[]string{"Compression block depth (a)", fmtLength(r.A, 2)},
+		[]string{"Cc (concrete compression)", fmtForce(r.Cc, 2)},
+		[]string{"Cs (compression steel)", fmtForce(r.Cs, 2)},
+		[]string{"T (tension steel)", fmtForce(r.T, 2)},
+		[]string{"Residual T − ΣC", residualLabel(r.Residual, r.Iterations)},
+		[]string{"Strength reduction factor (φ)", fmt.Sprintf("%.2f", r.Phi)},
+		[]string{"Cracking moment (Mcr)", fmtMoment(r.Mcr, 2)},
+	)
+
+	doc.Section("Moment Capacity")
+	doc.Paragraph(fmt.Sprintf("Mn = %s, **φMn = %s**.", fmtMoment(r.Mn, 2), fmtMoment(r.PhiMn, 2)))
+	reportDiagram(doc, sectionAnalysisDiagramData(sec, r), sectionAnalyzeExportFile, "")
+	return doc
+}
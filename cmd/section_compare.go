@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sectionCompareMu         float64
+	sectionCompareExportFile string
+)
+
+var sectionCompareCmd = &cobra.Command{
+	Use:   "compare <a.json> <b.json>",
+	Short: "Compare two sections side by side",
+	Long: `Analyze two sections defined in JSON files and tabulate their
+capacities, steel quantities, self-weights and efficiency side by side.
+Either file may be - to read that section from standard input.
+
+When a factored moment is given with --mu, each section is also designed
+for that moment and its utilization (Mu/φMn) is reported.
+
+With --output, both sections are drawn side by side to the same scale with
+their stress blocks and key results, for an option study.
+
+Examples:
+  gorcb section compare rectangular.json t-beam.json
+  gorcb section compare rectangular.json t-beam.json --mu 200
+  gorcb section compare rectangular.json t-beam.json --mu 200 -o options.png`,
+	Args: cobra.ExactArgs(2),
+	Run:  runSectionCompare,
+}
+
+func init() {
+	sectionCmd.AddCommand(sectionCompareCmd)
+
+	sectionCompareCmd.Flags().Float64VarP(&sectionCompareMu, "mu", "m", 0, "Factored moment Mu (kN-m) for utilization and design")
+	sectionCompareCmd.Flags().StringVarP(&sectionCompareExportFile, "output", "o", "", "Export both sections side by side to the same scale to file (png, svg, pdf)")
+}
+
+// sectionComparison holds the per-section quantities shown in the comparison table
+type sectionComparison struct {
+	Label    string
+	Section  *section.Section
+	Analysis *section.AnalysisResult
+	Design   *section.DesignResult
+
+	SelfWeight float64 // kN/m
+	SteelMass  float64 // kg/m
+	Rho        float64 // As/Ag
+}
+
+func runSectionCompare(cmd *cobra.Command, args []string) {
+	if args[0] == section.Stdin && args[1] == section.Stdin {
+		fmt.Println("Error: only one section can be read from stdin (-)")
+		setExit(exitInvalidInput)
+		return
+	}
+	var items []sectionComparison
+
+	for _, path := range args {
+		sec, err := section.LoadFromFile(path)
+		if err != nil {
+			fmt.Printf("Error loading section %s: %v\n", path, err)
+			setExit(exitFor(err))
+			return
+		}
+		sec.Code = selectedCode
+
+		analysis, err := sec.Analyze()
+		if err != nil {
+			fmt.Printf("Error analyzing section %s: %v\n", path, err)
+			setExit(exitFor(err))
+			return
+		}
+
+		item := sectionComparison{
+			Label:    sec.Name,
+			Section:  sec,
+			Analysis: analysis,
+		}
+		if item.Label == "" {
+			item.Label = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+
+		props := analysis.Properties
+		totalSteel := props.TotalTensionSteel + props.TotalCompressionSteel
+		item.SelfWeight = props.Area / 1e6 * nscp.UnitWeightConcrete
+		item.SteelMass = totalSteel / 1e6 * nscp.SteelDensity
+		if props.Area > 0 {
+			item.Rho = totalSteel / props.Area
+		}
+
+		if sectionCompareMu > 0 {
+			design, err := sec.Design(sectionCompareMu)
+			if err != nil {
+				fmt.Printf("Error designing section %s: %v\n", path, err)
+				setExit(exitFor(err))
+				return
+			}
+			item.Design = design
+			checkAdequacy(design.IsAdequate)
+		}
+
+		items = append(items, item)
+	}
+
+	if structuredOutput() {
+		printReport(cmd, nil, items)
+		return
+	}
+
+	a, b := items[0], items[1]
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     SECTION COMPARISON - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	// Fixed column widths keep the blocks aligned with each other
+	row := func(label, format string, va, vb float64) {
+		compareText(label, fmt.Sprintf(format, va), fmt.Sprintf(format, vb))
+	}
+
+	fmt.Println("GEOMETRY & MATERIALS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	compareText("", a.Label, b.Label)
+	compareText("", underline(a.Label), underline(b.Label))
+	row("Width (max), mm", "%.0f", a.Analysis.Properties.Width, b.Analysis.Properties.Width)
+	row("Height, mm", "%.0f", a.Analysis.Properties.Height, b.Analysis.Properties.Height)
+	row("Gross Area, mm²", "%.0f", a.Analysis.Properties.Area, b.Analysis.Properties.Area)
+	row("Effective Depth (d), mm", "%.0f", a.Analysis.Properties.EffectiveDepth, b.Analysis.Properties.EffectiveDepth)
+	row("f'c, MPa", "%.1f", a.Section.Fc, b.Section.Fc)
+	row("fy, MPa", "%.1f", a.Section.Fy, b.Section.Fy)
+	fmt.Println()
+
+	fmt.Println("QUANTITIES:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	row("Tension Steel, mm²", "%.2f", a.Analysis.Properties.TotalTensionSteel, b.Analysis.Properties.TotalTensionSteel)
+	row("Compression Steel, mm²", "%.2f", a.Analysis.Properties.TotalCompressionSteel, b.Analysis.Properties.TotalCompressionSteel)
+	row("Steel Ratio (As/Ag)", "%.4f", a.Rho, b.Rho)
+	row("Concrete Volume, m³/m", "%.4f", a.Analysis.Properties.Area/1e6, b.Analysis.Properties.Area/1e6)
+	row("Self-weight, kN/m", "%.2f", a.SelfWeight, b.SelfWeight)
+	row("Steel Mass, kg/m", "%.2f", a.SteelMass, b.SteelMass)
+	fmt.Println()
+
+	fmt.Println("CAPACITY:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	row("Neutral Axis (c), mm", "%.2f", a.Analysis.C, b.Analysis.C)
+	row("Tensile Strain (εt)", "%.6f", a.Analysis.EpsilonT, b.Analysis.EpsilonT)
+	row("φ", "%.2f", a.Analysis.Phi, b.Analysis.Phi)
+	row("Mn, kN-m", "%.2f", a.Analysis.Mn, b.Analysis.Mn)
+	row("φMn, kN-m", "%.2f", a.Analysis.PhiMn, b.Analysis.PhiMn)
+	fmt.Println()
+
+	fmt.Println("EFFICIENCY:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	row("φMn / Self-weight, m²", "%.2f", a.Analysis.PhiMn/a.SelfWeight, b.Analysis.PhiMn/b.SelfWeight)
+	row("φMn / Steel Mass, kN-m per kg/m", "%.2f", a.Analysis.PhiMn/a.SteelMass, b.Analysis.PhiMn/b.SteelMass)
+	row("φMn / Gross Area, kN-m per 10³mm²", "%.3f", a.Analysis.PhiMn/a.Analysis.Properties.Area*1000, b.Analysis.PhiMn/b.Analysis.Properties.Area*1000)
+	fmt.Println()
+
+	if sectionCompareMu > 0 {
+		fmt.Printf("DEMAND CHECK (Mu = %.2f kN-m):\n", sectionCompareMu)
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		row("Utilization (Mu/φMn)", "%.3f", sectionCompareMu/a.Analysis.PhiMn, sectionCompareMu/b.Analysis.PhiMn)
+		compareText("Provided Steel", adequacyMark(a.Analysis.PhiMn >= sectionCompareMu), adequacyMark(b.Analysis.PhiMn >= sectionCompareMu))
+		row("As Required, mm²", "%.2f", a.Design.AsRequired, b.Design.AsRequired)
+		row("As,min, mm²", "%.2f", a.Design.AsMin, b.Design.AsMin)
+		compareText("Designed Steel", adequacyMark(a.Design.IsAdequate), adequacyMark(b.Design.IsAdequate))
+		fmt.Println()
+	}
+
+	// Summary
+	fmt.Println("SUMMARY:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	stronger, weaker := a, b
+	if b.Analysis.PhiMn > a.Analysis.PhiMn {
+		stronger, weaker = b, a
+	}
+	if weaker.Analysis.PhiMn > 0 {
+		fmt.Printf("  %s has %.1f%% more capacity than %s.\n", stronger.Label,
+			(stronger.Analysis.PhiMn/weaker.Analysis.PhiMn-1)*100, weaker.Label)
+	}
+	lighter := a
+	if b.SelfWeight < a.SelfWeight {
+		lighter = b
+	}
+	fmt.Printf("  %s is the lighter section.\n", lighter.Label)
+	fmt.Println()
+
+	if sectionCompareExportFile != "" {
+		data := diagram.ComparisonData{A: sectionComparedData(a), B: sectionComparedData(b)}
+		if err := diagram.ExportComparisonDiagram(data, sectionCompareExportFile); err != nil {
+			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
+		} else {
+			fmt.Printf("Comparison exported to: %s\n", sectionCompareExportFile)
+			printPlotData(sectionCompareExportFile)
+		}
+	}
+}
+
+// sectionComparedData returns a compared section with its analysis drawn
+// and the key results of the comparison listed under it
+func sectionComparedData(item sectionComparison) diagram.ComparedSection {
+	props := item.Analysis.Properties
+	results := []diagram.ComparisonResult{
+		{Name: "h", Value: fmtLength(props.Height, 0)},
+		{Name: "As", Value: fmtArea(props.TotalTensionSteel, 0)},
+	}
+	if props.TotalCompressionSteel > 0 {
+		results = append(results, diagram.ComparisonResult{Name: "As'", Value: fmtArea(props.TotalCompressionSteel, 0)})
+	}
+	results = append(results,
+		diagram.ComparisonResult{Name: "c", Value: fmtLength(item.Analysis.C, 1)},
+		diagram.ComparisonResult{Name: "εt", Value: fmt.Sprintf("%.5f", item.Analysis.EpsilonT)},
+		diagram.ComparisonResult{Name: "φMn", Value: fmtMoment(item.Analysis.PhiMn, 2)},
+		diagram.ComparisonResult{Name: "Self-weight", Value: fmt.Sprintf("%.2f kN/m", item.SelfWeight)},
+		diagram.ComparisonResult{Name: "Steel", Value: fmt.Sprintf("%.2f kg/m", item.SteelMass)},
+	)
+	if sectionCompareMu > 0 && item.Analysis.PhiMn > 0 {
+		results = append(results, diagram.ComparisonResult{Name: "Mu/φMn", Value: fmt.Sprintf("%.3f", sectionCompareMu/item.Analysis.PhiMn)})
+	}
+	return diagram.ComparedSection{
+		Label:   item.Label,
+		Section: sectionAnalysisDiagramData(item.Section, item.Analysis),
+		Results: results,
+	}
+}
+
+// compareLabelWidth and compareValueWidth are the fixed column widths of the comparison table
+const (
+	compareLabelWidth = 36
+	compareValueWidth = 20
+)
+
+func compareText(label, va, vb string) {
+	fmt.Printf("  %s%s%s\n", padRight(label, compareLabelWidth), padRight(va, compareValueWidth), vb)
+}
+
+func padRight(s string, width int) string {
+	n := len([]rune(s))
+	if n >= width {
+		return s + " "
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+func underline(s string) string {
+	return strings.Repeat("─", len([]rune(s)))
+}
+
+func adequacyMark(ok bool) string {
+	if ok {
+		return "OK ✓"
+	}
+	return "NOT ADEQUATE ⚠"
+}
@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
+	"github.com/alexiusacademia/gorcb/internal/codes"
 	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
 	"github.com/alexiusacademia/gorcb/internal/section"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +17,11 @@ var (
 	sectionDesignMu         float64
 	sectionDesignShowDiagram bool
 	sectionDesignExportFile string
+
+	sectionDesignDeterminate bool
+
+	// Concrete type for λ, in place of the lambda of the file
+	sectionDesignConcreteType string
 )
 
 var sectionDesignCmd = &cobra.Command{
@@ -27,18 +33,29 @@ section to resist a given factored moment (Mu).
 The section geometry and compression reinforcement (if any) are defined
 in the JSON file. The design will calculate the required tension steel.
 
+As,min is based on the web width bw. For a statically determinate member
+with a flange in tension (e.g. an inverted T-beam on simple supports),
+the width is increased to the smaller of bf and 2bw (NSCP 2015 Section
+409.6.1.2); set "statically_determinate" in the file or use --determinate.
+
 Examples:
   gorcb section design --file t-beam.json --mu 200
-  gorcb section design -f my-section.json -m 150`,
+  gorcb section design -f my-section.json -m 150
+  gorcb section design -f inverted-t.json -m 150 --determinate
+  cat t-beam.json | gorcb section design -f - -m 200`,
 	Run: runSectionDesign,
 }
 
 func init() {
 	sectionCmd.AddCommand(sectionDesignCmd)
+	reportable(sectionDesignCmd)
 
-	sectionDesignCmd.Flags().StringVarP(&sectionDesignFile, "file", "f", "", "Path to section JSON file [required]")
+	sectionDesignCmd.Flags().StringVarP(&sectionDesignFile, "file", "f", "", "Path to section JSON or YAML file, or - for stdin [required]")
 	sectionDesignCmd.Flags().Float64VarP(&sectionDesignMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
 
+	sectionDesignCmd.Flags().StringVar(&sectionDesignConcreteType, "concrete-type", "", concreteTypeUsage)
+	sectionDesignCmd.Flags().BoolVar(&sectionDesignDeterminate, "determinate", false, "Treat the member as statically determinate (flange-in-tension As,min rule)")
+
 	sectionDesignCmd.MarkFlagRequired("file")
 	sectionDesignCmd.MarkFlagRequired("mu")
 
@@ -52,20 +69,43 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	sec, err := section.LoadFromFile(sectionDesignFile)
 	if err != nil {
 		fmt.Printf("Error loading section: %v\n", err)
+		setExit(exitFor(err))
 		return
 	}
+	sec.Code = selectedCode
+	if sectionDesignDeterminate {
+		sec.StaticallyDeterminate = true
+	}
+	if sectionDesignConcreteType != "" {
+		sec.Lambda, err = concreteLambda(sectionDesignConcreteType)
+		if err != nil {
+			printError(err)
+			return
+		}
+	}
 
 	// Run design
 	result, err := sec.Design(sectionDesignMu)
 	if err != nil {
 		fmt.Printf("Error designing section: %v\n", err)
+		setExit(exitFor(err))
+		return
+	}
+	checkAdequacy(result.IsAdequate)
+	if reportFile != "" {
+		defer writeReport(sectionDesignReport(cmd, sec, result))
+	}
+	runPlugins(cmd, sec, result)
+	defer printPluginResults()
+	if structuredOutput() {
+		printReport(cmd, sec, result)
 		return
 	}
 
 	// Print results
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════════════════")
-	fmt.Println("     NON-RECTANGULAR SECTION DESIGN - NSCP 2015")
+	fmt.Printf("     NON-RECTANGULAR SECTION DESIGN - %s\n", selectedCode.Name())
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
@@ -81,7 +121,7 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	// Material properties
 	fmt.Println("MATERIAL PROPERTIES:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTextWriter()
 	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", sec.Fc)
 	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", sec.Fy)
 	fmt.Fprintf(w, "  β₁:\t%.4f\n", result.Beta1)
@@ -91,7 +131,7 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	// Geometric properties
 	fmt.Println("SECTION GEOMETRY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Width (max):\t%.0f mm\n", result.Properties.Width)
 	fmt.Fprintf(w, "  Height:\t%.0f mm\n", result.Properties.Height)
 	fmt.Fprintf(w, "  Gross Area:\t%.0f mm²\n", result.Properties.Area)
@@ -102,7 +142,7 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	// Design input
 	fmt.Println("DESIGN REQUIREMENT:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", sectionDesignMu)
 	w.Flush()
 	fmt.Println()
@@ -110,7 +150,7 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	// Section analysis at design
 	fmt.Println("SECTION AT DESIGN CAPACITY:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
 	fmt.Fprintf(w, "  Neutral axis depth (c):\t%.2f mm\n", result.C)
 	fmt.Fprintf(w, "  Compression block depth (a):\t%.2f mm\n", result.A)
 	fmt.Fprintf(w, "  Strength reduction factor (φ):\t%.2f\n", result.Phi)
@@ -120,8 +160,18 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 	// Steel area limits
 	fmt.Println("REINFORCEMENT LIMITS:")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Web width (bw):\t%.0f mm\n", result.WebWidth)
+	if result.FlangeInTension {
+		fmt.Fprintf(w, "  Tension flange width (bf):\t%.0f mm\n", result.TensionWidth)
+		if sec.StaticallyDeterminate {
+			fmt.Fprintf(w, "  Width for As,min:\t%.0f mm (statically determinate, flange in tension)\n", result.AsMinWidth)
+		} else {
+			fmt.Fprintf(w, "  Width for As,min:\t%.0f mm (flange in tension; use --determinate for simple spans)\n", result.AsMinWidth)
+		}
+	}
 	fmt.Fprintf(w, "  As,min:\t%.2f mm²\n", result.AsMin)
+	printMinSteelAlternative(w, result.AsStrength, result.AsAlternative)
 	w.Flush()
 	fmt.Println()
 
@@ -136,13 +186,13 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 		fmt.Println()
 		fmt.Printf("  φMn = %.2f kN-m ≥ Mu = %.2f kN-m ✓\n", result.PhiMn, sectionDesignMu)
 		fmt.Println()
-		fmt.Printf("  Status: %s\n", result.Message)
+		fmt.Printf("  Status: %s\n", i18n.T(result.Message))
 	} else {
 		fmt.Println("  ╔═════════════════════════════════════════════════╗")
 		fmt.Println("  ║  DESIGN NOT ADEQUATE                            ║")
 		fmt.Println("  ╚═════════════════════════════════════════════════╝")
 		fmt.Println()
-		fmt.Printf("  %s\n", result.Message)
+		fmt.Printf("  %s\n", i18n.T(result.Message))
 	}
 	fmt.Println()
 
@@ -153,81 +203,132 @@ func runSectionDesign(cmd *cobra.Command, args []string) {
 		printBarSuggestionsFor(result.AsRequired, "  ")
 	}
 
-	// Convert section vertices to diagram points
-	var diagramVertices []diagram.Point
-	for _, v := range sec.Vertices {
-		diagramVertices = append(diagramVertices, diagram.Point{X: v.X, Y: v.Y})
-	}
-
 	// Show diagram if requested
 	if sectionDesignShowDiagram && result.IsAdequate {
-		epsilonY := sec.Fy / nscp.Es
-
-		// Get tension steel position from original section
-		var tensionSteelY float64
-		for _, layer := range sec.Reinforcement {
-			if layer.Type == "tension" || layer.Y < result.Properties.Height/2 {
-				tensionSteelY = layer.Y
-				break
-			}
-		}
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            result.Properties.Width,
-			Height:           result.Properties.Height,
-			Vertices:         diagramVertices,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    tensionSteelY,
-			TensionSteelArea: result.AsRequired,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         0.005, // Tension-controlled by design
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * sec.Fc,
-			FsTension:        sec.Fy,
-			TensionYields:    true, // By design
-			IsDoubly:         false,
-		}
+		diagramData := sectionDesignDiagramData(sec, result)
 
 		fmt.Println(diagram.DrawASCIISectionDiagram(diagramData))
 		fmt.Println(diagram.DrawStrainDiagram(diagramData))
+		fmt.Println(diagram.DrawStressBlock(diagramData))
 	}
 
 	// Export diagram if requested
 	if sectionDesignExportFile != "" && result.IsAdequate {
-		epsilonY := sec.Fy / nscp.Es
-
-		var tensionSteelY float64
-		for _, layer := range sec.Reinforcement {
-			if layer.Type == "tension" || layer.Y < result.Properties.Height/2 {
-				tensionSteelY = layer.Y
-				break
-			}
-		}
-
-		diagramData := diagram.SectionDiagramData{
-			Width:            result.Properties.Width,
-			Height:           result.Properties.Height,
-			Vertices:         diagramVertices,
-			NeutralAxisDepth: result.C,
-			StressBlockDepth: result.A,
-			TensionSteelY:    tensionSteelY,
-			TensionSteelArea: result.AsRequired,
-			EpsilonCU:        nscp.EpsilonCU,
-			EpsilonT:         0.005,
-			EpsilonY:         epsilonY,
-			Fc:               0.85 * sec.Fc,
-			FsTension:        sec.Fy,
-			TensionYields:    true,
-			IsDoubly:         false,
-		}
+		diagramData := sectionDesignDiagramData(sec, result)
 
 		err := diagram.ExportSectionDiagram(diagramData, sectionDesignExportFile)
 		if err != nil {
 			fmt.Printf("Error exporting diagram: %v\n", err)
+			setExit(exitFailure)
 		} else {
 			fmt.Printf("Diagram exported to: %s\n", sectionDesignExportFile)
+			printPlotData(sectionDesignExportFile)
 		}
 	}
 }
 
+// sectionDesignDiagramData returns the section diagram of a designed
+// section, with the tension steel yielding at the tension-controlled strain
+func sectionDesignDiagramData(sec *section.Section, result *section.DesignResult) diagram.SectionDiagramData {
+	var vertices []diagram.Point
+	for _, v := range sec.Vertices {
+		vertices = append(vertices, diagram.Point{X: v.X, Y: v.Y})
+	}
+
+	// Get tension steel position from original section
+	var tensionSteelY float64
+	for _, layer := range sec.Reinforcement {
+		if layer.Type == "tension" || layer.Y < result.Properties.Height/2 {
+			tensionSteelY = layer.Y
+			break
+		}
+	}
+
+	return diagram.SectionDiagramData{
+		Width:            result.Properties.Width,
+		Height:           result.Properties.Height,
+		Vertices:         vertices,
+		NeutralAxisDepth: result.C,
+		StressBlockDepth: result.A,
+		TensionSteelY:    tensionSteelY,
+		TensionSteelArea: result.AsRequired,
+		EpsilonCU:        selectedCode.EpsilonCU(sec.Fc),
+		EpsilonT:         selectedCode.TensionControlledStrain(sec.Fc, sec.Fy), // Tension-controlled by design
+		EpsilonY:         selectedCode.DesignYieldStrength(sec.Fy) / nscp.Es,
+		Fc:               selectedCode.Alpha1(sec.Fc) * sec.Fc,
+		FsTension:        selectedCode.DesignYieldStrength(sec.Fy),
+		TensionYields:    true, // By design
+		IsDoubly:         false,
+		Units:            selectedUnits,
+	}
+}
+
+// sectionReportInputs adds the name, materials and geometry of a section to
+// a report, followed by the rows of other inputs
+func sectionReportInputs(doc *report.Document, sec *section.Section, props *section.SectionProperties, rows ...[]string) {
+	var fields [][]string
+	if sec.Name != "" {
+		fields = append(fields, []string{"Section", sec.Name})
+	}
+	if sec.Description != "" {
+		fields = append(fields, []string{"Description", sec.Description})
+	}
+	fields = append(fields,
+		[]string{"f'c", fmtStress(sec.Fc, 1)},
+		[]string{"fy", fmtStress(sec.Fy, 1)},
+		[]string{"Width (max)", fmtLength(props.Width, 0)},
+		[]string{"Height", fmtLength(props.Height, 0)},
+		[]string{"Gross area", fmtArea(props.Area, 0)},
+		[]string{"Effective depth (d)", fmtLength(props.EffectiveDepth, 0)},
+	)
+	doc.Section("Input Data")
+	doc.Fields(append(fields, rows...)...)
+}
+
+// sectionDesignReport returns the design report of a non-rectangular section
+func sectionDesignReport(cmd *cobra.Command, sec *section.Section, r *section.DesignResult) *report.Document {
+	doc := newReport(cmd, "Non-Rectangular Section Design")
+	doc.Conclude(r.IsAdequate, r.Message)
+	sectionReportInputs(doc, sec, r.Properties, []string{"Factored moment (Mu)", fmtMoment(sectionDesignMu, 2)})
+
+	doc.Section("Reinforcement Limits")
+	var flange []string
+	if r.FlangeInTension {
+		flange = []string{"Tension flange width (bf)", fmtLength(r.TensionWidth, 0)}
+	}
+	doc.Fields(
+		[]string{"Web width (bw)", fmtLength(r.WebWidth, 0)},
+		flange,
+		[]string{"Width for As,min", fmtLength(r.AsMinWidth, 0)},
+		[]string{"As,min", fmtArea(r.AsMin, 2)},
+	)
+	if r.AsAlternative > 0 {
+		doc.Paragraph(fmt.Sprintf("As by analysis is %s; 4/3·As = %s may be provided in lieu of As,min.",
+			fmtArea(r.AsStrength, 2), fmtArea(r.AsAlternative, 2)))
+	}
+
+	doc.Section("Code Checks")
+	if r.AsRequired > 0 {
+		reportChecks(doc,
+			reportCheck("Minimum steel (As ≥ As,min)", codes.ProvisionMinSteel, fmtArea(r.AsRequired, 2), fmtArea(r.AsMin, 2),
+				quietStatus(r.AsRequired >= r.AsMin || (r.AsAlternative > 0 && r.AsRequired >= r.AsAlternative))),
+			strengthCheck(r.PhiMn, sectionDesignMu))
+	}
+	doc.Paragraph(r.Message)
+
+	doc.Section("Design Result")
+	if !r.IsAdequate {
+		doc.Paragraph("**Design not adequate.**")
+		return doc
+	}
+	doc.Fields(
+		[]string{"Neutral axis depth (c)", fmtLength(r.C, 2)},
+		[]string{"Compression block depth (a)", fmtLength(r.A, 2)},
+		[]string{"Strength reduction factor (φ)", fmt.Sprintf("%.2f", r.Phi)},
+	)
+	doc.Paragraph(fmt.Sprintf("**Required tension steel As = %s**, φMn = %s ≥ Mu = %s.",
+		fmtArea(r.AsRequired, 2), fmtMoment(r.PhiMn, 2), fmtMoment(sectionDesignMu, 2)))
+	reportBars(doc, "Suggested Bar Combinations", r.AsRequired)
+	reportDiagram(doc, sectionDesignDiagramData(sec, r), sectionDesignExportFile, "")
+	return doc
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sectionInitShape  string
+	sectionInitOutput string
+	sectionInitForce  bool
+)
+
+var sectionInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a starter section file of a common shape",
+	Long: `Write a valid section JSON file of a common shape with typical dimensions
+and placeholder reinforcement, ready to edit and analyze. The description of
+the section and of each reinforcement layer explain what to replace.
+
+Shapes: ` + strings.Join(section.StarterShapes, ", ") + `
+
+The file is printed when no --output is given, so it can be piped into the
+other section commands.
+
+Examples:
+  gorcb section init --shape t-beam -o t1.json
+  gorcb section init --shape box | gorcb section analyze -f -`,
+	Run: runSectionInit,
+}
+
+func init() {
+	sectionCmd.AddCommand(sectionInitCmd)
+
+	sectionInitCmd.Flags().StringVar(&sectionInitShape, "shape", "rectangular", "Section shape ("+strings.Join(section.StarterShapes, ", ")+")")
+	sectionInitCmd.Flags().StringVarP(&sectionInitOutput, "output", "o", "", "Section file to write (default: print to stdout)")
+	sectionInitCmd.Flags().BoolVar(&sectionInitForce, "force", false, "Overwrite an existing section file")
+}
+
+// pointPattern matches a vertex of an indented section file, e.g.
+// {\n  "x": 0,\n  "y": 0\n}
+var pointPattern = regexp.MustCompile(`\{\s+"x": ([^,\s]+),\s+"y": ([^\s]+)\s+\}`)
+
+func runSectionInit(cmd *cobra.Command, args []string) {
+	sec, err := section.Starter(sectionInitShape)
+	if err != nil {
+		printError(err)
+		return
+	}
+	data, err := encodeSection(sec, formatJSON)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if sectionInitOutput == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if !writeSectionFile(sectionInitOutput, data, sectionInitForce) {
+		return
+	}
+	fmt.Printf("Edit it, then run: gorcb section analyze -f %s\n", sectionInitOutput)
+}
+
+// encodeSection returns a section file as indented JSON with one vertex per
+// line, as in the example section files, or as YAML
+func encodeSection(sec *section.Section, format string) ([]byte, error) {
+	if format == formatYAML {
+		return toYAML(sec)
+	}
+	data, err := json.MarshalIndent(sec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(pointPattern.ReplaceAll(data, []byte(`{"x": $1, "y": $2}`)), '\n'), nil
+}
+
+// writeSectionFile writes a section file, refusing to overwrite an existing
+// one unless force is set, and reports whether it was written
+func writeSectionFile(path string, data []byte, force bool) bool {
+	if _, err := os.Stat(path); err == nil && !force {
+		fmt.Printf("Error: %s already exists (use --force to overwrite)\n", path)
+		setExit(exitInvalidInput)
+		return false
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		fmt.Printf("Error writing section file: %v\n", err)
+		setExit(exitFailure)
+		return false
+	}
+	fmt.Printf("Section file written to: %s\n", path)
+	return true
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sensitivityBase    string
+	sensitivityMu      float64
+	sensitivityPercent float64
+	sensitivityInputs  []string
+)
+
+// tornadoWidth is the width of each side of the tornado bars (characters)
+const tornadoWidth = 20
+
+var sensitivityCmd = &cobra.Command{
+	Use:   "sensitivity",
+	Short: "Rank the inputs of a beam design by their effect on As or φMn",
+	Long: `Decrease and increase each input of a rectangular beam by a percentage and
+rank the inputs by the swing of the result, as a tornado chart: the required
+As of a design, or φMn of a beam given its provided As.
+
+The inputs are f'c, fy, the effective depth d (varied through the height at
+the same cover), the cover to the tension steel (at the same height) and Mu.
+The base beam is given as comma-separated name=value pairs of the fields of
+'gorcb batch run'; omitted covers default to 65 mm, f'c to 28 MPa and fy to
+415 MPa.
+
+Examples:
+  gorcb sensitivity --mu 250 --base width=300,height=500
+  gorcb sensitivity --base width=300,height=500,as=1500 --perturb 5
+  gorcb sensitivity --mu 250 --base width=300,height=500 --inputs fc,fy,d --format csv`,
+	Run: runSensitivity,
+}
+
+func init() {
+	rootCmd.AddCommand(sensitivityCmd)
+	tabular(sensitivityCmd)
+
+	sensitivityCmd.Flags().StringVar(&sensitivityBase, "base", "", "Base beam as name=value pairs, e.g. width=300,height=500 [required]")
+	sensitivityCmd.Flags().Float64VarP(&sensitivityMu, "mu", "m", 0, "Factored moment Mu (kN-m)")
+	sensitivityCmd.Flags().Float64Var(&sensitivityPercent, "perturb", 10, "Decrease and increase of each input (%)")
+	sensitivityCmd.Flags().StringSliceVar(&sensitivityInputs, "inputs", batch.SensitivityInputs, "Inputs to perturb ("+strings.Join(batch.SensitivityInputs, ", ")+")")
+
+	sensitivityCmd.MarkFlagRequired("base")
+}
+
+func runSensitivity(cmd *cobra.Command, args []string) {
+	m, err := batch.ParseMember(sensitivityBase)
+	if err != nil {
+		printError(fmt.Errorf("base: %w", err))
+		return
+	}
+	if cmd.Flags().Changed("mu") || m.Mu == 0 {
+		m.Mu = sensitivityMu
+	}
+	base, effects, err := m.Sensitivity(sensitivityInputs, sensitivityPercent/100, selectedCode)
+	if err != nil {
+		printError(err)
+		return
+	}
+	for _, s := range effects {
+		if s.Error != "" {
+			setExit(exitInvalidInput)
+		}
+	}
+	output := batch.SensitivityOutput(base)
+	unit := "mm²"
+	if output == "φMn" {
+		unit = "kN-m"
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, s := range effects {
+			rows = append(rows, []string{s.Input, cell(s.Base), cell(s.Low), cell(s.High),
+				cell(s.LowOutput), cell(s.HighOutput), cell(s.LowChange), cell(s.HighChange), cell(s.Swing), s.Error})
+		}
+		printTable([]string{"Input", "Base", "Low", "High", output + " Low (" + unit + ")", output + " High (" + unit + ")",
+			"Low Change (%)", "High Change (%)", "Swing (%)", "Error"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"base": m, "perturbation": sensitivityPercent, "output": output},
+			map[string]any{"base": base, "effects": effects})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     SENSITIVITY ANALYSIS - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("BASE BEAM:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Base:\t%s\n", sensitivityBase)
+	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", m.Mu)
+	fmt.Fprintf(w, "  Perturbation:\t±%g%%\n", sensitivityPercent)
+	if output == "φMn" {
+		fmt.Fprintf(w, "  Design Strength (φMn):\t%.2f kN-m\n", base.PhiMn)
+	} else {
+		fmt.Fprintf(w, "  Required Steel (As):\t%.2f mm²\n", base.As)
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Printf("EFFECT ON %s (largest first):\n", output)
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	maxSwing := 0.0
+	for _, s := range effects {
+		maxSwing = math.Max(maxSwing, math.Max(math.Abs(s.LowChange), math.Abs(s.HighChange)))
+	}
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Input\t−%g%%\t+%g%%\tSwing\t\n", sensitivityPercent, sensitivityPercent)
+	fmt.Fprintln(w, "  ─────\t────\t────\t─────\t")
+	for _, s := range effects {
+		if s.Error != "" {
+			fmt.Fprintf(w, "  %s\t-\t-\t-\tERROR: %s\n", s.Input, s.Error)
+			continue
+		}
+		fmt.Fprintf(w, "  %s\t%+.2f%%\t%+.2f%%\t%.2f%%\t%s\n", s.Input, s.LowChange, s.HighChange, s.Swing,
+			tornadoBar(s.LowChange, s.HighChange, maxSwing))
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Printf("  Changes of %s from its base value; bars extend left for decreases\n", output)
+	fmt.Println("  and right for increases of the result.")
+	fmt.Println()
+}
+
+// tornadoBar draws the changes of an input as a bar centered on the base
+// value, scaled so that the largest change fills one side
+func tornadoBar(low, high, scale float64) string {
+	var left, right float64
+	for _, c := range []float64{low, high} {
+		if c < 0 {
+			left = math.Max(left, -c)
+		} else {
+			right = math.Max(right, c)
+		}
+	}
+	cells := func(v float64) int {
+		if scale <= 0 {
+			return 0
+		}
+		return int(math.Round(v / scale * tornadoWidth))
+	}
+	l, r := cells(left), cells(right)
+	return strings.Repeat(" ", tornadoWidth-l) + strings.Repeat("█", l) + "│" + strings.Repeat("█", r)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/rpc"
+	"github.com/alexiusacademia/gorcb/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr string
+	serveGRPC string
+	serveSpec bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the beam, batch and section engines as a JSON HTTP API",
+	Long: `Serve the design and analysis engines as a JSON HTTP API for other tools,
+with the design code selected by --code. The API is described by an OpenAPI 3
+specification served at /openapi.yaml, from which clients can be generated.
+
+Endpoints:
+  POST /v1/beam              Design or analyze a rectangular beam (a batch member)
+  POST /v1/batch             Design or analyze the members of a batch file
+  POST /v1/section/analyze   Analyze the JSON of a section file
+  GET  /v1/version           Version of the server
+  GET  /openapi.yaml         The OpenAPI specification
+
+Requests must be application/json of the schemas of the specification;
+unknown fields are rejected. Failed requests return {"error": {"code": ...,
+"message": ..., "field": ...}} with the code invalid_json, invalid_input,
+no_convergence, not_found, unsupported_media_type or too_large.
+
+With --grpc the same engines are also served over gRPC on another address,
+as the BeamService (Design, Analyze, Batch) and SectionService (Analyze) of
+api/gorcb/v1/gorcb.proto, from which typed clients can be generated for Go,
+C# or Python. Invalid inputs fail with InvalidArgument, and solvers that do
+not converge with FailedPrecondition.
+
+Examples:
+  gorcb serve
+  gorcb serve --addr :9000 --code aci318-19
+  gorcb serve --grpc localhost:9090
+  gorcb serve --spec > openapi.yaml
+  curl -H 'Content-Type: application/json' -d '{"width":300,"height":500,"mu":180}' localhost:8080/v1/beam`,
+	Run: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	unrecorded(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on, e.g. :8080 for all interfaces")
+	serveCmd.Flags().StringVar(&serveGRPC, "grpc", "", "Address to also serve the gRPC services on, e.g. :9090")
+	serveCmd.Flags().BoolVar(&serveSpec, "spec", false, "Print the OpenAPI specification and exit")
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	if serveSpec {
+		os.Stdout.Write(server.Spec)
+		return
+	}
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		printError(err)
+		return
+	}
+	fmt.Printf("Serving the gorcb API (%s) on http://%s (Ctrl+C to stop)\n", selectedCode.Name(), listener.Addr())
+	fmt.Printf("OpenAPI specification: http://%s/openapi.yaml\n", listener.Addr())
+	if serveGRPC != "" {
+		grpcListener, err := net.Listen("tcp", serveGRPC)
+		if err != nil {
+			listener.Close()
+			printError(err)
+			return
+		}
+		grpcServer := rpc.NewServer(selectedCode)
+		go grpcServer.Serve(grpcListener)
+		defer grpcServer.GracefulStop()
+		fmt.Printf("gRPC services (api/gorcb/v1/gorcb.proto) on %s\n", grpcListener.Addr())
+	}
+	serveUntilInterrupt(listener, server.New(selectedCode).Handler())
+}
+
+// serveUntilInterrupt serves HTTP on a listener until Ctrl+C, then lets the
+// requests in progress finish
+func serveUntilInterrupt(listener net.Listener, handler http.Handler) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(listener) }()
+
+	select {
+	case err := <-done:
+		fmt.Printf("Error: %v\n", err)
+		setExit(exitFailure)
+	case <-ctx.Done():
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Error: %v\n", err)
+			setExit(exitFailure)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/aci"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+// serviceInputs holds the unfactored moments and options of the serviceability checks
+type serviceInputs struct {
+	Dead          float64
+	Live          float64
+	Roof          float64
+	Wind          float64
+	Rain          float64
+	Fluid         float64
+	Earth         float64
+	SustainedLive float64
+	ClearCover    float64
+
+	// Long-term deflection
+	LoadDuration     float64
+	CreepModel       string
+	LoadingAge       float64
+	RelativeHumidity float64
+	VolumeSurface    float64
+}
+
+// addServiceFlags registers the service moment flags on a beam analysis command
+func addServiceFlags(cmd *cobra.Command, in *serviceInputs) {
+	cmd.Flags().Float64Var(&in.Dead, "service-dead", 0, "Unfactored dead load moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Live, "service-live", 0, "Unfactored live load moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Roof, "service-roof", 0, "Unfactored roof live load moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Wind, "service-wind", 0, "Unfactored wind load moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Rain, "service-rain", 0, "Unfactored rain load moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Fluid, "service-fluid", 0, "Unfactored fluid pressure moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.Earth, "service-earth", 0, "Unfactored lateral earth pressure moment for service checks (kN-m)")
+	cmd.Flags().Float64Var(&in.SustainedLive, "sustained-live", 0, "Sustained fraction ψ of the live load (0 to 1)")
+	cmd.Flags().Float64Var(&in.ClearCover, "clear-cover", beam.DefaultClearCover, "Clear cover to tension bars for crack control (mm)")
+	cmd.Flags().Float64Var(&in.LoadDuration, "load-duration", beam.DefaultLoadDuration, "Sustained load duration for long-term deflection (months)")
+	cmd.Flags().StringVar(&in.CreepModel, "creep-model", "nscp", "Long-term deflection model: nscp (time-dependent factor ξ) or aci209 (ACI 209R-92 creep)")
+	cmd.Flags().Float64Var(&in.LoadingAge, "loading-age", 7, "Age of concrete at loading for the aci209 creep model (days)")
+	cmd.Flags().Float64Var(&in.RelativeHumidity, "humidity", 70, "Ambient relative humidity for the aci209 creep model (%)")
+	cmd.Flags().Float64Var(&in.VolumeSurface, "volume-surface", 38, "Volume-to-surface ratio for the aci209 creep model (mm)")
+}
+
+// requested reports whether any service moment was given
+func (in serviceInputs) requested() bool {
+	return in.Dead != 0 || in.Live != 0 || in.Roof != 0 || in.Wind != 0 || in.Rain != 0 ||
+		in.Fluid != 0 || in.Earth != 0
+}
+
+// serviceMoments evaluates the service combinations for the given moments
+func (in serviceInputs) serviceMoments() (nscp.ServiceMoments, error) {
+	if err := nscp.ValidateSustainedLive(in.SustainedLive); err != nil {
+		return nscp.ServiceMoments{}, err
+	}
+	moments := nscp.LoadMoments{
+		Dead:  in.Dead,
+		Live:  in.Live,
+		Roof:  in.Roof,
+		Wind:  in.Wind,
+		Rain:  in.Rain,
+		Fluid: in.Fluid,
+		Earth: in.Earth,
+	}
+	return nscp.CalculateServiceMoments(moments, nscp.ServiceCombinations, in.SustainedLive), nil
+}
+
+// options returns the serviceability check options
+func (in serviceInputs) options() (beam.ServiceOptions, error) {
+	opts := beam.ServiceOptions{
+		ClearCover:   in.ClearCover,
+		LoadDuration: in.LoadDuration,
+	}
+	switch strings.ToLower(in.CreepModel) {
+	case "nscp":
+	case "aci209":
+		opts.Creep = &aci.CreepShrinkageConditions{
+			LoadingAge:       in.LoadingAge,
+			CuringAge:        7,
+			RelativeHumidity: in.RelativeHumidity,
+			VolumeSurface:    in.VolumeSurface,
+		}
+	default:
+		return opts, fmt.Errorf("unknown creep model %q (use nscp or aci209)", in.CreepModel)
+	}
+	return opts, nil
+}
+
+// crackControl marks on a section diagram the maximum spacing of the bars
+// nearest the tension face from the crack control check, when the service
+// checks were run
+func crackControl(data diagram.SectionDiagramData, service *beam.ServiceResult) diagram.SectionDiagramData {
+	if service != nil {
+		data.MaxBarSpacing = service.MaxSpacing
+	}
+	return data
+}
+
+// printServiceCheck prints the service moments and serviceability checks of a beam
+func printServiceCheck(sm nscp.ServiceMoments, r *beam.ServiceResult) {
+	fmt.Println("SERVICE MOMENTS:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Total (Ma):\t%s\t%s (%s)\n", fmtMoment(sm.Total, 2), sm.TotalCombo.ID, sm.TotalCombo.Description)
+	fmt.Fprintf(w, "  Sustained (Msus):\t%s\tD + %.2gL\n", fmtMoment(sm.Sustained, 2), sm.SustainedLL)
+	fmt.Fprintf(w, "  Transient (Ma - MD):\t%s\t\n", fmtMoment(sm.Transient, 2))
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("CRACKED SECTION (NSCP 2015 Section 424.2.3):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Ec:\t%s\n", fmtStress(r.Ec, 0))
+	fmt.Fprintf(w, "  Modular ratio (n):\t%.2f\n", r.N)
+	fmt.Fprintf(w, "  Cracked neutral axis (kd):\t%s\n", fmtLength(r.Kd, 2))
+	fmt.Fprintf(w, "  Ig:\t%s\n", fmtInertia(r.Ig))
+	fmt.Fprintf(w, "  Icr:\t%s\n", fmtInertia(r.Icr))
+	fmt.Fprintf(w, "  Mcr:\t%s\n", fmtMoment(r.Mcr, 2))
+	fmt.Fprintf(w, "  Ie at Ma:\t%s\n", fmtInertia(r.Ie))
+	fmt.Fprintf(w, "  Ie at Msus:\t%s\n", fmtInertia(r.IeSustained))
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("LONG-TERM DEFLECTION (NSCP 2015 Section 424.2.4):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Model:\t%s\n", r.CreepModel)
+	fmt.Fprintf(w, "  Load duration:\t%.0f months\n", r.LoadDuration)
+	if r.CreepModel == "ACI 209R-92" {
+		fmt.Fprintf(w, "  Creep coefficient (νt):\t%.2f\n", r.Xi)
+	} else {
+		fmt.Fprintf(w, "  Time-dependent factor (ξ):\t%.2f\n", r.Xi)
+	}
+	fmt.Fprintf(w, "  Long-term multiplier (λΔ):\t%.2f\n", r.LambdaDelta)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("SERVICE STRESSES:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Concrete (fc):\t%s\t≤ 0.45f'c = %s\t%s\n", fmtStress(r.FcService, 2), fmtStress(r.FcAllow, 2), checkMark(r.MeetsFcService))
+	fmt.Fprintf(w, "  Steel (fs):\t%s\t≤ 0.60fy = %s\t%s\n", fmtStress(r.FsService, 2), fmtStress(r.FsAllow, 2), checkMark(r.MeetsFsService))
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("CRACK CONTROL (NSCP 2015 Section 424.3.2):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	if !r.IsCracked {
+		fmt.Fprintf(w, "  Section:\tuncracked (Ma ≤ Mcr)\n")
+	}
+	fmt.Fprintf(w, "  Clear cover (cc):\t%s\n", fmtLength(r.ClearCover, 0))
+	fmt.Fprintf(w, "  Max bar spacing (s):\t%s\n", fmtSpacing(r.MaxSpacing, 0))
+	w.Flush()
+	fmt.Println()
+}
+
+// checkMark returns the status mark used in check tables
+func checkMark(ok bool) string {
+	if ok {
+		return "✓"
+	}
+	return "⚠"
+}
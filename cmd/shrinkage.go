@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	shrinkageThickness float64
+	shrinkageFc        float64
+	shrinkageFy        float64
+	shrinkageGrade     gradeInput
+	shrinkageMember    string
+	shrinkageMaxBar    float64
+)
+
+// shrinkageSpacingStep is the increment practical bar spacings are rounded down to (mm)
+const shrinkageSpacingStep = 25.0
+
+var shrinkageCmd = &cobra.Command{
+	Use:     "shrinkage",
+	Aliases: []string{"temperature"},
+	Short:   "Calculate shrinkage and temperature reinforcement for slabs and walls",
+	Long: `Calculate the minimum shrinkage and temperature reinforcement of a slab or
+wall-like member per meter width, with its maximum spacing and the spacing of
+each bar size of the selected rebar catalog.
+
+The steel ratio is taken on the gross concrete area (NSCP 2015 Table 424.4.3.2:
+0.0020 for fy < 420 MPa, 0.0018·420/fy ≥ 0.0014 otherwise) and the spacing is
+limited to the lesser of 5h and 450 mm. Walls thicker than 250 mm are
+reinforced in two layers, one near each face (NSCP 2015 Section 411.7.2.3).
+
+Examples:
+  # 150 mm slab with Grade 40 bars
+  gorcb shrinkage --thickness 150 --fy 275
+
+  # 300 mm wall, reinforcement split between both faces
+  gorcb shrinkage --thickness 300 --member wall`,
+	Run: runShrinkage,
+}
+
+func init() {
+	rootCmd.AddCommand(shrinkageCmd)
+	tabular(shrinkageCmd)
+
+	shrinkageCmd.Flags().Float64VarP(&shrinkageThickness, "thickness", "t", 0, "Slab or wall thickness h (mm)")
+	shrinkageCmd.Flags().Float64Var(&shrinkageFc, "fc", 28, "Concrete compressive strength f'c (MPa)")
+	shrinkageCmd.Flags().Float64Var(&shrinkageFy, "fy", 415, "Steel yield strength fy (MPa)")
+	addGradeFlag(shrinkageCmd, &shrinkageGrade)
+	shrinkageCmd.Flags().StringVar(&shrinkageMember, "member", string(nscp.MemberSlab), "Member type: slab or wall")
+	shrinkageCmd.Flags().Float64Var(&shrinkageMaxBar, "max-bar", 16, "Largest bar diameter to list (mm)")
+
+	shrinkageCmd.MarkFlagRequired("thickness")
+}
+
+// shrinkageBar is the spacing of one bar size for the shrinkage steel
+type shrinkageBar struct {
+	Bar             string  `json:"bar"`
+	Area            float64 `json:"area"`             // mm²
+	SpacingRequired float64 `json:"spacing_required"` // mm
+	Spacing         float64 `json:"spacing"`          // Practical spacing (mm)
+	AsProvided      float64 `json:"as_provided"`      // mm²/m
+}
+
+// shrinkageReport is the JSON result of the shrinkage command
+type shrinkageReport struct {
+	Rho        float64        `json:"rho"`
+	AsTotal    float64        `json:"as_total"` // mm²/m
+	Layers     int            `json:"layers"`
+	AsPerLayer float64        `json:"as_per_layer"` // mm²/m
+	MaxSpacing float64        `json:"max_spacing"`  // mm
+	Bars       []shrinkageBar `json:"bars"`
+}
+
+func runShrinkage(cmd *cobra.Command, args []string) {
+	if err := shrinkageGrade.resolve(&shrinkageFy); err != nil {
+		printError(err)
+		return
+	}
+	member, err := nscp.ParseMemberType(shrinkageMember)
+	if err != nil {
+		printError(err)
+		return
+	}
+	if member != nscp.MemberSlab && member != nscp.MemberWall {
+		fmt.Printf("Error: shrinkage and temperature steel applies to slabs and walls, got %q\n", member)
+		setExit(exitInvalidInput)
+		return
+	}
+	if shrinkageThickness <= 0 {
+		fmt.Printf("Error: thickness must be positive, got %.2f\n", shrinkageThickness)
+		setExit(exitInvalidInput)
+		return
+	}
+	if shrinkageFc <= 0 || shrinkageFy <= 0 {
+		fmt.Println("Error: f'c and fy must be positive")
+		setExit(exitInvalidInput)
+		return
+	}
+
+	h := shrinkageThickness
+	rho := selectedCode.ShrinkageTemperatureRatio(shrinkageFc, shrinkageFy)
+	sMax := selectedCode.ShrinkageTemperatureSpacing(h)
+	asTotal := rho * 1000 * h // mm² per meter width
+
+	layers := 1
+	if member == nscp.MemberWall && h > 250 {
+		layers = 2
+	}
+	asLayer := asTotal / float64(layers)
+
+	// Practical spacing of each bar size up to the largest listed
+	var bars []shrinkageBar
+	for _, bar := range selectedCatalog.Bars {
+		if bar.Diameter > shrinkageMaxBar {
+			continue
+		}
+		sReq := bar.Area * 1000 / asLayer
+		sUse := math.Floor(math.Min(sReq, sMax)/shrinkageSpacingStep) * shrinkageSpacingStep
+		if sUse <= 0 {
+			continue
+		}
+		bars = append(bars, shrinkageBar{Bar: bar.Label(), Area: bar.Area, SpacingRequired: sReq, Spacing: sUse, AsProvided: bar.Area * 1000 / sUse})
+	}
+
+	if tabularOutput() {
+		var rows [][]string
+		for _, bar := range bars {
+			rows = append(rows, []string{bar.Bar, cell(bar.Area), cell(bar.SpacingRequired), cell(bar.Spacing), cell(bar.AsProvided)})
+		}
+		printTable([]string{"Bar", "Area (mm²)", "s,req (mm)", "s,use (mm)", "As,prov (mm²/m)"}, rows)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"member": member, "thickness": h, "fc": shrinkageFc, "fy": shrinkageFy}, shrinkageReport{
+			Rho:        rho,
+			AsTotal:    asTotal,
+			Layers:     layers,
+			AsPerLayer: asLayer,
+			MaxSpacing: sMax,
+			Bars:       bars,
+		})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     SHRINKAGE & TEMPERATURE REINFORCEMENT - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("INPUT DATA:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Member:\t%s\n", member)
+	fmt.Fprintf(w, "  Thickness (h):\t%.0f mm\n", h)
+	fmt.Fprintf(w, "  f'c:\t%.1f MPa\n", shrinkageFc)
+	fmt.Fprintf(w, "  fy:\t%.1f MPa\n", shrinkageFy)
+	printGrade(w, shrinkageGrade)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("REQUIRED REINFORCEMENT (per meter width):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintf(w, "  Minimum steel ratio (ρ):\t%.4f\n", rho)
+	fmt.Fprintf(w, "  As,min = ρ·b·h:\t%.2f mm²/m\n", asTotal)
+	if layers > 1 {
+		fmt.Fprintf(w, "  Layers:\t%d (one near each face)\n", layers)
+		fmt.Fprintf(w, "  As per layer:\t%.2f mm²/m\n", asLayer)
+	}
+	fmt.Fprintf(w, "  Maximum spacing:\t%.0f mm\n", sMax)
+	w.Flush()
+	fmt.Println()
+
+	fmt.Println("BAR SPACING (per layer):")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprintln(w, "  Bar\tArea (mm²)\ts,req (mm)\ts,use (mm)\tAs,prov (mm²/m)")
+	fmt.Fprintln(w, "  ───\t──────────\t──────────\t──────────\t───────────────")
+	for _, bar := range bars {
+		fmt.Fprintf(w, "  %s\t%.2f\t%.0f\t%.0f\t%.2f\n", bar.Bar, bar.Area, bar.SpacingRequired, bar.Spacing, bar.AsProvided)
+	}
+	w.Flush()
+	if len(bars) == 0 {
+		fmt.Printf("  No bars up to %.0f mm in catalog %q fit the required spacing.\n", shrinkageMaxBar, selectedCatalog.Name)
+	}
+	fmt.Println()
+}
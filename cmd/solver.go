@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/solver"
+)
+
+// Neutral axis solver settings selected with --max-iter and --tolerance,
+// the defaults of each solver when zero
+var (
+	solverMaxIter   int
+	solverTolerance float64
+)
+
+// applySolver validates --max-iter and --tolerance and passes them to the
+// beam and section solvers
+func applySolver() error {
+	if solverMaxIter < 0 {
+		return fmt.Errorf("--max-iter must be positive, got %d", solverMaxIter)
+	}
+	if solverTolerance < 0 {
+		return fmt.Errorf("--tolerance must be positive, got %g", solverTolerance)
+	}
+	solver.Default = solver.Settings{MaxIterations: solverMaxIter, Tolerance: solverTolerance}
+	return nil
+}
+
+// residualLabel prints the force imbalance left by a neutral axis solver
+// with the iterations it took, e.g. "0.0213 kN in 7 iterations"
+func residualLabel(residual float64, iterations int) string {
+	return fmt.Sprintf("%s in %d iterations", fmtForce(residual, 4), iterations)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// spliceInputs holds the lap splice options of the design commands
+type spliceInputs struct {
+	Show            bool
+	FractionSpliced float64
+
+	// Lambda is the λ of the concrete, set by the command from its
+	// --concrete-type; zero is normalweight concrete
+	Lambda float64
+}
+
+// addSpliceFlags registers the lap splice flags on a design command
+func addSpliceFlags(cmd *cobra.Command, in *spliceInputs) {
+	cmd.Flags().BoolVar(&in.Show, "splices", false, "Show lap splice classes and lengths for the suggested bars")
+	cmd.Flags().Float64Var(&in.FractionSpliced, "spliced-fraction", 1.0, "Fraction of bars lap spliced at the same location (0 to 1)")
+}
+
+// validate checks the lap splice options
+func (in spliceInputs) validate() error {
+	if in.FractionSpliced <= 0 || in.FractionSpliced > 1 {
+		return fmt.Errorf("spliced fraction must be between 0 and 1, got %g", in.FractionSpliced)
+	}
+	return nil
+}
+
+// printLapSplicesFor prints the tension and compression lap splices of the bars
+// suggested for asRequired in the concrete of the options. topBar applies the
+// top bar factor to the tension lap.
+func printLapSplicesFor(in spliceInputs, asRequired, fc, fy float64, topBar bool, indent string) {
+	lambda := in.Lambda
+	if lambda == 0 {
+		lambda = 1.0
+	}
+	w := newTextWriter()
+	fmt.Fprintf(w, "%sBars\tld\tSplice\tTension Lap\tCompression Lap\n", indent)
+	fmt.Fprintf(w, "%s────\t──\t──────\t───────────\t───────────────\n", indent)
+
+	for _, s := range selectedCatalog.Suggest(asRequired, suggestMinBars, suggestMaxBars) {
+		db := s.Bar.Diameter
+		ld := selectedCode.DevelopmentLength(db, fc, fy, lambda, topBar)
+		lap, class := selectedCode.TensionLapLength(db, fc, fy, lambda, topBar, s.Area/asRequired, in.FractionSpliced)
+		lsc := selectedCode.CompressionLapLength(db, fc, fy)
+		fmt.Fprintf(w, "%s%d - %s\t%s\t%s\t%s\t%s\n", indent, s.Count, s.Bar.Label(), fmtLength(ld, 0), class, fmtLength(lap, 0), fmtLength(lsc, 0))
+	}
+	w.Flush()
+}
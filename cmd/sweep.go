@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sweepBase   string
+	sweepMu     float64
+	sweepVu     float64
+	sweepVaried []string
+
+	sweepConcreteType string
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Tabulate a beam design over a range of one or two parameters",
+	Long: `Design a rectangular beam for the same factored moment while varying one
+or two of its parameters, and tabulate the required As, φMn and utilization
+of every combination - a sizing study of depth, width or material strength.
+
+The base beam is given as comma-separated name=value pairs of the fields of
+'gorcb batch run'. Each --vary takes a numeric field with either a range
+start:stop:step or a list of values. Omitted covers default to 65 mm, f'c to
+28 MPa and fy to 415 MPa.
+
+Utilization is Mu/φMn; ρ/ρmax compares the steel ratio with the maximum for
+a tension-controlled singly reinforced section (above 1, compression steel
+is needed).
+
+Examples:
+  # Depth study of a 300 mm wide beam
+  gorcb sweep --mu 250 --base width=300 --vary height=400:700:50
+
+  # Depth against concrete strength
+  gorcb sweep --mu 250 --base width=300 --vary height=400:700:50 --vary fc=21,28,35
+
+  # Table for a spreadsheet
+  gorcb sweep --mu 250 --base width=300 --vary height=400:700:50 --format csv`,
+	Run: runSweep,
+}
+
+func init() {
+	rootCmd.AddCommand(sweepCmd)
+	tabular(sweepCmd)
+
+	sweepCmd.Flags().StringVar(&sweepBase, "base", "", "Base beam as name=value pairs, e.g. width=300,height=500")
+	sweepCmd.Flags().Float64VarP(&sweepMu, "mu", "m", 0, "Factored moment Mu (kN-m) [required]")
+	sweepCmd.Flags().Float64Var(&sweepVu, "vu", 0, "Factored shear Vu for stirrup design (kN)")
+	sweepCmd.Flags().StringVar(&sweepConcreteType, "concrete-type", nscp.ConcreteNormal, concreteTypeUsage)
+	sweepCmd.Flags().StringArrayVar(&sweepVaried, "vary", nil, "Parameter to vary as name=start:stop:step or name=v1,v2,... (once or twice) [required]")
+
+	sweepCmd.MarkFlagRequired("mu")
+	sweepCmd.MarkFlagRequired("vary")
+}
+
+// sweepRow is one combination of the swept parameters
+type sweepRow struct {
+	Values []float64    `json:"values"` // Values of the swept parameters, in --vary order
+	Result batch.Result `json:"result"`
+}
+
+func runSweep(cmd *cobra.Command, args []string) {
+	if len(sweepVaried) > 2 {
+		fmt.Println("Error: vary at most two parameters")
+		setExit(exitInvalidInput)
+		return
+	}
+	base, err := batch.ParseMember(sweepBase)
+	if err != nil {
+		printError(fmt.Errorf("base: %w", err))
+		return
+	}
+	base.Mu, base.Vu = sweepMu, sweepVu
+	if base.Lambda == 0 {
+		if base.Lambda, err = concreteLambda(sweepConcreteType); err != nil {
+			printError(err)
+			return
+		}
+	}
+
+	var params []batch.Parameter
+	for _, spec := range sweepVaried {
+		p, err := batch.ParseParameter(spec)
+		if err != nil {
+			printError(err)
+			return
+		}
+		params = append(params, p)
+	}
+	if len(params) == 2 && params[0].Name == params[1].Name {
+		fmt.Printf("Error: %s is varied twice\n", params[0].Name)
+		setExit(exitInvalidInput)
+		return
+	}
+
+	// Every combination, the first parameter varying slowest
+	combos := [][]float64{nil}
+	for _, p := range params {
+		var next [][]float64
+		for _, c := range combos {
+			for _, v := range p.Values {
+				next = append(next, append(append([]float64{}, c...), v))
+			}
+		}
+		combos = next
+	}
+
+	var rows []sweepRow
+	for _, values := range combos {
+		m := base
+		for i, p := range params {
+			m = m.With(p.Name, values[i])
+		}
+		r, err := batch.RunMember(m, selectedCode)
+		if err != nil {
+			r = batch.Failed(m, err)
+		}
+		rows = append(rows, sweepRow{Values: values, Result: *r})
+	}
+
+	if tabularOutput() {
+		var header []string
+		for _, p := range params {
+			header = append(header, p.Name)
+		}
+		header = append(header, "As (mm²)", "As' (mm²)", "φMn (kN-m)", "Mu/φMn", "ρ/ρmax", "Stirrups (mm)", "Status")
+		var cells [][]string
+		for _, row := range rows {
+			var line []string
+			for _, v := range row.Values {
+				line = append(line, cell(v))
+			}
+			r := row.Result
+			spacing := ""
+			if r.Shear != nil {
+				spacing = cell(r.Shear.Spacing)
+			}
+			line = append(line, cell(r.As), cell(r.Asc), cell(r.PhiMn), cell(r.Utilization()), cell(sweepSteelRatio(r)), spacing, r.Status())
+			cells = append(cells, line)
+		}
+		printTable(header, cells)
+		return
+	}
+
+	if structuredOutput() {
+		printReport(cmd, map[string]any{"base": base, "parameters": params}, rows)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("     PARAMETRIC SWEEP - %s\n", selectedCode.Name())
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Println("BASE BEAM:")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w := newTextWriter()
+	fmt.Fprintf(w, "  Base:\t%s\n", sweepBase)
+	fmt.Fprintf(w, "  Factored Moment (Mu):\t%.2f kN-m\n", sweepMu)
+	if sweepVu > 0 {
+		fmt.Fprintf(w, "  Factored Shear (Vu):\t%.2f kN\n", sweepVu)
+	}
+	for _, p := range params {
+		fmt.Fprintf(w, "  Varied:\t%s (%d values)\n", p.Name, len(p.Values))
+	}
+	w.Flush()
+	fmt.Println()
+
+	fmt.Printf("RESULTS (%d combinations):\n", len(rows))
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	w = newTextWriter()
+	fmt.Fprint(w, " ")
+	for _, p := range params {
+		fmt.Fprintf(w, " %s\t", p.Name)
+	}
+	fmt.Fprintln(w, "As (mm²)\tAs' (mm²)\tφMn (kN-m)\tMu/φMn\tρ/ρmax\tStirrups\tStatus")
+	fmt.Fprint(w, " ")
+	for _, p := range params {
+		fmt.Fprintf(w, " %s\t", strings.Repeat("─", len(p.Name)))
+	}
+	fmt.Fprintln(w, "────────\t─────────\t──────────\t──────\t──────\t────────\t──────")
+	for _, row := range rows {
+		fmt.Fprint(w, " ")
+		for _, v := range row.Values {
+			fmt.Fprintf(w, " %g\t", v)
+		}
+		r := row.Result
+		if r.Error != "" {
+			fmt.Fprintf(w, "-\t-\t-\t-\t-\t-\t%s: %s\n", r.Status(), r.Error)
+			continue
+		}
+		as, asc := "-", "-"
+		if r.As > 0 {
+			as = fmt.Sprintf("%.2f", r.As)
+		}
+		if r.Member.IsDoubly() {
+			asc = fmt.Sprintf("%.2f", r.Asc)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.3f\t%.3f\t%s\t%s\n",
+			as, asc, r.PhiMn, r.Utilization(), sweepSteelRatio(r), batchStirrups(r), r.Status())
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// sweepSteelRatio returns ρ/ρmax of a result
+func sweepSteelRatio(r batch.Result) float64 {
+	if r.RhoMax <= 0 {
+		return 0
+	}
+	return r.Rho / r.RhoMax
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+)
+
+// asciiPlain draws the text diagrams in 7-bit ASCII, set with --ascii-plain
+var asciiPlain bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&asciiPlain, "ascii-plain", false,
+		"Draw text diagrams in 7-bit ASCII only, without shading, bullets or box drawing, e.g. for logs and legacy terminals")
+}
+
+// applyText sizes the text diagrams to the terminal and passes --ascii-plain
+// to them
+func applyText() {
+	columns, lines := terminalSize(os.Stdout)
+	if columns <= 0 {
+		columns, lines = envSize("COLUMNS"), envSize("LINES")
+	}
+	diagram.Text = diagram.TextOptions{Columns: columns, Lines: lines, Plain: asciiPlain}
+}
+
+// envSize returns a terminal size set in an environment variable, zero when
+// unset
+func envSize(name string) int {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
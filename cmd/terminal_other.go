@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package cmd
+
+import "os"
+
+// terminalSize returns zero where the size of the terminal is not read, for
+// the size in COLUMNS and LINES or the default sizes of the diagrams
+func terminalSize(f *os.File) (columns, lines int) {
+	return 0, 0
+}
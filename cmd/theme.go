@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+)
+
+var (
+	// plotTheme is the color theme of the exported diagrams, set with --theme
+	plotTheme string
+
+	// Title block stamped on the exported diagrams
+	stampProject  string
+	stampMember   string
+	stampEngineer string
+	stampDate     string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&plotTheme, "theme", diagram.ThemeDefault,
+		fmt.Sprintf("Color theme of exported diagrams: %s", strings.Join(diagram.ThemeNames(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&stampProject, "project-name", "", "Project name for the title block of exported diagrams")
+	rootCmd.PersistentFlags().StringVar(&stampMember, "member-id", "", "Member ID for the title block of exported diagrams, e.g. B-12")
+	rootCmd.PersistentFlags().StringVar(&stampEngineer, "engineer", "", "Engineer for the title block of exported diagrams")
+	rootCmd.PersistentFlags().StringVar(&stampDate, "date", "", "Date for the title block of exported diagrams (default today)")
+}
+
+// applyTheme selects the theme of the diagrams and fills their title block,
+// stamped with the edition of the selected code
+func applyTheme() error {
+	if err := diagram.SetTheme(plotTheme); err != nil {
+		return err
+	}
+	date := stampDate
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	diagram.Stamp = diagram.TitleBlock{
+		Project:  stampProject,
+		Member:   stampMember,
+		Engineer: stampEngineer,
+		Date:     date,
+		Code:     selectedCode.Name(),
+	}
+	return nil
+}
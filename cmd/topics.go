@@ -0,0 +1,121 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// Help topics of the features shared by the commands, listed under
+// "Additional help topics" and shown with 'gorcb help <topic>'
+
+var configTopic = &cobra.Command{
+	Use:   "config",
+	Short: "Flag defaults from configuration files and the environment",
+	Long: `Defaults for any flag (e.g. fc, fy, cover, code, bars, format) can be kept in
+~/.gorcb.yaml and overridden per project in ./gorcb.yaml, or read from the file
+given with --config. Flags given on the command line take precedence, e.g.
+
+  code: aci318-19
+  fc: 28
+  grade: 60
+  cover: 65
+  format: text
+  units: {length: mm, stress: MPa}   # assumed for section files without units
+
+A plain units value (units: us) sets the --units default instead, and the
+units of the values in that file; without it they are in SI units whatever
+--units is given on the command line.
+
+Defaults can also be set in GORCB_* environment variables named after the
+flags, e.g. GORCB_FC=28, GORCB_FY=415, GORCB_CODE=aci318-19 or
+GORCB_COVER_COMP=50, to configure containers and batch jobs without editing
+files; GORCB_CONFIG names the configuration file in place of --config. Their
+values are in SI units unless GORCB_UNITS=us is also set. The precedence is:
+flags on the command line, then the environment, then ./gorcb.yaml, then
+~/.gorcb.yaml.
+
+~/.gorcb.yaml and the file of --config also register the plugins run after
+an analysis (see 'gorcb check --help'); those of ./gorcb.yaml are not run.`,
+}
+
+var outputTopic = &cobra.Command{
+	Use:   "output",
+	Short: "Output formats, rounding, language and logging",
+	Long: `Use --format json or --format yaml to write the inputs and results as
+structured data for scripting or for committing to project repositories.
+Commands with tabular results (load combinations, steel layers, bar spacing)
+also accept --format csv or --format tsv for pasting into spreadsheets.
+
+Use --plain to print text output in plain ASCII for awk, grep and logging
+systems that do not handle Unicode: box-drawing lines become - and =, marks
+become OK, NG and !, and symbols are spelled out (phi, rho, eps, mm2, >=)
+with the columns still aligned to fixed widths. Combined with --format tsv
+the tables are written as tab-separated ASCII rows.
+
+Use --precision 1 to print every value with one decimal, and --round to
+follow office rounding conventions, e.g. --round area=10,spacing=5 prints
+steel areas rounded up to 10 mm² and bar and stirrup spacings rounded down to
+5 mm, in text output and reports alike. Steps are in the printed units; the
+quantities are length, spacing, area, stress, force and moment, areas round
+up and spacings down unless :up, :down or :nearest is given, the others to the
+nearest step. --precision also fixes the decimals of csv and tsv cells so
+that results diff cleanly; json and yaml are written unrounded.
+
+Use --lang fil (Filipino) or --lang es (Spanish) to write the report headings,
+labels, design messages, warnings and the titles, axes and legends of exported
+diagrams in that language for submission to local building officials; values,
+symbols and code clauses are unchanged.
+
+Use --log-level debug to log the inputs of a run, every neutral axis iteration
+of the solvers and the warnings, e.g. when a section does not converge, or
+--log-file audit.jsonl to keep a JSON record of the members of batch and
+project runs.`,
+}
+
+var reportsTopic = &cobra.Command{
+	Use:   "reports",
+	Short: "Design reports in Markdown, HTML and PDF",
+	Long: `Use --report out.md with the beam, section, batch and project commands to also
+write a Markdown design report of the inputs, code checks and results, with
+the section diagrams exported as images next to the report and linked in it.
+With --report out.html the report is a single HTML file with a pass/fail
+summary, the diagrams embedded as SVG and collapsible calculation steps.
+With --report calc.pdf it is an A4 calculation sheet with the diagrams and a
+signature block for submission.
+
+Markdown and HTML reports are laid out by Go templates; --template gives a
+file (company.md.tmpl, company.html.tmpl) or a directory of report.md.tmpl and
+report.html.tmpl that redefine the "header", "body", "section", "block" or
+"footer" templates, e.g. for a company header, translated headings or the
+order of the sections. Set template in the configuration to use it always.`,
+}
+
+var diagramsTopic = &cobra.Command{
+	Use:   "diagrams",
+	Short: "Text diagrams and exported diagram files",
+	Long: `Text diagrams are sized to the terminal, or to COLUMNS and LINES when the
+output is piped. Use --ascii-plain to draw only the diagrams in 7-bit ASCII,
+with . for shading, * for bars, ^ for supports and + - | for lines.
+
+Exported diagrams are drawn in the --theme default, print (black and grays
+for monochrome printers), dark or colorblind (colors told apart with color
+vision deficiencies). Give --project-name, --member-id or --engineer to stamp
+a title block along their bottom with the --date (default today) and the
+code edition; keep them in gorcb.yaml as e.g. project-name: Tower A.
+
+Diagrams are exported to png, svg, pdf, eps, jpeg or tiff files by their
+extension, each at its own size unless --plot-size gives one for all, e.g.
+180x120mm for a journal column, with bitmaps at --dpi (default 96).`,
+}
+
+var exitCodesTopic = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "Exit codes for scripts and CI pipelines",
+	Long: `Exit codes let scripts and CI pipelines branch on the result:
+  0  success, every design and check adequate
+  1  writing a diagram, report or output failed
+  2  invalid flags, files or input values
+  3  a design or check is not adequate (NG)
+  4  an iterative solver did not converge`,
+}
+
+func init() {
+	rootCmd.AddCommand(configTopic, outputTopic, reportsTopic, diagramsTopic, exitCodesTopic)
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// traceAnnotation marks commands that print their calculations step by step with --trace
+const traceAnnotation = "gorcb/trace"
+
+// Step-by-step calculations with code clauses, selected with --trace
+var traceOutput bool
+
+// traceable marks a command as supporting --trace
+func traceable(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[traceAnnotation] = "true"
+}
+
+// validateTrace checks --trace against the command being run, --format and --quiet
+func validateTrace(cmd *cobra.Command) error {
+	if !traceOutput {
+		return nil
+	}
+	if cmd.Annotations[traceAnnotation] == "" {
+		return fmt.Errorf("%s has no calculation trace for --trace", cmd.CommandPath())
+	}
+	if outputFormat != formatText {
+		return fmt.Errorf("--trace prints text and cannot be combined with --format %s", outputFormat)
+	}
+	if quietOutput {
+		return fmt.Errorf("--trace and --quiet cannot be combined")
+	}
+	return nil
+}
+
+// tracer collects the steps of a calculation trace with their clauses, for
+// printing with --trace and for design reports
+type tracer struct {
+	code  codes.DesignCode
+	steps []report.Step
+}
+
+// step adds a step titled with the clause of p, followed by its equations
+func (t *tracer) step(title string, p codes.Provision, lines ...string) {
+	t.steps = append(t.steps, report.Step{Title: title, Cite: codes.Cite(t.code, p), Lines: lines})
+}
+
+// print prints the numbered steps with their equations indented below them
+func (t *tracer) print() {
+	fmt.Printf("CALCULATION TRACE (%s; N, mm, MPa):\n", t.code.Name())
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	for i, s := range t.steps {
+		fmt.Printf("  %2d. %s", i+1, s.Title)
+		if s.Cite != "" {
+			fmt.Printf("  %s", s.Cite)
+		}
+		fmt.Println()
+		for _, line := range s.Lines {
+			fmt.Printf("       %s\n", line)
+		}
+		fmt.Println()
+	}
+}
+
+// symbols returns the symbols of the stress block intensity and the steel
+// strength used in equilibrium, 0.85f'c and fy for NSCP and ACI
+func (t *tracer) symbols(fc, fy float64) (string, string) {
+	fcd, fyd := "0.85f'c", "fy"
+	if t.code.Alpha1(fc) != 0.85 {
+		fcd = "α1f'c"
+	}
+	if t.code.DesignYieldStrength(fy) != fy {
+		fyd = "fyd"
+	}
+	return fcd, fyd
+}
+
+// traceHeader starts a trace with the material steps common to design and
+// analysis, returning the tracer for the steps that follow
+func traceHeader(b *beam.SinglyReinforced) *tracer {
+	t := &tracer{code: selectedCode}
+	fcdSym, fydSym := t.symbols(b.Fc, b.Fy)
+	alpha1, fcd, fyd := t.code.Alpha1(b.Fc), t.code.Alpha1(b.Fc)*b.Fc, t.code.DesignYieldStrength(b.Fy)
+
+	t.step("Effective depth", "",
+		fmt.Sprintf("d = h − cover = %.2f − %.2f = %.2f mm", b.Height, b.Cover, b.EffectiveDepth))
+
+	lines := []string{fmt.Sprintf("%s = %.4g × %.2f = %.2f MPa", fcdSym, alpha1, b.Fc, fcd)}
+	if fydSym != "fy" {
+		lines = append(lines, fmt.Sprintf("fyd = fy/γs = %.2f/%.2f = %.2f MPa", b.Fy, b.Fy/fyd, fyd))
+	}
+	t.step("Stress block intensity", codes.ProvisionStressBlock, lines...)
+	t.step("Stress block depth factor", codes.ProvisionBeta1,
+		fmt.Sprintf("β1 = %.4f for f'c = %.2f MPa", t.code.Beta1(b.Fc), b.Fc))
+	return t
+}
+
+// traceLimits prints the minimum and maximum steel of the section
+func traceLimits(t *tracer, b *beam.SinglyReinforced, rhoMin, rhoMax, rhoBal float64) {
+	bd := b.Width * b.EffectiveDepth
+	t.step("Minimum reinforcement", codes.ProvisionMinSteel,
+		fmt.Sprintf("ρmin = %.6f", rhoMin),
+		fmt.Sprintf("As,min = ρmin·b·d = %.6f × %.2f × %.2f = %.2f mm²", rhoMin, b.Width, b.EffectiveDepth, rhoMin*bd))
+	t.step("Maximum reinforcement (tension-controlled)", codes.ProvisionTensionControlled,
+		fmt.Sprintf("εt,tc = %.4g, ρb = %.6f", t.code.TensionControlledStrain(b.Fc, b.Fy), rhoBal),
+		fmt.Sprintf("ρmax = %.6f", rhoMax),
+		fmt.Sprintf("As,max = ρmax·b·d = %.6f × %.2f × %.2f = %.2f mm²", rhoMax, b.Width, b.EffectiveDepth, rhoMax*bd))
+}
+
+// traceCapacity prints the stress block, strains, φ and design strength of
+// the section with tension steel as, checked against mu when positive
+func traceCapacity(t *tracer, b *beam.SinglyReinforced, as, mu float64) {
+	fcdSym, fydSym := t.symbols(b.Fc, b.Fy)
+	fcd, fyd := t.code.Alpha1(b.Fc)*b.Fc, t.code.DesignYieldStrength(b.Fy)
+	beta1, epsCU := t.code.Beta1(b.Fc), t.code.EpsilonCU(b.Fc)
+	d := b.EffectiveDepth
+
+	a := as * fyd / (fcd * b.Width)
+	t.step("Depth of compression block (T = C)", codes.ProvisionStressBlock,
+		fmt.Sprintf("a = As·%s/(%s·b) = %.2f × %.2f/(%.2f × %.2f) = %.2f mm", fydSym, fcdSym, as, fyd, fcd, b.Width, a))
+
+	c := a / beta1
+	t.step("Neutral axis depth", codes.ProvisionBeta1,
+		fmt.Sprintf("c = a/β1 = %.2f/%.4f = %.2f mm", a, beta1, c))
+
+	epsT := epsCU * (d - c) / c
+	t.step("Net tensile strain", codes.ProvisionStrainCompatibility,
+		fmt.Sprintf("εcu = %.4g %s", epsCU, codes.Cite(t.code, codes.ProvisionConcreteStrain)),
+		fmt.Sprintf("εt = εcu·(d − c)/c = %.4g × (%.2f − %.2f)/%.2f = %.6f", epsCU, d, c, c, epsT))
+
+	phi := t.code.Phi(epsT, b.Fy, nscp.TransverseTied)
+	epsTC := t.code.TensionControlledStrain(b.Fc, b.Fy)
+	zone := fmt.Sprintf("εt = %.6f ≥ %.4g: tension-controlled", epsT, epsTC)
+	if epsT < epsTC {
+		zone = fmt.Sprintf("εt = %.6f < %.4g: not tension-controlled", epsT, epsTC)
+	}
+	t.step("Strength reduction factor", codes.ProvisionStrengthReduction, zone, fmt.Sprintf("φ = %.2f", phi))
+
+	mn := as * fyd * (d - a/2) / 1e6
+	lines := []string{
+		fmt.Sprintf("Mn = As·%s·(d − a/2) = %.2f × %.2f × (%.2f − %.2f/2)/10⁶ = %.2f kN-m", fydSym, as, fyd, d, a, mn),
+		fmt.Sprintf("φMn = %.2f × %.2f = %.2f kN-m", phi, mn, phi*mn),
+	}
+	if mu > 0 {
+		check := "≥"
+		verdict := "OK"
+		if phi*mn < mu*0.999 {
+			check, verdict = "<", "NOT ADEQUATE"
+		}
+		lines = append(lines, fmt.Sprintf("φMn = %.2f %s Mu = %.2f kN-m: %s", phi*mn, check, mu, verdict))
+	}
+	t.step("Design strength", codes.ProvisionDesignStrength, lines...)
+}
+
+// designTrace traces the design of a singly reinforced beam step by step
+func designTrace(b *beam.SinglyReinforced, mu float64, r *beam.DesignResult) *tracer {
+	t := traceHeader(b)
+	fcdSym, fydSym := t.symbols(b.Fc, b.Fy)
+	fcd, fyd := t.code.Alpha1(b.Fc)*b.Fc, t.code.DesignYieldStrength(b.Fy)
+	d := b.EffectiveDepth
+	traceLimits(t, b, r.RhoMin, r.RhoMax, r.RhoBalanced)
+
+	if r.RhoRequired == 0 {
+		// Design stopped before the steel ratio: the singly reinforced limit governs
+		t.step("Singly reinforced limit", codes.ProvisionTensionControlled, r.Message)
+		return t
+	}
+
+	phi := t.code.PhiFlexure()
+	t.step("Assumed strength reduction factor", codes.ProvisionStrengthReduction,
+		fmt.Sprintf("φ = %.2f (tension-controlled section assumed)", phi))
+
+	rn := mu * 1e6 / (phi * b.Width * d * d)
+	t.step("Coefficient of resistance", "",
+		fmt.Sprintf("Rn = Mu/(φ·b·d²) = %.2f × 10⁶/(%.2f × %.2f × %.2f²) = %.4f MPa", mu, phi, b.Width, d, rn))
+
+	t.step("Required steel ratio", codes.ProvisionStressBlock,
+		fmt.Sprintf("ρ = (%s/%s)·(1 − √(1 − 2Rn/%s))", fcdSym, fydSym, fcdSym),
+		fmt.Sprintf("  = (%.2f/%.2f) × (1 − √(1 − 2 × %.4f/%.2f)) = %.6f", fcd, fyd, rn, fcd, r.RhoRequired))
+
+	bd := b.Width * d
+	if r.RhoRequired < r.RhoMin {
+		lines := []string{
+			fmt.Sprintf("ρ = %.6f < ρmin = %.6f: use ρmin", r.RhoRequired, r.RhoMin),
+			fmt.Sprintf("As = ρmin·b·d = %.6f × %.2f × %.2f = %.2f mm²", r.RhoMin, b.Width, d, r.AsRequired),
+		}
+		if r.AsAlternative > 0 {
+			lines = append(lines, fmt.Sprintf("or 4/3·As by analysis = 4/3 × %.2f = %.2f mm² %s",
+				r.AsStrength, r.AsAlternative, codes.Cite(t.code, codes.ProvisionMinSteelAlternative)))
+		}
+		t.step("Required tension steel", codes.ProvisionMinSteel, lines...)
+	} else {
+		t.step("Required tension steel", "",
+			fmt.Sprintf("ρmin = %.6f ≤ ρ = %.6f ≤ ρmax = %.6f", r.RhoMin, r.RhoRequired, r.RhoMax),
+			fmt.Sprintf("As = ρ·b·d = %.6f × %.2f × %.2f = %.2f mm²", r.RhoRequired, b.Width, d, r.RhoRequired*bd))
+	}
+
+	traceCapacity(t, b, r.AsRequired, mu)
+	return t
+}
+
+// analysisTrace traces the analysis of a singly reinforced beam step by step
+func analysisTrace(b *beam.SinglyReinforced, as float64, r *beam.AnalysisResult) *tracer {
+	t := traceHeader(b)
+	traceLimits(t, b, r.RhoMin, r.RhoMax, r.RhoBalanced)
+
+	check := "ρmin ≤ ρ ≤ ρmax: OK"
+	if !r.MeetsMinReinf {
+		check = "ρ < ρmin: below minimum reinforcement"
+	} else if !r.MeetsMaxReinf {
+		check = "ρ > ρmax: exceeds maximum reinforcement"
+	}
+	t.step("Provided steel ratio", codes.ProvisionMinSteel,
+		fmt.Sprintf("ρ = As/(b·d) = %.2f/(%.2f × %.2f) = %.6f", as, b.Width, b.EffectiveDepth, r.Rho), check)
+
+	traceCapacity(t, b, as, 0)
+	return t
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// unitsAnnotation marks commands that take inputs and print results in the
+// units selected with --units
+const unitsAnnotation = "gorcb/units"
+
+var (
+	// System of units selected with --units
+	unitSystemName = units.SystemSI
+	selectedUnits  = units.SI()
+)
+
+// flagUnit matches the SI unit in the usage of a flag, e.g. "(mm)" in
+// "Beam width (mm) [required]"
+var flagUnit = regexp.MustCompile(`\((mm²|mm|MPa|kN-m|kN)\)`)
+
+// unitAware marks a command as supporting --units us
+func unitAware(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[unitsAnnotation] = "true"
+}
+
+// applyUnits selects the system of units of the command being run and
+// converts the values of its flags to SI units: those given on the command
+// line from the selected units, those set from the environment or the
+// configuration files from the units they set themselves, or SI
+func applyUnits(cmd *cobra.Command) error {
+	system, err := units.GetSystem(unitSystemName)
+	if err != nil {
+		return err
+	}
+	selectedUnits = system
+	if !system.IsSI() && cmd.Annotations[unitsAnnotation] == "" {
+		return fmt.Errorf("%s does not support --units %s (use --units %s)", cmd.CommandPath(), system.Name, units.SystemSI)
+	}
+
+	var convErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if convErr != nil || f.Value.Type() != "float64" {
+			return
+		}
+		from, err := flagUnits(f)
+		if err != nil {
+			convErr = err
+			return
+		}
+		match := flagUnit.FindStringSubmatch(f.Usage)
+		if from.IsSI() || match == nil {
+			return
+		}
+		unit, _ := from.Unit(match[1])
+		v, err := strconv.ParseFloat(f.Value.String(), 64)
+		if err == nil {
+			err = f.Value.Set(strconv.FormatFloat(unit.ToSI(v), 'g', -1, 64))
+		}
+		if err != nil {
+			convErr = fmt.Errorf("--%s: %w", f.Name, err)
+		}
+	})
+	return convErr
+}
+
+// flagUnits returns the system of units the value of a flag was given in:
+// the selected units on the command line, the units the environment or the
+// configuration files set for their own values, and SI for defaults
+func flagUnits(f *pflag.Flag) (units.System, error) {
+	switch {
+	case f.Changed:
+		return selectedUnits, nil
+	case envFlags[f.Name]:
+		return units.GetSystem(envUnitsName)
+	case configuredFlags[f.Name]:
+		return units.GetSystem(fileUnitsName)
+	}
+	return units.SI(), nil
+}
+
+// flagValue returns the value of a flag converted back from SI to the
+// selected units, as it would be given on the command line
+func flagValue(f *pflag.Flag) string {
+	match := flagUnit.FindStringSubmatch(f.Usage)
+	if selectedUnits.IsSI() || f.Value.Type() != "float64" || match == nil {
+		return f.Value.String()
+	}
+	v, err := strconv.ParseFloat(f.Value.String(), 64)
+	if err != nil {
+		return f.Value.String()
+	}
+	unit, _ := selectedUnits.Unit(match[1])
+	return strconv.FormatFloat(unit.FromSI(v), 'g', -1, 64)
+}
+
+// fmtLength prints a length in mm in the selected units with prec decimals in SI
+func fmtLength(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Length, units.QuantityLength, v, prec)
+}
+
+// fmtSpacing prints a bar or stirrup spacing in mm in the selected units
+func fmtSpacing(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Length, units.QuantitySpacing, v, prec)
+}
+
+// fmtArea prints an area in mm² in the selected units
+func fmtArea(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Area, units.QuantityArea, v, prec)
+}
+
+// fmtStress prints a stress in MPa in the selected units
+func fmtStress(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Stress, units.QuantityStress, v, prec)
+}
+
+// fmtForce prints a force in kN in the selected units
+func fmtForce(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Force, units.QuantityForce, v, prec)
+}
+
+// fmtMoment prints a moment in kN-m in the selected units
+func fmtMoment(v float64, prec int) string {
+	return fmtQuantity(selectedUnits.Moment, units.QuantityMoment, v, prec)
+}
+
+// fmtMass prints a steel mass per length in kg/m in the selected units
+func fmtMass(v float64, prec int) string {
+	return selectedUnits.Mass.Format(v, prec)
+}
+
+// fmtInertia prints a second moment of area in mm⁴ in the selected units
+func fmtInertia(v float64) string {
+	return fmt.Sprintf("%.4g %s⁴", v/math.Pow(selectedUnits.Length.Factor, 4), selectedUnits.Length.Label)
+}
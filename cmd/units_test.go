@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"github.com/spf13/cobra"
+)
+
+// unitsTestCommand returns a unit-aware command with flags like those of
+// beam design, reading its defaults from a configuration file
+func unitsTestCommand(t *testing.T, config string) (*cobra.Command, map[string]*float64) {
+	t.Helper()
+	configuredFlags = make(map[string]bool)
+	envFlags = make(map[string]bool)
+	unitSystemName = units.SystemSI
+	configFile = filepath.Join(t.TempDir(), "gorcb.yaml")
+	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { configFile = "" })
+
+	cmd := &cobra.Command{Use: "design", Run: func(*cobra.Command, []string) {}}
+	unitAware(cmd)
+	values := map[string]*float64{"width": new(float64), "cover": new(float64), "fc": new(float64)}
+	cmd.Flags().Float64Var(values["width"], "width", 0, "Beam width (mm) [required]")
+	cmd.Flags().Float64Var(values["cover"], "cover", 65, "Effective cover to steel centroid (mm)")
+	cmd.Flags().Float64Var(values["fc"], "fc", 28, "Concrete compressive strength f'c (MPa)")
+	cmd.Flags().StringVar(&unitSystemName, "units", units.SystemSI, "System of units")
+	return cmd, values
+}
+
+// runUnits parses the arguments of a command, then sets its flags from the
+// configuration and converts them to SI units as the root command does
+func runUnits(t *testing.T, cmd *cobra.Command, args ...string) {
+	t.Helper()
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyConfig(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyUnits(cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyUnitsConfigInSI(t *testing.T) {
+	t.Setenv("GORCB_FC", "")
+	cmd, values := unitsTestCommand(t, "fc: 28\ncover: 65\nunits:\n  length: mm\n  stress: MPa\n")
+	runUnits(t, cmd, "--units", "us", "--width", "12")
+
+	want := map[string]float64{"width": 304.8, "cover": 65, "fc": 28}
+	for name, v := range want {
+		if got := *values[name]; math.Abs(got-v) > 1e-9 {
+			t.Errorf("--%s = %g, want %g", name, got, v)
+		}
+	}
+}
+
+func TestApplyUnitsConfigInUS(t *testing.T) {
+	t.Setenv("GORCB_FC", "")
+	cmd, values := unitsTestCommand(t, "units: us\nfc: 4\ncover: 2.5\n")
+	runUnits(t, cmd, "--units", "si", "--width", "300")
+
+	want := map[string]float64{"width": 300, "cover": 63.5, "fc": 27.579}
+	for name, v := range want {
+		if got := *values[name]; math.Abs(got-v) > 1e-3 {
+			t.Errorf("--%s = %g, want %g", name, got, v)
+		}
+	}
+}
+
+func TestApplyUnitsEnvInSI(t *testing.T) {
+	t.Setenv("GORCB_FC", "21")
+	cmd, values := unitsTestCommand(t, "fc: 28\n")
+	runUnits(t, cmd, "--units", "us", "--width", "12")
+
+	if got := *values["fc"]; got != 21 {
+		t.Errorf("--fc = %g, want 21 from GORCB_FC in MPa", got)
+	}
+}
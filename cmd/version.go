@@ -11,6 +11,15 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number of gorcb",
 	Run: func(cmd *cobra.Command, args []string) {
+		if structuredOutput() {
+			printReport(cmd, nil, map[string]string{
+				"version":    version.Version,
+				"commit":     version.GitCommit,
+				"build_time": version.BuildTime,
+			})
+			return
+		}
+
 		fmt.Println()
 		fmt.Printf("  gorcb v%s\n", version.Version)
 		fmt.Println("  ─────────────────────────────────────────")
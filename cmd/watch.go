@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// watchInterval is how often a watched file is checked for changes
+const watchInterval = 500 * time.Millisecond
+
+// watchFile runs a command, then runs it again whenever the file at path is
+// saved, until interrupted with Ctrl+C. The exit code is the one of the last run.
+func watchFile(path string, run func()) {
+	if path == "-" {
+		fmt.Println("Error: --watch needs a file, not stdin (-)")
+		setExit(exitInvalidInput)
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	last := fileStamp(path)
+	for {
+		exitCode = exitOK
+		run()
+		fmt.Printf("\nWatching %s for changes (Ctrl+C to stop)...\n", path)
+
+		// Wait for a change, then for the file to settle as editors may
+		// write it in several steps
+		for changed := false; ; {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchInterval):
+			}
+			stamp := fileStamp(path)
+			if stamp == last && changed {
+				break
+			}
+			changed = changed || stamp != last
+			last = stamp
+		}
+		fmt.Printf("\n── %s changed at %s ──\n", path, time.Now().Format("15:04:05"))
+	}
+}
+
+// fileStamp returns the modification time and size of a file, or its error,
+// to tell when it was saved
+func fileStamp(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+}
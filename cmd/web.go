@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/alexiusacademia/gorcb/internal/api"
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"github.com/alexiusacademia/gorcb/internal/server"
+	"github.com/alexiusacademia/gorcb/internal/web"
+	"github.com/spf13/cobra"
+)
+
+var webAddr string
+
+var webCmd = &cobra.Command{
+	Use:   "web",
+	Short: "Serve a local web UI for beam and section inputs",
+	Long: `Serve a small web UI on localhost with forms for rectangular beam and section
+inputs, showing the results with the section and strain diagrams, for
+engineers who prefer a GUI over flags. The UI is built into gorcb and needs
+no other install; open the printed address in a browser.
+
+The design code is the one selected with --code. The JSON API of 'gorcb serve'
+is served alongside the UI.
+
+Examples:
+  gorcb web
+  gorcb web --addr localhost:9000 --code aci318-19`,
+	Run: runWeb,
+}
+
+func init() {
+	rootCmd.AddCommand(webCmd)
+	unrecorded(webCmd)
+
+	webCmd.Flags().StringVar(&webAddr, "addr", "localhost:8088", "Address to listen on")
+}
+
+// webResult is the response of the UI: the API response with the diagrams
+type webResult struct {
+	*api.Response
+	SectionDiagram string `json:"section_diagram,omitempty"` // SVG
+	StrainDiagram  string `json:"strain_diagram,omitempty"`  // SVG
+}
+
+func runWeb(cmd *cobra.Command, args []string) {
+	listener, err := net.Listen("tcp", webAddr)
+	if err != nil {
+		printError(err)
+		return
+	}
+	mux := http.NewServeMux()
+	server.New(selectedCode).Register(mux)
+	mux.HandleFunc("GET /{$}", web.Page)
+	mux.HandleFunc("GET /web/starter", webStarter)
+	mux.Handle("POST /web/beam", webHandler(webBeam))
+	mux.Handle("POST /web/section", webHandler(webSection))
+	mux.HandleFunc("/", server.NotFound)
+
+	fmt.Printf("gorcb web UI (%s) at http://%s (Ctrl+C to stop)\n", selectedCode.Name(), listener.Addr())
+	serveUntilInterrupt(listener, mux)
+}
+
+// webHandler returns the handler of a UI request, which runs the engine on
+// the JSON body and adds the diagrams of the result
+func webHandler(run func(data []byte) (*api.Response, *diagram.SectionDiagramData, *api.Error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := server.ReadJSON(w, r)
+		if !ok {
+			return
+		}
+		resp, diagramData, apiErr := run(data)
+		if apiErr != nil {
+			server.WriteError(w, apiErr)
+			return
+		}
+		result := webResult{Response: resp}
+		if diagramData != nil {
+			sectionImage, err := diagram.SectionDiagramSVG(*diagramData)
+			var strainImage []byte
+			if err == nil {
+				strainImage, err = diagram.StrainDiagramSVG(*diagramData)
+			}
+			if err == nil {
+				result.SectionDiagram, result.StrainDiagram = string(sectionImage), string(strainImage)
+			}
+		}
+		server.WriteJSON(w, http.StatusOK, result)
+	})
+}
+
+// webBeam runs a rectangular beam, with the diagram of a singly reinforced
+// beam whose stress block is known
+func webBeam(data []byte) (*api.Response, *diagram.SectionDiagramData, *api.Error) {
+	resp, apiErr := api.New(selectedCode).Beam(data)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	r := resp.Result.(*batch.Result)
+	a, c, ok := batchStressBlock(*r)
+	if !ok {
+		return resp, nil, nil
+	}
+	cover := r.Member.Cover
+	if cover == 0 {
+		cover = batch.DefaultCover
+	}
+	b := beam.NewSinglyReinforced(r.Member.Width, r.Member.Height, cover, r.Fc, r.Fy)
+	d := singlyDiagramData(b, r.As, a, c, r.EpsilonT)
+	return resp, &d, nil
+}
+
+// webSection analyzes the JSON of a section file, with its diagram
+func webSection(data []byte) (*api.Response, *diagram.SectionDiagramData, *api.Error) {
+	resp, apiErr := api.New(selectedCode).AnalyzeSection(data)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	d := sectionAnalysisDiagramData(resp.Input.(*section.Section), resp.Result.(*section.AnalysisResult))
+	return resp, &d, nil
+}
+
+// webStarter returns the starter section file of ?shape=, or the list of
+// shapes without one
+func webStarter(w http.ResponseWriter, r *http.Request) {
+	shape := r.URL.Query().Get("shape")
+	if shape == "" {
+		server.WriteJSON(w, http.StatusOK, section.StarterShapes)
+		return
+	}
+	sec, err := section.Starter(shape)
+	var data []byte
+	if err == nil {
+		data, err = encodeSection(sec, formatJSON)
+	}
+	if err != nil {
+		server.WriteError(w, &api.Error{Code: api.CodeInvalidInput, Message: err.Error(), Field: "shape"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"github.com/alexiusacademia/gorcb/internal/xlsx"
+	"github.com/spf13/cobra"
+)
+
+// Excel workbook written with --xlsx
+var workbookFile string
+
+// exportable registers --xlsx on a command that writes its results to a
+// workbook
+func exportable(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&workbookFile, "xlsx", "",
+		"Also write the results and load combination tables to an Excel workbook (.xlsx)")
+}
+
+// validateWorkbook checks the extension of the --xlsx workbook file
+func validateWorkbook() error {
+	if workbookFile == "" {
+		return nil
+	}
+	if !strings.EqualFold(filepath.Ext(workbookFile), ".xlsx") {
+		return fmt.Errorf("unsupported workbook file %q (use .xlsx)", workbookFile)
+	}
+	return nil
+}
+
+// writeWorkbook writes the workbook to the --xlsx file
+func writeWorkbook(book *xlsx.Workbook) {
+	if err := book.WriteFile(workbookFile); err != nil {
+		fmt.Printf("Error writing workbook: %v\n", err)
+		setExit(exitFailure)
+		return
+	}
+	if outputFormat == formatText && !quietOutput {
+		fmt.Printf("Workbook written to: %s\n", workbookFile)
+	}
+}
+
+// fieldRows returns the cells of a table row as Item/Value rows, skipping
+// empty cells
+func fieldRows(header, row []string) [][]string {
+	var rows [][]string
+	for i, c := range row {
+		if c != "" {
+			rows = append(rows, []string{header[i], c})
+		}
+	}
+	return rows
+}
+
+// projectWorkbook returns the summary of the members of a project followed by
+// one sheet per member with its results and the factored effects of its load
+// combinations
+func projectWorkbook(p *project.Project, result *project.Result) *xlsx.Workbook {
+	book := xlsx.New()
+	rows := projectRows(result)
+	book.AddSheet("Summary").Table(batchHeader, rows)
+
+	combinations := selectedCode.LoadCombinations()
+	for i, row := range rows {
+		sheet := book.AddSheet(row[0])
+		sheet.Table([]string{"Item", "Value"}, fieldRows(batchHeader, row))
+		var loads project.Loads
+		if i < len(p.Beams) {
+			loads = p.Beams[i].Loads
+		} else {
+			loads = p.Sections[i-len(p.Beams)].Loads
+		}
+		if len(loads) > 0 {
+			sheet.Table(combinationTable(nscp.CalculateDemands(loads.Effects(), combinations), false))
+		}
+	}
+	return book
+}
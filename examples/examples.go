@@ -0,0 +1,137 @@
+// Package examples bundles the example inputs of gorcb with the results
+// they are expected to give, for 'gorcb examples' to write out and verify
+package examples
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed *.json *.yaml *.csv
+var files embed.FS
+
+// Kinds of examples, by the command that runs them
+const (
+	KindSection = "section" // gorcb section analyze
+	KindCheck   = "check"   // gorcb check
+	KindBatch   = "batch"   // gorcb batch run
+	KindProject = "project" // gorcb project run or check, and gorcb schedule
+	KindConfig  = "config"  // Supporting files given to flags of other commands
+)
+
+// Keys of expected results
+const (
+	ResultPhiMn     = "phi_mn"    // Design strength φMn (kN-m)
+	ResultC         = "c"         // Neutral axis depth (mm)
+	ResultEpsilonT  = "epsilon_t" // Net tensile strain εt
+	ResultMu        = "mu"        // Governing factored moment (kN-m)
+	ResultChecksOK  = "checks_ok" // Checks passed
+	ResultAdequate  = "adequate"  // Members adequate
+	ResultScheduled = "scheduled" // Beams in the bar bending schedule
+)
+
+// Example is a bundled input file
+type Example struct {
+	Name        string             `json:"name"`
+	File        string             `json:"file"`
+	Kind        string             `json:"kind"`
+	Description string             `json:"description"`
+	Command     string             `json:"command"`            // Command that runs the file where it was written
+	Expected    map[string]float64 `json:"expected,omitempty"` // Results under NSCP 2015 with the default flags
+}
+
+// All lists the bundled examples, case studies with expected results first
+var All = []Example{
+	{
+		Name: "rectangular", File: "rectangular.json", Kind: KindSection,
+		Description: "300×500 mm rectangular beam with 4-20 mm bars",
+		Command:     "gorcb section analyze -f rectangular.json",
+		Expected:    map[string]float64{ResultPhiMn: 186.88, ResultC: 86.37, ResultEpsilonT: 0.012109},
+	},
+	{
+		Name: "t-beam", File: "t-beam.json", Kind: KindSection,
+		Description: "T-beam with a 600 mm flange and a 300 mm web",
+		Command:     "gorcb section analyze -f t-beam.json",
+		Expected:    map[string]float64{ResultPhiMn: 195.54, ResultC: 43.19, ResultEpsilonT: 0.027218},
+	},
+	{
+		Name: "l-beam", File: "l-beam.json", Kind: KindSection,
+		Description: "L-beam with the flange extended on one side",
+		Command:     "gorcb section analyze -f l-beam.json",
+		Expected:    map[string]float64{ResultPhiMn: 147.32, ResultC: 38.87, ResultEpsilonT: 0.030572},
+	},
+	{
+		Name: "box-girder", File: "box-girder.json", Kind: KindSection,
+		Description: "Hollow single-cell 800×900 mm box with 200 mm walls",
+		Command:     "gorcb section analyze -f box-girder.json",
+		Expected:    map[string]float64{ResultPhiMn: 1154.05, ResultC: 101.21, ResultEpsilonT: 0.021602},
+	},
+	{
+		Name: "doubly", File: "doubly.json", Kind: KindSection,
+		Description: "Doubly reinforced 300×500 mm beam in the transition zone",
+		Command:     "gorcb section analyze -f doubly.json",
+		Expected:    map[string]float64{ResultPhiMn: 387.72, ResultC: 165.13, ResultEpsilonT: 0.004948},
+	},
+	{
+		Name: "design", File: "design.json", Kind: KindCheck,
+		Description: "Detailed doubly reinforced beam checked against every limit state",
+		Command:     "gorcb check -f design.json",
+		Expected:    map[string]float64{ResultPhiMn: 189.91, ResultMu: 128, ResultChecksOK: 13},
+	},
+	{
+		Name: "beams", File: "beams.json", Kind: KindBatch,
+		Description: "Batch of beams to design and analyze",
+		Command:     "gorcb batch run -f beams.json",
+	},
+	{
+		Name: "beams-csv", File: "beams.csv", Kind: KindBatch,
+		Description: "The batch of beams as a spreadsheet export",
+		Command:     "gorcb batch run -f beams.csv",
+	},
+	{
+		Name: "project", File: "project.yaml", Kind: KindProject,
+		Description: "Project of members with loads, combinations and sections",
+		Command:     "gorcb project check -f project.yaml",
+		Expected:    map[string]float64{ResultAdequate: 5, ResultScheduled: 4},
+	},
+	{
+		Name: "combinations", File: "combinations.yaml", Kind: KindConfig,
+		Description: "Custom load combination sets",
+		Command:     "gorcb moment --dead 50 --live 30 --combinations combinations.yaml --set warehouse",
+	},
+	{
+		Name: "code-overrides", File: "code-overrides.yaml", Kind: KindConfig,
+		Description: "Project φ factors and strain limit overrides",
+		Command:     "gorcb beam design -b 300 --height 500 -m 150 --code-overrides code-overrides.yaml",
+	},
+	{
+		Name: "rebar-catalog", File: "rebar-catalog.json", Kind: KindConfig,
+		Description: "Bars added to the rebar catalog",
+		Command:     "gorcb rebar list --bar-catalog rebar-catalog.json",
+	},
+	{
+		Name: "config", File: "gorcb.yaml", Kind: KindConfig,
+		Description: "Flag defaults of a project directory",
+		Command:     "gorcb beam design -b 300 --height 500 -m 150 --config gorcb.yaml",
+	},
+}
+
+// Find returns the example of a name or file name
+func Find(name string) (Example, error) {
+	for _, e := range All {
+		if strings.EqualFold(e.Name, name) || strings.EqualFold(e.File, name) {
+			return e, nil
+		}
+	}
+	names := make([]string, len(All))
+	for i, e := range All {
+		names[i] = e.Name
+	}
+	return Example{}, fmt.Errorf("unknown example %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// Data returns the contents of the file of an example
+func (e Example) Data() ([]byte, error) {
+	return files.ReadFile(e.File)
+}
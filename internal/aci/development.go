@@ -0,0 +1,45 @@
+package aci
+
+import "math"
+
+// Development length modification factors (ACI 318-19 Table 25.4.2.5)
+const (
+	PsiTopBar   = 1.3 // ψt, more than 300 mm of fresh concrete below the bar
+	PsiOtherBar = 1.0
+)
+
+// PsiG returns the reinforcement grade factor ψg
+// ACI 318-19 Table 25.4.2.5
+func PsiG(fy float64) float64 {
+	switch {
+	case fy <= 420:
+		return 1.0
+	case fy <= 550:
+		return 1.15
+	default:
+		return 1.3
+	}
+}
+
+// DevelopmentLength calculates the tension development length of a
+// straight deformed bar with clear spacing ≥ db and cover ≥ db (mm)
+// ACI 318-19 Table 25.4.2.3 (uncoated bars, ψe = 1.0)
+func DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	psiT := PsiOtherBar
+	if topBar {
+		psiT = PsiTopBar
+	}
+
+	// √f'c shall not exceed 8.3 MPa (Section 25.4.1.4)
+	sqrtFc := math.Min(math.Sqrt(fc), 8.3)
+
+	var ld float64
+	if db <= 20 {
+		// No. 19 and smaller bars
+		ld = fy * psiT * PsiG(fy) / (2.1 * lambda * sqrtFc) * db
+	} else {
+		// No. 22 and larger bars
+		ld = fy * psiT * PsiG(fy) / (1.7 * lambda * sqrtFc) * db
+	}
+	return math.Max(ld, 300)
+}
@@ -0,0 +1,173 @@
+package aci
+
+import "github.com/alexiusacademia/gorcb/internal/nscp"
+
+// ACI 318-19 Table 5.3.1 - Load Combinations
+// F takes the same factor as D in combinations (a) to (e) (Section 5.3.8);
+// H is factored 1.6 and always taken as adding to the load effect.
+var LoadCombinations = []nscp.LoadCombination{
+	{
+		ID:          "5.3.1a",
+		Description: "1.4(D + F) + 1.6H",
+		Dead:        1.4,
+		Fluid:       1.4,
+		Earth:       1.6,
+	},
+	{
+		ID:           "5.3.1b",
+		Description:  "1.2(D + F) + 1.6(L + H) + 0.5(Lr or S or R)",
+		Dead:         1.2,
+		Live:         1.6,
+		Roof:         0.5,
+		Snow:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}},
+	},
+	{
+		ID:           "5.3.1c",
+		Description:  "1.2(D + F) + 1.6(Lr or S or R) + (1.0L or 0.5W) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Roof:         1.6,
+		Snow:         1.6,
+		Rain:         1.6,
+		Wind:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}, {nscp.LoadLive, nscp.LoadWind}},
+	},
+	{
+		ID:           "5.3.1d",
+		Description:  "1.2(D + F) + 1.0W + 1.0L + 0.5(Lr or S or R) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Wind:         1.0,
+		Roof:         0.5,
+		Snow:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}},
+	},
+	{
+		ID:          "5.3.1e",
+		Description: "1.2(D + F) + 1.0E + 1.0L + 0.2S + 1.6H",
+		Dead:        1.2,
+		Live:        1.0,
+		Earthquake:  1.0,
+		Snow:        0.2,
+		Fluid:       1.2,
+		Earth:       1.6,
+	},
+	{
+		ID:          "5.3.1f",
+		Description: "0.9D + 1.0W + 1.6H",
+		Dead:        0.9,
+		Wind:        1.0,
+		Earth:       1.6,
+	},
+	{
+		ID:          "5.3.1g",
+		Description: "0.9D + 1.0E + 1.6H",
+		Dead:        0.9,
+		Earthquake:  1.0,
+		Earth:       1.6,
+	},
+}
+
+// ASCE/SEI 7-16 Section 2.4.1 - Basic Combinations for Allowable Stress Design
+// Referenced by ACI 318-19 for service-level checks (e.g. foundations).
+var ASDCombinations = []nscp.LoadCombination{
+	{
+		ID:          "2.4.1-1",
+		Description: "D + F",
+		Dead:        1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "2.4.1-2",
+		Description: "D + H + F + L",
+		Dead:        1.0,
+		Live:        1.0,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:           "2.4.1-3",
+		Description:  "D + H + F + (Lr or S or R)",
+		Dead:         1.0,
+		Roof:         1.0,
+		Snow:         1.0,
+		Rain:         1.0,
+		Earth:        1.0,
+		Fluid:        1.0,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}},
+	},
+	{
+		ID:           "2.4.1-4",
+		Description:  "D + H + F + 0.75L + 0.75(Lr or S or R)",
+		Dead:         1.0,
+		Live:         0.75,
+		Roof:         0.75,
+		Snow:         0.75,
+		Rain:         0.75,
+		Earth:        1.0,
+		Fluid:        1.0,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}},
+	},
+	{
+		ID:          "2.4.1-5a",
+		Description: "D + H + F + 0.6W",
+		Dead:        1.0,
+		Wind:        0.6,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "2.4.1-5b",
+		Description: "D + H + F + 0.7E",
+		Dead:        1.0,
+		Earthquake:  0.7,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:           "2.4.1-6a",
+		Description:  "D + H + F + 0.75L + 0.75(0.6W) + 0.75(Lr or S or R)",
+		Dead:         1.0,
+		Live:         0.75,
+		Wind:         0.45,
+		Roof:         0.75,
+		Snow:         0.75,
+		Rain:         0.75,
+		Earth:        1.0,
+		Fluid:        1.0,
+		Alternatives: [][]nscp.LoadType{{nscp.LoadRoof, nscp.LoadSnow, nscp.LoadRain}},
+	},
+	{
+		ID:          "2.4.1-6b",
+		Description: "D + H + F + 0.75L + 0.75(0.7E) + 0.75S",
+		Dead:        1.0,
+		Live:        0.75,
+		Earthquake:  0.525,
+		Snow:        0.75,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "2.4.1-7",
+		Description: "0.6D + 0.6W + H",
+		Dead:        0.6,
+		Wind:        0.6,
+		Earth:       1.0,
+	},
+	{
+		ID:          "2.4.1-8",
+		Description: "0.6D + 0.7E + H",
+		Dead:        0.6,
+		Earthquake:  0.7,
+		Earth:       1.0,
+	},
+}
@@ -0,0 +1,131 @@
+package aci
+
+import (
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// ACI 318-19 Material Constants
+
+const (
+	// Beta1 factors for equivalent rectangular stress block
+	// Table 22.2.2.4.3
+	Beta1Max = 0.85 // for 17 <= f'c <= 28 MPa
+	Beta1Min = 0.65 // for f'c >= 55 MPa
+
+	// Ultimate concrete strain (Section 22.2.2.1)
+	EpsilonCU = 0.003
+
+	// Strength reduction factors (Table 21.2.1 and 21.2.2)
+	PhiFlexure       = 0.90 // Tension-controlled sections
+	PhiShear         = 0.75 // Shear and torsion
+	PhiCompression   = 0.65 // Compression-controlled (other)
+	PhiCompressionSp = 0.75 // Compression-controlled (spiral)
+
+	// Lightweight concrete modification factor limits (Table 19.2.4.1)
+	LambdaNormal      = 1.00 // Normalweight concrete
+	LambdaSandLight   = 0.85 // Sand-lightweight concrete (lower bound of Table 19.2.4.2)
+	LambdaAllLight    = 0.75 // All-lightweight concrete
+	LambdaNormalLimit = 2160 // kg/m³, equilibrium density above which λ = 1.0
+
+	// Modulus of elasticity for steel (Section 20.2.2.2)
+	Es = nscp.Es // MPa
+)
+
+// Beta1 calculates the factor for equivalent rectangular stress block
+// ACI 318-19 Table 22.2.2.4.3
+func Beta1(fc float64) float64 {
+	if fc <= 28 {
+		return Beta1Max
+	}
+	// β1 = 0.85 - 0.05(f'c - 28)/7 for 28 < f'c < 55 MPa
+	beta1 := Beta1Max - 0.05*(fc-28)/7
+	return math.Max(beta1, Beta1Min)
+}
+
+// TensionControlledStrain returns the net tensile strain limit for
+// tension-controlled sections, εty + 0.003
+// ACI 318-19 Table 21.2.2
+func TensionControlledStrain(fy float64) float64 {
+	return fy/Es + 0.003
+}
+
+// Phi calculates the strength reduction factor based on net tensile strain
+// ACI 318-19 Table 21.2.2, starting at 0.65 (other) or 0.75 (spiral)
+func Phi(epsilonT float64, fy float64, transverse nscp.Transverse) float64 {
+	epsilonTY := fy / Es
+	phiC := PhiCompression
+	if transverse == nscp.TransverseSpiral {
+		phiC = PhiCompressionSp
+	}
+
+	if epsilonT >= TensionControlledStrain(fy) {
+		// Tension-controlled
+		return PhiFlexure
+	} else if epsilonT <= epsilonTY {
+		// Compression-controlled
+		return phiC
+	}
+	// Transition zone
+	return phiC + (PhiFlexure-phiC)*(epsilonT-epsilonTY)/0.003
+}
+
+// RhoMin calculates minimum flexural reinforcement ratio
+// ACI 318-19 Section 9.6.1.2
+func RhoMin(fc, fy float64) float64 {
+	// ρmin = max(0.25√f'c / fy, 1.4/fy)
+	rho1 := 0.25 * math.Sqrt(fc) / fy
+	rho2 := 1.4 / fy
+	return math.Max(rho1, rho2)
+}
+
+// RhoMax calculates maximum reinforcement ratio for a tension-controlled section
+// Based on strain compatibility at εt = εty + 0.003
+func RhoMax(fc, fy float64) float64 {
+	beta1 := Beta1(fc)
+	// c/d = εcu / (εcu + εt)
+	return 0.85 * beta1 * (fc / fy) * (EpsilonCU / (EpsilonCU + TensionControlledStrain(fy)))
+}
+
+// RhoBalanced calculates balanced reinforcement ratio
+func RhoBalanced(fc, fy float64) float64 {
+	beta1 := Beta1(fc)
+	epsilonTY := fy / Es
+	// c/d at balanced = εcu / (εcu + εy)
+	cb := EpsilonCU / (EpsilonCU + epsilonTY)
+	return 0.85 * beta1 * (fc / fy) * cb
+}
+
+// ModulusOfRupture calculates the modulus of rupture of concrete
+// ACI 318-19 Section 19.2.3.1: fr = 0.62λ√f'c
+func ModulusOfRupture(fc, lambda float64) float64 {
+	return 0.62 * lambda * math.Sqrt(fc)
+}
+
+// ModulusOfElasticity calculates the modulus of elasticity of concrete from
+// its density wc (kg/m³); a zero density gives the normal-weight value 4700√f'c
+// ACI 318-19 Section 19.2.2.1: Ec = wc^1.5·0.043√f'c for 1440 ≤ wc ≤ 2560
+func ModulusOfElasticity(fc, wc float64) float64 {
+	if wc <= 0 {
+		return 4700 * math.Sqrt(fc)
+	}
+	return math.Pow(wc, 1.5) * 0.043 * math.Sqrt(fc)
+}
+
+// Lambda calculates the lightweight concrete modification factor from the
+// equilibrium density wc (kg/m³)
+// ACI 318M-19 Table 19.2.4.1(a): λ = 0.000471wc, between 0.75 and 1.0
+func Lambda(wc float64) float64 {
+	if wc <= 0 || wc > LambdaNormalLimit {
+		return LambdaNormal
+	}
+	return math.Min(math.Max(0.000471*wc, LambdaAllLight), LambdaNormal)
+}
+
+// ShrinkageTemperatureRatio returns the minimum ratio of deformed shrinkage
+// and temperature reinforcement to the gross concrete area
+// ACI 318-19 Table 24.4.3.2: 0.0018 for all grades of deformed bars
+func ShrinkageTemperatureRatio(fy float64) float64 {
+	return 0.0018
+}
@@ -0,0 +1,144 @@
+// Package api runs the beam, batch and section engines on JSON requests and
+// returns their responses or typed errors, independent of the transport, for
+// the HTTP server and the WebAssembly build.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// Engine answers requests with a design code
+type Engine struct {
+	Code codes.DesignCode
+}
+
+// New returns an engine of the design code
+func New(code codes.DesignCode) *Engine {
+	return &Engine{Code: codes.OrDefault(code)}
+}
+
+// Response is the result of a successful request, as the structured output
+// of the commands
+type Response struct {
+	Code   string `json:"code"` // Design code
+	Input  any    `json:"input"`
+	Result any    `json:"result"`
+}
+
+// BatchRequest is the body of a batch request, the layout of a batch file
+type BatchRequest = batch.File
+
+// Beam designs a member for Mu, or analyzes its provided steel, from the
+// JSON of a batch member
+func (e *Engine) Beam(data []byte) (*Response, *Error) {
+	var m batch.Member
+	if err := Decode(data, &m); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, invalid(err, "")
+	}
+	result, err := batch.RunMember(m, e.Code)
+	if err != nil {
+		return nil, invalid(err, "")
+	}
+	return &Response{Code: e.Code.Name(), Input: m, Result: result}, nil
+}
+
+// Batch designs or analyzes every member of the JSON of a batch file.
+// Members that fail carry the error in their result, as in a batch file.
+func (e *Engine) Batch(data []byte) (*Response, *Error) {
+	var req BatchRequest
+	if err := Decode(data, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Members) == 0 {
+		return nil, &Error{Code: CodeInvalidInput, Message: "members must not be empty", Field: "members"}
+	}
+	for i, m := range req.Members {
+		if err := m.Validate(); err != nil {
+			return nil, invalid(err, fmt.Sprintf("members[%d]", i))
+		}
+	}
+	return &Response{Code: e.Code.Name(), Input: req, Result: batch.Run(req.Members, e.Code)}, nil
+}
+
+// AnalyzeSection analyzes the section of the JSON of a section file
+func (e *Engine) AnalyzeSection(data []byte) (*Response, *Error) {
+	var sec section.Section
+	if err := Decode(data, &sec); err != nil {
+		return nil, err
+	}
+	if err := sec.Prepare(); err != nil {
+		return nil, invalid(err, "")
+	}
+	sec.Code = e.Code
+	result, err := sec.Analyze()
+	if err != nil {
+		return nil, invalid(err, "")
+	}
+	return &Response{Code: e.Code.Name(), Input: &sec, Result: result}, nil
+}
+
+// Decode reads a single JSON object into v, rejecting unknown fields
+func Decode(data []byte, v any) *Error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil && dec.More() {
+		err = errors.New("request body must be a single JSON object")
+	}
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr):
+		// The request body itself is of the wrong type when no field is named
+		field, name := typeErr.Field, typeErr.Field
+		if field == "" {
+			field, name = requestBody, "request body"
+		}
+		return &Error{Code: CodeInvalidJSON, Field: field,
+			Message: fmt.Sprintf("%s must be %s, got %s", name, jsonType(typeErr.Type), typeErr.Value)}
+	case strings.HasPrefix(err.Error(), unknownField):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), unknownField), `"`)
+		return &Error{Code: CodeInvalidJSON, Message: "unknown field " + field, Field: field}
+	case len(bytes.TrimSpace(data)) == 0:
+		return &Error{Code: CodeInvalidJSON, Message: "request body is empty"}
+	}
+	return &Error{Code: CodeInvalidJSON, Message: err.Error()}
+}
+
+// requestBody is the field of the errors of the request body as a whole
+const requestBody = "body"
+
+// unknownField prefixes the decoding errors of fields not in the schema
+const unknownField = "json: unknown field "
+
+// jsonType returns the JSON type of a Go type, for decoding errors
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// Error codes of the error payloads, as listed in the specification
+const (
+	CodeInvalidJSON          = "invalid_json"           // The body is not JSON of the request schema
+	CodeInvalidInput         = "invalid_input"          // The input values are not valid for the design or analysis
+	CodeNoConvergence        = "no_convergence"         // An iterative solver did not converge
+	CodeNotFound             = "not_found"              // No route matches the method and path
+	CodeUnsupportedMediaType = "unsupported_media_type" // The body is not application/json
+	CodeTooLarge             = "too_large"              // The body exceeds the size limit of the server
+)
+
+// Error is the payload of a failed request, {"error": {...}}
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"` // The request field at fault, when known
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorResponse is the body of a failed request
+type ErrorResponse struct {
+	Error *Error `json:"error"`
+}
+
+// invalid returns the payload of an error of the design engines on the input
+// at field, naming the value at fault within it when the engine gives one,
+// e.g. members[2].width
+func invalid(err error, field string) *Error {
+	code := CodeInvalidInput
+	if errors.Is(err, beam.ErrNoConvergence) || errors.Is(err, section.ErrNoConvergence) {
+		code = CodeNoConvergence
+	}
+	var memberErr *batch.ValidationError
+	var sectionErr *section.ValidationError
+	switch {
+	case errors.As(err, &memberErr):
+		field = joinField(field, memberErr.Field)
+	case errors.As(err, &sectionErr):
+		field = joinField(field, sectionErr.Field)
+	}
+	return &Error{Code: code, Message: err.Error(), Field: field}
+}
+
+// joinField returns the path of a field within a parent field
+func joinField(parent, field string) string {
+	if parent == "" || field == "" {
+		return parent + field
+	}
+	return parent + "." + field
+}
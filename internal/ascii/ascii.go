@@ -0,0 +1,58 @@
+// Package ascii spells text output in 7-bit ASCII for plain terminals, log
+// collectors and tools such as awk and grep
+package ascii
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// replacements spell the symbols, box-drawing characters and accented
+// letters of text output in ASCII
+var replacements = map[rune]string{
+	// Box drawing and marks
+	'─': "-", '═': "=", '│': "|", '║': "|",
+	'┌': "+", '┐': "+", '└': "+", '┘': "+", '├': "+", '┤': "+",
+	'┼': "+", '┬': "+", '┴': "+",
+	'╔': "+", '╗': "+", '╚': "+", '╝': "+", '╠': "+", '╣': "+",
+	'█': "#", '░': ".", '▶': ">", '◄': "<", '●': "*", '•': "*",
+	'▲': "^", '▼': "v", 'Ø': "D",
+	'✓': "OK", '✗': "NG", '⚠': "!", '←': "<-", '→': "->",
+
+	// Greek letters
+	'α': "alpha", 'β': "beta", 'γ': "gamma", 'δ': "delta", 'ε': "eps",
+	'η': "eta", 'θ': "theta", 'λ': "lambda", 'μ': "u", 'ν': "nu",
+	'ξ': "xi", 'ρ': "rho", 'φ': "phi", 'ψ': "psi", 'Δ': "Delta",
+	'Σ': "Sum", 'Ω': "Omega",
+
+	// Math and units
+	'²': "2", '³': "3", '⁴': "4", '⁶': "6", '⁻': "-", '₁': "1",
+	'·': "*", '×': "x", '−': "-", '—': "-", '±': "+/-", '√': "sqrt",
+	'≈': "~", '≤': "<=", '≥': ">=", '°': "deg", 'ℓ': "l", 'ȳ': "y",
+	'©': "(c)",
+
+	// Letters of the Filipino and Spanish translations
+	'á': "a", 'é': "e", 'í': "i", 'ó': "o", 'ú': "u", 'ü': "u", 'ñ': "n",
+	'Á': "A", 'É': "E", 'Í': "I", 'Ó': "O", 'Ú': "U", 'Ü': "U", 'Ñ': "N",
+	'¿': "", '¡': "",
+}
+
+// Spell spells a string in ASCII, other characters as "?"
+func Spell(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(Rune(r))
+	}
+	return b.String()
+}
+
+// Rune spells one character in ASCII
+func Rune(r rune) string {
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	if s, ok := replacements[r]; ok {
+		return s
+	}
+	return "?"
+}
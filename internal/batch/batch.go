@@ -0,0 +1,286 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Member types
+const (
+	TypeSingly = "singly"
+	TypeDoubly = "doubly"
+)
+
+// Member is one rectangular beam of a batch. A member with As is analyzed
+// and checked against Mu, one without is designed for Mu.
+type Member struct {
+	ID   string `json:"id" yaml:"id"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"` // singly (default) or doubly
+
+	// Geometry (mm)
+	Width     float64 `json:"width" yaml:"width"`
+	Height    float64 `json:"height" yaml:"height"`
+	Cover     float64 `json:"cover,omitempty" yaml:"cover,omitempty"`           // To tension steel centroid, DefaultCover when zero
+	CoverComp float64 `json:"cover_comp,omitempty" yaml:"cover_comp,omitempty"` // d', DefaultCover when zero
+
+	// Materials (MPa), defaults when zero
+	Fc    float64 `json:"fc,omitempty" yaml:"fc,omitempty"`
+	Fy    float64 `json:"fy,omitempty" yaml:"fy,omitempty"`
+	Grade string  `json:"grade,omitempty" yaml:"grade,omitempty"` // Steel grade in place of fy
+
+	// Lightweight concrete modification factor λ, 1.0 when zero
+	Lambda float64 `json:"lambda,omitempty" yaml:"lambda,omitempty"`
+
+	// Factored actions (kN-m, kN)
+	Mu float64 `json:"mu,omitempty" yaml:"mu,omitempty"`
+	Vu float64 `json:"vu,omitempty" yaml:"vu,omitempty"`
+
+	// Provided reinforcement (mm²) for analysis
+	As  float64 `json:"as,omitempty" yaml:"as,omitempty"`
+	Asc float64 `json:"asc,omitempty" yaml:"asc,omitempty"`
+
+	// Stirrups for shear design
+	StirrupDia  float64 `json:"stirrup_dia,omitempty" yaml:"stirrup_dia,omitempty"` // mm
+	StirrupLegs int     `json:"stirrup_legs,omitempty" yaml:"stirrup_legs,omitempty"`
+	Fyt         float64 `json:"fyt,omitempty" yaml:"fyt,omitempty"` // MPa, fy when zero
+}
+
+// Defaults of omitted member properties
+const (
+	DefaultCover = 65.0  // mm
+	DefaultFc    = 28.0  // MPa
+	DefaultFy    = 415.0 // MPa
+)
+
+// File is the layout of a JSON or YAML batch file
+type File struct {
+	Members []Member `json:"members" yaml:"members"`
+}
+
+// Stdin is the file name that reads a batch from standard input as JSON
+const Stdin = "-"
+
+// Load reads the members of a batch from a CSV, JSON or YAML file (selected
+// by the .csv and .yaml/.yml extensions), or from JSON on standard input when
+// path is Stdin. A CSV file has a header row naming the member fields, e.g.
+// id,width,height,mu,vu.
+func Load(path string) ([]Member, error) {
+	var data []byte
+	var err error
+	if path == Stdin {
+		data, err = io.ReadAll(os.Stdin)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var members []Member
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		members, err = ReadCSV(strings.NewReader(string(data)))
+	case ".yaml", ".yml":
+		var file File
+		err = yaml.Unmarshal(data, &file)
+		members = file.Members
+	default:
+		var file File
+		err = json.Unmarshal(data, &file)
+		members = file.Members
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%s defines no members", path)
+	}
+	seen := make(map[string]bool)
+	for i := range members {
+		m := &members[i]
+		if strings.TrimSpace(m.ID) == "" {
+			m.ID = strconv.Itoa(i + 1)
+		}
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[m.ID] {
+			return nil, fmt.Errorf("duplicate member %q", m.ID)
+		}
+		seen[m.ID] = true
+	}
+	return members, nil
+}
+
+// ReadCSV reads members from CSV rows headed by the member field names
+func ReadCSV(r io.Reader) ([]Member, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := (&Member{}).field(name); !ok {
+			return nil, fmt.Errorf("unknown column %q", header[i])
+		}
+		header[i] = name
+	}
+
+	var members []Member
+	for n, row := range rows[1:] {
+		var m Member
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if err := m.set(header[i], value); err != nil {
+				return nil, fmt.Errorf("row %d: %w", n+2, err)
+			}
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// ParseMember reads a member from comma-separated name=value pairs of the
+// CSV column names, e.g. "width=300,height=500,fc=28"
+func ParseMember(spec string) (Member, error) {
+	var m Member
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return m, fmt.Errorf("expected name=value, got %q", pair)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := m.field(name); !ok {
+			return m, fmt.Errorf("unknown field %q", name)
+		}
+		if err := m.set(name, strings.TrimSpace(value)); err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+// field returns a pointer to the member field of a CSV column
+func (m *Member) field(name string) (any, bool) {
+	fields := map[string]any{
+		"id":           &m.ID,
+		"type":         &m.Type,
+		"width":        &m.Width,
+		"height":       &m.Height,
+		"cover":        &m.Cover,
+		"cover_comp":   &m.CoverComp,
+		"fc":           &m.Fc,
+		"fy":           &m.Fy,
+		"grade":        &m.Grade,
+		"lambda":       &m.Lambda,
+		"mu":           &m.Mu,
+		"vu":           &m.Vu,
+		"as":           &m.As,
+		"asc":          &m.Asc,
+		"stirrup_dia":  &m.StirrupDia,
+		"stirrup_legs": &m.StirrupLegs,
+		"fyt":          &m.Fyt,
+	}
+	f, ok := fields[name]
+	return f, ok
+}
+
+// set parses a CSV value into the member field of a column
+func (m *Member) set(name, value string) error {
+	f, _ := m.field(name)
+	switch p := f.(type) {
+	case *string:
+		*p = value
+	case *float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", name, value)
+		}
+		*p = v
+	case *int:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", name, value)
+		}
+		*p = v
+	}
+	return nil
+}
+
+// Validate checks the geometry, type and actions of the member
+func (m Member) Validate() error {
+	switch {
+	case m.Width <= 0:
+		return m.invalid("width", "width and height must be positive")
+	case m.Height <= 0:
+		return m.invalid("height", "width and height must be positive")
+	}
+	switch strings.ToLower(m.Type) {
+	case "", TypeSingly, TypeDoubly:
+	default:
+		return m.invalid("type", "unknown type %q (use singly or doubly)", m.Type)
+	}
+	if m.Grade != "" && m.Fy != 0 {
+		return m.invalid("grade", "give either fy or grade, not both")
+	}
+	if m.As <= 0 && m.Mu <= 0 {
+		return m.invalid("mu", "give Mu to design or As to analyze")
+	}
+	if m.Lambda < 0 || m.Lambda > 1 {
+		return m.invalid("lambda", "lambda must be between 0 and 1, got %g", m.Lambda)
+	}
+	for _, v := range []struct {
+		field string
+		value float64
+	}{{"mu", m.Mu}, {"vu", m.Vu}, {"as", m.As}, {"asc", m.Asc}} {
+		if v.value < 0 {
+			return m.invalid(v.field, "Mu, Vu, As and Asc must not be negative")
+		}
+	}
+	return nil
+}
+
+// ValidationError is an invalid value of a member
+type ValidationError struct {
+	msg   string
+	Field string // JSON name of the value at fault, e.g. width
+}
+
+func (e *ValidationError) Error() string {
+	return e.msg
+}
+
+// invalid returns the error of an invalid value of the member at field
+func (m Member) invalid(field, format string, a ...any) error {
+	return &ValidationError{msg: fmt.Sprintf("member %q: ", m.ID) + fmt.Sprintf(format, a...), Field: field}
+}
+
+// IsDoubly reports whether the member is doubly reinforced
+func (m Member) IsDoubly() bool {
+	return strings.ToLower(m.Type) == TypeDoubly
+}
@@ -0,0 +1,143 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+)
+
+// MinClearBarSpacing is the least clear spacing between parallel bars in a
+// layer (mm); the bar diameter governs when larger
+const MinClearBarSpacing = 25.0
+
+// OptimizeOptions bound the search of the optimizer
+type OptimizeOptions struct {
+	Widths  []float64 // Section widths tried (mm)
+	Heights []float64 // Section heights tried (mm)
+	MinBars int       // Fewest tension bars in a layout
+	MaxBars int       // Most tension bars in a layout
+
+	Length float64 // Member length of the quantities (m)
+	Costs  cost.UnitCosts
+}
+
+// Layout is a single layer of identical tension bars
+type Layout struct {
+	Bar          rebar.Bar `json:"bar"`
+	Count        int       `json:"count"`
+	Area         float64   `json:"area"`          // Provided area (mm²)
+	ClearSpacing float64   `json:"clear_spacing"` // Clear spacing between bars (mm)
+}
+
+// Label returns the layout as printed in reports, e.g. "4-φ20mm"
+func (l Layout) Label() string {
+	return fmt.Sprintf("%d-%s", l.Count, l.Bar.Label())
+}
+
+// Candidate is an adequate design of the search with its cost
+type Candidate struct {
+	Layout     Layout          `json:"layout"`
+	Result     Result          `json:"result"` // Analysis of the provided layout
+	Quantities cost.Quantities `json:"quantities"`
+}
+
+// Optimize designs the singly reinforced base member for every width and
+// height, provides each required steel area with one layer of bars of every
+// flexural size of the catalog, and returns the adequate candidates from the
+// cheapest up along with the number of sections tried
+func Optimize(base Member, catalog *rebar.Catalog, opts OptimizeOptions, code codes.DesignCode) ([]Candidate, int, error) {
+	if base.IsDoubly() {
+		return nil, 0, fmt.Errorf("the optimizer designs singly reinforced sections only")
+	}
+	if base.Mu <= 0 {
+		return nil, 0, fmt.Errorf("give Mu to optimize")
+	}
+	if base.As > 0 || base.Asc > 0 {
+		return nil, 0, fmt.Errorf("the optimizer selects the steel, do not give As")
+	}
+	if len(opts.Widths) == 0 || len(opts.Heights) == 0 {
+		return nil, 0, fmt.Errorf("give the widths and heights to search")
+	}
+	if opts.MinBars < 1 || opts.MaxBars < opts.MinBars {
+		return nil, 0, fmt.Errorf("bar counts must satisfy 1 ≤ min ≤ max")
+	}
+
+	var candidates []Candidate
+	tried := 0
+	for _, width := range opts.Widths {
+		for _, height := range opts.Heights {
+			tried++
+			m := base
+			m.Width, m.Height = width, height
+			design, err := RunMember(m, code)
+			if err != nil || !design.IsAdequate {
+				continue
+			}
+			for _, bar := range catalog.FlexuralBars() {
+				layout, ok := fitLayout(m, bar, design.As, opts)
+				if !ok {
+					continue
+				}
+				m.As = layout.Area
+				r, err := RunMember(m, code)
+				if err != nil || !r.IsAdequate {
+					continue
+				}
+				candidates = append(candidates, Candidate{
+					Layout:     layout,
+					Result:     *r,
+					Quantities: r.Quantities(opts.Length, opts.Costs),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Quantities.Total < candidates[j].Quantities.Total
+	})
+	return candidates, tried, nil
+}
+
+// BarLayout returns the layout of one flexural bar size of the catalog that
+// provides asRequired with the least area in one layer inside the stirrups of
+// the member, preferring fewer bars on ties. When no size fits one layer it
+// returns the fewest bars of the largest size and false.
+func (m Member) BarLayout(catalog *rebar.Catalog, asRequired float64) (Layout, bool) {
+	opts := OptimizeOptions{MinBars: 2, MaxBars: math.MaxInt32}
+	var best Layout
+	found := false
+	for _, bar := range catalog.FlexuralBars() {
+		l, ok := fitLayout(m, bar, asRequired, opts)
+		if ok && (!found || l.Area < best.Area || (l.Area == best.Area && l.Count < best.Count)) {
+			best, found = l, true
+		}
+	}
+	if found {
+		return best, true
+	}
+	bars := catalog.FlexuralBars()
+	l, _ := fitLayout(m, bars[len(bars)-1], asRequired, opts)
+	return l, false
+}
+
+// fitLayout returns the fewest bars of a size providing asRequired, when
+// they fit in one layer inside the stirrups of the member
+func fitLayout(m Member, bar rebar.Bar, asRequired float64, opts OptimizeOptions) (Layout, bool) {
+	count := max(int(math.Ceil(asRequired/bar.Area)), opts.MinBars)
+	if count > opts.MaxBars {
+		return Layout{}, false
+	}
+	l := Layout{Bar: bar, Count: count, Area: float64(count) * bar.Area}
+	if count == 1 {
+		return l, true
+	}
+	stirrup := orDefault(m.StirrupDia, beam.DefaultStirrupDiameter)
+	clear := m.Width - 2*(beam.DefaultClearCover+stirrup) - float64(count)*bar.Diameter
+	l.ClearSpacing = clear / float64(count-1)
+	return l, l.ClearSpacing >= math.Max(MinClearBarSpacing, bar.Diameter)
+}
@@ -0,0 +1,245 @@
+package batch
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+)
+
+// Modes of a member result
+const (
+	ModeDesign   = "design"
+	ModeAnalysis = "analysis"
+)
+
+// Result holds the outcome of one member of a batch
+type Result struct {
+	Member Member `json:"member"`
+	Mode   string `json:"mode"` // design or analysis
+
+	// Resolved materials (MPa)
+	Fc float64 `json:"fc"`
+	Fy float64 `json:"fy"`
+
+	// Flexure
+	As       float64 `json:"as"`  // Required (design) or provided (analysis) tension steel (mm²)
+	Asc      float64 `json:"asc"` // Required or provided compression steel (mm²)
+	EpsilonT float64 `json:"epsilon_t"`
+	Phi      float64 `json:"phi"`
+	PhiMn    float64 `json:"phi_mn"`  // kN-m
+	Rho      float64 `json:"rho"`     // Tension steel ratio As/(b·d)
+	RhoMax   float64 `json:"rho_max"` // Maximum ratio for a tension-controlled singly reinforced section
+
+	// Detailed results of the member, by type and mode
+	Design         *beam.DesignResult         `json:"design,omitempty"`
+	Analysis       *beam.AnalysisResult       `json:"analysis,omitempty"`
+	DoublyDesign   *beam.DoublyDesignResult   `json:"doubly_design,omitempty"`
+	DoublyAnalysis *beam.DoublyAnalysisResult `json:"doubly_analysis,omitempty"`
+	Shear          *beam.ShearResult          `json:"shear,omitempty"`
+
+	IsAdequate    bool   `json:"adequate"`
+	Message       string `json:"message"`
+	Error         string `json:"error,omitempty"`
+	NoConvergence bool   `json:"no_convergence,omitempty"` // The error is a solver that did not converge
+}
+
+// Failed returns the result of a member whose design or analysis failed with err
+func Failed(m Member, err error) *Result {
+	return &Result{Member: m, Error: err.Error(), NoConvergence: errors.Is(err, beam.ErrNoConvergence)}
+}
+
+// Status returns OK, NG or ERROR for the summary of the member
+func (r Result) Status() string {
+	switch {
+	case r.Error != "":
+		return "ERROR"
+	case r.IsAdequate:
+		return "OK"
+	}
+	return "NG"
+}
+
+// Run designs or analyzes every member with the design code. Members that
+// fail carry the error in their result instead of stopping the batch.
+func Run(members []Member, code codes.DesignCode) []Result {
+	results := make([]Result, len(members))
+	for i, m := range members {
+		r, err := RunMember(m, code)
+		if err != nil {
+			r = Failed(m, err)
+		}
+		r.Log()
+		results[i] = *r
+	}
+	return results
+}
+
+// Log records the result of a member, as a warning when it failed
+func (r Result) Log() {
+	if r.Error != "" {
+		slog.Warn("member failed", "member", r)
+		return
+	}
+	slog.Info("member", "member", r)
+}
+
+// LogValue returns the inputs and outcome of the result for the log
+func (r Result) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", r.Member.ID),
+		slog.String("mode", r.Mode),
+		slog.Float64("mu", r.Member.Mu),
+		slog.Float64("as", r.As),
+		slog.Float64("phi_mn", r.PhiMn),
+		slog.String("status", r.Status()),
+	}
+	if r.Error != "" {
+		attrs = append(attrs, slog.String("error", r.Error))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// RunMember designs the member for Mu, or analyzes its provided steel and
+// checks it against Mu, then designs its stirrups for Vu when given
+func RunMember(m Member, code codes.DesignCode) (*Result, error) {
+	slog.Debug("member input", "member", m)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	cover := orDefault(m.Cover, DefaultCover)
+	coverComp := orDefault(m.CoverComp, DefaultCover)
+	fc := orDefault(m.Fc, DefaultFc)
+	fy := orDefault(m.Fy, DefaultFy)
+	if m.Grade != "" {
+		g, err := rebar.FindGrade(m.Grade)
+		if err != nil {
+			return nil, err
+		}
+		fy = g.Fy
+	}
+
+	r := &Result{Member: m, Fc: fc, Fy: fy, Mode: ModeDesign}
+	if m.As > 0 {
+		r.Mode = ModeAnalysis
+	}
+	stirrups := beam.StirrupOptions{Diameter: m.StirrupDia, Legs: m.StirrupLegs, Fyt: m.Fyt}
+
+	if m.IsDoubly() {
+		b := beam.NewDoublyReinforced(m.Width, m.Height, cover, coverComp, fc, fy)
+		b.Code = code
+		b.Lambda = m.Lambda
+		if r.Mode == ModeAnalysis {
+			res, err := b.Analyze(m.As, m.Asc)
+			if err != nil {
+				return nil, err
+			}
+			r.DoublyAnalysis = res
+			r.As, r.Asc, r.EpsilonT, r.Phi, r.PhiMn = m.As, m.Asc, res.EpsilonT, res.Phi, res.PhiMn
+			r.Rho, r.RhoMax = res.Rho, res.RhoMax
+			r.checkCapacity(res.Message)
+		} else {
+			res, err := b.Design(m.Mu)
+			if err != nil {
+				return nil, err
+			}
+			r.DoublyDesign = res
+			r.As, r.Asc, r.EpsilonT, r.Phi, r.PhiMn = res.AsTotal, res.AscRequired, res.EpsilonT, res.Phi, res.PhiMn
+			r.Rho, r.RhoMax = res.AsTotal/(b.Width*b.EffectiveDepth), res.RhoMax
+			r.IsAdequate, r.Message = res.IsAdequate, res.Message
+			b.As, b.Asc = res.AsTotal, res.AscRequired
+		}
+		if m.Vu > 0 {
+			shear, err := b.DesignShear(m.Vu, stirrups)
+			if err != nil {
+				return nil, err
+			}
+			r.addShear(shear)
+		}
+		return r, nil
+	}
+
+	b := beam.NewSinglyReinforced(m.Width, m.Height, cover, fc, fy)
+	b.Code = code
+	b.Lambda = m.Lambda
+	if r.Mode == ModeAnalysis {
+		res, err := b.Analyze(m.As)
+		if err != nil {
+			return nil, err
+		}
+		r.Analysis = res
+		r.As, r.EpsilonT, r.Phi, r.PhiMn = m.As, res.EpsilonT, res.Phi, res.PhiMn
+		r.Rho, r.RhoMax = res.Rho, res.RhoMax
+		r.checkCapacity(res.Message)
+	} else {
+		res, err := b.Design(m.Mu)
+		if err != nil {
+			return nil, err
+		}
+		r.Design = res
+		r.As, r.EpsilonT, r.Phi, r.PhiMn = res.AsRequired, res.EpsilonT, res.Phi, res.PhiMn
+		r.Rho, r.RhoMax = res.RhoRequired, res.RhoMax
+		r.IsAdequate, r.Message = res.IsAdequate, res.Message
+		b.As = res.AsRequired
+	}
+	if m.Vu > 0 {
+		shear, err := b.DesignShear(m.Vu, stirrups)
+		if err != nil {
+			return nil, err
+		}
+		r.addShear(shear)
+	}
+	return r, nil
+}
+
+// checkCapacity sets the adequacy of an analyzed member from φMn ≥ Mu
+func (r *Result) checkCapacity(message string) {
+	r.IsAdequate = r.PhiMn >= r.Member.Mu
+	r.Message = message
+	if !r.IsAdequate {
+		r.Message = "φMn < Mu | " + message
+	}
+}
+
+// addShear records the stirrup design, which must also be adequate
+func (r *Result) addShear(shear *beam.ShearResult) {
+	r.Shear = shear
+	if !shear.IsAdequate {
+		r.IsAdequate = false
+		r.Message += " | " + shear.Message
+	}
+}
+
+// orDefault returns v, or def when v is unset (zero)
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// Utilization returns the demand-capacity ratio Mu/φMn
+func (r Result) Utilization() float64 {
+	if r.PhiMn <= 0 {
+		return 0
+	}
+	return r.Member.Mu / r.PhiMn
+}
+
+// Quantities returns the material quantities and cost of the member over a
+// length (m), with its stirrups when shear was designed
+func (r Result) Quantities(length float64, costs cost.UnitCosts) cost.Quantities {
+	m := r.Member
+	var barArea, barLength, spacing float64
+	if s := r.Shear; s != nil && s.Spacing > 0 {
+		db := orDefault(m.StirrupDia, beam.DefaultStirrupDiameter)
+		barArea = math.Pi * db * db / 4
+		barLength = cost.StirrupLength(m.Width, m.Height, beam.DefaultClearCover, db)
+		spacing = s.Spacing
+	}
+	return cost.RectangularBeam(m.Width, m.Height, length, r.As+r.Asc, barArea, barLength, spacing, costs)
+}
@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+)
+
+// SensitivityInputs are the inputs perturbed by Sensitivity: f'c, fy, the
+// effective depth d (through the height, at the same cover), the cover to the
+// tension steel (at the same height) and Mu
+var SensitivityInputs = []string{"fc", "fy", "d", "cover", "mu"}
+
+// Sensitivity is the effect on the output of a member of decreasing and
+// increasing one input by the perturbation
+type Sensitivity struct {
+	Input string  `json:"input"`
+	Base  float64 `json:"base"` // Input value of the base member
+	Low   float64 `json:"low"`  // Decreased input value
+	High  float64 `json:"high"` // Increased input value
+
+	LowOutput  float64 `json:"low_output"`
+	HighOutput float64 `json:"high_output"`
+	LowChange  float64 `json:"low_change"`  // Change of the output at the low value (%)
+	HighChange float64 `json:"high_change"` // Change of the output at the high value (%)
+	Swing      float64 `json:"swing"`       // |HighChange - LowChange| (%)
+
+	Error string `json:"error,omitempty"`
+}
+
+// SensitivityOutput names the output of a member compared by Sensitivity:
+// the required As of a design, or φMn of an analysis of provided steel
+func SensitivityOutput(r *Result) string {
+	if r.Mode == ModeAnalysis {
+		return "φMn"
+	}
+	return "As"
+}
+
+// output returns the value of the output compared by Sensitivity
+func (r *Result) output() float64 {
+	if r.Mode == ModeAnalysis {
+		return r.PhiMn
+	}
+	return r.As
+}
+
+// Sensitivity runs the member, then each input with the member decreased and
+// increased by a fraction of its value, e.g. 0.1 for ±10%, and returns the
+// base result with the effects ranked from the largest swing of the output
+func (m Member) Sensitivity(inputs []string, fraction float64, code codes.DesignCode) (*Result, []Sensitivity, error) {
+	if fraction <= 0 || fraction >= 1 {
+		return nil, nil, fmt.Errorf("perturbation must be between 0 and 100%%, got %g%%", fraction*100)
+	}
+	for _, input := range inputs {
+		if !slices.Contains(SensitivityInputs, strings.ToLower(input)) {
+			return nil, nil, fmt.Errorf("unknown input %q (use %s)", input, strings.Join(SensitivityInputs, ", "))
+		}
+	}
+	base, err := RunMember(m, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	output := base.output()
+	if output <= 0 {
+		return nil, nil, fmt.Errorf("base member has no %s to compare", SensitivityOutput(base))
+	}
+
+	var effects []Sensitivity
+	for _, input := range inputs {
+		value, set := m.perturbed(strings.ToLower(input), base)
+		s := Sensitivity{Input: input, Base: value, Low: value * (1 - fraction), High: value * (1 + fraction)}
+		for i, v := range []float64{s.Low, s.High} {
+			r, err := RunMember(set(v), code)
+			if err == nil && r.Error != "" {
+				err = fmt.Errorf("%s", r.Error)
+			}
+			if err != nil {
+				s.Error = fmt.Sprintf("%s = %g: %v", input, v, err)
+				break
+			}
+			change := (r.output()/output - 1) * 100
+			if i == 0 {
+				s.LowOutput, s.LowChange = r.output(), change
+			} else {
+				s.HighOutput, s.HighChange = r.output(), change
+			}
+		}
+		if s.Error == "" {
+			s.Swing = math.Abs(s.HighChange - s.LowChange)
+		}
+		effects = append(effects, s)
+	}
+
+	sort.SliceStable(effects, func(i, j int) bool {
+		return effects[i].Swing > effects[j].Swing
+	})
+	return base, effects, nil
+}
+
+// perturbed returns the base value of an input of the member and a function
+// returning the member with the input set to another value
+func (m Member) perturbed(input string, base *Result) (float64, func(float64) Member) {
+	cover := orDefault(m.Cover, DefaultCover)
+	switch input {
+	case "fc":
+		return base.Fc, func(v float64) Member { m.Fc = v; return m }
+	case "fy":
+		return base.Fy, func(v float64) Member { m.Fy, m.Grade = v, ""; return m }
+	case "d":
+		return m.Height - cover, func(v float64) Member { m.Height = v + cover; return m }
+	case "cover":
+		return cover, func(v float64) Member { m.Cover = v; return m }
+	}
+	return m.Mu, func(v float64) Member { m.Mu = v; return m }
+}
@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxSweepValues limits the number of values of one swept parameter
+const maxSweepValues = 1000
+
+// Parameter is a member field varied over a list of values
+type Parameter struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+}
+
+// ParseParameter reads a swept parameter as name=start:stop:step or as a
+// list name=v1,v2,v3, e.g. "height=400:700:50" or "fc=21,28,35"
+func ParseParameter(spec string) (Parameter, error) {
+	name, values, ok := strings.Cut(spec, "=")
+	if !ok {
+		return Parameter{}, fmt.Errorf("expected name=values, got %q", spec)
+	}
+	p := Parameter{Name: strings.ToLower(strings.TrimSpace(name))}
+	f, ok := (&Member{}).field(p.Name)
+	if !ok {
+		return p, fmt.Errorf("unknown field %q", p.Name)
+	}
+	if _, ok := f.(*float64); !ok {
+		return p, fmt.Errorf("field %q is not numeric", p.Name)
+	}
+
+	if parts := strings.Split(values, ":"); len(parts) == 3 {
+		var bounds [3]float64
+		for i, s := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return p, fmt.Errorf("%s: invalid number %q", p.Name, s)
+			}
+			bounds[i] = v
+		}
+		start, stop, step := bounds[0], bounds[1], bounds[2]
+		if step <= 0 || stop < start {
+			return p, fmt.Errorf("%s: range needs start ≤ stop and a positive step", p.Name)
+		}
+		if (stop-start)/step >= maxSweepValues {
+			return p, fmt.Errorf("%s: range has more than %d values", p.Name, maxSweepValues)
+		}
+		for i := 0; ; i++ {
+			v := start + float64(i)*step
+			if v > stop+step*1e-9 {
+				break
+			}
+			p.Values = append(p.Values, math.Round(v*1e9)/1e9)
+		}
+		return p, nil
+	}
+
+	for _, s := range strings.Split(values, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return p, fmt.Errorf("%s: invalid number %q", p.Name, s)
+		}
+		p.Values = append(p.Values, v)
+	}
+	return p, nil
+}
+
+// With returns a copy of the member with the numeric field of a parameter set to v
+func (m Member) With(name string, v float64) Member {
+	if f, ok := m.field(name); ok {
+		if p, ok := f.(*float64); ok {
+			*p = v
+		}
+	}
+	return m
+}
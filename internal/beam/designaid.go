@@ -0,0 +1,60 @@
+package beam
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// DesignAidPoint is a point of the classical design aid curves of a
+// rectangular section with tension steel only, Rn = Mn/bd² against ρ
+type DesignAidPoint struct {
+	Rho      float64 `json:"rho"`       // ρ = As/bd
+	Rn       float64 `json:"rn"`        // Mn/bd² (MPa)
+	PhiRn    float64 `json:"phi_rn"`    // φMn/bd² (MPa)
+	EpsilonT float64 `json:"epsilon_t"` // Net tensile strain
+	Phi      float64 `json:"phi"`       // Strength reduction factor for εt
+}
+
+// DesignAidAt returns the design aid point of a steel ratio for f'c and fy
+// (MPa): Rn = ρ·fy·(1 − ρ·fy/(2·0.85f'c)), with φ from the net tensile
+// strain of the steel. Above the balanced ratio the steel does not yield
+// and the point does not apply.
+func DesignAidAt(code codes.DesignCode, fc, fy, rho float64) DesignAidPoint {
+	code = codes.OrDefault(code)
+	fyd := code.DesignYieldStrength(fy)
+	fcd := code.Alpha1(fc) * fc
+	p := DesignAidPoint{Rho: rho}
+	p.Rn = rho * fyd * (1 - rho*fyd/(2*fcd))
+
+	// Neutral axis depth as a fraction of d, c/d = ρ·fy/(0.85f'c·β1)
+	c := rho * fyd / (fcd * code.Beta1(fc))
+	p.Phi = code.PhiFlexure()
+	if c > 0 {
+		p.EpsilonT = code.EpsilonCU(fc) * (1 - c) / c
+		p.Phi = code.Phi(p.EpsilonT, fy, nscp.TransverseTied)
+	}
+	p.PhiRn = p.Phi * p.Rn
+	return p
+}
+
+// DesignAid returns n points of the design aid curves for f'c and fy (MPa)
+// at steel ratios evenly spaced up to the balanced ratio
+func DesignAid(code codes.DesignCode, fc, fy float64, n int) []DesignAidPoint {
+	code = codes.OrDefault(code)
+	rhoB := code.RhoBalanced(fc, fy)
+	points := make([]DesignAidPoint, n)
+	for i := range points {
+		points[i] = DesignAidAt(code, fc, fy, rhoB*float64(i+1)/float64(n))
+	}
+	return points
+}
+
+// TensionControlledRatio returns the largest steel ratio of a
+// tension-controlled section for f'c and fy (MPa), at which εt reaches the
+// tension-controlled limit
+func TensionControlledRatio(code codes.DesignCode, fc, fy float64) float64 {
+	code = codes.OrDefault(code)
+	epsCU := code.EpsilonCU(fc)
+	c := epsCU / (epsCU + code.TensionControlledStrain(fc, fy))
+	return c * code.Alpha1(fc) * fc * code.Beta1(fc) / code.DesignYieldStrength(fy)
+}
@@ -1,31 +1,56 @@
 package beam
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 
+	"github.com/alexiusacademia/gorcb/internal/codes"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/solver"
+)
+
+// ErrNoConvergence is returned when the neutral axis iteration does not
+// reach force equilibrium
+var ErrNoConvergence = errors.New("neutral axis iteration did not converge")
+
+// maxIterations bounds the neutral axis iteration of the doubly reinforced
+// analysis, and cTolerance is the change in c (mm) at which it is converged,
+// unless solver.Default sets others
+const (
+	maxIterations = 50
+	cTolerance    = 0.01
 )
 
 // DoublyReinforced represents a doubly reinforced rectangular beam section
 type DoublyReinforced struct {
 	// Geometry (mm)
-	Width          float64 // b - beam width
-	Height         float64 // h - total depth
-	EffectiveDepth float64 // d - effective depth (to centroid of tension steel)
-	Cover          float64 // concrete cover to centroid of tension reinforcement
-	CoverComp      float64 // d' - cover to centroid of compression reinforcement
+	Width          float64 `json:"width"`           // b - beam width
+	Height         float64 `json:"height"`          // h - total depth
+	EffectiveDepth float64 `json:"effective_depth"` // d - effective depth (to centroid of tension steel)
+	Cover          float64 `json:"cover"`           // concrete cover to centroid of tension reinforcement
+	CoverComp      float64 `json:"cover_comp"`      // d' - cover to centroid of compression reinforcement
 
 	// Materials (MPa)
-	Fc float64 // f'c - concrete compressive strength
-	Fy float64 // fy - steel yield strength
+	Fc float64 `json:"fc"` // f'c - concrete compressive strength
+	Fy float64 `json:"fy"` // fy - steel yield strength
+
+	// Lightweight concrete modification factor λ (1.0 for normal-weight when zero)
+	Lambda float64 `json:"lambda"`
+
+	// Concrete density wc (kg/m³) for Ec, zero for normal-weight concrete
+	Density float64 `json:"density"`
 
 	// Loading (kN-m)
-	Mu float64 // Factored moment
+	Mu float64 `json:"mu"` // Factored moment
 
 	// Reinforcement (mm²)
-	As  float64 // Area of tension reinforcement
-	Asc float64 // Area of compression reinforcement
+	As  float64 `json:"as"`  // Area of tension reinforcement
+	Asc float64 `json:"asc"` // Area of compression reinforcement
+
+	// Design code (defaults to NSCP 2015 when nil)
+	Code codes.DesignCode `json:"-"`
 }
 
 // NewDoublyReinforced creates a new doubly reinforced beam
@@ -44,47 +69,51 @@ func NewDoublyReinforced(width, height, cover, coverComp, fc, fy float64) *Doubl
 // DoublyDesignResult holds the results of doubly reinforced beam design
 type DoublyDesignResult struct {
 	// Is doubly reinforced needed?
-	RequiresCompSteel bool
+	RequiresCompSteel bool `json:"requires_comp_steel"`
 
 	// Moment components
-	Mu1 float64 // Moment resisted by tension steel with concrete (kN-m)
-	Mu2 float64 // Moment resisted by steel couple (kN-m)
+	Mu1 float64 `json:"mu1"` // Moment resisted by tension steel with concrete (kN-m)
+	Mu2 float64 `json:"mu2"` // Moment resisted by steel couple (kN-m)
 
 	// Reinforcement
-	As1        float64 // Tension steel for concrete compression (mm²)
-	As2        float64 // Additional tension steel for compression steel (mm²)
-	AsTotal    float64 // Total tension reinforcement (mm²)
-	AscRequired float64 // Required compression reinforcement (mm²)
+	As1         float64 `json:"as1"`          // Tension steel for concrete compression (mm²)
+	As2         float64 `json:"as2"`          // Additional tension steel for compression steel (mm²)
+	AsTotal     float64 `json:"as_total"`     // Total tension reinforcement (mm²)
+	AscRequired float64 `json:"asc_required"` // Required compression reinforcement (mm²)
 
 	// Limits
-	AsMin float64 // Minimum tension steel (mm²)
-	AsMax float64 // Maximum tension steel for singly reinforced (mm²)
+	AsMin float64 `json:"as_min"` // Minimum tension steel (mm²)
+	AsMax float64 `json:"as_max"` // Maximum tension steel for singly reinforced (mm²)
+
+	// Alternative to the minimum steel when As,min governs
+	AsStrength    float64 `json:"as_strength"`    // Tension steel required by analysis alone (mm²)
+	AsAlternative float64 `json:"as_alternative"` // Steel that may be provided in lieu of As,min, zero if not applicable (mm²)
 
 	// Reinforcement ratios
-	RhoMin      float64
-	RhoMax      float64
-	RhoBalanced float64
+	RhoMin      float64 `json:"rho_min"`
+	RhoMax      float64 `json:"rho_max"`
+	RhoBalanced float64 `json:"rho_balanced"`
 
 	// Section properties at max capacity (singly)
-	AMax float64 // Maximum a for tension-controlled (mm)
-	CMax float64 // Maximum c for tension-controlled (mm)
+	AMax float64 `json:"a_max"` // Maximum a for tension-controlled (mm)
+	CMax float64 `json:"c_max"` // Maximum c for tension-controlled (mm)
 
 	// Compression steel stress
-	FscStress    float64 // Actual stress in compression steel (MPa)
-	CompYielded  bool    // Whether compression steel has yielded
+	FscStress   float64 `json:"fsc_stress"`   // Actual stress in compression steel (MPa)
+	CompYielded bool    `json:"comp_yielded"` // Whether compression steel has yielded
 
 	// Strains
-	EpsilonT  float64 // Tensile strain
-	EpsilonSc float64 // Compression steel strain
+	EpsilonT  float64 `json:"epsilon_t"`  // Tensile strain
+	EpsilonSc float64 `json:"epsilon_sc"` // Compression steel strain
 
 	// Capacity
-	Phi   float64 // Strength reduction factor
-	PhiMn float64 // Design moment capacity (kN-m)
+	Phi   float64 `json:"phi"`    // Strength reduction factor
+	PhiMn float64 `json:"phi_mn"` // Design moment capacity (kN-m)
 
 	// Status
-	IsTensionControlled bool
-	IsAdequate          bool
-	Message             string
+	IsTensionControlled bool   `json:"tension_controlled"`
+	IsAdequate          bool   `json:"adequate"`
+	Message             string `json:"message"`
 }
 
 // Design calculates required reinforcement for a doubly reinforced beam
@@ -101,24 +130,30 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 		return nil, fmt.Errorf("invalid compression cover: d'=%.2f", b.CoverComp)
 	}
 
+	code := codes.OrDefault(b.Code)
+	fy := code.DesignYieldStrength(b.Fy)
+	fcd := code.Alpha1(b.Fc) * b.Fc // Stress block intensity (0.85f'c)
+	epsCU := code.EpsilonCU(b.Fc)
 	result := &DoublyDesignResult{}
-	beta1 := nscp.Beta1(b.Fc)
+	beta1 := code.Beta1(b.Fc)
 
 	// Calculate reinforcement ratio limits
-	result.RhoMin = nscp.RhoMin(b.Fc, b.Fy)
-	result.RhoMax = nscp.RhoMax(b.Fc, b.Fy)
-	result.RhoBalanced = nscp.RhoBalanced(b.Fc, b.Fy)
+	result.RhoMin = code.RhoMin(b.Fc, b.Fy)
+	result.RhoMax = code.RhoMax(b.Fc, b.Fy)
+	result.RhoBalanced = code.RhoBalanced(b.Fc, b.Fy)
 
 	result.AsMin = result.RhoMin * b.Width * b.EffectiveDepth
 	result.AsMax = result.RhoMax * b.Width * b.EffectiveDepth
 
 	// Calculate maximum moment for singly reinforced (tension-controlled)
-	// Using ρmax which corresponds to εt = 0.005
-	result.AMax = result.RhoMax * b.Fy * b.Width * b.EffectiveDepth / (0.85 * b.Fc * b.Width)
+	// Using ρmax which corresponds to the tension-controlled strain limit
+	result.AMax = result.RhoMax * fy * b.Width * b.EffectiveDepth / (fcd * b.Width)
 	result.CMax = result.AMax / beta1
 
-	phi := nscp.PhiFlexure
-	Mu1Max := phi * 0.85 * b.Fc * b.Width * result.AMax * (b.EffectiveDepth - result.AMax/2) / 1e6
+	// φ at the ρmax limit (tension-controlled unless ρmax is a fraction of ρb)
+	epsilonTMax := epsCU * (b.EffectiveDepth - result.CMax) / result.CMax
+	phi := codes.LimitPhi(code, b.Fc, b.Fy, epsilonTMax)
+	Mu1Max := phi * fcd * b.Width * result.AMax * (b.EffectiveDepth - result.AMax/2) / 1e6
 
 	// Convert Mu from kN-m to N-mm
 	muNmm := mu * 1e6
@@ -132,11 +167,13 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 
 		// Calculate required steel using singly reinforced approach
 		Rn := muNmm / (phi * b.Width * math.Pow(b.EffectiveDepth, 2))
-		term := 2 * Rn / (0.85 * b.Fc)
-		rhoRequired := (0.85 * b.Fc / b.Fy) * (1 - math.Sqrt(1-term))
+		term := 2 * Rn / fcd
+		rhoRequired := (fcd / fy) * (1 - math.Sqrt(1-term))
+		result.AsStrength = rhoRequired * b.Width * b.EffectiveDepth
 
 		if rhoRequired < result.RhoMin {
 			rhoRequired = result.RhoMin
+			result.AsAlternative = codes.MinSteelAlternative(code, result.AsStrength, result.AsMin)
 		}
 
 		result.As1 = rhoRequired * b.Width * b.EffectiveDepth
@@ -145,13 +182,13 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 		result.AscRequired = 0
 
 		// Calculate section properties
-		a := result.AsTotal * b.Fy / (0.85 * b.Fc * b.Width)
+		a := result.AsTotal * fy / (fcd * b.Width)
 		c := a / beta1
-		result.EpsilonT = nscp.EpsilonCU * (b.EffectiveDepth - c) / c
-		result.Phi = nscp.Phi(result.EpsilonT, b.Fy)
-		result.IsTensionControlled = result.EpsilonT >= 0.005
+		result.EpsilonT = epsCU * (b.EffectiveDepth - c) / c
+		result.Phi = code.Phi(result.EpsilonT, b.Fy, nscp.TransverseTied)
+		result.IsTensionControlled = result.EpsilonT >= code.TensionControlledStrain(b.Fc, b.Fy)
 
-		result.PhiMn = result.Phi * result.AsTotal * b.Fy * (b.EffectiveDepth - a/2) / 1e6
+		result.PhiMn = result.Phi * result.AsTotal * fy * (b.EffectiveDepth - a/2) / 1e6
 		result.IsAdequate = true
 		result.Message = "Singly reinforced design is adequate"
 
@@ -168,13 +205,13 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 
 	// Check if compression steel yields
 	// εsc = εcu * (c - d') / c
-	result.EpsilonSc = nscp.EpsilonCU * (result.CMax - b.CoverComp) / result.CMax
-	epsilonY := b.Fy / nscp.Es
+	result.EpsilonSc = epsCU * (result.CMax - b.CoverComp) / result.CMax
+	epsilonY := fy / nscp.Es
 
 	if result.EpsilonSc >= epsilonY {
 		// Compression steel yields
 		result.CompYielded = true
-		result.FscStress = b.Fy
+		result.FscStress = fy
 	} else {
 		// Compression steel does not yield
 		result.CompYielded = false
@@ -185,26 +222,25 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 	// Mu2 = φ * As2 * fy * (d - d')
 	// As2 = Mu2 / (φ * fy * (d - d'))
 	leverArm := b.EffectiveDepth - b.CoverComp
-	result.As2 = (result.Mu2 * 1e6) / (phi * b.Fy * leverArm)
+	result.As2 = (result.Mu2 * 1e6) / (phi * fy * leverArm)
 
 	result.AsTotal = result.As1 + result.As2
 
 	// Required compression steel
 	// Force equilibrium: As2 * fy = Asc * fsc
-	result.AscRequired = result.As2 * b.Fy / result.FscStress
+	result.AscRequired = result.As2 * fy / result.FscStress
 
-	// For doubly reinforced at ρmax, the section is at the tension-controlled limit
-	// εt = 0.005, so φ = 0.90
-	result.EpsilonT = 0.005 // At the tension-controlled limit by design
-	result.Phi = nscp.PhiFlexure
-	result.IsTensionControlled = true
+	// For doubly reinforced at ρmax, the tension steel strain is the ρmax limit strain
+	result.EpsilonT = epsilonTMax
+	result.Phi = phi
+	result.IsTensionControlled = phi == code.PhiFlexure()
 
 	// Calculate capacity
 	// Mn = Mn1 + Mn2 where:
 	// Mn1 = As1 * fy * (d - a/2) - moment from concrete couple
 	// Mn2 = As2 * fy * (d - d') - moment from steel couple
-	Mn1 := result.As1 * b.Fy * (b.EffectiveDepth - result.AMax/2)
-	Mn2 := result.As2 * b.Fy * leverArm
+	Mn1 := result.As1 * fy * (b.EffectiveDepth - result.AMax/2)
+	Mn2 := result.As2 * fy * leverArm
 	result.PhiMn = result.Phi * (Mn1 + Mn2) / 1e6
 
 	result.IsAdequate = result.PhiMn >= mu*0.999 // Small tolerance for floating point
@@ -225,43 +261,52 @@ func (b *DoublyReinforced) Design(mu float64) (*DoublyDesignResult, error) {
 // DoublyAnalysisResult holds the results of doubly reinforced beam analysis
 type DoublyAnalysisResult struct {
 	// Section properties
-	A     float64 // Depth of compression block (mm)
-	C     float64 // Neutral axis depth (mm)
-	Beta1 float64 // Stress block factor
+	A     float64 `json:"a"`     // Depth of compression block (mm)
+	C     float64 `json:"c"`     // Neutral axis depth (mm)
+	Beta1 float64 `json:"beta1"` // Stress block factor
 
 	// Strains
-	EpsilonT  float64 // Tensile strain
-	EpsilonSc float64 // Compression steel strain
+	EpsilonT  float64 `json:"epsilon_t"`  // Tensile strain
+	EpsilonSc float64 `json:"epsilon_sc"` // Compression steel strain
 
 	// Stresses
-	FsStress  float64 // Tension steel stress (MPa)
-	FscStress float64 // Compression steel stress (MPa)
+	FsStress  float64 `json:"fs_stress"`  // Tension steel stress (MPa)
+	FscStress float64 `json:"fsc_stress"` // Compression steel stress (MPa)
 
 	// Steel yielding status
-	TensionYielded bool
-	CompYielded    bool
+	TensionYielded bool `json:"tension_yielded"`
+	CompYielded    bool `json:"comp_yielded"`
 
 	// Reinforcement ratios
-	Rho         float64
-	RhoComp     float64
-	RhoMin      float64
-	RhoMax      float64
-	RhoBalanced float64
+	Rho         float64 `json:"rho"`
+	RhoComp     float64 `json:"rho_comp"`
+	RhoMin      float64 `json:"rho_min"`
+	RhoMax      float64 `json:"rho_max"`
+	RhoBalanced float64 `json:"rho_balanced"`
 
 	// Forces (kN)
-	Cc float64 // Concrete compression force
-	Cs float64 // Compression steel force
-	T  float64 // Tension steel force
+	Cc float64 `json:"cc"` // Concrete compression force
+	Cs float64 `json:"cs"` // Compression steel force
+	T  float64 `json:"t"`  // Tension steel force
+
+	// Convergence of the neutral axis iteration
+	Iterations int     `json:"iterations"` // Iterations to equilibrium
+	Residual   float64 `json:"residual"`   // Force imbalance T − (Cc + Cs) at equilibrium (kN)
 
 	// Capacity
-	Phi   float64 // Strength reduction factor
-	Mn    float64 // Nominal moment capacity (kN-m)
-	PhiMn float64 // Design moment capacity (kN-m)
+	Phi   float64 `json:"phi"`    // Strength reduction factor
+	Mn    float64 `json:"mn"`     // Nominal moment capacity (kN-m)
+	PhiMn float64 `json:"phi_mn"` // Design moment capacity (kN-m)
+
+	// Cracking (gross section)
+	Lambda float64 `json:"lambda"` // Lightweight concrete modification factor
+	Fr     float64 `json:"fr"`     // Modulus of rupture (MPa)
+	Mcr    float64 `json:"mcr"`    // Cracking moment (kN-m)
 
 	// Status
-	IsTensionControlled bool
-	MeetsMinReinf       bool
-	Message             string
+	IsTensionControlled bool   `json:"tension_controlled"`
+	MeetsMinReinf       bool   `json:"meets_min_reinf"`
+	Message             string `json:"message"`
 }
 
 // Analyze calculates moment capacity for a doubly reinforced beam
@@ -282,20 +327,24 @@ func (b *DoublyReinforced) Analyze(as, asc float64) (*DoublyAnalysisResult, erro
 		return nil, fmt.Errorf("invalid compression reinforcement: A'sc=%.2f", asc)
 	}
 
+	code := codes.OrDefault(b.Code)
+	fy := code.DesignYieldStrength(b.Fy)
+	fcd := code.Alpha1(b.Fc) * b.Fc // Stress block intensity (0.85f'c)
+	epsCU := code.EpsilonCU(b.Fc)
 	result := &DoublyAnalysisResult{}
-	result.Beta1 = nscp.Beta1(b.Fc)
+	result.Beta1 = code.Beta1(b.Fc)
 
 	// Calculate reinforcement ratio limits
-	result.RhoMin = nscp.RhoMin(b.Fc, b.Fy)
-	result.RhoMax = nscp.RhoMax(b.Fc, b.Fy)
-	result.RhoBalanced = nscp.RhoBalanced(b.Fc, b.Fy)
+	result.RhoMin = code.RhoMin(b.Fc, b.Fy)
+	result.RhoMax = code.RhoMax(b.Fc, b.Fy)
+	result.RhoBalanced = code.RhoBalanced(b.Fc, b.Fy)
 
 	// Actual reinforcement ratios
 	result.Rho = as / (b.Width * b.EffectiveDepth)
 	result.RhoComp = asc / (b.Width * b.EffectiveDepth)
 	result.MeetsMinReinf = result.Rho >= result.RhoMin
 
-	epsilonY := b.Fy / nscp.Es
+	epsilonY := fy / nscp.Es
 
 	// Iterative solution to find neutral axis depth c
 	// Force equilibrium: T = Cc + Cs
@@ -303,61 +352,103 @@ func (b *DoublyReinforced) Analyze(as, asc float64) (*DoublyAnalysisResult, erro
 	// Note: We subtract 0.85*f'c from compression steel stress to account for
 	// displaced concrete (only if compression steel is within the stress block)
 
+	slog.Debug("doubly reinforced analysis", "b", b.Width, "h", b.Height, "d", b.EffectiveDepth,
+		"d_prime", b.CoverComp, "fc", b.Fc, "fy", b.Fy, "as", as, "asc", asc)
+
 	// Initial guess: assume both steels yield
 	// As*fy = 0.85*f'c*b*β1*c + Asc*(fy - 0.85*f'c)
-	c := (as*b.Fy - asc*(b.Fy-0.85*b.Fc)) / (0.85 * b.Fc * b.Width * result.Beta1)
+	c := (as*fy - asc*(fy-fcd)) / (fcd * b.Width * result.Beta1)
 
-	// Iterate to find correct c
-	for i := 0; i < 50; i++ {
+	// Neutral axis depth from force equilibrium for the steel stresses at depth c
+	equilibriumDepth := func(c float64) float64 {
 		// Calculate strains
-		epsilonT := nscp.EpsilonCU * (b.EffectiveDepth - c) / c
-		epsilonSc := nscp.EpsilonCU * (c - b.CoverComp) / c
+		epsilonT := epsCU * (b.EffectiveDepth - c) / c
+		epsilonSc := epsCU * (c - b.CoverComp) / c
 
 		// Calculate stresses
-		fs := math.Min(epsilonT*nscp.Es, b.Fy)
+		fs := math.Min(epsilonT*nscp.Es, fy)
 		if epsilonT < 0 {
 			fs = 0 // Should not happen for properly reinforced beam
 		}
 
 		var fsc float64
 		if epsilonSc > 0 {
-			fsc = math.Min(epsilonSc*nscp.Es, b.Fy)
+			fsc = math.Min(epsilonSc*nscp.Es, fy)
 		} else {
-			fsc = math.Max(epsilonSc*nscp.Es, -b.Fy) // Compression steel in tension
+			fsc = math.Max(epsilonSc*nscp.Es, -fy) // Compression steel in tension
 		}
 
-		// Recalculate c based on force equilibrium
-		a := result.Beta1 * c
-		
 		// Account for displaced concrete if compression steel is within stress block
+		a := result.Beta1 * c
 		var fscNet float64
 		if a >= b.CoverComp {
-			fscNet = fsc - 0.85*b.Fc // Subtract displaced concrete
+			fscNet = fsc - fcd // Subtract displaced concrete
 		} else {
 			fscNet = fsc
 		}
 
-		cNew := (as*fs - asc*fscNet) / (0.85 * b.Fc * b.Width * result.Beta1)
+		return (as*fs - asc*fscNet) / (fcd * b.Width * result.Beta1)
+	}
+
+	// A force tolerance is the change in c of the concrete force it carries
+	maxIter := solver.Default.Iterations(maxIterations)
+	tolerance := cTolerance
+	if solver.Default.Tolerance > 0 {
+		tolerance = solver.Default.Tolerance * 1000 / (fcd * b.Width * result.Beta1)
+	}
 
-		if math.Abs(cNew-c) < 0.01 {
+	// Iterate to find correct c
+	converged := false
+	for i := 0; i < maxIter; i++ {
+		cNew := equilibriumDepth(c)
+		result.Iterations++
+		slog.Debug("neutral axis iteration", "method", "damped", "iteration", i+1, "c", c, "c_new", cNew)
+		if math.Abs(cNew-c) < tolerance {
 			c = cNew
+			converged = true
 			break
 		}
 		c = (c + cNew) / 2 // Damped iteration
 	}
 
+	// The damped iteration oscillates when the tension steel does not yield;
+	// bisect instead, as equilibriumDepth(c) - c decreases with c
+	if !converged {
+		slog.Debug("damped iteration did not converge, bisecting", "as", as, "asc", asc)
+		lo, hi := 1e-3, b.Height
+		if equilibriumDepth(lo) > lo && equilibriumDepth(hi) < hi {
+			for i := 0; i < maxIter && hi-lo >= tolerance; i++ {
+				mid := (lo + hi) / 2
+				result.Iterations++
+				slog.Debug("neutral axis iteration", "method", "bisection", "iteration", i+1, "lo", lo, "hi", hi)
+				if equilibriumDepth(mid) > mid {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			c = (lo + hi) / 2
+			converged = hi-lo < tolerance
+		}
+	}
+	if !converged {
+		slog.Warn("neutral axis iteration did not converge", "iterations", maxIter, "as", as, "asc", asc)
+		return nil, fmt.Errorf("%w in %d iterations (As=%.2f, A'sc=%.2f)", ErrNoConvergence, maxIter, as, asc)
+	}
+	slog.Debug("neutral axis converged", "c", c)
+
 	result.C = c
 	result.A = result.Beta1 * c
 
 	// Final strains and stresses
-	result.EpsilonT = nscp.EpsilonCU * (b.EffectiveDepth - c) / c
-	result.EpsilonSc = nscp.EpsilonCU * (c - b.CoverComp) / c
+	result.EpsilonT = epsCU * (b.EffectiveDepth - c) / c
+	result.EpsilonSc = epsCU * (c - b.CoverComp) / c
 
-	result.FsStress = math.Min(result.EpsilonT*nscp.Es, b.Fy)
+	result.FsStress = math.Min(result.EpsilonT*nscp.Es, fy)
 	result.TensionYielded = result.EpsilonT >= epsilonY
 
 	if result.EpsilonSc > 0 {
-		result.FscStress = math.Min(result.EpsilonSc*nscp.Es, b.Fy)
+		result.FscStress = math.Min(result.EpsilonSc*nscp.Es, fy)
 		result.CompYielded = result.EpsilonSc >= epsilonY
 	} else {
 		result.FscStress = 0
@@ -365,31 +456,37 @@ func (b *DoublyReinforced) Analyze(as, asc float64) (*DoublyAnalysisResult, erro
 	}
 
 	// Calculate forces (in kN)
-	result.Cc = 0.85 * b.Fc * b.Width * result.A / 1000
-	
+	result.Cc = fcd * b.Width * result.A / 1000
+
 	// Net compression steel force (accounting for displaced concrete)
 	var fscNet float64
 	if result.A >= b.CoverComp {
-		fscNet = result.FscStress - 0.85*b.Fc
+		fscNet = result.FscStress - fcd
 	} else {
 		fscNet = result.FscStress
 	}
 	result.Cs = asc * fscNet / 1000
 	result.T = as * result.FsStress / 1000
+	result.Residual = result.T - (result.Cc + result.Cs)
 
 	// Strength reduction factor
-	result.Phi = nscp.Phi(result.EpsilonT, b.Fy)
-	result.IsTensionControlled = result.EpsilonT >= 0.005
+	result.Phi = code.Phi(result.EpsilonT, b.Fy, nscp.TransverseTied)
+	result.IsTensionControlled = result.EpsilonT >= code.TensionControlledStrain(b.Fc, b.Fy)
 
 	// Calculate moment capacity
 	// Mn = Cc*(d - a/2) + Cs*(d - d')
 	Mn := result.Cc*(b.EffectiveDepth-result.A/2) + result.Cs*(b.EffectiveDepth-b.CoverComp)
-	result.Mn = Mn / 1000   // Convert to kN-m
+	result.Mn = Mn / 1000 // Convert to kN-m
 	result.PhiMn = result.Phi * result.Mn
 
+	// Cracking moment of the gross section, Mcr = fr·b·h²/6
+	result.Lambda = lambdaOrDefault(b.Lambda)
+	result.Fr = code.ModulusOfRupture(b.Fc, result.Lambda)
+	result.Mcr = nscp.CrackingMoment(result.Fr, b.Width*math.Pow(b.Height, 3)/12, b.Height/2)
+
 	// Build status message
 	if result.IsTensionControlled {
-		result.Message = "Section is tension-controlled (εt ≥ 0.005)"
+		result.Message = fmt.Sprintf("Section is tension-controlled (εt ≥ %.4g)", code.TensionControlledStrain(b.Fc, b.Fy))
 	} else if result.EpsilonT >= epsilonY {
 		result.Message = "Section is in transition zone"
 	} else {
@@ -402,4 +499,3 @@ func (b *DoublyReinforced) Analyze(as, asc float64) (*DoublyAnalysisResult, erro
 
 	return result, nil
 }
-
@@ -0,0 +1,56 @@
+package beam
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Ductility computes the ductility and over-strength metrics for a given
+// tension reinforcement area
+func (b *SinglyReinforced) Ductility(as float64) (*nscp.DuctilityResult, error) {
+	nominal := *b
+	r, err := nominal.Analyze(as)
+	if err != nil {
+		return nil, err
+	}
+	probable := *b
+	probable.Fy = b.Fy * nscp.ProbableStrengthFactor
+	pr, err := probable.Analyze(as)
+	if err != nil {
+		return nil, err
+	}
+
+	code := codes.OrDefault(b.Code)
+	kd := crackedNeutralAxis(code, b.Fc, b.Density, b.Width, b.EffectiveDepth, 0, as, 0)
+	return nscp.NewDuctilityResult(code.EpsilonCU(b.Fc), code.DesignYieldStrength(b.Fy)/nscp.Es,
+		r.C, kd, b.EffectiveDepth, pr.Mn, r.PhiMn), nil
+}
+
+// Ductility computes the ductility and over-strength metrics for given
+// tension and compression reinforcement areas
+func (b *DoublyReinforced) Ductility(as, asc float64) (*nscp.DuctilityResult, error) {
+	nominal := *b
+	r, err := nominal.Analyze(as, asc)
+	if err != nil {
+		return nil, err
+	}
+	probable := *b
+	probable.Fy = b.Fy * nscp.ProbableStrengthFactor
+	pr, err := probable.Analyze(as, asc)
+	if err != nil {
+		return nil, err
+	}
+
+	code := codes.OrDefault(b.Code)
+	kd := crackedNeutralAxis(code, b.Fc, b.Density, b.Width, b.EffectiveDepth, b.CoverComp, as, asc)
+	return nscp.NewDuctilityResult(code.EpsilonCU(b.Fc), code.DesignYieldStrength(b.Fy)/nscp.Es,
+		r.C, kd, b.EffectiveDepth, pr.Mn, r.PhiMn), nil
+}
+
+// crackedNeutralAxis returns the elastic neutral axis depth kd (mm) of the
+// cracked rectangular section at first yield
+func crackedNeutralAxis(code codes.DesignCode, fc, density, width, d, dPrime, as, asc float64) float64 {
+	n := nscp.Es / code.ModulusOfElasticity(fc, density)
+	k := nscp.CrackedNeutralAxisRatio(as/(width*d), asc/(width*d), dPrime/d, n)
+	return k * d
+}
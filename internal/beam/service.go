@@ -0,0 +1,177 @@
+package beam
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/aci"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// DefaultClearCover is the clear cover to the tension bars assumed for crack control (mm)
+const DefaultClearCover = 40.0
+
+// DefaultLoadDuration is the sustained load duration for long-term deflection (months)
+const DefaultLoadDuration = 60.0
+
+// ServiceOptions control the serviceability checks
+type ServiceOptions struct {
+	ClearCover   float64 // Clear cover to tension bars cc (mm), DefaultClearCover when zero
+	LoadDuration float64 // Sustained load duration (months), DefaultLoadDuration when zero
+
+	// Creep selects the ACI 209R-92 creep model for the long-term multiplier
+	// instead of the NSCP time-dependent factor ξ
+	Creep *aci.CreepShrinkageConditions
+}
+
+// ServiceResult holds the service-level stresses and section properties of a
+// beam under the service moments, for deflection, crack control and stress checks
+type ServiceResult struct {
+	// Service moments (kN-m)
+	Ma   float64 `json:"ma"`   // Governing total service moment
+	Msus float64 `json:"msus"` // Sustained service moment
+	Md   float64 `json:"md"`   // Permanent service moment
+
+	// Elastic properties
+	Ec  float64 `json:"ec"`  // Modulus of elasticity of concrete (MPa)
+	N   float64 `json:"n"`   // Modular ratio Es/Ec
+	Ig  float64 `json:"ig"`  // Gross moment of inertia (mm⁴)
+	Icr float64 `json:"icr"` // Cracked transformed moment of inertia (mm⁴)
+	Kd  float64 `json:"kd"`  // Cracked neutral axis depth (mm)
+	Mcr float64 `json:"mcr"` // Cracking moment (kN-m)
+
+	// Effective moment of inertia (NSCP 2015 Section 424.2.3.5)
+	Ie          float64 `json:"ie"`           // At Ma (mm⁴)
+	IeSustained float64 `json:"ie_sustained"` // At Msus (mm⁴)
+	IePermanent float64 `json:"ie_permanent"` // At Md (mm⁴)
+
+	// Long-term deflection (NSCP 2015 Section 424.2.4)
+	LoadDuration float64 `json:"load_duration"` // Sustained load duration (months)
+	Xi           float64 `json:"xi"`            // Time-dependent factor ξ, or creep coefficient νt with the ACI 209 model
+	CreepModel   string  `json:"creep_model"`   // "NSCP ξ" or "ACI 209R-92"
+	LambdaDelta  float64 `json:"lambda_delta"`  // Long-term deflection multiplier λΔ
+
+	// Service stresses at Ma (MPa)
+	FcService float64 `json:"fc_service"` // Extreme fiber concrete compressive stress
+	FsService float64 `json:"fs_service"` // Tension steel stress
+	FcAllow   float64 `json:"fc_allow"`   // 0.45f'c
+	FsAllow   float64 `json:"fs_allow"`   // 0.60fy
+
+	// Crack control (NSCP 2015 Section 424.3.2)
+	ClearCover float64 `json:"clear_cover"` // Clear cover to tension bars cc (mm)
+	MaxSpacing float64 `json:"max_spacing"` // Maximum bar spacing s (mm)
+
+	IsCracked      bool `json:"cracked"`
+	MeetsFcService bool `json:"meets_fc_service"`
+	MeetsFsService bool `json:"meets_fs_service"`
+}
+
+// ServiceCheck evaluates the section under the service moments using the
+// tension steel of the last analysis or design
+func (b *SinglyReinforced) ServiceCheck(sm nscp.ServiceMoments, opts ServiceOptions) (*ServiceResult, error) {
+	if b.As <= 0 {
+		return nil, fmt.Errorf("invalid reinforcement area: As=%.2f", b.As)
+	}
+	return serviceCheck(codes.OrDefault(b.Code), b.Width, b.Height, b.EffectiveDepth, 0, b.As, 0,
+		b.Fc, b.Fy, lambdaOrDefault(b.Lambda), b.Density, sm, opts)
+}
+
+// ServiceCheck evaluates the section under the service moments using the
+// tension and compression steel of the last analysis or design
+func (b *DoublyReinforced) ServiceCheck(sm nscp.ServiceMoments, opts ServiceOptions) (*ServiceResult, error) {
+	if b.As <= 0 {
+		return nil, fmt.Errorf("invalid reinforcement area: As=%.2f", b.As)
+	}
+	return serviceCheck(codes.OrDefault(b.Code), b.Width, b.Height, b.EffectiveDepth, b.CoverComp, b.As, b.Asc,
+		b.Fc, b.Fy, lambdaOrDefault(b.Lambda), b.Density, sm, opts)
+}
+
+// serviceCheck performs the cracked elastic analysis of a rectangular section
+// with tension steel as at depth d and compression steel asc at depth dPrime
+func serviceCheck(code codes.DesignCode, width, height, d, dPrime, as, asc, fc, fy, lambda, density float64,
+	sm nscp.ServiceMoments, opts ServiceOptions) (*ServiceResult, error) {
+	if width <= 0 || height <= 0 || d <= 0 {
+		return nil, fmt.Errorf("invalid beam dimensions: width=%.2f, h=%.2f, d=%.2f", width, height, d)
+	}
+	if sm.Total < 0 || sm.Sustained < 0 {
+		return nil, fmt.Errorf("service moments must not be negative: Ma=%.2f, Msus=%.2f", sm.Total, sm.Sustained)
+	}
+	clearCover := opts.ClearCover
+	if clearCover <= 0 {
+		clearCover = DefaultClearCover
+	}
+	duration := opts.LoadDuration
+	if duration <= 0 {
+		duration = DefaultLoadDuration
+	}
+	if opts.Creep != nil {
+		if err := opts.Creep.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &ServiceResult{
+		Ma:           sm.Total,
+		Msus:         sm.Sustained,
+		Md:           sm.Dead,
+		ClearCover:   clearCover,
+		LoadDuration: duration,
+	}
+
+	r.Ec = code.ModulusOfElasticity(fc, density)
+	r.N = nscp.Es / r.Ec
+
+	// Gross section and cracking moment, Mcr = fr·Ig/yt
+	r.Ig = width * math.Pow(height, 3) / 12
+	r.Mcr = nscp.CrackingMoment(code.ModulusOfRupture(fc, lambda), r.Ig, height/2)
+
+	// Cracked transformed section: b·kd²/2 + (n-1)A's(kd - d') = n·As(d - kd)
+	nAs := r.N * as
+	nAsc := (r.N - 1) * asc
+	qa := width / 2
+	qb := nAs + nAsc
+	qc := -(nAs*d + nAsc*dPrime)
+	r.Kd = (-qb + math.Sqrt(qb*qb-4*qa*qc)) / (2 * qa)
+	r.Icr = width*math.Pow(r.Kd, 3)/3 + nAsc*math.Pow(r.Kd-dPrime, 2) + nAs*math.Pow(d-r.Kd, 2)
+
+	r.Ie = effectiveInertia(r.Mcr, r.Ma, r.Ig, r.Icr)
+	r.IeSustained = effectiveInertia(r.Mcr, r.Msus, r.Ig, r.Icr)
+	r.IePermanent = effectiveInertia(r.Mcr, r.Md, r.Ig, r.Icr)
+
+	// Long-term multiplier, NSCP ξ/(1 + 50ρ') or ACI 435R kr·νt with kr = 0.85/(1 + 50ρ')
+	rhoPrime := asc / (width * d)
+	if opts.Creep != nil {
+		r.CreepModel = "ACI 209R-92"
+		r.Xi = aci.CreepCoefficient(duration*30.4, *opts.Creep)
+		r.LambdaDelta = 0.85 * nscp.LongTermDeflectionFactor(r.Xi, rhoPrime)
+	} else {
+		r.CreepModel = "NSCP ξ"
+		r.Xi = nscp.TimeDependentFactor(duration)
+		r.LambdaDelta = nscp.LongTermDeflectionFactor(r.Xi, rhoPrime)
+	}
+	r.IsCracked = r.Ma > r.Mcr
+
+	// Service stresses from the cracked section
+	ma := r.Ma * 1e6 // N-mm
+	r.FcService = ma * r.Kd / r.Icr
+	r.FsService = r.N * ma * (d - r.Kd) / r.Icr
+	r.FcAllow = 0.45 * fc
+	r.FsAllow = 0.60 * fy
+	r.MeetsFcService = r.FcService <= r.FcAllow
+	r.MeetsFsService = r.FsService <= r.FsAllow
+
+	r.MaxSpacing = nscp.MaxBarSpacing(r.FsService, clearCover)
+
+	return r, nil
+}
+
+// effectiveInertia calculates the effective moment of inertia by Branson's equation
+// NSCP 2015 Section 424.2.3.5: Ie = (Mcr/Ma)³Ig + [1 - (Mcr/Ma)³]Icr ≤ Ig
+func effectiveInertia(mcr, ma, ig, icr float64) float64 {
+	if ma <= mcr {
+		return ig
+	}
+	ratio := math.Pow(mcr/ma, 3)
+	return math.Min(ratio*ig+(1-ratio)*icr, ig)
+}
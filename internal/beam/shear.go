@@ -0,0 +1,128 @@
+package beam
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+)
+
+// Stirrup defaults for shear design
+const (
+	DefaultStirrupDiameter = 10.0 // mm
+	DefaultStirrupLegs     = 2
+)
+
+// StirrupSpacingStep is the increment practical stirrup spacings are rounded down to (mm)
+const StirrupSpacingStep = 10.0
+
+// StirrupOptions describe the vertical stirrups used for shear design
+type StirrupOptions struct {
+	Diameter float64 // Stirrup bar diameter (mm), DefaultStirrupDiameter when zero
+	Legs     int     // Number of legs, DefaultStirrupLegs when zero
+	Fyt      float64 // Yield strength of the stirrups (MPa), fy of the beam when zero
+}
+
+// ShearResult holds the stirrup design of a beam for a factored shear
+type ShearResult struct {
+	Vu float64 `json:"vu"` // Factored shear (kN)
+
+	// Strength (kN)
+	Phi   float64 `json:"phi"` // Strength reduction factor for shear
+	Vc    float64 `json:"vc"`  // Nominal shear strength of concrete
+	PhiVc float64 `json:"phi_vc"`
+	VsReq float64 `json:"vs_req"` // Shear to be carried by stirrups
+	VsMax float64 `json:"vs_max"` // Upper limit of Vs from the section size
+
+	// Stirrups
+	Av              float64 `json:"av"`                 // Area of the stirrup legs (mm²)
+	Fyt             float64 `json:"fyt"`                // Stirrup yield strength (MPa)
+	AvMinPerSpacing float64 `json:"av_min_per_spacing"` // Minimum Av/s (mm²/mm)
+	SpacingRequired float64 `json:"spacing_required"`   // Spacing for strength and minimum Av (mm), zero when stirrups are not required
+	MaxSpacing      float64 `json:"max_spacing"`        // Code maximum spacing (mm)
+	Spacing         float64 `json:"spacing"`            // Practical spacing (mm), zero when stirrups are not required
+	PhiVn           float64 `json:"phi_vn"`             // Design shear strength with the practical spacing (kN)
+
+	// Status
+	StirrupsRequired bool   `json:"stirrups_required"` // Vu > φVc/2
+	IsAdequate       bool   `json:"adequate"`
+	Message          string `json:"message"`
+}
+
+// DesignShear designs the vertical stirrups of the beam for a factored shear
+// Vu (kN), using the tension steel of the last analysis or design for ρw
+func (b *SinglyReinforced) DesignShear(vu float64, opts StirrupOptions) (*ShearResult, error) {
+	return designShear(codes.OrDefault(b.Code), b.Width, b.EffectiveDepth, b.As, b.Fc, b.Fy,
+		lambdaOrDefault(b.Lambda), vu, opts)
+}
+
+// DesignShear designs the vertical stirrups of the beam for a factored shear
+// Vu (kN), using the tension steel of the last analysis or design for ρw
+func (b *DoublyReinforced) DesignShear(vu float64, opts StirrupOptions) (*ShearResult, error) {
+	return designShear(codes.OrDefault(b.Code), b.Width, b.EffectiveDepth, b.As, b.Fc, b.Fy,
+		lambdaOrDefault(b.Lambda), vu, opts)
+}
+
+// designShear sizes the spacing of vertical stirrups in a rectangular web of
+// width bw and effective depth d
+func designShear(code codes.DesignCode, bw, d, as, fc, fy, lambda, vu float64, opts StirrupOptions) (*ShearResult, error) {
+	if bw <= 0 || d <= 0 {
+		return nil, fmt.Errorf("invalid beam dimensions: width=%.2f, d=%.2f", bw, d)
+	}
+	if vu < 0 {
+		return nil, fmt.Errorf("factored shear must not be negative: Vu=%.2f", vu)
+	}
+	diameter := opts.Diameter
+	if diameter <= 0 {
+		diameter = DefaultStirrupDiameter
+	}
+	legs := opts.Legs
+	if legs <= 0 {
+		legs = DefaultStirrupLegs
+	}
+	fyt := opts.Fyt
+	if fyt <= 0 {
+		fyt = fy
+	}
+
+	result := &ShearResult{Vu: vu, Fyt: fyt}
+	result.Phi = code.PhiShear()
+	result.Vc = code.Vc(fc, bw, d, as/(bw*d), lambda) / 1e3
+	result.PhiVc = result.Phi * result.Vc
+	result.VsMax = code.VsMax(fc, bw, d) / 1e3
+	result.Av = float64(legs) * math.Pi * diameter * diameter / 4
+	result.AvMinPerSpacing = code.AvMinPerSpacing(fc, fyt, bw)
+
+	result.VsReq = math.Max(vu/result.Phi-result.Vc, 0)
+	result.MaxSpacing = code.MaxStirrupSpacing(fc, bw, d, result.VsReq*1e3)
+	result.StirrupsRequired = vu > result.PhiVc/2
+
+	if result.VsReq > result.VsMax {
+		result.Message = fmt.Sprintf("Section too small for shear: Vs = %.2f kN > Vs,max = %.2f kN, increase the section", result.VsReq, result.VsMax)
+		return result, nil
+	}
+	result.IsAdequate = true
+
+	if !result.StirrupsRequired {
+		result.PhiVn = result.PhiVc
+		result.Message = "Vu ≤ φVc/2, stirrups not required by strength"
+		return result, nil
+	}
+
+	// Spacing for strength (Vs = Av·fyt·d/s) and for the minimum shear reinforcement
+	s := result.Av / result.AvMinPerSpacing
+	if result.VsReq > 0 {
+		s = math.Min(s, result.Av*fyt*d/(result.VsReq*1e3))
+	}
+	result.SpacingRequired = s
+	result.Spacing = math.Floor(math.Min(s, result.MaxSpacing)/StirrupSpacingStep) * StirrupSpacingStep
+	if result.Spacing <= 0 {
+		result.IsAdequate = false
+		result.Message = fmt.Sprintf("Required stirrup spacing %.0f mm is impractical, use larger or more stirrup legs", s)
+		return result, nil
+	}
+	vs := math.Min(result.Av*fyt*d/result.Spacing/1e3, result.VsMax)
+	result.PhiVn = result.Phi * (result.Vc + vs)
+	result.Message = fmt.Sprintf("%d-leg φ%.0fmm stirrups @ %.0f mm", legs, diameter, result.Spacing)
+	return result, nil
+}
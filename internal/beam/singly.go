@@ -4,26 +4,36 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/alexiusacademia/gorcb/internal/codes"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
 )
 
 // SinglyReinforced represents a singly reinforced rectangular beam section
 type SinglyReinforced struct {
 	// Geometry (mm)
-	Width         float64 // b - beam width
-	Height        float64 // h - total depth
-	EffectiveDepth float64 // d - effective depth (to centroid of tension steel)
-	Cover         float64 // concrete cover to centroid of reinforcement
+	Width          float64 `json:"width"`           // b - beam width
+	Height         float64 `json:"height"`          // h - total depth
+	EffectiveDepth float64 `json:"effective_depth"` // d - effective depth (to centroid of tension steel)
+	Cover          float64 `json:"cover"`           // concrete cover to centroid of reinforcement
 
 	// Materials (MPa)
-	Fc float64 // f'c - concrete compressive strength
-	Fy float64 // fy - steel yield strength
+	Fc float64 `json:"fc"` // f'c - concrete compressive strength
+	Fy float64 `json:"fy"` // fy - steel yield strength
+
+	// Lightweight concrete modification factor λ (1.0 for normal-weight when zero)
+	Lambda float64 `json:"lambda"`
+
+	// Concrete density wc (kg/m³) for Ec, zero for normal-weight concrete
+	Density float64 `json:"density"`
 
 	// Loading (kN-m)
-	Mu float64 // Factored moment
+	Mu float64 `json:"mu"` // Factored moment
 
 	// Reinforcement (mm²)
-	As float64 // Area of tension reinforcement
+	As float64 `json:"as"` // Area of tension reinforcement
+
+	// Design code (defaults to NSCP 2015 when nil)
+	Code codes.DesignCode `json:"-"`
 }
 
 // NewSinglyReinforced creates a new singly reinforced beam with calculated effective depth
@@ -41,30 +51,34 @@ func NewSinglyReinforced(width, height, cover, fc, fy float64) *SinglyReinforced
 // DesignResult holds the results of beam design
 type DesignResult struct {
 	// Reinforcement
-	AsRequired float64 // Required steel area (mm²)
-	AsMin      float64 // Minimum steel area (mm²)
-	AsMax      float64 // Maximum steel area (mm²)
-	AsProvided float64 // Provided steel area (mm²)
+	AsRequired float64 `json:"as_required"` // Required steel area (mm²)
+	AsMin      float64 `json:"as_min"`      // Minimum steel area (mm²)
+	AsMax      float64 `json:"as_max"`      // Maximum steel area (mm²)
+	AsProvided float64 `json:"as_provided"` // Provided steel area (mm²)
+
+	// Alternative to the minimum steel when As,min governs
+	AsStrength    float64 `json:"as_strength"`    // Steel required by analysis alone (mm²)
+	AsAlternative float64 `json:"as_alternative"` // Steel that may be provided in lieu of As,min, zero if not applicable (mm²)
 
 	// Reinforcement ratios
-	RhoRequired float64
-	RhoMin      float64
-	RhoMax      float64
-	RhoBalanced float64
+	RhoRequired float64 `json:"rho_required"`
+	RhoMin      float64 `json:"rho_min"`
+	RhoMax      float64 `json:"rho_max"`
+	RhoBalanced float64 `json:"rho_balanced"`
 
 	// Section properties
-	A          float64 // Depth of compression block (mm)
-	C          float64 // Neutral axis depth (mm)
-	EpsilonT   float64 // Tensile strain
-	Phi        float64 // Strength reduction factor
+	A        float64 `json:"a"`         // Depth of compression block (mm)
+	C        float64 `json:"c"`         // Neutral axis depth (mm)
+	EpsilonT float64 `json:"epsilon_t"` // Tensile strain
+	Phi      float64 `json:"phi"`       // Strength reduction factor
 
 	// Capacity
-	PhiMn float64 // Design moment capacity (kN-m)
+	PhiMn float64 `json:"phi_mn"` // Design moment capacity (kN-m)
 
 	// Status
-	IsTensionControlled bool
-	IsAdequate          bool
-	Message             string
+	IsTensionControlled bool   `json:"tension_controlled"`
+	IsAdequate          bool   `json:"adequate"`
+	Message             string `json:"message"`
 }
 
 // Design calculates the required reinforcement for a given factored moment
@@ -78,12 +92,16 @@ func (b *SinglyReinforced) Design(mu float64) (*DesignResult, error) {
 		return nil, fmt.Errorf("invalid material properties: f'c=%.2f, fy=%.2f", b.Fc, b.Fy)
 	}
 
+	code := codes.OrDefault(b.Code)
+	fy := code.DesignYieldStrength(b.Fy)
+	fcd := code.Alpha1(b.Fc) * b.Fc // Stress block intensity (0.85f'c)
+	epsCU := code.EpsilonCU(b.Fc)
 	result := &DesignResult{}
 
 	// Calculate reinforcement ratio limits
-	result.RhoMin = nscp.RhoMin(b.Fc, b.Fy)
-	result.RhoMax = nscp.RhoMax(b.Fc, b.Fy)
-	result.RhoBalanced = nscp.RhoBalanced(b.Fc, b.Fy)
+	result.RhoMin = code.RhoMin(b.Fc, b.Fy)
+	result.RhoMax = code.RhoMax(b.Fc, b.Fy)
+	result.RhoBalanced = code.RhoBalanced(b.Fc, b.Fy)
 
 	// Calculate min and max steel areas
 	result.AsMin = result.RhoMin * b.Width * b.EffectiveDepth
@@ -94,9 +112,11 @@ func (b *SinglyReinforced) Design(mu float64) (*DesignResult, error) {
 
 	// Check if section is adequate for singly reinforced design
 	// Maximum moment capacity with tension-controlled section
-	beta1 := nscp.Beta1(b.Fc)
-	aMax := result.RhoMax * b.Fy * b.Width * b.EffectiveDepth / (0.85 * b.Fc * b.Width)
-	phiMnMax := nscp.PhiFlexure * 0.85 * b.Fc * b.Width * aMax * (b.EffectiveDepth - aMax/2) / 1e6
+	beta1 := code.Beta1(b.Fc)
+	aMax := result.RhoMax * fy * b.Width * b.EffectiveDepth / (fcd * b.Width)
+	cMax := aMax / beta1
+	phiMax := codes.LimitPhi(code, b.Fc, b.Fy, epsCU*(b.EffectiveDepth-cMax)/cMax)
+	phiMnMax := phiMax * fcd * b.Width * aMax * (b.EffectiveDepth - aMax/2) / 1e6
 
 	if mu > phiMnMax {
 		result.IsAdequate = false
@@ -107,44 +127,46 @@ func (b *SinglyReinforced) Design(mu float64) (*DesignResult, error) {
 
 	// Calculate required steel using iterative approach
 	// Start with assuming φ = 0.90 (tension-controlled)
-	phi := nscp.PhiFlexure
+	phi := code.PhiFlexure()
 
 	// Rn = Mu / (φ * b * d²)
 	Rn := muNmm / (phi * b.Width * math.Pow(b.EffectiveDepth, 2))
 
 	// ρ = (0.85*f'c/fy) * (1 - √(1 - 2*Rn/(0.85*f'c)))
-	term := 2 * Rn / (0.85 * b.Fc)
+	term := 2 * Rn / fcd
 	if term > 1 {
 		result.IsAdequate = false
 		result.Message = "Section inadequate - moment too high for singly reinforced design"
 		return result, nil
 	}
 
-	rhoRequired := (0.85 * b.Fc / b.Fy) * (1 - math.Sqrt(1-term))
+	rhoRequired := (fcd / fy) * (1 - math.Sqrt(1-term))
 	result.RhoRequired = rhoRequired
+	result.AsStrength = rhoRequired * b.Width * b.EffectiveDepth
 
 	// Check against minimum
 	if rhoRequired < result.RhoMin {
 		rhoRequired = result.RhoMin
+		result.AsAlternative = codes.MinSteelAlternative(code, result.AsStrength, result.AsMin)
 	}
 
 	result.AsRequired = rhoRequired * b.Width * b.EffectiveDepth
 
 	// Verify the design - calculate actual a and c
-	result.A = result.AsRequired * b.Fy / (0.85 * b.Fc * b.Width)
+	result.A = result.AsRequired * fy / (fcd * b.Width)
 	result.C = result.A / beta1
 
 	// Calculate tensile strain
-	result.EpsilonT = nscp.EpsilonCU * (b.EffectiveDepth - result.C) / result.C
+	result.EpsilonT = epsCU * (b.EffectiveDepth - result.C) / result.C
 
 	// Recalculate phi based on actual strain
-	result.Phi = nscp.Phi(result.EpsilonT, b.Fy)
-	result.IsTensionControlled = result.EpsilonT >= 0.005
+	result.Phi = code.Phi(result.EpsilonT, b.Fy, nscp.TransverseTied)
+	result.IsTensionControlled = result.EpsilonT >= code.TensionControlledStrain(b.Fc, b.Fy)
 
 	// Calculate actual capacity
-	result.PhiMn = result.Phi * result.AsRequired * b.Fy * (b.EffectiveDepth - result.A/2) / 1e6
+	result.PhiMn = result.Phi * result.AsRequired * fy * (b.EffectiveDepth - result.A/2) / 1e6
 
-	result.IsAdequate = result.PhiMn >= mu
+	result.IsAdequate = result.PhiMn >= mu*0.999 // Small tolerance for floating point
 	result.AsProvided = result.AsRequired
 
 	if result.IsAdequate {
@@ -160,27 +182,32 @@ func (b *SinglyReinforced) Design(mu float64) (*DesignResult, error) {
 // AnalysisResult holds the results of beam analysis
 type AnalysisResult struct {
 	// Section properties
-	A        float64 // Depth of compression block (mm)
-	C        float64 // Neutral axis depth (mm)
-	Beta1    float64 // Stress block factor
-	EpsilonT float64 // Tensile strain
-	Phi      float64 // Strength reduction factor
+	A        float64 `json:"a"`         // Depth of compression block (mm)
+	C        float64 `json:"c"`         // Neutral axis depth (mm)
+	Beta1    float64 `json:"beta1"`     // Stress block factor
+	EpsilonT float64 `json:"epsilon_t"` // Tensile strain
+	Phi      float64 `json:"phi"`       // Strength reduction factor
 
 	// Reinforcement ratios
-	Rho         float64
-	RhoMin      float64
-	RhoMax      float64
-	RhoBalanced float64
+	Rho         float64 `json:"rho"`
+	RhoMin      float64 `json:"rho_min"`
+	RhoMax      float64 `json:"rho_max"`
+	RhoBalanced float64 `json:"rho_balanced"`
 
 	// Capacity
-	Mn    float64 // Nominal moment capacity (kN-m)
-	PhiMn float64 // Design moment capacity (kN-m)
+	Mn    float64 `json:"mn"`     // Nominal moment capacity (kN-m)
+	PhiMn float64 `json:"phi_mn"` // Design moment capacity (kN-m)
+
+	// Cracking (gross section)
+	Lambda float64 `json:"lambda"` // Lightweight concrete modification factor
+	Fr     float64 `json:"fr"`     // Modulus of rupture (MPa)
+	Mcr    float64 `json:"mcr"`    // Cracking moment (kN-m)
 
 	// Status
-	IsTensionControlled bool
-	MeetsMinReinf       bool
-	MeetsMaxReinf       bool
-	Message             string
+	IsTensionControlled bool   `json:"tension_controlled"`
+	MeetsMinReinf       bool   `json:"meets_min_reinf"`
+	MeetsMaxReinf       bool   `json:"meets_max_reinf"`
+	Message             string `json:"message"`
 }
 
 // Analyze calculates the moment capacity for a given reinforcement area
@@ -197,13 +224,17 @@ func (b *SinglyReinforced) Analyze(as float64) (*AnalysisResult, error) {
 		return nil, fmt.Errorf("invalid reinforcement area: As=%.2f", as)
 	}
 
+	code := codes.OrDefault(b.Code)
+	fy := code.DesignYieldStrength(b.Fy)
+	fcd := code.Alpha1(b.Fc) * b.Fc // Stress block intensity (0.85f'c)
+	epsCU := code.EpsilonCU(b.Fc)
 	result := &AnalysisResult{}
-	result.Beta1 = nscp.Beta1(b.Fc)
+	result.Beta1 = code.Beta1(b.Fc)
 
 	// Calculate reinforcement ratio limits
-	result.RhoMin = nscp.RhoMin(b.Fc, b.Fy)
-	result.RhoMax = nscp.RhoMax(b.Fc, b.Fy)
-	result.RhoBalanced = nscp.RhoBalanced(b.Fc, b.Fy)
+	result.RhoMin = code.RhoMin(b.Fc, b.Fy)
+	result.RhoMax = code.RhoMax(b.Fc, b.Fy)
+	result.RhoBalanced = code.RhoBalanced(b.Fc, b.Fy)
 
 	// Actual reinforcement ratio
 	result.Rho = as / (b.Width * b.EffectiveDepth)
@@ -214,25 +245,30 @@ func (b *SinglyReinforced) Analyze(as float64) (*AnalysisResult, error) {
 
 	// Calculate depth of compression block
 	// T = C → As*fy = 0.85*f'c*b*a
-	result.A = as * b.Fy / (0.85 * b.Fc * b.Width)
+	result.A = as * fy / (fcd * b.Width)
 	result.C = result.A / result.Beta1
 
 	// Calculate tensile strain
-	result.EpsilonT = nscp.EpsilonCU * (b.EffectiveDepth - result.C) / result.C
+	result.EpsilonT = epsCU * (b.EffectiveDepth - result.C) / result.C
 
 	// Determine phi based on strain
-	result.Phi = nscp.Phi(result.EpsilonT, b.Fy)
-	result.IsTensionControlled = result.EpsilonT >= 0.005
+	result.Phi = code.Phi(result.EpsilonT, b.Fy, nscp.TransverseTied)
+	result.IsTensionControlled = result.EpsilonT >= code.TensionControlledStrain(b.Fc, b.Fy)
 
 	// Calculate moment capacity
 	// Mn = As * fy * (d - a/2)
-	result.Mn = as * b.Fy * (b.EffectiveDepth - result.A/2) / 1e6
+	result.Mn = as * fy * (b.EffectiveDepth - result.A/2) / 1e6
 	result.PhiMn = result.Phi * result.Mn
 
+	// Cracking moment of the gross section, Mcr = fr·Ig/yt = fr·b·h²/6
+	result.Lambda = lambdaOrDefault(b.Lambda)
+	result.Fr = code.ModulusOfRupture(b.Fc, result.Lambda)
+	result.Mcr = nscp.CrackingMoment(result.Fr, b.Width*math.Pow(b.Height, 3)/12, b.Height/2)
+
 	// Build status message
 	if result.IsTensionControlled {
-		result.Message = "Section is tension-controlled (εt ≥ 0.005)"
-	} else if result.EpsilonT >= b.Fy/nscp.Es {
+		result.Message = fmt.Sprintf("Section is tension-controlled (εt ≥ %.4g)", code.TensionControlledStrain(b.Fc, b.Fy))
+	} else if result.EpsilonT >= fy/nscp.Es {
 		result.Message = "Section is in transition zone"
 	} else {
 		result.Message = "Section is compression-controlled (εt < εy)"
@@ -248,3 +284,10 @@ func (b *SinglyReinforced) Analyze(as float64) (*AnalysisResult, error) {
 	return result, nil
 }
 
+// lambdaOrDefault returns λ, treating an unset (zero) value as normal-weight concrete
+func lambdaOrDefault(lambda float64) float64 {
+	if lambda <= 0 {
+		return 1.0
+	}
+	return lambda
+}
@@ -0,0 +1,183 @@
+package beam
+
+import (
+	"fmt"
+	"math"
+)
+
+// StirrupZone is a length of a span with stirrups at one spacing, or
+// without stirrups where they are not required
+type StirrupZone struct {
+	Start   float64 `json:"start"`   // From the start of the span (m)
+	End     float64 `json:"end"`     // Where the zone ends, from the start of the span (m)
+	Spacing float64 `json:"spacing"` // Stirrup spacing (mm), zero without stirrups
+	Count   int     `json:"count"`   // Number of stirrups, the first half a spacing from Start
+	Vu      float64 `json:"vu"`      // Largest factored shear the zone is designed for (kN)
+	PhiVn   float64 `json:"phi_vn"`  // Design shear strength with the stirrups of the zone, φVc without (kN)
+}
+
+// DesignStirrupZones designs the stirrup zones of a span of length x[len-1]
+// from the factored shear magnitudes vu (kN) at the positions x (m) from its
+// start. From each support the span has a zone at the spacing required for
+// the shear a distance d from the support, a zone of minimum stirrups where
+// φVc/2 < Vu ≤ φVc, and no stirrups where Vu ≤ φVc/2. Zones run to a whole
+// number of spacings, and meet from both supports where the shear is least.
+func (b *SinglyReinforced) DesignStirrupZones(x, vu []float64, opts StirrupOptions) ([]StirrupZone, error) {
+	if len(x) < 2 || len(x) != len(vu) {
+		return nil, fmt.Errorf("give the shear at two or more positions along the span")
+	}
+	length := x[len(x)-1] - x[0]
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid span length: %.2f m", length)
+	}
+
+	// Shear within d of a support is that at d
+	d := b.EffectiveDepth / 1000
+	shear := func(at float64) float64 {
+		if 2*d < length {
+			at = math.Min(math.Max(at, x[0]+d), x[len(x)-1]-d)
+		}
+		return shearAt(x, vu, at)
+	}
+
+	// Strength of the section without stirrups and with minimum stirrups
+	none, err := b.DesignShear(0, opts)
+	if err != nil {
+		return nil, err
+	}
+	minimum, err := b.DesignShear(none.PhiVc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sides of the span meet at the least shear
+	middle := x[0]
+	for i := range x {
+		if vu[i] < shearAt(x, vu, middle) {
+			middle = x[i]
+		}
+	}
+
+	// Zones from the left support, then mirrored zones from the right
+	left, err := b.halfZones(x[0], middle, shear, none, minimum, opts)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.halfZones(x[len(x)-1], middle, shear, none, minimum, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := left
+	start := x[0]
+	if len(left) > 0 {
+		start = left[len(left)-1].End
+	}
+	end := x[len(x)-1]
+	if len(right) > 0 {
+		end = right[len(right)-1].Start
+	}
+	if end-start > 1e-6 {
+		zones = append(zones, StirrupZone{Start: start, End: end, Vu: math.Max(shear(start), shear(end)), PhiVn: none.PhiVc})
+	}
+	for i := len(right) - 1; i >= 0; i-- {
+		zones = append(zones, right[i])
+	}
+
+	// Zones of one spacing on both sides of the middle become one
+	merged := zones[:1]
+	for _, z := range zones[1:] {
+		last := &merged[len(merged)-1]
+		if z.Spacing == last.Spacing {
+			last.End, last.Vu = z.End, math.Max(last.Vu, z.Vu)
+			last.Count = int(math.Round((last.End - last.Start) * 1000 / math.Max(last.Spacing, 1)))
+			if last.Spacing == 0 {
+				last.Count = 0
+			}
+			continue
+		}
+		merged = append(merged, z)
+	}
+	for i := range merged {
+		merged[i].Start -= x[0]
+		merged[i].End -= x[0]
+	}
+	return merged, nil
+}
+
+// halfZones designs the zones with stirrups from a support at from toward
+// the middle of the span at to, in order from the support, given the shear
+// strength of the section without stirrups and with minimum stirrups
+func (b *SinglyReinforced) halfZones(from, to float64, shear func(float64) float64, none, minimum *ShearResult, opts StirrupOptions) ([]StirrupZone, error) {
+	const samples = 200
+	dir := math.Copysign(1, to-from)
+	half := math.Abs(to - from)
+
+	// Distance from the support to the last point where the shear exceeds
+	// a limit
+	reach := func(limit float64) float64 {
+		far := 0.0
+		for i := 0; i <= samples; i++ {
+			s := half * float64(i) / samples
+			if shear(from+dir*s) > limit {
+				far = math.Min(s+half/samples, half)
+			}
+		}
+		return far
+	}
+
+	support := shear(from)
+	strength, err := b.DesignShear(support, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !strength.IsAdequate {
+		return nil, fmt.Errorf("at %.2f m: %s", from, strength.Message)
+	}
+
+	var zones []StirrupZone
+	at := 0.0
+	add := func(extent float64, strength *ShearResult, vu float64) {
+		spacing := strength.Spacing
+		if extent <= at || spacing <= 0 {
+			return
+		}
+		count := int(math.Ceil((extent - at) * 1000 / spacing))
+		end := at + float64(count)*spacing/1000
+		if end > half {
+			end = half
+			count = max(int(math.Round((end-at)*1000/spacing)), 1)
+		}
+		zones = append(zones, StirrupZone{Start: from + dir*at, End: from + dir*end, Spacing: spacing, Count: count, Vu: vu, PhiVn: strength.PhiVn})
+		at = end
+	}
+	if strength.Spacing < minimum.Spacing {
+		add(reach(none.PhiVc), strength, support)
+	}
+	add(reach(none.PhiVc/2), minimum, shear(from+dir*at))
+
+	// Zones from the right support run right to left
+	if dir < 0 {
+		for i := range zones {
+			zones[i].Start, zones[i].End = zones[i].End, zones[i].Start
+		}
+	}
+	return zones, nil
+}
+
+// shearAt interpolates the shear at a position, taking the larger of two
+// values at the same position
+func shearAt(x, vu []float64, at float64) float64 {
+	v := 0.0
+	for i := 1; i < len(x); i++ {
+		if at < x[i-1] || at > x[i] {
+			continue
+		}
+		if x[i] == x[i-1] {
+			v = math.Max(v, math.Max(vu[i-1], vu[i]))
+			continue
+		}
+		v = math.Max(v, vu[i-1]+(vu[i]-vu[i-1])*(at-x[i-1])/(x[i]-x[i-1]))
+	}
+	return v
+}
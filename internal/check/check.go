@@ -0,0 +1,168 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Supports of a member for the deflection check
+const (
+	SupportSimple     = "simple"
+	SupportCantilever = "cantilever"
+)
+
+// MinClearLayerSpacing is the least clear distance between layers of
+// parallel bars (mm)
+const MinClearLayerSpacing = 25.0
+
+// Design is a rectangular beam as drawn, with the position of every layer of
+// bars, its stirrups and its loads, verified by Run against every limit state
+type Design struct {
+	ID          string `json:"id" yaml:"id"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Geometry (mm)
+	Width      float64 `json:"width" yaml:"width"`
+	Height     float64 `json:"height" yaml:"height"`
+	ClearCover float64 `json:"clear_cover,omitempty" yaml:"clear_cover,omitempty"` // To the stirrups, beam.DefaultClearCover when zero
+
+	// Materials (MPa), defaults of a batch member when zero
+	Fc    float64 `json:"fc,omitempty" yaml:"fc,omitempty"`
+	Fy    float64 `json:"fy,omitempty" yaml:"fy,omitempty"`
+	Grade string  `json:"grade,omitempty" yaml:"grade,omitempty"` // Steel grade in place of fy
+	Fyt   float64 `json:"fyt,omitempty" yaml:"fyt,omitempty"`     // Stirrups, fy when zero
+
+	// Layers of bars nearest the tension and compression faces first
+	Tension     []Layer   `json:"tension" yaml:"tension"`
+	Compression []Layer   `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Stirrups    *Stirrups `json:"stirrups,omitempty" yaml:"stirrups,omitempty"`
+
+	// TopBars is set when more than 300 mm of fresh concrete is cast below
+	// the tension bars, e.g. at the supports of a continuous beam
+	TopBars bool `json:"top_bars,omitempty" yaml:"top_bars,omitempty"`
+
+	// Factored actions (kN-m, kN), or the unfactored loads they are governed
+	// from by the load combinations, which also give the service moments
+	Mu            float64       `json:"mu,omitempty" yaml:"mu,omitempty"`
+	Vu            float64       `json:"vu,omitempty" yaml:"vu,omitempty"`
+	Loads         project.Loads `json:"loads,omitempty" yaml:"loads,omitempty"`
+	SustainedLive float64       `json:"sustained_live,omitempty" yaml:"sustained_live,omitempty"` // Sustained fraction ψ of the live load
+
+	// Deflection
+	Span      float64 `json:"span,omitempty" yaml:"span,omitempty"`           // m
+	Support   string  `json:"support,omitempty" yaml:"support,omitempty"`     // simple (default) or cantilever
+	Sensitive bool    `json:"sensitive,omitempty" yaml:"sensitive,omitempty"` // Supports elements likely to be damaged by deflection
+}
+
+// Layer is a row of identical bars at one depth
+type Layer struct {
+	Bars string `json:"bars" yaml:"bars"` // Bars of the rebar catalog, e.g. "4-20"
+
+	// Depth of the bar centers from the extreme compression fiber (mm), from
+	// the clear cover, the stirrups and the layers before it when zero
+	Depth float64 `json:"depth,omitempty" yaml:"depth,omitempty"`
+
+	// Embedment is the length of the bars beyond the critical section
+	// available to develop them (mm), not checked when zero
+	Embedment float64 `json:"embedment,omitempty" yaml:"embedment,omitempty"`
+}
+
+// Stirrups are the vertical stirrups of the beam
+type Stirrups struct {
+	Diameter float64 `json:"dia" yaml:"dia"`                             // mm
+	Legs     int     `json:"legs,omitempty" yaml:"legs,omitempty"`       // beam.DefaultStirrupLegs when zero
+	Spacing  float64 `json:"spacing,omitempty" yaml:"spacing,omitempty"` // mm, the stirrups are not checked when zero
+}
+
+// Stdin is the file name that reads a design from standard input as JSON
+const Stdin = "-"
+
+// Load reads a design from a JSON or YAML file (selected by the .yaml/.yml
+// extension), or from JSON on standard input when path is Stdin
+func Load(path string) (*Design, error) {
+	var data []byte
+	var err error
+	if path == Stdin {
+		data, err = io.ReadAll(os.Stdin)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var d Design
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &d)
+	default:
+		err = json.Unmarshal(data, &d)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// Validate checks the dimensions, reinforcement and loads of the design
+func (d *Design) Validate() error {
+	if d.Width <= 0 || d.Height <= 0 {
+		return fmt.Errorf("width and height must be positive")
+	}
+	if d.ClearCover < 0 || d.Fc < 0 || d.Fy < 0 || d.Fyt < 0 {
+		return fmt.Errorf("clear_cover, fc, fy and fyt must not be negative")
+	}
+	if len(d.Tension) == 0 {
+		return fmt.Errorf("give the tension bars")
+	}
+	for _, layers := range [][]Layer{d.Tension, d.Compression} {
+		for _, l := range layers {
+			if strings.TrimSpace(l.Bars) == "" {
+				return fmt.Errorf("every layer must give its bars, e.g. 4-20")
+			}
+			if l.Depth < 0 || l.Depth > d.Height || l.Embedment < 0 {
+				return fmt.Errorf("layer %s: depth must be within the height and embedment must not be negative", l.Bars)
+			}
+		}
+	}
+	if s := d.Stirrups; s != nil && (s.Diameter <= 0 || s.Spacing < 0 || s.Legs < 0) {
+		return fmt.Errorf("stirrups need a positive dia, and spacing and legs must not be negative")
+	}
+
+	if err := d.Loads.Validate(d.ID); err != nil {
+		return err
+	}
+	if len(d.Loads) > 0 && (d.Mu != 0 || d.Vu != 0) {
+		return fmt.Errorf("give either mu/vu or loads, not both")
+	}
+	if len(d.Loads) == 0 && d.Mu <= 0 {
+		return fmt.Errorf("give mu or loads to check")
+	}
+	if d.Mu < 0 || d.Vu < 0 {
+		return fmt.Errorf("mu and vu must not be negative")
+	}
+	if err := nscp.ValidateSustainedLive(d.SustainedLive); err != nil {
+		return err
+	}
+	if d.Span < 0 {
+		return fmt.Errorf("span must not be negative")
+	}
+	switch d.Support {
+	case "", SupportSimple, SupportCantilever:
+	default:
+		return fmt.Errorf("unknown support %q (use simple or cantilever)", d.Support)
+	}
+	return nil
+}
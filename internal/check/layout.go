@@ -0,0 +1,205 @@
+package check
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+)
+
+// DefaultMaxLayers is the most layers of tension bars in a layout
+const DefaultMaxLayers = 2
+
+// minLayoutMu is the moment layouts are verified for when no moment is
+// given (kN-m), so that the minimum steel of a design is still laid out
+const minLayoutMu = 0.001
+
+// LayoutOptions describe the section and the bar counts of layouts
+type LayoutOptions struct {
+	Width      float64 // mm
+	Height     float64 // mm
+	ClearCover float64 // To the stirrups (mm), beam.DefaultClearCover when zero
+	Stirrup    float64 // Stirrup diameter (mm), beam.DefaultStirrupDiameter when zero
+	Fc         float64 // MPa
+	Fy         float64 // MPa
+
+	MinBars   int // Fewest tension bars
+	MaxBars   int // Most tension bars
+	MaxLayers int // Most layers of tension bars, DefaultMaxLayers when zero
+}
+
+// Layout is a buildable arrangement of the bars of a design: the bars of one
+// size in as few layers as fit inside the stirrups at the minimum clear
+// spacing, with d and d' of the layout and φMn verified with them
+type Layout struct {
+	Tension     rebar.Group `json:"tension"`
+	Compression rebar.Group `json:"compression"` // No bars when the design needs no compression steel
+	Layers      []int       `json:"layers"`      // Tension bars in each layer, nearest the face first
+
+	Result *Result `json:"result"` // Verification of the layout for Mu
+	Mass   float64 `json:"mass"`   // Flexural steel per meter of member (kg/m)
+}
+
+// Utilization returns Mu/φMn of the layout
+func (l Layout) Utilization() float64 {
+	if l.Result.PhiMn <= 0 {
+		return math.Inf(1)
+	}
+	return l.Result.Mu / l.Result.PhiMn
+}
+
+// Type returns "Singly" or "Doubly" by the compression bars of the layout
+func (l Layout) Type() string {
+	if l.Compression.Count > 0 {
+		return "Doubly"
+	}
+	return "Singly"
+}
+
+// LayersLabel returns the bars of each layer, e.g. "3 + 2"
+func (l Layout) LayersLabel() string {
+	label := ""
+	for i, n := range l.Layers {
+		if i > 0 {
+			label += " + "
+		}
+		label += fmt.Sprint(n)
+	}
+	return label
+}
+
+// Layouts lays out the tension steel as required with every flexural bar
+// size of the catalog, and the compression steel asc in one layer. Each
+// layout is verified with the d and d' of its bars; while φMn < Mu or the
+// flexural checks fail, heavier compression steel is tried and then another
+// tension bar, up to opts.MaxBars. The layouts are returned from the
+// lightest up. When mu is not positive the layouts are verified for a
+// nominal moment, for the minimum steel of the design.
+func Layouts(as, asc, mu float64, opts LayoutOptions, catalog *rebar.Catalog, code codes.DesignCode) []Layout {
+	mu = math.Max(mu, minLayoutMu)
+	maxLayers := opts.MaxLayers
+	if maxLayers <= 0 {
+		maxLayers = DefaultMaxLayers
+	}
+	stirrup := orDefault(opts.Stirrup, beam.DefaultStirrupDiameter)
+	clear := orDefault(opts.ClearCover, beam.DefaultClearCover)
+	inner := opts.Width - 2*(clear+stirrup)
+
+	// Compression bars of at least asc in one layer, lightest first
+	compressions := []rebar.Group{{}}
+	if asc > 0 {
+		compressions = nil
+		for _, bar := range catalog.FlexuralBars() {
+			for count := max(int(math.Ceil(asc/bar.Area)), 2); arrange(rebar.Group{Bar: bar, Count: count}, inner, 1) != nil; count++ {
+				compressions = append(compressions, rebar.Group{Bar: bar, Count: count})
+			}
+		}
+		sort.SliceStable(compressions, func(i, j int) bool { return compressions[i].Mass(1) < compressions[j].Mass(1) })
+	}
+
+	var layouts []Layout
+	for _, bar := range catalog.FlexuralBars() {
+	counts:
+		for count := max(int(math.Ceil(as/bar.Area)), opts.MinBars); count <= opts.MaxBars; count++ {
+			g := rebar.Group{Bar: bar, Count: count}
+			layers := arrange(g, inner, maxLayers)
+			if layers == nil {
+				break
+			}
+			for _, compression := range compressions {
+				d := &Design{Width: opts.Width, Height: opts.Height, ClearCover: clear,
+					Fc: opts.Fc, Fy: opts.Fy, Stirrups: &Stirrups{Diameter: stirrup}, Mu: mu}
+				for _, n := range layers {
+					d.Tension = append(d.Tension, Layer{Bars: fmt.Sprintf("%d-%s", n, bar.Designation)})
+				}
+				if compression.Count > 0 {
+					d.Compression = []Layer{{Bars: fmt.Sprintf("%d-%s", compression.Count, compression.Bar.Designation)}}
+				}
+				r, err := Run(d, code, catalog)
+				if err != nil || r.PhiMn < mu*0.999 || !flexureAdequate(r) {
+					continue
+				}
+				layouts = append(layouts, Layout{Tension: g, Compression: compression, Layers: layers, Result: r,
+					Mass: g.Mass(1) + compression.Mass(1)})
+				break counts
+			}
+		}
+	}
+	sort.SliceStable(layouts, func(i, j int) bool { return layouts[i].Mass < layouts[j].Mass })
+	return layouts
+}
+
+// Alternatives lays out a design singly reinforced with tension steel as,
+// and doubly reinforced with as2 and compression steel asc when the doubly
+// design needs compression steel, in one layer and in up to opts.MaxLayers
+// layers of tension bars. The distinct layouts are ranked by steel mass, the
+// better utilized first when the masses are equal.
+func Alternatives(as, as2, asc, mu float64, opts LayoutOptions, catalog *rebar.Catalog, code codes.DesignCode) []Layout {
+	maxLayers := opts.MaxLayers
+	if maxLayers <= 0 {
+		maxLayers = DefaultMaxLayers
+	}
+	var alternatives []Layout
+	seen := map[string]bool{}
+	for _, layers := range []int{1, maxLayers} {
+		o := opts
+		o.MaxLayers = layers
+		layouts := Layouts(as, 0, mu, o, catalog, code)
+		if asc > 0 {
+			layouts = append(layouts, Layouts(as2, asc, mu, o, catalog, code)...)
+		}
+		for _, l := range layouts {
+			key := fmt.Sprint(l.Tension, l.Compression, l.Layers)
+			if !seen[key] {
+				seen[key] = true
+				alternatives = append(alternatives, l)
+			}
+		}
+	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		a, b := alternatives[i], alternatives[j]
+		if math.Abs(a.Mass-b.Mass) > 1e-9 {
+			return a.Mass < b.Mass
+		}
+		return a.Utilization() > b.Utilization()
+	})
+	return alternatives
+}
+
+// arrange distributes the bars of a group in layers of as many bars as fit
+// in the inner width at the minimum clear spacing, leaving at least two bars
+// in the last layer, or returns nil when more than maxLayers are needed
+func arrange(g rebar.Group, inner float64, maxLayers int) []int {
+	spacing := math.Max(batch.MinClearBarSpacing, g.Bar.Diameter)
+	perLayer := int(math.Floor((inner + spacing) / (g.Bar.Diameter + spacing)))
+	if perLayer < 1 || (perLayer < 2 && g.Count > 1) {
+		return nil
+	}
+	var layers []int
+	for left := g.Count; left > 0; left -= perLayer {
+		layers = append(layers, min(left, perLayer))
+	}
+	if len(layers) > maxLayers {
+		return nil
+	}
+	if n := len(layers); n > 1 && layers[n-1] == 1 && layers[n-2] > 2 {
+		layers[n-2]--
+		layers[n-1]++
+	}
+	return layers
+}
+
+// flexureAdequate reports whether the flexural and spacing checks of a
+// layout pass
+func flexureAdequate(r *Result) bool {
+	for _, c := range r.Checks {
+		if (c.LimitState == LimitFlexure || c.LimitState == LimitSpacing) && c.Status == StatusNG {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+)
+
+func TestLayoutsWithoutMoment(t *testing.T) {
+	opts := LayoutOptions{Width: 300, Height: 500, Fc: 28, Fy: 415, MinBars: 2, MaxBars: 8}
+	for _, mu := range []float64{0, -5} {
+		layouts := Layouts(440.24, 0, mu, opts, rebar.Default(), codes.Default())
+		if len(layouts) == 0 {
+			t.Fatalf("Mu = %g: no layouts, want the minimum steel laid out", mu)
+		}
+		if l := layouts[0]; l.Tension.Area() < 440.24 || l.Compression.Count != 0 {
+			t.Errorf("Mu = %g: lightest layout %v, want singly with As >= 440.24 mm²", mu, l.Tension)
+		}
+	}
+}
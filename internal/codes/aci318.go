@@ -0,0 +1,99 @@
+package codes
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/aci"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// ACI31819 implements DesignCode for ACI 318-19
+type ACI31819 struct{}
+
+func init() {
+	Register("aci318-19", ACI31819{})
+}
+
+func (ACI31819) Name() string { return "ACI 318-19" }
+
+func (ACI31819) Beta1(fc float64) float64 { return aci.Beta1(fc) }
+
+func (ACI31819) Alpha1(fc float64) float64 { return 0.85 }
+
+func (ACI31819) EpsilonCU(fc float64) float64 { return aci.EpsilonCU }
+
+func (ACI31819) DesignYieldStrength(fy float64) float64 { return fy }
+
+func (ACI31819) Phi(epsilonT, fy float64, transverse nscp.Transverse) float64 {
+	return aci.Phi(epsilonT, fy, transverse)
+}
+
+func (ACI31819) PhiFlexure() float64 { return aci.PhiFlexure }
+
+func (ACI31819) TensionControlledStrain(fc, fy float64) float64 {
+	return aci.TensionControlledStrain(fy)
+}
+
+func (ACI31819) RhoMin(fc, fy float64) float64 { return aci.RhoMin(fc, fy) }
+
+func (ACI31819) RhoMax(fc, fy float64) float64 { return aci.RhoMax(fc, fy) }
+
+func (ACI31819) RhoBalanced(fc, fy float64) float64 { return aci.RhoBalanced(fc, fy) }
+
+// AlternativeMinSteel is 4/3 of As required by analysis (Section 9.6.1.3)
+func (ACI31819) AlternativeMinSteel(asRequired float64) float64 {
+	return nscp.AlternativeMinSteel(asRequired)
+}
+
+// TensionFlangeMinWidth is the smaller of bf and 2bw (Section 9.6.1.2)
+func (ACI31819) TensionFlangeMinWidth(bw, bf float64) float64 {
+	return nscp.TensionFlangeMinWidth(bw, bf)
+}
+
+func (ACI31819) Lambda(density float64) float64 { return aci.Lambda(density) }
+
+func (ACI31819) ModulusOfRupture(fc, lambda float64) float64 {
+	return aci.ModulusOfRupture(fc, lambda)
+}
+
+func (ACI31819) ModulusOfElasticity(fc, density float64) float64 {
+	return aci.ModulusOfElasticity(fc, density)
+}
+
+func (ACI31819) LoadCombinations() []nscp.LoadCombination { return aci.LoadCombinations }
+
+func (ACI31819) ASDCombinations() []nscp.LoadCombination { return aci.ASDCombinations }
+
+func (ACI31819) PhiShear() float64 { return aci.PhiShear }
+
+func (ACI31819) Vc(fc, bw, d, rhoW, lambda float64) float64 { return aci.Vc(fc, bw, d, lambda) }
+
+func (ACI31819) VsMax(fc, bw, d float64) float64 { return aci.VsMax(fc, bw, d) }
+
+func (ACI31819) MaxStirrupSpacing(fc, bw, d, vs float64) float64 {
+	return aci.MaxStirrupSpacing(fc, bw, d, vs)
+}
+
+func (ACI31819) AvMinPerSpacing(fc, fyt, bw float64) float64 { return aci.AvMinPerSpacing(fc, fyt, bw) }
+
+func (ACI31819) DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	return aci.DevelopmentLength(db, fc, fy, lambda, topBar)
+}
+
+func (c ACI31819) TensionLapLength(db, fc, fy, lambda float64, topBar bool, asRatio, fractionSpliced float64) (float64, string) {
+	class := nscp.TensionSpliceClass(asRatio, fractionSpliced)
+	return nscp.TensionLapLength(c.DevelopmentLength(db, fc, fy, lambda, topBar), class), "Class " + string(class)
+}
+
+func (ACI31819) CompressionLapLength(db, fc, fy float64) float64 {
+	return nscp.CompressionLapLength(db, fc, fy)
+}
+
+func (ACI31819) ShrinkageTemperatureRatio(fc, fy float64) float64 {
+	return aci.ShrinkageTemperatureRatio(fy)
+}
+
+// ShrinkageTemperatureSpacing is the lesser of 5h and 450 mm (Section 24.4.3.3)
+func (ACI31819) ShrinkageTemperatureSpacing(h float64) float64 {
+	return nscp.ShrinkageTemperatureSpacing(h)
+}
+
+func (ACI31819) Clause(p Provision) string { return aci31819Clauses[p] }
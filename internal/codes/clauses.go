@@ -0,0 +1,122 @@
+package codes
+
+// Provision identifies a code provision cited in calculation traces
+type Provision string
+
+// Provisions of flexural design cited by DesignCode.Clause
+const (
+	ProvisionStrainCompatibility Provision = "strain-compatibility"  // Strain proportional to the distance from the neutral axis
+	ProvisionConcreteStrain      Provision = "concrete-strain"       // Ultimate concrete compressive strain εcu
+	ProvisionStressBlock         Provision = "stress-block"          // Equivalent rectangular stress block
+	ProvisionBeta1               Provision = "beta1"                 // Stress block depth factor β1
+	ProvisionStrengthReduction   Provision = "strength-reduction"    // φ for moment from the net tensile strain
+	ProvisionTensionControlled   Provision = "tension-controlled"    // Tension-controlled strain limit
+	ProvisionMinSteel            Provision = "min-steel"             // Minimum flexural reinforcement
+	ProvisionMinSteelAlternative Provision = "min-steel-alternative" // 4/3 of the steel required in lieu of As,min
+	ProvisionDesignStrength      Provision = "design-strength"       // φMn ≥ Mu
+)
+
+// Provisions of shear, detailing and serviceability cited by the checks of a
+// complete design
+const (
+	ProvisionShearStrength  Provision = "shear-strength"   // φVn ≥ Vu
+	ProvisionShearLimit     Provision = "shear-limit"      // Upper limit of Vs from the section size
+	ProvisionStirrupSpacing Provision = "stirrup-spacing"  // Maximum spacing of stirrups
+	ProvisionMinShear       Provision = "min-shear"        // Minimum shear reinforcement Av,min/s
+	ProvisionBarSpacing     Provision = "bar-spacing"      // Minimum clear spacing of parallel bars
+	ProvisionCrackControl   Provision = "crack-control"    // Maximum spacing of tension bars for crack control
+	ProvisionDevelopment    Provision = "development"      // Tension development length of straight bars
+	ProvisionDeflection     Provision = "deflection-limit" // Maximum permissible computed deflections
+)
+
+// Cite returns the clause of a provision in brackets for a trace line, e.g.
+// "[Section 409.6.1.2]", or "" when the code has no such provision
+func Cite(code DesignCode, p Provision) string {
+	clause := code.Clause(p)
+	if clause == "" {
+		return ""
+	}
+	return "[" + clause + "]"
+}
+
+// Clause tables of the registered codes
+
+var nscp2015Clauses = map[Provision]string{
+	ProvisionStrainCompatibility: "Section 422.2.1",
+	ProvisionConcreteStrain:      "Section 422.2.2.1",
+	ProvisionStressBlock:         "Section 422.2.2.4.1",
+	ProvisionBeta1:               "Table 422.2.2.4.3",
+	ProvisionStrengthReduction:   "Table 421.2.2",
+	ProvisionTensionControlled:   "Section 421.2.2",
+	ProvisionMinSteel:            "Section 409.6.1.2",
+	ProvisionMinSteelAlternative: "Section 409.6.1.3",
+	ProvisionDesignStrength:      "Section 409.5.1.1",
+	ProvisionShearStrength:       "Section 409.5.1.1",
+	ProvisionShearLimit:          "Section 422.5.1.2",
+	ProvisionStirrupSpacing:      "Table 409.7.6.2.2",
+	ProvisionMinShear:            "Section 409.6.3.3",
+	ProvisionBarSpacing:          "Section 425.2.1",
+	ProvisionCrackControl:        "Table 424.3.2",
+	ProvisionDevelopment:         "Table 425.4.2.2",
+	ProvisionDeflection:          "Table 424.2.2",
+}
+
+var nscp2010Clauses = map[Provision]string{
+	ProvisionStrainCompatibility: "Section 410.3.2",
+	ProvisionConcreteStrain:      "Section 410.3.3",
+	ProvisionStressBlock:         "Section 410.3.7.1",
+	ProvisionBeta1:               "Section 410.3.7.3",
+	ProvisionStrengthReduction:   "Section 409.4.2",
+	ProvisionTensionControlled:   "Section 410.4.4",
+	ProvisionMinSteel:            "Section 410.6.1",
+	ProvisionMinSteelAlternative: "Section 410.6.3",
+	ProvisionDesignStrength:      "Section 409.2.1",
+	ProvisionShearStrength:       "Section 411.2.1",
+	ProvisionShearLimit:          "Section 411.5.7.9",
+	ProvisionStirrupSpacing:      "Section 411.5.5.1",
+	ProvisionMinShear:            "Section 411.5.6.3",
+	ProvisionBarSpacing:          "Section 407.7.1",
+	ProvisionCrackControl:        "Section 410.7.4",
+	ProvisionDevelopment:         "Section 412.3.2",
+	ProvisionDeflection:          "Table 409-2",
+}
+
+var aci31819Clauses = map[Provision]string{
+	ProvisionStrainCompatibility: "Section 22.2.1",
+	ProvisionConcreteStrain:      "Section 22.2.2.1",
+	ProvisionStressBlock:         "Section 22.2.2.4.1",
+	ProvisionBeta1:               "Table 22.2.2.4.3",
+	ProvisionStrengthReduction:   "Table 21.2.2",
+	ProvisionTensionControlled:   "Section 21.2.2",
+	ProvisionMinSteel:            "Section 9.6.1.2",
+	ProvisionMinSteelAlternative: "Section 9.6.1.3",
+	ProvisionDesignStrength:      "Section 9.5.1.1",
+	ProvisionShearStrength:       "Section 9.5.1.1",
+	ProvisionShearLimit:          "Section 22.5.1.2",
+	ProvisionStirrupSpacing:      "Table 9.7.6.2.2",
+	ProvisionMinShear:            "Table 9.6.3.4",
+	ProvisionBarSpacing:          "Section 25.2.1",
+	ProvisionCrackControl:        "Table 24.3.2",
+	ProvisionDevelopment:         "Table 25.4.2.3",
+	ProvisionDeflection:          "Table 24.2.2",
+}
+
+// EN 1992-1-1 applies partial factors instead of φ and always requires As,min
+var ec2Clauses = map[Provision]string{
+	ProvisionStrainCompatibility: "Section 6.1(2)",
+	ProvisionConcreteStrain:      "Table 3.1",
+	ProvisionStressBlock:         "Section 3.1.7(3)",
+	ProvisionBeta1:               "Section 3.1.7(3), Eq. 3.19",
+	ProvisionStrengthReduction:   "Section 2.4.2.4",
+	ProvisionTensionControlled:   "Section 5.5(4)",
+	ProvisionMinSteel:            "Section 9.2.1.1",
+	ProvisionDesignStrength:      "Section 6.1",
+	ProvisionShearStrength:       "Section 6.2.1",
+	ProvisionShearLimit:          "Section 6.2.3(3)",
+	ProvisionStirrupSpacing:      "Section 9.2.2(6)",
+	ProvisionMinShear:            "Section 9.2.2(5)",
+	ProvisionBarSpacing:          "Section 8.2(2)",
+	ProvisionCrackControl:        "Table 7.3N",
+	ProvisionDevelopment:         "Section 8.4.4",
+	ProvisionDeflection:          "Section 7.4.1",
+}
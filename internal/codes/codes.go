@@ -0,0 +1,177 @@
+package codes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// DesignCode provides the code-specific provisions used by the design engines.
+// The beam and section engines only reach code provisions through this
+// interface, so a new code or amendment is added by implementing it and
+// calling Register from an init function.
+type DesignCode interface {
+	// Name is the display name of the code, e.g. "NSCP 2015"
+	Name() string
+
+	// Beta1 is the equivalent rectangular stress block depth factor
+	Beta1(fc float64) float64
+
+	// Alpha1 is the stress block intensity as a fraction of f'c (0.85 for NSCP/ACI)
+	Alpha1(fc float64) float64
+
+	// EpsilonCU is the ultimate concrete compressive strain
+	EpsilonCU(fc float64) float64
+
+	// DesignYieldStrength is the steel strength used in equilibrium (fy, or fy/γs)
+	DesignYieldStrength(fy float64) float64
+
+	// Phi is the flexural strength reduction factor for a net tensile strain
+	// and the transverse reinforcement of the member (tied or spiral)
+	Phi(epsilonT, fy float64, transverse nscp.Transverse) float64
+
+	// PhiFlexure is the strength reduction factor for tension-controlled sections
+	PhiFlexure() float64
+
+	// TensionControlledStrain is the net tensile strain limit for tension-controlled sections
+	TensionControlledStrain(fc, fy float64) float64
+
+	// Reinforcement ratio limits
+	RhoMin(fc, fy float64) float64
+	RhoMax(fc, fy float64) float64
+	RhoBalanced(fc, fy float64) float64
+
+	// AlternativeMinSteel is the tension steel (mm²) that may be provided in
+	// lieu of As,min for a strength requirement asRequired, or 0 if the code
+	// does not permit it
+	AlternativeMinSteel(asRequired float64) float64
+
+	// TensionFlangeMinWidth is the width (mm) used with RhoMin for a statically
+	// determinate member with a flange of width bf in tension and web width bw
+	TensionFlangeMinWidth(bw, bf float64) float64
+
+	// Lambda is the lightweight concrete modification factor for a concrete density (kg/m³)
+	Lambda(density float64) float64
+
+	// ModulusOfRupture is the flexural tensile strength of concrete used for Mcr (MPa)
+	ModulusOfRupture(fc, lambda float64) float64
+
+	// ModulusOfElasticity is the modulus of elasticity of concrete (MPa) for a
+	// concrete density (kg/m³), zero for normal-weight concrete
+	ModulusOfElasticity(fc, density float64) float64
+
+	// LoadCombinations are the strength design load combinations
+	LoadCombinations() []nscp.LoadCombination
+
+	// ASDCombinations are the allowable stress design load combinations,
+	// or nil if the code does not define them
+	ASDCombinations() []nscp.LoadCombination
+
+	// PhiShear is the strength reduction factor for shear and torsion
+	PhiShear() float64
+
+	// Vc is the nominal shear strength provided by concrete (N),
+	// rhoW is the longitudinal tension steel ratio As/(bw·d)
+	Vc(fc, bw, d, rhoW, lambda float64) float64
+
+	// VsMax is the upper limit of the shear strength provided by stirrups (N)
+	VsMax(fc, bw, d float64) float64
+
+	// MaxStirrupSpacing is the maximum spacing of vertical stirrups carrying vs (N)
+	MaxStirrupSpacing(fc, bw, d, vs float64) float64
+
+	// AvMinPerSpacing is the minimum shear reinforcement Av/s (mm²/mm)
+	AvMinPerSpacing(fc, fyt, bw float64) float64
+
+	// DevelopmentLength is the tension development length of a straight deformed bar (mm)
+	DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64
+
+	// TensionLapLength is the tension lap splice length (mm) and its class for
+	// the ratio As,provided/As,required and the fraction of bars lapped together
+	TensionLapLength(db, fc, fy, lambda float64, topBar bool, asRatio, fractionSpliced float64) (float64, string)
+
+	// CompressionLapLength is the compression lap splice length (mm)
+	CompressionLapLength(db, fc, fy float64) float64
+
+	// ShrinkageTemperatureRatio is the minimum ratio of shrinkage and
+	// temperature reinforcement to the gross concrete area of slabs and walls
+	ShrinkageTemperatureRatio(fc, fy float64) float64
+
+	// ShrinkageTemperatureSpacing is the maximum spacing (mm) of shrinkage and
+	// temperature reinforcement in a member of thickness h (mm)
+	ShrinkageTemperatureSpacing(h float64) float64
+
+	// Clause is the section or table of the code for a provision, e.g.
+	// "Section 409.6.1.2", or "" if the code has no such provision
+	Clause(p Provision) string
+}
+
+// DefaultName is the key of the code used when none is selected
+const DefaultName = "nscp2015"
+
+var registry = map[string]DesignCode{}
+
+// Register adds a design code under the given key
+func Register(key string, code DesignCode) {
+	registry[strings.ToLower(key)] = code
+}
+
+// Get returns the design code registered under key
+func Get(key string) (DesignCode, error) {
+	code, ok := registry[strings.ToLower(strings.TrimSpace(key))]
+	if !ok {
+		return nil, fmt.Errorf("unknown design code %q (available: %s)", key, strings.Join(Names(), ", "))
+	}
+	return code, nil
+}
+
+// Names returns the sorted keys of all registered design codes
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the default design code (NSCP 2015)
+func Default() DesignCode {
+	return registry[DefaultName]
+}
+
+// OrDefault returns code, or the default design code if code is nil
+func OrDefault(code DesignCode) DesignCode {
+	if code == nil {
+		return Default()
+	}
+	return code
+}
+
+// LimitPhi returns φ for a section designed at the reinforcement limit ρmax,
+// where the net tensile strain is epsilonT. Codes that define ρmax at the
+// tension-controlled strain give PhiFlexure; older codes that use a fraction
+// of ρb may place the limit in the transition zone.
+func LimitPhi(code DesignCode, fc, fy, epsilonT float64) float64 {
+	if epsilonT >= code.TensionControlledStrain(fc, fy)-1e-9 {
+		return code.PhiFlexure()
+	}
+	return code.Phi(epsilonT, fy, nscp.TransverseTied)
+}
+
+// MinSteelAlternative returns the tension steel that may be provided instead of
+// asMin when the steel required by analysis, asStrength, is below it. Zero is
+// returned when As,min does not govern, the code does not permit the
+// alternative, or the alternative is not less than asMin.
+func MinSteelAlternative(code DesignCode, asStrength, asMin float64) float64 {
+	if asStrength >= asMin {
+		return 0
+	}
+	alt := code.AlternativeMinSteel(asStrength)
+	if alt <= 0 || alt >= asMin {
+		return 0
+	}
+	return alt
+}
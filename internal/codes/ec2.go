@@ -0,0 +1,100 @@
+package codes
+
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/ec2"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// EC2 implements DesignCode for EN 1992-1-1 (Eurocode 2) with the rectangular
+// stress block of Section 3.1.7(3). Partial material factors γc and γs are
+// applied to the strengths, so the strength reduction factor φ is always 1.0
+// and the reported φMn is the design resistance MRd.
+type EC2 struct{}
+
+func init() {
+	Register("ec2", EC2{})
+}
+
+func (EC2) Name() string { return "EUROCODE 2" }
+
+func (EC2) Beta1(fc float64) float64 { return ec2.Lambda(fc) }
+
+// Alpha1 is η·αcc/γc, so that Alpha1·fck = η·fcd
+func (EC2) Alpha1(fc float64) float64 { return ec2.Eta(fc) * ec2.AlphaCC / ec2.GammaC }
+
+func (EC2) EpsilonCU(fc float64) float64 { return ec2.EpsilonCU(fc) }
+
+func (EC2) DesignYieldStrength(fy float64) float64 { return ec2.Fyd(fy) }
+
+func (EC2) Phi(epsilonT, fy float64, transverse nscp.Transverse) float64 { return 1.0 }
+
+func (EC2) PhiFlexure() float64 { return 1.0 }
+
+// TensionControlledStrain is the steel strain at the x/d ductility limit (Section 5.5(4))
+func (EC2) TensionControlledStrain(fc, fy float64) float64 { return ec2.DuctilityStrain(fc) }
+
+func (EC2) RhoMin(fc, fy float64) float64 { return ec2.RhoMin(fc, fy) }
+
+func (EC2) RhoMax(fc, fy float64) float64 { return ec2.RhoMax(fc, fy) }
+
+func (EC2) RhoBalanced(fc, fy float64) float64 { return ec2.RhoBalanced(fc, fy) }
+
+// AlternativeMinSteel is not permitted; Section 9.2.1.1 always applies As,min
+func (EC2) AlternativeMinSteel(asRequired float64) float64 { return 0 }
+
+func (EC2) TensionFlangeMinWidth(bw, bf float64) float64 { return ec2.TensionFlangeMinWidth(bw, bf) }
+
+func (EC2) Lambda(density float64) float64 { return ec2.Eta1(density) }
+
+// ModulusOfRupture is the mean tensile strength η1·fctm (Section 7.1(2))
+func (EC2) ModulusOfRupture(fc, lambda float64) float64 { return lambda * ec2.Fctm(fc) }
+
+// ModulusOfElasticity is the secant modulus Ecm (Table 3.1)
+func (EC2) ModulusOfElasticity(fc, density float64) float64 { return ec2.Ecm(fc, density) }
+
+func (EC2) LoadCombinations() []nscp.LoadCombination { return ec2.LoadCombinations }
+
+// ASDCombinations is nil; Eurocode has no allowable stress design method
+func (EC2) ASDCombinations() []nscp.LoadCombination { return nil }
+
+// PhiShear is 1.0; γc and γs are included in the EC2 shear resistances
+func (EC2) PhiShear() float64 { return 1.0 }
+
+// Vc is the design resistance VRd,c of the section without shear reinforcement
+func (EC2) Vc(fc, bw, d, rhoW, lambda float64) float64 { return ec2.VRdc(fc, bw, d, rhoW, lambda) }
+
+// VsMax is the strut crushing limit VRd,max (θ = 45°)
+func (EC2) VsMax(fc, bw, d float64) float64 { return ec2.VRdMax(fc, bw, d) }
+
+func (EC2) MaxStirrupSpacing(fc, bw, d, vs float64) float64 { return ec2.MaxLinkSpacing(d) }
+
+func (EC2) AvMinPerSpacing(fc, fyt, bw float64) float64 { return ec2.AswMinPerSpacing(fc, fyt, bw) }
+
+// DevelopmentLength is the design anchorage length lbd; top bars are taken
+// as being in poor bond conditions
+func (EC2) DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	return ec2.AnchorageLength(db, fc, fy, !topBar) / lambda
+}
+
+// TensionLapLength is the lap length l0 = α6·lbd (Section 8.7.3) with α6 from
+// the percentage of bars lapped; the splice class reports α6
+func (EC2) TensionLapLength(db, fc, fy, lambda float64, topBar bool, asRatio, fractionSpliced float64) (float64, string) {
+	l0 := ec2.LapLength(db, fc, fy, !topBar, fractionSpliced*100) / lambda
+	return l0, fmt.Sprintf("α6 = %.2f", ec2.LapFactor(fractionSpliced*100))
+}
+
+// CompressionLapLength is the lap length with all bars lapped at one section (α6 = 1.5)
+func (EC2) CompressionLapLength(db, fc, fy float64) float64 {
+	return ec2.LapLength(db, fc, fy, true, 100)
+}
+
+// ShrinkageTemperatureRatio applies the Eq. 9.1N minimum over the full
+// thickness; EN 1992-1-1 has no separate shrinkage and temperature steel
+func (EC2) ShrinkageTemperatureRatio(fc, fy float64) float64 { return ec2.RhoMin(fc, fy) }
+
+// ShrinkageTemperatureSpacing is the secondary reinforcement spacing limit of Section 9.3.1.1(3)
+func (EC2) ShrinkageTemperatureSpacing(h float64) float64 { return ec2.MaxSecondarySpacing(h) }
+
+func (EC2) Clause(p Provision) string { return ec2Clauses[p] }
@@ -0,0 +1,100 @@
+package codes
+
+import "github.com/alexiusacademia/gorcb/internal/nscp"
+
+// NSCP2010 implements DesignCode for the National Structural Code of the
+// Philippines 2010, kept for checking existing designs and legacy projects
+type NSCP2010 struct{}
+
+func init() {
+	Register("nscp2010", NSCP2010{})
+}
+
+func (NSCP2010) Name() string { return "NSCP 2010" }
+
+func (NSCP2010) Beta1(fc float64) float64 { return nscp.Beta1(fc) }
+
+func (NSCP2010) Alpha1(fc float64) float64 { return 0.85 }
+
+func (NSCP2010) EpsilonCU(fc float64) float64 { return nscp.EpsilonCU }
+
+func (NSCP2010) DesignYieldStrength(fy float64) float64 { return fy }
+
+func (NSCP2010) Phi(epsilonT, fy float64, transverse nscp.Transverse) float64 {
+	return nscp.Phi2010(epsilonT, fy, transverse)
+}
+
+func (NSCP2010) PhiFlexure() float64 { return nscp.PhiFlexure }
+
+// TensionControlledStrain is 0.005 (NSCP 2010 Section 410.4.4)
+func (NSCP2010) TensionControlledStrain(fc, fy float64) float64 { return 0.005 }
+
+func (NSCP2010) RhoMin(fc, fy float64) float64 { return nscp.RhoMin(fc, fy) }
+
+// RhoMax is 0.75ρb; sections at this limit may fall in the transition zone
+func (NSCP2010) RhoMax(fc, fy float64) float64 { return nscp.RhoMax2010(fc, fy) }
+
+func (NSCP2010) RhoBalanced(fc, fy float64) float64 { return nscp.RhoBalanced(fc, fy) }
+
+// AlternativeMinSteel is 4/3 of As required by analysis (Section 410.6.3)
+func (NSCP2010) AlternativeMinSteel(asRequired float64) float64 {
+	return nscp.AlternativeMinSteel(asRequired)
+}
+
+// TensionFlangeMinWidth applies min(bf, 2bw), equivalent to the 2010 limit
+// of the smaller of 0.5√f'c/fy·bw·d and 0.25√f'c/fy·bf·d (Section 410.6.2)
+func (NSCP2010) TensionFlangeMinWidth(bw, bf float64) float64 {
+	return nscp.TensionFlangeMinWidth(bw, bf)
+}
+
+func (NSCP2010) Lambda(density float64) float64 { return nscp.LambdaFromDensity(density) }
+
+func (NSCP2010) ModulusOfRupture(fc, lambda float64) float64 {
+	return nscp.ModulusOfRupture(fc, lambda)
+}
+
+func (NSCP2010) ModulusOfElasticity(fc, density float64) float64 {
+	return nscp.ModulusOfElasticityDensity(fc, density)
+}
+
+func (NSCP2010) LoadCombinations() []nscp.LoadCombination { return nscp.LoadCombinations2010 }
+
+// ASDCombinations is nil; only the NSCP 2015 ASD combinations are provided
+func (NSCP2010) ASDCombinations() []nscp.LoadCombination { return nil }
+
+func (NSCP2010) PhiShear() float64 { return nscp.PhiShear }
+
+func (NSCP2010) Vc(fc, bw, d, rhoW, lambda float64) float64 { return nscp.Vc(fc, bw, d, lambda) }
+
+func (NSCP2010) VsMax(fc, bw, d float64) float64 { return nscp.VsMax(fc, bw, d) }
+
+func (NSCP2010) MaxStirrupSpacing(fc, bw, d, vs float64) float64 {
+	return nscp.MaxStirrupSpacing(fc, bw, d, vs)
+}
+
+func (NSCP2010) AvMinPerSpacing(fc, fyt, bw float64) float64 {
+	return nscp.AvMinPerSpacing(fc, fyt, bw)
+}
+
+func (NSCP2010) DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	return nscp.DevelopmentLength(db, fc, fy, lambda, topBar)
+}
+
+func (c NSCP2010) TensionLapLength(db, fc, fy, lambda float64, topBar bool, asRatio, fractionSpliced float64) (float64, string) {
+	class := nscp.TensionSpliceClass(asRatio, fractionSpliced)
+	return nscp.TensionLapLength(c.DevelopmentLength(db, fc, fy, lambda, topBar), class), "Class " + string(class)
+}
+
+func (NSCP2010) CompressionLapLength(db, fc, fy float64) float64 {
+	return nscp.CompressionLapLength(db, fc, fy)
+}
+
+func (NSCP2010) ShrinkageTemperatureRatio(fc, fy float64) float64 {
+	return nscp.ShrinkageTemperatureRatio(fy)
+}
+
+func (NSCP2010) ShrinkageTemperatureSpacing(h float64) float64 {
+	return nscp.ShrinkageTemperatureSpacing(h)
+}
+
+func (NSCP2010) Clause(p Provision) string { return nscp2010Clauses[p] }
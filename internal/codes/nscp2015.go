@@ -0,0 +1,94 @@
+package codes
+
+import "github.com/alexiusacademia/gorcb/internal/nscp"
+
+// NSCP2015 implements DesignCode for the National Structural Code of the Philippines 2015
+type NSCP2015 struct{}
+
+func init() {
+	Register(DefaultName, NSCP2015{})
+}
+
+func (NSCP2015) Name() string { return "NSCP 2015" }
+
+func (NSCP2015) Beta1(fc float64) float64 { return nscp.Beta1(fc) }
+
+func (NSCP2015) Alpha1(fc float64) float64 { return 0.85 }
+
+func (NSCP2015) EpsilonCU(fc float64) float64 { return nscp.EpsilonCU }
+
+func (NSCP2015) DesignYieldStrength(fy float64) float64 { return fy }
+
+func (NSCP2015) Phi(epsilonT, fy float64, transverse nscp.Transverse) float64 {
+	return nscp.Phi(epsilonT, fy, transverse)
+}
+
+func (NSCP2015) PhiFlexure() float64 { return nscp.PhiFlexure }
+
+// TensionControlledStrain is 0.005 for NSCP 2015 (Section 421.2.2)
+func (NSCP2015) TensionControlledStrain(fc, fy float64) float64 { return 0.005 }
+
+func (NSCP2015) RhoMin(fc, fy float64) float64 { return nscp.RhoMin(fc, fy) }
+
+func (NSCP2015) RhoMax(fc, fy float64) float64 { return nscp.RhoMax(fc, fy) }
+
+func (NSCP2015) RhoBalanced(fc, fy float64) float64 { return nscp.RhoBalanced(fc, fy) }
+
+func (NSCP2015) AlternativeMinSteel(asRequired float64) float64 {
+	return nscp.AlternativeMinSteel(asRequired)
+}
+
+func (NSCP2015) TensionFlangeMinWidth(bw, bf float64) float64 {
+	return nscp.TensionFlangeMinWidth(bw, bf)
+}
+
+func (NSCP2015) Lambda(density float64) float64 { return nscp.LambdaFromDensity(density) }
+
+func (NSCP2015) ModulusOfRupture(fc, lambda float64) float64 {
+	return nscp.ModulusOfRupture(fc, lambda)
+}
+
+func (NSCP2015) ModulusOfElasticity(fc, density float64) float64 {
+	return nscp.ModulusOfElasticityDensity(fc, density)
+}
+
+func (NSCP2015) LoadCombinations() []nscp.LoadCombination { return nscp.LoadCombinations }
+
+func (NSCP2015) ASDCombinations() []nscp.LoadCombination { return nscp.ASDCombinations }
+
+func (NSCP2015) PhiShear() float64 { return nscp.PhiShear }
+
+func (NSCP2015) Vc(fc, bw, d, rhoW, lambda float64) float64 { return nscp.Vc(fc, bw, d, lambda) }
+
+func (NSCP2015) VsMax(fc, bw, d float64) float64 { return nscp.VsMax(fc, bw, d) }
+
+func (NSCP2015) MaxStirrupSpacing(fc, bw, d, vs float64) float64 {
+	return nscp.MaxStirrupSpacing(fc, bw, d, vs)
+}
+
+func (NSCP2015) AvMinPerSpacing(fc, fyt, bw float64) float64 {
+	return nscp.AvMinPerSpacing(fc, fyt, bw)
+}
+
+func (NSCP2015) DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	return nscp.DevelopmentLength(db, fc, fy, lambda, topBar)
+}
+
+func (c NSCP2015) TensionLapLength(db, fc, fy, lambda float64, topBar bool, asRatio, fractionSpliced float64) (float64, string) {
+	class := nscp.TensionSpliceClass(asRatio, fractionSpliced)
+	return nscp.TensionLapLength(c.DevelopmentLength(db, fc, fy, lambda, topBar), class), "Class " + string(class)
+}
+
+func (NSCP2015) CompressionLapLength(db, fc, fy float64) float64 {
+	return nscp.CompressionLapLength(db, fc, fy)
+}
+
+func (NSCP2015) ShrinkageTemperatureRatio(fc, fy float64) float64 {
+	return nscp.ShrinkageTemperatureRatio(fy)
+}
+
+func (NSCP2015) ShrinkageTemperatureSpacing(h float64) float64 {
+	return nscp.ShrinkageTemperatureSpacing(h)
+}
+
+func (NSCP2015) Clause(p Provision) string { return nscp2015Clauses[p] }
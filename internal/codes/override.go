@@ -0,0 +1,185 @@
+package codes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides are project-specific replacements of strength reduction factors
+// and the tension-controlled strain limit of a design code. Unset fields keep
+// the value of the code.
+type Overrides struct {
+	PhiFlexure              *float64 `json:"phi_flexure,omitempty" yaml:"phi_flexure,omitempty"`                             // φ for tension-controlled sections
+	PhiCompression          *float64 `json:"phi_compression,omitempty" yaml:"phi_compression,omitempty"`                     // φ for compression-controlled sections
+	PhiShear                *float64 `json:"phi_shear,omitempty" yaml:"phi_shear,omitempty"`                                 // φ for shear and torsion
+	TensionControlledStrain *float64 `json:"tension_controlled_strain,omitempty" yaml:"tension_controlled_strain,omitempty"` // Net tensile strain limit εt
+}
+
+// LoadOverrides reads code overrides from a JSON or YAML file (selected by
+// the .yaml/.yml extension)
+func LoadOverrides(path string) (*Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var o Overrides
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &o)
+	default:
+		err = json.Unmarshal(data, &o)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &o, nil
+}
+
+// Validate checks that the φ factors are within 0..1 and the strain limit is positive
+func (o *Overrides) Validate() error {
+	for _, f := range []struct {
+		name  string
+		value *float64
+	}{
+		{"phi_flexure", o.PhiFlexure},
+		{"phi_compression", o.PhiCompression},
+		{"phi_shear", o.PhiShear},
+	} {
+		if f.value != nil && (*f.value <= 0 || *f.value > 1) {
+			return fmt.Errorf("%s must be between 0 and 1, got %g", f.name, *f.value)
+		}
+	}
+	if o.PhiFlexure != nil && o.PhiCompression != nil && *o.PhiCompression > *o.PhiFlexure {
+		return fmt.Errorf("phi_compression (%g) must not exceed phi_flexure (%g)", *o.PhiCompression, *o.PhiFlexure)
+	}
+	if o.TensionControlledStrain != nil && *o.TensionControlledStrain <= 0 {
+		return fmt.Errorf("tension_controlled_strain must be positive, got %g", *o.TensionControlledStrain)
+	}
+	return nil
+}
+
+// IsEmpty reports whether no value is overridden
+func (o *Overrides) IsEmpty() bool {
+	return o == nil || (o.PhiFlexure == nil && o.PhiCompression == nil &&
+		o.PhiShear == nil && o.TensionControlledStrain == nil)
+}
+
+// Summary lists the overridden values, e.g. "φf = 0.85, εt,tc = 0.004"
+func (o *Overrides) Summary() string {
+	var parts []string
+	if o.PhiFlexure != nil {
+		parts = append(parts, fmt.Sprintf("φf = %.2f", *o.PhiFlexure))
+	}
+	if o.PhiCompression != nil {
+		parts = append(parts, fmt.Sprintf("φc = %.2f", *o.PhiCompression))
+	}
+	if o.PhiShear != nil {
+		parts = append(parts, fmt.Sprintf("φv = %.2f", *o.PhiShear))
+	}
+	if o.TensionControlledStrain != nil {
+		parts = append(parts, fmt.Sprintf("εt,tc = %.4g", *o.TensionControlledStrain))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WithOverrides returns base with the overridden values applied, or base
+// itself when o is empty. The name of the result echoes the overrides.
+func WithOverrides(base DesignCode, o *Overrides) DesignCode {
+	if o.IsEmpty() {
+		return base
+	}
+	return overridden{DesignCode: base, o: *o}
+}
+
+// overridden wraps a design code and replaces its φ factors and strain limit
+type overridden struct {
+	DesignCode
+	o Overrides
+}
+
+func (c overridden) Name() string {
+	return fmt.Sprintf("%s (overrides: %s)", c.DesignCode.Name(), c.o.Summary())
+}
+
+func (c overridden) PhiFlexure() float64 {
+	if c.o.PhiFlexure != nil {
+		return *c.o.PhiFlexure
+	}
+	return c.DesignCode.PhiFlexure()
+}
+
+func (c overridden) PhiShear() float64 {
+	if c.o.PhiShear != nil {
+		return *c.o.PhiShear
+	}
+	return c.DesignCode.PhiShear()
+}
+
+func (c overridden) TensionControlledStrain(fc, fy float64) float64 {
+	if c.o.TensionControlledStrain != nil {
+		return *c.o.TensionControlledStrain
+	}
+	return c.DesignCode.TensionControlledStrain(fc, fy)
+}
+
+// Phi varies linearly from φc at the yield strain to φf at the overridden
+// tension-controlled strain limit. Without a strain override the code's own
+// transition is kept and rescaled to the overridden φ values.
+func (c overridden) Phi(epsilonT, fy float64, transverse nscp.Transverse) float64 {
+	phiC := c.DesignCode.Phi(0, fy, transverse)
+	if c.o.PhiCompression != nil {
+		phiC = *c.o.PhiCompression
+	}
+	phiF := c.PhiFlexure()
+
+	if c.o.TensionControlledStrain == nil {
+		base := c.DesignCode.Phi(epsilonT, fy, transverse)
+		baseC := c.DesignCode.Phi(0, fy, transverse)
+		baseF := c.DesignCode.PhiFlexure()
+		if baseF == baseC {
+			return phiF
+		}
+		return phiC + (phiF-phiC)*(base-baseC)/(baseF-baseC)
+	}
+
+	epsilonTY := c.DesignYieldStrength(fy) / nscp.Es
+	epsilonTC := *c.o.TensionControlledStrain
+	switch {
+	case epsilonT >= epsilonTC:
+		return phiF
+	case epsilonT <= epsilonTY || epsilonTC <= epsilonTY:
+		return phiC
+	}
+	return phiC + (phiF-phiC)*(epsilonT-epsilonTY)/(epsilonTC-epsilonTY)
+}
+
+// RhoMax is recomputed at the overridden tension-controlled strain,
+// ρmax = α1·f'c·β1/fy · εcu/(εcu + εt)
+func (c overridden) RhoMax(fc, fy float64) float64 {
+	if c.o.TensionControlledStrain == nil {
+		return c.DesignCode.RhoMax(fc, fy)
+	}
+	epsCU := c.EpsilonCU(fc)
+	return c.Alpha1(fc) * fc * c.Beta1(fc) / c.DesignYieldStrength(fy) *
+		epsCU / (epsCU + *c.o.TensionControlledStrain)
+}
+
+// Clause cites the overrides file for the φ factors and strain limit it replaces
+func (c overridden) Clause(p Provision) string {
+	switch {
+	case p == ProvisionStrengthReduction && (c.o.PhiFlexure != nil || c.o.PhiCompression != nil || c.o.TensionControlledStrain != nil),
+		p == ProvisionTensionControlled && c.o.TensionControlledStrain != nil:
+		return "code overrides"
+	}
+	return c.DesignCode.Clause(p)
+}
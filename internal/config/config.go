@@ -0,0 +1,155 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/plugin"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"gopkg.in/yaml.v3"
+)
+
+// File names searched for defaults: HomeFile in the home directory, then
+// ProjectFile in the working directory
+const (
+	HomeFile    = ".gorcb.yaml"
+	ProjectFile = "gorcb.yaml"
+)
+
+// Config holds default values for command-line flags, keyed by flag name,
+// e.g. "fc", "cover" or "code"
+type Config struct {
+	Defaults map[string]string
+
+	// Units assumed for section files that do not declare their own
+	Units *section.Units
+
+	// Plugins run after analyses, in the order they were registered
+	Plugins []plugin.Plugin
+
+	// Plugins of the ProjectFile of the working directory, which are not run:
+	// a cloned project must not run programs of its own
+	SkippedPlugins []string
+
+	// Files the configuration was read from, lowest precedence first
+	Files []string
+}
+
+// Paths returns the default configuration files in order of increasing
+// precedence: ~/.gorcb.yaml then ./gorcb.yaml
+func Paths() []string {
+	var paths []string
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, HomeFile))
+	}
+	return append(paths, ProjectFile)
+}
+
+// Load reads the configuration files in order, values of later files
+// overriding earlier ones. Missing files are skipped unless required. The
+// plugins of the ProjectFile found in the working directory are skipped;
+// only files given explicitly (required) and the home file register them.
+func Load(paths []string, required bool) (*Config, error) {
+	cfg := &Config{Defaults: make(map[string]string)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		trusted := required || path != ProjectFile
+		if err := cfg.merge(data, filepath.Dir(path), trusted); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		cfg.Files = append(cfg.Files, path)
+	}
+	return cfg, nil
+}
+
+// merge adds the settings of one YAML document in directory dir to the
+// configuration, with its plugins only when the document is trusted
+func (c *Config) merge(data []byte, dir string, trusted bool) error {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	for key, node := range doc {
+		name := strings.ReplaceAll(strings.ToLower(key), "_", "-")
+		if name == "units" && node.Kind == yaml.MappingNode {
+			var u section.Units
+			if err := node.Decode(&u); err != nil {
+				return fmt.Errorf("units: %w", err)
+			}
+			c.Units = &u
+			continue
+		}
+		if name == "plugins" {
+			if err := c.mergePlugins(node, dir, trusted); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch node.Kind {
+		case yaml.ScalarNode:
+			c.Defaults[name] = node.Value
+		case yaml.SequenceNode:
+			var values []string
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					return fmt.Errorf("%s: list items must be values", key)
+				}
+				values = append(values, item.Value)
+			}
+			c.Defaults[name] = strings.Join(values, ",")
+		default:
+			return fmt.Errorf("%s: expected a value or a list", key)
+		}
+	}
+	return nil
+}
+
+// mergePlugins adds the plugins of a plugins list, replacing those of the
+// same name registered by an earlier file, or lists them as skipped when the
+// file is not trusted
+func (c *Config) mergePlugins(node yaml.Node, dir string, trusted bool) error {
+	var plugins []plugin.Plugin
+	if err := node.Decode(&plugins); err != nil {
+		return fmt.Errorf("plugins: %w", err)
+	}
+	for _, p := range plugins {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("plugins: %w", err)
+		}
+		if !trusted {
+			c.SkippedPlugins = append(c.SkippedPlugins, p.Name)
+			continue
+		}
+		p.Dir = dir
+		i := slices.IndexFunc(c.Plugins, func(q plugin.Plugin) bool { return q.Name == p.Name })
+		if i >= 0 {
+			c.Plugins[i] = p
+		} else {
+			c.Plugins = append(c.Plugins, p)
+		}
+	}
+	return nil
+}
+
+// Keys returns the configured flag names in sorted order
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.Defaults))
+	for key := range c.Defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
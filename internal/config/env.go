@@ -0,0 +1,40 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// EnvPrefix starts the names of the environment variables holding flag
+// defaults, e.g. GORCB_FC for fc and GORCB_COVER_COMP for cover-comp
+const EnvPrefix = "GORCB_"
+
+// EnvName returns the environment variable of a flag name
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Env returns the flag defaults set in the environment, given as KEY=value
+// entries as by os.Environ, keyed by flag name. Empty values are skipped.
+func Env(environ []string) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range environ {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, EnvPrefix) || value == "" {
+			continue
+		}
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(key, EnvPrefix)), "_", "-")
+		values[name] = value
+	}
+	return values
+}
+
+// EnvKeys returns the flag names of env in sorted order
+func EnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
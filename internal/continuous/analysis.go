@@ -0,0 +1,322 @@
+package continuous
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Divisions is the number of equal segments each span is sampled at, in
+// addition to the positions of the point loads and, once combined, the
+// points of zero factored shear
+const Divisions = 20
+
+// Station is a section of the beam with the unfactored moment and shear of
+// each load type. Moments are positive in sagging, shears positive upward
+// on the left of the section. A point load inside a span gives two
+// stations at its position, just before and just after it, and so does an
+// interior support, as the end of one span and the start of the next.
+type Station struct {
+	Span   int              // Span, counted from 1
+	X      float64          // From the left end of the beam (m)
+	Moment nscp.LoadMoments // kN-m
+	Shear  nscp.LoadMoments // kN
+}
+
+// Reaction is the unfactored reaction of a support for each load type
+type Reaction struct {
+	X      float64          // From the left end of the beam (m)
+	Force  nscp.LoadMoments // Upward (kN)
+	Moment nscp.LoadMoments // Counterclockwise, at fixed ends only (kN-m)
+}
+
+// Analysis holds the unfactored moments and shears along the beam and the
+// support reactions
+type Analysis struct {
+	Stations  []Station
+	Reactions []Reaction
+}
+
+// endForces are the shear and counterclockwise moment on the left end of a
+// span
+type endForces struct {
+	V, M float64
+}
+
+// Analyze analyzes the beam for each of its load types by the stiffness
+// method, with the deflection and rotation of each support as unknowns.
+// Every span has the same flexural stiffness, which then drops out of the
+// moments and shears.
+func (b *Beam) Analyze() (*Analysis, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	result := &Analysis{}
+	for i := range b.Spans {
+		for _, x := range b.positions(i) {
+			result.Stations = append(result.Stations, Station{Span: i + 1, X: b.Start(i) + x})
+			if b.pointLoadAt(i, x) {
+				result.Stations = append(result.Stations, Station{Span: i + 1, X: b.Start(i) + x})
+			}
+		}
+	}
+	result.Reactions = make([]Reaction, len(b.Spans)+1)
+	for i := range result.Reactions {
+		result.Reactions[i].X = b.Start(i)
+	}
+
+	for _, t := range b.LoadTypes() {
+		ends, err := b.solve(t)
+		if err != nil {
+			return nil, err
+		}
+		k := 0
+		for i, l := range b.Spans {
+			for _, x := range b.positions(i) {
+				before, after := b.forces(i, t, ends[i], x)
+				result.Stations[k].Moment.Set(t, before.M)
+				result.Stations[k].Shear.Set(t, before.V)
+				k++
+				if b.pointLoadAt(i, x) {
+					result.Stations[k].Moment.Set(t, after.M)
+					result.Stations[k].Shear.Set(t, after.V)
+					k++
+				}
+			}
+
+			// The left end forces of the span act on its left support, and
+			// the right end forces, by equilibrium, on its right support
+			_, right := b.forces(i, t, ends[i], l)
+			left := &result.Reactions[i]
+			left.Force.Set(t, left.Force.Moment(t)+ends[i].V)
+			if i == 0 && b.Left == SupportFixed {
+				left.Moment.Set(t, ends[i].M)
+			}
+			next := &result.Reactions[i+1]
+			next.Force.Set(t, next.Force.Moment(t)-right.V)
+			if i == len(b.Spans)-1 && b.Right == SupportFixed {
+				next.Moment.Set(t, right.M)
+			}
+		}
+	}
+
+	// No reactions at free ends
+	if b.Left == SupportFree {
+		result.Reactions = result.Reactions[1:]
+	}
+	if b.Right == SupportFree {
+		result.Reactions = result.Reactions[:len(result.Reactions)-1]
+	}
+	return result, nil
+}
+
+// positions returns where a span is sampled, from its start (m)
+func (b *Beam) positions(span int) []float64 {
+	l := b.Spans[span]
+	var xs []float64
+	for i := 0; i <= Divisions; i++ {
+		xs = append(xs, l*float64(i)/Divisions)
+	}
+	for _, load := range b.Loads {
+		if load.P != 0 && (load.Span == 0 || load.Span == span+1) {
+			xs = append(xs, load.X)
+		}
+	}
+	slices.Sort(xs)
+	return slices.CompactFunc(xs, func(a, b float64) bool { return math.Abs(a-b) < 1e-9 })
+}
+
+// pointLoadAt reports whether a point load of any type acts at x from the
+// start of a span, inside the span
+func (b *Beam) pointLoadAt(span int, x float64) bool {
+	for _, load := range b.Loads {
+		if load.P != 0 && (load.Span == 0 || load.Span == span+1) && load.X == x && x > 0 && x < b.Spans[span] {
+			return true
+		}
+	}
+	return false
+}
+
+// forces returns the moment and shear at x from the start of a span, just
+// before and just after any point load there
+func (b *Beam) forces(span int, t nscp.LoadType, end endForces, x float64) (before, after endForces) {
+	before = endForces{V: end.V, M: -end.M + end.V*x}
+	for _, load := range b.on(span, t) {
+		before.V -= load.W * x
+		before.M -= load.W * x * x / 2
+		if load.P != 0 && load.X < x {
+			before.V -= load.P
+			before.M -= load.P * (x - load.X)
+		}
+	}
+	after = before
+	for _, load := range b.on(span, t) {
+		if load.P != 0 && load.X == x {
+			after.V -= load.P
+		}
+	}
+	return before.rounded(), after.rounded()
+}
+
+// rounded drops the round-off of the solution from forces that are zero,
+// e.g. the moment at a pinned end
+func (f endForces) rounded() endForces {
+	if math.Abs(f.V) < 1e-9 {
+		f.V = 0
+	}
+	if math.Abs(f.M) < 1e-9 {
+		f.M = 0
+	}
+	return f
+}
+
+// solve returns the forces on the left end of every span under the loads
+// of one type
+func (b *Beam) solve(t nscp.LoadType) ([]endForces, error) {
+	n := 2 * (len(b.Spans) + 1) // Deflection and rotation of each support
+
+	// Restrained degrees of freedom
+	fixed := make([]bool, n)
+	for i := 1; i < len(b.Spans); i++ {
+		fixed[2*i] = true
+	}
+	for _, end := range []struct {
+		node    int
+		support string
+	}{{0, b.Left}, {len(b.Spans), b.Right}} {
+		switch end.support {
+		case "", SupportPinned:
+			fixed[2*end.node] = true
+		case SupportFixed:
+			fixed[2*end.node], fixed[2*end.node+1] = true, true
+		}
+	}
+
+	// Stiffness matrix, and the fixed-end forces with opposite sign as loads
+	k := make([][]float64, n)
+	for i := range k {
+		k[i] = make([]float64, n)
+	}
+	loads := make([]float64, n)
+	spanStiffness := make([][4][4]float64, len(b.Spans))
+	fixedEnd := make([][4]float64, len(b.Spans))
+	for i, l := range b.Spans {
+		spanStiffness[i] = stiffness(l)
+		fixedEnd[i] = b.fixedEndForces(i, t)
+		for r := 0; r < 4; r++ {
+			loads[2*i+r] -= fixedEnd[i][r]
+			for c := 0; c < 4; c++ {
+				k[2*i+r][2*i+c] += spanStiffness[i][r][c]
+			}
+		}
+	}
+
+	// Solve for the free degrees of freedom
+	var free []int
+	for i := range fixed {
+		if !fixed[i] {
+			free = append(free, i)
+		}
+	}
+	a := make([][]float64, len(free))
+	rhs := make([]float64, len(free))
+	for r, i := range free {
+		a[r] = make([]float64, len(free))
+		for c, j := range free {
+			a[r][c] = k[i][j]
+		}
+		rhs[r] = loads[i]
+	}
+	solution, err := gauss(a, rhs)
+	if err != nil {
+		return nil, err
+	}
+	d := make([]float64, n)
+	for r, i := range free {
+		d[i] = solution[r]
+	}
+
+	ends := make([]endForces, len(b.Spans))
+	for i := range b.Spans {
+		var f [4]float64
+		for r := 0; r < 4; r++ {
+			f[r] = fixedEnd[i][r]
+			for c := 0; c < 4; c++ {
+				f[r] += spanStiffness[i][r][c] * d[2*i+c]
+			}
+		}
+		ends[i] = endForces{V: f[0], M: f[1]}
+	}
+	return ends, nil
+}
+
+// stiffness returns the stiffness matrix of a span of unit EI for the
+// deflection (up) and rotation (counterclockwise) of its ends
+func stiffness(l float64) [4][4]float64 {
+	l2, l3 := l*l, l*l*l
+	return [4][4]float64{
+		{12 / l3, 6 / l2, -12 / l3, 6 / l2},
+		{6 / l2, 4 / l, -6 / l2, 2 / l},
+		{-12 / l3, -6 / l2, 12 / l3, -6 / l2},
+		{6 / l2, 2 / l, -6 / l2, 4 / l},
+	}
+}
+
+// fixedEndForces returns the end shears (up) and moments (counterclockwise)
+// of a span with both ends fixed under the loads of one type
+func (b *Beam) fixedEndForces(span int, t nscp.LoadType) [4]float64 {
+	l := b.Spans[span]
+	var f [4]float64
+	for _, load := range b.on(span, t) {
+		w := load.W
+		f[0] += w * l / 2
+		f[1] += w * l * l / 12
+		f[2] += w * l / 2
+		f[3] -= w * l * l / 12
+		if load.P != 0 {
+			p, a := load.P, load.X
+			c := l - a
+			f[0] += p * c * c * (3*a + c) / (l * l * l)
+			f[1] += p * a * c * c / (l * l)
+			f[2] += p * a * a * (a + 3*c) / (l * l * l)
+			f[3] -= p * a * a * c / (l * l)
+		}
+	}
+	return f
+}
+
+// gauss solves a·x = b by Gaussian elimination with partial pivoting
+func gauss(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errors.New("the beam is unstable")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for r := col + 1; r < n; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c < n; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+			b[r] -= f * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		x[r] = b[r]
+		for c := r + 1; c < n; c++ {
+			x[r] -= a[r][c] * x[c]
+		}
+		x[r] /= a[r][r]
+	}
+	return x, nil
+}
@@ -0,0 +1,161 @@
+package continuous
+
+import (
+	"math"
+	"slices"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// EnvelopeID names the envelope among the diagrams of the combinations
+const EnvelopeID = "Envelope"
+
+// Diagram is the factored moment and shear along the beam for one load
+// combination, or the envelope of all of them. Combinations with "or"
+// loads or reversible wind and earthquake give a range at each point, from
+// their most negative to their most positive branch.
+type Diagram struct {
+	ID          string
+	Description string
+	Points      []DiagramPoint
+}
+
+// DiagramPoint is the factored moment and shear range at a station
+type DiagramPoint struct {
+	Span                 int
+	X                    float64 // m
+	MaxMoment, MinMoment float64 // kN-m
+	MaxShear, MinShear   float64 // kN
+}
+
+// Combine returns the factored diagrams of the combinations that have an
+// effect on the beam. Stations are first added where the factored shear of
+// a branch of a combination is zero between two stations, at the peak of
+// its parabolic moment, so that no diagram misses its largest moment.
+func (a *Analysis) Combine(combinations []nscp.LoadCombination) []Diagram {
+	a.addPeaks(combinations)
+	var diagrams []Diagram
+	for _, combo := range combinations {
+		d := Diagram{ID: combo.ID, Description: combo.Description}
+		effect := false
+		for _, st := range a.Stations {
+			maxM, minM := combo.GoverningBranch(st.Moment)
+			maxV, minV := combo.GoverningBranch(st.Shear)
+			p := DiagramPoint{Span: st.Span, X: st.X,
+				MaxMoment: maxM.Moment, MinMoment: minM.Moment, MaxShear: maxV.Moment, MinShear: minV.Moment}
+			effect = effect || p.MaxMoment != 0 || p.MinMoment != 0 || p.MaxShear != 0 || p.MinShear != 0
+			d.Points = append(d.Points, p)
+		}
+		if effect {
+			diagrams = append(diagrams, d)
+		}
+	}
+	return diagrams
+}
+
+// Envelope returns the largest and smallest moment and shear of the
+// diagrams at each station
+func Envelope(diagrams []Diagram) Diagram {
+	env := Diagram{ID: EnvelopeID, Description: "Envelope of all combinations"}
+	for i, d := range diagrams {
+		for j, p := range d.Points {
+			if i == 0 {
+				env.Points = append(env.Points, p)
+				continue
+			}
+			e := &env.Points[j]
+			e.MaxMoment, e.MinMoment = math.Max(e.MaxMoment, p.MaxMoment), math.Min(e.MinMoment, p.MinMoment)
+			e.MaxShear, e.MinShear = math.Max(e.MaxShear, p.MaxShear), math.Min(e.MinShear, p.MinShear)
+		}
+	}
+	return env
+}
+
+// Extremes returns the largest sagging and hogging moments and the largest
+// shear magnitude of a diagram, with where they occur (m)
+func (d Diagram) Extremes() (sagging, hogging, shear, xSagging, xHogging, xShear float64) {
+	for _, p := range d.Points {
+		if p.MaxMoment > sagging {
+			sagging, xSagging = p.MaxMoment, p.X
+		}
+		if p.MinMoment < hogging {
+			hogging, xHogging = p.MinMoment, p.X
+		}
+		for _, v := range []float64{p.MaxShear, p.MinShear} {
+			if math.Abs(v) > math.Abs(shear) {
+				shear, xShear = v, p.X
+			}
+		}
+	}
+	return
+}
+
+// addPeaks adds the stations where the factored shear of a branch of any of
+// the combinations changes sign between two stations of a span. Only
+// uniform loads act between stations, so the shear of each load type is
+// linear there and its moment parabolic.
+func (a *Analysis) addPeaks(combinations []nscp.LoadCombination) {
+	var stations []Station
+	for k, st := range a.Stations {
+		stations = append(stations, st)
+		if k+1 == len(a.Stations) {
+			break
+		}
+		next := a.Stations[k+1]
+		h := next.X - st.X
+		if next.Span != st.Span || h < 1e-9 {
+			continue
+		}
+
+		// Load types acting on the segment, for the branches of each combination
+		var acting nscp.LoadMoments
+		for _, t := range nscp.LoadTypes {
+			acting.Set(t, math.Abs(st.Moment.Moment(t))+math.Abs(next.Moment.Moment(t))+
+				math.Abs(st.Shear.Moment(t))+math.Abs(next.Shear.Moment(t)))
+		}
+		var peaks []float64
+		for _, combo := range combinations {
+			for _, branch := range combo.Branches(acting) {
+				v1, v2 := factored(combo, branch, st.Shear), factored(combo, branch, next.Shear)
+				if v1*v2 < 0 {
+					peaks = append(peaks, h*v1/(v1-v2))
+				}
+			}
+		}
+		slices.Sort(peaks)
+		peaks = slices.CompactFunc(peaks, func(p, q float64) bool { return math.Abs(p-q) < 1e-9 })
+		for _, x := range peaks {
+			if x > 1e-9 && x < h-1e-9 {
+				stations = append(stations, st.at(next, x))
+			}
+		}
+	}
+	a.Stations = stations
+}
+
+// at returns the station at x from st toward next, in the same span with
+// only uniform loads between them
+func (st Station) at(next Station, x float64) Station {
+	h := next.X - st.X
+	s := Station{Span: st.Span, X: st.X + x}
+	for _, t := range nscp.LoadTypes {
+		v := st.Shear.Moment(t)
+		w := (v - next.Shear.Moment(t)) / h
+		s.Moment.Set(t, st.Moment.Moment(t)+v*x-w*x*x/2)
+		s.Shear.Set(t, v-w*x)
+	}
+	return s
+}
+
+// factored returns the factored effect of a branch of a combination
+func factored(combo nscp.LoadCombination, branch nscp.CombinationBranch, effects nscp.LoadMoments) float64 {
+	value := 0.0
+	for _, t := range branch.Loads {
+		sign := 1.0
+		if s, ok := branch.Signs[t]; ok {
+			sign = s
+		}
+		value += sign * combo.Factor(t) * effects.Moment(t)
+	}
+	return value
+}
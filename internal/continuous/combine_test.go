@@ -0,0 +1,33 @@
+package continuous
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+func TestCombinePeakSagging(t *testing.T) {
+	// Two equal spans under a uniform load: the largest sagging moment is
+	// 9wL²/128 at 3L/8 from the end supports, where the shear is zero
+	b := &Beam{Spans: []float64{6, 6}, Loads: []Load{{Type: nscp.LoadDead, W: 10}}}
+	a, err := b.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagrams := a.Combine(nscp.LoadCombinations[:1])
+	if len(diagrams) != 1 {
+		t.Fatalf("%d diagrams, want combination 1 only", len(diagrams))
+	}
+	sagging, hogging, _, x, _, _ := diagrams[0].Extremes()
+	w, l := 1.4*10, 6.0
+	if want := 9 * w * l * l / 128; math.Abs(sagging-want) > 1e-6 {
+		t.Errorf("+Mu = %.4f kN-m, want 9wL²/128 = %.4f", sagging, want)
+	}
+	if want := 3 * l / 8; math.Abs(x-want) > 1e-6 {
+		t.Errorf("+Mu at %.4f m, want 3L/8 = %.4f", x, want)
+	}
+	if want := -w * l * l / 8; math.Abs(hogging-want) > 1e-6 {
+		t.Errorf("-Mu = %.4f kN-m, want -wL²/8 = %.4f", hogging, want)
+	}
+}
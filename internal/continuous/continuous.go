@@ -0,0 +1,190 @@
+// Package continuous analyzes continuous beams over several spans for the
+// unfactored load types and combines them into factored moment and shear
+// diagrams and their envelope.
+package continuous
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"gopkg.in/yaml.v3"
+)
+
+// End supports of a beam. Interior supports are always pinned.
+const (
+	SupportPinned = "pinned"
+	SupportFixed  = "fixed"
+	SupportFree   = "free"
+)
+
+// Beam is a continuous beam of constant stiffness over one or more spans,
+// e.g.
+//
+//	name: B1
+//	spans: [6, 7.5, 6]
+//	right: fixed
+//	loads:
+//	  - {type: D, w: 18}
+//	  - {type: L, w: 12}
+//	  - {type: L, p: 40, x: 3, span: 2}
+type Beam struct {
+	Name  string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Spans []float64 `json:"spans" yaml:"spans"` // m
+
+	// Supports at the left and right ends: pinned (default), fixed or free
+	Left  string `json:"left,omitempty" yaml:"left,omitempty"`
+	Right string `json:"right,omitempty" yaml:"right,omitempty"`
+
+	Loads []Load `json:"loads" yaml:"loads"`
+}
+
+// Load is an unfactored uniform or point load of one load type, acting
+// downward
+type Load struct {
+	Type nscp.LoadType `json:"type" yaml:"type"`
+	W    float64       `json:"w,omitempty" yaml:"w,omitempty"`       // Uniform load (kN/m)
+	P    float64       `json:"p,omitempty" yaml:"p,omitempty"`       // Point load (kN)
+	X    float64       `json:"x,omitempty" yaml:"x,omitempty"`       // Position of the point load from the start of its span (m)
+	Span int           `json:"span,omitempty" yaml:"span,omitempty"` // Loaded span, counted from 1 (default: every span)
+}
+
+// Stdin is the file name that reads a beam from standard input as JSON
+const Stdin = "-"
+
+// LoadFile reads a beam from a JSON or YAML file (selected by the
+// .yaml/.yml extension), or from JSON on standard input when path is Stdin
+func LoadFile(path string) (*Beam, error) {
+	var data []byte
+	var err error
+	if path == Stdin {
+		data, err = io.ReadAll(os.Stdin)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Beam
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &b)
+	default:
+		err = json.Unmarshal(data, &b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// Validate checks the spans, supports and loads of the beam
+func (b *Beam) Validate() error {
+	if len(b.Spans) == 0 {
+		return fmt.Errorf("give the spans of the beam")
+	}
+	for i, l := range b.Spans {
+		if l <= 0 {
+			return fmt.Errorf("span %d must be positive", i+1)
+		}
+	}
+	for _, s := range []string{b.Left, b.Right} {
+		switch s {
+		case "", SupportPinned, SupportFixed, SupportFree:
+		default:
+			return fmt.Errorf("unknown support %q (use pinned, fixed or free)", s)
+		}
+	}
+	vertical, fixed := len(b.Spans)-1, 0
+	for _, s := range []string{b.Left, b.Right} {
+		if s != SupportFree {
+			vertical++
+		}
+		if s == SupportFixed {
+			fixed++
+		}
+	}
+	if vertical < 2 && fixed == 0 {
+		return fmt.Errorf("the beam is unstable: it needs two supports or a fixed end")
+	}
+	if len(b.Loads) == 0 {
+		return fmt.Errorf("give the loads of the beam")
+	}
+	for i, l := range b.Loads {
+		known := false
+		for _, t := range nscp.LoadTypes {
+			known = known || l.Type == t
+		}
+		if !known {
+			return fmt.Errorf("load %d: unknown load type %q", i+1, l.Type)
+		}
+		if l.W == 0 && l.P == 0 {
+			return fmt.Errorf("load %d: give a uniform load w or a point load p", i+1)
+		}
+		if l.Span < 0 || l.Span > len(b.Spans) {
+			return fmt.Errorf("load %d: span must be between 1 and %d", i+1, len(b.Spans))
+		}
+		if l.P != 0 {
+			if l.Span == 0 && len(b.Spans) > 1 {
+				return fmt.Errorf("load %d: give the span of the point load", i+1)
+			}
+			if l.X < 0 || l.X > b.Spans[max(l.Span, 1)-1] {
+				return fmt.Errorf("load %d: x must be within the span", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Length returns the total length of the beam (m)
+func (b *Beam) Length() float64 {
+	var length float64
+	for _, l := range b.Spans {
+		length += l
+	}
+	return length
+}
+
+// Start returns the position of the start of a span from the left end of
+// the beam (m), with spans counted from 0
+func (b *Beam) Start(span int) float64 {
+	var x float64
+	for _, l := range b.Spans[:span] {
+		x += l
+	}
+	return x
+}
+
+// LoadTypes returns the load types that load the beam, in display order
+func (b *Beam) LoadTypes() []nscp.LoadType {
+	var types []nscp.LoadType
+	for _, t := range nscp.LoadTypes {
+		for _, l := range b.Loads {
+			if l.Type == t {
+				types = append(types, t)
+				break
+			}
+		}
+	}
+	return types
+}
+
+// on returns the loads of one type acting on a span, counted from 0
+func (b *Beam) on(span int, t nscp.LoadType) []Load {
+	var loads []Load
+	for _, l := range b.Loads {
+		if l.Type == t && (l.Span == 0 || l.Span == span+1) {
+			loads = append(loads, l)
+		}
+	}
+	return loads
+}
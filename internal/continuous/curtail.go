@@ -0,0 +1,237 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+)
+
+// BarRun is a group of bars of one size along the top or bottom of a beam
+type BarRun struct {
+	Top        bool
+	Count      int
+	Diameter   float64 // mm
+	Start, End float64 // From the left end of the beam (m)
+
+	// Continuous bars run the length of the beam, lapped at the laps of
+	// their face. Other bars are added at the sagging moment of a span or
+	// the hogging moment of a support, and cut off past where the
+	// continuous bars suffice.
+	Continuous bool
+	Span       int     // Span of added bottom bars, counted from 1
+	Support    float64 // Support of added top bars (m)
+
+	// Where the continuous bars suffice, inside the ends (m)
+	TheoreticalStart, TheoreticalEnd float64
+}
+
+// Lap is a lap splice of the continuous bars of a face
+type Lap struct {
+	Top        bool
+	Start, End float64 // From the left end of the beam (m)
+	Class      string
+}
+
+// Curtailment holds the bars of a beam for the moments of a diagram: the
+// continuous top and bottom bars with their laps, and the added bars with
+// their cutoff points
+type Curtailment struct {
+	Runs []BarRun
+	Laps []Lap
+
+	// Lengths (mm)
+	LdTop, LdBottom   float64 // Development length of top and bottom bars
+	LapTop, LapBottom float64 // Tension lap splice length
+	Extension         float64 // Past the theoretical cutoff, max(d, 12db)
+
+	// Continuous top and bottom bars and their strength φMn (kN-m)
+	ContinuousTop, ContinuousBottom int
+	CapacityTop, CapacityBottom     float64
+}
+
+// Curtail lays out bars of diameter db (mm) in the rectangular section for
+// the moments of a diagram. At least a third of the bars of the largest
+// moment of each face, and not fewer than two, run the length of the beam:
+// the bottom bars lapped over the interior supports and the top bars at
+// midspan, where their stress is least. The other bars extend max(d, 12db)
+// past where the continuous bars suffice, and a development length past the
+// largest moment.
+func (b *Beam) Curtail(d Diagram, section *beam.SinglyReinforced, db float64) (*Curtailment, error) {
+	if db <= 0 {
+		return nil, fmt.Errorf("invalid bar diameter: %.1f mm", db)
+	}
+	code := codes.OrDefault(section.Code)
+	area := math.Pi * db * db / 4
+	lambda := section.Lambda
+	if lambda <= 0 {
+		lambda = 1
+	}
+
+	bars := func(mu float64, where string) (int, error) {
+		if mu <= 0 {
+			return 0, nil
+		}
+		r, err := section.Design(mu)
+		if err != nil {
+			return 0, err
+		}
+		if !r.IsAdequate {
+			return 0, fmt.Errorf("%s: %s", where, r.Message)
+		}
+		return int(math.Ceil(r.AsRequired / area)), nil
+	}
+	capacity := func(count int) (float64, error) {
+		r, err := section.Analyze(float64(count) * area)
+		if err != nil {
+			return 0, err
+		}
+		return r.PhiMn, nil
+	}
+
+	c := &Curtailment{
+		LdTop:     code.DevelopmentLength(db, section.Fc, section.Fy, lambda, true),
+		LdBottom:  code.DevelopmentLength(db, section.Fc, section.Fy, lambda, false),
+		Extension: math.Max(section.EffectiveDepth, 12*db),
+	}
+	var class string
+	c.LapTop, class = code.TensionLapLength(db, section.Fc, section.Fy, lambda, true, 1, 1)
+	c.LapBottom, _ = code.TensionLapLength(db, section.Fc, section.Fy, lambda, false, 1, 1)
+	ext := c.Extension / 1000
+
+	// Bars at the largest sagging moment of each span
+	sagging := make([]int, len(b.Spans))
+	peaks := make([]float64, len(b.Spans))
+	for i := range b.Spans {
+		mu := 0.0
+		for _, p := range d.Points {
+			if p.Span == i+1 && p.MaxMoment > mu {
+				mu, peaks[i] = p.MaxMoment, p.X
+			}
+		}
+		n, err := bars(mu, fmt.Sprintf("span %d", i+1))
+		if err != nil {
+			return nil, err
+		}
+		sagging[i] = n
+	}
+
+	// Bars at the largest hogging moment of each support
+	var supports []float64
+	var hogging []int
+	for i := 0; i <= len(b.Spans); i++ {
+		x := b.Start(i)
+		mu := 0.0
+		for _, p := range d.Points {
+			if math.Abs(p.X-x) < 1e-9 {
+				mu = math.Max(mu, -p.MinMoment)
+			}
+		}
+		n, err := bars(mu, fmt.Sprintf("support at %.2f m", x))
+		if err != nil {
+			return nil, err
+		}
+		supports, hogging = append(supports, x), append(hogging, n)
+	}
+
+	c.ContinuousBottom = continuousBars(sagging)
+	c.ContinuousTop = continuousBars(hogging)
+	var err error
+	if c.CapacityBottom, err = capacity(c.ContinuousBottom); err != nil {
+		return nil, err
+	}
+	if c.CapacityTop, err = capacity(c.ContinuousTop); err != nil {
+		return nil, err
+	}
+
+	length := b.Length()
+	c.Runs = append(c.Runs,
+		BarRun{Top: true, Count: c.ContinuousTop, Diameter: db, Start: 0, End: length, Continuous: true},
+		BarRun{Count: c.ContinuousBottom, Diameter: db, Start: 0, End: length, Continuous: true})
+
+	// Added bottom bars, cut off on both sides of the largest sagging moment
+	for i, n := range sagging {
+		if n <= c.ContinuousBottom {
+			continue
+		}
+		start, end := b.Start(i), b.Start(i)+b.Spans[i]
+		run := BarRun{Count: n - c.ContinuousBottom, Diameter: db, Span: i + 1}
+		run.TheoreticalStart = d.reach(peaks[i], start, c.CapacityBottom, false)
+		run.TheoreticalEnd = d.reach(peaks[i], end, c.CapacityBottom, false)
+		run.Start = math.Max(math.Min(run.TheoreticalStart-ext, peaks[i]-c.LdBottom/1000), start)
+		run.End = math.Min(math.Max(run.TheoreticalEnd+ext, peaks[i]+c.LdBottom/1000), end)
+		c.Runs = append(c.Runs, run)
+	}
+
+	// Added top bars, cut off on both sides of each support
+	for i, n := range hogging {
+		if n <= c.ContinuousTop {
+			continue
+		}
+		x := supports[i]
+		run := BarRun{Top: true, Count: n - c.ContinuousTop, Diameter: db, Support: x}
+		run.TheoreticalStart = d.reach(x, 0, c.CapacityTop, true)
+		run.TheoreticalEnd = d.reach(x, length, c.CapacityTop, true)
+		run.Start = math.Max(math.Min(run.TheoreticalStart-ext, x-c.LdTop/1000), 0)
+		run.End = math.Min(math.Max(run.TheoreticalEnd+ext, x+c.LdTop/1000), length)
+		c.Runs = append(c.Runs, run)
+	}
+
+	// Laps of the bottom bars over the interior supports and of the top
+	// bars at midspan
+	if len(b.Spans) > 1 {
+		for i := range b.Spans {
+			if i > 0 {
+				x := b.Start(i)
+				c.Laps = append(c.Laps, Lap{Start: x - c.LapBottom/2000, End: x + c.LapBottom/2000, Class: class})
+			}
+			x := b.Start(i) + b.Spans[i]/2
+			c.Laps = append(c.Laps, Lap{Top: true, Start: x - c.LapTop/2000, End: x + c.LapTop/2000, Class: class})
+		}
+	}
+	return c, nil
+}
+
+// continuousBars returns the bars run the length of a face: a third of the
+// most bars needed, and not fewer than two
+func continuousBars(needed []int) int {
+	n := 2
+	for _, m := range needed {
+		n = max(n, (m+2)/3)
+	}
+	return n
+}
+
+// reach returns the position between from and to (m) nearest from where
+// the sagging (or hogging when top) moment of the diagram falls to a
+// capacity (kN-m), or to when it does not
+func (d Diagram) reach(from, to, capacity float64, top bool) float64 {
+	moment := func(p DiagramPoint) float64 {
+		if top {
+			return -p.MinMoment
+		}
+		return p.MaxMoment
+	}
+	// Points from the start in the direction of to
+	var points []DiagramPoint
+	for _, p := range d.Points {
+		if (p.X >= from && p.X <= to) || (p.X <= from && p.X >= to) {
+			points = append(points, p)
+		}
+	}
+	if to < from {
+		slices.Reverse(points)
+	}
+	for i := 1; i < len(points); i++ {
+		m0, m1 := moment(points[i-1]), moment(points[i])
+		if m0 >= capacity && m1 < capacity {
+			if m0 == m1 {
+				return points[i].X
+			}
+			return points[i-1].X + (points[i].X-points[i-1].X)*(m0-capacity)/(m0-m1)
+		}
+	}
+	return to
+}
@@ -0,0 +1,106 @@
+package cost
+
+import (
+	"fmt"
+	"math"
+)
+
+// SteelDensity is the unit mass of reinforcing steel (kg/m³)
+const SteelDensity = 7850.0
+
+// Default unit costs (Philippine pesos, typical 2025 supply-and-install rates)
+const (
+	DefaultConcretePrice = 6500.0 // per m³ of concrete
+	DefaultSteelPrice    = 75.0   // per kg of reinforcing steel
+	DefaultFormworkPrice = 650.0  // per m² of contact area
+)
+
+// UnitCosts are the prices of the materials of a member
+type UnitCosts struct {
+	Concrete float64 `json:"concrete"` // Per m³
+	Steel    float64 `json:"steel"`    // Per kg
+	Formwork float64 `json:"formwork"` // Per m² of formed surface
+}
+
+// DefaultUnitCosts returns the default unit costs
+func DefaultUnitCosts() UnitCosts {
+	return UnitCosts{Concrete: DefaultConcretePrice, Steel: DefaultSteelPrice, Formwork: DefaultFormworkPrice}
+}
+
+// Validate checks that no unit cost is negative
+func (u UnitCosts) Validate() error {
+	if u.Concrete < 0 || u.Steel < 0 || u.Formwork < 0 {
+		return fmt.Errorf("unit costs must not be negative")
+	}
+	return nil
+}
+
+// Quantities are the material quantities of a member and their cost
+type Quantities struct {
+	Length   float64 `json:"length"`   // m
+	Concrete float64 `json:"concrete"` // m³
+	Steel    float64 `json:"steel"`    // kg
+	Formwork float64 `json:"formwork"` // m²
+
+	ConcreteCost float64 `json:"concrete_cost"`
+	SteelCost    float64 `json:"steel_cost"`
+	FormworkCost float64 `json:"formwork_cost"`
+	Total        float64 `json:"total"`
+}
+
+// RectangularBeam returns the quantities of a rectangular beam of width and
+// height (mm) over a length (m) with longitudinal steel area (mm²) and
+// stirrups of one bar area (mm²) and developed length (mm) at a spacing (mm).
+// Formwork covers the soffit and both sides.
+func RectangularBeam(width, height, length, steelArea, stirrupArea, stirrupLength, spacing float64, costs UnitCosts) Quantities {
+	return Member(width*height, width+2*height, length, steelArea, stirrupArea, stirrupLength, spacing, costs)
+}
+
+// Member returns the quantities of a member of any cross section of a
+// concrete area (mm²) and formed perimeter (mm) over a length (m), with
+// longitudinal steel and stirrups as for RectangularBeam
+func Member(area, formedPerimeter, length, steelArea, stirrupArea, stirrupLength, spacing float64, costs UnitCosts) Quantities {
+	q := Quantities{Length: length}
+	q.Concrete = area / 1e6 * length
+	q.Steel = steelArea / 1e6 * length * SteelDensity
+	if spacing > 0 {
+		count := length * 1e3 / spacing
+		q.Steel += count * stirrupArea * stirrupLength / 1e9 * SteelDensity
+	}
+	q.Formwork = formedPerimeter / 1e3 * length
+	q.price(costs)
+	return q
+}
+
+// StirrupLength returns the developed length (mm) of a closed stirrup with
+// 135° hooks in a width × height section with a clear cover, for bar diameter db
+func StirrupLength(width, height, clearCover, db float64) float64 {
+	return HoopLength(2*(width-2*clearCover)+2*(height-2*clearCover), db)
+}
+
+// HoopLength returns the developed length (mm) of a closed hoop of a
+// perimeter (mm) with two 135° hooks, for bar diameter db
+func HoopLength(perimeter, db float64) float64 {
+	return perimeter + 2*math.Max(6*db, 75)
+}
+
+// Add adds the quantities and costs of another member, e.g. for the totals
+// of a project
+func (q *Quantities) Add(other Quantities) {
+	q.Length += other.Length
+	q.Concrete += other.Concrete
+	q.Steel += other.Steel
+	q.Formwork += other.Formwork
+	q.ConcreteCost += other.ConcreteCost
+	q.SteelCost += other.SteelCost
+	q.FormworkCost += other.FormworkCost
+	q.Total += other.Total
+}
+
+// price sets the costs of the quantities
+func (q *Quantities) price(costs UnitCosts) {
+	q.ConcreteCost = q.Concrete * costs.Concrete
+	q.SteelCost = q.Steel * costs.Steel
+	q.FormworkCost = q.Formwork * costs.Formwork
+	q.Total = q.ConcreteCost + q.SteelCost + q.FormworkCost
+}
@@ -2,7 +2,10 @@ package diagram
 
 import (
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
 )
 
 // Point represents a 2D coordinate for section vertices
@@ -45,15 +48,41 @@ type SectionDiagramData struct {
 	TensionYields bool
 	CompYields    bool
 	IsDoubly      bool
+
+	// Bars at their actual positions and diameters. Steel areas given
+	// without bars are drawn as 20 mm bars at the steel levels.
+	Bars []Bar
+
+	// Clear cover to the stirrups and stirrup diameter of a rectangular
+	// section (mm), drawn as the stirrup outline when positive
+	ClearCover      float64
+	StirrupDiameter float64
+
+	// Maximum spacing of the bars nearest the tension face allowed by crack
+	// control (mm); the gaps between them wider than it are shaded when
+	// positive
+	MaxBarSpacing float64
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data SectionDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
 }
 
 // DrawASCIISectionDiagram creates an ASCII representation of beam section with stress block
 func DrawASCIISectionDiagram(data SectionDiagramData) string {
 	var sb strings.Builder
+	u := data.system()
 
 	// Scale factors for ASCII drawing
-	widthChars := 30
-	heightChars := 20
+	widthChars := textColumns(30)
+	heightChars := textRows(20)
 
 	// Calculate proportions
 	naRatio := data.NeutralAxisDepth / data.Height
@@ -67,47 +96,32 @@ func DrawASCIISectionDiagram(data SectionDiagramData) string {
 	compLine := heightChars - int(compY*float64(heightChars))
 
 	sb.WriteString("\n")
-	sb.WriteString("  BEAM SECTION                    STRAIN              STRESS\n")
-	sb.WriteString("  ────────────                    ──────              ──────\n")
+	sb.WriteString(fmt.Sprintf("  %-*s%-20sSTRESS\n", widthChars+2, "BEAM SECTION", "STRAIN"))
+	sb.WriteString(fmt.Sprintf("  %-*s%-20s──────\n", widthChars+2, "────────────", "──────"))
 
+	cells, interior := sectionCells(data, widthChars+2, heightChars+1)
 	for i := 0; i <= heightChars; i++ {
-		// Section column
-		if i == 0 {
-			sb.WriteString(fmt.Sprintf("  ┌%s┐", strings.Repeat("─", widthChars)))
-		} else if i == heightChars {
-			sb.WriteString(fmt.Sprintf("  └%s┘", strings.Repeat("─", widthChars)))
-		} else {
-			// Fill with stress block shading
-			var fill string
+		// Section column, shaded over the stress block
+		row := cells[i]
+		if i > 0 && i < heightChars {
 			if i <= aLine {
-				// Stress block region (compression)
-				fill = strings.Repeat("░", widthChars)
-			} else {
-				fill = strings.Repeat(" ", widthChars)
+				for j := range row {
+					if interior[i][j] {
+						row[j] = '░'
+					}
+				}
 			}
-
-			// Add compression steel marker
 			if data.IsDoubly && i == compLine {
-				mid := widthChars / 2
-				fill = fill[:mid-2] + "●──●" + fill[mid+2:]
+				markBars(row, interior[i], "●──●")
 			}
-
-			// Add tension steel marker
 			if i == tensionLine {
-				mid := widthChars / 2
-				if widthChars >= 10 {
-					fill = fill[:mid-3] + "●────●" + fill[mid+3:]
-				}
-			}
-
-			// Neutral axis marker
-			if i == naLine {
-				sb.WriteString(fmt.Sprintf("  │%s│", fill))
-				sb.WriteString(" ◄─ N.A.")
-			} else {
-				sb.WriteString(fmt.Sprintf("  │%s│", fill))
+				markBars(row, interior[i], "●────●")
 			}
 		}
+		sb.WriteString("  " + string(row))
+		if i == naLine && i > 0 && i < heightChars {
+			sb.WriteString(" ◄─ N.A.")
+		}
 
 		// Strain diagram column
 		sb.WriteString("    ")
@@ -137,13 +151,13 @@ func DrawASCIISectionDiagram(data SectionDiagramData) string {
 
 		// Stress diagram column
 		if i == 0 {
-			sb.WriteString(fmt.Sprintf("      ┌── 0.85f'c = %.1f MPa", data.Fc))
+			sb.WriteString("      ┌── 0.85f'c = " + u.Stress.Format(data.Fc, 1))
 		} else if i == aLine && aLine > 0 {
 			sb.WriteString("      └── (stress block)")
 		} else if i == tensionLine {
-			sb.WriteString(fmt.Sprintf("      ── fs = %.1f MPa", data.FsTension))
+			sb.WriteString("      ── fs = " + u.Stress.Format(data.FsTension, 1))
 		} else if data.IsDoubly && i == compLine {
-			sb.WriteString(fmt.Sprintf("      ── f'sc = %.1f MPa", data.FsComp))
+			sb.WriteString("      ── f'sc = " + u.Stress.Format(data.FsComp, 1))
 		}
 
 		sb.WriteString("\n")
@@ -154,18 +168,127 @@ func DrawASCIISectionDiagram(data SectionDiagramData) string {
 	sb.WriteString("  Legend:\n")
 	sb.WriteString("  ░░░ = Compression zone (stress block)\n")
 	sb.WriteString("  ●●● = Reinforcement\n")
-	sb.WriteString(fmt.Sprintf("  N.A. = Neutral Axis at c = %.1f mm from top\n", data.NeutralAxisDepth))
-	sb.WriteString(fmt.Sprintf("  Stress block depth a = %.1f mm\n", data.StressBlockDepth))
+	sb.WriteString(fmt.Sprintf("  N.A. = Neutral Axis at c = %s from top\n", u.Length.Format(data.NeutralAxisDepth, 1)))
+	sb.WriteString(fmt.Sprintf("  Stress block depth a = %s\n", u.Length.Format(data.StressBlockDepth, 1)))
+
+	return textOut(sb.String())
+}
+
+// sectionCells draws the outline of the section over a grid of columns by
+// rows of characters, the outline of its vertices or a rectangle, and
+// reports the blank cells inside it. The narrow web and wide flange of T and
+// L sections keep their widths, so that the stress block shaded over them is
+// the compression zone clipped to the section.
+func sectionCells(data SectionDiagramData, columns, rows int) (cells [][]rune, interior [][]bool) {
+	vertices := data.Vertices
+	if len(vertices) < 3 {
+		vertices = []Point{{X: 0, Y: 0}, {X: data.Width, Y: 0}, {X: data.Width, Y: data.Height}, {X: 0, Y: data.Height}}
+	}
+	minX, maxX := vertices[0].X, vertices[0].X
+	minY, maxY := vertices[0].Y, vertices[0].Y
+	for _, v := range vertices {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		minY, maxY = math.Min(minY, v.Y), math.Max(maxY, v.Y)
+	}
+
+	// Cells whose centers are inside the section
+	inside := make([][]bool, rows)
+	for i := range inside {
+		inside[i] = make([]bool, columns)
+		y := maxY - (float64(i)+0.5)/float64(rows)*(maxY-minY)
+		for j := range inside[i] {
+			x := minX + (float64(j)+0.5)/float64(columns)*(maxX-minX)
+			inside[i][j] = insidePolygon(vertices, x, y)
+		}
+	}
+	in := func(i, j int) bool {
+		return i >= 0 && i < rows && j >= 0 && j < columns && inside[i][j]
+	}
 
-	return sb.String()
+	cells, interior = make([][]rune, rows), make([][]bool, rows)
+	for i := range cells {
+		cells[i] = []rune(strings.Repeat(" ", columns))
+		interior[i] = make([]bool, columns)
+		for j := range cells[i] {
+			if !inside[i][j] {
+				continue
+			}
+			up, down, left, right := !in(i-1, j), !in(i+1, j), !in(i, j-1), !in(i, j+1)
+			var r rune
+			switch {
+			case up && left:
+				r = '┌'
+			case up && right:
+				r = '┐'
+			case down && left:
+				r = '└'
+			case down && right:
+				r = '┘'
+			case up || down:
+				r = '─'
+			case left || right:
+				r = '│'
+
+			// Inner corners, where a flange meets a web
+			case !in(i+1, j-1):
+				r = '┐'
+			case !in(i+1, j+1):
+				r = '┌'
+			case !in(i-1, j-1):
+				r = '┘'
+			case !in(i-1, j+1):
+				r = '└'
+			default:
+				interior[i][j] = true
+				continue
+			}
+			cells[i][j] = r
+		}
+	}
+	return cells, interior
+}
+
+// markBars writes a bar marker over the middle of the interior cells of a
+// row of the section, shortened to fit a narrow web
+func markBars(row []rune, interior []bool, marker string) {
+	lo, hi := -1, -1
+	for j, in := range interior {
+		if in {
+			if lo < 0 {
+				lo = j
+			}
+			hi = j
+		}
+	}
+	if lo < 0 {
+		return
+	}
+	m := []rune(marker)
+	if n := hi - lo + 1; len(m) > n {
+		m = []rune(strings.Repeat("●", min(n, 2)))
+	}
+	copy(row[(lo+hi+1)/2-len(m)/2:], m)
+}
+
+// insidePolygon reports whether a point is inside a polygon, by the edges
+// crossed by a ray from it to the right
+func insidePolygon(vertices []Point, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(vertices)-1; i < len(vertices); j, i = i, i+1 {
+		a, b := vertices[i], vertices[j]
+		if (a.Y > y) != (b.Y > y) && x < a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
 }
 
 // DrawStrainDiagram creates an ASCII strain distribution diagram
 func DrawStrainDiagram(data SectionDiagramData) string {
 	var sb strings.Builder
 
-	height := 15
-	width := 40
+	height := textRows(15)
+	width := textColumns(40)
 
 	// Scale strains to fit
 	maxStrain := max(data.EpsilonCU, data.EpsilonT)
@@ -221,33 +344,162 @@ func DrawStrainDiagram(data SectionDiagramData) string {
 	yieldBar := int(data.EpsilonY * scale)
 	sb.WriteString(fmt.Sprintf("\n  εy = %.4f %s (yield strain)\n", data.EpsilonY, strings.Repeat("─", yieldBar)+"┤"))
 
-	return sb.String()
+	return textOut(sb.String())
 }
 
-// DrawStressBlock creates a simple stress block diagram
+// DrawStressBlock draws the equivalent rectangular stress block to the scale
+// of the section depth, with the resultants Cc, Cs when doubly reinforced,
+// and T as arrows at their levels scaled to the largest, the lever arms of
+// the compressive forces about the tension steel, and the moment of the
+// couple
 func DrawStressBlock(data SectionDiagramData) string {
 	var sb strings.Builder
+	u := data.system()
 
 	sb.WriteString("\n")
 	sb.WriteString("  EQUIVALENT RECTANGULAR STRESS BLOCK\n")
 	sb.WriteString("  ────────────────────────────────────\n\n")
+	a := data.StressBlockDepth
+	if data.Height <= 0 || a <= 0 || data.Fc <= 0 {
+		sb.WriteString("  (no stress block)\n")
+		return textOut(sb.String())
+	}
+	sb.WriteString(fmt.Sprintf("  0.85f'c = %s over a = %s (c = %s)\n\n",
+		u.Stress.Format(data.Fc, 1), u.Length.Format(a, 1), u.Length.Format(data.NeutralAxisDepth, 1)))
+
+	const block = 8 // Width of the stress block
+	height := textRows(15)
+	arrows := textColumns(20)
+	row := func(y float64) int {
+		return clampInt(int(math.Round((data.Height-y)/data.Height*float64(height))), 0, height)
+	}
+	blockRows := clampInt(row(data.Height-a), 1, height)
+	naRow := row(data.Height - data.NeutralAxisDepth)
+
+	// Rows of the forces, the compressive ones a row apart
+	forces := resultants(data)
+	rows := make([]int, len(forces))
+	largest, tRow, yt := 0.0, height, forces[len(forces)-1].y
+	for i, f := range forces {
+		largest = math.Max(largest, math.Abs(f.force))
+		rows[i] = row(f.y)
+		if i > 0 && !f.tension && rows[i] <= rows[i-1] {
+			rows[i] = min(rows[i-1]+1, height)
+		}
+		if f.tension {
+			tRow = rows[i]
+		}
+	}
+	length := func(f resultant) int {
+		if largest <= 0 {
+			return 1
+		}
+		return clampInt(int(math.Round(float64(arrows)*math.Abs(f.force)/largest)), 1, arrows)
+	}
 
-	// Simplified view
-	sb.WriteString("       ┌───────────────┐\n")
-	sb.WriteString("       │               │\n")
-	sb.WriteString(fmt.Sprintf("       │  0.85f'c      │ ← a = %.1f mm\n", data.StressBlockDepth))
-	sb.WriteString(fmt.Sprintf("       │  = %.1f MPa   │\n", data.Fc))
-	sb.WriteString("       │               │\n")
-	sb.WriteString("       └───────────────┘ ─── Cc = 0.85·f'c·b·a\n")
-	sb.WriteString("       ─ ─ ─ ─ ─ ─ ─ ─ ─ ← N.A. (c = %.1f mm)\n")
-	sb.WriteString("                         │\n")
-	sb.WriteString("                         │  (d - a/2)\n")
-	sb.WriteString("                         │\n")
-	sb.WriteString("       ●═══════════════● ← Tension Steel\n")
-	sb.WriteString(fmt.Sprintf("         As = %.1f mm²\n", data.TensionSteelArea))
-	sb.WriteString(fmt.Sprintf("         T = As·fs = %.1f kN\n", data.TensionSteelArea*data.FsTension/1000))
-
-	return sb.String()
+	// Lever arms of the compressive forces, labeled at their middle rows
+	type lever struct {
+		from, label int
+		text        string
+	}
+	var levers []lever
+	for i, f := range forces {
+		if f.tension || rows[i] >= tRow {
+			continue
+		}
+		l := lever{from: rows[i], label: (rows[i] + tRow) / 2, text: f.lever + " = " + u.Length.Format(f.y-yt, 1)}
+		if len(levers) > 0 && l.label == levers[len(levers)-1].label {
+			l.label++
+		}
+		levers = append(levers, l)
+	}
+
+	for i := 0; i <= height; i++ {
+		var line strings.Builder
+		name := ""
+		switch i {
+		case 0:
+			name = "Top"
+		case naRow:
+			name = "N.A."
+		case tRow:
+			name = "Steel"
+		case height:
+			name = "Bottom"
+		}
+		line.WriteString(fmt.Sprintf("  %-6s ", name))
+
+		// The block, or the neutral axis, then the arrow of a force
+		cells := "│" + strings.Repeat(" ", block+arrows)
+		switch {
+		case i < blockRows:
+			cells = "│" + strings.Repeat("█", block) + strings.Repeat(" ", arrows)
+		case i == naRow:
+			cells = "├" + strings.Repeat("─ ", block/2) + strings.Repeat(" ", arrows)
+		}
+		text := ""
+		for k, f := range forces {
+			if rows[k] != i {
+				continue
+			}
+			n := length(f)
+			arrow := "◄" + strings.Repeat("─", n-1)
+			if f.tension {
+				arrow = strings.Repeat("─", n-1) + "▶"
+				cells = "●" + arrow + strings.Repeat(" ", block+arrows-n)
+			} else {
+				cells = string([]rune(cells)[:block+1]) + arrow + strings.Repeat(" ", arrows-n)
+			}
+			text = f.label + " = " + u.Force.Format(f.force/1000, 1)
+		}
+		line.WriteString(cells)
+		line.WriteString(fmt.Sprintf(" %-18s", text))
+
+		// Lever arm columns, then the label of a lever arm at its middle
+		label := ""
+		for _, l := range levers {
+			switch {
+			case i == l.from:
+				line.WriteString("┬ ")
+			case i == tRow:
+				line.WriteString("┴ ")
+			case i > l.from && i < tRow:
+				line.WriteString("│ ")
+			default:
+				line.WriteString("  ")
+			}
+			if i == l.label {
+				label = l.text
+			}
+		}
+		line.WriteString(label)
+		sb.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	// Equilibrium and the moment of the compressive forces about the
+	// tension steel
+	var compression, tension, mn float64
+	var terms []string
+	for _, f := range forces {
+		if f.tension {
+			tension += f.force / 1000
+			continue
+		}
+		compression += f.force / 1000
+		mn += f.force * (f.y - yt) / 1e6
+		lever := f.lever
+		if strings.Contains(lever, " ") {
+			lever = "(" + lever + ")"
+		}
+		terms = append(terms, f.label+"·"+lever)
+	}
+	sum := "Cc"
+	if len(forces) > 2 {
+		sum = "Cc + Cs"
+	}
+	sb.WriteString(fmt.Sprintf("\n  %s = %s, T = %s\n", sum, u.Force.Format(compression, 1), u.Force.Format(tension, 1)))
+	sb.WriteString(fmt.Sprintf("  Mn = %s = %s\n", strings.Join(terms, " + "), u.Moment.Format(mn, 1)))
+	return textOut(sb.String())
 }
 
 // DrawSummaryBox creates a summary box for results
@@ -271,7 +523,7 @@ func DrawSummaryBox(title string, lines []string) string {
 	}
 	sb.WriteString(fmt.Sprintf("  ╚%s╝\n", border))
 
-	return sb.String()
+	return textOut(sb.String())
 }
 
 func max(a, b float64) float64 {
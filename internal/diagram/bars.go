@@ -0,0 +1,183 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// estimatedBarDiameter is the bar size drawn for a steel area given without
+// its bars (mm)
+const estimatedBarDiameter = 20
+
+// Bar is a reinforcing bar drawn to scale at its position in the section
+type Bar struct {
+	X, Y     float64 // Center from the left and bottom of the section (mm)
+	Diameter float64 // mm
+	Tension  bool
+
+	// Mark of the bar in the bar bending schedule, when the layers are not
+	// marked by face, see barMarks
+	Mark string
+}
+
+// LayerBars spaces count bars of a diameter evenly in a layer at height y,
+// the outer bars touching the inner faces xMin and xMax of the stirrups.
+// A single bar is centered.
+func LayerBars(count int, diameter, y, xMin, xMax float64, tension bool) []Bar {
+	if count < 1 {
+		return nil
+	}
+	first, last := xMin+diameter/2, xMax-diameter/2
+	if count == 1 || last < first {
+		first, last = (xMin+xMax)/2, (xMin+xMax)/2
+	}
+	bars := make([]Bar, count)
+	for i := range bars {
+		x := first
+		if count > 1 {
+			x += (last - first) * float64(i) / float64(count-1)
+		}
+		bars[i] = Bar{X: x, Y: y, Diameter: diameter, Tension: tension}
+	}
+	return bars
+}
+
+// OutlineLayerBars places count bars of a diameter in a layer at height y
+// of a section, given by its vertices or as a width×height rectangle, inset
+// from the sides by the distance of the layer from the nearer of top and
+// bottom, i.e. with equal side and bottom cover
+func OutlineLayerBars(vertices []Point, width, height float64, count int, diameter, y float64, tension bool) []Bar {
+	xMin, xMax := findWidthAtY(vertices, y, 0, width)
+	inset := math.Min(y, height-y) - diameter/2
+	inset = math.Max(math.Min(inset, (xMax-xMin)/4), 0)
+	return LayerBars(count, diameter, y, xMin+inset, xMax-inset, tension)
+}
+
+// estimateBars lays out a steel area given without its bars as bars of
+// estimatedBarDiameter in one layer at height y
+func estimateBars(data SectionDiagramData, area, y float64, tension bool) []Bar {
+	if area <= 0 {
+		return nil
+	}
+	barArea := math.Pi * estimatedBarDiameter * estimatedBarDiameter / 4
+	count := int(math.Max(math.Ceil(area/barArea), 2))
+	return OutlineLayerBars(data.Vertices, data.Width, data.Height, count, estimatedBarDiameter, y, tension)
+}
+
+// sectionBars returns the bars of a section diagram, or the steel areas
+// given without bars estimated as bars
+func sectionBars(data SectionDiagramData) []Bar {
+	if len(data.Bars) > 0 {
+		return data.Bars
+	}
+	bars := estimateBars(data, data.TensionSteelArea, data.TensionSteelY, true)
+	if data.IsDoubly {
+		bars = append(bars, estimateBars(data, data.CompSteelArea, data.Height-data.CompSteelY, false)...)
+	}
+	return bars
+}
+
+// barRow is a row of bars of one size and mark at one level, for a callout
+type barRow struct {
+	Y, Diameter float64
+	Count       int
+	Mark        string
+	Last        Bar // Rightmost bar, where the leader starts
+}
+
+// barRows groups bars in rows of one size at one level, from the top
+func barRows(bars []Bar) []barRow {
+	var rows []barRow
+	for _, b := range bars {
+		found := false
+		for i := range rows {
+			r := &rows[i]
+			if math.Abs(r.Y-b.Y) < 1 && r.Diameter == b.Diameter && r.Mark == b.Mark {
+				r.Count++
+				if b.X > r.Last.X {
+					r.Last = b
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			rows = append(rows, barRow{Y: b.Y, Diameter: b.Diameter, Count: 1, Mark: b.Mark, Last: b})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Y > rows[j].Y })
+	return rows
+}
+
+// barMarks marks the rows of bars, from the top, that are not marked in the
+// schedule by their face and layer: T1, T2, ... counted down from the top
+// face above mid-height and B1, B2, ... counted up from the bottom face
+// below it, the rows at one level sharing a mark
+func barMarks(rows []barRow, height float64) {
+	mark := func(face string, order []int) {
+		layer, level := 0, math.NaN()
+		for _, i := range order {
+			r := &rows[i]
+			if !(math.Abs(r.Y-level) < 1) {
+				layer, level = layer+1, r.Y
+			}
+			if r.Mark == "" {
+				r.Mark = fmt.Sprintf("%s%d", face, layer)
+			}
+		}
+	}
+	var top, bottom []int
+	for i, r := range rows {
+		if r.Y > height/2 {
+			top = append(top, i)
+		} else {
+			bottom = append([]int{i}, bottom...)
+		}
+	}
+	mark("T", top)
+	mark("B", bottom)
+}
+
+// circle returns a closed polygon of a circle in plot units
+func circle(x, y, diameter float64) plotter.XYs {
+	const segments = 24
+	pts := make(plotter.XYs, segments)
+	for i := range pts {
+		angle := 2 * math.Pi * float64(i) / segments
+		pts[i] = plotter.XY{X: x + diameter/2*math.Cos(angle), Y: y + diameter/2*math.Sin(angle)}
+	}
+	return pts
+}
+
+// EqualScale widens the range of one axis of a section plot so that it is
+// drawn to scale on a canvas of another size than its own
+func EqualScale(p *plot.Plot, c draw.Canvas) {
+	equalScale(p, c)
+}
+
+// equalScale widens the range of one axis of a plot drawn on a canvas so
+// that a mm is as long along both axes, keeping circles round. The data area
+// depends on the labels near its edges, so the ranges are refined until they
+// settle.
+func equalScale(p *plot.Plot, c draw.Canvas) {
+	for i := 0; i < 5; i++ {
+		da := p.DataCanvas(c)
+		dx, dy := float64(da.Max.X-da.Min.X), float64(da.Max.Y-da.Min.Y)
+		rx, ry := p.X.Max-p.X.Min, p.Y.Max-p.Y.Min
+		if dx <= 0 || dy <= 0 || rx <= 0 || ry <= 0 || math.Abs(rx/dx-ry/dy) < 1e-3*ry/dy {
+			return
+		}
+		if rx/dx > ry/dy {
+			grow := (rx/dx*dy - ry) / 2
+			p.Y.Min, p.Y.Max = p.Y.Min-grow, p.Y.Max+grow
+		} else {
+			grow := (ry/dy*dx - rx) / 2
+			p.X.Min, p.X.Max = p.X.Min-grow, p.X.Max+grow
+		}
+	}
+}
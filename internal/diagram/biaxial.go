@@ -0,0 +1,179 @@
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// BiaxialContour is a contour of the biaxial interaction surface at a design
+// axial strength, as points with X the moment about the Y axis and Y the
+// moment about the X axis (kN-m)
+type BiaxialContour struct {
+	PhiPn  float64 // kN, compression positive
+	Points []Point
+}
+
+// BiaxialDiagramData holds the contours of a biaxial interaction surface,
+// each with as many points around the section
+type BiaxialDiagramData struct {
+	Name     string // Section name for the title
+	Contours []BiaxialContour
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data BiaxialDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// ExportBiaxialDiagram exports the φMnx–φMny contours of a biaxial
+// interaction surface to a png, svg or pdf file
+func ExportBiaxialDiagram(data BiaxialDiagramData, filename string) error {
+	p, err := biaxialPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 7*vg.Inch, 7*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return biaxialSeries(data) })
+}
+
+// BiaxialDiagramSVG returns the contours of a biaxial interaction surface as
+// an SVG document
+func BiaxialDiagramSVG(data BiaxialDiagramData) ([]byte, error) {
+	p, err := biaxialPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 7*vg.Inch, 7*vg.Inch, "svg")
+}
+
+// BiaxialDiagramPNG returns the contours of a biaxial interaction surface as
+// a PNG image
+func BiaxialDiagramPNG(data BiaxialDiagramData) ([]byte, error) {
+	p, err := biaxialPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 7*vg.Inch, 7*vg.Inch, "png")
+}
+
+// BiaxialPlot returns the biaxial interaction contours as a plot, for
+// drawing with the plots of another document
+func BiaxialPlot(data BiaxialDiagramData) (*plot.Plot, error) {
+	return biaxialPlot(data)
+}
+
+// biaxialPlot draws each contour as a closed curve in its own color and
+// dashes, labeled with its axial strength
+func biaxialPlot(data BiaxialDiagramData) (*plot.Plot, error) {
+	if len(data.Contours) == 0 {
+		return nil, fmt.Errorf("no contours to plot")
+	}
+	u := data.system()
+	p := newPlot()
+	p.Title.Text = i18n.T("Biaxial Interaction φMnx-φMny")
+	if data.Name != "" {
+		p.Title.Text += " - " + data.Name
+	}
+	p.X.Label.Text = axisLabel("φMny, right in compression", u.Moment.Label)
+	p.Y.Label.Text = axisLabel("φMnx, top in compression", u.Moment.Label)
+	p.Add(newGrid())
+
+	for i, c := range data.Contours {
+		if len(c.Points) == 0 {
+			continue
+		}
+		xys := make(plotter.XYs, 0, len(c.Points)+1)
+		for _, pt := range append(c.Points, c.Points[0]) {
+			xys = append(xys, plotter.XY{X: u.Moment.FromSI(pt.X), Y: u.Moment.FromSI(pt.Y)})
+		}
+		line, err := plotter.NewLine(xys)
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(1.5)
+		line.LineStyle.Color = paletteColor(i)
+		line.LineStyle.Dashes = plotutil.Dashes(i)
+		p.Add(line)
+		addLegend(p, "φPn = "+u.Force.Format(c.PhiPn, 0), line)
+	}
+	p.Legend.Top = true
+	p.Legend.Left = true
+	return p, nil
+}
+
+// ExportBiaxialSurface writes the biaxial interaction surface through its
+// contours, from the lowest axial strength to the highest, as a Wavefront
+// OBJ mesh with x the moment about the Y axis, y the moment about the X
+// axis and z the axial strength, in the units of the diagram, for viewing
+// in 3D tools. Each band between two contours is a ring of quadrilaterals
+// and the lowest and highest contours are capped.
+func ExportBiaxialSurface(data BiaxialDiagramData, filename string) error {
+	if len(data.Contours) < 2 {
+		return fmt.Errorf("a surface needs at least two contours")
+	}
+	n := len(data.Contours[0].Points)
+	for _, c := range data.Contours {
+		if len(c.Points) != n || n < 3 {
+			return fmt.Errorf("the contours of a surface need the same number of points, at least 3")
+		}
+	}
+	u := data.system()
+
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	fmt.Fprintf(w, "# Biaxial interaction surface %s\n", data.Name)
+	fmt.Fprintf(w, "# x = φMny (%s), y = φMnx (%s), z = φPn (%s)\n", u.Moment.Label, u.Moment.Label, u.Force.Label)
+	for _, c := range data.Contours {
+		for _, pt := range c.Points {
+			fmt.Fprintf(w, "v %.6g %.6g %.6g\n", u.Moment.FromSI(pt.X), u.Moment.FromSI(pt.Y), u.Force.FromSI(c.PhiPn))
+		}
+	}
+
+	// Vertices are numbered from 1, contour by contour
+	vertex := func(contour, i int) int { return contour*n + i%n + 1 }
+	for c := 1; c < len(data.Contours); c++ {
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(w, "f %d %d %d %d\n", vertex(c-1, i), vertex(c-1, i+1), vertex(c, i+1), vertex(c, i))
+		}
+	}
+	for _, c := range []int{0, len(data.Contours) - 1} {
+		fmt.Fprint(w, "f")
+		for i := 0; i < n; i++ {
+			j := i
+			if c == 0 {
+				j = n - 1 - i // Facing down
+			}
+			fmt.Fprintf(w, " %d", vertex(c, j))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
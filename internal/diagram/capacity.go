@@ -0,0 +1,266 @@
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ShearZone is a stirrup zone of a beam with its design shear strength
+type ShearZone struct {
+	Start, End float64 // From the left end of the beam (m)
+	Spacing    float64 // mm, zero without stirrups
+	PhiVn      float64 // φ(Vc + Vs) with the stirrups of the zone, φVc without (kN)
+}
+
+// ShearCapacityData holds the factored shear along a beam against the
+// shear strength of its concrete and of its stirrup zones
+type ShearCapacityData struct {
+	Name     string  // Beam name for the title
+	Demand   []Point // Factored shear magnitude (kN) at positions along the beam (m)
+	PhiVc    float64 // Design shear strength of the concrete (kN)
+	Zones    []ShearZone
+	Supports []float64 // Positions of the supports (m)
+	Symbol   string    // Symbol of the shear, Vu when unset
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data ShearCapacityData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// symbol returns the symbol of the factored shear
+func (data ShearCapacityData) symbol() string {
+	if data.Symbol == "" {
+		return "Vu"
+	}
+	return data.Symbol
+}
+
+// ExportShearCapacityDiagram exports the factored shear along a beam against
+// the shear strength of its stirrup zones to a png, svg or pdf file
+func ExportShearCapacityDiagram(data ShearCapacityData, filename string) error {
+	p, err := shearCapacityPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 5*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return shearCapacitySeries(data) })
+}
+
+// ShearCapacityDiagramSVG returns the shear demand and capacity along a beam
+// as an SVG document
+func ShearCapacityDiagramSVG(data ShearCapacityData) ([]byte, error) {
+	p, err := shearCapacityPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "svg")
+}
+
+// ShearCapacityDiagramPNG returns the shear demand and capacity along a beam
+// as a PNG image
+func ShearCapacityDiagramPNG(data ShearCapacityData) ([]byte, error) {
+	p, err := shearCapacityPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "png")
+}
+
+// ShearCapacityPlot returns the shear demand and capacity along a beam as a
+// plot, for drawing with the plots of another document
+func ShearCapacityPlot(data ShearCapacityData) (*plot.Plot, error) {
+	return shearCapacityPlot(data)
+}
+
+// shearCapacityPlot shades where the shear needs only minimum stirrups and
+// where it needs none, then draws φVc and φVc/2 across the beam, φ(Vc + Vs)
+// as a step over the zones with their spacings, and the factored shear over
+// them
+func shearCapacityPlot(data ShearCapacityData) (*plot.Plot, error) {
+	if len(data.Demand) < 2 {
+		return nil, fmt.Errorf("no shear to plot")
+	}
+	u := data.system()
+	force := u.Force
+	p := newPlot()
+	p.Title.Text = plotTitle(data.Name, "Shear Demand and Capacity")
+	setSpanAxis(p, "Position along the beam", u)
+	p.Y.Label.Text = axisLabel("Shear", force.Label)
+	p.Add(newGrid())
+	rows := 0
+	legend := func(name string, thumbnail plot.Thumbnailer) {
+		addLegend(p, name, thumbnail)
+		rows++
+	}
+
+	length, top := 0.0, data.PhiVc
+	demand := make(plotter.XYs, len(data.Demand))
+	for i, pt := range data.Demand {
+		demand[i] = plotter.XY{X: pt.X, Y: force.FromSI(pt.Y)}
+		length, top = math.Max(length, pt.X), math.Max(top, pt.Y)
+	}
+	for _, z := range data.Zones {
+		top = math.Max(top, z.PhiVn)
+	}
+	p.X.Min, p.X.Max = 0, length
+	p.Y.Min = 0
+
+	// Where minimum stirrups govern and where stirrups may be omitted,
+	// behind the curves
+	phiVc := force.FromSI(data.PhiVc)
+	for _, s := range []struct {
+		name   string
+		lo, hi float64
+		color  color.Color
+	}{
+		{"Minimum stirrups (φVc/2 < " + data.symbol() + " ≤ φVc)", phiVc / 2, phiVc, translucent(theme.Secondary, 45)},
+		{"Stirrups may be omitted (" + data.symbol() + " ≤ φVc/2)", math.Inf(-1), phiVc / 2, translucent(theme.Pass, 45)},
+	} {
+		if b := (bands{X: within(demand, s.lo, s.hi), Color: s.color}); len(b.X) > 0 {
+			p.Add(b)
+			legend(s.name, b)
+		}
+	}
+
+	// Strength of the concrete across the beam
+	for _, c := range []struct {
+		name   string
+		factor float64
+		dashes []vg.Length
+	}{{"φVc", 1, []vg.Length{vg.Points(6), vg.Points(3)}}, {"φVc/2", 0.5, []vg.Length{vg.Points(2), vg.Points(2)}}} {
+		v := force.FromSI(data.PhiVc * c.factor)
+		line, err := plotter.NewLine(plotter.XYs{{X: 0, Y: v}, {X: length, Y: v}})
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(1)
+		line.LineStyle.Color = theme.Muted
+		line.LineStyle.Dashes = c.dashes
+		p.Add(line)
+		legend(c.name, line)
+	}
+
+	// Strength of each zone as a step, with its spacing over it
+	if len(data.Zones) > 0 {
+		var steps plotter.XYs
+		var labels plotter.XYLabels
+		for _, z := range data.Zones {
+			v := force.FromSI(z.PhiVn)
+			steps = append(steps, plotter.XY{X: z.Start, Y: v}, plotter.XY{X: z.End, Y: v})
+			if z.Spacing > 0 {
+				labels.XYs = append(labels.XYs, plotter.XY{X: (z.Start + z.End) / 2, Y: v})
+				labels.Labels = append(labels.Labels, fmt.Sprintf("@ %.*f", u.Length.Digits, u.Length.FromSI(z.Spacing)))
+			}
+		}
+		capacity, err := plotter.NewLine(steps)
+		if err != nil {
+			return nil, err
+		}
+		capacity.LineStyle.Width = vg.Points(2)
+		capacity.LineStyle.Color = theme.Primary
+		p.Add(capacity)
+		legend("φ(Vc + Vs)", capacity)
+
+		if len(labels.XYs) > 0 {
+			l, err := newLabels(labels)
+			if err != nil {
+				return nil, err
+			}
+			for i := range l.TextStyle {
+				l.TextStyle[i].XAlign, l.TextStyle[i].YAlign = draw.XCenter, draw.YBottom
+				l.TextStyle[i].Color = theme.Primary
+			}
+			l.Offset = vg.Point{Y: vg.Points(2)}
+			p.Add(l)
+		}
+	}
+
+	line, err := plotter.NewLine(demand)
+	if err != nil {
+		return nil, err
+	}
+	line.LineStyle.Width = vg.Points(2)
+	line.LineStyle.Color = theme.Alert
+	p.Add(line)
+	legend(data.symbol(), line)
+
+	p.Add(supportMarks(data.Supports))
+
+	// Headroom above the curves for the legend, to a round value for the
+	// ticks
+	p.Y.Max = force.FromSI(top) * (1.1 + legendRow*float64(rows))
+	step := math.Pow(10, math.Floor(math.Log10(p.Y.Max))) / 2
+	p.Y.Max = math.Ceil(p.Y.Max/step) * step
+	p.Legend.Top = true
+	return p, nil
+}
+
+// within returns the extents along a curve where its value is above lo and
+// at most hi
+func within(curve plotter.XYs, lo, hi float64) [][2]float64 {
+	var extents [][2]float64
+	for i := 1; i < len(curve); i++ {
+		a, b := curve[i-1], curve[i]
+		if b.X <= a.X {
+			continue
+		}
+		t0, t1 := 0.0, 1.0
+		if a.Y == b.Y {
+			if a.Y <= lo || a.Y > hi {
+				continue
+			}
+		} else {
+			tl, th := (lo-a.Y)/(b.Y-a.Y), (hi-a.Y)/(b.Y-a.Y)
+			t0, t1 = math.Max(math.Min(tl, th), 0), math.Min(math.Max(tl, th), 1)
+			if t1 <= t0 {
+				continue
+			}
+		}
+		x0, x1 := a.X+t0*(b.X-a.X), a.X+t1*(b.X-a.X)
+		if n := len(extents); n > 0 && math.Abs(extents[n-1][1]-x0) < 1e-9 {
+			extents[n-1][1] = x1
+			continue
+		}
+		extents = append(extents, [2]float64{x0, x1})
+	}
+	return extents
+}
+
+// bands is a plotter shading the full height of a plot over extents of x
+type bands struct {
+	X     [][2]float64
+	Color color.Color
+}
+
+// Plot fills each extent from the bottom to the top of the plot
+func (b bands) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	for _, x := range b.X {
+		x0, x1 := math.Max(float64(trX(x[0])), float64(c.Min.X)), math.Min(float64(trX(x[1])), float64(c.Max.X))
+		c.FillPolygon(b.Color, []vg.Point{
+			{X: vg.Length(x0), Y: c.Min.Y}, {X: vg.Length(x1), Y: c.Min.Y},
+			{X: vg.Length(x1), Y: c.Max.Y}, {X: vg.Length(x0), Y: c.Max.Y},
+		})
+	}
+}
+
+// Thumbnail draws the legend entry of the bands as a filled box
+func (b bands) Thumbnail(c *draw.Canvas) {
+	c.FillPolygon(b.Color, []vg.Point{c.Min, {X: c.Max.X, Y: c.Min.Y}, c.Max, {X: c.Min.X, Y: c.Max.Y}})
+}
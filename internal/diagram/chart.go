@@ -0,0 +1,182 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ChartCurve is a curve of a design aid chart with its marked points, e.g.
+// the limits of the steel ratio
+type ChartCurve struct {
+	Label  string
+	Points []Point
+	Marks  []ChartMark
+}
+
+// ChartMark is a marked point of a curve, drawn with the glyph of its kind
+type ChartMark struct {
+	Kind string // Legend entry of the marks of this kind, e.g. "ρmin"
+	X, Y float64
+}
+
+// ChartRegion is a named range of the x axis, e.g. the compression-controlled
+// strains, bounded by dashed lines and labeled along the bottom of the chart
+type ChartRegion struct {
+	Label    string
+	From, To float64
+}
+
+// ChartData holds a design aid chart of curves against a common axis
+type ChartData struct {
+	Title          string
+	XLabel, YLabel string
+	Curves         []ChartCurve
+	Regions        []ChartRegion
+}
+
+// ExportChart exports a design aid chart to a png, svg or pdf file
+func ExportChart(data ChartData, filename string) error {
+	p, err := chartPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 9*vg.Inch, 6*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return chartSeries(data) })
+}
+
+// ChartSVG returns a design aid chart as an SVG document
+func ChartSVG(data ChartData) ([]byte, error) {
+	p, err := chartPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 9*vg.Inch, 6*vg.Inch, "svg")
+}
+
+// ChartPNG returns a design aid chart as a PNG image
+func ChartPNG(data ChartData) ([]byte, error) {
+	p, err := chartPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 9*vg.Inch, 6*vg.Inch, "png")
+}
+
+// ChartPlot returns a design chart as a plot, for drawing with the plots of
+// another document
+func ChartPlot(data ChartData) (*plot.Plot, error) {
+	return chartPlot(data)
+}
+
+// chartPlot draws the curves of a design aid chart in distinct colors and
+// dashes, so that they stay apart in print, over a light grid, with the
+// marks of each kind in one glyph
+func chartPlot(data ChartData) (*plot.Plot, error) {
+	if len(data.Curves) == 0 {
+		return nil, fmt.Errorf("no curves to chart")
+	}
+	p := newPlot()
+	p.Title.Text = i18n.T(data.Title)
+	p.Title.TextStyle.Font.Size = font.Length(14)
+	p.X.Label.Text = i18n.T(data.XLabel)
+	p.Y.Label.Text = i18n.T(data.YLabel)
+	p.X.Label.TextStyle.Font.Size = font.Length(12)
+	p.Y.Label.TextStyle.Font.Size = font.Length(12)
+	p.X.Min, p.Y.Min = 0, 0
+
+	p.Add(newGrid())
+
+	if err := chartRegions(p, data); err != nil {
+		return nil, err
+	}
+
+	glyphs := map[string]draw.GlyphDrawer{}
+	shapes := []draw.GlyphDrawer{draw.CircleGlyph{}, draw.SquareGlyph{}, draw.TriangleGlyph{}, draw.CrossGlyph{}, draw.RingGlyph{}}
+	var kinds []string
+	for i, c := range data.Curves {
+		pts := make(plotter.XYs, len(c.Points))
+		for j, pt := range c.Points {
+			pts[j] = plotter.XY{X: pt.X, Y: pt.Y}
+		}
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(1.75)
+		line.LineStyle.Color = paletteColor(i)
+		line.LineStyle.Dashes = plotutil.Dashes(i)
+		p.Add(line)
+		addLegend(p, c.Label, line)
+
+		for _, m := range c.Marks {
+			if _, ok := glyphs[m.Kind]; !ok {
+				glyphs[m.Kind] = shapes[len(glyphs)%len(shapes)]
+				kinds = append(kinds, m.Kind)
+			}
+			s, err := plotter.NewScatter(plotter.XYs{{X: m.X, Y: m.Y}})
+			if err != nil {
+				return nil, err
+			}
+			s.GlyphStyle = draw.GlyphStyle{Color: paletteColor(i), Radius: vg.Points(3.5), Shape: glyphs[m.Kind]}
+			p.Add(s)
+		}
+	}
+	for _, kind := range kinds {
+		s, _ := plotter.NewScatter(plotter.XYs{{}})
+		s.GlyphStyle = draw.GlyphStyle{Color: theme.Ink, Radius: vg.Points(3.5), Shape: glyphs[kind]}
+		addLegend(p, kind, s)
+	}
+	p.Legend.Top = true
+	p.Legend.Left = true
+	p.Legend.XOffs, p.Legend.YOffs = vg.Points(10), vg.Points(-10)
+	return p, nil
+}
+
+// chartRegions draws the start of each region of a chart as a dashed line
+// from the axis to the top of the curves, with each label centered in its
+// region just above the axis, leaving room above the curves for the legend
+func chartRegions(p *plot.Plot, data ChartData) error {
+	if len(data.Regions) == 0 {
+		return nil
+	}
+	top := 0.0
+	for _, c := range data.Curves {
+		for _, pt := range c.Points {
+			top = math.Max(top, pt.Y)
+		}
+	}
+	p.Y.Max = 1.2 * top
+	style := draw.LineStyle{Color: theme.Muted, Width: vg.Points(0.75), Dashes: []vg.Length{vg.Points(4), vg.Points(3)}}
+	var bounds []float64
+	var labels plotter.XYLabels
+	for _, r := range data.Regions {
+		if r.From > 0 {
+			bounds = append(bounds, r.From)
+		}
+		labels.XYs = append(labels.XYs, plotter.XY{X: (r.From + r.To) / 2, Y: 0.04 * top})
+		labels.Labels = append(labels.Labels, r.Label)
+	}
+	p.Add(verticals{X: bounds, Y0: 0, Y1: top, LineStyle: style})
+
+	l, err := newLabels(labels)
+	if err != nil {
+		return err
+	}
+	for i := range l.TextStyle {
+		l.TextStyle[i].Font.Size = font.Length(10)
+		l.TextStyle[i].Color = theme.Muted
+		l.TextStyle[i].XAlign = draw.XCenter
+	}
+	p.Add(l)
+	return nil
+}
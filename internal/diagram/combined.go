@@ -0,0 +1,417 @@
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// steelModulus is the modulus of elasticity Es of the reinforcement (MPa),
+// for the steel stresses below yield
+const steelModulus = 200000.0
+
+// ExportCombinedDiagram exports the section with its bars, the strain
+// profile and the stress block with its forces side by side in one image,
+// the three panels sharing the height of the section
+func ExportCombinedDiagram(data SectionDiagramData, filename string) error {
+	if !supportedFormat(filename) {
+		filename += ".png"
+	}
+	image, err := combinedImage(data, fileFormat(filename))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	if dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	if err := os.WriteFile(filename, image, 0644); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return sectionSeries(data) })
+}
+
+// CombinedDiagramSVG returns the section, strain and stress diagrams side
+// by side as an SVG document
+func CombinedDiagramSVG(data SectionDiagramData) ([]byte, error) {
+	return combinedImage(data, "svg")
+}
+
+// CombinedDiagramPNG returns the section, strain and stress diagrams side
+// by side as a PNG image
+func CombinedDiagramPNG(data SectionDiagramData) ([]byte, error) {
+	return combinedImage(data, "png")
+}
+
+// DrawCombinedDiagram draws the section, strain and stress diagrams side by
+// side on a canvas of another document, e.g. a tile of a page
+func DrawCombinedDiagram(c draw.Canvas, data SectionDiagramData) error {
+	return drawCombined(c, data)
+}
+
+// combinedImage draws the three panels in an image format
+func combinedImage(data SectionDiagramData, format string) ([]byte, error) {
+	c, err := newCanvas(14*vg.Inch, 6*vg.Inch, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawCombined(draw.New(c), data); err != nil {
+		return nil, err
+	}
+	return canvasBytes(c)
+}
+
+// drawCombined draws the three panels on a canvas, aligned so that a height
+// of the section is level across them
+func drawCombined(dc draw.Canvas, data SectionDiagramData) error {
+	section, err := sectionPlot(data)
+	if err != nil {
+		return err
+	}
+	section.Title.Text = i18n.T("Section")
+	strain, err := strainPanel(data)
+	if err != nil {
+		return err
+	}
+	stress, err := stressPanel(data)
+	if err != nil {
+		return err
+	}
+	panels := []*plot.Plot{section, strain, stress}
+	level := func() {
+		for _, p := range panels[1:] {
+			p.Y.Min, p.Y.Max = section.Y.Min, section.Y.Max
+		}
+	}
+	level()
+
+	tiles := draw.Tiles{
+		Rows: 1, Cols: 3,
+		PadTop: vg.Points(6), PadBottom: vg.Points(6),
+		PadLeft: vg.Points(6), PadRight: vg.Points(12),
+		PadX: vg.Points(18),
+	}
+	canvases := plot.Align([][]*plot.Plot{panels}, tiles, stamp(dc, section.X.Tick.Label))
+	equalScale(section, canvases[0][0])
+	level()
+	for i, p := range panels {
+		p.Draw(canvases[0][i])
+	}
+	return nil
+}
+
+// strainPanel draws the linear strain profile over the height of the
+// section, compression positive, with the strains of the steel levels
+func strainPanel(data SectionDiagramData) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = i18n.T("Strain")
+	p.X.Label.Text = i18n.T("Strain (compression positive)")
+	u := data.system()
+	p.Y.Label.Text = axisLabel("Height", u.Length.Label)
+	setLengthTicks(u.Length, &p.Y)
+
+	c := data.NeutralAxisDepth
+	if c <= 0 {
+		return p, nil
+	}
+	strainAt := func(y float64) float64 {
+		return data.EpsilonCU * (c - (data.Height - y)) / c
+	}
+
+	profile := plotter.XYs{
+		{X: 0, Y: data.Height},
+		{X: data.EpsilonCU, Y: data.Height},
+		{X: strainAt(0), Y: 0},
+		{X: 0, Y: 0},
+	}
+	fill, err := plotter.NewPolygon(profile)
+	if err != nil {
+		return nil, err
+	}
+	fill.Color = theme.StrainFill
+	fill.LineStyle.Width = 0
+	p.Add(fill)
+
+	line, err := plotter.NewLine(profile[1:3])
+	if err != nil {
+		return nil, err
+	}
+	line.LineStyle.Width = vg.Points(2)
+	line.LineStyle.Color = theme.Strain
+	p.Add(line)
+
+	axis, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 0, Y: data.Height}})
+	if err != nil {
+		return nil, err
+	}
+	axis.LineStyle.Width = vg.Points(1)
+	p.Add(axis)
+
+	for _, ey := range []float64{data.EpsilonY, -data.EpsilonY} {
+		if data.EpsilonY <= 0 {
+			break
+		}
+		yield, err := plotter.NewLine(plotter.XYs{{X: ey, Y: 0}, {X: ey, Y: data.Height}})
+		if err != nil {
+			return nil, err
+		}
+		yield.LineStyle.Color = theme.Yield
+		yield.LineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+		p.Add(yield)
+	}
+
+	na, err := plotter.NewLine(plotter.XYs{
+		{X: math.Min(strainAt(0), -data.EpsilonY), Y: data.Height - c},
+		{X: math.Max(data.EpsilonCU, data.EpsilonY), Y: data.Height - c},
+	})
+	if err != nil {
+		return nil, err
+	}
+	na.LineStyle.Color = theme.Alert
+	na.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+	p.Add(na)
+
+	// Strains at the top, the neutral axis and the steel levels
+	points := plotter.XYs{{X: data.EpsilonCU, Y: data.Height}, {X: -data.EpsilonT, Y: data.TensionSteelY}}
+	labels := []string{fmt.Sprintf("εcu = %.4f", data.EpsilonCU), fmt.Sprintf("εt = %.4f", data.EpsilonT)}
+	if data.IsDoubly && data.CompSteelArea > 0 {
+		y := data.Height - data.CompSteelY
+		points = append(points, plotter.XY{X: strainAt(y), Y: y})
+		labels = append(labels, fmt.Sprintf("ε's = %.4f", strainAt(y)))
+	}
+	marks, err := plotter.NewScatter(points)
+	if err != nil {
+		return nil, err
+	}
+	marks.GlyphStyle.Color = theme.Alert
+	marks.GlyphStyle.Radius = vg.Points(3)
+	marks.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(marks)
+
+	text, err := newLabels(plotter.XYLabels{XYs: points, Labels: labels})
+	if err != nil {
+		return nil, err
+	}
+	text.Offset = vg.Point{X: vg.Points(5), Y: vg.Points(-4)}
+	p.Add(text)
+
+	naLabel, err := newLabels(plotter.XYLabels{
+		XYs:    []plotter.XY{{X: 0, Y: data.Height - c}},
+		Labels: []string{"c = " + u.Length.Format(c, 1)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	naLabel.Offset = vg.Point{X: vg.Points(4), Y: vg.Points(3)}
+	p.Add(naLabel)
+	return p, nil
+}
+
+// stressPanel draws the Whitney stress block with the resultant forces of
+// the concrete and the steel as arrows at their levels, and the lever arms
+// of the compressive forces about the tension steel
+func stressPanel(data SectionDiagramData) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = i18n.T("Stress Block and Forces")
+	u := data.system()
+	p.X.Label.Text = axisLabel("Stress", u.Stress.Label)
+	p.Y.Label.Text = axisLabel("Height", u.Length.Label)
+	setLengthTicks(u.Length, &p.Y)
+
+	fc := u.Stress.FromSI(data.Fc)
+	a := data.StressBlockDepth
+	if fc <= 0 || a <= 0 {
+		return p, nil
+	}
+
+	block, err := plotter.NewPolygon(plotter.XYs{
+		{X: 0, Y: data.Height - a},
+		{X: fc, Y: data.Height - a},
+		{X: fc, Y: data.Height},
+		{X: 0, Y: data.Height},
+	})
+	if err != nil {
+		return nil, err
+	}
+	block.Color = theme.StressBlock
+	block.LineStyle.Color = theme.Primary
+	p.Add(block)
+
+	axis, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 0, Y: data.Height}})
+	if err != nil {
+		return nil, err
+	}
+	axis.LineStyle.Width = vg.Points(1)
+	p.Add(axis)
+
+	forces := resultants(data)
+	largest := 0.0
+	for _, f := range forces {
+		largest = math.Max(largest, math.Abs(f.force))
+	}
+	// Labels are offset above a point, or below it, and to its right, or
+	// to its left
+	label := func(at plotter.XY, text string, below, left bool) error {
+		l, err := newLabels(plotter.XYLabels{XYs: []plotter.XY{at}, Labels: []string{text}})
+		if err != nil {
+			return err
+		}
+		l.Offset = vg.Point{X: vg.Points(4), Y: vg.Points(3)}
+		if below {
+			l.Offset.Y = -vg.Points(3)
+			l.TextStyle[0].YAlign = draw.YTop
+		}
+		if left {
+			l.Offset.X = -vg.Points(4)
+			l.TextStyle[0].XAlign = draw.XRight
+		}
+		p.Add(l)
+		return nil
+	}
+
+	for _, f := range forces {
+		length := 0.8 * fc * math.Abs(f.force) / largest
+		from, to := plotter.XY{X: fc + length, Y: f.y}, plotter.XY{X: fc, Y: f.y}
+		clr := color.Color(theme.Primary)
+		if f.tension {
+			from, to = plotter.XY{X: 0, Y: f.y}, plotter.XY{X: length, Y: f.y}
+			clr = theme.Secondary
+		}
+		p.Add(arrow{From: from, To: to, Color: clr})
+
+		// The steel force below its arrow, clear of the concrete force
+		text := f.label + " = " + u.Force.Format(f.force/1000, 1)
+		if err := label(plotter.XY{X: math.Max(from.X, to.X), Y: f.y}, text, f.label == "Cs", false); err != nil {
+			return nil, err
+		}
+	}
+
+	// Lever arms of the compressive forces about the tension steel, the
+	// first labeled on its left and the second on its right
+	yt := forces[len(forces)-1].y
+	for i, f := range forces {
+		if f.tension {
+			continue
+		}
+		x := fc * (2.1 + 0.3*float64(i))
+		lever, err := plotter.NewLine(plotter.XYs{{X: x, Y: yt}, {X: x, Y: f.y}})
+		if err != nil {
+			return nil, err
+		}
+		lever.LineStyle.Color = theme.Muted
+		lever.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+		p.Add(lever)
+		ends, err := plotter.NewScatter(plotter.XYs{{X: x, Y: yt}, {X: x, Y: f.y}})
+		if err != nil {
+			return nil, err
+		}
+		ends.GlyphStyle.Shape = draw.PlusGlyph{}
+		ends.GlyphStyle.Color = theme.Muted
+		p.Add(ends)
+		text := f.lever + " = " + u.Length.Format(f.y-yt, 1)
+		if err := label(plotter.XY{X: x, Y: (yt + f.y) / 2}, text, false, i == 0); err != nil {
+			return nil, err
+		}
+	}
+
+	p.X.Min = 0
+	return p, nil
+}
+
+// resultants returns the resultant forces (N) of the stress block, the
+// compression steel less the concrete it displaces, and the tension steel,
+// at their heights above the bottom
+func resultants(data SectionDiagramData) []resultant {
+	a := data.StressBlockDepth
+	compression := clipSectionAtDepth(data.Vertices, data.Height, a)
+	if compression == nil {
+		compression = plotter.XYs{
+			{X: 0, Y: data.Height - a},
+			{X: data.Width, Y: data.Height - a},
+			{X: data.Width, Y: data.Height},
+			{X: 0, Y: data.Height},
+		}
+	}
+	area, yc := polygonArea(compression)
+	forces := []resultant{{"Cc", data.Fc * area, yc, false, "z"}}
+
+	if data.IsDoubly && data.CompSteelArea > 0 && data.NeutralAxisDepth > 0 {
+		d := data.CompSteelY
+		fs := steelModulus * data.EpsilonCU * (data.NeutralAxisDepth - d) / data.NeutralAxisDepth
+		fs = math.Max(math.Min(fs, data.FsComp), -data.FsComp)
+		if d < a {
+			fs -= data.Fc // Concrete displaced by the bars
+		}
+		forces = append(forces, resultant{"Cs", data.CompSteelArea * fs, data.Height - d, false, "d − d'"})
+	}
+	fs := math.Min(steelModulus*data.EpsilonT, data.FsTension)
+	return append(forces, resultant{"T", data.TensionSteelArea * fs, data.TensionSteelY, true, ""})
+}
+
+// resultant is a force of the stress panel (N) at its height above the
+// bottom, with the name of its lever arm about the tension steel
+type resultant struct {
+	label    string
+	force, y float64
+	tension  bool
+	lever    string
+}
+
+// polygonArea returns the area of a polygon and the height of its centroid
+func polygonArea(pts plotter.XYs) (area, cy float64) {
+	for i := range pts {
+		p, q := pts[i], pts[(i+1)%len(pts)]
+		cross := p.X*q.Y - q.X*p.Y
+		area += cross
+		cy += (p.Y + q.Y) * cross
+	}
+	if area == 0 {
+		return 0, 0
+	}
+	return math.Abs(area / 2), cy / (3 * area)
+}
+
+// arrow is a plotter drawing a straight arrow between two data points, its
+// head at To
+type arrow struct {
+	From, To plotter.XY
+	Color    color.Color
+}
+
+// Plot draws the arrow on the data area of a plot
+func (a arrow) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	from := vg.Point{X: trX(a.From.X), Y: trY(a.From.Y)}
+	to := vg.Point{X: trX(a.To.X), Y: trY(a.To.Y)}
+	style := draw.LineStyle{Color: a.Color, Width: vg.Points(2)}
+	c.StrokeLine2(style, from.X, from.Y, to.X, to.Y)
+
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	ux, uy := dx/length, dy/length
+	const head, half = 8.0, 3.5 // Points
+	base := vg.Point{X: to.X - vg.Length(head*ux), Y: to.Y - vg.Length(head*uy)}
+	c.FillPolygon(a.Color, []vg.Point{
+		to,
+		{X: base.X - vg.Length(half*uy), Y: base.Y + vg.Length(half*ux)},
+		{X: base.X + vg.Length(half*uy), Y: base.Y - vg.Length(half*ux)},
+	})
+}
+
+// DataRange returns the extent of the arrow so that the plot includes it
+func (a arrow) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return math.Min(a.From.X, a.To.X), math.Max(a.From.X, a.To.X),
+		math.Min(a.From.Y, a.To.Y), math.Max(a.From.Y, a.To.Y)
+}
@@ -0,0 +1,181 @@
+package diagram
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size of a comparison of two sections
+const (
+	comparisonWidth  = 12 * vg.Inch
+	comparisonHeight = 7 * vg.Inch
+)
+
+// ComparisonResult is a key result of a compared section, e.g. φMn with its
+// value as printed
+type ComparisonResult struct {
+	Name  string // e.g. "φMn"
+	Value string // e.g. "219.30 kN-m"
+}
+
+// ComparedSection is one of the sections of a comparison with its key
+// results, listed under it
+type ComparedSection struct {
+	Label   string // Name of the option, e.g. the file or design name
+	Section SectionDiagramData
+	Results []ComparisonResult
+}
+
+// ComparisonData holds the two sections of an option study
+type ComparisonData struct {
+	A, B ComparedSection
+}
+
+// ExportComparisonDiagram exports two sections side by side, drawn to the
+// same scale with their key results under them, to a png, svg or pdf file
+func ExportComparisonDiagram(data ComparisonData, filename string) error {
+	if !supportedFormat(filename) {
+		filename += ".png"
+	}
+	image, err := comparisonImage(data, fileFormat(filename))
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	if err := os.WriteFile(filename, image, 0644); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return comparisonSeries(data) })
+}
+
+// ComparisonDiagramSVG returns two sections side by side as an SVG document
+func ComparisonDiagramSVG(data ComparisonData) ([]byte, error) {
+	return comparisonImage(data, "svg")
+}
+
+// ComparisonDiagramPNG returns two sections side by side as a PNG image
+func ComparisonDiagramPNG(data ComparisonData) ([]byte, error) {
+	return comparisonImage(data, "png")
+}
+
+// DrawComparisonDiagram draws two sections side by side, to the same scale
+// with their key results under them, on a canvas of another document
+func DrawComparisonDiagram(c draw.Canvas, data ComparisonData) error {
+	return drawComparison(c, data)
+}
+
+// comparisonImage draws the comparison in an image format
+func comparisonImage(data ComparisonData, format string) ([]byte, error) {
+	c, err := newCanvas(comparisonWidth, comparisonHeight, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawComparison(draw.New(c), data); err != nil {
+		return nil, err
+	}
+	return canvasBytes(c)
+}
+
+// drawComparison draws each section over its results in a column of its
+// own, the sections to one scale and standing on a common base
+func drawComparison(c draw.Canvas, data ComparisonData) error {
+	compared := []ComparedSection{data.A, data.B}
+	var plots []*plot.Plot
+	lines := 0
+	for _, s := range compared {
+		p, err := sectionPlot(s.Section)
+		if err != nil {
+			return err
+		}
+		p.Title.Text = s.Label
+		plots = append(plots, p)
+		if len(s.Results) > lines {
+			lines = len(s.Results)
+		}
+	}
+
+	sty := plots[0].X.Tick.Label
+	dc := stamp(c, sty)
+	sty.Color = theme.Ink
+	sty.Font.Size = font.Length(10)
+	sty.XAlign, sty.YAlign = draw.XCenter, draw.YTop
+	lineHeight := sty.Height("φMn") * 1.4
+	results := lineHeight*vg.Length(lines) + vg.Points(12)
+
+	tiles := draw.Tiles{
+		Rows: 1, Cols: 2,
+		PadTop: vg.Points(6), PadBottom: vg.Points(6),
+		PadLeft: vg.Points(6), PadRight: vg.Points(12),
+		PadX: vg.Points(36),
+	}
+	// The axes are aligned again with the ranges widened to the common
+	// scale, since their ticks set the space they take
+	var canvases [][]draw.Canvas
+	for pass := 0; pass < 3; pass++ {
+		canvases = plot.Align([][]*plot.Plot{plots}, tiles, draw.Crop(dc, 0, 0, results, 0))
+		sameScale(plots, canvases[0])
+	}
+	for i, p := range plots {
+		p.Draw(canvases[0][i])
+	}
+
+	// Results under each section, centered on its column
+	strip := draw.Crop(dc, 0, 0, 0, -(dc.Max.Y - dc.Min.Y - results))
+	for i, s := range compared {
+		col := tiles.At(strip, i, 0)
+		x, y := (col.Min.X+col.Max.X)/2, col.Max.Y
+		for _, r := range s.Results {
+			col.FillText(sty, vg.Point{X: x, Y: y}, i18n.T(r.Name)+" = "+i18n.T(r.Value))
+			y -= lineHeight
+		}
+	}
+	return nil
+}
+
+// sameScale widens the ranges of section plots drawn on canvases so that a
+// length is drawn the same on each, over a common range of heights so that
+// the sections stand on the same base
+func sameScale(plots []*plot.Plot, canvases []draw.Canvas) {
+	bottom, top := math.Inf(1), math.Inf(-1)
+	for _, p := range plots {
+		bottom, top = math.Min(bottom, p.Y.Min), math.Max(top, p.Y.Max)
+	}
+	grow := func(min, max *float64, span float64) {
+		if extra := (span - (*max - *min)) / 2; extra > 0 {
+			*min, *max = *min-extra, *max+extra
+		}
+	}
+
+	// The ticks labeled with a range change the data area, so the ranges
+	// are settled over a few passes
+	for pass := 0; pass < 5; pass++ {
+		scale := 0.0 // Length per point of the most crowded plot
+		for i, p := range plots {
+			p.Y.Min, p.Y.Max = bottom, top
+			da := p.DataCanvas(canvases[i])
+			dx, dy := float64(da.Max.X-da.Min.X), float64(da.Max.Y-da.Min.Y)
+			if dx <= 0 || dy <= 0 {
+				return
+			}
+			scale = math.Max(scale, math.Max((p.X.Max-p.X.Min)/dx, (top-bottom)/dy))
+		}
+		for i, p := range plots {
+			da := p.DataCanvas(canvases[i])
+			grow(&p.X.Min, &p.X.Max, scale*float64(da.Max.X-da.Min.X))
+			grow(&bottom, &top, scale*float64(da.Max.Y-da.Min.Y))
+		}
+	}
+	for _, p := range plots {
+		p.Y.Min, p.Y.Max = bottom, top
+	}
+}
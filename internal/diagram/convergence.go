@@ -0,0 +1,209 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color/palette"
+	imagedraw "image/draw"
+	"image/gif"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size and delays (1/100 s) of the frames of a convergence animation
+const (
+	convergenceWidth  = 11 * vg.Inch
+	convergenceHeight = 5 * vg.Inch
+	frameDelay        = 70
+	lastFrameDelay    = 300
+)
+
+// ConvergenceStep is a neutral axis depth tried by the equilibrium
+// iteration of a section analysis
+type ConvergenceStep struct {
+	Method    string  // damped or bisection
+	C         float64 // Neutral axis depth (mm)
+	Imbalance float64 // Force imbalance T − (Cc + Cs) (kN)
+}
+
+// ConvergenceData holds the section of an analysis and the steps of its
+// equilibrium iteration, for an animation of the neutral axis moving to
+// equilibrium
+type ConvergenceData struct {
+	Section SectionDiagramData // At equilibrium
+	Beta1   float64            // Stress block depth a = β1·c
+	Steps   []ConvergenceStep
+}
+
+// ExportConvergenceAnimation exports a frame for each step of the
+// equilibrium iteration, the section with the neutral axis and stress
+// block of the step beside the force imbalance of the steps so far. A gif
+// file is an animation of the frames; files of the other Formats are numbered,
+// e.g. frames/beam-01.png, frames/beam-02.png. It returns the files
+// written.
+func ExportConvergenceAnimation(data ConvergenceData, filename string) ([]string, error) {
+	if len(data.Steps) == 0 {
+		return nil, fmt.Errorf("no iteration steps to animate")
+	}
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	format := fileFormat(filename)
+	if format == "gif" {
+		animation, err := convergenceGIF(data)
+		if err != nil {
+			return nil, err
+		}
+		return []string{filename}, os.WriteFile(filename, animation, 0644)
+	}
+
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	digits := len(fmt.Sprint(len(data.Steps)))
+	var files []string
+	for i := range data.Steps {
+		c, err := newCanvas(convergenceWidth, convergenceHeight, format)
+		if err != nil {
+			return files, err
+		}
+		if err := drawConvergenceFrame(draw.New(c), data, i); err != nil {
+			return files, err
+		}
+		frame, err := canvasBytes(c)
+		if err != nil {
+			return files, err
+		}
+		name := fmt.Sprintf("%s-%0*d%s", base, digits, i+1, filepath.Ext(filename))
+		if err := os.WriteFile(name, frame, 0644); err != nil {
+			return files, err
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// convergenceGIF draws the frames of the iteration as an animated GIF that
+// holds on the last, converged frame
+func convergenceGIF(data ConvergenceData) ([]byte, error) {
+	animation := &gif.GIF{}
+	for i := range data.Steps {
+		c := newRaster(Figure.size(convergenceWidth, convergenceHeight))
+		if err := drawConvergenceFrame(draw.New(c), data, i); err != nil {
+			return nil, err
+		}
+		img := c.Image()
+		frame := image.NewPaletted(img.Bounds(), palette.Plan9)
+		imagedraw.FloydSteinberg.Draw(frame, img.Bounds(), img, image.Point{})
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, frameDelay)
+	}
+	animation.Delay[len(animation.Delay)-1] = lastFrameDelay
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DrawConvergenceFrame draws the frame of step i of the iteration, the
+// section at the depth of the step beside the imbalance of the steps up to
+// it, on a canvas of another document
+func DrawConvergenceFrame(c draw.Canvas, data ConvergenceData, i int) error {
+	if i < 0 || i >= len(data.Steps) {
+		return fmt.Errorf("no iteration %d of %d to draw", i+1, len(data.Steps))
+	}
+	return drawConvergenceFrame(c, data, i)
+}
+
+// drawConvergenceFrame draws the frame of step i: the section at the depth
+// of the step and the imbalance of the steps up to it
+func drawConvergenceFrame(dc draw.Canvas, data ConvergenceData, i int) error {
+	step := data.Steps[i]
+	frame := data.Section
+	frame.NeutralAxisDepth = step.C
+	frame.StressBlockDepth = math.Min(data.Beta1*step.C, frame.Height)
+	section, err := sectionPlot(frame)
+	if err != nil {
+		return err
+	}
+	u := frame.system()
+	section.Title.Text = i18n.T(fmt.Sprintf("Iteration %d (%s): c = %s", i+1, step.Method, u.Length.Format(step.C, 1)))
+
+	residual, err := imbalancePlot(data.Steps, i, u.Force)
+	if err != nil {
+		return err
+	}
+
+	tiles := draw.Tiles{
+		Rows: 1, Cols: 2,
+		PadTop: vg.Points(6), PadBottom: vg.Points(6),
+		PadLeft: vg.Points(6), PadRight: vg.Points(12),
+		PadX: vg.Points(24),
+	}
+	canvases := plot.Align([][]*plot.Plot{{section, residual}}, tiles, stamp(dc, section.X.Tick.Label))
+	equalScale(section, canvases[0][0])
+	section.Draw(canvases[0][0])
+	residual.Draw(canvases[0][1])
+	return nil
+}
+
+// imbalancePlot draws the force imbalance of every step of the iteration in
+// a force unit, faint, with the steps up to i drawn over it and step i
+// marked
+func imbalancePlot(steps []ConvergenceStep, i int, force units.Unit) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = i18n.T(fmt.Sprintf("Force imbalance T − (Cc + Cs) = %s", force.Format(steps[i].Imbalance, 2)))
+	p.X.Label.Text = i18n.T("Iteration")
+	p.Y.Label.Text = axisLabel("Imbalance", force.Label)
+	p.Add(newGrid())
+
+	all := make(plotter.XYs, len(steps))
+	for j, s := range steps {
+		all[j] = plotter.XY{X: float64(j + 1), Y: force.FromSI(s.Imbalance)}
+	}
+	zero, err := plotter.NewLine(plotter.XYs{{X: 1, Y: 0}, {X: math.Max(float64(len(steps)), 2), Y: 0}})
+	if err != nil {
+		return nil, err
+	}
+	zero.LineStyle.Color = theme.Muted
+	zero.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+	p.Add(zero)
+
+	future, err := plotter.NewLine(all)
+	if err != nil {
+		return nil, err
+	}
+	future.LineStyle.Color = translucent(theme.Primary, 60)
+	p.Add(future)
+
+	line, points, err := plotter.NewLinePoints(all[:i+1])
+	if err != nil {
+		return nil, err
+	}
+	line.LineStyle.Width = vg.Points(1.5)
+	line.LineStyle.Color = theme.Primary
+	points.GlyphStyle.Color = theme.Primary
+	points.GlyphStyle.Radius = vg.Points(2)
+	points.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(line, points)
+
+	current, err := plotter.NewScatter(all[i : i+1])
+	if err != nil {
+		return nil, err
+	}
+	current.GlyphStyle.Color = theme.Alert
+	current.GlyphStyle.Radius = vg.Points(4)
+	current.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(current)
+	return p, nil
+}
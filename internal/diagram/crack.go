@@ -0,0 +1,98 @@
+package diagram
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// crackGap is the space between two adjacent bars of the layer nearest the
+// tension face, checked against the maximum spacing of crack control
+type crackGap struct {
+	X0, X1 float64 // Centers of the bars (mm)
+	Y      float64 // Height of the layer (mm)
+}
+
+// spacing returns the center-to-center spacing of the bars of the gap
+func (g crackGap) spacing() float64 {
+	return g.X1 - g.X0
+}
+
+// tensionFaceGaps returns the gaps between the bars of the layer nearest the
+// tension face, the bottom or, when the tension bars are in the upper half,
+// the top, and the height of that face
+func tensionFaceGaps(data SectionDiagramData, bars []Bar) ([]crackGap, float64) {
+	var tension []Bar
+	for _, b := range bars {
+		if b.Tension {
+			tension = append(tension, b)
+		}
+	}
+	if len(tension) < 2 {
+		return nil, 0
+	}
+	mean := 0.0
+	for _, b := range tension {
+		mean += b.Y / float64(len(tension))
+	}
+	face, sign := 0.0, 1.0
+	if mean > data.Height/2 {
+		face, sign = data.Height, -1
+	}
+
+	// Bars of the layer nearest the face
+	nearest := math.Inf(1)
+	for _, b := range tension {
+		nearest = math.Min(nearest, sign*(b.Y-face))
+	}
+	var layer []Bar
+	for _, b := range tension {
+		if sign*(b.Y-face)-nearest < 1 {
+			layer = append(layer, b)
+		}
+	}
+	sort.Slice(layer, func(i, j int) bool { return layer[i].X < layer[j].X })
+
+	var gaps []crackGap
+	for i := 1; i < len(layer); i++ {
+		gaps = append(gaps, crackGap{X0: layer[i-1].X, X1: layer[i].X, Y: layer[i].Y})
+	}
+	return gaps, face
+}
+
+// crackOverlay shades, from the tension face to the bars, the gaps between
+// the bars nearest the face that are wider than the maximum spacing of crack
+// control, and returns the widest gap to call out, nil when the spacing is
+// not checked
+func crackOverlay(p *plot.Plot, data SectionDiagramData, bars []Bar) (*crackGap, error) {
+	if data.MaxBarSpacing <= 0 {
+		return nil, nil
+	}
+	gaps, face := tensionFaceGaps(data, bars)
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+	widest := gaps[0]
+	for _, g := range gaps {
+		if g.spacing() > widest.spacing() {
+			widest = g
+		}
+		if g.spacing() <= data.MaxBarSpacing {
+			continue
+		}
+		shade, err := plotter.NewPolygon(plotter.XYs{
+			{X: g.X0, Y: face}, {X: g.X1, Y: face}, {X: g.X1, Y: g.Y}, {X: g.X0, Y: g.Y},
+		})
+		if err != nil {
+			return nil, err
+		}
+		shade.Color = translucent(theme.Alert, 110)
+		shade.LineStyle.Color = theme.Alert
+		shade.LineStyle.Width = vg.Points(0.5)
+		p.Add(shade)
+	}
+	return &widest, nil
+}
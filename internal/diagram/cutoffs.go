@@ -0,0 +1,254 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// BarExtent is a group of bars along the top or bottom of a beam
+type BarExtent struct {
+	Top        bool
+	Label      string  // e.g. "2-Ø20"
+	Start, End float64 // From the left end of the beam (m)
+	Continuous bool    // Runs the length of the beam, lapped at the laps of its face
+}
+
+// BarLap is a lap splice of the continuous bars of a face
+type BarLap struct {
+	Top        bool
+	Start, End float64 // From the left end of the beam (m)
+}
+
+// CutoffDiagramData holds the bars of a beam for its elevation
+type CutoffDiagramData struct {
+	Name     string    // Beam name for the title
+	Spans    []float64 // m
+	Supports []float64 // Positions of the supports (m)
+	Height   float64   // Beam depth (mm)
+	Cover    float64   // Cover to the bar centers (mm)
+	Bars     []BarExtent
+	Laps     []BarLap
+
+	// Development lengths of the top and bottom bars (mm)
+	LdTop, LdBottom float64
+
+	// Units of the printed lengths, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data CutoffDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// ExportCutoffDiagram exports the elevation of the bars of a beam with
+// their cutoff points and laps to a png, svg or pdf file
+func ExportCutoffDiagram(data CutoffDiagramData, filename string) error {
+	p, err := cutoffPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 5*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return cutoffSeries(data) })
+}
+
+// CutoffDiagramSVG returns the elevation of the bars of a beam as an SVG
+// document
+func CutoffDiagramSVG(data CutoffDiagramData) ([]byte, error) {
+	p, err := cutoffPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "svg")
+}
+
+// CutoffDiagramPNG returns the elevation of the bars of a beam as a PNG
+// image
+func CutoffDiagramPNG(data CutoffDiagramData) ([]byte, error) {
+	p, err := cutoffPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "png")
+}
+
+// CutoffPlot returns the bar cutoffs of a beam as a plot, for drawing with
+// the plots of another document
+func CutoffPlot(data CutoffDiagramData) (*plot.Plot, error) {
+	return cutoffPlot(data)
+}
+
+// cutoffPlot draws the elevation of the beam, its depth exaggerated, with
+// the continuous bars broken at their laps and the added bars inside them.
+// The top bars are dimensioned above the beam from their support, the
+// bottom bars below it from the supports of their span, then the laps and
+// the spans.
+func cutoffPlot(data CutoffDiagramData) (*plot.Plot, error) {
+	var length float64
+	for _, l := range data.Spans {
+		length += l
+	}
+	if length <= 0 || data.Height <= 0 {
+		return nil, fmt.Errorf("invalid beam: length=%.2f m, height=%.0f mm", length, data.Height)
+	}
+	u := data.system()
+	h := data.Height / 1000
+	cover := min(data.Cover, data.Height/4) / 1000
+	level := h / 10 // Between the continuous and added bars
+	lapOffset := h / 25
+
+	p := newPlot()
+	p.Title.Text = plotTitle(data.Name, "Bar Cutoffs")
+	if data.LdTop > 0 || data.LdBottom > 0 {
+		p.Title.Text += " - " + i18n.T(fmt.Sprintf("ld = %s top, %s bottom", u.Length.Format(data.LdTop, 0), u.Length.Format(data.LdBottom, 0)))
+	}
+	setSpanAxis(p, "Position along the beam", u)
+	p.HideY()
+	p.X.Min, p.X.Max = 0, length
+	p.Y.Min, p.Y.Max = 0, h
+
+	outline, err := plotter.NewPolygon(plotter.XYs{{X: 0, Y: 0}, {X: length, Y: 0}, {X: length, Y: h}, {X: 0, Y: h}})
+	if err != nil {
+		return nil, err
+	}
+	outline.Color = theme.Concrete
+	outline.LineStyle.Width = vg.Points(1.5)
+	p.Add(outline)
+	p.Add(supportMarks(data.Supports))
+
+	steel := theme.Steel
+	bar := func(pts plotter.XYs, width vg.Length) error {
+		l, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		l.LineStyle.Width = width
+		l.LineStyle.Color = steel
+		p.Add(l)
+		return nil
+	}
+	nearest := func(x float64) float64 {
+		best := 0.0
+		for i, s := range data.Supports {
+			if i == 0 || math.Abs(x-s) < math.Abs(x-best) {
+				best = s
+			}
+		}
+		return best
+	}
+	var labels plotter.XYLabels
+	var cutoffs plotter.XYs
+
+	for _, b := range data.Bars {
+		y, inward := cover, 1.0
+		if b.Top {
+			y, inward = h-cover, -1.0
+		}
+
+		if b.Continuous {
+			// Lengths of bar between the laps of the face, every other one
+			// offset to show the lap, hooked at the ends of the beam
+			start, offset := b.Start, 0.0
+			var laps []BarLap
+			for _, l := range data.Laps {
+				if l.Top == b.Top {
+					laps = append(laps, l)
+				}
+			}
+			for i := 0; i <= len(laps); i++ {
+				end := b.End
+				if i < len(laps) {
+					end = laps[i].End
+				}
+				pts := plotter.XYs{{X: start, Y: y + offset}, {X: end, Y: y + offset}}
+				if i == 0 {
+					pts = append(plotter.XYs{{X: start, Y: y + inward*2*level}}, pts...)
+				}
+				if i == len(laps) {
+					pts = append(pts, plotter.XY{X: end, Y: y + offset + inward*2*level})
+				}
+				if err := bar(pts, vg.Points(2)); err != nil {
+					return nil, err
+				}
+				if i < len(laps) {
+					start = laps[i].Start
+					offset = inward * lapOffset * float64(1-i%2)
+				}
+			}
+			labels.XYs = append(labels.XYs, plotter.XY{X: length * 0.02, Y: y - inward*level*0.5})
+			labels.Labels = append(labels.Labels, b.Label+" continuous")
+			continue
+		}
+
+		y += inward * level
+		if err := bar(plotter.XYs{{X: b.Start, Y: y}, {X: b.End, Y: y}}, vg.Points(1.5)); err != nil {
+			return nil, err
+		}
+		cutoffs = append(cutoffs, plotter.XY{X: b.Start, Y: y}, plotter.XY{X: b.End, Y: y})
+		labels.XYs = append(labels.XYs, plotter.XY{X: (b.Start + b.End) / 2, Y: y + inward*level*0.5})
+		labels.Labels = append(labels.Labels, b.Label)
+
+		// Cutoff points from the nearest supports
+		offset, edge := dimensionStep, h
+		if !b.Top {
+			offset, edge = -dimensionStep, 0
+		}
+		for _, end := range []float64{b.Start, b.End} {
+			if s := nearest(end); math.Abs(end-s) > 1e-6 {
+				p.Add(dimension{A: plotter.XY{X: s, Y: edge}, B: plotter.XY{X: end, Y: edge}, Offset: offset, Text: fmt.Sprintf("%.2f", u.Span.FromSI(math.Abs(end-s)))})
+			}
+		}
+	}
+
+	if len(cutoffs) > 0 {
+		s, err := plotter.NewScatter(cutoffs)
+		if err != nil {
+			return nil, err
+		}
+		s.GlyphStyle.Shape = draw.CircleGlyph{}
+		s.GlyphStyle.Radius = vg.Points(2.5)
+		s.GlyphStyle.Color = steel
+		p.Add(s)
+	}
+	if len(labels.XYs) > 0 {
+		l, err := newLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		for i := range l.TextStyle {
+			l.TextStyle[i].XAlign, l.TextStyle[i].YAlign = draw.XCenter, draw.YCenter
+			if labels.XYs[i].X < length*0.05 {
+				l.TextStyle[i].XAlign = draw.XLeft
+			}
+		}
+		p.Add(l)
+	}
+
+	// Laps, then the spans below the beam
+	for _, l := range data.Laps {
+		offset, edge := 2*dimensionStep, h
+		if !l.Top {
+			offset, edge = -2*dimensionStep, 0
+		}
+		text := i18n.T(fmt.Sprintf("lap %.*f", u.Length.Digits, u.Length.FromSI((l.End-l.Start)*1000)))
+		p.Add(dimension{A: plotter.XY{X: l.Start, Y: edge}, B: plotter.XY{X: l.End, Y: edge}, Offset: offset, Text: text})
+	}
+	start := 0.0
+	for i, l := range data.Spans {
+		p.Add(dimension{A: plotter.XY{X: start, Y: 0}, B: plotter.XY{X: start + l, Y: 0}, Offset: -3 * dimensionStep, Text: fmt.Sprintf("L%d = %s", i+1, u.Span.Format(l, 2))})
+		start += l
+	}
+	return p, nil
+}
@@ -0,0 +1,321 @@
+package diagram
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteData makes every diagram exported to a file also write the data
+// series it plots to a CSV file beside it, see DataFile, so that they can be
+// replotted in other tools
+var WriteData bool
+
+// DataFile returns the CSV file the data series of a diagram exported to
+// filename are written to, e.g. beam.csv for beam.png
+func DataFile(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ".csv"
+}
+
+// dataTable is the data series of a diagram as rows of a CSV file
+type dataTable struct {
+	headers []string
+	rows    [][]string
+}
+
+// add appends a row of a series name and its values
+func (t *dataTable) add(name string, values ...float64) {
+	row := []string{name}
+	for _, v := range values {
+		row = append(row, fmt.Sprintf("%.6g", v))
+	}
+	t.rows = append(t.rows, row)
+}
+
+// writeData writes the data series of a diagram exported to filename when
+// WriteData is set
+func writeData(filename string, series func() dataTable) error {
+	if !WriteData {
+		return nil
+	}
+	t := series()
+	f, err := os.Create(DataFile(filename))
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write(t.headers)
+	w.WriteAll(t.rows)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sectionSeries returns the strain profile of a section diagram at the top
+// and bottom fibers, the depth of the stress block, the neutral axis and the
+// steel levels, followed by the strain and stress of each bar, compression
+// positive
+func sectionSeries(data SectionDiagramData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{
+		"Point",
+		"Height (" + u.Length.Label + ")",
+		"Strain (compression positive)",
+		"Stress (" + u.Stress.Label + ", compression positive)",
+	}}
+	c := data.NeutralAxisDepth
+	if c <= 0 {
+		return t
+	}
+	strainAt := func(y float64) float64 {
+		return data.EpsilonCU * (c - (data.Height - y)) / c
+	}
+	steelStress := func(strain float64) float64 {
+		fs := strain * steelModulus
+		if data.EpsilonY > 0 {
+			fy := data.EpsilonY * steelModulus
+			fs = math.Max(-fy, math.Min(fy, fs))
+		}
+		return u.Stress.FromSI(fs)
+	}
+	add := func(name string, y, strain, stress float64) {
+		t.add(name, u.Length.FromSI(y), strain, stress)
+	}
+
+	add("Top fiber", data.Height, data.EpsilonCU, u.Stress.FromSI(data.Fc))
+	if a := data.StressBlockDepth; a > 0 {
+		add("Stress block a", data.Height-a, strainAt(data.Height-a), u.Stress.FromSI(data.Fc))
+	}
+	add("Neutral axis c", data.Height-c, 0, 0)
+	if data.IsDoubly && data.CompSteelArea > 0 {
+		y := data.Height - data.CompSteelY
+		add("Compression steel", y, strainAt(y), u.Stress.FromSI(data.FsComp))
+	}
+	add("Tension steel", data.TensionSteelY, -data.EpsilonT, -u.Stress.FromSI(data.FsTension))
+	add("Bottom fiber", 0, strainAt(0), 0)
+
+	for i, b := range sectionBars(data) {
+		strain := strainAt(b.Y)
+		add(fmt.Sprintf("Bar %d (%s)", i+1, u.Length.Format(b.Diameter, 0)), b.Y, strain, steelStress(strain))
+	}
+	return t
+}
+
+// interactionSeries returns the points of the nominal and design curves of
+// an interaction diagram and the applied load
+func interactionSeries(data InteractionDiagramData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{
+		"Curve",
+		"Moment (" + u.Moment.Label + ")",
+		"Axial force (" + u.Force.Label + ", compression positive)",
+	}}
+	for _, s := range []struct {
+		name   string
+		points []Point
+	}{{"Pn-Mn", data.Nominal}, {"φPn-φMn", data.Design}} {
+		for _, pt := range s.points {
+			t.add(s.name, u.Moment.FromSI(pt.X), u.Force.FromSI(pt.Y))
+		}
+	}
+	if data.Load != nil {
+		t.add("Load", u.Moment.FromSI(data.Load.X), u.Force.FromSI(data.Load.Y))
+	}
+	return t
+}
+
+// biaxialSeries returns the points of the contours of a biaxial interaction
+// surface
+func biaxialSeries(data BiaxialDiagramData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{
+		"Axial strength φPn (" + u.Force.Label + ")",
+		"φMny (" + u.Moment.Label + ")",
+		"φMnx (" + u.Moment.Label + ")",
+	}}
+	for _, c := range data.Contours {
+		for _, pt := range c.Points {
+			t.add(fmt.Sprintf("%.6g", u.Force.FromSI(c.PhiPn)), u.Moment.FromSI(pt.X), u.Moment.FromSI(pt.Y))
+		}
+	}
+	return t
+}
+
+// inclinedAxisSeries returns the corners of the compression zone of a
+// section bent about both axes and the ends of its neutral axis
+func inclinedAxisSeries(data InclinedAxisData) dataTable {
+	u := data.Section.system()
+	t := dataTable{headers: []string{"Point", "X (" + u.Length.Label + ")", "Y (" + u.Length.Label + ")"}}
+	for _, pt := range data.compressionZone() {
+		t.add("Compression zone", u.Length.FromSI(pt.X), u.Length.FromSI(pt.Y))
+	}
+	for _, pt := range data.neutralAxis() {
+		t.add("Neutral axis", u.Length.FromSI(pt.X), u.Length.FromSI(pt.Y))
+	}
+	return t
+}
+
+// fiberSeries returns the cells of the strips of concrete of a fiber
+// diagram, by the level of their strip, followed by the steel layers
+func fiberSeries(data FiberDiagramData) dataTable {
+	u := data.Section.system()
+	t := dataTable{headers: []string{
+		"Fiber",
+		"Y (" + u.Length.Label + ")",
+		"X min (" + u.Length.Label + ")",
+		"X max (" + u.Length.Label + ")",
+		"Strain (compression positive)",
+		"Stress (" + u.Stress.Label + ", compression positive)",
+	}}
+	for _, s := range data.Strips {
+		for _, c := range s.Cells {
+			t.add("Concrete", u.Length.FromSI(s.Y), u.Length.FromSI(c.XMin), u.Length.FromSI(c.XMax), s.Strain, u.Stress.FromSI(c.Stress))
+		}
+	}
+	for _, l := range data.Layers {
+		t.rows = append(t.rows, []string{"Steel", fmt.Sprintf("%.6g", u.Length.FromSI(l.Y)), "", "",
+			fmt.Sprintf("%.6g", l.Strain), fmt.Sprintf("%.6g", u.Stress.FromSI(l.Stress))})
+	}
+	return t
+}
+
+// forceSeries returns the points of the main curve of a force diagram
+// followed by those of the other combinations
+func forceSeries(data ForceDiagramData) dataTable {
+	_, unit := data.title()
+	quantity := "Moment"
+	if data.Kind == ShearDiagram {
+		quantity = "Shear"
+	}
+	t := dataTable{headers: []string{
+		"Combination",
+		"Position (m)",
+		quantity + " max (" + unit.Label + ")",
+		quantity + " min (" + unit.Label + ")",
+	}}
+	for _, c := range append([]ForceCurve{data.Main}, data.Others...) {
+		for i, pt := range c.Max {
+			t.add(c.Label, pt.X, unit.FromSI(pt.Y), unit.FromSI(c.Min[i].Y))
+		}
+	}
+	return t
+}
+
+// stirrupSeries returns the stirrup zones of a beam
+func stirrupSeries(data StirrupDiagramData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{"Zone", "Start (m)", "End (m)", "Spacing (" + u.Length.Label + ")", "Count"}}
+	for i, z := range data.Zones {
+		t.add(fmt.Sprintf("%d", i+1), z.Start, z.End, u.Length.FromSI(z.Spacing), float64(z.Count))
+	}
+	return t
+}
+
+// cutoffSeries returns the extents of the bars of a beam and their laps
+func cutoffSeries(data CutoffDiagramData) dataTable {
+	t := dataTable{headers: []string{"Bars", "Face", "Start (m)", "End (m)"}}
+	face := func(top bool) string {
+		if top {
+			return "top"
+		}
+		return "bottom"
+	}
+	row := func(name string, top bool, start, end float64) {
+		t.rows = append(t.rows, []string{name, face(top), fmt.Sprintf("%.6g", start), fmt.Sprintf("%.6g", end)})
+	}
+	for _, b := range data.Bars {
+		row(b.Label, b.Top, b.Start, b.End)
+	}
+	for _, l := range data.Laps {
+		row("Lap", l.Top, l.Start, l.End)
+	}
+	return t
+}
+
+// elevationSeries returns the loads of a beam elevation
+func elevationSeries(data ElevationDiagramData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{"Load type", "w (" + u.Force.Label + "/m)", "P (" + u.Force.Label + ")", "Start (m)", "End (m)"}}
+	for _, l := range data.Loads {
+		start, end := l.Start, l.End
+		if l.W == 0 {
+			start, end = l.X, l.X
+		}
+		t.add(l.Type, u.Force.FromSI(l.W), u.Force.FromSI(l.P), start, end)
+	}
+	return t
+}
+
+// chartSeries returns the points of the curves of a chart and their marks
+func chartSeries(data ChartData) dataTable {
+	t := dataTable{headers: []string{"Curve", "Point", data.XLabel, data.YLabel}}
+	for _, c := range data.Curves {
+		for _, pt := range c.Points {
+			t.rows = append(t.rows, []string{c.Label, "", fmt.Sprintf("%.6g", pt.X), fmt.Sprintf("%.6g", pt.Y)})
+		}
+		for _, m := range c.Marks {
+			t.rows = append(t.rows, []string{c.Label, m.Kind, fmt.Sprintf("%.6g", m.X), fmt.Sprintf("%.6g", m.Y)})
+		}
+	}
+	return t
+}
+
+// deflectionSeries returns the points of the deflected shapes of a span
+// followed by its limits at both ends
+func deflectionSeries(data DeflectionDiagramData) dataTable {
+	u := data.system().Length
+	t := dataTable{headers: []string{"Curve", "Position (m)", "Deflection (" + u.Label + ")"}}
+	for _, c := range data.Curves {
+		for _, pt := range c.Points {
+			t.add(c.Label, pt.X, u.FromSI(pt.Y))
+		}
+	}
+	for _, l := range data.Limits {
+		t.add(l.Label, 0, u.FromSI(l.Value))
+		t.add(l.Label, data.Span, u.FromSI(l.Value))
+	}
+	return t
+}
+
+// shearCapacitySeries returns the factored shear along a beam followed by
+// the extent and strength of each stirrup zone
+func shearCapacitySeries(data ShearCapacityData) dataTable {
+	u := data.system()
+	t := dataTable{headers: []string{"Series", "Start (m)", "End (m)", "Shear (" + u.Force.Label + ")", "Spacing (" + u.Length.Label + ")"}}
+	for _, pt := range data.Demand {
+		t.add(data.symbol(), pt.X, pt.X, u.Force.FromSI(pt.Y), 0)
+	}
+	t.add("φVc", 0, data.Demand[len(data.Demand)-1].X, u.Force.FromSI(data.PhiVc), 0)
+	for _, z := range data.Zones {
+		t.add("φ(Vc + Vs)", z.Start, z.End, u.Force.FromSI(z.PhiVn), u.Length.FromSI(z.Spacing))
+	}
+	return t
+}
+
+// comparisonSeries returns the key results of two compared sections side by
+// side, a result missing from one of them left blank
+func comparisonSeries(data ComparisonData) dataTable {
+	t := dataTable{headers: []string{"Result", data.A.Label, data.B.Label}}
+	value := func(results []ComparisonResult, name string) string {
+		for _, r := range results {
+			if r.Name == name {
+				return r.Value
+			}
+		}
+		return ""
+	}
+	seen := map[string]bool{}
+	for _, r := range append(append([]ComparisonResult{}, data.A.Results...), data.B.Results...) {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+		t.rows = append(t.rows, []string{r.Name, value(data.A.Results, r.Name), value(data.B.Results, r.Name)})
+	}
+	return t
+}
@@ -0,0 +1,197 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DeflectionCurve is a deflected shape along a span, as points with X the
+// position (m) and Y the deflection (mm), downward positive
+type DeflectionCurve struct {
+	Label  string
+	Points []Point
+}
+
+// DeflectionLimit is a code limit of a deflection, e.g. L/360
+type DeflectionLimit struct {
+	Label string  // e.g. "L/360"
+	Value float64 // mm
+	Of    string  // Label of the curve it limits, e.g. "Immediate live load"
+}
+
+// DeflectionDiagramData holds the deflected shapes of a span with the code
+// limits of their largest deflection
+type DeflectionDiagramData struct {
+	Name       string  // Beam name for the title
+	Span       float64 // m
+	Cantilever bool    // Fixed at X = 0 and free at the span, else simply supported
+	Curves     []DeflectionCurve
+	Limits     []DeflectionLimit
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data DeflectionDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// ExportDeflectionDiagram exports the deflected shapes of a span with their
+// limits to a png, svg or pdf file
+func ExportDeflectionDiagram(data DeflectionDiagramData, filename string) error {
+	p, err := deflectionPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 5*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return deflectionSeries(data) })
+}
+
+// DeflectionDiagramSVG returns the deflected shapes of a span as an SVG
+// document
+func DeflectionDiagramSVG(data DeflectionDiagramData) ([]byte, error) {
+	p, err := deflectionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "svg")
+}
+
+// DeflectionDiagramPNG returns the deflected shapes of a span as a PNG
+// image
+func DeflectionDiagramPNG(data DeflectionDiagramData) ([]byte, error) {
+	p, err := deflectionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "png")
+}
+
+// DeflectionPlot returns the deflected shapes of a span as a plot, for
+// drawing with the plots of another document
+func DeflectionPlot(data DeflectionDiagramData) (*plot.Plot, error) {
+	return deflectionPlot(data)
+}
+
+// deflectionPlot draws the span on its supports with each deflected shape
+// below it, and each limit as a dashed line across the span in the color of
+// the curve it limits
+func deflectionPlot(data DeflectionDiagramData) (*plot.Plot, error) {
+	if data.Span <= 0 {
+		return nil, fmt.Errorf("invalid span: %.2f m", data.Span)
+	}
+	system := data.system()
+	u := system.Length
+	p := newPlot()
+	p.Title.Text = plotTitle(data.Name, "Deflection")
+	if data.Cantilever {
+		setSpanAxis(p, "Position from the fixed end", system)
+	} else {
+		setSpanAxis(p, "Position along the span", system)
+	}
+	p.Y.Label.Text = axisLabel("Deflection, downward", u.Label)
+	p.X.Min, p.X.Max = 0, data.Span
+	p.Add(newGrid())
+
+	beam, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: data.Span, Y: 0}})
+	if err != nil {
+		return nil, err
+	}
+	beam.LineStyle.Color = theme.Muted
+	beam.LineStyle.Width = vg.Points(1)
+	p.Add(beam)
+	if data.Cantilever {
+		p.Add(fixedEnds{{X: 0, Left: true}})
+	} else {
+		p.Add(supportMarks{0, data.Span})
+	}
+
+	colors := map[string]int{}
+	for i, c := range data.Curves {
+		colors[c.Label] = i
+		xys := make(plotter.XYs, len(c.Points))
+		for j, pt := range c.Points {
+			xys[j] = plotter.XY{X: pt.X, Y: -u.FromSI(pt.Y)}
+		}
+		line, err := plotter.NewLine(xys)
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(2)
+		line.LineStyle.Color = paletteColor(i)
+		p.Add(line)
+		addLegend(p, c.Label, line)
+	}
+
+	var labels plotter.XYLabels
+	for _, l := range data.Limits {
+		y := -u.FromSI(l.Value)
+		limit, err := plotter.NewLine(plotter.XYs{{X: 0, Y: y}, {X: data.Span, Y: y}})
+		if err != nil {
+			return nil, err
+		}
+		limit.LineStyle.Width = vg.Points(1)
+		limit.LineStyle.Dashes = []vg.Length{vg.Points(6), vg.Points(3)}
+		limit.LineStyle.Color = theme.Alert
+		if i, ok := colors[l.Of]; ok {
+			limit.LineStyle.Color = paletteColor(i)
+		}
+		p.Add(limit)
+		labels.XYs = append(labels.XYs, plotter.XY{X: data.Span, Y: y})
+		labels.Labels = append(labels.Labels, fmt.Sprintf("%s = %s", l.Label, u.Format(l.Value, 1)))
+	}
+	if len(labels.XYs) > 0 {
+		l, err := newLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		for i := range l.TextStyle {
+			l.TextStyle[i].XAlign, l.TextStyle[i].YAlign = draw.XRight, draw.YTop
+		}
+		l.Offset = vg.Point{X: -vg.Points(4), Y: -vg.Points(2)}
+		p.Add(l)
+	}
+
+	// Room below the lowest curve or limit for the legend
+	lowest := 0.0
+	for _, c := range data.Curves {
+		for _, pt := range c.Points {
+			lowest = math.Max(lowest, u.FromSI(pt.Y))
+		}
+	}
+	for _, l := range data.Limits {
+		lowest = math.Max(lowest, u.FromSI(l.Value))
+	}
+	p.Y.Min = -1.35 * lowest
+	p.Y.Tick.Marker = downwardTicks{}
+	p.Legend.Left = true
+	p.Legend.YOffs = vg.Points(6)
+	return p, nil
+}
+
+// downwardTicks labels an axis of downward values plotted negative with
+// their magnitudes
+type downwardTicks struct{}
+
+// Ticks returns the default ticks between min and max labeled without
+// their sign
+func (downwardTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i := range ticks {
+		ticks[i].Label = strings.TrimPrefix(ticks[i].Label, "-")
+	}
+	return ticks
+}
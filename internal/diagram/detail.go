@@ -0,0 +1,148 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/dxf"
+)
+
+// Layers of the detailing drawing
+const (
+	LayerSection    = "SECTION"
+	LayerRebar      = "REBAR"
+	LayerStirrup    = "STIRRUP"
+	LayerDimensions = "DIMENSIONS"
+	LayerText       = "TEXT"
+)
+
+// ExportSectionDXF exports a detailing drawing of the section to a DXF file
+// at 1:1 in mm: the outline, the bars, the stirrup of a rectangular section,
+// the overall, effective and clear cover dimensions, and the bar and
+// stirrup callouts, each on its own layer
+func ExportSectionDXF(data SectionDiagramData, filename string) error {
+	return SectionDXF(data).WriteFile(filename)
+}
+
+// SectionDXF returns the detailing drawing of the section
+func SectionDXF(data SectionDiagramData) *dxf.Drawing {
+	d := dxf.New()
+	d.Layer(LayerSection, dxf.White)
+	d.Layer(LayerRebar, dxf.Red)
+	d.Layer(LayerStirrup, dxf.Green)
+	d.Layer(LayerDimensions, dxf.Cyan)
+	d.Layer(LayerText, dxf.Yellow)
+
+	// Text and dimension offsets in proportion to the section, as it is
+	// plotted at the scale that fits it on the sheet
+	text := math.Max(data.Width, data.Height) / 25
+	u := data.system()
+	dimension := func(v float64) string {
+		if u.Length.Factor == 1 {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.*f", 1+u.Length.Digits, u.Length.FromSI(v))
+	}
+
+	// Outline
+	var outline []dxf.Point
+	minX, maxX := 0.0, data.Width
+	if len(data.Vertices) >= 3 {
+		minX, maxX = data.Vertices[0].X, data.Vertices[0].X
+		for _, v := range data.Vertices {
+			outline = append(outline, dxf.Point{X: v.X, Y: v.Y})
+			minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		}
+	} else {
+		outline = rectangle(0, 0, data.Width, data.Height)
+	}
+	d.Polyline(LayerSection, outline, true)
+
+	// Stirrup of a rectangular section, its outer and inner faces
+	stirrup := len(data.Vertices) < 3 && data.ClearCover > 0 && data.StirrupDiameter > 0
+	if stirrup {
+		for _, inset := range []float64{data.ClearCover, data.ClearCover + data.StirrupDiameter} {
+			d.Polyline(LayerStirrup, rectangle(inset, inset, data.Width-inset, data.Height-inset), true)
+		}
+	}
+
+	// Bars, and a callout of each row of bars of one size on the right
+	for _, b := range sectionBars(data) {
+		d.Circle(LayerRebar, dxf.Point{X: b.X, Y: b.Y}, b.Diameter)
+	}
+	rows := barRows(sectionBars(data))
+
+	calloutX := maxX + 5*text
+	for i, r := range rows {
+		// Callouts of rows close together are spread apart
+		y := r.Y
+		if i > 0 {
+			y = math.Min(y, rows[i-1].Y-1.8*text)
+			rows[i].Y = y
+		}
+		from := dxf.Point{X: r.Last.X + r.Last.Diameter/2, Y: r.Last.Y}
+		elbow := dxf.Point{X: calloutX - text, Y: y}
+		d.Line(LayerText, from, elbow)
+		d.Line(LayerText, elbow, dxf.Point{X: calloutX - text/4, Y: y})
+		d.Text(LayerText, dxf.Point{X: calloutX, Y: y - text/2}, text, fmt.Sprintf("%d-%%%%c%.0f", r.Count, r.Diameter), dxf.Left, 0)
+	}
+	if stirrup {
+		y := data.Height - data.ClearCover - data.StirrupDiameter/2
+		if len(rows) > 0 {
+			y = math.Max(y, rows[0].Y+1.8*text)
+		}
+		x := data.Width - data.ClearCover - data.StirrupDiameter/2
+		d.Line(LayerText, dxf.Point{X: x, Y: data.Height - data.ClearCover - data.StirrupDiameter}, dxf.Point{X: calloutX - text, Y: y})
+		d.Line(LayerText, dxf.Point{X: calloutX - text, Y: y}, dxf.Point{X: calloutX - text/4, Y: y})
+		d.Text(LayerText, dxf.Point{X: calloutX, Y: y - text/2}, text, fmt.Sprintf("%%%%c%.0f STIRRUPS", data.StirrupDiameter), dxf.Left, 0)
+	}
+
+	// Overall width below, height on the left, and on the right the
+	// effective depth and cover to the tension steel and the clear cover
+	gap := 2.5 * text
+	dimensionLine(d, dxf.Point{X: minX, Y: 0}, dxf.Point{X: maxX, Y: 0}, -gap, text, dimension(maxX-minX))
+	dimensionLine(d, dxf.Point{X: minX, Y: 0}, dxf.Point{X: minX, Y: data.Height}, -gap, text, dimension(data.Height))
+	if data.TensionSteelY > 0 {
+		right := dxf.Point{X: maxX, Y: data.TensionSteelY}
+		dimensionLine(d, dxf.Point{X: maxX, Y: data.Height}, right, 2*text, text, "d="+dimension(data.Height-data.TensionSteelY))
+		dimensionLine(d, right, dxf.Point{X: maxX, Y: 0}, 2*text, text, dimension(data.TensionSteelY))
+	}
+	if stirrup {
+		dimensionLine(d, dxf.Point{X: 0, Y: 0}, dxf.Point{X: data.ClearCover, Y: 0}, -gap/2, text, dimension(data.ClearCover))
+	}
+
+	d.Text(LayerText, dxf.Point{X: (minX + maxX) / 2, Y: -2*gap - text}, 1.4*text, "SECTION", dxf.Center, 0)
+	return d
+}
+
+// dimensionLine draws a horizontal or vertical dimension between two points
+// of the section, offset from them below or to the left when negative, with
+// extension lines, tick marks and the text over the line
+func dimensionLine(d *dxf.Drawing, a, b dxf.Point, offset, text float64, value string) {
+	tick := text / 2
+	if a.Y == b.Y {
+		y := a.Y + offset
+		d.Line(LayerDimensions, dxf.Point{X: a.X, Y: a.Y + math.Copysign(tick/2, offset)}, dxf.Point{X: a.X, Y: y + math.Copysign(tick, offset)})
+		d.Line(LayerDimensions, dxf.Point{X: b.X, Y: b.Y + math.Copysign(tick/2, offset)}, dxf.Point{X: b.X, Y: y + math.Copysign(tick, offset)})
+		d.Line(LayerDimensions, dxf.Point{X: a.X - tick, Y: y}, dxf.Point{X: b.X + tick, Y: y})
+		for _, x := range []float64{a.X, b.X} {
+			d.Line(LayerDimensions, dxf.Point{X: x - tick/2, Y: y - tick/2}, dxf.Point{X: x + tick/2, Y: y + tick/2})
+		}
+		d.Text(LayerDimensions, dxf.Point{X: (a.X + b.X) / 2, Y: y + tick/2}, text, value, dxf.Center, 0)
+		return
+	}
+	x := a.X + offset
+	d.Line(LayerDimensions, dxf.Point{X: a.X + math.Copysign(tick/2, offset), Y: a.Y}, dxf.Point{X: x + math.Copysign(tick, offset), Y: a.Y})
+	d.Line(LayerDimensions, dxf.Point{X: b.X + math.Copysign(tick/2, offset), Y: b.Y}, dxf.Point{X: x + math.Copysign(tick, offset), Y: b.Y})
+	d.Line(LayerDimensions, dxf.Point{X: x, Y: math.Min(a.Y, b.Y) - tick}, dxf.Point{X: x, Y: math.Max(a.Y, b.Y) + tick})
+	for _, y := range []float64{a.Y, b.Y} {
+		d.Line(LayerDimensions, dxf.Point{X: x - tick/2, Y: y - tick/2}, dxf.Point{X: x + tick/2, Y: y + tick/2})
+	}
+	d.Text(LayerDimensions, dxf.Point{X: x - tick/2, Y: (a.Y + b.Y) / 2}, text, value, dxf.Center, 90)
+}
+
+// rectangle returns the corners of a rectangle counterclockwise from the
+// bottom left
+func rectangle(x1, y1, x2, y2 float64) []dxf.Point {
+	return []dxf.Point{{X: x1, Y: y1}, {X: x2, Y: y1}, {X: x2, Y: y2}, {X: x1, Y: y2}}
+}
@@ -0,0 +1,233 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Spacing of the dimensions of a section sketch (points)
+const (
+	dimensionGap  = vg.Length(3)  // Between the section and an extension line
+	dimensionStep = vg.Length(22) // Between dimension lines side by side
+	dimensionTick = vg.Length(4)  // Half the length of a tick mark
+)
+
+// dimension is a plotter drawing the dimension between two points of a
+// sketch, horizontal when they are level and vertical otherwise, its line
+// Offset from them: up or right when positive, down or left when negative
+type dimension struct {
+	A, B   plotter.XY
+	Offset vg.Length
+	Text   string
+}
+
+// horizontal reports whether the dimension measures along x
+func (d dimension) horizontal() bool {
+	return d.A.Y == d.B.Y
+}
+
+// sketchText returns the text style of dimensions and callouts
+func sketchText(plt *plot.Plot) text.Style {
+	sty := plt.X.Tick.Label
+	sty.Color = theme.Ink
+	return sty
+}
+
+// Plot draws the extension lines, the dimension line with tick marks at
+// its ends, and the text over its middle
+func (d dimension) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	a := vg.Point{X: trX(d.A.X), Y: trY(d.A.Y)}
+	b := vg.Point{X: trX(d.B.X), Y: trY(d.B.Y)}
+	line := draw.LineStyle{Color: theme.Ink, Width: vg.Points(0.5)}
+	sign := vg.Length(math.Copysign(1, float64(d.Offset)))
+	sty := sketchText(plt)
+
+	if d.horizontal() {
+		y := a.Y + d.Offset
+		for _, p := range []vg.Point{a, b} {
+			c.StrokeLine2(line, p.X, p.Y+sign*dimensionGap, p.X, y+sign*dimensionTick)
+			c.StrokeLine2(line, p.X-dimensionTick, y-dimensionTick, p.X+dimensionTick, y+dimensionTick)
+		}
+		lo, hi := ordered(a.X, b.X)
+		c.StrokeLine2(line, lo-dimensionTick, y, hi+dimensionTick, y)
+		sty.XAlign, sty.YAlign = draw.XCenter, draw.YBottom
+		c.FillText(sty, vg.Point{X: (a.X + b.X) / 2, Y: y + 1}, d.Text)
+		return
+	}
+	x := a.X + d.Offset
+	for _, p := range []vg.Point{a, b} {
+		c.StrokeLine2(line, p.X+sign*dimensionGap, p.Y, x+sign*dimensionTick, p.Y)
+		c.StrokeLine2(line, x-dimensionTick, p.Y-dimensionTick, x+dimensionTick, p.Y+dimensionTick)
+	}
+	lo, hi := ordered(a.Y, b.Y)
+	c.StrokeLine2(line, x, lo-dimensionTick, x, hi+dimensionTick)
+	sty.XAlign, sty.YAlign = draw.XCenter, draw.YBottom
+	sty.Rotation = math.Pi / 2
+	c.FillText(sty, vg.Point{X: x - 1, Y: (a.Y + b.Y) / 2}, d.Text)
+}
+
+// ordered returns two lengths, the smaller first
+func ordered(a, b vg.Length) (vg.Length, vg.Length) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// GlyphBoxes leaves room for the dimension line and its text beside the
+// sketch
+func (d dimension) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	sty := sketchText(plt)
+	h := sty.Height(d.Text) + dimensionTick
+	box := plot.GlyphBox{X: plt.X.Norm(d.A.X), Y: plt.Y.Norm(d.A.Y)}
+	if d.horizontal() {
+		box.X = plt.X.Norm((d.A.X + d.B.X) / 2)
+		box.Rectangle = vg.Rectangle{Min: vg.Point{X: -1, Y: min(0, d.Offset-dimensionTick)}, Max: vg.Point{X: 1, Y: vg.Length(math.Max(0, float64(d.Offset+h)))}}
+	} else {
+		box.Y = plt.Y.Norm((d.A.Y + d.B.Y) / 2)
+		box.Rectangle = vg.Rectangle{Min: vg.Point{X: min(0, d.Offset-h), Y: -1}, Max: vg.Point{X: vg.Length(math.Max(0, float64(d.Offset+dimensionTick))), Y: 1}}
+	}
+	return []plot.GlyphBox{box}
+}
+
+// callouts is a plotter drawing leaders from points of a sketch to callouts
+// on its right, Length past the edge at Right, spread apart so that the
+// callouts overlap neither each other nor the labels beside the sketch at
+// the heights Avoid
+type callouts struct {
+	Points []plotter.XY
+	Texts  []string
+	Right  float64 // Data x of the sketch edge
+	Length vg.Length
+	Avoid  []float64 // Data y of the labels beside the sketch
+}
+
+// Plot draws each leader from a dot at its point to the edge of the sketch,
+// then level with its callout to the end
+func (l callouts) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	sty := sketchText(plt)
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YCenter
+	line := draw.LineStyle{Color: theme.Ink, Width: vg.Points(0.5)}
+	edge := trX(l.Right) + dimensionGap
+	x := trX(l.Right) + l.Length
+
+	order := make([]int, len(l.Points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return l.Points[order[i]].Y > l.Points[order[j]].Y })
+	var previous vg.Length
+	for n, i := range order {
+		from := vg.Point{X: trX(l.Points[i].X), Y: trY(l.Points[i].Y)}
+		spacing := sty.Height(l.Texts[i]) + 2
+		y := from.Y
+		if n > 0 && y > previous-spacing {
+			y = previous - spacing
+		}
+		for _, a := range l.Avoid {
+			if ay := trY(a); y > ay-spacing && y < ay+spacing {
+				y = ay - spacing
+			}
+		}
+		previous = y
+		c.StrokeLines(line, []vg.Point{from, {X: edge, Y: y}, {X: x + 8, Y: y}})
+		c.DrawGlyph(draw.GlyphStyle{Color: theme.Ink, Radius: 1.5, Shape: draw.CircleGlyph{}}, from)
+		c.FillText(sty, vg.Point{X: x + 10, Y: y}, l.Texts[i])
+	}
+}
+
+// GlyphBoxes leaves room for the callouts on the right of the sketch
+func (l callouts) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	sty := sketchText(plt)
+	boxes := make([]plot.GlyphBox, len(l.Points))
+	for i, pt := range l.Points {
+		w, h := sty.Width(l.Texts[i]), sty.Height(l.Texts[i])
+		boxes[i] = plot.GlyphBox{
+			X:         plt.X.Norm(l.Right),
+			Y:         plt.Y.Norm(pt.Y),
+			Rectangle: vg.Rectangle{Min: vg.Point{X: 0, Y: -h}, Max: vg.Point{X: l.Length + 10 + w, Y: h / 2}},
+		}
+	}
+	return boxes
+}
+
+// dimensionSection adds to a section diagram the dimensions of a sketch: the
+// width b below, the height h, the depths d and d' of the steel and the
+// cover to it on the left and the clear cover on the right
+func dimensionSection(p *plot.Plot, data SectionDiagramData) {
+	u := data.system()
+	length := func(v float64) string {
+		if u.Length.Factor == 1 {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.*f", 1+u.Length.Digits, u.Length.FromSI(v))
+	}
+	minX, maxX := 0.0, data.Width
+	for i, v := range data.Vertices {
+		if i == 0 {
+			minX, maxX = v.X, v.X
+		}
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+	}
+	top := plotter.XY{X: minX, Y: data.Height}
+
+	p.Add(dimension{A: plotter.XY{X: minX, Y: 0}, B: plotter.XY{X: maxX, Y: 0}, Offset: -dimensionStep, Text: "b = " + length(maxX-minX)})
+	p.Add(dimension{A: plotter.XY{X: minX, Y: 0}, B: top, Offset: -dimensionStep, Text: "h = " + length(data.Height)})
+	offset := -2 * dimensionStep
+	if data.TensionSteelY > 0 {
+		steel := plotter.XY{X: minX, Y: data.TensionSteelY}
+		p.Add(dimension{A: steel, B: top, Offset: offset, Text: "d = " + length(data.Height-data.TensionSteelY)})
+		p.Add(dimension{A: plotter.XY{X: minX, Y: 0}, B: steel, Offset: offset, Text: length(data.TensionSteelY)})
+		offset -= dimensionStep
+	}
+	if data.IsDoubly && data.CompSteelY > 0 {
+		p.Add(dimension{A: plotter.XY{X: minX, Y: data.Height - data.CompSteelY}, B: top, Offset: offset, Text: "d' = " + length(data.CompSteelY)})
+	}
+
+	// Clear cover to the stirrup, where it is drawn
+	stirrup := len(data.Vertices) < 3 && data.ClearCover > 0 && data.StirrupDiameter > 0
+	if stirrup {
+		p.Add(dimension{A: plotter.XY{X: maxX, Y: 0}, B: plotter.XY{X: maxX, Y: data.ClearCover}, Offset: dimensionStep, Text: i18n.T("clear cover") + " = " + length(data.ClearCover)})
+	}
+}
+
+// calloutSection adds to a section diagram a callout of each row of bars of
+// one size with its count, diameter and mark, e.g. 3-Ø25 B1, and of the
+// stirrup, and of the widest spacing of the bars of a crack control check
+// against the maximum, on the right of the section between the edge maxX
+// and the labels of the neutral axis and stress block
+func calloutSection(p *plot.Plot, data SectionDiagramData, bars []Bar, crack *crackGap, maxX float64) {
+	notes := callouts{Right: maxX, Length: 2*dimensionStep + 8, Avoid: []float64{data.Height - data.NeutralAxisDepth, data.Height - data.StressBlockDepth/2}}
+	rows := barRows(bars)
+	barMarks(rows, data.Height)
+	for _, r := range rows {
+		notes.Points = append(notes.Points, plotter.XY{X: r.Last.X, Y: r.Last.Y})
+		notes.Texts = append(notes.Texts, fmt.Sprintf("%d-Ø%.0f %s", r.Count, r.Diameter, r.Mark))
+	}
+	if len(data.Vertices) < 3 && data.ClearCover > 0 && data.StirrupDiameter > 0 {
+		notes.Points = append(notes.Points, plotter.XY{X: data.Width - data.ClearCover - data.StirrupDiameter/2, Y: data.Height * 0.75})
+		notes.Texts = append(notes.Texts, fmt.Sprintf("Ø%.0f stirrup", data.StirrupDiameter))
+	}
+	if crack != nil {
+		u := data.system()
+		compare := "≤"
+		if crack.spacing() > data.MaxBarSpacing {
+			compare = ">"
+		}
+		notes.Points = append(notes.Points, plotter.XY{X: (crack.X0 + crack.X1) / 2, Y: crack.Y})
+		notes.Texts = append(notes.Texts, i18n.T(fmt.Sprintf("s = %s %s %s max", u.Length.Format(crack.spacing(), 0), compare, u.Length.Format(data.MaxBarSpacing, 0))))
+	}
+	if len(notes.Points) > 0 {
+		p.Add(notes)
+	}
+}
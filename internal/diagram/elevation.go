@@ -0,0 +1,326 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// End supports of an elevation, as in the beam file
+const (
+	SupportPinned = "pinned"
+	SupportFixed  = "fixed"
+	SupportFree   = "free"
+)
+
+// Spacing of the loads drawn over an elevation (points)
+const (
+	loadGap     = vg.Length(12) // Between the beam and the lowest load
+	loadRow     = vg.Length(30) // Height of the row of each load, at most
+	loadArrow   = vg.Length(16) // Between the arrows of a uniform load, at most
+	loadHead    = vg.Length(5)  // Length of an arrowhead
+	wallHatches = 5             // Hatches of a fixed end
+)
+
+// ElevationLoad is an unfactored load on a beam, acting downward
+type ElevationLoad struct {
+	Type       string  // Load type, e.g. D or L
+	W          float64 // Uniform load (kN/m), zero for a point load
+	P          float64 // Point load (kN)
+	Start, End float64 // Extent of a uniform load from the left end of the beam (m)
+	X          float64 // Position of a point load from the left end of the beam (m)
+}
+
+// ElevationDiagramData holds the load model of a beam for its elevation:
+// the spans, the end supports and the loads, optionally with the bending
+// moment diagram drawn below it
+type ElevationDiagramData struct {
+	Name        string    // Beam name for the title
+	Spans       []float64 // m
+	Left, Right string    // End supports: SupportPinned (default), SupportFixed or SupportFree
+	Loads       []ElevationLoad
+
+	// Bending moment diagram drawn below the elevation to the same scale,
+	// when set
+	Moments *ForceDiagramData
+
+	// Units of the printed loads, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data ElevationDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// length returns the length of the beam (m)
+func (data ElevationDiagramData) length() float64 {
+	var length float64
+	for _, l := range data.Spans {
+		length += l
+	}
+	return length
+}
+
+// label returns the callout of a load, e.g. "D: w = 18.0 kN/m"
+func (data ElevationDiagramData) label(l ElevationLoad) string {
+	system := data.system()
+	u := system.Force
+	if l.W != 0 {
+		// kN/m in the force per span unit, e.g. kip/ft
+		w := u.FromSI(l.W) * system.Span.Factor
+		return fmt.Sprintf("%s: w = %.*f %s/%s", l.Type, u.Digits, w, u.Label, system.Span.Label)
+	}
+	return fmt.Sprintf("%s: P = %s", l.Type, u.Format(l.P, u.Digits))
+}
+
+// ExportElevationDiagram exports the elevation of a beam with its supports,
+// spans and loads, and its bending moment diagram when given, to a png, svg
+// or pdf file
+func ExportElevationDiagram(data ElevationDiagramData, filename string) error {
+	image, err := elevationImage(data, fileFormat(filename))
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	if err := os.WriteFile(filename, image, 0644); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return elevationSeries(data) })
+}
+
+// ElevationDiagramSVG returns the elevation of a beam as an SVG document
+func ElevationDiagramSVG(data ElevationDiagramData) ([]byte, error) {
+	return elevationImage(data, "svg")
+}
+
+// ElevationDiagramPNG returns the elevation of a beam as a PNG image
+func ElevationDiagramPNG(data ElevationDiagramData) ([]byte, error) {
+	return elevationImage(data, "png")
+}
+
+// ElevationPlot returns the elevation of a beam, without its bending moment
+// diagram, as a plot for drawing with the plots of another document
+func ElevationPlot(data ElevationDiagramData) (*plot.Plot, error) {
+	return elevationPlot(data)
+}
+
+// DrawElevationDiagram draws the elevation of a beam above its bending
+// moment diagram when given on a canvas of another document
+func DrawElevationDiagram(c draw.Canvas, data ElevationDiagramData) error {
+	elevation, err := elevationPlot(data)
+	if err != nil {
+		return err
+	}
+	return drawElevation(c, elevation, data)
+}
+
+// elevationImage draws the elevation in an image format, above the bending
+// moment diagram when given
+func elevationImage(data ElevationDiagramData, format string) ([]byte, error) {
+	elevation, err := elevationPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Moments == nil {
+		return plotImage(elevation, 10*vg.Inch, 4*vg.Inch, format)
+	}
+
+	c, err := newCanvas(10*vg.Inch, 8*vg.Inch, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawElevation(draw.New(c), elevation, data); err != nil {
+		return nil, err
+	}
+	return canvasBytes(c)
+}
+
+// drawElevation draws the plot of the elevation on a canvas, above the
+// bending moment diagram when given, the two sharing the positions along
+// the beam
+func drawElevation(dc draw.Canvas, elevation *plot.Plot, data ElevationDiagramData) error {
+	if data.Moments == nil {
+		elevation.Draw(stamp(dc, elevation.X.Tick.Label))
+		return nil
+	}
+	moments, err := forcePlot(*data.Moments)
+	if err != nil {
+		return err
+	}
+	moments.Title.Text = ""
+	moments.X.Min, moments.X.Max = elevation.X.Min, elevation.X.Max
+
+	tiles := draw.Tiles{
+		Rows: 2, Cols: 1,
+		PadTop: vg.Points(6), PadBottom: vg.Points(6),
+		PadLeft: vg.Points(6), PadRight: vg.Points(12),
+		PadY: vg.Points(12),
+	}
+	canvases := plot.Align([][]*plot.Plot{{elevation}, {moments}}, tiles, stamp(dc, elevation.X.Tick.Label))
+	elevation.Draw(canvases[0][0])
+	moments.Draw(canvases[1][0])
+	return nil
+}
+
+// elevationPlot draws the beam as a line on its supports, the loads above
+// it each in its own row, and the spans dimensioned below
+func elevationPlot(data ElevationDiagramData) (*plot.Plot, error) {
+	length := data.length()
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid beam: length=%.2f m", length)
+	}
+
+	p := newPlot()
+	u := data.system()
+	p.Title.Text = plotTitle(data.Name, "Elevation and Loads")
+	setSpanAxis(p, "Position along the beam", u)
+	p.HideY()
+	p.X.Min, p.X.Max = 0, length
+	p.Y.Min, p.Y.Max = 0, 1
+
+	beam, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: length, Y: 0}})
+	if err != nil {
+		return nil, err
+	}
+	beam.LineStyle.Width = vg.Points(4)
+	beam.LineStyle.Color = theme.Ink
+	p.Add(beam)
+
+	var supports supportMarks
+	var walls fixedEnds
+	for i, end := range []struct {
+		x    float64
+		kind string
+	}{{0, data.Left}, {length, data.Right}} {
+		switch end.kind {
+		case SupportFixed:
+			walls = append(walls, fixedEnd{X: end.x, Left: i == 0})
+		case SupportFree:
+		default:
+			supports = append(supports, end.x)
+		}
+	}
+	start := 0.0
+	for _, l := range data.Spans[:len(data.Spans)-1] {
+		start += l
+		supports = append(supports, start)
+	}
+	p.Add(supports, walls, loadModel{data: data})
+
+	start = 0
+	for i, l := range data.Spans {
+		p.Add(dimension{A: plotter.XY{X: start, Y: 0}, B: plotter.XY{X: start + l, Y: 0}, Offset: -dimensionStep, Text: fmt.Sprintf("L%d = %s", i+1, u.Span.Format(l, 2))})
+		start += l
+	}
+	return p, nil
+}
+
+// fixedEnd is the wall of a fixed end of a beam, beyond its end on the
+// left or the right
+type fixedEnd struct {
+	X    float64
+	Left bool
+}
+
+// fixedEnds is a plotter drawing the walls of the fixed ends of a beam as
+// a vertical line with hatches behind it
+type fixedEnds []fixedEnd
+
+// Plot draws each wall across the beam line
+func (f fixedEnds) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	line := draw.LineStyle{Color: theme.Ink, Width: vg.Points(1.5)}
+	hatch := draw.LineStyle{Color: theme.Ink, Width: vg.Points(0.5)}
+	const half = vg.Length(14)
+	for _, w := range f {
+		x, y := trX(w.X), trY(0)
+		back := vg.Length(6)
+		if w.Left {
+			back = -back
+		}
+		c.StrokeLine2(line, x, y-half, x, y+half)
+		for i := 0; i < wallHatches; i++ {
+			hy := y - half + 2*half*vg.Length(i+1)/wallHatches
+			c.StrokeLine2(hatch, x, hy, x+back, hy-6)
+		}
+	}
+}
+
+// GlyphBoxes leaves room for the walls beside the beam
+func (f fixedEnds) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, len(f))
+	for i, w := range f {
+		r := vg.Rectangle{Min: vg.Point{X: 0, Y: -14}, Max: vg.Point{X: 6, Y: 14}}
+		if w.Left {
+			r.Min.X, r.Max.X = -6, 0
+		}
+		boxes[i] = plot.GlyphBox{X: plt.X.Norm(w.X), Y: plt.Y.Norm(0), Rectangle: r}
+	}
+	return boxes
+}
+
+// loadModel is a plotter drawing the loads of a beam above it, each in its
+// own row from the beam up in the order given and colored by load type: a
+// uniform load as arrows under a line over its extent, a point load as one
+// arrow, each with its callout
+type loadModel struct {
+	data ElevationDiagramData
+}
+
+// Plot draws the rows of loads, as tall as fit between the beam and the
+// top of the plot
+func (m loadModel) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(m.data.Loads) == 0 {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	base := trY(0) + loadGap
+	row := min(loadRow, (trY(1)-base)/vg.Length(len(m.data.Loads)))
+	sty := sketchText(plt)
+	sty.YAlign = draw.YBottom
+
+	colors := map[string]int{}
+	for i, l := range m.data.Loads {
+		if _, ok := colors[l.Type]; !ok {
+			colors[l.Type] = len(colors)
+		}
+		color := paletteColor(colors[l.Type])
+		line := draw.LineStyle{Color: color, Width: vg.Points(1)}
+		bottom := base + row*vg.Length(i)
+		top := bottom + row*0.55
+		arrow := func(x vg.Length) {
+			c.StrokeLine2(line, x, top, x, bottom+loadHead)
+			c.FillPolygon(color, []vg.Point{{X: x, Y: bottom}, {X: x - loadHead/2, Y: bottom + loadHead}, {X: x + loadHead/2, Y: bottom + loadHead}})
+		}
+
+		sty.Color = color
+		if l.W == 0 {
+			x := trX(l.X)
+			arrow(x)
+			sty.XAlign = draw.XLeft
+			c.FillText(sty, vg.Point{X: x + 3, Y: top - sty.Height("P")}, m.data.label(l))
+			continue
+		}
+		x0, x1 := trX(l.Start), trX(l.End)
+		n := int(math.Max(math.Ceil(float64((x1-x0)/loadArrow)), 1))
+		for k := 0; k <= n; k++ {
+			arrow(x0 + (x1-x0)*vg.Length(k)/vg.Length(n))
+		}
+		c.StrokeLine2(line, x0, top, x1, top)
+		sty.XAlign = draw.XCenter
+		c.FillText(sty, vg.Point{X: (x0 + x1) / 2, Y: top + 1}, m.data.label(l))
+	}
+}
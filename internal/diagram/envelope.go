@@ -0,0 +1,237 @@
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// legendRow is the headroom left above a moment envelope for each row of
+// its legend, as a fraction of the range of the moments
+const legendRow = 0.06
+
+// EnvelopeValue is a design moment of a moment envelope, hogging at a
+// support or sagging in a span
+type EnvelopeValue struct {
+	X      float64 // Position along the beam (m)
+	Moment float64 // kN-m, negative in hogging
+}
+
+// EnvelopeDiagramData holds the factored moments of every load combination
+// of a beam superimposed with their envelope, and the design values of the
+// envelope annotated on it
+type EnvelopeDiagramData struct {
+	// Moment diagram with the envelope as its main curve and the
+	// combinations as the others
+	Moments ForceDiagramData
+
+	Values []EnvelopeValue
+	Symbol string // Symbol of the moments, e.g. Mu, or Ma for ASD
+}
+
+// label returns the annotation of a design value, e.g. "−Mu = 142.3 kN-m"
+func (data EnvelopeDiagramData) label(v EnvelopeValue) string {
+	sign := "+"
+	if v.Moment < 0 {
+		sign = "−"
+	}
+	symbol := data.Symbol
+	if symbol == "" {
+		symbol = "M"
+	}
+	return fmt.Sprintf("%s%s = %s", sign, symbol, data.Moments.system().Moment.Format(math.Abs(v.Moment), 1))
+}
+
+// ExportEnvelopeDiagram exports the moment envelope of a beam over the
+// moments of its combinations, with the design values annotated, to a png,
+// svg or pdf file
+func ExportEnvelopeDiagram(data EnvelopeDiagramData, filename string) error {
+	p, err := envelopePlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 6*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return forceSeries(data.Moments) })
+}
+
+// EnvelopeDiagramSVG returns the moment envelope of a beam as an SVG
+// document
+func EnvelopeDiagramSVG(data EnvelopeDiagramData) ([]byte, error) {
+	p, err := envelopePlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 6*vg.Inch, "svg")
+}
+
+// EnvelopeDiagramPNG returns the moment envelope of a beam as a PNG image
+func EnvelopeDiagramPNG(data EnvelopeDiagramData) ([]byte, error) {
+	p, err := envelopePlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 6*vg.Inch, "png")
+}
+
+// EnvelopePlot returns the moment envelope of a beam as a plot, for drawing
+// with the plots of another document
+func EnvelopePlot(data EnvelopeDiagramData) (*plot.Plot, error) {
+	return envelopePlot(data)
+}
+
+// envelopePlot draws each combination in a color of the palette, the
+// envelope over them, and the design values as dots labeled above the
+// sagging and below the hogging moments
+func envelopePlot(data EnvelopeDiagramData) (*plot.Plot, error) {
+	moments := data.Moments
+	moments.Kind = MomentDiagram
+	_, unit := moments.title()
+	p := newPlot()
+	p.Title.Text = plotTitle(moments.Name, "Moment Envelope")
+	setSpanAxis(p, "Position along the beam", moments.system())
+	p.Y.Label.Text = axisLabel("Moment, sagging positive", unit.Label)
+	p.Add(newGrid())
+
+	xys := func(points []Point) plotter.XYs {
+		out := make(plotter.XYs, len(points))
+		for i, pt := range points {
+			out[i] = plotter.XY{X: pt.X, Y: unit.FromSI(pt.Y)}
+		}
+		return out
+	}
+	curve := func(c ForceCurve, sty draw.LineStyle) (*plotter.Line, error) {
+		var first *plotter.Line
+		for i, points := range [][]Point{c.Max, c.Min} {
+			line, err := plotter.NewLine(xys(points))
+			if err != nil {
+				return nil, err
+			}
+			line.LineStyle = sty
+			if i > 0 {
+				line.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+			}
+			p.Add(line)
+			if first == nil {
+				first = line
+			}
+			if !c.differs() {
+				break
+			}
+		}
+		return first, nil
+	}
+
+	for i, c := range moments.Others {
+		line, err := curve(c, draw.LineStyle{Color: paletteColor(i), Width: vg.Points(1)})
+		if err != nil {
+			return nil, err
+		}
+		addLegend(p, c.Label, line)
+	}
+
+	for _, e := range []struct {
+		points []Point
+		color  color.Color
+		name   string
+	}{{moments.Main.Max, theme.Primary, "Envelope, maximum"}, {moments.Main.Min, theme.Secondary, "Envelope, minimum"}} {
+		line, err := plotter.NewLine(xys(e.points))
+		if err != nil {
+			return nil, err
+		}
+		line.LineStyle.Width = vg.Points(2.5)
+		line.LineStyle.Color = e.color
+		p.Add(line)
+		addLegend(p, e.name, line)
+	}
+
+	var supports plotter.XYs
+	for _, x := range moments.Supports {
+		supports = append(supports, plotter.XY{X: x, Y: 0})
+	}
+	if len(supports) > 0 {
+		s, err := plotter.NewScatter(supports)
+		if err != nil {
+			return nil, err
+		}
+		s.GlyphStyle.Shape = draw.TriangleGlyph{}
+		s.GlyphStyle.Radius = vg.Points(5)
+		s.GlyphStyle.Color = theme.Ink
+		p.Add(s)
+	}
+
+	if err := annotateEnvelope(p, data); err != nil {
+		return nil, err
+	}
+
+	// Headroom above the envelope for the legend, a row for each curve
+	top, bottom := 0.0, 0.0
+	for i := range moments.Main.Max {
+		top = math.Max(top, unit.FromSI(moments.Main.Max[i].Y))
+		bottom = math.Min(bottom, unit.FromSI(moments.Main.Min[i].Y))
+	}
+	rows := float64(len(moments.Others) + 2)
+	p.Y.Max = math.Max(p.Y.Max, top+legendRow*rows*(top-bottom))
+	p.Legend.Top = true
+	return p, nil
+}
+
+// annotateEnvelope marks the design values of the envelope with a dot,
+// labeled above it when sagging and below it when hogging
+func annotateEnvelope(p *plot.Plot, data EnvelopeDiagramData) error {
+	if len(data.Values) == 0 {
+		return nil
+	}
+	unit := data.Moments.system().Moment
+	length := 0.0
+	for _, pt := range data.Moments.Main.Max {
+		length = math.Max(length, pt.X)
+	}
+
+	for _, sagging := range []bool{true, false} {
+		var labels plotter.XYLabels
+		for _, v := range data.Values {
+			if (v.Moment >= 0) == sagging {
+				labels.XYs = append(labels.XYs, plotter.XY{X: v.X, Y: unit.FromSI(v.Moment)})
+				labels.Labels = append(labels.Labels, data.label(v))
+			}
+		}
+		if len(labels.XYs) == 0 {
+			continue
+		}
+		dots, err := plotter.NewScatter(labels.XYs)
+		if err != nil {
+			return err
+		}
+		dots.GlyphStyle.Shape = draw.CircleGlyph{}
+		dots.GlyphStyle.Radius = vg.Points(3)
+		dots.GlyphStyle.Color = theme.Ink
+
+		l, err := newLabels(labels)
+		if err != nil {
+			return err
+		}
+		l.Offset = vg.Point{Y: vg.Points(6)}
+		for i, at := range labels.XYs {
+			sty := &l.TextStyle[i]
+			sty.XAlign, sty.YAlign = draw.XCenter, draw.YBottom
+			if !sagging {
+				sty.YAlign, l.Offset.Y = draw.YTop, -vg.Points(6)
+			}
+			switch {
+			case at.X < 0.05*length:
+				sty.XAlign = draw.XLeft
+			case at.X > 0.95*length:
+				sty.XAlign = draw.XRight
+			}
+		}
+		p.Add(dots, l)
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size of a fiber diagram and of the color scale at its right
+const (
+	fiberWidth      = 9.5 * vg.Inch
+	fiberHeight     = 6 * vg.Inch
+	fiberScaleWidth = 1.5 * vg.Inch
+)
+
+// fiberScaleBands is the number of bands the color scale is drawn in
+const fiberScaleBands = 100
+
+// FiberField is the quantity the fibers of a fiber diagram are colored by
+type FiberField int
+
+// Fields of a fiber diagram
+const (
+	FiberStress FiberField = iota
+	FiberStrain
+)
+
+// FiberCell is a stretch of a strip of concrete across the section, in the
+// confined core or in the cover
+type FiberCell struct {
+	XMin, XMax float64 // mm
+	Stress     float64 // MPa, compression positive
+}
+
+// FiberStrip is a horizontal strip of the concrete of a section
+type FiberStrip struct {
+	Y      float64 // Center (mm)
+	Height float64 // mm
+	Strain float64 // Compression positive
+	Cells  []FiberCell
+}
+
+// FiberLayer is a steel layer of a section with its strain and stress
+type FiberLayer struct {
+	Y      float64 // mm
+	Strain float64 // Compression positive
+	Stress float64 // MPa, compression positive
+}
+
+// FiberDiagramData holds the strain and stress over the discretized
+// concrete and the steel layers of a section at a point of its fiber
+// moment-curvature analysis
+type FiberDiagramData struct {
+	Name string // Section name for the title
+
+	// Outline, bars and units of the section, with the neutral axis depth
+	// of the point; its stress block is not drawn
+	Section SectionDiagramData
+
+	Core   []Point // Hoop centerline bounding the confined core, if any
+	Strips []FiberStrip
+	Layers []FiberLayer
+	Field  FiberField
+
+	TopStrain float64 // Extreme compression fiber strain
+	Curvature float64 // 1/m
+	Moment    float64 // kN-m
+}
+
+// value returns the value of a cell of a strip colored in the diagram
+func (data FiberDiagramData) value(strip FiberStrip, cell FiberCell) float64 {
+	if data.Field == FiberStrain {
+		return strip.Strain
+	}
+	return cell.Stress
+}
+
+// scale returns the range of the values colored in the diagram, with zero
+// in it, and the largest magnitude, which takes the deepest color
+func (data FiberDiagramData) scale() (lo, hi, limit float64) {
+	for _, s := range data.Strips {
+		for _, c := range s.Cells {
+			v := data.value(s, c)
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	limit = math.Max(-lo, hi)
+	if limit == 0 {
+		return 0, 1, 1
+	}
+	return lo, hi, limit
+}
+
+// ExportFiberDiagram exports the section colored by the stress or strain of
+// its fibers, beside the color scale, to a png, svg or pdf file
+func ExportFiberDiagram(data FiberDiagramData, filename string) error {
+	if !supportedFormat(filename) {
+		filename += ".png"
+	}
+	image, err := fiberImage(data, fileFormat(filename))
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	if err := os.WriteFile(filename, image, 0644); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return fiberSeries(data) })
+}
+
+// FiberDiagramSVG returns the section colored by its fibers as an SVG
+// document
+func FiberDiagramSVG(data FiberDiagramData) ([]byte, error) {
+	return fiberImage(data, "svg")
+}
+
+// FiberDiagramPNG returns the section colored by its fibers as a PNG image
+func FiberDiagramPNG(data FiberDiagramData) ([]byte, error) {
+	return fiberImage(data, "png")
+}
+
+// DrawFiberDiagram draws the section colored by the stress or strain of its
+// fibers, beside the color scale, on a canvas of another document
+func DrawFiberDiagram(c draw.Canvas, data FiberDiagramData) error {
+	return drawFibers(c, data)
+}
+
+// fiberImage draws the fiber diagram in an image format
+func fiberImage(data FiberDiagramData, format string) ([]byte, error) {
+	c, err := newCanvas(fiberWidth, fiberHeight, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := drawFibers(draw.New(c), data); err != nil {
+		return nil, err
+	}
+	return canvasBytes(c)
+}
+
+// drawFibers draws the section to scale with the color scale at its right,
+// the scale spanning the height of the plotted section
+func drawFibers(dc draw.Canvas, data FiberDiagramData) error {
+	if len(data.Strips) == 0 {
+		return fmt.Errorf("no fibers to draw")
+	}
+	section, err := fiberPlot(data)
+	if err != nil {
+		return err
+	}
+	scale, err := fiberScalePlot(data)
+	if err != nil {
+		return err
+	}
+
+	dc = stamp(dc, section.X.Tick.Label)
+	pad := vg.Points(6)
+	width := dc.Max.X - dc.Min.X
+	left := draw.Crop(dc, pad, -fiberScaleWidth, pad, -pad)
+	equalScale(section, left)
+	section.Draw(left)
+
+	area := section.DataCanvas(left)
+	right := draw.Crop(dc, width-fiberScaleWidth+pad, -vg.Points(12), area.Min.Y-dc.Min.Y, area.Max.Y-dc.Max.Y)
+	scale.Draw(right)
+	return nil
+}
+
+// fiberPlot draws the section with each strip of concrete colored by its
+// stress or strain, the core and the neutral axis over it, and the value of
+// each steel layer at its left
+func fiberPlot(data FiberDiagramData) (*plot.Plot, error) {
+	p, err := outlinedSection(data.Section, func(p *plot.Plot, _ SectionDiagramData, outline []Point) error {
+		return fiberZone(p, data, outline)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u := data.Section.system()
+	title := "Fiber Stresses"
+	if data.Field == FiberStrain {
+		title = "Fiber Strains"
+	}
+	p.Title.Text = plotTitle(data.Name, title)
+	p.Title.Text += fmt.Sprintf("\nεc = %.4f, φ = %.5f 1/m, M = %s", data.TopStrain, data.Curvature, u.Moment.Format(data.Moment, 1))
+	return p, nil
+}
+
+// fiberZone draws the colored strips of concrete within the outline of a
+// section, the outline again over their edges, the confined core, the
+// neutral axis and the labels of the steel layers
+func fiberZone(p *plot.Plot, data FiberDiagramData, outline []Point) error {
+	_, _, limit := data.scale()
+	p.Add(fiberCells{data: data, limit: limit})
+
+	edge := make(plotter.XYs, len(outline)+1)
+	minX, maxX := outline[0].X, outline[0].X
+	for i, v := range outline {
+		edge[i] = plotter.XY{X: v.X, Y: v.Y}
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+	}
+	edge[len(outline)] = edge[0]
+	line, err := plotter.NewLine(edge)
+	if err != nil {
+		return err
+	}
+	line.LineStyle.Width = vg.Points(2)
+	line.LineStyle.Color = theme.Ink
+	p.Add(line)
+
+	if len(data.Core) >= 3 {
+		core := make(plotter.XYs, len(data.Core)+1)
+		for i, v := range data.Core {
+			core[i] = plotter.XY{X: v.X, Y: v.Y}
+		}
+		core[len(data.Core)] = core[0]
+		hoop, err := plotter.NewLine(core)
+		if err != nil {
+			return err
+		}
+		hoop.LineStyle.Width = vg.Points(0.75)
+		hoop.LineStyle.Color = theme.Muted
+		hoop.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(2)}
+		p.Add(hoop)
+	}
+
+	u := data.Section.system()
+	var labels plotter.XYLabels
+	if c := data.Section.NeutralAxisDepth; c > 0 && c < data.Section.Height {
+		naY := data.Section.Height - c
+		na, err := plotter.NewLine(plotter.XYs{{X: minX - 20, Y: naY}, {X: maxX + 20, Y: naY}})
+		if err != nil {
+			return err
+		}
+		na.LineStyle.Width = vg.Points(1.5)
+		na.LineStyle.Color = theme.Alert
+		na.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+		p.Add(na)
+		labels.XYs = append(labels.XYs, plotter.XY{X: maxX + 30, Y: naY})
+		labels.Labels = append(labels.Labels, "N.A.")
+	}
+	for _, l := range data.Layers {
+		labels.XYs = append(labels.XYs, plotter.XY{X: minX - 10, Y: l.Y})
+		if data.Field == FiberStrain {
+			labels.Labels = append(labels.Labels, fmt.Sprintf("εs = %.5f", l.Strain))
+		} else {
+			labels.Labels = append(labels.Labels, "fs = "+u.Stress.Format(l.Stress, 1))
+		}
+	}
+	if len(labels.XYs) == 0 {
+		return nil
+	}
+	l, err := newLabels(labels)
+	if err != nil {
+		return err
+	}
+	for i := range l.TextStyle {
+		l.TextStyle[i].XAlign, l.TextStyle[i].YAlign = draw.XRight, draw.YCenter
+	}
+	if data.Section.NeutralAxisDepth > 0 && data.Section.NeutralAxisDepth < data.Section.Height {
+		l.TextStyle[0].XAlign = draw.XLeft
+	}
+	p.Add(l)
+	return nil
+}
+
+// fiberScalePlot draws the color scale of a fiber diagram from its lowest
+// to its highest value
+func fiberScalePlot(data FiberDiagramData) (*plot.Plot, error) {
+	lo, hi, limit := data.scale()
+	u := data.Section.system()
+	p := newPlot()
+	p.HideX()
+	if data.Field == FiberStrain {
+		p.Y.Label.Text = i18n.T("Strain (compression positive)")
+	} else {
+		lo, hi = u.Stress.FromSI(lo), u.Stress.FromSI(hi)
+		limit = u.Stress.FromSI(limit)
+		p.Y.Label.Text = axisLabel("Stress, compression positive", u.Stress.Label)
+	}
+	p.Y.Min, p.Y.Max = lo, hi
+	p.X.Min, p.X.Max = 0, 1
+	p.Add(fiberBands{lo: lo, hi: hi, limit: limit})
+	return p, nil
+}
+
+// fiberColor returns the color of a value of a fiber diagram, from the
+// background at zero to the color of compressive forces, or of tensile
+// forces when negative, at the largest magnitude
+func fiberColor(v, limit float64) color.Color {
+	end := theme.Primary
+	if v < 0 {
+		end = theme.Secondary
+	}
+	t := math.Min(math.Abs(v)/limit, 1)
+	from := color.NRGBAModel.Convert(theme.Background).(color.NRGBA)
+	to := color.NRGBAModel.Convert(end).(color.NRGBA)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + t*(float64(b)-float64(a))))
+	}
+	return color.NRGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 255}
+}
+
+// fiberCells is a plotter filling each cell of the strips of a fiber
+// diagram in the color of its value
+type fiberCells struct {
+	data  FiberDiagramData
+	limit float64
+}
+
+// Plot fills the cells, each overlapping the one above it by a point
+// so that no seams show between the strips
+func (f fiberCells) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, s := range f.data.Strips {
+		bottom, top := trY(s.Y-s.Height/2), trY(s.Y+s.Height/2)+vg.Points(1)
+		for _, cell := range s.Cells {
+			x0, x1 := trX(cell.XMin), trX(cell.XMax)
+			c.FillPolygon(fiberColor(f.data.value(s, cell), f.limit), []vg.Point{
+				{X: x0, Y: bottom}, {X: x1, Y: bottom}, {X: x1, Y: top}, {X: x0, Y: top},
+			})
+		}
+	}
+}
+
+// fiberBands is a plotter drawing the color scale of a fiber diagram as
+// bands across the plot from its lowest to its highest value
+type fiberBands struct {
+	lo, hi, limit float64
+}
+
+// Plot fills the bands
+func (b fiberBands) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	step := (b.hi - b.lo) / fiberScaleBands
+	for i := 0; i < fiberScaleBands; i++ {
+		v0 := b.lo + float64(i)*step
+		y0, y1 := trY(v0), trY(v0+step)+vg.Points(1)
+		if i == fiberScaleBands-1 {
+			y1 = trY(b.hi)
+		}
+		c.FillPolygon(fiberColor(v0+step/2, b.limit), []vg.Point{
+			{X: trX(0), Y: y0}, {X: trX(1), Y: y0}, {X: trX(1), Y: y1}, {X: trX(0), Y: y1},
+		})
+	}
+	c.StrokeLines(draw.LineStyle{Color: theme.Ink, Width: vg.Points(0.75)}, []vg.Point{
+		{X: trX(0), Y: trY(b.lo)}, {X: trX(1), Y: trY(b.lo)}, {X: trX(1), Y: trY(b.hi)},
+		{X: trX(0), Y: trY(b.hi)}, {X: trX(0), Y: trY(b.lo)},
+	})
+}
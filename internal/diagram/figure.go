@@ -0,0 +1,69 @@
+package diagram
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// Formats are the image formats, by file extension, of exported diagrams
+var Formats = []string{"png", "svg", "pdf", "eps", "jpg", "jpeg", "tif", "tiff"}
+
+// FigureOptions sets the size and resolution of exported diagrams
+type FigureOptions struct {
+	Width, Height vg.Length // Size of every diagram, its own size when zero
+	DPI           int       // Resolution of png, jpeg and tiff images, 96 when zero
+}
+
+// Figure is the size and resolution of exported diagrams
+var Figure FigureOptions
+
+// size returns the size of a diagram drawn at width by height by default
+func (f FigureOptions) size(width, height vg.Length) (vg.Length, vg.Length) {
+	if f.Width > 0 && f.Height > 0 {
+		return f.Width, f.Height
+	}
+	return width, height
+}
+
+// supportedFormat reports whether diagrams are exported to files with the
+// extension of filename
+func supportedFormat(filename string) bool {
+	return slices.Contains(Formats, fileFormat(filename))
+}
+
+// fileFormat returns the image format of a file by its extension
+func fileFormat(filename string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+}
+
+// newCanvas returns the canvas of a diagram of a size in an image format,
+// at the size and resolution of Figure
+func newCanvas(width, height vg.Length, format string) (vg.CanvasWriterTo, error) {
+	width, height = Figure.size(width, height)
+	if Figure.DPI <= 0 {
+		return draw.NewFormattedCanvas(width, height, format)
+	}
+	switch format {
+	case "png":
+		return vgimg.PngCanvas{Canvas: newRaster(width, height)}, nil
+	case "jpg", "jpeg":
+		return vgimg.JpegCanvas{Canvas: newRaster(width, height)}, nil
+	case "tif", "tiff":
+		return vgimg.TiffCanvas{Canvas: newRaster(width, height)}, nil
+	}
+	return draw.NewFormattedCanvas(width, height, format)
+}
+
+// newRaster returns a bitmap canvas of a diagram at the resolution of
+// Figure, of the size given by the caller
+func newRaster(width, height vg.Length) *vgimg.Canvas {
+	if Figure.DPI <= 0 {
+		return vgimg.New(width, height)
+	}
+	return vgimg.NewWith(vgimg.UseWH(width, height), vgimg.UseDPI(Figure.DPI))
+}
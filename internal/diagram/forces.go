@@ -0,0 +1,302 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ForceDiagramKind selects the bending moment or shear force diagram
+type ForceDiagramKind int
+
+const (
+	MomentDiagram ForceDiagramKind = iota
+	ShearDiagram
+)
+
+// ForceCurve is the range of a factored moment or shear along a beam, as
+// points with X the position (m) and Y the moment (kN-m) or shear (kN).
+// Max and Min are the same curve for a combination without "or" or
+// reversible loads.
+type ForceCurve struct {
+	Label    string
+	Max, Min []Point
+}
+
+// differs reports whether the curve is a range, its maximum and minimum
+// apart somewhere along the beam
+func (c ForceCurve) differs() bool {
+	for i := range c.Max {
+		if c.Max[i].Y != c.Min[i].Y {
+			return true
+		}
+	}
+	return false
+}
+
+// ForceDiagramData holds the bending moment or shear force diagram of a
+// beam for one combination or the envelope of several
+type ForceDiagramData struct {
+	Name     string // Beam name for the title
+	Kind     ForceDiagramKind
+	Main     ForceCurve   // Combination or envelope drawn in full
+	Others   []ForceCurve // Combinations drawn faintly behind the envelope
+	Supports []float64    // Positions of the supports (m)
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data ForceDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// title returns the name and unit of the diagram
+func (data ForceDiagramData) title() (string, units.Unit) {
+	u := data.system()
+	if data.Kind == ShearDiagram {
+		return "Shear Force Diagram", u.Force
+	}
+	return "Bending Moment Diagram", u.Moment
+}
+
+// DrawASCIIForceDiagram draws the bending moment or shear force diagram of
+// a beam in text, sagging moments and upward shears on the left of a
+// section above the axis
+func DrawASCIIForceDiagram(data ForceDiagramData) string {
+	width, rows := textColumns(60), textRows(16)
+	title, unit := data.title()
+	length := 0.0
+	for _, p := range data.Main.Max {
+		length = math.Max(length, p.X)
+	}
+
+	// Extremes of each column
+	hi, lo := make([]float64, width), make([]float64, width)
+	var top, bottom float64
+	for c := 0; c < width; c++ {
+		x0, x1 := length*float64(c)/float64(width), length*float64(c+1)/float64(width)
+		hi[c] = math.Max(curveAt(data.Main.Max, (x0+x1)/2), 0)
+		lo[c] = math.Min(curveAt(data.Main.Min, (x0+x1)/2), 0)
+		for i, p := range data.Main.Max {
+			if p.X >= x0 && p.X <= x1 {
+				hi[c] = math.Max(hi[c], p.Y)
+				lo[c] = math.Min(lo[c], data.Main.Min[i].Y)
+			}
+		}
+		top, bottom = math.Max(top, hi[c]), math.Min(bottom, lo[c])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("  %s - %s\n", strings.ToUpper(title), data.Main.Label))
+	sb.WriteString("  " + strings.Repeat("─", len([]rune(title))+3+len([]rune(data.Main.Label))) + "\n\n")
+	if top == 0 && bottom == 0 {
+		sb.WriteString("  (zero along the beam)\n")
+		return textOut(sb.String())
+	}
+
+	// Rows above and below the axis in proportion to the extremes
+	up := int(math.Round(float64(rows) * top / (top - bottom)))
+	if top > 0 && up == 0 {
+		up = 1
+	}
+	if bottom < 0 && up == rows {
+		up = rows - 1
+	}
+	down := rows - up
+	step := (top - bottom) / float64(rows)
+
+	label := func(v float64) string { return fmt.Sprintf("%12s", unit.Format(v, 1)) }
+	blank := strings.Repeat(" ", 12)
+	for r := up; r >= 1; r-- {
+		line := blank
+		if r == up {
+			line = label(top)
+		}
+		sb.WriteString("  " + line + " │")
+		for c := 0; c < width; c++ {
+			if hi[c] >= (float64(r)-0.5)*step {
+				sb.WriteString("█")
+			} else {
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	axis := []rune(strings.Repeat("─", width))
+	for _, s := range data.Supports {
+		c := int(s / length * float64(width))
+		if c >= width {
+			c = width - 1
+		}
+		axis[c] = '▲'
+	}
+	sb.WriteString("  " + label(0) + " ┼" + string(axis) + "\n")
+
+	for r := 1; r <= down; r++ {
+		line := blank
+		if r == down {
+			line = label(bottom)
+		}
+		sb.WriteString("  " + line + " │")
+		for c := 0; c < width; c++ {
+			if -lo[c] >= (float64(r)-0.5)*step {
+				sb.WriteString("█")
+			} else {
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("  %s  0%s%.2f m\n", blank, strings.Repeat(" ", width-len(fmt.Sprintf("%.2f m", length))), length))
+	sb.WriteString("  ▲ = Support\n")
+	return textOut(sb.String())
+}
+
+// curveAt interpolates a curve at x, taking the first of two points at the
+// same position
+func curveAt(points []Point, x float64) float64 {
+	for i := 1; i < len(points); i++ {
+		p1, p2 := points[i-1], points[i]
+		if x < p1.X || x > p2.X {
+			continue
+		}
+		if p2.X == p1.X {
+			return p1.Y
+		}
+		return p1.Y + (p2.Y-p1.Y)*(x-p1.X)/(p2.X-p1.X)
+	}
+	return 0
+}
+
+// ExportForceDiagram exports a bending moment or shear force diagram to a
+// png, svg or pdf file
+func ExportForceDiagram(data ForceDiagramData, filename string) error {
+	p, err := forcePlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 5*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return forceSeries(data) })
+}
+
+// ForceDiagramSVG returns a bending moment or shear force diagram as an SVG
+// document
+func ForceDiagramSVG(data ForceDiagramData) ([]byte, error) {
+	p, err := forcePlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "svg")
+}
+
+// ForceDiagramPNG returns a bending moment or shear force diagram as a PNG
+// image
+func ForceDiagramPNG(data ForceDiagramData) ([]byte, error) {
+	p, err := forcePlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 5*vg.Inch, "png")
+}
+
+// ForcePlot returns a bending moment or shear force diagram as a plot, for
+// drawing with the plots of another document
+func ForcePlot(data ForceDiagramData) (*plot.Plot, error) {
+	return forcePlot(data)
+}
+
+// forcePlot draws the diagram of the main curve over the faint curves of
+// the other combinations, with the supports on the axis
+func forcePlot(data ForceDiagramData) (*plot.Plot, error) {
+	title, unit := data.title()
+	p := newPlot()
+	p.Title.Text = plotTitle(data.Name, title) + " - " + i18n.T(data.Main.Label)
+	setSpanAxis(p, "Position along the beam", data.system())
+	p.Y.Label.Text = axisLabel("Moment, sagging positive", unit.Label)
+	if data.Kind == ShearDiagram {
+		p.Y.Label.Text = axisLabel("Shear", unit.Label)
+	}
+	p.Add(newGrid())
+
+	xys := func(points []Point) plotter.XYs {
+		out := make(plotter.XYs, len(points))
+		for i, pt := range points {
+			out[i] = plotter.XY{X: pt.X, Y: unit.FromSI(pt.Y)}
+		}
+		return out
+	}
+
+	for _, c := range data.Others {
+		for _, points := range [][]Point{c.Max, c.Min} {
+			line, err := plotter.NewLine(xys(points))
+			if err != nil {
+				return nil, err
+			}
+			line.LineStyle.Width = vg.Points(0.75)
+			line.LineStyle.Color = theme.Faint
+			p.Add(line)
+			if !c.differs() {
+				break
+			}
+		}
+	}
+
+	maxLine, err := plotter.NewLine(xys(data.Main.Max))
+	if err != nil {
+		return nil, err
+	}
+	maxLine.LineStyle.Width = vg.Points(2)
+	maxLine.LineStyle.Color = theme.Primary
+	p.Add(maxLine)
+	if data.Main.differs() {
+		addLegend(p, "Maximum", maxLine)
+		minLine, err := plotter.NewLine(xys(data.Main.Min))
+		if err != nil {
+			return nil, err
+		}
+		minLine.LineStyle.Width = vg.Points(2)
+		minLine.LineStyle.Color = theme.Secondary
+		p.Add(minLine)
+		addLegend(p, "Minimum", minLine)
+	} else {
+		addLegend(p, data.Main.Label, maxLine)
+	}
+	if len(data.Others) > 0 {
+		other, _ := plotter.NewLine(plotter.XYs{{}, {}})
+		other.LineStyle.Color = theme.Faint
+		addLegend(p, "Combinations", other)
+	}
+
+	var supports plotter.XYs
+	for _, x := range data.Supports {
+		supports = append(supports, plotter.XY{X: x, Y: 0})
+	}
+	if len(supports) > 0 {
+		s, err := plotter.NewScatter(supports)
+		if err != nil {
+			return nil, err
+		}
+		s.GlyphStyle.Shape = draw.TriangleGlyph{}
+		s.GlyphStyle.Radius = vg.Points(5)
+		s.GlyphStyle.Color = theme.Ink
+		p.Add(s)
+	}
+	p.Legend.Top = true
+	return p, nil
+}
@@ -1,98 +1,198 @@
 package diagram
 
 import (
-	"fmt"
-	"image/color"
-	"os"
+	"bytes"
+	"math"
 	"path/filepath"
+	"slices"
 
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
 )
 
-// ExportSectionDiagram exports a beam section diagram to an image file
+// ExportSectionDiagram exports a beam section diagram to an image file. SVG
+// files are dimensioned sketches of the section.
 func ExportSectionDiagram(data SectionDiagramData, filename string) error {
-	p := plot.New()
-	p.Title.Text = "Beam Section Analysis"
-	p.X.Label.Text = "Width (mm)"
-	p.Y.Label.Text = "Height (mm)"
-
-	var minX, maxX float64
-
-	// Check if we have custom vertices (non-rectangular section)
-	if len(data.Vertices) >= 3 {
-		// Draw custom section outline
-		beamOutline := make(plotter.XYs, len(data.Vertices)+1)
-		minX, maxX = data.Vertices[0].X, data.Vertices[0].X
-		
-		for i, v := range data.Vertices {
-			beamOutline[i] = plotter.XY{X: v.X, Y: v.Y}
-			if v.X < minX {
-				minX = v.X
-			}
-			if v.X > maxX {
-				maxX = v.X
-			}
-		}
-		beamOutline[len(data.Vertices)] = plotter.XY{X: data.Vertices[0].X, Y: data.Vertices[0].Y}
+	plotSection := sectionPlot
+	if filepath.Ext(filename) == ".svg" {
+		plotSection = sketchPlot
+	}
+	p, err := plotSection(data)
+	if err != nil {
+		return err
+	}
 
-		beamLine, err := plotter.NewLine(beamOutline)
-		if err != nil {
-			return err
-		}
-		beamLine.LineStyle.Width = vg.Points(2)
-		beamLine.LineStyle.Color = color.Black
-		p.Add(beamLine)
-
-		// Draw stress block by clipping section at stress block depth
-		stressBlockPts := clipSectionAtDepth(data.Vertices, data.Height, data.StressBlockDepth)
-		if len(stressBlockPts) >= 3 {
-			stressBlock, err := plotter.NewPolygon(stressBlockPts)
-			if err == nil {
-				stressBlock.Color = color.RGBA{R: 100, G: 149, B: 237, A: 150}
-				stressBlock.LineStyle.Color = color.RGBA{R: 0, G: 0, B: 139, A: 255}
-				p.Add(stressBlock)
+	// Files without an image format are exported as png
+	width := 8 * vg.Inch
+	height := 6 * vg.Inch
+	if !supportedFormat(filename) {
+		filename += ".png"
+	}
+	if err := savePlot(p, width, height, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return sectionSeries(data) })
+}
+
+// SectionDiagramSVG returns a beam section diagram as an SVG document,
+// dimensioned as a sketch of the section
+func SectionDiagramSVG(data SectionDiagramData) ([]byte, error) {
+	p, err := sketchPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 8*vg.Inch, 6*vg.Inch, "svg")
+}
+
+// SectionDiagramPNG returns a beam section diagram as a PNG image
+func SectionDiagramPNG(data SectionDiagramData) ([]byte, error) {
+	p, err := sectionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 8*vg.Inch, 6*vg.Inch, "png")
+}
+
+// SectionPlot returns a beam section diagram as a plot, for drawing with the
+// plots of another document. The section is to scale in an 8 by 6 inch
+// figure; EqualScale keeps it to scale on a canvas of another size.
+func SectionPlot(data SectionDiagramData) (*plot.Plot, error) {
+	return sectionPlot(data)
+}
+
+// SectionSketchPlot returns a beam section diagram dimensioned as a sketch
+// of the section as a plot, as SectionPlot
+func SectionSketchPlot(data SectionDiagramData) (*plot.Plot, error) {
+	return sketchPlot(data)
+}
+
+// canvasBytes returns a drawn canvas in its image format
+func canvasBytes(c vg.CanvasWriterTo) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sectionPlot draws the outline, stress block and steel of a beam section
+func sectionPlot(data SectionDiagramData) (*plot.Plot, error) {
+	return outlinedSection(data, stressBlock)
+}
+
+// outlinedSection draws the outline of a section, its compression zone and
+// neutral axis with zone, and the stirrups and bars over them
+func outlinedSection(data SectionDiagramData, zone func(p *plot.Plot, data SectionDiagramData, outline []Point) error) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = i18n.T("Beam Section Analysis")
+	u := data.system()
+	p.X.Label.Text = axisLabel("Width", u.Length.Label)
+	p.Y.Label.Text = axisLabel("Height", u.Length.Label)
+	setLengthTicks(u.Length, &p.X, &p.Y)
+
+	// Custom vertices of a non-rectangular section, else the rectangle
+	outline := data.Vertices
+	if len(outline) < 3 {
+		outline = []Point{{X: 0, Y: 0}, {X: data.Width, Y: 0}, {X: data.Width, Y: data.Height}, {X: 0, Y: data.Height}}
+	}
+	beamOutline := make(plotter.XYs, len(outline)+1)
+	maxX := outline[0].X
+	for i, v := range outline {
+		beamOutline[i] = plotter.XY{X: v.X, Y: v.Y}
+		maxX = math.Max(maxX, v.X)
+	}
+	beamOutline[len(outline)] = beamOutline[0]
+
+	beamLine, err := plotter.NewLine(beamOutline)
+	if err != nil {
+		return nil, err
+	}
+	beamLine.LineStyle.Width = vg.Points(2)
+	beamLine.LineStyle.Color = theme.Ink
+	p.Add(beamLine)
+
+	if err := zone(p, data, outline); err != nil {
+		return nil, err
+	}
+
+	// Stirrup outline of a rectangular section, from the clear cover to
+	// the inner face of the stirrups
+	if len(data.Vertices) < 3 && data.ClearCover > 0 && data.StirrupDiameter > 0 {
+		rect := func(inset float64) plotter.XYs {
+			return plotter.XYs{
+				{X: inset, Y: inset},
+				{X: data.Width - inset, Y: inset},
+				{X: data.Width - inset, Y: data.Height - inset},
+				{X: inset, Y: data.Height - inset},
 			}
 		}
-	} else {
-		// Draw rectangular beam outline
-		minX, maxX = 0, data.Width
-		beamOutline := plotter.XYs{
-			{X: 0, Y: 0},
-			{X: data.Width, Y: 0},
-			{X: data.Width, Y: data.Height},
-			{X: 0, Y: data.Height},
-			{X: 0, Y: 0},
+		// The inner face, wound the other way, is a hole in the band
+		inner := rect(data.ClearCover + data.StirrupDiameter)
+		slices.Reverse(inner)
+		stirrup, err := plotter.NewPolygon(rect(data.ClearCover), inner)
+		if err != nil {
+			return nil, err
 		}
-		beamLine, err := plotter.NewLine(beamOutline)
+		stirrup.Color = theme.Stirrup
+		stirrup.LineStyle.Width = vg.Points(0.5)
+		p.Add(stirrup)
+
+		cover, err := plotter.NewLine(append(rect(data.ClearCover), rect(data.ClearCover)[0]))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		beamLine.LineStyle.Width = vg.Points(2)
-		beamLine.LineStyle.Color = color.Black
-		p.Add(beamLine)
-
-		// Draw rectangular stress block
-		stressBlockPts := plotter.XYs{
-			{X: 0, Y: data.Height},
-			{X: data.Width, Y: data.Height},
-			{X: data.Width, Y: data.Height - data.StressBlockDepth},
-			{X: 0, Y: data.Height - data.StressBlockDepth},
+		cover.LineStyle.Width = vg.Points(0.75)
+		cover.LineStyle.Color = theme.Pass
+		cover.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(2)}
+		p.Add(cover)
+	}
+
+	// Bars to scale at their positions, over the gaps too wide for crack
+	// control, with a callout of each row
+	bars := sectionBars(data)
+	crack, err := crackOverlay(p, data, bars)
+	if err != nil {
+		return nil, err
+	}
+	for _, bar := range bars {
+		c, err := plotter.NewPolygon(circle(bar.X, bar.Y, bar.Diameter))
+		if err != nil {
+			return nil, err
 		}
+		c.Color = theme.Steel
+		c.LineStyle.Width = vg.Points(0.5)
+		c.LineStyle.Color = theme.Ink
+		p.Add(c)
+	}
+	calloutSection(p, data, bars, crack, maxX)
+
+	equalScale(p, stamp(draw.New(vgimg.New(Figure.size(8*vg.Inch, 6*vg.Inch))), p.X.Tick.Label))
+	return p, nil
+}
+
+// stressBlock draws the stress block of a section down from its top and
+// its neutral axis across it, with their depths
+func stressBlock(p *plot.Plot, data SectionDiagramData, outline []Point) error {
+	stressBlockPts := clipSectionAtDepth(outline, data.Height, data.StressBlockDepth)
+	if len(stressBlockPts) >= 3 {
 		stressBlock, err := plotter.NewPolygon(stressBlockPts)
 		if err != nil {
 			return err
 		}
-		stressBlock.Color = color.RGBA{R: 100, G: 149, B: 237, A: 150}
-		stressBlock.LineStyle.Color = color.RGBA{R: 0, G: 0, B: 139, A: 255}
+		stressBlock.Color = theme.Compression
+		stressBlock.LineStyle.Color = theme.Primary
 		p.Add(stressBlock)
 	}
 
-	sectionWidth := maxX - minX
-
-	// Draw neutral axis line
+	minX, maxX := outline[0].X, outline[0].X
+	for _, v := range outline {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+	}
 	naY := data.Height - data.NeutralAxisDepth
 	naLine, err := plotter.NewLine(plotter.XYs{
 		{X: minX - 20, Y: naY},
@@ -102,62 +202,19 @@ func ExportSectionDiagram(data SectionDiagramData, filename string) error {
 		return err
 	}
 	naLine.LineStyle.Width = vg.Points(1.5)
-	naLine.LineStyle.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	naLine.LineStyle.Color = theme.Alert
 	naLine.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
 	p.Add(naLine)
 
-	// Find web width at tension steel level for positioning
-	webMinX, webMaxX := findWidthAtY(data.Vertices, data.TensionSteelY, minX, maxX)
-	webCenter := (webMinX + webMaxX) / 2
-	webWidth := webMaxX - webMinX
-	if webWidth == 0 {
-		webWidth = sectionWidth
-		webCenter = (minX + maxX) / 2
-	}
-
-	// Draw tension steel
-	tensionY := data.TensionSteelY
-	tensionSteel, err := plotter.NewScatter(plotter.XYs{
-		{X: webCenter - webWidth*0.2, Y: tensionY},
-		{X: webCenter, Y: tensionY},
-		{X: webCenter + webWidth*0.2, Y: tensionY},
-	})
-	if err != nil {
-		return err
-	}
-	tensionSteel.GlyphStyle.Color = color.RGBA{R: 139, G: 69, B: 19, A: 255}
-	tensionSteel.GlyphStyle.Radius = vg.Points(6)
-	tensionSteel.GlyphStyle.Shape = draw.CircleGlyph{}
-	p.Add(tensionSteel)
-
-	// Draw compression steel if present
-	if data.IsDoubly && data.CompSteelArea > 0 {
-		compY := data.Height - data.CompSteelY
-		compSteel, err := plotter.NewScatter(plotter.XYs{
-			{X: webCenter - webWidth*0.15, Y: compY},
-			{X: webCenter + webWidth*0.15, Y: compY},
-		})
-		if err != nil {
-			return err
-		}
-		compSteel.GlyphStyle.Color = color.RGBA{R: 139, G: 69, B: 19, A: 255}
-		compSteel.GlyphStyle.Radius = vg.Points(5)
-		compSteel.GlyphStyle.Shape = draw.CircleGlyph{}
-		p.Add(compSteel)
-	}
-
-	// Add annotations
 	labels := []struct {
 		x, y float64
 		text string
 	}{
 		{maxX + 30, naY, "N.A."},
-		{maxX + 30, data.Height - data.StressBlockDepth/2, fmt.Sprintf("a=%.1fmm", data.StressBlockDepth)},
-		{webCenter, tensionY - 25, fmt.Sprintf("As=%.0fmm²", data.TensionSteelArea)},
+		{maxX + 30, data.Height - data.StressBlockDepth/2, "a=" + data.system().Length.Format(data.StressBlockDepth, 1)},
 	}
-
 	for _, lbl := range labels {
-		l, err := plotter.NewLabels(plotter.XYLabels{
+		l, err := newLabels(plotter.XYLabels{
 			XYs:    []plotter.XY{{X: lbl.x, Y: lbl.y}},
 			Labels: []string{lbl.text},
 		})
@@ -166,27 +223,43 @@ func ExportSectionDiagram(data SectionDiagramData, filename string) error {
 		}
 		p.Add(l)
 	}
+	return nil
+}
 
-	// Determine file format from extension
-	ext := filepath.Ext(filename)
-	width := 8 * vg.Inch
-	height := 6 * vg.Inch
+// sketchPlot draws the section diagram with the dimensions and callouts of
+// an engineering sketch
+func sketchPlot(data SectionDiagramData) (*plot.Plot, error) {
+	p, err := sectionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	dimensionSection(p, data)
+	equalScale(p, stamp(draw.New(vgimg.New(Figure.size(8*vg.Inch, 6*vg.Inch))), p.X.Tick.Label))
+	return p, nil
+}
 
-	// Create directory if needed
-	dir := filepath.Dir(filename)
-	if dir != "" && dir != "." {
-		os.MkdirAll(dir, 0755)
+// lengthTicks places axis ticks at round values of a length unit while the
+// plot itself stays in mm
+type lengthTicks struct {
+	unit units.Unit
+}
+
+// Ticks returns the ticks between min and max (mm) labeled in the unit
+func (t lengthTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(t.unit.FromSI(min), t.unit.FromSI(max))
+	for i := range ticks {
+		ticks[i].Value = t.unit.ToSI(ticks[i].Value)
 	}
+	return ticks
+}
 
-	switch ext {
-	case ".png":
-		return p.Save(width, height, filename)
-	case ".svg":
-		return p.Save(width, height, filename)
-	case ".pdf":
-		return p.Save(width, height, filename)
-	default:
-		return p.Save(width, height, filename+".png")
+// setLengthTicks labels length axes of a plot in a unit other than mm
+func setLengthTicks(unit units.Unit, axes ...*plot.Axis) {
+	if unit.Factor == 1 {
+		return
+	}
+	for _, axis := range axes {
+		axis.Tick.Marker = lengthTicks{unit}
 	}
 }
 
@@ -264,10 +337,52 @@ func findWidthAtY(vertices []Point, y, defaultMin, defaultMax float64) (float64,
 
 // ExportStrainDiagram exports a strain distribution diagram
 func ExportStrainDiagram(data SectionDiagramData, filename string) error {
-	p := plot.New()
-	p.Title.Text = "Strain Distribution"
-	p.X.Label.Text = "Strain"
-	p.Y.Label.Text = "Depth from top (mm)"
+	p, err := strainPlot(data)
+	if err != nil {
+		return err
+	}
+
+	width := 6 * vg.Inch
+	height := 8 * vg.Inch
+
+	if err := savePlot(p, width, height, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return sectionSeries(data) })
+}
+
+// StrainDiagramSVG returns a strain distribution diagram as an SVG document
+func StrainDiagramSVG(data SectionDiagramData) ([]byte, error) {
+	p, err := strainPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 6*vg.Inch, 8*vg.Inch, "svg")
+}
+
+// StrainDiagramPNG returns a strain distribution diagram as a PNG image
+func StrainDiagramPNG(data SectionDiagramData) ([]byte, error) {
+	p, err := strainPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 6*vg.Inch, 8*vg.Inch, "png")
+}
+
+// StrainPlot returns a strain distribution diagram as a plot, for drawing
+// with the plots of another document
+func StrainPlot(data SectionDiagramData) (*plot.Plot, error) {
+	return strainPlot(data)
+}
+
+// strainPlot draws the strain distribution over the depth of a section
+func strainPlot(data SectionDiagramData) (*plot.Plot, error) {
+	p := newPlot()
+	p.Title.Text = i18n.T("Strain Distribution")
+	p.X.Label.Text = i18n.T("Strain")
+	u := data.system()
+	p.Y.Label.Text = axisLabel("Depth from top", u.Length.Label)
+	setLengthTicks(u.Length, &p.Y)
 
 	// Invert Y axis (depth increases downward)
 	p.Y.Min = data.Height
@@ -281,10 +396,10 @@ func ExportStrainDiagram(data SectionDiagramData, filename string) error {
 	}
 	strainLine, err := plotter.NewLine(strainPts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	strainLine.LineStyle.Width = vg.Points(2)
-	strainLine.LineStyle.Color = color.RGBA{R: 0, G: 100, B: 0, A: 255}
+	strainLine.LineStyle.Color = theme.Strain
 	p.Add(strainLine)
 
 	// Zero strain reference line
@@ -293,10 +408,10 @@ func ExportStrainDiagram(data SectionDiagramData, filename string) error {
 		{X: 0, Y: data.Height},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	zeroLine.LineStyle.Width = vg.Points(1)
-	zeroLine.LineStyle.Color = color.Gray{Y: 128}
+	zeroLine.LineStyle.Color = theme.Muted
 	zeroLine.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(3)}
 	p.Add(zeroLine)
 
@@ -305,7 +420,7 @@ func ExportStrainDiagram(data SectionDiagramData, filename string) error {
 		{X: data.EpsilonY, Y: 0},
 		{X: data.EpsilonY, Y: data.Height},
 	})
-	yieldLinePos.LineStyle.Color = color.RGBA{R: 255, G: 165, B: 0, A: 255}
+	yieldLinePos.LineStyle.Color = theme.Yield
 	yieldLinePos.LineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
 	p.Add(yieldLinePos)
 
@@ -313,7 +428,7 @@ func ExportStrainDiagram(data SectionDiagramData, filename string) error {
 		{X: -data.EpsilonY, Y: 0},
 		{X: -data.EpsilonY, Y: data.Height},
 	})
-	yieldLineNeg.LineStyle.Color = color.RGBA{R: 255, G: 165, B: 0, A: 255}
+	yieldLineNeg.LineStyle.Color = theme.Yield
 	yieldLineNeg.LineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
 	p.Add(yieldLineNeg)
 
@@ -324,28 +439,11 @@ func ExportStrainDiagram(data SectionDiagramData, filename string) error {
 		{X: -data.EpsilonT, Y: data.Height - data.TensionSteelY},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	keyPoints.GlyphStyle.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	keyPoints.GlyphStyle.Color = theme.Alert
 	keyPoints.GlyphStyle.Radius = vg.Points(4)
 	p.Add(keyPoints)
 
-	width := 6 * vg.Inch
-	height := 8 * vg.Inch
-
-	// Create directory if needed
-	dir := filepath.Dir(filename)
-	if dir != "" && dir != "." {
-		os.MkdirAll(dir, 0755)
-	}
-
-	return p.Save(width, height, filename)
+	return p, nil
 }
-
-// ExportCombinedDiagram creates a combined section and strain diagram
-func ExportCombinedDiagram(data SectionDiagramData, filename string) error {
-	// For now, just export the section diagram
-	// A more sophisticated version could use subplots
-	return ExportSectionDiagram(data, filename)
-}
-
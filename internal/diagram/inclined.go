@@ -0,0 +1,260 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// InclinedAxisData holds a section bent about both axes at a point of its
+// biaxial interaction surface, with its neutral axis inclined to the axes of
+// the section
+type InclinedAxisData struct {
+	Name string // Section name for the title
+
+	// Outline, bars and units of the section; its neutral axis depth and
+	// stress block depth are not drawn
+	Section SectionDiagramData
+
+	Angle float64 // Direction of the compression face, counterclockwise from +X (degrees)
+	C     float64 // Neutral axis depth from the extreme compression fiber (mm)
+	A     float64 // Stress block depth from the extreme compression fiber (mm)
+
+	PhiPn          float64 // kN, compression positive
+	PhiMnx, PhiMny float64 // kN-m, signed as the top and the right face in compression
+}
+
+// Inclination returns the angle of the neutral axis from the X axis of the
+// section, counterclockwise between -90 and 90 degrees
+func (data InclinedAxisData) Inclination() float64 {
+	theta := math.Remainder(data.Angle-90, 180)
+	if theta <= -90 {
+		theta += 180
+	}
+	return theta
+}
+
+// geometry returns the outline of the section, the unit vector toward its
+// compression face and the level along it of the extreme compression fiber
+func (data InclinedAxisData) geometry() (outline []Point, nx, ny, top float64) {
+	outline = data.Section.Vertices
+	if len(outline) < 3 {
+		w, h := data.Section.Width, data.Section.Height
+		outline = []Point{{X: 0, Y: 0}, {X: w, Y: 0}, {X: w, Y: h}, {X: 0, Y: h}}
+	}
+	nx, ny = math.Cos(data.Angle*math.Pi/180), math.Sin(data.Angle*math.Pi/180)
+	top = math.Inf(-1)
+	for _, v := range outline {
+		top = math.Max(top, nx*v.X+ny*v.Y)
+	}
+	return outline, nx, ny, top
+}
+
+// compressionZone returns the part of the section within the stress block
+// depth of the extreme compression fiber
+func (data InclinedAxisData) compressionZone() plotter.XYs {
+	outline, nx, ny, top := data.geometry()
+	level := top - data.A
+	var zone plotter.XYs
+	for i, curr := range outline {
+		next := outline[(i+1)%len(outline)]
+		dc, dn := nx*curr.X+ny*curr.Y-level, nx*next.X+ny*next.Y-level
+		if dc >= 0 {
+			zone = append(zone, plotter.XY{X: curr.X, Y: curr.Y})
+		}
+		if (dc >= 0) != (dn >= 0) {
+			t := dc / (dc - dn)
+			zone = append(zone, plotter.XY{X: curr.X + t*(next.X-curr.X), Y: curr.Y + t*(next.Y-curr.Y)})
+		}
+	}
+	return zone
+}
+
+// neutralAxis returns the ends of the neutral axis across the section,
+// extended 20 mm past its outline, the second end to the right, or none
+// when the whole section is in compression
+func (data InclinedAxisData) neutralAxis() plotter.XYs {
+	if data.C <= 0 {
+		return nil
+	}
+	outline, nx, ny, top := data.geometry()
+	level := top - data.C
+	dx, dy := -ny, nx // Along the axis
+	if dx < 0 || dx == 0 && dy < 0 {
+		dx, dy = -dx, -dy
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range outline {
+		t := dx*v.X + dy*v.Y
+		lo, hi = math.Min(lo, t), math.Max(hi, t)
+	}
+	at := func(t float64) plotter.XY {
+		return plotter.XY{X: level*nx + t*dx, Y: level*ny + t*dy}
+	}
+	return plotter.XYs{at(lo - 20), at(hi + 20)}
+}
+
+// ExportInclinedAxisDiagram exports a section bent about both axes with
+// its inclined neutral axis and compression zone to a png, svg or pdf file
+func ExportInclinedAxisDiagram(data InclinedAxisData, filename string) error {
+	p, err := inclinedAxisPlot(data)
+	if err != nil {
+		return err
+	}
+	if !supportedFormat(filename) {
+		filename += ".png"
+	}
+	if err := savePlot(p, 8*vg.Inch, 6*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return inclinedAxisSeries(data) })
+}
+
+// InclinedAxisDiagramSVG returns a section with its inclined neutral axis
+// as an SVG document
+func InclinedAxisDiagramSVG(data InclinedAxisData) ([]byte, error) {
+	p, err := inclinedAxisPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 8*vg.Inch, 6*vg.Inch, "svg")
+}
+
+// InclinedAxisDiagramPNG returns a section with its inclined neutral axis
+// as a PNG image
+func InclinedAxisDiagramPNG(data InclinedAxisData) ([]byte, error) {
+	p, err := inclinedAxisPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 8*vg.Inch, 6*vg.Inch, "png")
+}
+
+// InclinedAxisPlot returns a section with its inclined neutral axis as a
+// plot, for drawing with the plots of another document
+func InclinedAxisPlot(data InclinedAxisData) (*plot.Plot, error) {
+	return inclinedAxisPlot(data)
+}
+
+// inclinedAxisPlot draws the section with the compression zone turned to
+// the direction of the compression face, the neutral axis across it and
+// the angle of the axis from the horizontal, titled with the strengths
+func inclinedAxisPlot(data InclinedAxisData) (*plot.Plot, error) {
+	p, err := outlinedSection(data.Section, func(p *plot.Plot, _ SectionDiagramData, _ []Point) error {
+		return inclinedZone(p, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u := data.Section.system()
+	p.Title.Text = i18n.T("Biaxial Bending")
+	if data.Name != "" {
+		p.Title.Text += " - " + data.Name
+	}
+	p.Title.Text += fmt.Sprintf("\nφPn = %s, φMnx = %s, φMny = %s",
+		u.Force.Format(data.PhiPn, 1), u.Moment.Format(data.PhiMnx, 1), u.Moment.Format(data.PhiMny, 1))
+	return p, nil
+}
+
+// inclinedZone draws the compression zone of a section bent about both
+// axes, its neutral axis, and the angle of the axis in an arc from a
+// horizontal line through it
+func inclinedZone(p *plot.Plot, data InclinedAxisData) error {
+	if zone := data.compressionZone(); len(zone) >= 3 {
+		block, err := plotter.NewPolygon(zone)
+		if err != nil {
+			return err
+		}
+		block.Color = theme.Compression
+		block.LineStyle.Color = theme.Primary
+		p.Add(block)
+	}
+
+	axis := data.neutralAxis()
+	if axis == nil {
+		return nil
+	}
+	na, err := plotter.NewLine(axis)
+	if err != nil {
+		return err
+	}
+	na.LineStyle.Width = vg.Points(1.5)
+	na.LineStyle.Color = theme.Alert
+	na.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+	p.Add(na)
+
+	// Angle from a horizontal line through the middle of the axis, with
+	// its arc at a fraction of the size of the section
+	outline, _, _, _ := data.geometry()
+	minX, maxX, minY, maxY := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, v := range outline {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		minY, maxY = math.Min(minY, v.Y), math.Max(maxY, v.Y)
+	}
+	pivot := plotter.XY{X: (axis[0].X + axis[1].X) / 2, Y: (axis[0].Y + axis[1].Y) / 2}
+	radius := 0.3 * math.Min(maxX-minX, maxY-minY)
+	theta := data.Inclination()
+
+	level, err := plotter.NewLine(plotter.XYs{pivot, {X: pivot.X + 1.25*radius, Y: pivot.Y}})
+	if err != nil {
+		return err
+	}
+	level.LineStyle.Width = vg.Points(0.75)
+	level.LineStyle.Color = theme.Muted
+	level.LineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	p.Add(level)
+
+	var arc plotter.XYs
+	for i := 0; i <= 24; i++ {
+		t := theta * float64(i) / 24 * math.Pi / 180
+		arc = append(arc, plotter.XY{X: pivot.X + radius*math.Cos(t), Y: pivot.Y + radius*math.Sin(t)})
+	}
+	angle, err := plotter.NewLine(arc)
+	if err != nil {
+		return err
+	}
+	angle.LineStyle.Width = vg.Points(1)
+	angle.LineStyle.Color = theme.Ink
+	p.Add(angle)
+
+	mid := theta / 2 * math.Pi / 180
+	angleLabel, err := newLabels(plotter.XYLabels{
+		XYs:    []plotter.XY{{X: pivot.X + 1.1*radius*math.Cos(mid), Y: pivot.Y + 1.1*radius*math.Sin(mid)}},
+		Labels: []string{fmt.Sprintf("θ = %.1f°", theta)},
+	})
+	if err != nil {
+		return err
+	}
+	angleLabel.TextStyle[0].YAlign = draw.YBottom
+	if theta < 0 {
+		angleLabel.TextStyle[0].YAlign = draw.YTop
+	}
+	angleLabel.Offset = vg.Point{X: vg.Points(4)}
+
+	// Depth of the axis written along it on the tension side, clear of the
+	// angle in its middle and of the bar callouts at its ends
+	u := data.Section.system()
+	depthLabel, err := newLabels(plotter.XYLabels{
+		XYs:    []plotter.XY{{X: axis[0].X + 0.2*(axis[1].X-axis[0].X), Y: axis[0].Y + 0.2*(axis[1].Y-axis[0].Y)}},
+		Labels: []string{fmt.Sprintf("N.A., c = %s", u.Length.Format(data.C, 1))},
+	})
+	if err != nil {
+		return err
+	}
+	sty := &depthLabel.TextStyle[0]
+	sty.Rotation = theta * math.Pi / 180
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YTop
+	upX, upY := -math.Sin(sty.Rotation), math.Cos(sty.Rotation) // Up the text
+	gap := -vg.Points(6)
+	if _, nx, ny, _ := data.geometry(); upX*nx+upY*ny < 0 {
+		sty.YAlign, gap = draw.YBottom, vg.Points(6) // The compression face is below the text
+	}
+	depthLabel.Offset = vg.Point{X: gap * vg.Length(upX), Y: gap * vg.Length(upY)}
+	p.Add(angleLabel, depthLabel)
+	return nil
+}
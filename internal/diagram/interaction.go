@@ -0,0 +1,127 @@
+package diagram
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// InteractionDiagramData holds the curves of an axial force-moment
+// interaction diagram, as points with X the moment (kN-m) and Y the axial
+// force (kN, compression positive)
+type InteractionDiagramData struct {
+	Name    string  // Section name for the title
+	Nominal []Point // Pn-Mn curve
+	Design  []Point // φPn-φMn curve
+	Load    *Point  // Applied Mu and Pu, nil for none
+	Inside  bool    // The applied load is inside the design curve
+
+	// Units of the printed values, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data InteractionDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// ExportInteractionDiagram exports an interaction diagram to a png, svg or
+// pdf file
+func ExportInteractionDiagram(data InteractionDiagramData, filename string) error {
+	p, err := interactionPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 7*vg.Inch, 7*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return interactionSeries(data) })
+}
+
+// InteractionDiagramSVG returns an interaction diagram as an SVG document
+func InteractionDiagramSVG(data InteractionDiagramData) ([]byte, error) {
+	p, err := interactionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 7*vg.Inch, 7*vg.Inch, "svg")
+}
+
+// InteractionDiagramPNG returns an interaction diagram as a PNG image
+func InteractionDiagramPNG(data InteractionDiagramData) ([]byte, error) {
+	p, err := interactionPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 7*vg.Inch, 7*vg.Inch, "png")
+}
+
+// InteractionPlot returns a P-M interaction diagram as a plot, for drawing
+// with the plots of another document
+func InteractionPlot(data InteractionDiagramData) (*plot.Plot, error) {
+	return interactionPlot(data)
+}
+
+// interactionPlot draws the nominal and design interaction curves and the
+// applied load point
+func interactionPlot(data InteractionDiagramData) (*plot.Plot, error) {
+	u := data.system()
+	p := newPlot()
+	p.Title.Text = i18n.T("P-M Interaction Diagram")
+	if data.Name != "" {
+		p.Title.Text += " - " + data.Name
+	}
+	p.X.Label.Text = axisLabel("Moment", u.Moment.Label)
+	p.Y.Label.Text = axisLabel("Axial force, compression positive", u.Force.Label)
+	p.Add(newGrid())
+
+	xys := func(points []Point) plotter.XYs {
+		out := make(plotter.XYs, len(points))
+		for i, pt := range points {
+			out[i] = plotter.XY{X: u.Moment.FromSI(pt.X), Y: u.Force.FromSI(pt.Y)}
+		}
+		return out
+	}
+
+	nominal, err := plotter.NewLine(xys(data.Nominal))
+	if err != nil {
+		return nil, err
+	}
+	nominal.LineStyle.Width = vg.Points(1)
+	nominal.LineStyle.Color = theme.Muted
+	nominal.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+	p.Add(nominal)
+	addLegend(p, "Pn-Mn", nominal)
+
+	design, err := plotter.NewLine(xys(data.Design))
+	if err != nil {
+		return nil, err
+	}
+	design.LineStyle.Width = vg.Points(2)
+	design.LineStyle.Color = theme.Primary
+	p.Add(design)
+	addLegend(p, "φPn-φMn", design)
+
+	if data.Load != nil {
+		load, err := plotter.NewScatter(xys([]Point{*data.Load}))
+		if err != nil {
+			return nil, err
+		}
+		load.GlyphStyle.Radius = vg.Points(5)
+		load.GlyphStyle.Shape = draw.CrossGlyph{}
+		load.GlyphStyle.Color = theme.Pass
+		if !data.Inside {
+			load.GlyphStyle.Color = theme.Alert
+		}
+		p.Add(load)
+		addLegend(p, "Pu, Mu", load)
+	}
+	p.Legend.Top = true
+	return p, nil
+}
@@ -0,0 +1,34 @@
+package diagram
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+)
+
+// axisLabel returns the label of an axis in the selected language with its
+// unit, e.g. "Position along the beam (m)"
+func axisLabel(name, unit string) string {
+	return i18n.T(name) + " (" + unit + ")"
+}
+
+// plotTitle returns the title of a diagram in the selected language,
+// prefixed with the name of the member when it has one
+func plotTitle(name, title string) string {
+	if name == "" {
+		return i18n.T(title)
+	}
+	return name + ": " + i18n.T(title)
+}
+
+// addLegend adds an entry to the legend of a plot in the selected language
+func addLegend(p *plot.Plot, name string, thumbs ...plot.Thumbnailer) {
+	p.Legend.Add(i18n.T(name), thumbs...)
+}
+
+// setSpanAxis labels the position axis of a member diagram drawn in m in
+// the span unit of the system
+func setSpanAxis(p *plot.Plot, name string, u units.System) {
+	p.X.Label.Text = axisLabel(name, u.Span.Label)
+	setLengthTicks(u.Span, &p.X)
+}
@@ -0,0 +1,278 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// StirrupZone is a length of a beam with stirrups at one spacing, or
+// without stirrups
+type StirrupZone struct {
+	Start, End float64 // From the left end of the beam (m)
+	Spacing    float64 // mm, zero without stirrups
+	Count      int     // Stirrups, the first half a spacing from Start
+}
+
+// StirrupDiagramData holds the stirrup zones of a beam for its elevation
+type StirrupDiagramData struct {
+	Name       string    // Beam name for the title
+	Stirrup    string    // Stirrup description for the title, e.g. "2-leg Ø10"
+	Spans      []float64 // m
+	Supports   []float64 // Positions of the supports (m)
+	Height     float64   // Beam depth (mm)
+	ClearCover float64   // Clear cover to the stirrups (mm)
+	Zones      []StirrupZone
+
+	// Units of the printed spacings, SI when unset
+	Units units.System
+}
+
+// system returns the units of the printed values of the diagram
+func (data StirrupDiagramData) system() units.System {
+	if data.Units.IsSI() {
+		return units.SI()
+	}
+	return data.Units
+}
+
+// length returns the length of the beam (m)
+func (data StirrupDiagramData) length() float64 {
+	var length float64
+	for _, l := range data.Spans {
+		length += l
+	}
+	return length
+}
+
+// label returns the callout of a zone, e.g. "8 @ 150", or "none"
+func (data StirrupDiagramData) label(z StirrupZone) string {
+	if z.Spacing <= 0 {
+		return i18n.T("none")
+	}
+	u := data.system().Length
+	return fmt.Sprintf("%d @ %.*f", z.Count, u.Digits, u.FromSI(z.Spacing))
+}
+
+// positions returns the positions of the stirrups of a zone (m)
+func (z StirrupZone) positions() []float64 {
+	var xs []float64
+	for i := 0; i < z.Count && z.Spacing > 0; i++ {
+		x := z.Start + (float64(i)+0.5)*z.Spacing/1000
+		if x > z.End+1e-9 {
+			break
+		}
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+// DrawASCIIStirrupDiagram draws the elevation of a beam in text with its
+// stirrups, supports and the extents and spacings of the stirrup zones
+func DrawASCIIStirrupDiagram(data StirrupDiagramData) string {
+	width := textColumns(60)
+	length := data.length()
+	column := func(x float64) int {
+		return min(int(x/length*float64(width)), width-1)
+	}
+	edge := func(x float64) int {
+		return int(math.Round(x / length * float64(width+1)))
+	}
+	title := "STIRRUP ZONES"
+	if data.Stirrup != "" {
+		title += " - " + data.Stirrup
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString("  " + title + "\n")
+	sb.WriteString("  " + strings.Repeat("─", len([]rune(title))) + "\n\n")
+	if length <= 0 {
+		sb.WriteString("  (no spans)\n")
+		return textOut(sb.String())
+	}
+
+	web := []rune(strings.Repeat(" ", width))
+	for _, z := range data.Zones {
+		for _, x := range z.positions() {
+			web[column(x)] = '|'
+		}
+	}
+	sb.WriteString("  ┌" + strings.Repeat("─", width) + "┐\n")
+	for i := 0; i < 2; i++ {
+		sb.WriteString("  │" + string(web) + "│\n")
+	}
+	sb.WriteString("  └" + strings.Repeat("─", width) + "┘\n")
+
+	supports := []rune(strings.Repeat(" ", width+2))
+	for _, x := range data.Supports {
+		supports[edge(x)] = '▲'
+	}
+	sb.WriteString("  " + string(supports) + "\n")
+
+	// Extent of each zone, with its callout when it fits
+	zones := []rune(strings.Repeat(" ", width+2))
+	for _, z := range data.Zones {
+		a, b := edge(z.Start), edge(z.End)
+		for c := a + 1; c < b; c++ {
+			zones[c] = '─'
+		}
+		if zones[a] == '┤' {
+			zones[a] = '┼'
+		} else {
+			zones[a] = '├'
+		}
+		zones[b] = '┤'
+		if text := []rune(data.label(z)); len(text) <= b-a-1 {
+			copy(zones[a+1+(b-a-1-len(text))/2:], text)
+		}
+	}
+	sb.WriteString("  " + string(zones) + "\n")
+	sb.WriteString(fmt.Sprintf("  0%s%.2f m\n", strings.Repeat(" ", width+1-len(fmt.Sprintf("%.2f m", length))), length))
+	sb.WriteString(fmt.Sprintf("  | = Stirrup, ▲ = Support, n @ s = stirrups @ spacing (%s)\n", data.system().Length.Label))
+	return textOut(sb.String())
+}
+
+// ExportStirrupDiagram exports the elevation of the stirrup zones of a
+// beam to a png, svg or pdf file
+func ExportStirrupDiagram(data StirrupDiagramData, filename string) error {
+	p, err := stirrupPlot(data)
+	if err != nil {
+		return err
+	}
+	if err := savePlot(p, 10*vg.Inch, 4*vg.Inch, filename); err != nil {
+		return err
+	}
+	return writeData(filename, func() dataTable { return stirrupSeries(data) })
+}
+
+// StirrupDiagramSVG returns the elevation of the stirrup zones of a beam as
+// an SVG document
+func StirrupDiagramSVG(data StirrupDiagramData) ([]byte, error) {
+	p, err := stirrupPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 4*vg.Inch, "svg")
+}
+
+// StirrupDiagramPNG returns the elevation of the stirrup zones of a beam as
+// a PNG image
+func StirrupDiagramPNG(data StirrupDiagramData) ([]byte, error) {
+	p, err := stirrupPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return plotImage(p, 10*vg.Inch, 4*vg.Inch, "png")
+}
+
+// StirrupPlot returns the stirrup zones of a beam as a plot, for drawing
+// with the plots of another document
+func StirrupPlot(data StirrupDiagramData) (*plot.Plot, error) {
+	return stirrupPlot(data)
+}
+
+// stirrupPlot draws the elevation of the beam, its depth exaggerated, with
+// the stirrups, the supports, the zones dimensioned above and the spans
+// below
+func stirrupPlot(data StirrupDiagramData) (*plot.Plot, error) {
+	length := data.length()
+	if length <= 0 || data.Height <= 0 {
+		return nil, fmt.Errorf("invalid beam: length=%.2f m, height=%.0f mm", length, data.Height)
+	}
+	h := data.Height / 1000
+	cover := math.Min(data.ClearCover, data.Height/4) / 1000
+
+	p := newPlot()
+	u := data.system()
+	p.Title.Text = plotTitle(data.Name, "Stirrup Zones")
+	if data.Stirrup != "" {
+		p.Title.Text += " - " + data.Stirrup
+	}
+	setSpanAxis(p, "Position along the beam", u)
+	p.HideY()
+	p.X.Min, p.X.Max = 0, length
+	p.Y.Min, p.Y.Max = 0, h
+
+	outline, err := plotter.NewPolygon(plotter.XYs{{X: 0, Y: 0}, {X: length, Y: 0}, {X: length, Y: h}, {X: 0, Y: h}})
+	if err != nil {
+		return nil, err
+	}
+	outline.Color = theme.Concrete
+	outline.LineStyle.Width = vg.Points(1.5)
+	p.Add(outline)
+
+	p.Add(supportMarks(data.Supports))
+
+	stirrups := verticals{Y0: cover, Y1: h - cover, LineStyle: draw.LineStyle{Color: theme.Stirrup, Width: vg.Points(0.75)}}
+	for _, z := range data.Zones {
+		stirrups.X = append(stirrups.X, z.positions()...)
+	}
+	p.Add(stirrups)
+
+	// Callouts wider than their zone, estimated on the width of the page,
+	// are raised clear of those of the zones beside them
+	sty := sketchText(p)
+	raised := false
+	for _, z := range data.Zones {
+		offset := dimensionStep
+		wide := vg.Length((z.End-z.Start)/length)*9*vg.Inch < sty.Width(data.label(z))+4
+		if raised = wide && !raised; raised {
+			offset *= 2
+		}
+		p.Add(dimension{A: plotter.XY{X: z.Start, Y: h}, B: plotter.XY{X: z.End, Y: h}, Offset: offset, Text: data.label(z)})
+	}
+	for i, l := range data.Spans {
+		start := 0.0
+		for _, prev := range data.Spans[:i] {
+			start += prev
+		}
+		p.Add(dimension{A: plotter.XY{X: start, Y: 0}, B: plotter.XY{X: start + l, Y: 0}, Offset: -dimensionStep, Text: fmt.Sprintf("L%d = %s", i+1, u.Span.Format(l, 2))})
+	}
+	return p, nil
+}
+
+// verticals is a plotter drawing vertical lines at X between Y0 and Y1
+type verticals struct {
+	X      []float64
+	Y0, Y1 float64
+	draw.LineStyle
+}
+
+// Plot draws the lines
+func (v verticals) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, x := range v.X {
+		c.StrokeLine2(v.LineStyle, trX(x), trY(v.Y0), trX(x), trY(v.Y1))
+	}
+}
+
+// supportMarks is a plotter drawing a triangle under the beam at each
+// support
+type supportMarks []float64
+
+// Plot draws the triangles with their apex on the soffit
+func (s supportMarks) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, x := range s {
+		apex := vg.Point{X: trX(x), Y: trY(0)}
+		triangle := []vg.Point{apex, {X: apex.X - 6, Y: apex.Y - 10}, {X: apex.X + 6, Y: apex.Y - 10}}
+		c.FillPolygon(theme.Ink, triangle)
+	}
+}
+
+// GlyphBoxes leaves room for the triangles
+func (s supportMarks) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, len(s))
+	for i, x := range s {
+		boxes[i] = plot.GlyphBox{X: plt.X.Norm(x), Y: plt.Y.Norm(0), Rectangle: vg.Rectangle{Min: vg.Point{X: -6, Y: -10}, Max: vg.Point{X: 6}}}
+	}
+	return boxes
+}
@@ -0,0 +1,55 @@
+package diagram
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/ascii"
+)
+
+// TextOptions size the text diagrams to the terminal and select their
+// characters
+type TextOptions struct {
+	// Size of the terminal in characters, zero when unknown, e.g. when the
+	// output is piped, for the default sizes drawn for 80 by 24
+	Columns, Lines int
+
+	// Plain draws in 7-bit ASCII only, without shading, bullets or box
+	// drawing, for logs and legacy terminals
+	Plain bool
+}
+
+// Text are the options of the text diagrams
+var Text TextOptions
+
+// textColumns returns the width of a drawing of def characters in a text
+// diagram laid out for 80 columns, widened or narrowed with the terminal
+// while its labels keep their width
+func textColumns(def int) int {
+	if Text.Columns <= 0 {
+		return def
+	}
+	return clampInt(def+Text.Columns-80, def/2, 3*def)
+}
+
+// textRows returns the height of a drawing of def rows in a text diagram
+// laid out for 24 lines, in proportion to the height of the terminal
+func textRows(def int) int {
+	if Text.Lines <= 0 {
+		return def
+	}
+	return clampInt(def*Text.Lines/24, def/2, 2*def)
+}
+
+// clampInt limits n to lo through hi
+func clampInt(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	return min(n, hi)
+}
+
+// textOut returns a text diagram as drawn, or spelled in ASCII when plain
+func textOut(s string) string {
+	if Text.Plain {
+		return ascii.Spell(s)
+	}
+	return s
+}
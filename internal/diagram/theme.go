@@ -0,0 +1,214 @@
+package diagram
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+)
+
+// Theme holds the colors of the plotted diagrams by what they draw
+type Theme struct {
+	Background color.Color
+	Ink        color.Color // Outlines, axes, text, dimensions and supports
+	Muted      color.Color // Nominal curves, reference lines and their notes
+	Faint      color.Color // Curves drawn behind the main one
+	Grid       color.Color
+
+	Concrete    color.Color // Elevations of beams
+	Compression color.Color // Compression zone of a section
+	StressBlock color.Color // Stress block beside the strain profile
+	Steel       color.Color // Bars
+	Stirrup     color.Color // Stirrups
+
+	Primary    color.Color // Design curves, maximum envelopes and compressive forces
+	Secondary  color.Color // Minimum envelopes and tensile forces
+	Alert      color.Color // Neutral axis, strains and loads outside the design curve
+	Pass       color.Color // Loads inside the design curve and clear cover lines
+	Strain     color.Color // Strain profile
+	StrainFill color.Color
+	Yield      color.Color // Yield strains
+
+	// Colors of the curves of charts, in turn
+	Palette []color.Color
+}
+
+// Names of the themes
+const (
+	ThemeDefault    = "default"
+	ThemePrint      = "print"
+	ThemeDark       = "dark"
+	ThemeColorblind = "colorblind"
+)
+
+var (
+	darkBlue   = color.RGBA{R: 0, G: 0, B: 139, A: 255}
+	firebrick  = color.RGBA{R: 178, G: 34, B: 34, A: 255}
+	red        = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	green      = color.RGBA{R: 0, G: 128, B: 0, A: 255}
+	darkGreen  = color.RGBA{R: 0, G: 100, B: 0, A: 255}
+	orange     = color.RGBA{R: 255, G: 165, B: 0, A: 255}
+	brown      = color.RGBA{R: 139, G: 69, B: 19, A: 255}
+	cornflower = color.RGBA{R: 100, G: 149, B: 237, A: 150}
+
+	// Okabe-Ito colors, told apart with the common color vision deficiencies
+	okabeBlue       = color.RGBA{R: 0, G: 114, B: 178, A: 255}
+	okabeVermillion = color.RGBA{R: 213, G: 94, B: 0, A: 255}
+	okabeGreen      = color.RGBA{R: 0, G: 158, B: 115, A: 255}
+	okabeOrange     = color.RGBA{R: 230, G: 159, B: 0, A: 255}
+	okabePurple     = color.RGBA{R: 204, G: 121, B: 167, A: 255}
+	okabeSky        = color.RGBA{R: 86, G: 180, B: 233, A: 255}
+	okabeYellow     = color.RGBA{R: 240, G: 228, B: 66, A: 255}
+)
+
+// themes are the built-in themes by name
+var themes = map[string]Theme{
+	ThemeDefault: {
+		Background: color.White, Ink: color.Black,
+		Muted: color.Gray{Y: 120}, Faint: color.Gray{Y: 170}, Grid: color.Gray{Y: 210},
+		Concrete:    color.Gray{Y: 235},
+		Compression: cornflower,
+		StressBlock: color.RGBA{R: 200, G: 255, B: 100, A: 255},
+		Steel:       brown,
+		Stirrup:     color.Gray{Y: 90},
+		Primary:     darkBlue, Secondary: firebrick,
+		Alert: red, Pass: green,
+		Strain: darkGreen, StrainFill: color.RGBA{R: 200, G: 230, B: 200, A: 255},
+		Yield: orange,
+	},
+
+	// Black and grays for monochrome printers, told apart by dashes
+	ThemePrint: {
+		Background: color.White, Ink: color.Black,
+		Muted: color.Gray{Y: 100}, Faint: color.Gray{Y: 180}, Grid: color.Gray{Y: 225},
+		Concrete:    color.Gray{Y: 240},
+		Compression: color.Gray{Y: 205},
+		StressBlock: color.Gray{Y: 215},
+		Steel:       color.Black,
+		Stirrup:     color.Gray{Y: 70},
+		Primary:     color.Black, Secondary: color.Gray{Y: 90},
+		Alert: color.Black, Pass: color.Gray{Y: 90},
+		Strain: color.Black, StrainFill: color.Gray{Y: 225},
+		Yield:   color.Gray{Y: 120},
+		Palette: []color.Color{color.Black, color.Gray{Y: 70}, color.Gray{Y: 120}, color.Gray{Y: 160}},
+	},
+
+	// Light lines on a dark background for screens and slides
+	ThemeDark: {
+		Background: color.RGBA{R: 30, G: 30, B: 30, A: 255}, Ink: color.Gray{Y: 230},
+		Muted: color.Gray{Y: 160}, Faint: color.Gray{Y: 95}, Grid: color.Gray{Y: 60},
+		Concrete:    color.Gray{Y: 65},
+		Compression: color.RGBA{R: 100, G: 149, B: 237, A: 120},
+		StressBlock: color.RGBA{R: 90, G: 130, B: 50, A: 255},
+		Steel:       color.RGBA{R: 215, G: 140, B: 70, A: 255},
+		Stirrup:     color.Gray{Y: 170},
+		Primary:     color.RGBA{R: 110, G: 170, B: 255, A: 255},
+		Secondary:   color.RGBA{R: 255, G: 125, B: 105, A: 255},
+		Alert:       color.RGBA{R: 255, G: 90, B: 90, A: 255},
+		Pass:        color.RGBA{R: 90, G: 210, B: 90, A: 255},
+		Strain:      color.RGBA{R: 120, G: 220, B: 120, A: 255},
+		StrainFill:  color.RGBA{R: 40, G: 80, B: 40, A: 255},
+		Yield:       color.RGBA{R: 255, G: 190, B: 60, A: 255},
+		Palette: []color.Color{
+			color.RGBA{R: 110, G: 170, B: 255, A: 255}, color.RGBA{R: 255, G: 125, B: 105, A: 255},
+			color.RGBA{R: 120, G: 220, B: 120, A: 255}, color.RGBA{R: 255, G: 190, B: 60, A: 255},
+			color.RGBA{R: 210, G: 140, B: 255, A: 255}, color.RGBA{R: 100, G: 220, B: 220, A: 255},
+		},
+	},
+
+	// Okabe-Ito colors for readers with color vision deficiencies
+	ThemeColorblind: {
+		Background: color.White, Ink: color.Black,
+		Muted: color.Gray{Y: 120}, Faint: color.Gray{Y: 175}, Grid: color.Gray{Y: 230},
+		Concrete:    color.Gray{Y: 235},
+		Compression: color.RGBA{R: 86, G: 180, B: 233, A: 150},
+		StressBlock: okabeYellow,
+		Steel:       color.RGBA{R: 102, G: 51, B: 0, A: 255},
+		Stirrup:     color.Gray{Y: 90},
+		Primary:     okabeBlue, Secondary: okabeVermillion,
+		Alert: okabeVermillion, Pass: okabeGreen,
+		Strain: okabeGreen, StrainFill: color.RGBA{R: 204, G: 236, B: 226, A: 255},
+		Yield:   okabeOrange,
+		Palette: []color.Color{okabeBlue, okabeVermillion, okabeGreen, okabeOrange, okabePurple, okabeSky, color.Black},
+	},
+}
+
+// theme is the theme the diagrams are drawn in
+var theme = themes[ThemeDefault]
+
+// ThemeNames returns the names of the themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SetTheme selects the theme the diagrams are drawn in by name
+func SetTheme(name string) error {
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	theme = t
+	return nil
+}
+
+// paletteColor returns the color of the i-th curve of a chart
+func paletteColor(i int) color.Color {
+	if len(theme.Palette) == 0 {
+		return plotutil.Color(i)
+	}
+	return theme.Palette[i%len(theme.Palette)]
+}
+
+// translucent returns a color with an opacity from 0 to 255
+func translucent(c color.Color, alpha uint8) color.Color {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = alpha
+	return n
+}
+
+// newPlot returns a plot with the background, title, axes and legend in
+// the colors of the theme
+func newPlot() *plot.Plot {
+	p := plot.New()
+	p.BackgroundColor = theme.Background
+	p.Title.TextStyle.Color = theme.Ink
+	p.Legend.TextStyle.Color = theme.Ink
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.LineStyle.Color = theme.Ink
+		a.Label.TextStyle.Color = theme.Ink
+		a.Tick.Label.Color = theme.Ink
+		a.Tick.LineStyle.Color = theme.Ink
+	}
+	return p
+}
+
+// newGrid returns a grid in the color of the theme
+func newGrid() *plotter.Grid {
+	g := plotter.NewGrid()
+	g.Vertical.Color, g.Horizontal.Color = theme.Grid, theme.Grid
+	return g
+}
+
+// newLabels returns labels in the ink of the theme and the selected
+// language
+func newLabels(d plotter.XYLabeller) (*plotter.Labels, error) {
+	l, err := plotter.NewLabels(d)
+	if err != nil {
+		return nil, err
+	}
+	for i := range l.TextStyle {
+		l.TextStyle[i].Color = theme.Ink
+		l.Labels[i] = i18n.T(l.Labels[i])
+	}
+	return l, nil
+}
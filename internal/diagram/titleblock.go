@@ -0,0 +1,106 @@
+package diagram
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TitleBlock identifies the member a diagram is drawn for in a strip of
+// boxed cells along the bottom of the exported images
+type TitleBlock struct {
+	Project  string
+	Member   string
+	Engineer string
+	Date     string
+	Code     string // Edition of the design code
+}
+
+// Stamp is the title block of the exported diagrams, drawn when it names a
+// project, member or engineer
+var Stamp TitleBlock
+
+// titleBlockHeight is the height of the strip of the title block
+const titleBlockHeight = 0.35 * vg.Inch
+
+// drawn reports whether the title block is stamped on the diagrams
+func (t TitleBlock) drawn() bool {
+	return t.Project != "" || t.Member != "" || t.Engineer != ""
+}
+
+// cells returns the captions and values of the title block that are set
+func (t TitleBlock) cells() []string {
+	var cells []string
+	for _, f := range []struct{ caption, value string }{
+		{"Project", t.Project},
+		{"Member", t.Member},
+		{"Engineer", t.Engineer},
+		{"Date", t.Date},
+		{"Code", t.Code},
+	} {
+		if v := strings.TrimSpace(f.value); v != "" {
+			cells = append(cells, f.caption+": "+v)
+		}
+	}
+	return cells
+}
+
+// stamp fills a canvas with the background of the theme and draws the
+// title block along its bottom, returning the area above it for the plots
+func stamp(c draw.Canvas, sty text.Style) draw.Canvas {
+	c.SetColor(theme.Background)
+	c.Fill(c.Rectangle.Path())
+	if !Stamp.drawn() {
+		return c
+	}
+
+	const pad = 4
+	cells := Stamp.cells()
+	line := draw.LineStyle{Color: theme.Ink, Width: vg.Points(0.75)}
+	x0, x1 := c.Min.X+pad, c.Max.X-pad
+	y0, y1 := c.Min.Y+pad, c.Min.Y+titleBlockHeight
+	c.StrokeLines(line, []vg.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}, {X: x0, Y: y0}})
+
+	sty.Color = theme.Ink
+	sty.Font.Size = font.Length(8)
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YCenter
+	width := (x1 - x0) / vg.Length(len(cells))
+	for i, cell := range cells {
+		x := x0 + vg.Length(i)*width
+		if i > 0 {
+			c.StrokeLine2(line, x, y0, x, y1)
+		}
+		c.FillText(sty, vg.Point{X: x + 2*pad, Y: (y0 + y1) / 2}, cell)
+	}
+	return draw.Crop(c, 0, 0, titleBlockHeight, 0)
+}
+
+// plotImage renders a plot in an image format of Formats, at the size and
+// resolution of Figure, on the background of the theme with the title block
+func plotImage(p *plot.Plot, width, height vg.Length, format string) ([]byte, error) {
+	c, err := newCanvas(width, height, format)
+	if err != nil {
+		return nil, err
+	}
+	p.Draw(stamp(draw.New(c), p.X.Tick.Label))
+	return canvasBytes(c)
+}
+
+// savePlot renders a plot to a file in the format of its extension,
+// creating its directory
+func savePlot(p *plot.Plot, width, height vg.Length, filename string) error {
+	image, err := plotImage(p, width, height, fileFormat(filename))
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(filename); dir != "" && dir != "." {
+		os.MkdirAll(dir, 0755)
+	}
+	return os.WriteFile(filename, image, 0644)
+}
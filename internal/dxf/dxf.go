@@ -0,0 +1,219 @@
+// Package dxf writes drawings of lines, polylines, circles and text on named
+// layers as AutoCAD R12 ASCII DXF files, which every CAD program reads.
+package dxf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Colors of the AutoCAD color index used for layers
+const (
+	Red     = 1
+	Yellow  = 2
+	Green   = 3
+	Cyan    = 4
+	Blue    = 5
+	Magenta = 6
+	White   = 7
+)
+
+// Horizontal alignments of text
+const (
+	Left = iota
+	Center
+	Right
+)
+
+// Point is a point of the drawing in drawing units (mm)
+type Point struct {
+	X, Y float64
+}
+
+// Drawing is a DXF drawing of entities on layers
+type Drawing struct {
+	layers   []layer
+	entities bytes.Buffer
+
+	min, max Point // Extents of the entities
+	empty    bool
+}
+
+// layer is a named layer and its color
+type layer struct {
+	name  string
+	color int
+}
+
+// New returns an empty drawing
+func New() *Drawing {
+	return &Drawing{empty: true}
+}
+
+// Layer adds a layer of a color, e.g. dxf.Red. Entities may only be drawn
+// on layers that were added.
+func (d *Drawing) Layer(name string, color int) {
+	d.layers = append(d.layers, layer{name: name, color: color})
+}
+
+// Line draws a line between two points
+func (d *Drawing) Line(layer string, a, b Point) {
+	d.entity("LINE", layer)
+	d.point(10, a)
+	d.point(11, b)
+}
+
+// Polyline draws lines through the points, back to the first point when
+// closed
+func (d *Drawing) Polyline(layer string, points []Point, closed bool) {
+	flags := 0
+	if closed {
+		flags = 1
+	}
+	d.entity("POLYLINE", layer)
+	d.group(66, "1")
+	d.group(70, strconv.Itoa(flags))
+	for _, p := range points {
+		d.entity("VERTEX", layer)
+		d.point(10, p)
+	}
+	d.entity("SEQEND", layer)
+}
+
+// Circle draws a circle of a diameter about a center
+func (d *Drawing) Circle(layer string, center Point, diameter float64) {
+	d.entity("CIRCLE", layer)
+	d.point(10, center)
+	d.group(40, number(diameter/2))
+	d.extend(Point{X: center.X - diameter/2, Y: center.Y - diameter/2})
+	d.extend(Point{X: center.X + diameter/2, Y: center.Y + diameter/2})
+}
+
+// Text writes a line of text of a height at a point, aligned on it by
+// align (dxf.Left, dxf.Center or dxf.Right) and rotated counterclockwise
+// by angle degrees. "%%c" writes the diameter symbol.
+func (d *Drawing) Text(layer string, at Point, height float64, text string, align int, angle float64) {
+	d.entity("TEXT", layer)
+	d.point(10, at)
+	d.group(40, number(height))
+	d.group(1, encode(text))
+	if angle != 0 {
+		d.group(50, number(angle))
+	}
+	if align != Left {
+		d.group(72, strconv.Itoa(align))
+		d.point(11, at)
+	}
+}
+
+// entity starts an entity on a layer
+func (d *Drawing) entity(kind, layer string) {
+	d.group(0, kind)
+	d.group(8, layer)
+}
+
+// point writes the coordinates of a point with the group code of its x
+func (d *Drawing) point(code int, p Point) {
+	d.group(code, number(p.X))
+	d.group(code+10, number(p.Y))
+	d.group(code+20, "0.0")
+	d.extend(p)
+}
+
+// group writes a group code and its value
+func (d *Drawing) group(code int, value string) {
+	fmt.Fprintf(&d.entities, "%3d\n%s\n", code, value)
+}
+
+// extend grows the extents of the drawing to include a point
+func (d *Drawing) extend(p Point) {
+	if d.empty {
+		d.min, d.max, d.empty = p, p, false
+		return
+	}
+	d.min = Point{X: math.Min(d.min.X, p.X), Y: math.Min(d.min.Y, p.Y)}
+	d.max = Point{X: math.Max(d.max.X, p.X), Y: math.Max(d.max.Y, p.Y)}
+}
+
+// Bytes returns the drawing as a DXF file in millimeters
+func (d *Drawing) Bytes() []byte {
+	var out bytes.Buffer
+	group := func(code int, value string) {
+		fmt.Fprintf(&out, "%3d\n%s\n", code, value)
+	}
+
+	group(0, "SECTION")
+	group(2, "HEADER")
+	group(9, "$ACADVER")
+	group(1, "AC1009")
+	group(9, "$INSUNITS")
+	group(70, "4") // Millimeters
+	group(9, "$MEASUREMENT")
+	group(70, "1") // Metric
+	group(9, "$EXTMIN")
+	group(10, number(d.min.X))
+	group(20, number(d.min.Y))
+	group(9, "$EXTMAX")
+	group(10, number(d.max.X))
+	group(20, number(d.max.Y))
+	group(0, "ENDSEC")
+
+	group(0, "SECTION")
+	group(2, "TABLES")
+	group(0, "TABLE")
+	group(2, "LAYER")
+	group(70, strconv.Itoa(len(d.layers)+1))
+	for _, l := range append([]layer{{name: "0", color: White}}, d.layers...) {
+		group(0, "LAYER")
+		group(2, l.name)
+		group(70, "0")
+		group(62, strconv.Itoa(l.color))
+		group(6, "CONTINUOUS")
+	}
+	group(0, "ENDTAB")
+	group(0, "ENDSEC")
+
+	group(0, "SECTION")
+	group(2, "ENTITIES")
+	out.Write(d.entities.Bytes())
+	group(0, "ENDSEC")
+	group(0, "EOF")
+	return out.Bytes()
+}
+
+// WriteFile writes the drawing to a .dxf file, creating its directory when
+// missing
+func (d *Drawing) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, d.Bytes(), 0644)
+}
+
+// number formats a coordinate or size without trailing zeros
+func number(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0.0"
+	}
+	return s
+}
+
+// encode writes characters outside ASCII as \U+XXXX escapes
+func encode(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		if r < 128 {
+			sb.WriteRune(r)
+		} else {
+			fmt.Fprintf(&sb, `\U+%04X`, r)
+		}
+	}
+	return sb.String()
+}
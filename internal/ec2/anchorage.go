@@ -0,0 +1,44 @@
+package ec2
+
+import "math"
+
+// DesignBondStrength calculates the ultimate bond stress fbd for ribbed bars
+// Section 8.4.2, Eq. 8.2 with η2 = 1.0 for bars up to 32 mm.
+// goodBond selects η1 = 1.0 (good bond conditions) or 0.7 (all other, e.g. top bars).
+func DesignBondStrength(fck, db float64, goodBond bool) float64 {
+	eta1 := 1.0
+	if !goodBond {
+		eta1 = 0.7
+	}
+	eta2 := 1.0
+	if db > 32 {
+		eta2 = (132 - db) / 100
+	}
+	// fctd = αct·fctk,0.05/γc with αct = 1.0 and fctk,0.05 = 0.7fctm
+	fctd := 0.7 * Fctm(math.Min(fck, 60)) / GammaC
+	return 2.25 * eta1 * eta2 * fctd
+}
+
+// AnchorageLength calculates the design anchorage length lbd of a straight
+// bar in tension developing fyd, Eq. 8.3 and 8.4 with α1..α5 = 1.0 (mm)
+func AnchorageLength(db, fck, fyk float64, goodBond bool) float64 {
+	lbRqd := db / 4 * Fyd(fyk) / DesignBondStrength(fck, db, goodBond)
+	// Minimum anchorage length in tension, Eq. 8.6
+	lbMin := math.Max(math.Max(0.3*lbRqd, 10*db), 100)
+	return math.Max(lbRqd, lbMin)
+}
+
+// LapFactor returns α6 for the percentage of lapped bars within 0.65l0 of the lap centre
+// Section 8.7.3, Table 8.3: α6 = (ρ1/25)^0.5 between 1.0 and 1.5
+func LapFactor(percentLapped float64) float64 {
+	return math.Min(math.Max(math.Sqrt(percentLapped/25), 1.0), 1.5)
+}
+
+// LapLength calculates the design lap length l0 = α6·lbd of a bar, not less than
+// l0,min = max(0.3α6·lb,rqd, 15db, 200 mm) (Section 8.7.3, Eq. 8.10 and 8.11)
+func LapLength(db, fck, fyk float64, goodBond bool, percentLapped float64) float64 {
+	alpha6 := LapFactor(percentLapped)
+	lbRqd := db / 4 * Fyd(fyk) / DesignBondStrength(fck, db, goodBond)
+	l0Min := math.Max(math.Max(0.3*alpha6*lbRqd, 15*db), 200)
+	return math.Max(alpha6*AnchorageLength(db, fck, fyk, goodBond), l0Min)
+}
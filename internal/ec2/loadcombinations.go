@@ -0,0 +1,76 @@
+package ec2
+
+import "github.com/alexiusacademia/gorcb/internal/nscp"
+
+// EN 1990 Eq. 6.10 (Table A1.2(B)) and Eq. 6.12b - Load Combinations
+// Each variable action is taken in turn as the leading action with the
+// recommended ψ0 factors for the accompanying actions:
+// imposed (category A-D) 0.7, wind 0.6, snow 0.5, roofs (category H) 0.
+// Rain is not a separate action in EN 1991 and is not combined.
+// Fluid (F) and earth (H) pressures are permanent actions factored with G;
+// self-straining effects (T) are not combined.
+var LoadCombinations = []nscp.LoadCombination{
+	{
+		ID:          "6.10a",
+		Description: "1.35G",
+		Dead:        1.35,
+		Fluid:       1.35,
+		Earth:       1.35,
+	},
+	{
+		ID:          "6.10b",
+		Description: "1.35G + 1.5Q + 0.9W + 0.75S",
+		Dead:        1.35,
+		Fluid:       1.35,
+		Earth:       1.35,
+		Live:        1.5,
+		Wind:        0.9,
+		Snow:        0.75,
+	},
+	{
+		ID:          "6.10c",
+		Description: "1.35G + 1.5W + 1.05Q + 0.75S",
+		Dead:        1.35,
+		Fluid:       1.35,
+		Earth:       1.35,
+		Live:        1.05,
+		Wind:        1.5,
+		Snow:        0.75,
+	},
+	{
+		ID:          "6.10d",
+		Description: "1.35G + 1.5S + 1.05Q + 0.9W",
+		Dead:        1.35,
+		Fluid:       1.35,
+		Earth:       1.35,
+		Live:        1.05,
+		Wind:        0.9,
+		Snow:        1.5,
+	},
+	{
+		ID:          "6.10e",
+		Description: "1.35G + 1.5Qr + 0.9W",
+		Dead:        1.35,
+		Fluid:       1.35,
+		Earth:       1.35,
+		Roof:        1.5,
+		Wind:        0.9,
+	},
+	{
+		ID:          "6.10f",
+		Description: "1.0G + 1.5W",
+		Dead:        1.0,
+		Fluid:       1.0,
+		Earth:       1.0,
+		Wind:        1.5,
+	},
+	{
+		ID:          "6.12b",
+		Description: "1.0G + 1.0AEd + 0.3Q",
+		Dead:        1.0,
+		Fluid:       1.0,
+		Earth:       1.0,
+		Live:        0.3,
+		Earthquake:  1.0,
+	},
+}
@@ -0,0 +1,150 @@
+package ec2
+
+import (
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// EN 1992-1-1 (Eurocode 2) Material Constants
+
+const (
+	// Partial factors for materials, persistent and transient situations (Table 2.1N)
+	GammaC = 1.50 // Concrete
+	GammaS = 1.15 // Reinforcing steel
+
+	// Coefficient for long-term effects on compressive strength (Section 3.1.6(1)P)
+	// The recommended value is 1.0; 0.85 is adopted by most National Annexes for flexure
+	AlphaCC = 0.85
+
+	// Ultimate concrete strain for the rectangular stress block, f_ck <= 50 MPa (Table 3.1)
+	EpsilonCU3 = 0.0035
+
+	// Limits of neutral axis depth ratio x/d for ductility without redistribution (Section 5.5(4))
+	XdLimitNormal = 0.45 // f_ck <= 50 MPa
+	XdLimitHigh   = 0.35 // f_ck > 50 MPa
+
+	// Minimum tension reinforcement floor, As,min >= 0.0013·bt·d (Section 9.2.1.1)
+	RhoMinFloor = 0.0013
+
+	// Modulus of elasticity for steel (Section 3.2.7(4))
+	Es = nscp.Es // MPa
+)
+
+// Lambda returns the effective height factor of the rectangular stress block
+// Section 3.1.7(3), Eq. 3.19 and 3.20
+func Lambda(fck float64) float64 {
+	if fck <= 50 {
+		return 0.8
+	}
+	return 0.8 - (fck-50)/400
+}
+
+// Eta returns the effective strength factor of the rectangular stress block
+// Section 3.1.7(3), Eq. 3.21 and 3.22
+func Eta(fck float64) float64 {
+	if fck <= 50 {
+		return 1.0
+	}
+	return 1.0 - (fck-50)/200
+}
+
+// EpsilonCU returns the ultimate compressive strain εcu3
+// Table 3.1
+func EpsilonCU(fck float64) float64 {
+	if fck <= 50 {
+		return EpsilonCU3
+	}
+	return (2.6 + 35*math.Pow((90-fck)/100, 4)) / 1000
+}
+
+// Fcd returns the design compressive strength αcc·fck/γc
+// Section 3.1.6(1)P
+func Fcd(fck float64) float64 {
+	return AlphaCC * fck / GammaC
+}
+
+// Fyd returns the design yield strength of reinforcement fyk/γs
+// Section 3.2.7(2)
+func Fyd(fyk float64) float64 {
+	return fyk / GammaS
+}
+
+// Fctm returns the mean axial tensile strength of concrete
+// Table 3.1
+func Fctm(fck float64) float64 {
+	if fck <= 50 {
+		return 0.30 * math.Pow(fck, 2.0/3.0)
+	}
+	fcm := fck + 8
+	return 2.12 * math.Log(1+fcm/10)
+}
+
+// Ecm returns the secant modulus of elasticity of concrete, reduced by
+// ηE = (ρ/2200)² for lightweight aggregate concrete of oven-dry density ρ (kg/m³)
+// Table 3.1 and Section 11.3.2: Ecm = 22000(fcm/10)^0.3
+func Ecm(fck, density float64) float64 {
+	fcm := fck + 8
+	ecm := 22000 * math.Pow(fcm/10, 0.3)
+	if density > 0 && density < 2200 {
+		ecm *= math.Pow(density/2200, 2)
+	}
+	return ecm
+}
+
+// XdLimit returns the maximum neutral axis depth ratio x/d
+// Section 5.5(4) with δ = 1.0
+func XdLimit(fck float64) float64 {
+	if fck <= 50 {
+		return XdLimitNormal
+	}
+	return XdLimitHigh
+}
+
+// DuctilityStrain returns the steel strain reached when x/d equals XdLimit
+func DuctilityStrain(fck float64) float64 {
+	k := XdLimit(fck)
+	return EpsilonCU(fck) * (1 - k) / k
+}
+
+// RhoMin calculates minimum longitudinal tension reinforcement ratio
+// Section 9.2.1.1(1), Eq. 9.1N
+func RhoMin(fck, fyk float64) float64 {
+	return math.Max(0.26*Fctm(fck)/fyk, RhoMinFloor)
+}
+
+// TensionFlangeMinWidth returns the mean width of the tension zone bt used in
+// Eq. 9.1N when the flange is in tension, taken as the flange width bf
+func TensionFlangeMinWidth(bw, bf float64) float64 {
+	return math.Max(bf, bw)
+}
+
+// RhoMax calculates the reinforcement ratio at the x/d ductility limit
+func RhoMax(fck, fyk float64) float64 {
+	return Eta(fck) * Fcd(fck) * Lambda(fck) * XdLimit(fck) / Fyd(fyk)
+}
+
+// RhoBalanced calculates the reinforcement ratio at which steel yields as concrete crushes
+func RhoBalanced(fck, fyk float64) float64 {
+	epsilonYD := Fyd(fyk) / Es
+	epsCU := EpsilonCU(fck)
+	xb := epsCU / (epsCU + epsilonYD)
+	return Eta(fck) * Fcd(fck) * Lambda(fck) * xb / Fyd(fyk)
+}
+
+// Eta1 returns the strength factor for lightweight aggregate concrete from the
+// oven-dry density ρ (kg/m³), η1 = 0.40 + 0.60ρ/2200
+// Section 11.3.1
+func Eta1(density float64) float64 {
+	if density <= 0 {
+		return 1.0
+	}
+	return math.Min(0.40+0.60*density/2200, 1.0)
+}
+
+// MaxSecondarySpacing returns the maximum spacing of secondary (distribution)
+// reinforcement in a slab of thickness h (mm)
+// Section 9.3.1.1(3): 3.5h, not more than 450 mm
+func MaxSecondarySpacing(h float64) float64 {
+	return math.Min(3.5*h, 450)
+}
@@ -0,0 +1,47 @@
+package ec2
+
+import "math"
+
+// EN 1992-1-1 Section 6.2 - Shear
+// Forces are in N and dimensions in mm.
+
+// SizeFactor returns k = 1 + √(200/d) ≤ 2.0
+func SizeFactor(d float64) float64 {
+	return math.Min(1+math.Sqrt(200/d), 2.0)
+}
+
+// VRdc calculates the design shear resistance of members without shear
+// reinforcement, Eq. 6.2a and 6.2b (no axial force)
+// eta1 is the lightweight concrete factor (1.0 for normal-weight concrete)
+func VRdc(fck, bw, d, rhoL, eta1 float64) float64 {
+	k := SizeFactor(d)
+	rhoL = math.Min(rhoL, 0.02)
+	cRdc := 0.18 / GammaC
+	if eta1 < 1.0 {
+		// Section 11.6.1: CRd,c = 0.15/γc for lightweight aggregate concrete
+		cRdc = 0.15 / GammaC
+	}
+	vRdc := cRdc * eta1 * k * math.Cbrt(100*rhoL*fck)
+	vMin := 0.035 * math.Pow(k, 1.5) * math.Sqrt(fck) * eta1
+	return math.Max(vRdc, vMin) * bw * d
+}
+
+// VRdMax calculates the crushing limit of the compression struts for
+// vertical links with θ = 45° and z = 0.9d, Eq. 6.9
+func VRdMax(fck, bw, d float64) float64 {
+	nu1 := 0.6 * (1 - fck/250)
+	z := 0.9 * d
+	return bw * z * nu1 * Fcd(fck) / 2
+}
+
+// MaxLinkSpacing returns the maximum longitudinal spacing of vertical links
+// Section 9.2.2(6), Eq. 9.6N: 0.75d
+func MaxLinkSpacing(d float64) float64 {
+	return 0.75 * d
+}
+
+// AswMinPerSpacing calculates the minimum shear reinforcement Asw/s (mm²/mm)
+// Section 9.2.2(5), Eq. 9.5N: ρw,min = 0.08√fck/fyk
+func AswMinPerSpacing(fck, fyk, bw float64) float64 {
+	return 0.08 * math.Sqrt(fck) / fyk * bw
+}
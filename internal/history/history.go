@@ -0,0 +1,362 @@
+// Package history keeps a local record of gorcb runs, their inputs, their
+// results and, when asked, everything they printed, so earlier calculations
+// can be retrieved and reproduced during design reviews.
+//
+// Runs are stored in a SQLite database, numbered by the order they were
+// recorded. The database is opened for each run and waits for other runs
+// writing at the same time.
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Registers the sqlite driver
+)
+
+// FileName is the name of the history database in the gorcb directory of
+// the user configuration directory, e.g. ~/.config/gorcb/history.db
+const FileName = "history.db"
+
+// MaxOutput is the most output of a run that is kept, and MaxInput the
+// largest input file that is copied into the history
+const (
+	MaxOutput = 1 << 20
+	MaxInput  = 1 << 20
+)
+
+// Statuses of a run by its exit code
+const (
+	StatusOK    = "OK"
+	StatusNG    = "NG"
+	StatusError = "ERROR"
+)
+
+// Run is a recorded run of gorcb
+type Run struct {
+	ID         int               `json:"id,omitempty"`
+	Time       time.Time         `json:"time"`
+	Version    string            `json:"version"`
+	Command    string            `json:"command"`              // Command path, e.g. "gorcb beam design"
+	Args       []string          `json:"args"`                 // Arguments as given on the command line
+	Dir        string            `json:"dir"`                  // Working directory of the run
+	Configured map[string]string `json:"configured,omitempty"` // Flags set from the environment or configuration files
+	Inputs     []Input           `json:"inputs,omitempty"`
+	Stdin      bool              `json:"stdin,omitempty"` // The input was read from stdin and was not kept
+	Exit       int               `json:"exit"`
+	Results    []string          `json:"results,omitempty"` // One-line results of the members, as printed with --quiet
+
+	// Output printed by the run, kept only with --record-output
+	OutputRecorded bool   `json:"output_recorded"`
+	Output         string `json:"output,omitempty"`
+	Truncated      bool   `json:"truncated,omitempty"` // Only the first MaxOutput bytes of the output were kept
+}
+
+// Input is an input file of a run with its contents at the time of the run
+type Input struct {
+	Flag    string `json:"flag"`
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Size    int64  `json:"size"`
+	Omitted bool   `json:"omitted,omitempty"` // Larger than MaxInput, so the contents were not kept
+}
+
+// Status returns OK, NG or ERROR for the exit code of the run, where 3
+// marks an inadequate design or check
+func (r Run) Status() string {
+	switch r.Exit {
+	case 0:
+		return StatusOK
+	case 3:
+		return StatusNG
+	}
+	return StatusError
+}
+
+// CommandLine returns the command line of the run, quoting arguments with
+// spaces
+func (r Run) CommandLine() string {
+	parts := []string{"gorcb"}
+	for _, arg := range r.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReadInput returns an input file of a run as it is now
+func ReadInput(flag, path string) (Input, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Input{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return Input{}, fmt.Errorf("%s is not a file", path)
+	}
+	in := Input{Flag: flag, Path: path, Size: info.Size()}
+	if info.Size() > MaxInput {
+		in.Omitted = true
+		return in, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Input{}, err
+	}
+	in.Content = string(data)
+	return in, nil
+}
+
+// Changed reports whether an input file was changed or removed since the
+// run. Files too large to keep are compared by size.
+func (in Input) Changed(dir string) bool {
+	path := in.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	now, err := ReadInput(in.Flag, path)
+	if err != nil || now.Size != in.Size {
+		return true
+	}
+	return !in.Omitted && now.Content != in.Content
+}
+
+// DefaultPath returns the history database in the user configuration
+// directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gorcb", FileName), nil
+}
+
+// Store is a history database
+type Store struct {
+	Path string
+}
+
+// schema creates the tables of the history: the runs, and the input files
+// of each run in the order they were read
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	time            TEXT NOT NULL,
+	version         TEXT NOT NULL,
+	command         TEXT NOT NULL,
+	args            TEXT NOT NULL,
+	dir             TEXT NOT NULL,
+	configured      TEXT,
+	stdin           INTEGER NOT NULL DEFAULT 0,
+	exit            INTEGER NOT NULL,
+	output_recorded INTEGER NOT NULL DEFAULT 0,
+	output          TEXT,
+	truncated       INTEGER NOT NULL DEFAULT 0,
+	results         TEXT
+);
+CREATE TABLE IF NOT EXISTS inputs (
+	run_id  INTEGER NOT NULL REFERENCES runs(id),
+	seq     INTEGER NOT NULL,
+	flag    TEXT NOT NULL,
+	path    TEXT NOT NULL,
+	content TEXT,
+	size    INTEGER NOT NULL,
+	omitted INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (run_id, seq)
+);`
+
+// open opens the history database, creating it when create is set. A
+// missing database is reported as fs.ErrNotExist otherwise.
+func (s Store) open(create bool) (*sql.DB, error) {
+	if create {
+		if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(s.Path); err != nil {
+		return nil, err
+	}
+	// Runs finishing together wait for each other rather than fail
+	db, err := sql.Open("sqlite", s.Path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", s.Path, err)
+	}
+	// Histories made before the results were kept gain their column
+	if _, err := db.Exec(`SELECT results FROM runs LIMIT 0`); err != nil {
+		if _, err := db.Exec(`ALTER TABLE runs ADD COLUMN results TEXT`); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("%s: %w", s.Path, err)
+		}
+	}
+	return db, nil
+}
+
+// Append records a run at the end of the history and returns its ID
+func (s Store) Append(r Run) (int, error) {
+	db, err := s.open(true)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	args, err := json.Marshal(r.Args)
+	if err != nil {
+		return 0, err
+	}
+	var configured, results []byte
+	if len(r.Configured) > 0 {
+		if configured, err = json.Marshal(r.Configured); err != nil {
+			return 0, err
+		}
+	}
+	if len(r.Results) > 0 {
+		if results, err = json.Marshal(r.Results); err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(`INSERT INTO runs (time, version, command, args, dir, configured, stdin, exit,
+		output_recorded, output, truncated, results) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.Time.UTC().Format(time.RFC3339Nano), r.Version, r.Command, string(args), r.Dir,
+		nullString(string(configured)), r.Stdin, r.Exit, r.OutputRecorded, nullString(r.Output), r.Truncated,
+		nullString(string(results)))
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	for i, in := range r.Inputs {
+		if _, err := tx.Exec(`INSERT INTO inputs (run_id, seq, flag, path, content, size, omitted)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`, id, i, in.Flag, in.Path, nullString(in.Content), in.Size, in.Omitted); err != nil {
+			return 0, err
+		}
+	}
+	return int(id), tx.Commit()
+}
+
+// runColumns are the columns of a run read by scanRun
+const runColumns = `id, time, version, command, args, dir, configured, stdin, exit, output_recorded, output, truncated, results`
+
+// List returns the last recorded runs, all of them when last is 0, oldest
+// first, without their inputs and output. A missing history has no runs.
+func (s Store) List(last int) ([]Run, error) {
+	db, err := s.open(false)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	limit := -1
+	if last > 0 {
+		limit = last
+	}
+	rows, err := db.Query(`SELECT `+runColumns+` FROM (SELECT * FROM runs ORDER BY id DESC LIMIT ?) ORDER BY id`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var runs []Run
+	for rows.Next() {
+		r, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		r.Output, r.Truncated = "", false
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
+
+// Get returns the run of an ID with its inputs and output
+func (s Store) Get(id int) (Run, error) {
+	db, err := s.open(false)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Run{}, fmt.Errorf("no run %d in the history (no runs recorded)", id)
+	}
+	if err != nil {
+		return Run{}, err
+	}
+	defer db.Close()
+
+	r, err := scanRun(db.QueryRow(`SELECT `+runColumns+` FROM runs WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		var count int
+		db.QueryRow(`SELECT count(*) FROM runs`).Scan(&count)
+		return Run{}, fmt.Errorf("no run %d in the history (%d runs recorded)", id, count)
+	}
+	if err != nil {
+		return Run{}, err
+	}
+
+	rows, err := db.Query(`SELECT flag, path, content, size, omitted FROM inputs WHERE run_id = ? ORDER BY seq`, id)
+	if err != nil {
+		return Run{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var in Input
+		var content sql.NullString
+		if err := rows.Scan(&in.Flag, &in.Path, &content, &in.Size, &in.Omitted); err != nil {
+			return Run{}, err
+		}
+		in.Content = content.String
+		r.Inputs = append(r.Inputs, in)
+	}
+	return r, rows.Err()
+}
+
+// scanRun reads a run from a row of runColumns
+func scanRun(row interface{ Scan(...any) error }) (Run, error) {
+	var r Run
+	var when, args string
+	var configured, output, results sql.NullString
+	err := row.Scan(&r.ID, &when, &r.Version, &r.Command, &args, &r.Dir, &configured, &r.Stdin, &r.Exit,
+		&r.OutputRecorded, &output, &r.Truncated, &results)
+	if err != nil {
+		return Run{}, err
+	}
+	if r.Time, err = time.Parse(time.RFC3339Nano, when); err != nil {
+		return Run{}, fmt.Errorf("run %d: %w", r.ID, err)
+	}
+	if err := json.Unmarshal([]byte(args), &r.Args); err != nil {
+		return Run{}, fmt.Errorf("run %d: %w", r.ID, err)
+	}
+	if configured.Valid {
+		if err := json.Unmarshal([]byte(configured.String), &r.Configured); err != nil {
+			return Run{}, fmt.Errorf("run %d: %w", r.ID, err)
+		}
+	}
+	if results.Valid {
+		if err := json.Unmarshal([]byte(results.String), &r.Results); err != nil {
+			return Run{}, fmt.Errorf("run %d: %w", r.ID, err)
+		}
+	}
+	r.Output = output.String
+	return r, nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,270 @@
+package i18n
+
+// spanish is the Spanish catalog
+var spanish = catalog{
+	// Report titles and headings
+	"Singly Reinforced Beam Design":            "Diseño de Viga con Refuerzo Simple",
+	"Singly Reinforced Beam Analysis":          "Análisis de Viga con Refuerzo Simple",
+	"Doubly Reinforced Beam Design":            "Diseño de Viga con Refuerzo Doble",
+	"Doubly Reinforced Beam Analysis":          "Análisis de Viga con Refuerzo Doble",
+	"Non-Rectangular Section Design":           "Diseño de Sección No Rectangular",
+	"Non-Rectangular Section Analysis":         "Análisis de Sección No Rectangular",
+	"Batch Design Results":                     "Resultados del Diseño por Lotes",
+	"Input Data":                               "Datos de Entrada",
+	"Code Checks":                              "Verificaciones del Código",
+	"Section Analysis":                         "Análisis de la Sección",
+	"Design Result":                            "Resultado del Diseño",
+	"Moment Capacity":                          "Capacidad a Momento",
+	"Summary":                                  "Resumen",
+	"Reinforcement Limits":                     "Límites de Refuerzo",
+	"Reinforcement Limits (Singly Reinforced)": "Límites de Refuerzo (Refuerzo Simple)",
+	"Doubly Reinforced Design":                 "Diseño con Refuerzo Doble",
+	"Members":                                  "Elementos",
+	"Steel Layers":                             "Capas de Acero",
+	"Calculation steps (N, mm, MPa)":           "Pasos de cálculo (N, mm, MPa)",
+	"Suggested Bar Combinations":               "Combinaciones de Barras Sugeridas",
+	"Suggested Tension Bars":                   "Barras de Tracción Sugeridas",
+	"Suggested Compression Bars":               "Barras de Compresión Sugeridas",
+	"Suggested Bars for %s":                    "Barras Sugeridas para %s",
+	"Beams (%s)":                               "Vigas (%s)",
+	"Sections (%s)":                            "Secciones (%s)",
+	"Member %s (%s)":                           "Elemento %s (%s)",
+	"Section %s (%s)":                          "Sección %s (%s)",
+	"Section diagram":                          "Diagrama de la sección",
+	"Strain diagram":                           "Diagrama de deformaciones",
+	"Section diagram of %s":                    "Diagrama de la sección de %s",
+	"Strain diagram of %s":                     "Diagrama de deformaciones de %s",
+
+	// Report layout
+	"Result":                      "Resultado",
+	"PASS":                        "CUMPLE",
+	"FAIL":                        "NO CUMPLE",
+	"steps":                       "pasos",
+	"Sign-off":                    "Firmas",
+	"Prepared by":                 "Elaborado por",
+	"Checked by":                  "Revisado por",
+	"Approved by":                 "Aprobado por",
+	"Signature over printed name": "Firma sobre nombre impreso",
+	"Page %s of %s":               "Página %s de %s",
+
+	// Table headers and field labels
+	"Item":                          "Concepto",
+	"Value":                         "Valor",
+	"Check":                         "Verificación",
+	"Clause":                        "Cláusula",
+	"Limit":                         "Límite",
+	"Status":                        "Estado",
+	"Command":                       "Comando",
+	"Design code":                   "Código de diseño",
+	"Units":                         "Unidades",
+	"Date":                          "Fecha",
+	"Program":                       "Programa",
+	"Project: **%s**":               "Proyecto: **%s**",
+	"Layer":                         "Capa",
+	"Area":                          "Área",
+	"Strain":                        "Deformación",
+	"Stress":                        "Esfuerzo",
+	"Force":                         "Fuerza",
+	"Member":                        "Elemento",
+	"File":                          "Archivo",
+	"Mode":                          "Modo",
+	"Stirrups":                      "Estribos",
+	"Bars":                          "Barras",
+	"Bar":                           "Barra",
+	"As provided":                   "As provisto",
+	"Ratio":                         "Relación",
+	"Mass":                          "Masa",
+	"Mark":                          "Marca",
+	"Rank":                          "Rango",
+	"Section":                       "Sección",
+	"Description":                   "Descripción",
+	"Height":                        "Altura",
+	"Input":                         "Entrada",
+	"Grade":                         "Grado",
+	"Combination":                   "Combinación",
+	"Age (days)":                    "Edad (días)",
+	"Beam width (b)":                "Ancho de la viga (b)",
+	"Beam depth (h)":                "Peralte de la viga (h)",
+	"Effective depth (d)":           "Peralte efectivo (d)",
+	"Concrete cover":                "Recubrimiento",
+	"Tension cover":                 "Recubrimiento a tracción",
+	"Compression cover (d')":        "Recubrimiento a compresión (d')",
+	"Steel grade":                   "Grado del acero",
+	"Factored moment (Mu)":          "Momento último (Mu)",
+	"Section (b × h)":               "Sección (b × h)",
+	"Reinforcement (As)":            "Refuerzo (As)",
+	"Tension steel (As)":            "Acero de tracción (As)",
+	"Compression steel":             "Acero de compresión",
+	"Compression steel (A'sc)":      "Acero de compresión (A'sc)",
+	"Compression block depth (a)":   "Profundidad del bloque de compresión (a)",
+	"Neutral axis depth (c)":        "Profundidad del eje neutro (c)",
+	"Tensile strain (εt)":           "Deformación de tracción (εt)",
+	"Strength reduction factor (φ)": "Factor de reducción de resistencia (φ)",
+	"Cracking moment (Mcr)":         "Momento de agrietamiento (Mcr)",
+	"Cc (concrete compression)":     "Cc (compresión del concreto)",
+	"Cs (compression steel)":        "Cs (acero de compresión)",
+	"T (tension steel)":             "T (acero de tracción)",
+	"Gross area":                    "Área bruta",
+	"Width (max)":                   "Ancho (máx.)",
+	"Width for As,min":              "Ancho para As,min",
+	"Web width (bw)":                "Ancho del alma (bw)",
+	"Tension flange width (bf)":     "Ancho del ala en tracción (bf)",
+	"Governing combination":         "Combinación gobernante",
+	"Governing combinations":        "Combinaciones gobernantes",
+	"λ (concrete type)":             "λ (tipo de concreto)",
+	"ρmax (tension-controlled)":     "ρmax (controlada por tracción)",
+	"c (at ρmax)":                   "c (en ρmax)",
+	"As,max (singly)":               "As,max (refuerzo simple)",
+	"As1 (for Mu1)":                 "As1 (para Mu1)",
+	"As2 (for Mu2)":                 "As2 (para Mu2)",
+	"Mu1 (concrete couple)":         "Mu1 (par del concreto)",
+	"Mu2 (steel couple)":            "Mu2 (par del acero)",
+	"εt (tension steel)":            "εt (acero de tracción)",
+	"ε'sc (compression steel)":      "ε'sc (acero de compresión)",
+	"fs (tension)":                  "fs (tracción)",
+	"f'sc (compression)":            "f'sc (compresión)",
+	"design":                        "diseño",
+	"analysis":                      "análisis",
+	"Tension":                       "Tracción",
+	"Compression":                   "Compresión",
+	"%s (yields)":                   "%s (fluye)",
+	"%s (fractured)":                "%s (fracturada)",
+	"Transition zone":               "Zona de transición",
+
+	// Code checks
+	"Design strength (φMn ≥ Mu)":      "Resistencia de diseño (φMn ≥ Mu)",
+	"Maximum steel (ρ ≤ ρmax)":        "Acero máximo (ρ ≤ ρmax)",
+	"Minimum steel (As ≥ As,min)":     "Acero mínimo (As ≥ As,min)",
+	"Minimum steel (ρ ≥ ρmin)":        "Acero mínimo (ρ ≥ ρmin)",
+	"Shear strength (φVn ≥ Vu)":       "Resistencia a cortante (φVn ≥ Vu)",
+	"Tension-controlled (εt ≥ εt,tc)": "Controlada por tracción (εt ≥ εt,tc)",
+
+	// Results
+	"**%s** - %s":                               "**%s** - %s",
+	"**Error:** %s":                             "**Error:** %s",
+	"**Design not adequate.**":                  "**El diseño no es adecuado.**",
+	"Shear: %s":                                 "Cortante: %s",
+	"%s of %s members adequate":                 "%s de %s elementos adecuados",
+	"%s of %s members adequate.":                "%s de %s elementos adecuados.",
+	"%s of %s members of %s adequate.":          "%s de %s elementos de %s adecuados.",
+	"**Required As = %s**, φMn = %s ≥ Mu = %s.": "**As requerido = %s**, φMn = %s ≥ Mu = %s.",
+	"**Required tension steel As = %s**, φMn = %s ≥ Mu = %s.":                  "**Acero de tracción requerido As = %s**, φMn = %s ≥ Mu = %s.",
+	"**Tension steel As = %s**":                                                "**Acero de tracción As = %s**",
+	"%s, **compression steel A'sc = %s**":                                      "%s, **acero de compresión A'sc = %s**",
+	"%s; φMn = %s ≥ Mu = %s (φ = %s).":                                         "%s; φMn = %s ≥ Mu = %s (φ = %s).",
+	"As by analysis is %s; 4/3·As = %s may be provided in lieu of As,min.":     "El As por análisis es %s; se puede proveer 4/3·As = %s en lugar de As,min.",
+	"Singly reinforced section is adequate; no compression steel is required.": "La sección con refuerzo simple es adecuada; no se requiere acero de compresión.",
+
+	// Messages of the design engines
+	"Singly reinforced design is adequate":                                           "El diseño con refuerzo simple es adecuado",
+	"Design OK - Section is tension-controlled":                                      "Diseño adecuado - Sección controlada por tracción",
+	"Design OK - Section is in transition zone":                                      "Diseño adecuado - Sección en zona de transición",
+	"Design inadequate - Consider increasing section size":                           "Diseño inadecuado - Considere aumentar la sección",
+	"Design inadequate - Section cannot resist the required moment":                  "Diseño inadecuado - La sección no resiste el momento requerido",
+	"Doubly reinforced design OK - Compression steel yields":                         "Diseño con refuerzo doble adecuado - El acero de compresión fluye",
+	"Doubly reinforced design OK - Compression steel does not yield (f'sc = %s MPa)": "Diseño con refuerzo doble adecuado - El acero de compresión no fluye (f'sc = %s MPa)",
+	"Section inadequate - moment too high for singly reinforced design":              "Sección inadecuada - momento demasiado alto para refuerzo simple",
+	"Section inadequate for singly reinforced design. Mu=%s kN-m > φMn,max=%s kN-m. Consider increasing section size or using doubly reinforced design.": "Sección inadecuada para refuerzo simple. Mu=%s kN-m > φMn,max=%s kN-m. Considere aumentar la sección o usar refuerzo doble.",
+	"Section is tension-controlled (εt ≥ %s)":                                        "Sección controlada por tracción (εt ≥ %s)",
+	"Section is in transition zone":                                                  "Sección en zona de transición",
+	"Section is compression-controlled":                                              "Sección controlada por compresión",
+	"Section is compression-controlled (εt < εy)":                                    "Sección controlada por compresión (εt < εy)",
+	"φMn < Mu | %s":                                                                  "φMn < Mu | %s",
+	"Vu ≤ φVc/2, stirrups not required by strength":                                  "Vu ≤ φVc/2, no se requieren estribos por resistencia",
+	"%s-leg φ%smm stirrups @ %s mm":                                                  "Estribos de %s ramas φ%smm @ %s mm",
+	"Required stirrup spacing %s mm is impractical, use larger or more stirrup legs": "La separación requerida de estribos de %s mm no es práctica, use estribos mayores o más ramas",
+	"Section too small for shear: Vs = %s kN > Vs,max = %s kN, increase the section": "Sección insuficiente a cortante: Vs = %s kN > Vs,max = %s kN, aumente la sección",
+
+	// Calculation steps
+	"Effective depth":                            "Peralte efectivo",
+	"Stress block intensity":                     "Intensidad del bloque de esfuerzos",
+	"Stress block depth factor":                  "Factor de profundidad del bloque de esfuerzos",
+	"Minimum reinforcement":                      "Refuerzo mínimo",
+	"Maximum reinforcement (tension-controlled)": "Refuerzo máximo (controlada por tracción)",
+	"Assumed strength reduction factor":          "Factor de reducción de resistencia supuesto",
+	"Coefficient of resistance":                  "Coeficiente de resistencia",
+	"Required steel ratio":                       "Cuantía de acero requerida",
+	"Provided steel ratio":                       "Cuantía de acero provista",
+	"Required tension steel":                     "Acero de tracción requerido",
+	"Singly reinforced limit":                    "Límite del refuerzo simple",
+	"Depth of compression block (T = C)":         "Profundidad del bloque de compresión (T = C)",
+	"Neutral axis depth":                         "Profundidad del eje neutro",
+	"Net tensile strain":                         "Deformación neta de tracción",
+	"Strength reduction factor":                  "Factor de reducción de resistencia",
+	"Design strength":                            "Resistencia de diseño",
+
+	// Warnings
+	"%s clear cover %s is less than the %s required for %s exposure": "El recubrimiento libre %s de %s es menor que el %s requerido para la exposición %s",
+
+	// Diagrams
+	"Beam Section Analysis":                "Análisis de la sección de la viga",
+	"Strain Distribution":                  "Distribución de deformaciones",
+	"Strain (compression positive)":        "Deformación (compresión positiva)",
+	"Stress, compression positive":         "Esfuerzo, compresión positiva",
+	"Stress Block and Forces":              "Bloque de esfuerzos y fuerzas",
+	"P-M Interaction Diagram":              "Diagrama de interacción P-M",
+	"Biaxial Interaction φMnx-φMny":        "Interacción biaxial φMnx-φMny",
+	"Biaxial Bending":                      "Flexión biaxial",
+	"Fiber Stresses":                       "Esfuerzos en las fibras",
+	"Fiber Strains":                        "Deformaciones en las fibras",
+	"Shear Demand and Capacity":            "Demanda y capacidad a cortante",
+	"Shear Force Diagram":                  "Diagrama de fuerza cortante",
+	"Bending Moment Diagram":               "Diagrama de momento flector",
+	"Moment Envelope":                      "Envolvente de momentos",
+	"Elevation and Loads":                  "Elevación y cargas",
+	"Bar Cutoffs":                          "Corte de barras",
+	"Stirrup Zones":                        "Zonas de estribos",
+	"Deflection":                           "Deflexión",
+	"Width":                                "Ancho",
+	"Depth from top":                       "Profundidad desde la cara superior",
+	"Moment":                               "Momento",
+	"Moment, sagging positive":             "Momento, positivo con tracción abajo",
+	"Shear":                                "Cortante",
+	"Axial force, compression positive":    "Fuerza axial, compresión positiva",
+	"φMnx, top in compression":             "φMnx, cara superior en compresión",
+	"φMny, right in compression":           "φMny, cara derecha en compresión",
+	"Position along the beam":              "Posición a lo largo de la viga",
+	"Position along the span":              "Posición a lo largo del claro",
+	"Position from the fixed end":          "Posición desde el empotramiento",
+	"Deflection, downward":                 "Deflexión, hacia abajo",
+	"Iteration":                            "Iteración",
+	"Imbalance":                            "Desequilibrio",
+	"Maximum":                              "Máximo",
+	"Minimum":                              "Mínimo",
+	"Combinations":                         "Combinaciones",
+	"Combination %s":                       "Combinación %s",
+	"Envelope":                             "Envolvente",
+	"Envelope, maximum":                    "Envolvente, máxima",
+	"Envelope, minimum":                    "Envolvente, mínima",
+	"Minimum stirrups (φVc/2 < %s ≤ φVc)":  "Estribos mínimos (φVc/2 < %s ≤ φVc)",
+	"Stirrups may be omitted (%s ≤ φVc/2)": "Se pueden omitir los estribos (%s ≤ φVc/2)",
+	"Immediate (Ma)":                       "Inmediata (Ma)",
+	"Immediate live load":                  "Inmediata por carga viva",
+	"Long-term":                            "A largo plazo",
+	"N.A.":                                 "E.N.",
+	"none":                                 "ninguno",
+	"clear cover":                          "recubrimiento libre",
+	"lap %s":                               "empalme %s",
+	"%s continuous":                        "%s continuas",
+	"ld = %s top, %s bottom":               "ld = %s superior, %s inferior",
+	"s = %s %s %s max":                     "s = %s %s %s máx.",
+	"Iteration %s (%s): c = %s":            "Iteración %s (%s): c = %s",
+	"N.A., c = %s":                         "E.N., c = %s",
+	"Force imbalance T − (Cc + Cs) = %s":   "Desequilibrio de fuerzas T − (Cc + Cs) = %s",
+	"damped":                               "amortiguada",
+	"bisection":                            "bisección",
+	"Steel ratio ρ = As/bd":                "Cuantía de acero ρ = As/bd",
+	"Net tensile strain εt":                "Deformación neta de tracción εt",
+	"Strength reduction factor φ":          "Factor de reducción de resistencia φ",
+	"Compression-\ncontrolled":             "Controlada por\ncompresión",
+	"Transition":                           "Transición",
+	"Tension-controlled":                   "Controlada por tracción",
+	"Tied":                                 "Con estribos",
+	"Spiral":                               "Con espiral",
+	"ρt (tension-controlled)":              "ρt (controlada por tracción)",
+	"Section: εt = %s, φ = %s":             "Sección: εt = %s, φ = %s",
+	"Self-weight":                          "Peso propio",
+	"Steel":                                "Acero",
+	"Cost":                                 "Costo",
+	"%s per %s m":                          "%s por %s m",
+}
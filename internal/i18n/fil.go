@@ -0,0 +1,260 @@
+package i18n
+
+// filipino is the Filipino catalog. Terms without a common Filipino usage in
+// structural practice (moment, reinforcement, singly/doubly reinforced) are
+// kept in English, as in local design offices.
+var filipino = catalog{
+	// Report titles and headings
+	"Singly Reinforced Beam Design":            "Disenyo ng Singly Reinforced na Biga",
+	"Singly Reinforced Beam Analysis":          "Pagsusuri ng Singly Reinforced na Biga",
+	"Doubly Reinforced Beam Design":            "Disenyo ng Doubly Reinforced na Biga",
+	"Doubly Reinforced Beam Analysis":          "Pagsusuri ng Doubly Reinforced na Biga",
+	"Non-Rectangular Section Design":           "Disenyo ng Hindi Parihabang Seksyon",
+	"Non-Rectangular Section Analysis":         "Pagsusuri ng Hindi Parihabang Seksyon",
+	"Batch Design Results":                     "Mga Resulta ng Batch na Disenyo",
+	"Input Data":                               "Mga Datos na Ibinigay",
+	"Code Checks":                              "Mga Pagsusuri ayon sa Code",
+	"Section Analysis":                         "Pagsusuri ng Seksyon",
+	"Design Result":                            "Resulta ng Disenyo",
+	"Moment Capacity":                          "Kapasidad sa Moment",
+	"Summary":                                  "Buod",
+	"Reinforcement Limits":                     "Mga Hangganan ng Reinforcement",
+	"Reinforcement Limits (Singly Reinforced)": "Mga Hangganan ng Reinforcement (Singly Reinforced)",
+	"Doubly Reinforced Design":                 "Disenyong Doubly Reinforced",
+	"Members":                                  "Mga Miyembro",
+	"Steel Layers":                             "Mga Patong ng Bakal",
+	"Calculation steps (N, mm, MPa)":           "Mga hakbang ng kalkulasyon (N, mm, MPa)",
+	"Suggested Bar Combinations":               "Mga Iminumungkahing Kombinasyon ng Bakal",
+	"Suggested Tension Bars":                   "Mga Iminumungkahing Bakal sa Tension",
+	"Suggested Compression Bars":               "Mga Iminumungkahing Bakal sa Compression",
+	"Suggested Bars for %s":                    "Mga Iminumungkahing Bakal para sa %s",
+	"Beams (%s)":                               "Mga Biga (%s)",
+	"Sections (%s)":                            "Mga Seksyon (%s)",
+	"Member %s (%s)":                           "Miyembro %s (%s)",
+	"Section %s (%s)":                          "Seksyon %s (%s)",
+	"Section diagram":                          "Dayagram ng seksyon",
+	"Strain diagram":                           "Dayagram ng strain",
+	"Section diagram of %s":                    "Dayagram ng seksyon ng %s",
+	"Strain diagram of %s":                     "Dayagram ng strain ng %s",
+
+	// Report layout
+	"Result":                      "Resulta",
+	"PASS":                        "PASADO",
+	"FAIL":                        "HINDI PASADO",
+	"steps":                       "hakbang",
+	"Sign-off":                    "Paglagda",
+	"Prepared by":                 "Inihanda ni",
+	"Checked by":                  "Sinuri ni",
+	"Approved by":                 "Inaprubahan ni",
+	"Signature over printed name": "Lagda sa itaas ng pangalan",
+	"Page %s of %s":               "Pahina %s ng %s",
+
+	// Table headers and field labels
+	"Item":                          "Aytem",
+	"Value":                         "Halaga",
+	"Check":                         "Pagsusuri",
+	"Clause":                        "Probisyon",
+	"Limit":                         "Hangganan",
+	"Status":                        "Katayuan",
+	"Command":                       "Utos",
+	"Design code":                   "Code ng disenyo",
+	"Units":                         "Mga yunit",
+	"Date":                          "Petsa",
+	"Program":                       "Programa",
+	"Project: **%s**":               "Proyekto: **%s**",
+	"Layer":                         "Patong",
+	"Area":                          "Sukat",
+	"Member":                        "Miyembro",
+	"File":                          "File",
+	"Mode":                          "Uri",
+	"Stirrups":                      "Mga stirrup",
+	"Bars":                          "Mga bakal",
+	"Bar":                           "Bakal",
+	"As provided":                   "As na inilagay",
+	"Ratio":                         "Ratio",
+	"Mass":                          "Bigat",
+	"Mark":                          "Marka",
+	"Rank":                          "Ranggo",
+	"Section":                       "Seksyon",
+	"Description":                   "Paglalarawan",
+	"Height":                        "Taas",
+	"Input":                         "Ibinigay",
+	"Combination":                   "Kombinasyon",
+	"Age (days)":                    "Edad (araw)",
+	"Beam width (b)":                "Lapad ng biga (b)",
+	"Beam depth (h)":                "Lalim ng biga (h)",
+	"Effective depth (d)":           "Epektibong lalim (d)",
+	"Concrete cover":                "Takip ng konkreto",
+	"Tension cover":                 "Takip sa tension",
+	"Compression cover (d')":        "Takip sa compression (d')",
+	"Steel grade":                   "Grado ng bakal",
+	"Grade":                         "Grado",
+	"Factored moment (Mu)":          "Factored na moment (Mu)",
+	"Section (b × h)":               "Seksyon (b × h)",
+	"Reinforcement (As)":            "Reinforcement (As)",
+	"Tension steel (As)":            "Bakal sa tension (As)",
+	"Compression steel":             "Bakal sa compression",
+	"Compression steel (A'sc)":      "Bakal sa compression (A'sc)",
+	"Compression block depth (a)":   "Lalim ng compression block (a)",
+	"Neutral axis depth (c)":        "Lalim ng neutral axis (c)",
+	"Tensile strain (εt)":           "Tensile strain (εt)",
+	"Strength reduction factor (φ)": "Strength reduction factor (φ)",
+	"Cracking moment (Mcr)":         "Cracking moment (Mcr)",
+	"Cc (concrete compression)":     "Cc (compression ng konkreto)",
+	"Cs (compression steel)":        "Cs (bakal sa compression)",
+	"T (tension steel)":             "T (bakal sa tension)",
+	"Gross area":                    "Kabuuang sukat",
+	"Width (max)":                   "Lapad (pinakamalaki)",
+	"Width for As,min":              "Lapad para sa As,min",
+	"Web width (bw)":                "Lapad ng web (bw)",
+	"Tension flange width (bf)":     "Lapad ng flange sa tension (bf)",
+	"Governing combination":         "Nananaig na kombinasyon",
+	"Governing combinations":        "Mga nananaig na kombinasyon",
+	"λ (concrete type)":             "λ (uri ng konkreto)",
+	"ρmax (tension-controlled)":     "ρmax (tension-controlled)",
+	"c (at ρmax)":                   "c (sa ρmax)",
+	"As1 (for Mu1)":                 "As1 (para sa Mu1)",
+	"As2 (for Mu2)":                 "As2 (para sa Mu2)",
+	"Mu1 (concrete couple)":         "Mu1 (couple ng konkreto)",
+	"Mu2 (steel couple)":            "Mu2 (couple ng bakal)",
+	"εt (tension steel)":            "εt (bakal sa tension)",
+	"ε'sc (compression steel)":      "ε'sc (bakal sa compression)",
+	"design":                        "disenyo",
+	"analysis":                      "pagsusuri",
+	"Transition zone":               "Transition zone",
+
+	// Code checks
+	"Design strength (φMn ≥ Mu)":      "Lakas ng disenyo (φMn ≥ Mu)",
+	"Maximum steel (ρ ≤ ρmax)":        "Pinakamaraming bakal (ρ ≤ ρmax)",
+	"Minimum steel (As ≥ As,min)":     "Pinakakaunting bakal (As ≥ As,min)",
+	"Minimum steel (ρ ≥ ρmin)":        "Pinakakaunting bakal (ρ ≥ ρmin)",
+	"Shear strength (φVn ≥ Vu)":       "Lakas sa shear (φVn ≥ Vu)",
+	"Tension-controlled (εt ≥ εt,tc)": "Tension-controlled (εt ≥ εt,tc)",
+
+	// Results
+	"**%s** - %s":                               "**%s** - %s",
+	"**Error:** %s":                             "**Mali:** %s",
+	"**Design not adequate.**":                  "**Hindi sapat ang disenyo.**",
+	"Shear: %s":                                 "Shear: %s",
+	"%s of %s members adequate":                 "%s sa %s miyembro ang sapat",
+	"%s of %s members adequate.":                "%s sa %s miyembro ang sapat.",
+	"%s of %s members of %s adequate.":          "%s sa %s miyembro ng %s ang sapat.",
+	"**Required As = %s**, φMn = %s ≥ Mu = %s.": "**Kailangang As = %s**, φMn = %s ≥ Mu = %s.",
+	"**Required tension steel As = %s**, φMn = %s ≥ Mu = %s.":                  "**Kailangang bakal sa tension As = %s**, φMn = %s ≥ Mu = %s.",
+	"**Tension steel As = %s**":                                                "**Bakal sa tension As = %s**",
+	"%s, **compression steel A'sc = %s**":                                      "%s, **bakal sa compression A'sc = %s**",
+	"%s; φMn = %s ≥ Mu = %s (φ = %s).":                                         "%s; φMn = %s ≥ Mu = %s (φ = %s).",
+	"As by analysis is %s; 4/3·As = %s may be provided in lieu of As,min.":     "Ang As ayon sa pagsusuri ay %s; maaaring ilagay ang 4/3·As = %s sa halip na As,min.",
+	"Singly reinforced section is adequate; no compression steel is required.": "Sapat ang singly reinforced na seksyon; hindi kailangan ng bakal sa compression.",
+
+	// Messages of the design engines
+	"Singly reinforced design is adequate":                                           "Sapat ang singly reinforced na disenyo",
+	"Design OK - Section is tension-controlled":                                      "Pasado ang disenyo - Tension-controlled ang seksyon",
+	"Design OK - Section is in transition zone":                                      "Pasado ang disenyo - Nasa transition zone ang seksyon",
+	"Design inadequate - Consider increasing section size":                           "Hindi sapat ang disenyo - Palakihin ang seksyon",
+	"Design inadequate - Section cannot resist the required moment":                  "Hindi sapat ang disenyo - Hindi kaya ng seksyon ang kailangang moment",
+	"Doubly reinforced design OK - Compression steel yields":                         "Pasado ang doubly reinforced na disenyo - Nagye-yield ang bakal sa compression",
+	"Doubly reinforced design OK - Compression steel does not yield (f'sc = %s MPa)": "Pasado ang doubly reinforced na disenyo - Hindi nagye-yield ang bakal sa compression (f'sc = %s MPa)",
+	"Section inadequate - moment too high for singly reinforced design":              "Hindi sapat ang seksyon - masyadong malaki ang moment para sa singly reinforced na disenyo",
+	"Section inadequate for singly reinforced design. Mu=%s kN-m > φMn,max=%s kN-m. Consider increasing section size or using doubly reinforced design.": "Hindi sapat ang seksyon para sa singly reinforced na disenyo. Mu=%s kN-m > φMn,max=%s kN-m. Palakihin ang seksyon o gumamit ng doubly reinforced na disenyo.",
+	"Section is tension-controlled (εt ≥ %s)":                                        "Tension-controlled ang seksyon (εt ≥ %s)",
+	"Section is in transition zone":                                                  "Nasa transition zone ang seksyon",
+	"Section is compression-controlled":                                              "Compression-controlled ang seksyon",
+	"Section is compression-controlled (εt < εy)":                                    "Compression-controlled ang seksyon (εt < εy)",
+	"φMn < Mu | %s":                                                                  "φMn < Mu | %s",
+	"Vu ≤ φVc/2, stirrups not required by strength":                                  "Vu ≤ φVc/2, hindi kailangan ng stirrup ayon sa lakas",
+	"%s-leg φ%smm stirrups @ %s mm":                                                  "%s-leg na φ%smm na stirrup @ %s mm",
+	"Required stirrup spacing %s mm is impractical, use larger or more stirrup legs": "Hindi praktikal ang kailangang pagitan ng stirrup na %s mm, gumamit ng mas malaki o mas maraming leg",
+	"Section too small for shear: Vs = %s kN > Vs,max = %s kN, increase the section": "Masyadong maliit ang seksyon para sa shear: Vs = %s kN > Vs,max = %s kN, palakihin ang seksyon",
+
+	// Calculation steps
+	"Effective depth":                            "Epektibong lalim",
+	"Stress block intensity":                     "Tindi ng stress block",
+	"Stress block depth factor":                  "Factor ng lalim ng stress block",
+	"Minimum reinforcement":                      "Pinakakaunting reinforcement",
+	"Maximum reinforcement (tension-controlled)": "Pinakamaraming reinforcement (tension-controlled)",
+	"Assumed strength reduction factor":          "Ipinagpalagay na strength reduction factor",
+	"Coefficient of resistance":                  "Coefficient of resistance",
+	"Required steel ratio":                       "Kailangang steel ratio",
+	"Provided steel ratio":                       "Steel ratio na inilagay",
+	"Required tension steel":                     "Kailangang bakal sa tension",
+	"Singly reinforced limit":                    "Hangganan ng singly reinforced",
+	"Depth of compression block (T = C)":         "Lalim ng compression block (T = C)",
+	"Neutral axis depth":                         "Lalim ng neutral axis",
+	"Net tensile strain":                         "Net tensile strain",
+	"Strength reduction factor":                  "Strength reduction factor",
+	"Design strength":                            "Lakas ng disenyo",
+
+	// Warnings
+	"%s clear cover %s is less than the %s required for %s exposure": "Ang clear cover sa %s na %s ay kulang sa %s na kailangan para sa %s na exposure",
+
+	// Diagrams
+	"Beam Section Analysis":                "Pagsusuri ng Seksyon ng Biga",
+	"Strain Distribution":                  "Distribusyon ng Strain",
+	"Strain (compression positive)":        "Strain (positibo ang compression)",
+	"Stress, compression positive":         "Stress, positibo ang compression",
+	"Stress Block and Forces":              "Stress Block at mga Puwersa",
+	"P-M Interaction Diagram":              "Dayagram ng P-M Interaction",
+	"Biaxial Interaction φMnx-φMny":        "Biaxial na Interaction φMnx-φMny",
+	"Biaxial Bending":                      "Biaxial na Bending",
+	"Fiber Stresses":                       "Stress ng mga Fiber",
+	"Fiber Strains":                        "Strain ng mga Fiber",
+	"Shear Demand and Capacity":            "Demand at Kapasidad sa Shear",
+	"Shear Force Diagram":                  "Dayagram ng Shear",
+	"Bending Moment Diagram":               "Dayagram ng Moment",
+	"Moment Envelope":                      "Envelope ng Moment",
+	"Elevation and Loads":                  "Elebasyon at mga Karga",
+	"Bar Cutoffs":                          "Mga Putol ng Bakal",
+	"Stirrup Zones":                        "Mga Sona ng Stirrup",
+	"Deflection":                           "Deflection",
+	"Width":                                "Lapad",
+	"Depth from top":                       "Lalim mula sa itaas",
+	"Moment":                               "Moment",
+	"Moment, sagging positive":             "Moment, positibo ang sagging",
+	"Shear":                                "Shear",
+	"Axial force, compression positive":    "Axial na puwersa, positibo ang compression",
+	"φMnx, top in compression":             "φMnx, nasa compression ang itaas",
+	"φMny, right in compression":           "φMny, nasa compression ang kanan",
+	"Position along the beam":              "Posisyon sa haba ng biga",
+	"Position along the span":              "Posisyon sa haba ng span",
+	"Position from the fixed end":          "Posisyon mula sa nakapirming dulo",
+	"Deflection, downward":                 "Deflection, pababa",
+	"Iteration":                            "Iterasyon",
+	"Imbalance":                            "Kawalan ng balanse",
+	"Maximum":                              "Pinakamataas",
+	"Minimum":                              "Pinakamababa",
+	"Combinations":                         "Mga Kombinasyon",
+	"Combination %s":                       "Kombinasyon %s",
+	"Envelope":                             "Envelope",
+	"Envelope, maximum":                    "Envelope, pinakamataas",
+	"Envelope, minimum":                    "Envelope, pinakamababa",
+	"Minimum stirrups (φVc/2 < %s ≤ φVc)":  "Pinakakaunting stirrup (φVc/2 < %s ≤ φVc)",
+	"Stirrups may be omitted (%s ≤ φVc/2)": "Maaaring walang stirrup (%s ≤ φVc/2)",
+	"Immediate (Ma)":                       "Agaran (Ma)",
+	"Immediate live load":                  "Agaran sa live load",
+	"Long-term":                            "Pangmatagalan",
+	"none":                                 "wala",
+	"clear cover":                          "clear cover",
+	"lap %s":                               "lap %s",
+	"%s continuous":                        "%s tuloy-tuloy",
+	"ld = %s top, %s bottom":               "ld = %s itaas, %s ibaba",
+	"s = %s %s %s max":                     "s = %s %s %s max",
+	"Iteration %s (%s): c = %s":            "Iterasyon %s (%s): c = %s",
+	"N.A., c = %s":                         "N.A., c = %s",
+	"Force imbalance T − (Cc + Cs) = %s":   "Kawalan ng balanse ng puwersa T − (Cc + Cs) = %s",
+	"damped":                               "damped",
+	"bisection":                            "bisection",
+	"Steel ratio ρ = As/bd":                "Steel ratio ρ = As/bd",
+	"Net tensile strain εt":                "Net tensile strain εt",
+	"Strength reduction factor φ":          "Strength reduction factor φ",
+	"Compression-\ncontrolled":             "Compression-\ncontrolled",
+	"Transition":                           "Transition",
+	"Tension-controlled":                   "Tension-controlled",
+	"Tied":                                 "May tie",
+	"Spiral":                               "May spiral",
+	"Section: εt = %s, φ = %s":             "Seksyon: εt = %s, φ = %s",
+	"Self-weight":                          "Sariling bigat",
+	"Steel":                                "Bakal",
+	"Cost":                                 "Gastos",
+	"%s per %s m":                          "%s bawat %s m",
+}
@@ -0,0 +1,119 @@
+// Package i18n translates the headings, labels, messages and warnings of
+// reports and printed results into the language selected with --lang.
+//
+// Catalogs are keyed by the English text. A key may contain %s for any
+// text, e.g. "Shear: %s", so that messages with values are translated; the
+// text matched by each %s is itself translated and substituted, in order,
+// into the %s of the translation. Text without a translation is returned
+// unchanged.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// English is the language of the program text, used when none is selected
+const English = "en"
+
+// Languages are the codes of the supported languages
+var Languages = []string{English, "fil", "es"}
+
+// Names are the names of the supported languages by code
+var Names = map[string]string{
+	English: "English",
+	"fil":   "Filipino",
+	"es":    "Spanish",
+}
+
+// catalog maps English text to its translation
+type catalog map[string]string
+
+var catalogs = map[string]catalog{
+	"fil": filipino,
+	"es":  spanish,
+}
+
+// pattern is a catalog key with %s wildcards
+type pattern struct {
+	re          *regexp.Regexp
+	translation string
+}
+
+var (
+	lang     = English
+	patterns []pattern
+)
+
+// Set selects the language of the translations
+func Set(code string) error {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == English || code == "" {
+		lang, patterns = English, nil
+		return nil
+	}
+	c, ok := catalogs[code]
+	if !ok {
+		return fmt.Errorf("unknown language %q (use %s)", code, strings.Join(Languages, ", "))
+	}
+	lang, patterns = code, compile(c)
+	return nil
+}
+
+// Lang returns the code of the selected language
+func Lang() string {
+	return lang
+}
+
+// T returns the translation of text in the selected language
+func T(text string) string {
+	if lang == English || text == "" {
+		return text
+	}
+	if t, ok := catalogs[lang][text]; ok {
+		return t
+	}
+	for _, p := range patterns {
+		m := p.re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		parts := strings.Split(p.translation, "%s")
+		var sb strings.Builder
+		for i, part := range parts {
+			sb.WriteString(part)
+			if i+1 < len(parts) && i+1 < len(m) {
+				sb.WriteString(T(m[i+1]))
+			}
+		}
+		return sb.String()
+	}
+	return text
+}
+
+// compile returns the wildcard keys of a catalog, the most specific (the
+// longest) first
+func compile(c catalog) []pattern {
+	var ps []pattern
+	for key, translation := range c {
+		if !strings.Contains(key, "%s") {
+			continue
+		}
+		parts := strings.Split(key, "%s")
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		re := regexp.MustCompile("^" + strings.Join(parts, "(.+?)") + "$")
+		ps = append(ps, pattern{re: re, translation: translation})
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		a, b := ps[i].re.String(), ps[j].re.String()
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	return ps
+}
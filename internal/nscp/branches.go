@@ -0,0 +1,256 @@
+package nscp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoadType identifies a load in a combination
+type LoadType string
+
+const (
+	LoadDead        LoadType = "D"
+	LoadLive        LoadType = "L"
+	LoadRoof        LoadType = "Lr"
+	LoadWind        LoadType = "W"
+	LoadEarthquake  LoadType = "E"
+	LoadRain        LoadType = "R"
+	LoadSnow        LoadType = "S"
+	LoadFluid       LoadType = "F"
+	LoadEarth       LoadType = "H"
+	LoadTemperature LoadType = "T"
+)
+
+// LoadTypes lists all load types in display order
+var LoadTypes = []LoadType{LoadDead, LoadLive, LoadRoof, LoadWind, LoadEarthquake, LoadRain, LoadSnow, LoadFluid, LoadEarth, LoadTemperature}
+
+// IsReversible reports whether the load acts in either direction (±)
+func (t LoadType) IsReversible() bool {
+	return t == LoadWind || t == LoadEarthquake
+}
+
+// Factor returns the load factor of the given load type
+func (lc LoadCombination) Factor(t LoadType) float64 {
+	switch t {
+	case LoadDead:
+		return lc.Dead
+	case LoadLive:
+		return lc.Live
+	case LoadRoof:
+		return lc.Roof
+	case LoadWind:
+		return lc.Wind
+	case LoadEarthquake:
+		return lc.Earthquake
+	case LoadRain:
+		return lc.Rain
+	case LoadSnow:
+		return lc.Snow
+	case LoadFluid:
+		return lc.Fluid
+	case LoadEarth:
+		return lc.Earth
+	case LoadTemperature:
+		return lc.Temperature
+	}
+	return 0
+}
+
+// Moment returns the unfactored moment of the given load type
+func (m LoadMoments) Moment(t LoadType) float64 {
+	switch t {
+	case LoadDead:
+		return m.Dead
+	case LoadLive:
+		return m.Live
+	case LoadRoof:
+		return m.Roof
+	case LoadWind:
+		return m.Wind
+	case LoadEarthquake:
+		return m.Earthquake
+	case LoadRain:
+		return m.Rain
+	case LoadSnow:
+		return m.Snow
+	case LoadFluid:
+		return m.Fluid
+	case LoadEarth:
+		return m.Earth
+	case LoadTemperature:
+		return m.Temperature
+	}
+	return 0
+}
+
+// Set sets the unfactored moment of the given load type
+func (m *LoadMoments) Set(t LoadType, value float64) {
+	switch t {
+	case LoadDead:
+		m.Dead = value
+	case LoadLive:
+		m.Live = value
+	case LoadRoof:
+		m.Roof = value
+	case LoadWind:
+		m.Wind = value
+	case LoadEarthquake:
+		m.Earthquake = value
+	case LoadRain:
+		m.Rain = value
+	case LoadSnow:
+		m.Snow = value
+	case LoadFluid:
+		m.Fluid = value
+	case LoadEarth:
+		m.Earth = value
+	case LoadTemperature:
+		m.Temperature = value
+	}
+}
+
+// ValidateAlternatives checks that the "or" groups name known load types
+// that have a factor in the combination and appear in one group only
+func (lc LoadCombination) ValidateAlternatives() error {
+	seen := make(map[LoadType]bool)
+	for _, group := range lc.Alternatives {
+		if len(group) < 2 {
+			return fmt.Errorf("combination %q: an alternative group needs at least two loads", lc.ID)
+		}
+		for _, t := range group {
+			known := false
+			for _, k := range LoadTypes {
+				if t == k {
+					known = true
+				}
+			}
+			if !known {
+				return fmt.Errorf("combination %q: unknown load type %q in alternatives", lc.ID, t)
+			}
+			if seen[t] {
+				return fmt.Errorf("combination %q: load %s appears in more than one alternative group", lc.ID, t)
+			}
+			seen[t] = true
+		}
+	}
+	return nil
+}
+
+// CombinationBranch is one evaluated case of a load combination: one load
+// chosen from each "or" group and a direction for each reversible load
+type CombinationBranch struct {
+	Loads  []LoadType           // Loads applied in this branch
+	Signs  map[LoadType]float64 // +1 or -1 for reversible loads
+	Moment float64              // Factored moment (kN-m)
+}
+
+// Describe returns a short label of the choices made in the branch, e.g. "Lr, -W"
+func (b CombinationBranch) Describe() string {
+	var parts []string
+	for _, t := range b.Loads {
+		sign, ok := b.Signs[t]
+		switch {
+		case ok && sign < 0:
+			parts = append(parts, "-"+string(t))
+		case ok:
+			parts = append(parts, "+"+string(t))
+		default:
+			parts = append(parts, string(t))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Branches evaluates every branch of the combination: each load of an "or"
+// group in turn (loads outside groups always apply) and both directions of
+// wind and earthquake. Loads with zero factor or zero moment are skipped.
+func (lc LoadCombination) Branches(moments LoadMoments) []CombinationBranch {
+	grouped := make(map[LoadType]bool)
+	for _, group := range lc.Alternatives {
+		for _, t := range group {
+			grouped[t] = true
+		}
+	}
+
+	active := func(t LoadType) bool {
+		return lc.Factor(t) != 0 && moments.Moment(t) != 0
+	}
+
+	// Start with the loads that always apply
+	var always []LoadType
+	for _, t := range LoadTypes {
+		if !grouped[t] && active(t) {
+			always = append(always, t)
+		}
+	}
+	selections := [][]LoadType{always}
+
+	// Pick one load from each alternative group
+	for _, group := range lc.Alternatives {
+		var options []LoadType
+		for _, t := range group {
+			if active(t) {
+				options = append(options, t)
+			}
+		}
+		if len(options) == 0 {
+			continue
+		}
+		var next [][]LoadType
+		for _, sel := range selections {
+			for _, t := range options {
+				next = append(next, append(append([]LoadType(nil), sel...), t))
+			}
+		}
+		selections = next
+	}
+
+	// Apply both directions of each reversible load
+	var branches []CombinationBranch
+	for _, sel := range selections {
+		var reversible []LoadType
+		for _, t := range sel {
+			if t.IsReversible() {
+				reversible = append(reversible, t)
+			}
+		}
+		for mask := 0; mask < 1<<len(reversible); mask++ {
+			b := CombinationBranch{Loads: sel, Signs: make(map[LoadType]float64)}
+			for i, t := range reversible {
+				b.Signs[t] = 1
+				if mask&(1<<i) != 0 {
+					b.Signs[t] = -1
+				}
+			}
+			for _, t := range sel {
+				sign := 1.0
+				if s, ok := b.Signs[t]; ok {
+					sign = s
+				}
+				b.Moment += sign * lc.Factor(t) * moments.Moment(t)
+			}
+			branches = append(branches, b)
+		}
+	}
+
+	return branches
+}
+
+// GoverningBranch returns the branch with the largest factored moment and
+// the branch with the smallest (most negative) factored moment
+func (lc LoadCombination) GoverningBranch(moments LoadMoments) (maxBranch, minBranch CombinationBranch) {
+	branches := lc.Branches(moments)
+	if len(branches) == 0 {
+		return CombinationBranch{}, CombinationBranch{}
+	}
+	maxBranch, minBranch = branches[0], branches[0]
+	for _, b := range branches[1:] {
+		if b.Moment > maxBranch.Moment {
+			maxBranch = b
+		}
+		if b.Moment < minBranch.Moment {
+			minBranch = b
+		}
+	}
+	return maxBranch, minBranch
+}
@@ -0,0 +1,96 @@
+package nscp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exposure is the environment a concrete surface is exposed to
+type Exposure string
+
+const (
+	ExposureInterior         Exposure = "interior"           // Not exposed to weather or in contact with ground
+	ExposureExterior         Exposure = "exterior"           // Exposed to weather
+	ExposureSoil             Exposure = "soil"               // Formed surface in contact with ground
+	ExposureCastAgainstEarth Exposure = "cast-against-earth" // Cast against and permanently in contact with ground
+	ExposureMarine           Exposure = "marine"             // Seawater, spray or deicing chemicals (corrosive)
+)
+
+// Exposures lists the exposure classes in order of severity
+var Exposures = []Exposure{ExposureInterior, ExposureExterior, ExposureSoil, ExposureCastAgainstEarth, ExposureMarine}
+
+// MemberType is the kind of structural member the cover applies to
+type MemberType string
+
+const (
+	MemberBeam   MemberType = "beam"
+	MemberColumn MemberType = "column"
+	MemberSlab   MemberType = "slab"
+	MemberWall   MemberType = "wall"
+	MemberJoist  MemberType = "joist"
+)
+
+// MemberTypes lists the supported member types
+var MemberTypes = []MemberType{MemberBeam, MemberColumn, MemberSlab, MemberWall, MemberJoist}
+
+// ParseExposure returns the exposure class for a name (case-insensitive)
+func ParseExposure(name string) (Exposure, error) {
+	key := Exposure(strings.ToLower(strings.TrimSpace(name)))
+	for _, e := range Exposures {
+		if e == key {
+			return e, nil
+		}
+	}
+	names := make([]string, len(Exposures))
+	for i, e := range Exposures {
+		names[i] = string(e)
+	}
+	return "", fmt.Errorf("unknown exposure %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// ParseMemberType returns the member type for a name (case-insensitive)
+func ParseMemberType(name string) (MemberType, error) {
+	key := MemberType(strings.ToLower(strings.TrimSpace(name)))
+	for _, m := range MemberTypes {
+		if m == key {
+			return m, nil
+		}
+	}
+	names := make([]string, len(MemberTypes))
+	for i, m := range MemberTypes {
+		names[i] = string(m)
+	}
+	return "", fmt.Errorf("unknown member type %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// MinimumCover returns the specified clear concrete cover (mm) to the outermost
+// reinforcement of a cast-in-place nonprestressed member with bars of diameter db
+// NSCP 2015 Table 420.6.1.3.1. Marine exposure uses the increased cover for
+// corrosive environments (Section 420.6.1.4.1 and its commentary): 50 mm for
+// slabs and walls, 65 mm for other members.
+func MinimumCover(exposure Exposure, member MemberType, db float64) float64 {
+	slabLike := member == MemberSlab || member == MemberWall || member == MemberJoist
+
+	switch exposure {
+	case ExposureCastAgainstEarth:
+		return 75
+	case ExposureExterior, ExposureSoil:
+		if db <= 16 {
+			return 40
+		}
+		return 50
+	case ExposureMarine:
+		if slabLike {
+			return 50
+		}
+		return 65
+	default:
+		if slabLike {
+			if db > 36 {
+				return 40
+			}
+			return 20
+		}
+		return 40
+	}
+}
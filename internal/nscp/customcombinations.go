@@ -0,0 +1,121 @@
+package nscp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CombinationSet is a named, user-defined set of load combinations
+type CombinationSet struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Append adds the combinations to the built-in set of the design code
+	// instead of replacing it
+	Append bool `json:"append,omitempty" yaml:"append,omitempty"`
+
+	Combinations []LoadCombination `json:"combinations" yaml:"combinations"`
+}
+
+// CombinationFile is the layout of a load combination file
+type CombinationFile struct {
+	Sets []CombinationSet `json:"sets" yaml:"sets"`
+}
+
+// LoadCombinationSets reads named load combination sets from a JSON or
+// YAML file (selected by the .yaml/.yml extension)
+func LoadCombinationSets(path string) ([]CombinationSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file CombinationFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if len(file.Sets) == 0 {
+		return nil, fmt.Errorf("%s defines no load combination sets", path)
+	}
+	seen := make(map[string]bool)
+	for _, set := range file.Sets {
+		if err := set.Validate(); err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(set.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate load combination set %q", set.Name)
+		}
+		seen[key] = true
+	}
+
+	return file.Sets, nil
+}
+
+// Validate checks that the set is named, every combination has an ID and a
+// load factor, and its alternative groups are well formed
+func (cs CombinationSet) Validate() error {
+	if strings.TrimSpace(cs.Name) == "" {
+		return fmt.Errorf("load combination set must have a name")
+	}
+	if len(cs.Combinations) == 0 {
+		return fmt.Errorf("load combination set %q has no combinations", cs.Name)
+	}
+	ids := make(map[string]bool)
+	for i, lc := range cs.Combinations {
+		if lc.ID == "" {
+			return fmt.Errorf("combination %d of set %q must have an id", i+1, cs.Name)
+		}
+		if ids[lc.ID] {
+			return fmt.Errorf("duplicate combination id %q in set %q", lc.ID, cs.Name)
+		}
+		ids[lc.ID] = true
+		if lc.Dead == 0 && lc.Live == 0 && lc.Roof == 0 && lc.Wind == 0 &&
+			lc.Earthquake == 0 && lc.Rain == 0 && lc.Snow == 0 &&
+			lc.Fluid == 0 && lc.Earth == 0 && lc.Temperature == 0 {
+			return fmt.Errorf("combination %q of set %q has no load factors", lc.ID, cs.Name)
+		}
+		if err := lc.ValidateAlternatives(); err != nil {
+			return fmt.Errorf("set %q: %w", cs.Name, err)
+		}
+	}
+	return nil
+}
+
+// FindCombinationSet returns the set with the given name (case-insensitive).
+// An empty name selects the first set.
+func FindCombinationSet(sets []CombinationSet, name string) (CombinationSet, error) {
+	if name == "" && len(sets) > 0 {
+		return sets[0], nil
+	}
+	names := make([]string, 0, len(sets))
+	for _, set := range sets {
+		if strings.EqualFold(set.Name, name) {
+			return set, nil
+		}
+		names = append(names, set.Name)
+	}
+	return CombinationSet{}, fmt.Errorf("load combination set %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Resolve returns the combinations of the set, appended to base when Append is set
+func (cs CombinationSet) Resolve(base []LoadCombination) []LoadCombination {
+	if !cs.Append {
+		return cs.Combinations
+	}
+	combined := make([]LoadCombination, 0, len(base)+len(cs.Combinations))
+	combined = append(combined, base...)
+	return append(combined, cs.Combinations...)
+}
@@ -0,0 +1,93 @@
+package nscp
+
+import "math"
+
+// Action identifies a load effect evaluated by the load combinations
+type Action string
+
+const (
+	ActionMoment Action = "M"
+	ActionShear  Action = "V"
+	ActionAxial  Action = "P"
+)
+
+// Actions lists all actions in display order
+var Actions = []Action{ActionMoment, ActionShear, ActionAxial}
+
+// LoadEffects holds the unfactored moment, shear and axial force of each
+// load type. The factors of a combination apply to every action alike.
+type LoadEffects struct {
+	Moment LoadMoments // Moments (kN-m)
+	Shear  LoadMoments // Shears (kN)
+	Axial  LoadMoments // Axial forces, compression positive (kN)
+}
+
+// Of returns the unfactored effects of the given action
+func (e LoadEffects) Of(a Action) LoadMoments {
+	switch a {
+	case ActionShear:
+		return e.Shear
+	case ActionAxial:
+		return e.Axial
+	}
+	return e.Moment
+}
+
+// CombinationDemand holds the factored moment, shear and axial force of one
+// load combination, each taken from its own branch of largest magnitude
+type CombinationDemand struct {
+	Combination LoadCombination
+	Moment      CombinationBranch
+	Shear       CombinationBranch
+	Axial       CombinationBranch
+}
+
+// Branch returns the governing branch of the given action
+func (d CombinationDemand) Branch(a Action) CombinationBranch {
+	switch a {
+	case ActionShear:
+		return d.Shear
+	case ActionAxial:
+		return d.Axial
+	}
+	return d.Moment
+}
+
+// ExtremeBranch returns the branch with the factored effect of largest
+// magnitude, the most negative one when it exceeds the largest positive one
+func (lc LoadCombination) ExtremeBranch(effects LoadMoments) CombinationBranch {
+	maxBranch, minBranch := lc.GoverningBranch(effects)
+	if -minBranch.Moment > maxBranch.Moment {
+		return minBranch
+	}
+	return maxBranch
+}
+
+// CalculateDemands evaluates every combination for the moment, shear and axial force
+func CalculateDemands(effects LoadEffects, combinations []LoadCombination) []CombinationDemand {
+	demands := make([]CombinationDemand, len(combinations))
+	for i, combo := range combinations {
+		demands[i] = CombinationDemand{
+			Combination: combo,
+			Moment:      combo.ExtremeBranch(effects.Moment),
+			Shear:       combo.ExtremeBranch(effects.Shear),
+			Axial:       combo.ExtremeBranch(effects.Axial),
+		}
+	}
+	return demands
+}
+
+// GoverningDemand returns the index of the demand with the factored effect of
+// largest magnitude for the given action, or -1 when the action is zero in
+// every combination
+func GoverningDemand(demands []CombinationDemand, a Action) int {
+	governing := -1
+	var maxEffect float64
+	for i, d := range demands {
+		if effect := math.Abs(d.Branch(a).Moment); effect > maxEffect {
+			maxEffect = effect
+			governing = i
+		}
+	}
+	return governing
+}
@@ -0,0 +1,32 @@
+package nscp
+
+import "math"
+
+// Development length modification factors (NSCP 2015 Table 425.4.2.4)
+const (
+	PsiTopBar   = 1.3 // ψt, more than 300 mm of fresh concrete below the bar
+	PsiOtherBar = 1.0
+)
+
+// DevelopmentLength calculates the tension development length of a
+// straight deformed bar with clear spacing ≥ db and cover ≥ db (mm)
+// NSCP 2015 Table 425.4.2.2 (uncoated bars, ψe = 1.0)
+func DevelopmentLength(db, fc, fy, lambda float64, topBar bool) float64 {
+	psiT := PsiOtherBar
+	if topBar {
+		psiT = PsiTopBar
+	}
+
+	// √f'c shall not exceed 8.3 MPa (Section 425.4.1.4)
+	sqrtFc := math.Min(math.Sqrt(fc), 8.3)
+
+	var ld float64
+	if db <= 20 {
+		// 20 mm and smaller bars
+		ld = fy * psiT / (2.1 * lambda * sqrtFc) * db
+	} else {
+		// 25 mm and larger bars
+		ld = fy * psiT / (1.7 * lambda * sqrtFc) * db
+	}
+	return math.Max(ld, 300)
+}
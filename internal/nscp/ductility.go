@@ -0,0 +1,69 @@
+package nscp
+
+import "math"
+
+// ProbableStrengthFactor is the ratio of the probable to the specified yield
+// strength used for the probable flexural strength Mpr with φ = 1.0
+// NSCP 2015 Section 418.6.5.1 (1.25fy)
+const ProbableStrengthFactor = 1.25
+
+// BalancedDepthRatio returns the neutral axis depth ratio cb/d at which the
+// tension steel yields as the concrete reaches εcu: cb/d = εcu/(εcu + εy)
+func BalancedDepthRatio(epsilonCU, epsilonY float64) float64 {
+	return epsilonCU / (epsilonCU + epsilonY)
+}
+
+// CrackedNeutralAxisRatio returns k = kd/d of a cracked rectangular section
+// with linear elastic concrete, for tension and compression steel ratios ρ and
+// ρ' with ρ' at depth d' and modular ratio n:
+//
+//	k = √((ρ + ρ')²n² + 2(ρ + ρ'd'/d)n) - (ρ + ρ')n
+func CrackedNeutralAxisRatio(rho, rhoComp, dPrimeOverD, n float64) float64 {
+	sum := (rho + rhoComp) * n
+	return math.Sqrt(sum*sum+2*(rho+rhoComp*dPrimeOverD)*n) - sum
+}
+
+// DuctilityResult holds the ductility and over-strength metrics of a flexural
+// section used for capacity design
+type DuctilityResult struct {
+	// Neutral axis depth at nominal strength
+	CdRatio    float64 // c/d
+	CdBalanced float64 // cb/d = εcu/(εcu + εy)
+	CdRelative float64 // (c/d)/(cb/d), below 1.0 when the tension steel yields
+
+	// Curvature ductility estimate
+	ElasticK           float64 // Cracked elastic neutral axis ratio k at first yield
+	YieldCurvature     float64 // φy = εy/(d - kd) (1/m)
+	UltimateCurvature  float64 // φu = εcu/c (1/m)
+	CurvatureDuctility float64 // μφ = φu/φy
+
+	// Over-strength
+	ProbableMoment float64 // Mpr with 1.25fy and φ = 1.0 (kN-m)
+	OverStrength   float64 // Mpr/φMn
+}
+
+// NewDuctilityResult builds the ductility metrics from the neutral axis depth c
+// at nominal strength, the cracked elastic neutral axis depth kd at first yield
+// and the effective depth d (mm), with the probable and design moments (kN-m)
+func NewDuctilityResult(epsilonCU, epsilonY, c, kd, d, mpr, phiMn float64) *DuctilityResult {
+	result := &DuctilityResult{
+		CdRatio:        c / d,
+		CdBalanced:     BalancedDepthRatio(epsilonCU, epsilonY),
+		ElasticK:       kd / d,
+		ProbableMoment: mpr,
+	}
+	result.CdRelative = result.CdRatio / result.CdBalanced
+	if d > kd {
+		result.YieldCurvature = epsilonY / (d - kd) * 1000
+	}
+	if c > 0 {
+		result.UltimateCurvature = epsilonCU / c * 1000
+	}
+	if result.YieldCurvature > 0 {
+		result.CurvatureDuctility = result.UltimateCurvature / result.YieldCurvature
+	}
+	if phiMn > 0 {
+		result.OverStrength = mpr / phiMn
+	}
+	return result
+}
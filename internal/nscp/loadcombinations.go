@@ -3,68 +3,94 @@ package nscp
 // LoadCombination represents an NSCP load combination
 // Based on NSCP 2015 Section 203.3 - Load Combinations Using Strength Design
 type LoadCombination struct {
-	ID          string
-	Description string
+	ID          string `json:"id" yaml:"id"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// Load factors for each load type
-	Dead      float64 // D - Dead load
-	Live      float64 // L - Live load
-	Roof      float64 // Lr - Roof live load
-	Wind      float64 // W - Wind load
-	Earthquake float64 // E - Earthquake load
-	Rain      float64 // R - Rain load
+	Dead        float64 `json:"dead,omitempty" yaml:"dead,omitempty"`               // D - Dead load
+	Live        float64 `json:"live,omitempty" yaml:"live,omitempty"`               // L - Live load
+	Roof        float64 `json:"roof,omitempty" yaml:"roof,omitempty"`               // Lr - Roof live load
+	Wind        float64 `json:"wind,omitempty" yaml:"wind,omitempty"`               // W - Wind load
+	Earthquake  float64 `json:"earthquake,omitempty" yaml:"earthquake,omitempty"`   // E - Earthquake load
+	Rain        float64 `json:"rain,omitempty" yaml:"rain,omitempty"`               // R - Rain load
+	Snow        float64 `json:"snow,omitempty" yaml:"snow,omitempty"`               // S - Snow load (not used by NSCP combinations)
+	Fluid       float64 `json:"fluid,omitempty" yaml:"fluid,omitempty"`             // F - Fluid pressure of well-defined density and height
+	Earth       float64 `json:"earth,omitempty" yaml:"earth,omitempty"`             // H - Lateral earth pressure, ground water or bulk material pressure
+	Temperature float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"` // T - Self-straining (temperature, creep, shrinkage, settlement)
+
+	// Alternatives are mutually exclusive load groups, e.g. (Lr or R);
+	// only one load of each group is applied at a time
+	Alternatives [][]LoadType `json:"alternatives,omitempty" yaml:"alternatives,omitempty"`
 }
 
 // NSCP 2015 Section 203.3.1 - Basic Load Combinations
+// F takes the same factor as D in combinations 1 to 5 and 7 (Section 203.3.1.1);
+// H is factored 1.6 and always taken as adding to the load effect.
 var LoadCombinations = []LoadCombination{
 	{
 		ID:          "1",
-		Description: "1.4D",
+		Description: "1.4(D + F)",
 		Dead:        1.4,
+		Fluid:       1.4,
 	},
 	{
-		ID:          "2",
-		Description: "1.2D + 1.6L + 0.5(Lr or R)",
-		Dead:        1.2,
-		Live:        1.6,
-		Roof:        0.5,
-		Rain:        0.5,
+		ID:           "2",
+		Description:  "1.2(D + F + T) + 1.6(L + H) + 0.5(Lr or R)",
+		Dead:         1.2,
+		Live:         1.6,
+		Roof:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Temperature:  1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
 	},
 	{
-		ID:          "3",
-		Description: "1.2D + 1.6(Lr or R) + (1.0L or 0.5W)",
-		Dead:        1.2,
-		Live:        1.0,
-		Roof:        1.6,
-		Rain:        1.6,
-		Wind:        0.5,
+		ID:           "3",
+		Description:  "1.2(D + F) + 1.6(Lr or R) + (1.0L or 0.5W) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Roof:         1.6,
+		Rain:         1.6,
+		Wind:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}, {LoadLive, LoadWind}},
 	},
 	{
-		ID:          "4",
-		Description: "1.2D + 1.0W + 1.0L + 0.5(Lr or R)",
-		Dead:        1.2,
-		Live:        1.0,
-		Wind:        1.0,
-		Roof:        0.5,
-		Rain:        0.5,
+		ID:           "4",
+		Description:  "1.2(D + F) + 1.0W + 1.0L + 0.5(Lr or R) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Wind:         1.0,
+		Roof:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
 	},
 	{
 		ID:          "5",
-		Description: "1.2D + 1.0E + 1.0L",
+		Description: "1.2(D + F) + 1.0E + 1.0L + 1.6H",
 		Dead:        1.2,
 		Live:        1.0,
 		Earthquake:  1.0,
+		Fluid:       1.2,
+		Earth:       1.6,
 	},
 	{
 		ID:          "6",
-		Description: "0.9D + 1.0W",
+		Description: "0.9D + 1.0W + 1.6H",
 		Dead:        0.9,
 		Wind:        1.0,
+		Earth:       1.6,
 	},
 	{
 		ID:          "7",
-		Description: "0.9D + 1.0E",
+		Description: "0.9(D + F) + 1.0E + 1.6H",
 		Dead:        0.9,
 		Earthquake:  1.0,
+		Earth:       1.6,
+		Fluid:       0.9,
 	},
 }
 
@@ -84,24 +110,25 @@ var SimplifiedCombinations = []LoadCombination{
 	},
 }
 
-// CalculateFactoredMoment calculates the factored moment for a given load combination
+// CalculateFactoredMoment calculates the factored moment for a given load combination,
+// taking the governing branch of its "or" groups and reversible loads
 func (lc LoadCombination) CalculateFactoredMoment(moments LoadMoments) float64 {
-	return lc.Dead*moments.Dead +
-		lc.Live*moments.Live +
-		lc.Roof*moments.Roof +
-		lc.Wind*moments.Wind +
-		lc.Earthquake*moments.Earthquake +
-		lc.Rain*moments.Rain
+	maxBranch, _ := lc.GoverningBranch(moments)
+	return maxBranch.Moment
 }
 
 // LoadMoments holds unfactored moments from different load types
 type LoadMoments struct {
-	Dead       float64 // Moment due to dead load (kN-m)
-	Live       float64 // Moment due to live load (kN-m)
-	Roof       float64 // Moment due to roof live load (kN-m)
-	Wind       float64 // Moment due to wind load (kN-m)
-	Earthquake float64 // Moment due to earthquake load (kN-m)
-	Rain       float64 // Moment due to rain load (kN-m)
+	Dead        float64 // Moment due to dead load (kN-m)
+	Live        float64 // Moment due to live load (kN-m)
+	Roof        float64 // Moment due to roof live load (kN-m)
+	Wind        float64 // Moment due to wind load (kN-m)
+	Earthquake  float64 // Moment due to earthquake load (kN-m)
+	Rain        float64 // Moment due to rain load (kN-m)
+	Snow        float64 // Moment due to snow load (kN-m)
+	Fluid       float64 // Moment due to fluid pressure (kN-m)
+	Earth       float64 // Moment due to lateral earth pressure (kN-m)
+	Temperature float64 // Moment due to self-straining effects (kN-m)
 }
 
 // CalculateGoverningMoment finds the maximum factored moment from all combinations
@@ -120,3 +147,92 @@ func CalculateGoverningMoment(moments LoadMoments, combinations []LoadCombinatio
 	return maxMoment, governingCombo
 }
 
+// CalculateReversalMoment finds the most negative factored moment over all
+// branches of the combinations, i.e. the governing moment of opposite sign
+func CalculateReversalMoment(moments LoadMoments, combinations []LoadCombination) (float64, LoadCombination, CombinationBranch) {
+	var minMoment float64
+	var reversalCombo LoadCombination
+	var reversalBranch CombinationBranch
+
+	for _, combo := range combinations {
+		_, branch := combo.GoverningBranch(moments)
+		if branch.Moment < minMoment {
+			minMoment = branch.Moment
+			reversalCombo = combo
+			reversalBranch = branch
+		}
+	}
+
+	return minMoment, reversalCombo, reversalBranch
+}
+
+// NSCP 2015 Section 203.4.1 - Basic Load Combinations Using Allowable Stress Design
+var ASDCombinations = []LoadCombination{
+	{
+		ID:          "203-8",
+		Description: "D + F",
+		Dead:        1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "203-9",
+		Description: "D + H + F + L + T",
+		Dead:        1.0,
+		Live:        1.0,
+		Earth:       1.0,
+		Fluid:       1.0,
+		Temperature: 1.0,
+	},
+	{
+		ID:           "203-10",
+		Description:  "D + H + F + (Lr or R)",
+		Dead:         1.0,
+		Roof:         1.0,
+		Rain:         1.0,
+		Earth:        1.0,
+		Fluid:        1.0,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
+	},
+	{
+		ID:           "203-11",
+		Description:  "D + H + F + 0.75[L + T + (Lr or R)]",
+		Dead:         1.0,
+		Live:         0.75,
+		Roof:         0.75,
+		Rain:         0.75,
+		Earth:        1.0,
+		Fluid:        1.0,
+		Temperature:  0.75,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
+	},
+	{
+		ID:          "203-12a",
+		Description: "D + H + F + 0.6W",
+		Dead:        1.0,
+		Wind:        0.6,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "203-12b",
+		Description: "D + H + F + E/1.4",
+		Dead:        1.0,
+		Earthquake:  1.0 / 1.4,
+		Earth:       1.0,
+		Fluid:       1.0,
+	},
+	{
+		ID:          "203-13",
+		Description: "0.6D + 0.6W + H",
+		Dead:        0.6,
+		Wind:        0.6,
+		Earth:       1.0,
+	},
+	{
+		ID:          "203-14",
+		Description: "0.6D + E/1.4 + H",
+		Dead:        0.6,
+		Earthquake:  1.0 / 1.4,
+		Earth:       1.0,
+	},
+}
@@ -1,6 +1,10 @@
 package nscp
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // NSCP 2015 Material Constants
 
@@ -20,8 +24,17 @@ const (
 	PhiCompression   = 0.65 // Compression-controlled (tied)
 	PhiCompressionSp = 0.75 // Compression-controlled (spiral)
 
+	// Lightweight concrete modification factor (Table 419.2.4.2)
+	LambdaNormal          = 1.00 // Normal-weight concrete
+	LambdaSandLightweight = 0.85 // Sand-lightweight concrete
+	LambdaAllLightweight  = 0.75 // All-lightweight concrete
+
 	// Modulus of elasticity for steel (Section 420.2.2)
 	Es = 200000.0 // MPa
+
+	// Unit weights (Section 204, Table 204-1)
+	UnitWeightConcrete = 23.6   // kN/m³ - reinforced normal-weight concrete
+	SteelDensity       = 7850.0 // kg/m³ - reinforcing steel
 )
 
 // Beta1 calculates the factor for equivalent rectangular stress block
@@ -35,20 +48,52 @@ func Beta1(fc float64) float64 {
 	return math.Max(beta1, Beta1Min)
 }
 
-// Phi calculates the strength reduction factor based on strain
+// Transverse identifies the transverse reinforcement of a member, which sets
+// φ for compression-controlled sections
+type Transverse string
+
+const (
+	TransverseTied   Transverse = "tied"   // Ties, hoops or stirrups
+	TransverseSpiral Transverse = "spiral" // Spirals conforming to Section 425.7.3
+)
+
+// ParseTransverse returns the transverse reinforcement type for a name
+// (case-insensitive); an empty name means tied
+func ParseTransverse(name string) (Transverse, error) {
+	switch Transverse(strings.ToLower(strings.TrimSpace(name))) {
+	case "", TransverseTied:
+		return TransverseTied, nil
+	case TransverseSpiral:
+		return TransverseSpiral, nil
+	}
+	return "", fmt.Errorf("unknown transverse reinforcement %q (use tied or spiral)", name)
+}
+
+// PhiCompressionFor returns φ for compression-controlled sections with the
+// given transverse reinforcement
+func PhiCompressionFor(transverse Transverse) float64 {
+	if transverse == TransverseSpiral {
+		return PhiCompressionSp
+	}
+	return PhiCompression
+}
+
+// Phi calculates the strength reduction factor based on strain, transitioning
+// from 0.65 (tied) or 0.75 (spiral) at εty to 0.90 at εty + 0.003
 // NSCP 2015 Section 409.3.2
-func Phi(epsilonT float64, fy float64) float64 {
+func Phi(epsilonT float64, fy float64, transverse Transverse) float64 {
 	epsilonTY := fy / Es
+	phiC := PhiCompressionFor(transverse)
 
 	if epsilonT >= epsilonTY+0.003 {
 		// Tension-controlled
 		return PhiFlexure
 	} else if epsilonT <= epsilonTY {
 		// Compression-controlled
-		return PhiCompression
+		return phiC
 	}
 	// Transition zone
-	return PhiCompression + (PhiFlexure-PhiCompression)*(epsilonT-epsilonTY)/0.003
+	return phiC + (PhiFlexure-phiC)*(epsilonT-epsilonTY)/0.003
 }
 
 // RhoMin calculates minimum reinforcement ratio
@@ -60,6 +105,20 @@ func RhoMin(fc, fy float64) float64 {
 	return math.Max(rho1, rho2)
 }
 
+// AlternativeMinSteel returns the tension steel that may be provided in lieu
+// of As,min, one-third more than required by analysis
+// NSCP 2015 Section 409.6.1.3
+func AlternativeMinSteel(asRequired float64) float64 {
+	return 4.0 / 3.0 * asRequired
+}
+
+// TensionFlangeMinWidth returns the width used with ρmin for the minimum
+// reinforcement of a statically determinate member with a flange in tension
+// NSCP 2015 Section 409.6.1.2: the smaller of bf and 2bw
+func TensionFlangeMinWidth(bw, bf float64) float64 {
+	return math.Min(bf, 2*bw)
+}
+
 // RhoMax calculates maximum reinforcement ratio for tension-controlled section
 // Based on strain compatibility for εt = 0.004 (tension-controlled limit)
 func RhoMax(fc, fy float64) float64 {
@@ -79,3 +138,83 @@ func RhoBalanced(fc, fy float64) float64 {
 	return 0.85 * beta1 * (fc / fy) * cb
 }
 
+// Concrete types accepted by Lambda
+const (
+	ConcreteNormal          = "normal"
+	ConcreteSandLightweight = "sand-lightweight"
+	ConcreteAllLightweight  = "all-lightweight"
+)
+
+// Lambda returns the lightweight concrete modification factor for a concrete type
+// NSCP 2015 Table 419.2.4.2
+func Lambda(concreteType string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(concreteType)) {
+	case "", ConcreteNormal, "normalweight", "normal-weight":
+		return LambdaNormal, nil
+	case ConcreteSandLightweight, "sand-lw":
+		return LambdaSandLightweight, nil
+	case ConcreteAllLightweight, "all-lw", "lightweight":
+		return LambdaAllLightweight, nil
+	}
+	return 0, fmt.Errorf("unknown concrete type %q (use %s, %s or %s)",
+		concreteType, ConcreteNormal, ConcreteSandLightweight, ConcreteAllLightweight)
+}
+
+// LambdaFromDensity returns λ from the equilibrium density wc (kg/m³).
+// NSCP 2015 gives λ by concrete type only; for a known density the linear
+// relation λ = 0.000471wc (0.75 to 1.0) of ACI 318M-19 Table 19.2.4.1(a) is used.
+func LambdaFromDensity(wc float64) float64 {
+	if wc <= 0 || wc > 2160 {
+		return LambdaNormal
+	}
+	return math.Min(math.Max(0.000471*wc, LambdaAllLightweight), LambdaNormal)
+}
+
+// ModulusOfRupture calculates the modulus of rupture of concrete
+// NSCP 2015 Section 419.2.3.1: fr = 0.62λ√f'c
+func ModulusOfRupture(fc, lambda float64) float64 {
+	return 0.62 * lambda * math.Sqrt(fc)
+}
+
+// ModulusOfElasticity calculates the modulus of elasticity of normal-weight concrete
+// NSCP 2015 Section 419.2.2.1(b): Ec = 4700√f'c
+func ModulusOfElasticity(fc float64) float64 {
+	return 4700 * math.Sqrt(fc)
+}
+
+// ModulusOfElasticityDensity calculates the modulus of elasticity of concrete
+// from its density wc (kg/m³), falling back to 4700√f'c when wc is zero
+// NSCP 2015 Section 419.2.2.1(a): Ec = wc^1.5·0.043√f'c for 1440 ≤ wc ≤ 2560
+func ModulusOfElasticityDensity(fc, wc float64) float64 {
+	if wc <= 0 {
+		return ModulusOfElasticity(fc)
+	}
+	return math.Pow(wc, 1.5) * 0.043 * math.Sqrt(fc)
+}
+
+// CrackingMoment calculates the cracking moment Mcr = fr·Ig/yt (kN-m)
+// from fr (MPa), the gross moment of inertia Ig (mm⁴) and the distance yt
+// from the centroid to the extreme tension fiber (mm)
+// NSCP 2015 Section 424.2.3.5
+func CrackingMoment(fr, ig, yt float64) float64 {
+	if yt <= 0 {
+		return 0
+	}
+	return fr * ig / yt / 1e6
+}
+
+// MaxBarSpacing calculates the maximum spacing of flexural tension bars for crack control
+// NSCP 2015 Table 424.3.2: s = 380(280/fs) - 2.5cc ≤ 300(280/fs)
+// where fs is the service steel stress and cc the clear cover to the tension bars
+func MaxBarSpacing(fs, cc float64) float64 {
+	if fs <= 0 {
+		return math.Inf(1)
+	}
+	return math.Min(380*(280/fs)-2.5*cc, 300*(280/fs))
+}
+
+// LongTermDeflectionFactor calculates the multiplier for additional long-term deflection
+// NSCP 2015 Section 424.2.4.1: λΔ = ξ/(1 + 50ρ'), with ξ = 2.0 for loads sustained 5 years or more
+func LongTermDeflectionFactor(xi, rhoPrime float64) float64 {
+	return xi / (1 + 50*rhoPrime)
+}
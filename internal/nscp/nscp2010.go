@@ -0,0 +1,108 @@
+package nscp
+
+// NSCP 2010 (6th Edition) provisions that differ from NSCP 2015.
+// Beta1, RhoMin and RhoBalanced are unchanged between the two editions.
+
+// PhiCompressionSp2010 is φ for compression-controlled spirally reinforced
+// members, NSCP 2010 Section 409.4.2.2
+const PhiCompressionSp2010 = 0.70
+
+// Phi2010 calculates the strength reduction factor based on net tensile strain
+// NSCP 2010 Section 409.4.2 (transition from εty to 0.005, starting at 0.65
+// for tied and 0.70 for spiral members)
+func Phi2010(epsilonT float64, fy float64, transverse Transverse) float64 {
+	epsilonTY := fy / Es
+	phiC := PhiCompression
+	if transverse == TransverseSpiral {
+		phiC = PhiCompressionSp2010
+	}
+
+	if epsilonT >= 0.005 {
+		// Tension-controlled
+		return PhiFlexure
+	} else if epsilonT <= epsilonTY {
+		// Compression-controlled
+		return phiC
+	}
+	// Transition zone
+	return phiC + (PhiFlexure-phiC)*(epsilonT-epsilonTY)/(0.005-epsilonTY)
+}
+
+// RhoMax2010 calculates maximum reinforcement ratio
+// NSCP 2010 practice: ρmax = 0.75ρb
+func RhoMax2010(fc, fy float64) float64 {
+	return 0.75 * RhoBalanced(fc, fy)
+}
+
+// NSCP 2010 Section 203.3.1 - Basic Load Combinations
+// f1 is taken as 1.0 (places of public assembly, live loads over 4.8 kPa
+// and garages), which is conservative for other occupancies where f1 = 0.5.
+// F and H are factored as in the 2015 combinations.
+var LoadCombinations2010 = []LoadCombination{
+	{
+		ID:          "203-1",
+		Description: "1.4(D + F)",
+		Dead:        1.4,
+		Fluid:       1.4,
+	},
+	{
+		ID:           "203-2",
+		Description:  "1.2(D + F + T) + 1.6(L + H) + 0.5(Lr or R)",
+		Dead:         1.2,
+		Live:         1.6,
+		Roof:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Temperature:  1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
+	},
+	{
+		ID:           "203-3",
+		Description:  "1.2(D + F) + 1.6(Lr or R) + (f1L or 0.8W) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Roof:         1.6,
+		Rain:         1.6,
+		Wind:         0.8,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}, {LoadLive, LoadWind}},
+	},
+	{
+		ID:           "203-4",
+		Description:  "1.2(D + F) + 1.6W + f1L + 0.5(Lr or R) + 1.6H",
+		Dead:         1.2,
+		Live:         1.0,
+		Wind:         1.6,
+		Roof:         0.5,
+		Rain:         0.5,
+		Fluid:        1.2,
+		Earth:        1.6,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
+	},
+	{
+		ID:          "203-5",
+		Description: "1.2(D + F) + 1.0E + f1L + 1.6H",
+		Dead:        1.2,
+		Live:        1.0,
+		Earthquake:  1.0,
+		Fluid:       1.2,
+		Earth:       1.6,
+	},
+	{
+		ID:          "203-6",
+		Description: "0.9D + 1.6W + 1.6H",
+		Dead:        0.9,
+		Wind:        1.6,
+		Earth:       1.6,
+	},
+	{
+		ID:          "203-7",
+		Description: "0.9(D + F) + 1.0E + 1.6H",
+		Dead:        0.9,
+		Earthquake:  1.0,
+		Earth:       1.6,
+		Fluid:       0.9,
+	},
+}
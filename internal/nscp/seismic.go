@@ -0,0 +1,75 @@
+package nscp
+
+import (
+	"fmt"
+	"math"
+)
+
+// SeismicParameters define how the earthquake load effect E is built from the
+// horizontal seismic effect Eh (NSCP 2015 Section 208.5.1.1 / ASCE 7 Section 12.4.2):
+//
+//	E  = ρ·Eh ± Ev,  Ev = 0.2·SDS·D
+//	Em = Ω0·Eh ± Ev  (overstrength, Section 208.5.1.2)
+type SeismicParameters struct {
+	Rho    float64 // ρ - redundancy factor (1.0 or 1.3)
+	SDS    float64 // SDS - design spectral response acceleration at short period
+	Omega0 float64 // Ω0 - system overstrength factor (required for Em combinations)
+}
+
+// Validate checks the seismic parameters
+func (p SeismicParameters) Validate(overstrength bool) error {
+	if p.Rho <= 0 {
+		return fmt.Errorf("redundancy factor ρ must be positive, got %g", p.Rho)
+	}
+	if p.SDS < 0 {
+		return fmt.Errorf("SDS must not be negative, got %g", p.SDS)
+	}
+	if overstrength && p.Omega0 <= 0 {
+		return fmt.Errorf("overstrength factor Ω0 must be positive for Em combinations")
+	}
+	return nil
+}
+
+// SeismicCombinations expands every combination containing E into the two
+// branches E = ρEh + Ev and E = ρEh - Ev, where the earthquake moment is taken
+// as Eh and Ev acts on the dead load moment. With overstrength set, the
+// Em = Ω0Eh ± Ev branches are added as well. Combinations without E are kept.
+func SeismicCombinations(combinations []LoadCombination, p SeismicParameters, overstrength bool) []LoadCombination {
+	ev := 0.2 * p.SDS
+	var result []LoadCombination
+
+	for _, lc := range combinations {
+		if lc.Earthquake == 0 {
+			result = append(result, lc)
+			continue
+		}
+
+		result = append(result,
+			seismicBranch(lc, "E", lc.Earthquake*p.Rho, ev, 1, fmt.Sprintf("E = %.2gEh + %.3gD", p.Rho, ev)),
+			seismicBranch(lc, "E", lc.Earthquake*p.Rho, ev, -1, fmt.Sprintf("E = %.2gEh - %.3gD", p.Rho, ev)),
+		)
+		if overstrength {
+			result = append(result,
+				seismicBranch(lc, "Em", lc.Earthquake*p.Omega0, ev, 1, fmt.Sprintf("Em = %.2gEh + %.3gD", p.Omega0, ev)),
+				seismicBranch(lc, "Em", lc.Earthquake*p.Omega0, ev, -1, fmt.Sprintf("Em = %.2gEh - %.3gD", p.Omega0, ev)),
+			)
+		}
+	}
+
+	return result
+}
+
+// seismicBranch returns a copy of lc with E replaced by the factored Eh term
+// and the vertical effect sign·Ev·D folded into the dead load factor
+func seismicBranch(lc LoadCombination, label string, ehFactor, ev, sign float64, note string) LoadCombination {
+	branch := lc
+	suffix := "+"
+	if sign < 0 {
+		suffix = "-"
+	}
+	branch.ID = fmt.Sprintf("%s%s%s", lc.ID, label, suffix)
+	branch.Description = fmt.Sprintf("%s; %s", lc.Description, note)
+	branch.Earthquake = ehFactor
+	branch.Dead = lc.Dead + sign*math.Abs(lc.Earthquake)*ev
+	return branch
+}
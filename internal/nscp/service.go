@@ -0,0 +1,103 @@
+package nscp
+
+import "fmt"
+
+// Service-level (unfactored) load combinations for serviceability checks:
+// deflection, crack control and service stresses. These are kept separate
+// from the strength (Section 203.3) and allowable stress (Section 203.4)
+// combinations. Wind is taken at its full service value.
+var ServiceCombinations = []LoadCombination{
+	{
+		ID:          "SLS-1",
+		Description: "D + F + H + L",
+		Dead:        1.0,
+		Live:        1.0,
+		Fluid:       1.0,
+		Earth:       1.0,
+	},
+	{
+		ID:           "SLS-2",
+		Description:  "D + F + H + L + (Lr or R)",
+		Dead:         1.0,
+		Live:         1.0,
+		Roof:         1.0,
+		Rain:         1.0,
+		Fluid:        1.0,
+		Earth:        1.0,
+		Alternatives: [][]LoadType{{LoadRoof, LoadRain}},
+	},
+	{
+		ID:          "SLS-3",
+		Description: "D + F + H + L + W",
+		Dead:        1.0,
+		Live:        1.0,
+		Wind:        1.0,
+		Fluid:       1.0,
+		Earth:       1.0,
+	},
+	{
+		ID:          "SLS-4",
+		Description: "D + F + H + 0.75L + 0.75W",
+		Dead:        1.0,
+		Live:        0.75,
+		Wind:        0.75,
+		Fluid:       1.0,
+		Earth:       1.0,
+	},
+}
+
+// SustainedCombination returns the quasi-permanent combination D + F + H + ψL
+// used for long-term deflection, where ψ is the sustained fraction of live load
+func SustainedCombination(sustainedLive float64) LoadCombination {
+	return LoadCombination{
+		ID:          "SUS",
+		Description: fmt.Sprintf("D + F + H + %.2gL (sustained)", sustainedLive),
+		Dead:        1.0,
+		Live:        sustainedLive,
+		Fluid:       1.0,
+		Earth:       1.0,
+	}
+}
+
+// ServiceMoments holds the service-level moments fed to the serviceability checks
+type ServiceMoments struct {
+	Total       float64         // Governing total service moment Ma (kN-m)
+	TotalCombo  LoadCombination // Combination producing Ma
+	Sustained   float64         // Sustained moment Msus = MD + ψML (kN-m)
+	Dead        float64         // Permanent load moment MD, including F and H (kN-m)
+	Transient   float64         // Ma - MD, moment from transient loads (kN-m)
+	SustainedLL float64         // Sustained live load fraction ψ
+}
+
+// CalculateServiceMoments evaluates the service combinations and the
+// sustained combination for the given unfactored moments
+func CalculateServiceMoments(moments LoadMoments, combinations []LoadCombination, sustainedLive float64) ServiceMoments {
+	total, combo := CalculateGoverningMoment(moments, combinations)
+	sustained := SustainedCombination(sustainedLive).CalculateFactoredMoment(moments)
+
+	// Fluid and earth pressures act permanently alongside the dead load
+	permanent := moments.Dead + moments.Fluid + moments.Earth
+
+	// The permanent loads alone govern when every other load relieves the section
+	if permanent > total {
+		total = permanent
+		combo = LoadCombination{ID: "D", Description: "D + F + H", Dead: 1.0, Fluid: 1.0, Earth: 1.0}
+	}
+
+	return ServiceMoments{
+		Total:       total,
+		TotalCombo:  combo,
+		Sustained:   sustained,
+		Dead:        permanent,
+		Transient:   total - permanent,
+		SustainedLL: sustainedLive,
+	}
+}
+
+// ValidateSustainedLive checks that the sustained live load fraction is within 0..1
+func ValidateSustainedLive(sustainedLive float64) error {
+	if sustainedLive < 0 || sustainedLive > 1 {
+		return fmt.Errorf("sustained live load fraction must be between 0 and 1, got %g", sustainedLive)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package nscp
+
+import "math"
+
+// NSCP 2015 Section 422.5 - One-way Shear Strength
+// Forces are in N and dimensions in mm.
+
+// Vc calculates the nominal shear strength provided by concrete
+// NSCP 2015 Section 422.5.5.1: Vc = 0.17λ√f'c·bw·d
+func Vc(fc, bw, d, lambda float64) float64 {
+	return 0.17 * lambda * math.Sqrt(fc) * bw * d
+}
+
+// VsMax calculates the upper limit of the shear strength provided by stirrups
+// NSCP 2015 Section 422.5.1.2: Vs ≤ 0.66√f'c·bw·d
+func VsMax(fc, bw, d float64) float64 {
+	return 0.66 * math.Sqrt(fc) * bw * d
+}
+
+// MaxStirrupSpacing returns the maximum spacing of vertical stirrups
+// NSCP 2015 Table 409.7.6.2.2: d/2 ≤ 600 mm, halved when Vs > 0.33√f'c·bw·d
+func MaxStirrupSpacing(fc, bw, d, vs float64) float64 {
+	if vs > 0.33*math.Sqrt(fc)*bw*d {
+		return math.Min(d/4, 300)
+	}
+	return math.Min(d/2, 600)
+}
+
+// AvMinPerSpacing calculates the minimum shear reinforcement Av,min/s (mm²/mm)
+// NSCP 2015 Section 409.6.3.3: max(0.062√f'c, 0.35)·bw/fyt
+func AvMinPerSpacing(fc, fyt, bw float64) float64 {
+	return math.Max(0.062*math.Sqrt(fc), 0.35) * bw / fyt
+}
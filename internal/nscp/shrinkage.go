@@ -0,0 +1,21 @@
+package nscp
+
+import "math"
+
+// ShrinkageTemperatureRatio calculates the minimum ratio of deformed shrinkage
+// and temperature reinforcement to the gross concrete area
+// NSCP 2015 Table 424.4.3.2: 0.0020 for fy < 420 MPa; 0.0018·420/fy for
+// fy ≥ 420 MPa, but not less than 0.0014
+func ShrinkageTemperatureRatio(fy float64) float64 {
+	if fy < 420 {
+		return 0.0020
+	}
+	return math.Max(0.0018*420/fy, 0.0014)
+}
+
+// ShrinkageTemperatureSpacing calculates the maximum spacing of shrinkage and
+// temperature reinforcement for a member of thickness h (mm)
+// NSCP 2015 Section 424.4.3.3: the lesser of 5h and 450 mm
+func ShrinkageTemperatureSpacing(h float64) float64 {
+	return math.Min(5*h, 450)
+}
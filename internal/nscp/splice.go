@@ -0,0 +1,51 @@
+package nscp
+
+import "math"
+
+// SpliceClass is the class of a tension lap splice
+type SpliceClass string
+
+const (
+	SpliceClassA SpliceClass = "A" // Lap length 1.0ld
+	SpliceClassB SpliceClass = "B" // Lap length 1.3ld
+)
+
+// TensionSpliceClass determines the class of a tension lap splice from the
+// ratio As,provided/As,required over the splice length and the fraction of
+// As spliced within the required lap length
+// NSCP 2015 Table 425.5.2.1: Class A only when As,prov/As,req ≥ 2 and at most
+// 50% of As is spliced; Class B in all other cases
+func TensionSpliceClass(asRatio, fractionSpliced float64) SpliceClass {
+	if asRatio >= 2 && fractionSpliced <= 0.5 {
+		return SpliceClassA
+	}
+	return SpliceClassB
+}
+
+// TensionLapLength calculates the tension lap splice length of deformed bars
+// from the development length ld (mm)
+// NSCP 2015 Table 425.5.2.1: Class A = 1.0ld, Class B = 1.3ld, not less than 300 mm
+func TensionLapLength(ld float64, class SpliceClass) float64 {
+	factor := 1.0
+	if class == SpliceClassB {
+		factor = 1.3
+	}
+	return math.Max(factor*ld, 300)
+}
+
+// CompressionLapLength calculates the compression lap splice length of deformed bars (mm)
+// NSCP 2015 Section 425.5.5.1: 0.071fy·db for fy ≤ 420 MPa, (0.13fy - 24)db
+// otherwise, not less than 300 mm, increased by one-third when f'c < 21 MPa
+func CompressionLapLength(db, fc, fy float64) float64 {
+	var lsc float64
+	if fy <= 420 {
+		lsc = 0.071 * fy * db
+	} else {
+		lsc = (0.13*fy - 24) * db
+	}
+	lsc = math.Max(lsc, 300)
+	if fc < 21 {
+		lsc *= 4.0 / 3.0
+	}
+	return lsc
+}
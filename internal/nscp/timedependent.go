@@ -0,0 +1,31 @@
+package nscp
+
+// timeDependentFactors are the sustained-load durations (months) and time-dependent
+// factors ξ of NSCP 2015 Table 424.2.4.1.3
+var timeDependentFactors = []struct {
+	months float64
+	xi     float64
+}{
+	{0, 0},
+	{3, 1.0},
+	{6, 1.2},
+	{12, 1.4},
+	{60, 2.0},
+}
+
+// TimeDependentFactor returns the time-dependent factor ξ for sustained loads
+// applied for the given number of months, interpolated linearly between the
+// tabulated durations and taken as 2.0 for 5 years or more
+// NSCP 2015 Table 424.2.4.1.3
+func TimeDependentFactor(months float64) float64 {
+	if months <= 0 {
+		return 0
+	}
+	for i := 1; i < len(timeDependentFactors); i++ {
+		lo, hi := timeDependentFactors[i-1], timeDependentFactors[i]
+		if months <= hi.months {
+			return lo.xi + (hi.xi-lo.xi)*(months-lo.months)/(hi.months-lo.months)
+		}
+	}
+	return timeDependentFactors[len(timeDependentFactors)-1].xi
+}
@@ -0,0 +1,145 @@
+// Package plugin runs the external programs registered in the configuration
+// after an analysis, to add office-specific checks or export steps.
+//
+// A plugin is an executable in any language. It receives the JSON document
+// the command writes with --format json on stdin and may answer on stdout
+// with a JSON object of additional checks and messages:
+//
+//	{"checks": [{"limit_state": "Office", "check": "ρ ≤ 0.75ρb", "value": 0.012,
+//	  "limit": 0.016, "status": "OK"}], "messages": ["wrote B1.ifc"]}
+//
+// Plugins that only export files may print nothing. A plugin that exits with
+// a non-zero code, times out or prints anything but such an object fails.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexiusacademia/gorcb/internal/check"
+)
+
+// DefaultTimeout is how long a plugin may run when its configuration sets
+// no timeout
+const DefaultTimeout = 30 * time.Second
+
+// Plugin is an external program registered under plugins in the
+// configuration, e.g.
+//
+//	plugins:
+//	  - name: office-checks
+//	    exec: ./tools/office-checks.py
+//	    args: [--strict]
+//	    on: [beam design, check]
+type Plugin struct {
+	Name    string   `yaml:"name" json:"name"`
+	Exec    string   `yaml:"exec" json:"exec"`                 // Program, relative to the directory of the configuration file or on the PATH
+	Args    []string `yaml:"args" json:"args,omitempty"`       // Arguments given to the program
+	On      []string `yaml:"on" json:"on,omitempty"`           // Commands it runs after, e.g. "beam" or "beam design" (default: all analyses)
+	Timeout float64  `yaml:"timeout" json:"timeout,omitempty"` // Seconds (default 30)
+	Dir     string   `yaml:"-" json:"config_dir,omitempty"`    // Directory of the configuration file that registered it
+}
+
+// Output is what a plugin answers on stdout
+type Output struct {
+	Checks   []check.Item `json:"checks,omitempty"`
+	Messages []string     `json:"messages,omitempty"`
+}
+
+// Validate checks the registration of a plugin
+func (p Plugin) Validate() error {
+	if p.Name == "" {
+		return errors.New("a plugin needs a name")
+	}
+	if p.Exec == "" {
+		return fmt.Errorf("plugin %s needs an exec program", p.Name)
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("plugin %s: timeout must be positive, got %g", p.Name, p.Timeout)
+	}
+	return nil
+}
+
+// Runs reports whether the plugin runs after a command, given by its path
+// without the program name, e.g. "beam design"
+func (p Plugin) Runs(command string) bool {
+	if len(p.On) == 0 {
+		return true
+	}
+	for _, on := range p.On {
+		on = strings.Join(strings.Fields(on), " ")
+		if command == on || strings.HasPrefix(command, on+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// program returns the path of the executable: relative paths with a
+// directory are taken from the directory of the configuration file, bare
+// names are looked up on the PATH
+func (p Plugin) program() string {
+	if filepath.IsAbs(p.Exec) || !strings.ContainsAny(p.Exec, `/\`) || p.Dir == "" {
+		return p.Exec
+	}
+	return filepath.Join(p.Dir, p.Exec)
+}
+
+// Run runs the plugin on the JSON document of a command and returns its
+// answer. What it writes to stderr is passed on.
+func (p Plugin) Run(document []byte) (Output, error) {
+	timeout := DefaultTimeout
+	if p.Timeout > 0 {
+		timeout = time.Duration(p.Timeout * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.program(), p.Args...)
+	cmd.Stdin = bytes.NewReader(document)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return Output{}, fmt.Errorf("timed out after %s", timeout)
+		}
+		return Output{}, err
+	}
+
+	var out Output
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return out, nil
+	}
+	dec := json.NewDecoder(&stdout)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		return Output{}, fmt.Errorf("reading its output: %w", err)
+	}
+	for i, c := range out.Checks {
+		switch c.Status {
+		case check.StatusOK, check.StatusNG, check.StatusNotChecked:
+		default:
+			return Output{}, fmt.Errorf("check %d (%s): status must be OK, NG or N/A, got %q", i+1, c.Check, c.Status)
+		}
+	}
+	return out, nil
+}
+
+// Adequate reports whether no check of the output is NG
+func (o Output) Adequate() bool {
+	for _, c := range o.Checks {
+		if c.Status == check.StatusNG {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// MemberQuantities are the material quantities and cost of one member
+type MemberQuantities struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"` // beam or section
+	cost.Quantities
+	Error string `json:"error,omitempty"`
+}
+
+// Quantities returns the material quantities and cost of every member of the
+// project designed or checked by Run, over the member length or defaultLength
+// (m) when it gives none, with the totals of the project. Members that could
+// not be designed or loaded are listed with their error and left out of the
+// totals.
+func (p *Project) Quantities(r *Result, defaultLength float64, costs cost.UnitCosts) ([]MemberQuantities, cost.Quantities) {
+	var members []MemberQuantities
+	var total cost.Quantities
+	add := func(q MemberQuantities) {
+		members = append(members, q)
+		if q.Error == "" {
+			total.Add(q.Quantities)
+		}
+	}
+
+	for i, b := range r.Beams {
+		q := MemberQuantities{ID: b.Member.ID, Kind: "beam", Error: b.Error}
+		if q.Error == "" {
+			q.Quantities = b.Quantities(orLength(p.Beams[i].Length, defaultLength), costs)
+		}
+		add(q)
+	}
+	for _, s := range p.Sections {
+		q := MemberQuantities{ID: s.ID, Kind: "section"}
+		sec, err := section.LoadFromFile(p.SectionPath(s))
+		if err != nil {
+			q.Error = fmt.Sprintf("loading %s: %v", s.File, err)
+		} else {
+			q.Quantities = SectionQuantities(sec, orLength(s.Length, defaultLength), s.StirrupDia, s.StirrupSpacing, costs)
+		}
+		add(q)
+	}
+	return members, total
+}
+
+// SectionQuantities returns the quantities of a member of a section over a
+// length (m), with closed stirrups of diameter db (mm) along the stirrup
+// centerline of the section at a spacing (mm) when given
+func SectionQuantities(sec *section.Section, length, db, spacing float64, costs cost.UnitCosts) cost.Quantities {
+	var steel float64
+	for _, layer := range sec.Reinforcement {
+		steel += layer.Area
+	}
+	var barArea, barLength float64
+	if db > 0 && spacing > 0 {
+		barArea = math.Pi * db * db / 4
+		barLength = cost.HoopLength(sec.TorsionProperties().Ph, db)
+	}
+	return cost.Member(sec.CalculateProperties().Area, sec.FormedPerimeter(), length, steel, barArea, barLength, spacing, costs)
+}
+
+// orLength returns the length of a member, or def when it gives none
+func orLength(length, def float64) float64 {
+	if length > 0 {
+		return length
+	}
+	return def
+}
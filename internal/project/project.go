@@ -0,0 +1,168 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"gopkg.in/yaml.v3"
+)
+
+// Project describes the beams and sections of one structure with their loads
+type Project struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Engineer    string `json:"engineer,omitempty" yaml:"engineer,omitempty"`
+
+	// Defaults applied to properties omitted by the beams, e.g. fc, fy, cover
+	Defaults *batch.Member `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// Bar sizes available on the project, e.g. ["12", "16", "20"], for the
+	// rebar commands given --project (all bars of the catalog when empty)
+	AvailableBars []string `json:"available_bars,omitempty" yaml:"available_bars,omitempty"`
+
+	Beams    []Beam          `json:"beams,omitempty" yaml:"beams,omitempty"`
+	Sections []SectionMember `json:"sections,omitempty" yaml:"sections,omitempty"`
+
+	// Directory section files are resolved against
+	Dir string `json:"-" yaml:"-"`
+}
+
+// Loads are the unfactored load effects of a member per load type, as
+// M[,V] in kN-m and kN, e.g. {"D": [50, 80], "L": [30, 45]}
+type Loads map[nscp.LoadType][]float64
+
+// Beam is a rectangular beam of the project. Its factored Mu and Vu are
+// either given directly or governed by the load combinations of its loads.
+type Beam struct {
+	batch.Member `yaml:",inline"`
+	Loads        Loads   `json:"loads,omitempty" yaml:"loads,omitempty"`
+	Length       float64 `json:"length,omitempty" yaml:"length,omitempty"` // m, for quantities and cost
+}
+
+// SectionMember is a non-rectangular section of the project, defined in a
+// section file, checked for Mu given directly or from its loads
+type SectionMember struct {
+	ID    string  `json:"id" yaml:"id"`
+	File  string  `json:"file" yaml:"file"` // Section JSON file, relative to the project file
+	Mu    float64 `json:"mu,omitempty" yaml:"mu,omitempty"`
+	Loads Loads   `json:"loads,omitempty" yaml:"loads,omitempty"`
+
+	// Length (m) and closed stirrups (mm) for quantities and cost
+	Length         float64 `json:"length,omitempty" yaml:"length,omitempty"`
+	StirrupDia     float64 `json:"stirrup_dia,omitempty" yaml:"stirrup_dia,omitempty"`
+	StirrupSpacing float64 `json:"stirrup_spacing,omitempty" yaml:"stirrup_spacing,omitempty"`
+}
+
+// Load reads a project from a JSON or YAML file (selected by the .yaml/.yml extension)
+func Load(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Project
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &p)
+	default:
+		err = json.Unmarshal(data, &p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	p.Dir = filepath.Dir(path)
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate checks that the project has uniquely named members with either
+// factored actions or loads
+func (p *Project) Validate() error {
+	if len(p.Beams) == 0 && len(p.Sections) == 0 {
+		return fmt.Errorf("project defines no beams or sections")
+	}
+	seen := make(map[string]bool)
+	unique := func(id string) error {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("every member must have an id")
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate member %q", id)
+		}
+		seen[id] = true
+		return nil
+	}
+
+	for _, b := range p.Beams {
+		if err := unique(b.ID); err != nil {
+			return err
+		}
+		if err := b.Loads.Validate(b.ID); err != nil {
+			return err
+		}
+		if len(b.Loads) > 0 && (b.Mu != 0 || b.Vu != 0) {
+			return fmt.Errorf("member %q: give either mu/vu or loads, not both", b.ID)
+		}
+		if len(b.Loads) == 0 && b.Mu <= 0 && b.As <= 0 {
+			return fmt.Errorf("member %q: give mu or loads to design, or as to check", b.ID)
+		}
+		if b.Length < 0 {
+			return fmt.Errorf("member %q: length must not be negative", b.ID)
+		}
+	}
+	for _, s := range p.Sections {
+		if err := unique(s.ID); err != nil {
+			return err
+		}
+		if s.File == "" {
+			return fmt.Errorf("section %q: file is required", s.ID)
+		}
+		if s.Length < 0 || s.StirrupDia < 0 || s.StirrupSpacing < 0 {
+			return fmt.Errorf("section %q: length and stirrups must not be negative", s.ID)
+		}
+		if err := s.Loads.Validate(s.ID); err != nil {
+			return err
+		}
+		if len(s.Loads) > 0 && s.Mu != 0 {
+			return fmt.Errorf("section %q: give either mu or loads, not both", s.ID)
+		}
+	}
+	return nil
+}
+
+// Validate checks the load types and the number of effects of each load
+func (l Loads) Validate(id string) error {
+	for t, values := range l {
+		known := false
+		for _, k := range nscp.LoadTypes {
+			known = known || k == t
+		}
+		if !known {
+			return fmt.Errorf("member %q: unknown load type %q", id, t)
+		}
+		if len(values) == 0 || len(values) > 2 {
+			return fmt.Errorf("member %q: load %s takes M[,V], got %d values", id, t, len(values))
+		}
+	}
+	return nil
+}
+
+// Effects returns the unfactored moment and shear effects of the loads
+func (l Loads) Effects() nscp.LoadEffects {
+	var effects nscp.LoadEffects
+	for t, values := range l {
+		effects.Moment.Set(t, values[0])
+		if len(values) > 1 {
+			effects.Shear.Set(t, values[1])
+		}
+	}
+	return effects
+}
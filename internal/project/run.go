@@ -0,0 +1,193 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"path/filepath"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/section"
+)
+
+// BeamResult is the outcome of one beam with the combinations that govern
+// its factored actions when they come from loads
+type BeamResult struct {
+	batch.Result
+	MomentCombination string `json:"moment_combination,omitempty"`
+	ShearCombination  string `json:"shear_combination,omitempty"`
+}
+
+// SectionResult is the outcome of the check of one section
+type SectionResult struct {
+	ID                string                  `json:"id"`
+	File              string                  `json:"file"`
+	Name              string                  `json:"name,omitempty"`
+	Mu                float64                 `json:"mu"` // kN-m
+	MomentCombination string                  `json:"moment_combination,omitempty"`
+	Analysis          *section.AnalysisResult `json:"analysis,omitempty"`
+	IsAdequate        bool                    `json:"adequate"`
+	Message           string                  `json:"message"`
+	Error             string                  `json:"error,omitempty"`
+	NoConvergence     bool                    `json:"no_convergence,omitempty"` // The error is a solver that did not converge
+}
+
+// Status returns OK, NG or ERROR for the summary of the section
+func (r SectionResult) Status() string {
+	switch {
+	case r.Error != "":
+		return "ERROR"
+	case r.IsAdequate:
+		return "OK"
+	}
+	return "NG"
+}
+
+// Result holds the outcome of every member of a project
+type Result struct {
+	Beams    []BeamResult    `json:"beams,omitempty"`
+	Sections []SectionResult `json:"sections,omitempty"`
+}
+
+// Counts returns the number of members and of adequate members
+func (r *Result) Counts() (total, adequate int) {
+	for _, b := range r.Beams {
+		if b.Status() == "OK" {
+			adequate++
+		}
+	}
+	for _, s := range r.Sections {
+		if s.Status() == "OK" {
+			adequate++
+		}
+	}
+	return len(r.Beams) + len(r.Sections), adequate
+}
+
+// Run designs or checks every member of the project with the design code.
+// With check set, beams are only verified and must give their provided steel.
+func Run(p *Project, code codes.DesignCode, check bool) *Result {
+	combinations := codes.OrDefault(code).LoadCombinations()
+	result := &Result{}
+
+	for _, b := range p.Beams {
+		m := p.withDefaults(b.Member)
+		r := BeamResult{}
+		if len(b.Loads) > 0 {
+			demands := nscp.CalculateDemands(b.Loads.Effects(), combinations)
+			if i := nscp.GoverningDemand(demands, nscp.ActionMoment); i >= 0 {
+				m.Mu = math.Abs(demands[i].Moment.Moment)
+				r.MomentCombination = demands[i].Combination.ID
+			}
+			if i := nscp.GoverningDemand(demands, nscp.ActionShear); i >= 0 {
+				m.Vu = math.Abs(demands[i].Shear.Moment)
+				r.ShearCombination = demands[i].Combination.ID
+			}
+		}
+
+		var err error
+		if check && m.As <= 0 {
+			err = fmt.Errorf("member %q: no provided reinforcement to check (give as)", m.ID)
+		}
+		var res *batch.Result
+		if err == nil {
+			res, err = batch.RunMember(m, code)
+		}
+		if err != nil {
+			res = batch.Failed(m, err)
+		}
+		r.Result = *res
+		r.Result.Log()
+		result.Beams = append(result.Beams, r)
+	}
+
+	for _, s := range p.Sections {
+		r := p.checkSection(s, code, combinations)
+		if r.Error != "" {
+			slog.Warn("section failed", "id", r.ID, "file", r.File, "error", r.Error)
+		} else {
+			slog.Info("section", "id", r.ID, "file", r.File, "mu", r.Mu, "status", r.Status())
+		}
+		result.Sections = append(result.Sections, r)
+	}
+	return result
+}
+
+// checkSection analyzes a section and checks its capacity against Mu
+func (p *Project) checkSection(s SectionMember, code codes.DesignCode, combinations []nscp.LoadCombination) SectionResult {
+	r := SectionResult{ID: s.ID, File: s.File, Mu: s.Mu}
+	if len(s.Loads) > 0 {
+		demands := nscp.CalculateDemands(s.Loads.Effects(), combinations)
+		if i := nscp.GoverningDemand(demands, nscp.ActionMoment); i >= 0 {
+			r.Mu = math.Abs(demands[i].Moment.Moment)
+			r.MomentCombination = demands[i].Combination.ID
+		}
+	}
+
+	sec, err := section.LoadFromFile(p.SectionPath(s))
+	if err != nil {
+		r.Error = fmt.Sprintf("loading %s: %v", s.File, err)
+		return r
+	}
+	sec.Code = code
+	r.Name = sec.Name
+
+	analysis, err := sec.Analyze()
+	if err != nil {
+		r.Error = err.Error()
+		r.NoConvergence = errors.Is(err, section.ErrNoConvergence)
+		return r
+	}
+	r.Analysis = analysis
+	r.IsAdequate = analysis.PhiMn >= r.Mu
+	r.Message = analysis.Message
+	if !r.IsAdequate {
+		r.Message = "φMn < Mu | " + analysis.Message
+	}
+	return r
+}
+
+// SectionPath returns the path of the file of a section, which is relative
+// to the project file unless absolute
+func (p *Project) SectionPath(s SectionMember) string {
+	if filepath.IsAbs(s.File) {
+		return s.File
+	}
+	return filepath.Join(p.Dir, s.File)
+}
+
+// withDefaults fills the properties a beam omits from the project defaults
+func (p *Project) withDefaults(m batch.Member) batch.Member {
+	d := p.Defaults
+	if d == nil {
+		return m
+	}
+	if m.Type == "" {
+		m.Type = d.Type
+	}
+	if m.Cover == 0 {
+		m.Cover = d.Cover
+	}
+	if m.CoverComp == 0 {
+		m.CoverComp = d.CoverComp
+	}
+	if m.Fc == 0 {
+		m.Fc = d.Fc
+	}
+	if m.Fy == 0 && m.Grade == "" {
+		m.Fy, m.Grade = d.Fy, d.Grade
+	}
+	if m.StirrupDia == 0 {
+		m.StirrupDia = d.StirrupDia
+	}
+	if m.StirrupLegs == 0 {
+		m.StirrupLegs = d.StirrupLegs
+	}
+	if m.Fyt == 0 {
+		m.Fyt = d.Fyt
+	}
+	return m
+}
@@ -0,0 +1,238 @@
+package rebar
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Built-in catalogs shipped with gorcb
+//
+//go:embed data/*.json
+var builtinData embed.FS
+
+// DefaultName is the catalog used when none is selected
+const DefaultName = "pns"
+
+// Bar is a reinforcing bar size available from a catalog
+type Bar struct {
+	Designation  string  `json:"designation"`        // e.g. "20mm" or "#8"
+	Diameter     float64 `json:"diameter"`           // Nominal diameter (mm)
+	Area         float64 `json:"area"`               // Nominal area (mm²)
+	MassPerMeter float64 `json:"mass_per_meter"`     // Nominal mass (kg/m)
+	MinLength    float64 `json:"min_length"`         // Shortest available stock length (m)
+	MaxLength    float64 `json:"max_length"`         // Longest available stock length (m)
+	Flexural     bool    `json:"flexural,omitempty"` // Offered in main bar suggestions
+}
+
+// Label returns the bar designation as printed in reports, e.g. "φ20mm" or "#8"
+func (b Bar) Label() string {
+	if strings.HasPrefix(b.Designation, "#") {
+		return b.Designation
+	}
+	return "φ" + b.Designation
+}
+
+// Catalog is a named list of available bar sizes, ordered by diameter
+type Catalog struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Bars        []Bar  `json:"bars"`
+
+	// Replace discards the selected catalog instead of overriding its bars
+	// when the catalog is loaded as an override file
+	Replace bool `json:"replace,omitempty"`
+}
+
+// Validate checks that the catalog is named and every bar is fully defined
+func (c *Catalog) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("rebar catalog must have a name")
+	}
+	if len(c.Bars) == 0 {
+		return fmt.Errorf("rebar catalog %q has no bars", c.Name)
+	}
+	seen := make(map[string]bool)
+	for i, b := range c.Bars {
+		if b.Designation == "" {
+			return fmt.Errorf("bar %d of catalog %q must have a designation", i+1, c.Name)
+		}
+		key := strings.ToLower(b.Designation)
+		if seen[key] {
+			return fmt.Errorf("duplicate bar %q in catalog %q", b.Designation, c.Name)
+		}
+		seen[key] = true
+		if err := b.validate(); err != nil {
+			return fmt.Errorf("catalog %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+func (b Bar) validate() error {
+	if b.Diameter <= 0 || b.Area <= 0 {
+		return fmt.Errorf("bar %q: diameter and area must be positive", b.Designation)
+	}
+	if b.MassPerMeter < 0 {
+		return fmt.Errorf("bar %q: mass per meter must not be negative", b.Designation)
+	}
+	if b.MinLength < 0 || (b.MaxLength > 0 && b.MaxLength < b.MinLength) {
+		return fmt.Errorf("bar %q: invalid stock lengths %.2f to %.2f m", b.Designation, b.MinLength, b.MaxLength)
+	}
+	return nil
+}
+
+// Find returns the bar with the given designation (case-insensitive).
+// A leading "φ" and a missing "mm" suffix are accepted, so "20", "φ20"
+// and "20mm" all match the 20mm bar.
+func (c *Catalog) Find(designation string) (Bar, error) {
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(designation), "φ"))
+	for _, b := range c.Bars {
+		name := strings.ToLower(b.Designation)
+		if name == key || strings.TrimSuffix(name, "mm") == key {
+			return b, nil
+		}
+	}
+	return Bar{}, fmt.Errorf("bar %q not found in catalog %q", designation, c.Name)
+}
+
+// ByDiameter returns the bar with the given nominal diameter (mm)
+func (c *Catalog) ByDiameter(diameter float64) (Bar, bool) {
+	for _, b := range c.Bars {
+		if math.Abs(b.Diameter-diameter) < 0.05 {
+			return b, true
+		}
+	}
+	return Bar{}, false
+}
+
+// FlexuralBars returns the bars offered in main bar suggestions.
+// All bars are returned when none is marked flexural.
+func (c *Catalog) FlexuralBars() []Bar {
+	var bars []Bar
+	for _, b := range c.Bars {
+		if b.Flexural {
+			bars = append(bars, b)
+		}
+	}
+	if len(bars) == 0 {
+		return c.Bars
+	}
+	return bars
+}
+
+// Override replaces bars of c with same-designation bars of other and adds
+// the bars c does not have. The name and description of other are kept when set.
+func (c *Catalog) Override(other *Catalog) *Catalog {
+	merged := &Catalog{Name: c.Name, Description: c.Description}
+	if other.Name != "" {
+		merged.Name = other.Name
+	}
+	if other.Description != "" {
+		merged.Description = other.Description
+	}
+
+	index := make(map[string]int)
+	for _, b := range c.Bars {
+		index[strings.ToLower(b.Designation)] = len(merged.Bars)
+		merged.Bars = append(merged.Bars, b)
+	}
+	for _, b := range other.Bars {
+		key := strings.ToLower(b.Designation)
+		if i, ok := index[key]; ok {
+			merged.Bars[i] = b
+		} else {
+			index[key] = len(merged.Bars)
+			merged.Bars = append(merged.Bars, b)
+		}
+	}
+	merged.sortBars()
+	return merged
+}
+
+func (c *Catalog) sortBars() {
+	sort.SliceStable(c.Bars, func(i, j int) bool {
+		return c.Bars[i].Diameter < c.Bars[j].Diameter
+	})
+}
+
+// parseCatalog decodes and validates a catalog from JSON
+func parseCatalog(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	c.sortBars()
+	return &c, nil
+}
+
+// LoadFromFile reads a rebar catalog from a JSON file
+func LoadFromFile(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := parseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Get returns the built-in catalog with the given name (case-insensitive)
+func Get(name string) (*Catalog, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	data, err := builtinData.ReadFile("data/" + key + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown rebar catalog %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return parseCatalog(data)
+}
+
+// Names returns the names of the built-in catalogs in sorted order
+func Names() []string {
+	entries, _ := builtinData.ReadDir("data")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the default built-in catalog
+func Default() *Catalog {
+	c, err := Get(DefaultName)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Resolve selects a built-in catalog by name and applies the overrides in the
+// optional JSON file. A file with "replace": true is used on its own.
+func Resolve(name, overridePath string) (*Catalog, error) {
+	base, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if overridePath == "" {
+		return base, nil
+	}
+
+	custom, err := LoadFromFile(overridePath)
+	if err != nil {
+		return nil, err
+	}
+	if custom.Replace {
+		return custom, nil
+	}
+	return base.Override(custom), nil
+}
@@ -0,0 +1,58 @@
+package rebar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Grade is a named reinforcing steel grade
+type Grade struct {
+	Name        string   // e.g. "PNS 415" or "ASTM Grade 60"
+	Aliases     []string // Accepted lowercase keys, e.g. "415", "pns415"
+	Fy          float64  // Minimum yield strength (MPa)
+	Fu          float64  // Minimum tensile strength (MPa)
+	Es          float64  // Modulus of elasticity (MPa)
+	Description string
+}
+
+// YieldStrain returns the yield strain εy = fy/Es
+func (g Grade) YieldStrain() float64 {
+	return g.Fy / g.Es
+}
+
+// Grades lists the built-in steel grades: PNS 49 weldable and non-weldable
+// deformed bars and ASTM A615 grades (SI equivalents of A615M)
+var Grades = []Grade{
+	{Name: "PNS 230", Aliases: []string{"230", "pns230"}, Fy: 230, Fu: 390, Es: 200000, Description: "PNS 49 Grade 230 (formerly Grade 33)"},
+	{Name: "PNS 275", Aliases: []string{"275", "pns275"}, Fy: 275, Fu: 480, Es: 200000, Description: "PNS 49 Grade 275 (formerly Grade 40)"},
+	{Name: "PNS 415", Aliases: []string{"415", "pns415"}, Fy: 415, Fu: 620, Es: 200000, Description: "PNS 49 Grade 415 (formerly Grade 60)"},
+	{Name: "PNS 520", Aliases: []string{"520", "pns520"}, Fy: 520, Fu: 690, Es: 200000, Description: "PNS 49 Grade 520 (formerly Grade 75)"},
+	{Name: "ASTM Grade 40", Aliases: []string{"40", "grade40", "280"}, Fy: 280, Fu: 420, Es: 200000, Description: "ASTM A615 Grade 40 (A615M Grade 280)"},
+	{Name: "ASTM Grade 60", Aliases: []string{"60", "grade60", "420"}, Fy: 420, Fu: 620, Es: 200000, Description: "ASTM A615 Grade 60 (A615M Grade 420)"},
+	{Name: "ASTM Grade 75", Aliases: []string{"75", "grade75"}, Fy: 520, Fu: 690, Es: 200000, Description: "ASTM A615 Grade 75 (A615M Grade 520)"},
+	{Name: "ASTM Grade 80", Aliases: []string{"80", "grade80", "550"}, Fy: 550, Fu: 725, Es: 200000, Description: "ASTM A615 Grade 80 (A615M Grade 550)"},
+}
+
+// GradeNames returns the primary key (first alias) of every grade
+func GradeNames() []string {
+	names := make([]string, len(Grades))
+	for i, g := range Grades {
+		names[i] = g.Aliases[0]
+	}
+	return names
+}
+
+// FindGrade returns the grade matching a name or alias (case-insensitive,
+// spaces ignored), e.g. "60", "Grade 60", "PNS 415" or "pns415"
+func FindGrade(name string) (Grade, error) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
+	key = strings.TrimPrefix(key, "astm")
+	for _, g := range Grades {
+		for _, alias := range g.Aliases {
+			if key == alias {
+				return g, nil
+			}
+		}
+	}
+	return Grade{}, fmt.Errorf("unknown steel grade %q (available: %s)", name, strings.Join(GradeNames(), ", "))
+}
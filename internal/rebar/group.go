@@ -0,0 +1,82 @@
+package rebar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Perimeter returns the nominal perimeter of the bar (mm), used for bond
+func (b Bar) Perimeter() float64 {
+	return math.Pi * b.Diameter
+}
+
+// Group is a number of identical bars, e.g. 4-20mm
+type Group struct {
+	Count int
+	Bar   Bar
+}
+
+// Area returns the total area of the bars of the group (mm²)
+func (g Group) Area() float64 {
+	return float64(g.Count) * g.Bar.Area
+}
+
+// Mass returns the total mass of the bars of the group over a length (kg),
+// given in m
+func (g Group) Mass(length float64) float64 {
+	return float64(g.Count) * g.Bar.MassPerMeter * length
+}
+
+// String returns the group as written in reports, e.g. "4 - φ20mm"
+func (g Group) String() string {
+	return fmt.Sprintf("%d - %s", g.Count, g.Bar.Label())
+}
+
+// ParseGroup parses a group of bars of the catalog written as count-bar,
+// e.g. "4-20", "4-20mm" or "2-#8", or a single bar, e.g. "20"
+func (c *Catalog) ParseGroup(spec string) (Group, error) {
+	count, designation := 1, strings.TrimSpace(spec)
+	if n, bar, ok := strings.Cut(designation, "-"); ok {
+		v, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil || v < 1 {
+			return Group{}, fmt.Errorf("invalid bar count in %q (use count-bar, e.g. 4-20)", spec)
+		}
+		count, designation = v, bar
+	}
+	b, err := c.Find(designation)
+	if err != nil {
+		return Group{}, err
+	}
+	return Group{Count: count, Bar: b}, nil
+}
+
+// Needed returns, for every bar of the catalog, the fewest bars providing at
+// least asRequired (mm²)
+func (c *Catalog) Needed(asRequired float64) []Group {
+	groups := make([]Group, len(c.Bars))
+	for i, b := range c.Bars {
+		groups[i] = Group{Count: max(int(math.Ceil(asRequired/b.Area)), 1), Bar: b}
+	}
+	return groups
+}
+
+// Only returns the catalog restricted to the bars of the given designations,
+// e.g. the sizes available on a project
+func (c *Catalog) Only(designations []string) (*Catalog, error) {
+	only := &Catalog{Name: c.Name, Description: c.Description}
+	seen := make(map[string]bool)
+	for _, d := range designations {
+		b, err := c.Find(d)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[b.Designation] {
+			seen[b.Designation] = true
+			only.Bars = append(only.Bars, b)
+		}
+	}
+	only.sortBars()
+	return only, nil
+}
@@ -0,0 +1,32 @@
+package rebar
+
+// Suggestion is a number of identical bars providing a required steel area
+type Suggestion struct {
+	Bar   Bar
+	Count int
+	Area  float64 // Provided area (mm²)
+	Mass  float64 // Steel mass per meter of member (kg/m)
+}
+
+// Suggest returns, for every flexural bar size, the smallest number of bars
+// providing at least asRequired, keeping counts within minCount..maxCount
+func (c *Catalog) Suggest(asRequired float64, minCount, maxCount int) []Suggestion {
+	var suggestions []Suggestion
+	for _, b := range c.FlexuralBars() {
+		count := int(asRequired/b.Area) + 1
+		if count < minCount || count > maxCount {
+			continue
+		}
+		area := float64(count) * b.Area
+		if area < asRequired {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Bar:   b,
+			Count: count,
+			Area:  area,
+			Mass:  float64(count) * b.MassPerMeter,
+		})
+	}
+	return suggestions
+}
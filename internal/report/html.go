@@ -0,0 +1,85 @@
+package report
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"text/template/parse"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+)
+
+// htmlFuncs are the functions of the HTML report templates
+var htmlFuncs = template.FuncMap{
+	"status":   statusClass,
+	"emphasis": emphasis,
+	"svg":      inlineSVG,
+	"number":   func(i int) int { return i + 1 },
+	"t":        i18n.T,
+	"lang":     i18n.Lang,
+}
+
+// HTML returns the document as a self-contained HTML page, laid out by the
+// default template or the one of the document's Template
+func (d *Document) HTML() ([]byte, error) {
+	override, err := d.templateFile(FormatHTML)
+	if err != nil {
+		return nil, err
+	}
+	root := templateName(FormatHTML)
+	t, err := template.New(root).Funcs(htmlFuncs).ParseFS(templates, "templates/"+root)
+	if err == nil && override != "" {
+		t, err = t.ParseFiles(override)
+	}
+	if err != nil {
+		return nil, err
+	}
+	name := entry(root, override, func(name string) *parse.Tree {
+		if t := t.Lookup(name); t != nil {
+			return t.Tree
+		}
+		return nil
+	})
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// statusClass returns the style class of a check status cell
+func statusClass(cell string) string {
+	switch cell {
+	case "OK":
+		return "pass"
+	case "NG", "ERROR":
+		return "fail"
+	case "Transition zone", i18n.T("Transition zone"):
+		return "warn"
+	}
+	return ""
+}
+
+// emphasis escapes a paragraph and renders its **bold** spans
+func emphasis(text string) template.HTML {
+	parts := strings.Split(text, "**")
+	var sb strings.Builder
+	for i, part := range parts {
+		escaped := template.HTMLEscapeString(part)
+		if i%2 == 1 && i < len(parts)-1 {
+			escaped = "<strong>" + escaped + "</strong>"
+		}
+		sb.WriteString(escaped)
+	}
+	return template.HTML(sb.String())
+}
+
+// inlineSVG returns an SVG document for embedding in HTML, without its XML
+// declaration
+func inlineSVG(svg []byte) template.HTML {
+	s := string(svg)
+	if i := strings.Index(s, "<svg"); i > 0 {
+		s = s[i:]
+	}
+	return template.HTML(s)
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+)
+
+// markdownFuncs are the functions of the Markdown report templates
+var markdownFuncs = template.FuncMap{
+	"inline": inline,
+	"repeat": strings.Repeat,
+	"number": func(i int) int { return i + 1 },
+	"row":    markdownRow,
+	"rule": func(columns int) string {
+		return "| " + strings.TrimSuffix(strings.Repeat("--- | ", columns), " ") + "\n"
+	},
+	"link": func(path string) string { return strings.ReplaceAll(path, " ", "%20") },
+	"t":    i18n.T,
+}
+
+// Markdown returns the document as GitHub-flavored Markdown, laid out by the
+// default template or the one of the document's Template
+func (d *Document) Markdown() ([]byte, error) {
+	override, err := d.templateFile(FormatMarkdown)
+	if err != nil {
+		return nil, err
+	}
+	root := templateName(FormatMarkdown)
+	t, err := template.New(root).Funcs(markdownFuncs).ParseFS(templates, "templates/"+root)
+	if err == nil && override != "" {
+		t, err = t.ParseFiles(override)
+	}
+	if err != nil {
+		return nil, err
+	}
+	name := entry(root, override, func(name string) *parse.Tree {
+		if t := t.Lookup(name); t != nil {
+			return t.Tree
+		}
+		return nil
+	})
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// markdownRow returns a table row, escaping the pipes of its cells
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.ReplaceAll(inline(c), "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+// inline joins the lines of a text into one line
+func inline(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\n", " ")), " ")
+}
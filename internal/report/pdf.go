@@ -0,0 +1,347 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"codeberg.org/go-fonts/liberation/liberationmonoregular"
+	"codeberg.org/go-fonts/liberation/liberationsansbold"
+	"codeberg.org/go-fonts/liberation/liberationsansregular"
+	"codeberg.org/go-pdf/fpdf"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+)
+
+// signatories are the roles signing off a PDF calculation sheet
+var signatories = []string{"Prepared by", "Checked by", "Approved by"}
+
+// Fill colors of the PDF verdict banner, table headers and status cells,
+// matching the HTML report
+var pdfFills = map[string][3]int{
+	"pass":   {227, 244, 227},
+	"fail":   {251, 227, 227},
+	"warn":   {253, 243, 220},
+	"header": {243, 243, 243},
+}
+
+const (
+	pdfMargin = 20.0 // page margins (mm)
+	pdfLine   = 5.0  // line height of body text (mm)
+	pdfCell   = 4.5  // line height of table cells (mm)
+	pdfPad    = 1.5  // horizontal padding of table cells (mm)
+)
+
+// pdfWriter lays out the blocks of a document on A4 pages
+type pdfWriter struct {
+	*fpdf.Fpdf
+	width  float64 // width between the margins
+	images int
+}
+
+// PDF returns the document as an A4 calculation sheet with its diagrams
+// embedded and a signature block at the end
+func (d *Document) PDF() ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("sans", "", liberationsansregular.TTF)
+	pdf.AddUTF8FontFromBytes("sans", "B", liberationsansbold.TTF)
+	pdf.AddUTF8FontFromBytes("mono", "", liberationmonoregular.TTF)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetCellMargin(pdfPad)
+	pdf.SetTitle(d.Title, true)
+	pdf.AliasNbPages("")
+
+	pageWidth, _ := pdf.GetPageSize()
+	w := &pdfWriter{Fpdf: pdf, width: pageWidth - 2*pdfMargin}
+	pdf.SetFooterFunc(func() {
+		w.SetY(-pdfMargin + 5)
+		w.SetFont("sans", "", 8)
+		w.SetTextColor(110, 110, 110)
+		w.CellFormat(w.width/2, 5, inline(d.Title), "T", 0, "L", false, 0, "")
+		w.CellFormat(w.width/2, 5, i18n.T(fmt.Sprintf("Page %d of {nb}", w.PageNo())), "T", 0, "R", false, 0, "")
+		w.SetTextColor(0, 0, 0)
+	})
+
+	w.AddPage()
+	w.SetFont("sans", "B", 16)
+	w.MultiCell(0, 8, inline(d.Title), "", "L", false)
+	w.rule(0.6)
+	w.Ln(3)
+	if d.Verdict != nil {
+		w.verdict(d.Verdict)
+	}
+	for _, b := range d.Blocks {
+		switch b := b.(type) {
+		case Heading:
+			w.heading(b.Level, b.Text)
+		case Paragraph:
+			w.paragraph(b.Text)
+		case Table:
+			w.table(b)
+		case List:
+			w.list(b.Items)
+		case Steps:
+			w.steps(b)
+		case Image:
+			w.image(b)
+		}
+	}
+	w.signatures()
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bottom returns the lowest position of content on a page
+func (w *pdfWriter) bottom() float64 {
+	_, height := w.GetPageSize()
+	return height - pdfMargin
+}
+
+// keep starts a new page unless height fits on the current one
+func (w *pdfWriter) keep(height float64) {
+	if w.GetY()+height > w.bottom() {
+		w.AddPage()
+	}
+}
+
+// fill sets the fill color of a status, e.g. "pass"
+func (w *pdfWriter) fill(status string) {
+	c := pdfFills[status]
+	w.SetFillColor(c[0], c[1], c[2])
+}
+
+// rule draws a horizontal line across the page below the text
+func (w *pdfWriter) rule(thickness float64) {
+	y := w.GetY() + 1
+	w.SetLineWidth(thickness)
+	w.Line(pdfMargin, y, pdfMargin+w.width, y)
+	w.SetLineWidth(0.2)
+	w.SetY(y)
+}
+
+func (w *pdfWriter) verdict(v *Verdict) {
+	status, label := "fail", i18n.T("FAIL")
+	if v.Passed {
+		status, label = "pass", i18n.T("PASS")
+	}
+	w.fill(status)
+	w.SetFont("sans", "B", 11)
+	w.MultiCell(0, 8, label+" — "+inline(v.Text), "", "L", true)
+	w.Ln(3)
+}
+
+func (w *pdfWriter) heading(level int, text string) {
+	size := 13.0
+	if level > 2 {
+		size = 11
+	}
+	// Keep a heading together with the start of its section
+	w.keep(25)
+	w.Ln(3)
+	w.SetFont("sans", "B", size)
+	w.MultiCell(0, size/2, inline(text), "", "L", false)
+	if level == 2 {
+		w.SetDrawColor(180, 180, 180)
+		w.rule(0.2)
+		w.SetDrawColor(0, 0, 0)
+	}
+	w.Ln(2)
+}
+
+// paragraph writes a line of text with its **bold** spans
+func (w *pdfWriter) paragraph(text string) {
+	for i, part := range strings.Split(inline(text), "**") {
+		style := ""
+		if i%2 == 1 {
+			style = "B"
+		}
+		w.SetFont("sans", style, 10)
+		w.Write(pdfLine, part)
+	}
+	w.Ln(pdfLine + 2)
+}
+
+func (w *pdfWriter) table(t Table) {
+	widths := w.columns(t)
+	w.row(t.Header, widths, true)
+	for _, row := range t.Rows {
+		// Repeat the header on the next page of a long table
+		if w.GetY()+w.rowHeight(row, widths) > w.bottom() {
+			w.AddPage()
+			w.row(t.Header, widths, true)
+		}
+		w.row(row, widths, false)
+	}
+	w.Ln(3)
+}
+
+// columns sizes the columns of a table by their widest cells, narrowing them
+// in proportion when the table is wider than the page
+func (w *pdfWriter) columns(t Table) []float64 {
+	widths := make([]float64, len(t.Header))
+	measure := func(cells []string, style string) {
+		w.SetFont("sans", style, 9)
+		for i := range widths {
+			if i < len(cells) {
+				widths[i] = max(widths[i], w.GetStringWidth(inline(cells[i]))+2*pdfPad+0.5)
+			}
+		}
+	}
+	measure(t.Header, "B")
+	for _, row := range t.Rows {
+		measure(row, "")
+	}
+	total := 0.0
+	for _, width := range widths {
+		total += width
+	}
+	if total > w.width {
+		for i := range widths {
+			widths[i] *= w.width / total
+		}
+	}
+	return widths
+}
+
+// cellLines wraps the cells of a row to the widths of their columns
+func (w *pdfWriter) cellLines(cells []string, widths []float64) [][]string {
+	lines := make([][]string, len(widths))
+	for i, width := range widths {
+		if i < len(cells) {
+			lines[i] = w.SplitText(inline(cells[i]), width)
+		}
+	}
+	return lines
+}
+
+// rowHeight returns the height of a table row with its cells wrapped
+func (w *pdfWriter) rowHeight(cells []string, widths []float64) float64 {
+	w.SetFont("sans", "", 9)
+	n := 1
+	for _, lines := range w.cellLines(cells, widths) {
+		n = max(n, len(lines))
+	}
+	return float64(n) * pdfCell
+}
+
+func (w *pdfWriter) row(cells []string, widths []float64, header bool) {
+	style := ""
+	if header {
+		style = "B"
+	}
+	height := w.rowHeight(cells, widths)
+	w.SetFont("sans", style, 9)
+	lines := w.cellLines(cells, widths)
+
+	x, y := pdfMargin, w.GetY()
+	for i, width := range widths {
+		status := ""
+		if header {
+			status = "header"
+		} else if i < len(cells) {
+			status = statusClass(cells[i])
+		}
+		if status != "" {
+			w.fill(status)
+			w.Rect(x, y, width, height, "FD")
+		} else {
+			w.Rect(x, y, width, height, "D")
+		}
+		for k, line := range lines[i] {
+			w.SetXY(x, y+float64(k)*pdfCell)
+			w.CellFormat(width, pdfCell, line, "", 0, "L", false, 0, "")
+		}
+		x += width
+	}
+	w.SetXY(pdfMargin, y+height)
+}
+
+func (w *pdfWriter) list(items []string) {
+	w.SetFont("sans", "", 10)
+	for _, item := range items {
+		w.CellFormat(5, pdfLine, "•", "", 0, "L", false, 0, "")
+		w.MultiCell(0, pdfLine, inline(item), "", "L", false)
+	}
+	w.Ln(2)
+}
+
+func (w *pdfWriter) steps(s Steps) {
+	w.SetFont("sans", "B", 10)
+	w.MultiCell(0, pdfLine, inline(s.Title), "", "L", false)
+	w.Ln(1)
+	for i, step := range s.Steps {
+		w.keep(pdfLine + 4*float64(len(step.Lines)))
+		w.SetFont("sans", "B", 9)
+		w.Write(pdfLine, fmt.Sprintf("%d. %s", i+1, inline(step.Title)))
+		if step.Cite != "" {
+			w.SetFont("sans", "", 9)
+			w.SetTextColor(102, 102, 102)
+			w.Write(pdfLine, "  "+step.Cite)
+			w.SetTextColor(0, 0, 0)
+		}
+		w.Ln(pdfLine)
+		w.SetFont("mono", "", 8)
+		for _, line := range step.Lines {
+			w.SetX(pdfMargin + 6)
+			w.MultiCell(0, 4, line, "", "L", false)
+		}
+		w.Ln(1)
+	}
+	w.Ln(2)
+}
+
+// image embeds a PNG diagram centered on the page with its caption. Linked
+// image files are not embedded.
+func (w *pdfWriter) image(img Image) {
+	if img.PNG == nil {
+		return
+	}
+	w.images++
+	name := fmt.Sprintf("diagram%d", w.images)
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	info := w.RegisterImageOptionsReader(name, options, bytes.NewReader(img.PNG))
+	if info == nil || w.Err() {
+		return
+	}
+	width := min(w.width, 140)
+	height := width * info.Height() / info.Width()
+	if height > 120 {
+		width, height = width*120/height, 120
+	}
+	w.keep(height + pdfLine)
+	w.ImageOptions(name, pdfMargin+(w.width-width)/2, w.GetY(), width, height, false, options, 0, "")
+	w.SetY(w.GetY() + height + 1)
+	w.SetFont("sans", "", 8)
+	w.SetTextColor(102, 102, 102)
+	w.CellFormat(0, 4, inline(img.Alt), "", 1, "C", false, 0, "")
+	w.SetTextColor(0, 0, 0)
+	w.Ln(3)
+}
+
+// signatures adds the sign-off block of the calculation sheet
+func (w *pdfWriter) signatures() {
+	w.keep(50)
+	w.heading(2, i18n.T("Sign-off"))
+	w.Ln(2)
+	column := w.width / float64(len(signatories))
+	y := w.GetY()
+	for i, role := range signatories {
+		x := pdfMargin + float64(i)*column
+		width := column - 8
+		w.SetXY(x, y)
+		w.SetFont("sans", "B", 9)
+		w.CellFormat(width, 5, i18n.T(role), "", 0, "L", false, 0, "")
+		w.Line(x, y+20, x+width, y+20)
+		w.SetFont("sans", "", 8)
+		w.SetXY(x, y+21)
+		w.CellFormat(width, 4, i18n.T("Signature over printed name"), "", 0, "L", false, 0, "")
+		w.Line(x, y+32, x+width, y+32)
+		w.SetXY(x, y+33)
+		w.CellFormat(width, 4, i18n.T("Date"), "", 0, "L", false, 0, "")
+	}
+	w.SetXY(pdfMargin, y+40)
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+)
+
+// Report formats, selected by the extension of the report file
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatPDF      = "pdf"
+)
+
+// Document is a design report: a title and the overall verdict followed by
+// headings, paragraphs, tables, lists, calculation steps and images. The
+// text added is translated into the language selected with i18n.Set.
+type Document struct {
+	Title   string
+	Verdict *Verdict
+	Blocks  []Block
+
+	// Template file or directory overriding the layout of Markdown and HTML
+	// reports (see TemplateFile)
+	Template string
+}
+
+// Verdict is the pass/fail summary of a report
+type Verdict struct {
+	Passed bool
+	Text   string
+}
+
+// Block is one element of a document
+type Block interface {
+	// Kind names the block type for templates: heading, paragraph, table,
+	// list, steps or image
+	Kind() string
+}
+
+// Heading starts a section (level 2) or a subsection (level 3)
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// Paragraph is a line of text
+type Paragraph struct {
+	Text string
+}
+
+// Table is a table of text cells with a header row
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// List is a bulleted list
+type List struct {
+	Items []string
+}
+
+// Steps lists the numbered steps of a calculation
+type Steps struct {
+	Title string
+	Steps []Step
+}
+
+// Step is one step of a calculation with the clause it follows, e.g.
+// "[Section 409.6.1.2]", and its equations
+type Step struct {
+	Title string
+	Cite  string
+	Lines []string
+}
+
+// Image is a diagram: an image file with its path relative to the report,
+// or an SVG document or PNG image embedded in single-file formats
+type Image struct {
+	Alt  string
+	Path string
+	SVG  []byte
+	PNG  []byte
+}
+
+func (Heading) Kind() string   { return "heading" }
+func (Paragraph) Kind() string { return "paragraph" }
+func (Table) Kind() string     { return "table" }
+func (List) Kind() string      { return "list" }
+func (Steps) Kind() string     { return "steps" }
+func (Image) Kind() string     { return "image" }
+
+// New returns an empty document with a title
+func New(title string) *Document {
+	return &Document{Title: i18n.T(title)}
+}
+
+// Section starts a section of the document
+func (d *Document) Section(title string) {
+	d.Blocks = append(d.Blocks, Heading{Level: 2, Text: i18n.T(title)})
+}
+
+// Subsection starts a subsection of the current section
+func (d *Document) Subsection(title string) {
+	d.Blocks = append(d.Blocks, Heading{Level: 3, Text: i18n.T(title)})
+}
+
+// Paragraph adds a line of text
+func (d *Document) Paragraph(text string) {
+	d.Blocks = append(d.Blocks, Paragraph{Text: i18n.T(text)})
+}
+
+// Fields adds a two-column table of items and their values, skipping nil
+// rows of optional items
+func (d *Document) Fields(rows ...[]string) {
+	var fields [][]string
+	for _, row := range rows {
+		if row != nil {
+			fields = append(fields, append([]string{i18n.T(row[0])}, row[1:]...))
+		}
+	}
+	d.Table([]string{"Item", "Value"}, fields)
+}
+
+// Table adds a table, skipped when it has no rows
+func (d *Document) Table(header []string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	d.Blocks = append(d.Blocks, Table{Header: translate(header), Rows: rowsOf(rows)})
+}
+
+// List adds a bulleted list, skipped when it has no items
+func (d *Document) List(items ...string) {
+	if len(items) == 0 {
+		return
+	}
+	d.Blocks = append(d.Blocks, List{Items: translate(items)})
+}
+
+// Steps adds the steps of a calculation, skipped when there are none
+func (d *Document) Steps(title string, steps []Step) {
+	if len(steps) == 0 {
+		return
+	}
+	translated := make([]Step, len(steps))
+	for i, step := range steps {
+		step.Title = i18n.T(step.Title)
+		translated[i] = step
+	}
+	d.Blocks = append(d.Blocks, Steps{Title: i18n.T(title), Steps: translated})
+}
+
+// Image adds a link to an image file
+func (d *Document) Image(alt, path string) {
+	d.Blocks = append(d.Blocks, Image{Alt: i18n.T(alt), Path: path})
+}
+
+// EmbedSVG adds an SVG diagram embedded in the document
+func (d *Document) EmbedSVG(alt string, svg []byte) {
+	d.Blocks = append(d.Blocks, Image{Alt: i18n.T(alt), SVG: svg})
+}
+
+// EmbedPNG adds a PNG diagram embedded in the document
+func (d *Document) EmbedPNG(alt string, png []byte) {
+	d.Blocks = append(d.Blocks, Image{Alt: i18n.T(alt), PNG: png})
+}
+
+// Conclude sets the pass/fail verdict of the report
+func (d *Document) Conclude(passed bool, text string) {
+	d.Verdict = &Verdict{Passed: passed, Text: i18n.T(text)}
+}
+
+// translate returns the translations of a row of text
+func translate(texts []string) []string {
+	translated := make([]string, len(texts))
+	for i, t := range texts {
+		translated[i] = i18n.T(t)
+	}
+	return translated
+}
+
+// rowsOf returns the translations of the rows of a table
+func rowsOf(rows [][]string) [][]string {
+	translated := make([][]string, len(rows))
+	for i, row := range rows {
+		translated[i] = translate(row)
+	}
+	return translated
+}
+
+// FormatOf returns the report format of a file from its extension
+func FormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".html", ".htm":
+		return FormatHTML, nil
+	case ".pdf":
+		return FormatPDF, nil
+	}
+	return "", fmt.Errorf("unsupported report file %q (use .md, .html or .pdf)", path)
+}
+
+// WriteFile writes the document to a file in the format of its extension,
+// creating its directory when missing
+func (d *Document) WriteFile(path string) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	var content []byte
+	switch format {
+	case FormatHTML:
+		content, err = d.HTML()
+	case FormatPDF:
+		content, err = d.PDF()
+	default:
+		content, err = d.Markdown()
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
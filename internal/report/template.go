@@ -0,0 +1,112 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template/parse"
+
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+)
+
+// templates holds the default layouts of the Markdown and HTML reports
+//
+//go:embed templates
+var templates embed.FS
+
+// Section is a level-2 heading of a document with the blocks up to the next
+// one. The blocks before the first heading form a section without a title.
+type Section struct {
+	Title  string
+	Blocks []Block
+}
+
+// Sections returns the document split at its level-2 headings
+func (d *Document) Sections() []Section {
+	sections := []Section{{}}
+	for _, b := range d.Blocks {
+		if h, ok := b.(Heading); ok && h.Level == 2 {
+			sections = append(sections, Section{Title: h.Text})
+			continue
+		}
+		last := &sections[len(sections)-1]
+		last.Blocks = append(last.Blocks, b)
+	}
+	if len(sections[0].Blocks) == 0 {
+		sections = sections[1:]
+	}
+	return sections
+}
+
+// SectionNamed returns the section of a title, in English or translated, or
+// nil when the document has none, for templates that reorder the sections
+func (d *Document) SectionNamed(title string) *Section {
+	for _, s := range d.Sections() {
+		if s.Title == title || s.Title == i18n.T(title) {
+			return &s
+		}
+	}
+	return nil
+}
+
+// templateName returns the name of the default template of a format, e.g.
+// "report.html.tmpl"
+func templateName(format string) string {
+	if format == FormatHTML {
+		return "report.html.tmpl"
+	}
+	return "report.md.tmpl"
+}
+
+// Templated reports whether a report format is rendered from a template
+func Templated(format string) bool {
+	return format == FormatMarkdown || format == FormatHTML
+}
+
+// TemplateFile returns the template overriding the default layout of a
+// format: path itself when it is a file, e.g. "company.html.tmpl", or the
+// template of the format in a directory, e.g. "templates/report.html.tmpl".
+// It returns "" when a directory has no template for the format.
+func TemplateFile(path, format string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		file := filepath.Join(path, templateName(format))
+		if _, err := os.Stat(file); err != nil {
+			return "", nil
+		}
+		return file, nil
+	}
+	if f, _ := FormatOf(strings.TrimSuffix(path, ".tmpl")); f != format {
+		return "", fmt.Errorf("template %q does not lay out %s reports (name it *%s)", path, format,
+			strings.TrimPrefix(templateName(format), "report"))
+	}
+	return path, nil
+}
+
+// templateFile returns the template overriding the default layout of a
+// format, or "" for the default
+func (d *Document) templateFile(format string) (string, error) {
+	if d.Template == "" {
+		return "", nil
+	}
+	return TemplateFile(d.Template, format)
+}
+
+// entry returns the template to execute after parsing an override file: the
+// file itself when it has content outside its {{define}} blocks, otherwise
+// the default template with the blocks the file redefines
+func entry(root, override string, lookup func(name string) *parse.Tree) string {
+	if override == "" {
+		return root
+	}
+	name := filepath.Base(override)
+	if tree := lookup(name); name != root && tree != nil && !parse.IsEmptyTree(tree.Root) {
+		return name
+	}
+	return root
+}
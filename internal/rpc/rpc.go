@@ -0,0 +1,255 @@
+// Package rpc serves the beam, batch and section engines over gRPC, with the
+// services of api/gorcb/v1/gorcb.proto.
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	gorcbv1 "github.com/alexiusacademia/gorcb/api/gorcb/v1"
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/section"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server of the beam and section services with a
+// design code
+func NewServer(code codes.DesignCode) *grpc.Server {
+	s := grpc.NewServer()
+	Register(s, code)
+	return s
+}
+
+// Register adds the beam and section services with a design code to a gRPC
+// server
+func Register(s grpc.ServiceRegistrar, code codes.DesignCode) {
+	code = codes.OrDefault(code)
+	gorcbv1.RegisterBeamServiceServer(s, &beamService{code: code})
+	gorcbv1.RegisterSectionServiceServer(s, &sectionService{code: code})
+}
+
+// beamService designs and analyzes batch members
+type beamService struct {
+	gorcbv1.UnimplementedBeamServiceServer
+	code codes.DesignCode
+}
+
+// Design returns the steel required for the factored actions of a member,
+// ignoring any provided steel
+func (s *beamService) Design(_ context.Context, m *gorcbv1.Member) (*gorcbv1.Result, error) {
+	member := fromMember(m)
+	member.As, member.Asc = 0, 0
+	return s.run(member)
+}
+
+// Analyze returns the capacity of a member with its provided steel
+func (s *beamService) Analyze(_ context.Context, m *gorcbv1.Member) (*gorcbv1.Result, error) {
+	member := fromMember(m)
+	if member.As <= 0 {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "member %q: give As to analyze", member.ID)
+	}
+	return s.run(member)
+}
+
+func (s *beamService) run(m batch.Member) (*gorcbv1.Result, error) {
+	if err := m.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	result, err := batch.RunMember(m, s.code)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return toResult(*result), nil
+}
+
+// Batch designs or analyzes every member of a request. Members that fail
+// carry the error in their result, as in a batch file.
+func (s *beamService) Batch(_ context.Context, req *gorcbv1.BatchRequest) (*gorcbv1.BatchResponse, error) {
+	if len(req.GetMembers()) == 0 {
+		return nil, status.Error(grpccodes.InvalidArgument, "members must not be empty")
+	}
+	members := make([]batch.Member, len(req.GetMembers()))
+	for i, m := range req.GetMembers() {
+		members[i] = fromMember(m)
+		if err := members[i].Validate(); err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "members[%d]: %v", i, err)
+		}
+	}
+	resp := &gorcbv1.BatchResponse{}
+	for _, r := range batch.Run(members, s.code) {
+		resp.Results = append(resp.Results, toResult(r))
+	}
+	return resp, nil
+}
+
+// sectionService analyzes polygon sections
+type sectionService struct {
+	gorcbv1.UnimplementedSectionServiceServer
+	code codes.DesignCode
+}
+
+// Analyze returns the flexural analysis of the section of a request, given
+// as a message or as the contents of a section file
+func (s *sectionService) Analyze(_ context.Context, req *gorcbv1.SectionRequest) (*gorcbv1.SectionResult, error) {
+	var sec *section.Section
+	switch src := req.GetSource().(type) {
+	case *gorcbv1.SectionRequest_Section:
+		sec = fromSection(src.Section)
+	case *gorcbv1.SectionRequest_File:
+		var err error
+		if sec, err = section.Parse(src.File, true); err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "parsing the section file: %v", err)
+		}
+	default:
+		return nil, status.Error(grpccodes.InvalidArgument, "give the section or the contents of a section file")
+	}
+	if err := sec.Prepare(); err != nil {
+		return nil, invalid(err)
+	}
+	sec.Code = s.code
+	result, err := sec.Analyze()
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return toSectionResult(result), nil
+}
+
+// invalid returns the status of an error of the design engines on the input:
+// FailedPrecondition for a solver that did not converge, InvalidArgument for
+// others
+func invalid(err error) error {
+	if errors.Is(err, beam.ErrNoConvergence) || errors.Is(err, section.ErrNoConvergence) {
+		return status.Error(grpccodes.FailedPrecondition, err.Error())
+	}
+	return status.Error(grpccodes.InvalidArgument, err.Error())
+}
+
+func fromMember(m *gorcbv1.Member) batch.Member {
+	return batch.Member{
+		ID:          m.GetId(),
+		Type:        m.GetType(),
+		Width:       m.GetWidth(),
+		Height:      m.GetHeight(),
+		Cover:       m.GetCover(),
+		CoverComp:   m.GetCoverComp(),
+		Fc:          m.GetFc(),
+		Fy:          m.GetFy(),
+		Grade:       m.GetGrade(),
+		Mu:          m.GetMu(),
+		Vu:          m.GetVu(),
+		As:          m.GetAs(),
+		Asc:         m.GetAsc(),
+		StirrupDia:  m.GetStirrupDia(),
+		StirrupLegs: int(m.GetStirrupLegs()),
+		Fyt:         m.GetFyt(),
+		Lambda:      m.GetLambda(),
+	}
+}
+
+func toMember(m batch.Member) *gorcbv1.Member {
+	return &gorcbv1.Member{
+		Id:          m.ID,
+		Type:        m.Type,
+		Width:       m.Width,
+		Height:      m.Height,
+		Cover:       m.Cover,
+		CoverComp:   m.CoverComp,
+		Fc:          m.Fc,
+		Fy:          m.Fy,
+		Grade:       m.Grade,
+		Mu:          m.Mu,
+		Vu:          m.Vu,
+		As:          m.As,
+		Asc:         m.Asc,
+		StirrupDia:  m.StirrupDia,
+		StirrupLegs: int32(m.StirrupLegs),
+		Fyt:         m.Fyt,
+		Lambda:      m.Lambda,
+	}
+}
+
+func toResult(r batch.Result) *gorcbv1.Result {
+	return &gorcbv1.Result{
+		Member:        toMember(r.Member),
+		Mode:          r.Mode,
+		Fc:            r.Fc,
+		Fy:            r.Fy,
+		As:            r.As,
+		Asc:           r.Asc,
+		EpsilonT:      r.EpsilonT,
+		Phi:           r.Phi,
+		PhiMn:         r.PhiMn,
+		Rho:           r.Rho,
+		RhoMax:        r.RhoMax,
+		Adequate:      r.IsAdequate,
+		Message:       r.Message,
+		Error:         r.Error,
+		NoConvergence: r.NoConvergence,
+	}
+}
+
+func fromSection(s *gorcbv1.Section) *section.Section {
+	sec := &section.Section{
+		Name:        s.GetName(),
+		Description: s.GetDescription(),
+		Fc:          s.GetFc(),
+		Fy:          s.GetFy(),
+		Lambda:      s.GetLambda(),
+		Vertices:    fromPoints(s.GetVertices()),
+	}
+	for _, h := range s.GetHoles() {
+		sec.Holes = append(sec.Holes, fromPoints(h.GetVertices()))
+	}
+	for _, l := range s.GetReinforcement() {
+		sec.Reinforcement = append(sec.Reinforcement, section.RebarLayer{
+			Y: l.GetY(), Area: l.GetArea(), Description: l.GetDescription(), Type: l.GetType(),
+		})
+	}
+	return sec
+}
+
+func fromPoints(points []*gorcbv1.Point) []section.Point {
+	out := make([]section.Point, len(points))
+	for i, p := range points {
+		out[i] = section.Point{X: p.GetX(), Y: p.GetY()}
+	}
+	return out
+}
+
+func toSectionResult(r *section.AnalysisResult) *gorcbv1.SectionResult {
+	out := &gorcbv1.SectionResult{
+		C:                 r.C,
+		A:                 r.A,
+		Beta1:             r.Beta1,
+		EpsilonT:          r.EpsilonT,
+		Cc:                r.Cc,
+		Cs:                r.Cs,
+		T:                 r.T,
+		Phi:               r.Phi,
+		Mn:                r.Mn,
+		PhiMn:             r.PhiMn,
+		Fr:                r.Fr,
+		Mcr:               r.Mcr,
+		TensionControlled: r.IsTensionControlled,
+		BarFracture:       r.BarFracture,
+		Message:           r.Message,
+	}
+	for _, l := range r.SteelLayers {
+		out.SteelLayers = append(out.SteelLayers, &gorcbv1.SteelLayerResult{
+			Y:           l.Y,
+			Area:        l.Area,
+			Strain:      l.Strain,
+			Stress:      l.Stress,
+			Force:       l.Force,
+			Tension:     l.IsTension,
+			Yielded:     l.HasYielded,
+			Fractured:   l.Fractured,
+			Description: l.Description,
+		})
+	}
+	return out
+}
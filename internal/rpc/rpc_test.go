@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	gorcbv1 "github.com/alexiusacademia/gorcb/api/gorcb/v1"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves the services on an in-memory listener and returns a client
+// connection to them
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	s := NewServer(codes.Default())
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestBeamDesignAndAnalyze(t *testing.T) {
+	client := gorcbv1.NewBeamServiceClient(dial(t))
+	ctx := context.Background()
+
+	design, err := client.Design(ctx, &gorcbv1.Member{Id: "B1", Width: 300, Height: 500, Mu: 150})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if design.GetMode() != "design" || design.GetAs() <= 0 || design.GetPhiMn() < 150 || !design.GetAdequate() {
+		t.Fatalf("design = %v, want As > 0 and φMn >= Mu", design)
+	}
+	if design.GetMember().GetId() != "B1" {
+		t.Errorf("member id = %q, want B1", design.GetMember().GetId())
+	}
+
+	analysis, err := client.Analyze(ctx, &gorcbv1.Member{Id: "B1", Width: 300, Height: 500, Mu: 150, As: design.GetAs()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.GetMode() != "analysis" || analysis.GetPhiMn() < 150 {
+		t.Errorf("analysis = %v, want φMn >= 150 with the designed steel", analysis)
+	}
+
+	_, err = client.Analyze(ctx, &gorcbv1.Member{Id: "B2", Width: 300, Height: 500, Mu: 150})
+	if status.Code(err) != grpccodes.InvalidArgument {
+		t.Errorf("analysis without As: error %v, want InvalidArgument", err)
+	}
+}
+
+func TestBeamBatch(t *testing.T) {
+	client := gorcbv1.NewBeamServiceClient(dial(t))
+	resp, err := client.Batch(context.Background(), &gorcbv1.BatchRequest{Members: []*gorcbv1.Member{
+		{Id: "B1", Width: 300, Height: 500, Mu: 150},
+		{Id: "B2", Width: 300, Height: 500, As: 1500},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetResults()) != 2 {
+		t.Fatalf("%d results, want 2", len(resp.GetResults()))
+	}
+	if mode := resp.GetResults()[1].GetMode(); mode != "analysis" {
+		t.Errorf("B2 mode = %q, want analysis of its As", mode)
+	}
+
+	_, err = client.Batch(context.Background(), &gorcbv1.BatchRequest{})
+	if status.Code(err) != grpccodes.InvalidArgument {
+		t.Errorf("empty batch: error %v, want InvalidArgument", err)
+	}
+}
+
+func TestSectionAnalyze(t *testing.T) {
+	client := gorcbv1.NewSectionServiceClient(dial(t))
+	ctx := context.Background()
+
+	message, err := client.Analyze(ctx, &gorcbv1.SectionRequest{Source: &gorcbv1.SectionRequest_Section{
+		Section: &gorcbv1.Section{
+			Name: "R1", Fc: 28, Fy: 415,
+			Vertices:      []*gorcbv1.Point{{X: 0, Y: 0}, {X: 300, Y: 0}, {X: 300, Y: 500}, {X: 0, Y: 500}},
+			Reinforcement: []*gorcbv1.RebarLayer{{Y: 65, Area: 1500}},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.GetPhiMn() <= 0 || len(message.GetSteelLayers()) != 1 {
+		t.Fatalf("result = %v, want φMn > 0 and one steel layer", message)
+	}
+
+	file, err := client.Analyze(ctx, &gorcbv1.SectionRequest{Source: &gorcbv1.SectionRequest_File{File: []byte(`
+name: R1
+fc: 28
+fy: 415
+vertices: [{x: 0, y: 0}, {x: 300, y: 0}, {x: 300, y: 500}, {x: 0, y: 500}]
+reinforcement: [{y: 65, area: 1500}]
+`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.GetPhiMn() != message.GetPhiMn() {
+		t.Errorf("φMn of the file = %g, want %g as of the message", file.GetPhiMn(), message.GetPhiMn())
+	}
+
+	_, err = client.Analyze(ctx, &gorcbv1.SectionRequest{})
+	if status.Code(err) != grpccodes.InvalidArgument {
+		t.Errorf("no section: error %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/batch"
+	"github.com/alexiusacademia/gorcb/internal/beam"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/cost"
+	"github.com/alexiusacademia/gorcb/internal/rebar"
+)
+
+// Shape codes of the scheduled bars, after BS 8666
+const (
+	ShapeHooked = "21" // Bar with a 90° hook at both ends
+	ShapeLink   = "51" // Closed link with 135° hooks
+)
+
+// Detailing assumptions of the schedule (mm)
+const (
+	EndCover       = beam.DefaultClearCover // Cover to the bar ends at the member faces
+	FirstStirrup   = 50.0                   // Face of support to the first stirrup
+	CutIncrement   = 10.0                   // Cut lengths are rounded up to a multiple of this
+	HookExtension  = 12.0                   // Straight extension of a 90° hook, in bar diameters
+	HangerBarCount = 2                      // Top bars of a singly reinforced beam
+)
+
+// Entry is one bar mark of a bar bending schedule
+type Entry struct {
+	Mark      string  `json:"mark"`
+	Member    string  `json:"member"`
+	Location  string  `json:"location"` // Bottom, Top or Stirrups
+	Bar       string  `json:"bar"`      // Designation, e.g. "20mm"
+	Diameter  float64 `json:"diameter"` // mm
+	Shape     string  `json:"shape"`
+	Count     int     `json:"count"`
+	CutLength float64 `json:"cut_length"`     // Of one bar, with its hooks and share of laps (mm)
+	Lap       float64 `json:"lap,omitempty"`  // Lap splice length when spliced (mm)
+	Pieces    int     `json:"pieces"`         // Bars spliced along each run
+	Hook      float64 `json:"hook,omitempty"` // Hook extension (mm)
+	Mass      float64 `json:"mass"`           // kg
+	Note      string  `json:"note,omitempty"`
+}
+
+// Schedule is the bar bending schedule of a set of members
+type Schedule struct {
+	Entries []Entry `json:"entries"`
+	Mass    float64 `json:"mass"` // Total (kg)
+}
+
+// Add appends the entries of a member and adds their mass to the total
+func (s *Schedule) Add(entries []Entry) {
+	s.Entries = append(s.Entries, entries...)
+	for _, e := range entries {
+		s.Mass += e.Mass
+	}
+}
+
+// Beam returns the schedule of a rectangular beam of a length (m) designed or
+// checked in a batch: its tension bars at the bottom, its compression bars or
+// two hanger bars at the top, hooked at both ends, and closed stirrups at
+// the spacing of its shear design. Bars longer than the stock lengths of the
+// catalog are lap spliced with the tension lap of the design code.
+func Beam(r batch.Result, length float64, catalog *rebar.Catalog, code codes.DesignCode) ([]Entry, error) {
+	m := r.Member
+	if r.Error != "" {
+		return nil, fmt.Errorf("member %q: %s", m.ID, r.Error)
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("member %q: length must be positive", m.ID)
+	}
+	code = codes.OrDefault(code)
+	run := length*1e3 - 2*EndCover
+
+	var entries []Entry
+	add := func(location string, bar rebar.Bar, count int, topBar, fits bool) {
+		e := longitudinal(bar, count, run, r.Fc, r.Fy, topBar, code)
+		e.Mark = fmt.Sprintf("%s-%d", m.ID, len(entries)+1)
+		e.Member, e.Location = m.ID, location
+		if !fits {
+			e.Note = "does not fit one layer, detail in two layers"
+		}
+		entries = append(entries, e)
+	}
+
+	bottom, fits := m.BarLayout(catalog, r.As)
+	add("Bottom", bottom.Bar, bottom.Count, false, fits)
+	coverComp := m.CoverComp
+	if coverComp <= 0 {
+		coverComp = batch.DefaultCover
+	}
+	topBar := m.Height-coverComp > 300
+	if r.Asc > 0 {
+		top, fits := m.BarLayout(catalog, r.Asc)
+		add("Top", top.Bar, top.Count, topBar, fits)
+	} else {
+		add("Top", catalog.FlexuralBars()[0], HangerBarCount, topBar, true)
+	}
+
+	if s := r.Shear; s != nil {
+		spacing := s.Spacing
+		if spacing <= 0 {
+			spacing = s.MaxSpacing
+		}
+		if spacing > 0 {
+			db := m.StirrupDia
+			if db <= 0 {
+				db = beam.DefaultStirrupDiameter
+			}
+			bar := nearest(catalog, db)
+			e := Entry{
+				Mark:      fmt.Sprintf("%s-%d", m.ID, len(entries)+1),
+				Member:    m.ID,
+				Location:  "Stirrups",
+				Bar:       bar.Designation,
+				Diameter:  bar.Diameter,
+				Shape:     ShapeLink,
+				Count:     int(math.Floor((length*1e3-2*FirstStirrup)/spacing)) + 1,
+				CutLength: roundUp(cost.StirrupLength(m.Width, m.Height, beam.DefaultClearCover, bar.Diameter)),
+				Pieces:    1,
+			}
+			e.Mass = mass(bar, e.Count, e.CutLength)
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// longitudinal returns the entry of count bars over a run (mm) hooked at both
+// ends, spliced into equal pieces when longer than the longest stock length
+func longitudinal(bar rebar.Bar, count int, run, fc, fy float64, topBar bool, code codes.DesignCode) Entry {
+	hook := HookExtension * bar.Diameter
+	e := Entry{Bar: bar.Designation, Diameter: bar.Diameter, Shape: ShapeHooked, Hook: hook, Pieces: 1}
+	total := run + 2*hook
+	if stock := bar.MaxLength * 1e3; stock > 0 && total > stock {
+		e.Lap, _ = code.TensionLapLength(bar.Diameter, fc, fy, 1, topBar, 1, 1)
+		if e.Lap < stock {
+			e.Pieces = int(math.Ceil((total - e.Lap) / (stock - e.Lap)))
+			total += float64(e.Pieces-1) * e.Lap
+		}
+	}
+	e.Count = count * e.Pieces
+	e.CutLength = roundUp(total / float64(e.Pieces))
+	e.Mass = mass(bar, e.Count, e.CutLength)
+	return e
+}
+
+// nearest returns the bar of the catalog closest in diameter to db (mm),
+// e.g. the #3 bar for a 10 mm stirrup
+func nearest(catalog *rebar.Catalog, db float64) rebar.Bar {
+	best := catalog.Bars[0]
+	for _, b := range catalog.Bars {
+		if math.Abs(b.Diameter-db) < math.Abs(best.Diameter-db) {
+			best = b
+		}
+	}
+	return best
+}
+
+// roundUp rounds a cut length up to the cutting increment
+func roundUp(length float64) float64 {
+	return math.Ceil(length/CutIncrement) * CutIncrement
+}
+
+// mass returns the mass of count bars of a cut length (mm) in kg
+func mass(bar rebar.Bar, count int, cutLength float64) float64 {
+	return float64(count) * cutLength / 1e3 * bar.MassPerMeter
+}
@@ -2,31 +2,111 @@ package section
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"math"
 	"os"
+	"strings"
 
+	"github.com/alexiusacademia/gorcb/internal/codes"
 	"github.com/alexiusacademia/gorcb/internal/nscp"
+	"github.com/alexiusacademia/gorcb/internal/solver"
+	"gopkg.in/yaml.v3"
 )
 
-// LoadFromFile loads a section definition from a JSON file
+// ErrNoConvergence is returned when the neutral axis iteration does not
+// reach force equilibrium
+var ErrNoConvergence = errors.New("neutral axis iteration did not converge")
+
+// maxIterations bounds the neutral axis iteration of the section analysis,
+// and imbalanceTolerance is the force imbalance (kN) at which it is
+// converged, unless solver.Default sets others
+const (
+	maxIterations      = 100
+	imbalanceTolerance = 0.1
+)
+
+// DefaultUnits are the units of section files that do not declare their
+// own, mm and MPa when nil
+var DefaultUnits *Units
+
+// Stdin is the file name that reads a section from standard input
+const Stdin = "-"
+
+// LoadFromFile loads a section definition from a JSON or YAML file, or from
+// standard input when filepath is Stdin, in mm and MPa
 func LoadFromFile(filepath string) (*Section, error) {
-	data, err := os.ReadFile(filepath)
+	section, err := ReadFile(filepath)
 	if err != nil {
 		return nil, err
 	}
-
-	var section Section
-	if err := json.Unmarshal(data, &section); err != nil {
+	if err := section.Prepare(); err != nil {
 		return nil, err
 	}
+	return section, nil
+}
 
-	if err := section.Validate(); err != nil {
+// Prepare converts a section read with ReadFile to mm and MPa, assuming
+// DefaultUnits when it declares none, and validates it
+func (s *Section) Prepare() error {
+	if s.Units == nil && DefaultUnits != nil {
+		units := *DefaultUnits
+		s.Units = &units
+	}
+	if err := s.NormalizeUnits(); err != nil {
+		return err
+	}
+	return s.Validate()
+}
+
+// ReadFile reads a section definition as written in a file, without
+// converting its units or validating it. Files with a .yaml or .yml
+// extension are YAML with the keys of the JSON format, others are JSON;
+// standard input is always JSON.
+func ReadFile(filepath string) (*Section, error) {
+	var data []byte
+	var err error
+	if filepath == Stdin {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filepath)
+	}
+	if err != nil {
 		return nil, err
 	}
+	return Parse(data, IsYAML(filepath))
+}
+
+// Parse reads a section definition from the contents of a JSON file, or of
+// a YAML file when isYAML, without converting its units or validating it.
+// YAML being a superset of JSON, a YAML parse reads either.
+func Parse(data []byte, isYAML bool) (*Section, error) {
+	if isYAML {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		var err error
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
 
+	var section Section
+	if err := json.Unmarshal(data, &section); err != nil {
+		return nil, err
+	}
 	return &section, nil
 }
 
+// IsYAML reports whether a section file is YAML by its extension
+func IsYAML(filepath string) bool {
+	ext := strings.ToLower(filepath)
+	return strings.HasSuffix(ext, ".yaml") || strings.HasSuffix(ext, ".yml")
+}
+
 // AnalysisResult holds the results of section analysis
 type AnalysisResult struct {
 	// Section properties
@@ -49,6 +129,11 @@ type AnalysisResult struct {
 	Cs float64 // Compression steel force (if any)
 	T  float64 // Total tension steel force
 
+	// Convergence of the neutral axis iteration
+	Iterations int     // Iterations to equilibrium
+	Residual   float64 // Force imbalance T − (Cc + Cs) at equilibrium (kN)
+	Trials     []Trial // Neutral axis depths tried, in order
+
 	// Steel layer details
 	SteelLayers []SteelLayerResult
 
@@ -57,11 +142,24 @@ type AnalysisResult struct {
 	Mn    float64 // Nominal moment capacity (kN-m)
 	PhiMn float64 // Design moment capacity (kN-m)
 
+	// Cracking (gross section, tension at the bottom fiber)
+	Lambda float64 // Lightweight concrete modification factor
+	Fr     float64 // Modulus of rupture (MPa)
+	Mcr    float64 // Cracking moment (kN-m)
+
 	// Status
 	IsTensionControlled bool
+	BarFracture         bool // True if any layer exceeds the fracture strain
 	Message             string
 }
 
+// Trial is a neutral axis depth tried by the equilibrium iteration
+type Trial struct {
+	Method    string  // damped or bisection
+	C         float64 // Neutral axis depth (mm)
+	Imbalance float64 // Force imbalance T − (Cc + Cs) (kN)
+}
+
 // SteelLayerResult holds analysis results for each reinforcement layer
 type SteelLayerResult struct {
 	Y           float64 // Position from section bottom (mm)
@@ -71,6 +169,7 @@ type SteelLayerResult struct {
 	Force       float64 // Force (kN)
 	IsTension   bool    // True if in tension
 	HasYielded  bool    // True if steel has yielded
+	Fractured   bool    // True if strain exceeds the fracture strain of the steel model
 	Description string
 }
 
@@ -80,9 +179,13 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 		return nil, err
 	}
 
+	code := codes.OrDefault(s.Code)
+	fy := code.DesignYieldStrength(s.Fy)
+	fcd := code.Alpha1(s.Fc) * s.Fc // Stress block intensity (0.85f'c)
+	epsCU := code.EpsilonCU(s.Fc)
 	result := &AnalysisResult{}
 	result.Properties = s.CalculateProperties()
-	result.Beta1 = nscp.Beta1(s.Fc)
+	result.Beta1 = code.Beta1(s.Fc)
 
 	// Find neutral axis by iteration (force equilibrium)
 	// T = Cc + Cs
@@ -91,15 +194,16 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 	// Initial guess for c: assume tension-controlled
 	c := props.EffectiveDepth * 0.3
 
-	epsilonY := s.Fy / nscp.Es
+	epsilonY := fy / nscp.Es
 
-	// Iterate to find neutral axis
-	for iter := 0; iter < 100; iter++ {
+	// Forces of the section with the neutral axis at depth c, returning the
+	// force imbalance T - (Cc + Cs) in kN
+	equilibrium := func(c float64) float64 {
 		a := result.Beta1 * c
 
 		// Calculate concrete compression force
 		compArea := s.CompressionBlockArea(a)
-		Cc := 0.85 * s.Fc * compArea / 1000 // kN
+		Cc := fcd * compArea / 1000 // kN
 
 		// Calculate steel forces
 		var totalTension, totalCompression float64
@@ -111,17 +215,10 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 			depthFromTop := props.MaxY - layer.Y
 
 			// Strain at this layer
-			strain := nscp.EpsilonCU * (c - depthFromTop) / c
+			strain := epsCU * (c - depthFromTop) / c
 
-			// Stress (limited to fy)
-			var stress float64
-			if strain >= 0 {
-				// Compression
-				stress = math.Min(strain*nscp.Es, s.Fy)
-			} else {
-				// Tension
-				stress = math.Max(strain*nscp.Es, -s.Fy)
-			}
+			// Stress from the steel model (limited to fy unless strain hardening)
+			stress, fractured := s.SteelModel.Stress(strain, fy)
 
 			force := layer.Area * stress / 1000 // kN
 
@@ -133,6 +230,7 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 				Force:       force,
 				IsTension:   strain < 0,
 				HasYielded:  math.Abs(strain) >= epsilonY,
+				Fractured:   fractured,
 				Description: layer.Description,
 			}
 			result.SteelLayers = append(result.SteelLayers, layerResult)
@@ -141,7 +239,7 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 				// Compression steel - subtract displaced concrete if within compression block
 				if depthFromTop <= a {
 					// Within compression block, subtract displaced concrete
-					netStress := stress - 0.85*s.Fc
+					netStress := stress - fcd
 					force = layer.Area * netStress / 1000
 				}
 				totalCompression += force
@@ -150,25 +248,38 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 			}
 		}
 
+		result.C = c
+		result.A = a
+		result.CompressionArea = compArea
+		result.CompressionCentroid = s.CompressionBlockCentroid(a)
+		result.Cc = Cc
+		result.Cs = totalCompression
+		result.T = totalTension
+
 		// Check equilibrium: T = Cc + Cs
-		totalComp := Cc + totalCompression
-		imbalance := totalTension - totalComp
-
-		if math.Abs(imbalance) < 0.1 { // Converged (within 0.1 kN)
-			result.C = c
-			result.A = a
-			result.CompressionArea = compArea
-			result.CompressionCentroid = s.CompressionBlockCentroid(a)
-			result.Cc = Cc
-			result.Cs = totalCompression
-			result.T = totalTension
+		return totalTension - (Cc + totalCompression)
+	}
+
+	// Iterate to find neutral axis
+	converged := false
+	var imbalance float64
+	maxIter := solver.Default.Iterations(maxIterations)
+	tolerance := solver.Default.Imbalance(imbalanceTolerance)
+	slog.Debug("section analysis", "section", s.Name, "fc", s.Fc, "fy", s.Fy, "layers", len(s.Reinforcement))
+	for iter := 0; iter < maxIter; iter++ {
+		imbalance = equilibrium(c)
+		result.Iterations++
+		result.Trials = append(result.Trials, Trial{Method: "damped", C: c, Imbalance: imbalance})
+		slog.Debug("neutral axis iteration", "method", "damped", "iteration", iter+1, "c", c, "imbalance_kN", imbalance)
+		if math.Abs(imbalance) < tolerance {
+			converged = true
 			break
 		}
 
 		// Adjust c based on imbalance
 		// If T > C, need more compression, so increase c
 		// If T < C, need less compression, so decrease c
-		adjustment := imbalance / (0.85 * s.Fc * s.WidthAtDepth(c) / 1000)
+		adjustment := imbalance / (fcd * s.WidthAtDepth(c) / 1000)
 		adjustment = math.Max(math.Min(adjustment, 10), -10) // Limit adjustment
 		c += adjustment * 0.5 // Damped adjustment
 
@@ -177,6 +288,38 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 		c = math.Min(c, props.Height-1)
 	}
 
+	// The damped adjustment oscillates in heavily reinforced sections where
+	// the tension steel does not yield; bisect instead, as the imbalance
+	// decreases with c
+	if !converged {
+		slog.Debug("damped iteration did not converge, bisecting", "section", s.Name, "imbalance_kN", imbalance)
+		lo, hi := 1.0, props.Height-1
+		if equilibrium(lo) > 0 && equilibrium(hi) < 0 {
+			for iter := 0; iter < maxIter; iter++ {
+				c = (lo + hi) / 2
+				imbalance = equilibrium(c)
+				result.Iterations++
+				result.Trials = append(result.Trials, Trial{Method: "bisection", C: c, Imbalance: imbalance})
+				slog.Debug("neutral axis iteration", "method", "bisection", "iteration", iter+1, "c", c, "imbalance_kN", imbalance)
+				if math.Abs(imbalance) < tolerance {
+					converged = true
+					break
+				}
+				if imbalance > 0 {
+					lo = c
+				} else {
+					hi = c
+				}
+			}
+		}
+	}
+	if !converged {
+		slog.Warn("neutral axis iteration did not converge", "section", s.Name, "iterations", maxIter, "imbalance_kN", imbalance)
+		return nil, fmt.Errorf("%w in %d iterations (force imbalance %.2f kN)", ErrNoConvergence, maxIter, imbalance)
+	}
+	result.Residual = imbalance
+	slog.Debug("neutral axis converged", "section", s.Name, "c", c, "imbalance_kN", imbalance)
+
 	// Find maximum tensile strain (at bottom-most tension steel)
 	var maxTensileStrain float64
 	for _, layer := range result.SteelLayers {
@@ -187,8 +330,9 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 	result.EpsilonT = math.Abs(maxTensileStrain)
 
 	// Determine phi
-	result.Phi = nscp.Phi(result.EpsilonT, s.Fy)
-	result.IsTensionControlled = result.EpsilonT >= 0.005
+	transverse, _ := nscp.ParseTransverse(s.Transverse)
+	result.Phi = code.Phi(result.EpsilonT, s.Fy, transverse)
+	result.IsTensionControlled = result.EpsilonT >= code.TensionControlledStrain(s.Fc, s.Fy)
 
 	// Calculate moment capacity about the top of section
 	// Then convert to about the tension steel centroid
@@ -208,15 +352,33 @@ func (s *Section) Analyze() (*AnalysisResult, error) {
 	result.Mn = Mn / 1000 // Convert to kN-m
 	result.PhiMn = result.Phi * result.Mn
 
+	// Cracking moment, Mcr = fr·Ig/yt
+	result.Lambda = s.Lambda
+	if result.Lambda <= 0 {
+		result.Lambda = 1.0
+	}
+	result.Fr = code.ModulusOfRupture(s.Fc, result.Lambda)
+	ig, yt := s.CrackingProperties()
+	result.Mcr = nscp.CrackingMoment(result.Fr, ig, yt)
+
 	// Status message
 	if result.IsTensionControlled {
-		result.Message = "Section is tension-controlled (εt ≥ 0.005)"
+		result.Message = fmt.Sprintf("Section is tension-controlled (εt ≥ %.4g)", code.TensionControlledStrain(s.Fc, s.Fy))
 	} else if result.EpsilonT >= epsilonY {
 		result.Message = "Section is in transition zone"
 	} else {
 		result.Message = "Section is compression-controlled"
 	}
 
+	for _, layer := range result.SteelLayers {
+		if layer.Fractured {
+			result.BarFracture = true
+		}
+	}
+	if result.BarFracture {
+		result.Message += " | WARNING: Bar fracture - steel strain exceeds εsu before concrete crushing, Mn is unconservative"
+	}
+
 	return result, nil
 }
 
@@ -233,6 +395,16 @@ type DesignResult struct {
 	AsMin      float64 // Minimum steel area (mm²)
 	AsProvided float64 // Provided steel area (mm²)
 
+	// Alternative to the minimum steel when As,min governs
+	AsStrength    float64 // Steel required by analysis alone (mm²)
+	AsAlternative float64 // Steel that may be provided in lieu of As,min, zero if not applicable (mm²)
+
+	// Minimum steel basis
+	AsMinWidth      float64 // Width used with ρmin for As,min (mm)
+	WebWidth        float64 // Web width bw (mm)
+	TensionWidth    float64 // Width of the tension face (mm)
+	FlangeInTension bool    // Tension face is wider than the web
+
 	// Section at capacity
 	C     float64 // Neutral axis depth (mm)
 	A     float64 // Compression block depth (mm)
@@ -256,29 +428,34 @@ func (s *Section) Design(mu float64) (*DesignResult, error) {
 		return nil, err
 	}
 
+	code := codes.OrDefault(s.Code)
+	fy := code.DesignYieldStrength(s.Fy)
 	result := &DesignResult{
 		Mu: mu,
 	}
 	result.Properties = s.CalculateProperties()
-	result.Beta1 = nscp.Beta1(s.Fc)
+	result.Beta1 = code.Beta1(s.Fc)
 
 	props := result.Properties
 	d := props.EffectiveDepth
 
-	// Calculate minimum steel area
-	result.AsMin = nscp.RhoMin(s.Fc, s.Fy) * props.Width * d
+	// Calculate minimum steel area on the web width, or on the flange rule
+	// for statically determinate members with a flange in tension
+	s.minimumSteelWidth(code, result)
+	result.AsMin = code.RhoMin(s.Fc, s.Fy) * result.AsMinWidth * d
 
 	// Iterative design: adjust tension steel until capacity matches demand
 	// Start with an estimate based on rectangular section formula
-	phi := nscp.PhiFlexure
+	phi := code.PhiFlexure()
 	muNmm := mu * 1e6
 
 	// Estimate lever arm as 0.9d
 	jd := 0.9 * d
-	AsEstimate := muNmm / (phi * s.Fy * jd)
+	AsEstimate := muNmm / (phi * fy * jd)
 
 	// Create a working copy of the section to modify reinforcement
 	workingSection := *s
+	workingSection.Reinforcement = append([]RebarLayer(nil), s.Reinforcement...)
 
 	// Find or create the tension steel layer
 	tensionLayerIdx := -1
@@ -326,11 +503,13 @@ func (s *Section) Design(mu float64) (*DesignResult, error) {
 		// Increase As proportionally
 		ratio := mu / analysis.PhiMn
 		As *= ratio
-		As = math.Min(As, props.Width*d*nscp.RhoMax(s.Fc, s.Fy)*3) // Limit to prevent infinite loop
+		As = math.Min(As, props.Width*d*code.RhoMax(s.Fc, s.Fy)*3) // Limit to prevent infinite loop
 	}
 
 	// Check against minimum
+	result.AsStrength = result.AsRequired
 	if result.AsRequired < result.AsMin {
+		result.AsAlternative = codes.MinSteelAlternative(code, result.AsStrength, result.AsMin)
 		result.AsRequired = result.AsMin
 	}
 
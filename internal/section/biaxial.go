@@ -0,0 +1,305 @@
+package section
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// BiaxialAngles is the number of neutral axis directions swept around the
+// section for each contour of the biaxial interaction surface
+const BiaxialAngles = 72
+
+// BiaxialBar is a bar of the section at its position, for bending about
+// both axes
+type BiaxialBar struct {
+	X, Y float64 // Center (mm)
+	Area float64 // mm²
+}
+
+// BiaxialPoint is a point of a contour of the biaxial interaction surface,
+// with the compression face in a direction around the section
+type BiaxialPoint struct {
+	Angle    float64 // Direction of the compression face, counterclockwise from +X (degrees)
+	C        float64 // Neutral axis depth from the extreme compression fiber (mm)
+	EpsilonT float64 // Net tensile strain of the extreme tension bar
+	Phi      float64 // Strength reduction factor
+	PhiMnx   float64 // Design moment about the X axis through the gross centroid, positive with the top in compression (kN-m)
+	PhiMny   float64 // Design moment about the Y axis through the gross centroid, positive with the right in compression (kN-m)
+}
+
+// BiaxialContour is the φMnx–φMny contour of the biaxial interaction
+// surface at a design axial strength
+type BiaxialContour struct {
+	PhiPn  float64        // Compression positive (kN)
+	Points []BiaxialPoint // Around the section, from the compression face at +X
+}
+
+// Biaxial is the biaxial interaction surface of a section, by strain
+// compatibility with the neutral axis turned around the section and swept
+// over its depth, cut at levels of the design axial strength
+type Biaxial struct {
+	P0       float64 // Nominal axial strength at zero eccentricity (kN)
+	PhiPnt   float64 // Design axial strength in pure tension (kN, negative)
+	PhiPnMax float64 // Maximum design axial strength (kN)
+	Contours []BiaxialContour
+}
+
+// biaxialState is the strength of the section at a neutral axis depth in
+// one direction
+type biaxialState struct {
+	c, epsilonT, phi    float64
+	phiPn, phiMx, phiMy float64
+}
+
+// Biaxial calculates the contours of the biaxial interaction surface of the
+// section with bars at their positions, at the design axial strengths φPn
+// (kN, compression positive) between pure tension and φPn,max. Without
+// loads only the axial strengths are calculated, e.g. to choose them.
+func (s *Section) Biaxial(bars []BiaxialBar, loads []float64) (*Biaxial, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if len(bars) == 0 {
+		return nil, fmt.Errorf("no bars for the biaxial interaction")
+	}
+	code := codes.OrDefault(s.Code)
+	transverse, _ := nscp.ParseTransverse(s.Transverse)
+	props := s.CalculateProperties()
+	fy := code.DesignYieldStrength(s.Fy)
+	fcd := code.Alpha1(s.Fc) * s.Fc
+
+	var ast float64
+	for _, b := range bars {
+		ast += b.Area
+	}
+	p0 := fcd * (props.Area - ast)
+	pt := 0.0
+	for _, b := range bars {
+		compression, _ := s.SteelModel.Stress(code.EpsilonCU(s.Fc), fy)
+		tension, _ := s.SteelModel.Stress(-1, fy)
+		p0 += b.Area * compression
+		pt += b.Area * tension
+	}
+	limit := maxAxialTied
+	if transverse == nscp.TransverseSpiral {
+		limit = maxAxialSpiral
+	}
+	result := &Biaxial{
+		P0:       p0 / 1000,
+		PhiPnt:   code.Phi(1, s.Fy, transverse) * pt / 1000,
+		PhiPnMax: limit * code.Phi(0, s.Fy, transverse) * p0 / 1000,
+	}
+	for _, pu := range loads {
+		if pu < result.PhiPnt || pu > result.PhiPnMax {
+			return nil, fmt.Errorf("axial strength %.2f kN is outside the section's %.2f to %.2f kN", pu, result.PhiPnt, result.PhiPnMax)
+		}
+		result.Contours = append(result.Contours, BiaxialContour{PhiPn: pu})
+	}
+	if len(result.Contours) == 0 {
+		return result, nil
+	}
+
+	for i := 0; i < BiaxialAngles; i++ {
+		angle := 360 * float64(i) / BiaxialAngles
+		states := s.biaxialSweep(code, transverse, props, bars, angle, result.PhiPnMax)
+		for j := range result.Contours {
+			contour := &result.Contours[j]
+			contour.Points = append(contour.Points, biaxialAt(states, contour.PhiPn, angle))
+		}
+	}
+	return result, nil
+}
+
+// BiaxialAt calculates the point of the biaxial interaction surface at a
+// design axial strength φPn (kN, compression positive) whose design moment
+// acts in the direction of the factored moments Mux and Muy (kN-m, signed
+// as φMnx and φMny). The neutral axis is turned until the moment it resists
+// lines up with the load, so that it is generally inclined to the load.
+func (s *Section) BiaxialAt(bars []BiaxialBar, pu, mux, muy float64) (*BiaxialPoint, error) {
+	if mux == 0 && muy == 0 {
+		return nil, fmt.Errorf("no moment to incline the neutral axis to")
+	}
+	surface, err := s.Biaxial(bars, []float64{pu})
+	if err != nil {
+		return nil, err
+	}
+	code := codes.OrDefault(s.Code)
+	transverse, _ := nscp.ParseTransverse(s.Transverse)
+	props := s.CalculateProperties()
+	at := func(angle float64) BiaxialPoint {
+		return biaxialAt(s.biaxialSweep(code, transverse, props, bars, angle, surface.PhiPnMax), pu, angle)
+	}
+
+	// Angle of the moment of a point from the direction of the load,
+	// counterclockwise in the φMny-φMnx plane
+	target := math.Atan2(mux, muy)
+	offset := func(p BiaxialPoint) float64 {
+		return math.Remainder(math.Atan2(p.PhiMnx, p.PhiMny)-target, 2*math.Pi)
+	}
+
+	// The directions of the contour bracketing the load, narrowed by
+	// bisection
+	points := surface.Contours[0].Points
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		lo, hi := p.Angle, q.Angle
+		if hi < lo {
+			hi += 360
+		}
+		if p.PhiMnx == 0 && p.PhiMny == 0 || offset(p) > 0 || offset(q) < 0 || offset(q)-offset(p) > math.Pi {
+			continue
+		}
+		for j := 0; j < 40; j++ {
+			mid := (lo + hi) / 2
+			if offset(at(mid)) < 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		point := at(math.Mod((lo+hi)/2, 360))
+		return &point, nil
+	}
+	return nil, fmt.Errorf("no neutral axis resists a moment in the direction of Mux = %.2f, Muy = %.2f kN-m at φPn = %.2f kN", mux, muy, pu)
+}
+
+// biaxialSweep returns the strength of the section with the compression
+// face in a direction, from pure tension through neutral axis depths swept
+// as for the interaction diagram to pure compression
+func (s *Section) biaxialSweep(code codes.DesignCode, transverse nscp.Transverse, props *SectionProperties,
+	bars []BiaxialBar, angle, phiPnMax float64) []biaxialState {
+	fy := code.DesignYieldStrength(s.Fy)
+	fcd := code.Alpha1(s.Fc) * s.Fc
+	epsCU := code.EpsilonCU(s.Fc)
+	beta1 := code.Beta1(s.Fc)
+
+	// Depths below the compression face along the direction
+	nx, ny := math.Cos(angle*math.Pi/180), math.Sin(angle*math.Pi/180)
+	along := func(x, y float64) float64 { return nx*x + ny*y }
+	top, bottom := math.Inf(-1), math.Inf(1)
+	for _, v := range s.Vertices {
+		top, bottom = math.Max(top, along(v.X, v.Y)), math.Min(bottom, along(v.X, v.Y))
+	}
+	height := top - bottom
+	var dt float64
+	for _, b := range bars {
+		dt = math.Max(dt, top-along(b.X, b.Y))
+	}
+
+	state := func(pn, mx, my, epsilonT, c float64) biaxialState {
+		phi := code.Phi(math.Max(epsilonT, 0), s.Fy, transverse)
+		return biaxialState{c: c, epsilonT: epsilonT, phi: phi,
+			phiPn: math.Min(phi*pn/1000, phiPnMax), phiMx: phi * mx / 1e6, phiMy: phi * my / 1e6}
+	}
+
+	// Pure tension, every bar yielded
+	var pn, mx, my float64
+	for _, b := range bars {
+		stress, _ := s.SteelModel.Stress(-1, fy)
+		pn += b.Area * stress
+		mx += b.Area * stress * (b.Y - props.CentroidY)
+		my += b.Area * stress * (b.X - props.CentroidX)
+	}
+	states := []biaxialState{state(pn, mx, my, 1, 0)} // Tension-controlled
+
+	var depths []float64
+	for i := 0; i <= 40; i++ {
+		depths = append(depths, 0.01*height*math.Pow(100/beta1, float64(i)/40))
+	}
+	for _, f := range []float64{1.25, 1.5, 2, 3, 5, 10} {
+		depths = append(depths, f*height/beta1)
+	}
+	for _, c := range depths {
+		a := math.Min(beta1*c, height)
+		area, cx, cy := s.compressionZone(nx, ny, top-a)
+		pn = fcd * area
+		mx = fcd * area * (cy - props.CentroidY)
+		my = fcd * area * (cx - props.CentroidX)
+		for _, b := range bars {
+			depth := top - along(b.X, b.Y)
+			strain := epsCU * (c - depth) / c
+			stress, _ := s.SteelModel.Stress(strain, fy)
+			if strain > 0 && depth <= a {
+				stress -= fcd // Displaced concrete
+			}
+			pn += b.Area * stress
+			mx += b.Area * stress * (b.Y - props.CentroidY)
+			my += b.Area * stress * (b.X - props.CentroidX)
+		}
+		states = append(states, state(pn, mx, my, epsCU*(dt-c)/c, c))
+	}
+
+	// Pure compression over the whole section
+	pn, mx, my = fcd*props.Area, 0, 0
+	for _, b := range bars {
+		stress, _ := s.SteelModel.Stress(epsCU, fy)
+		pn += b.Area * (stress - fcd)
+		mx += b.Area * (stress - fcd) * (b.Y - props.CentroidY)
+		my += b.Area * (stress - fcd) * (b.X - props.CentroidX)
+	}
+	return append(states, state(pn, mx, my, 0, 0))
+}
+
+// biaxialAt interpolates the point of a sweep at a design axial strength
+func biaxialAt(states []biaxialState, pu, angle float64) BiaxialPoint {
+	for i := 1; i < len(states); i++ {
+		s1, s2 := states[i-1], states[i]
+		if pu < math.Min(s1.phiPn, s2.phiPn) || pu > math.Max(s1.phiPn, s2.phiPn) {
+			continue
+		}
+		t := 0.0
+		if s2.phiPn != s1.phiPn {
+			t = (pu - s1.phiPn) / (s2.phiPn - s1.phiPn)
+		}
+		lerp := func(a, b float64) float64 { return a + t*(b-a) }
+		return BiaxialPoint{
+			Angle: angle, C: lerp(s1.c, s2.c), EpsilonT: lerp(s1.epsilonT, s2.epsilonT), Phi: lerp(s1.phi, s2.phi),
+			PhiMnx: lerp(s1.phiMx, s2.phiMx), PhiMny: lerp(s1.phiMy, s2.phiMy),
+		}
+	}
+	last := states[len(states)-1]
+	return BiaxialPoint{Angle: angle, Phi: last.phi, PhiMnx: last.phiMx, PhiMny: last.phiMy}
+}
+
+// compressionZone returns the area and centroid of the part of the section,
+// less its holes, on the side of a line toward the direction (nx, ny), where
+// nx·x + ny·y ≥ level
+func (s *Section) compressionZone(nx, ny, level float64) (area, cx, cy float64) {
+	clip := func(polygon []Point) (float64, float64, float64) {
+		return polygonAreaAndCentroid(clipHalfPlane(polygon, nx, ny, level))
+	}
+	area, cx, cy = clip(s.Vertices)
+	momentX, momentY := area*cx, area*cy
+	for _, hole := range s.Holes {
+		a, x, y := clip(hole)
+		area -= a
+		momentX -= a * x
+		momentY -= a * y
+	}
+	if area <= 0 {
+		return 0, 0, 0
+	}
+	return area, momentX / area, momentY / area
+}
+
+// clipHalfPlane clips a polygon to the half plane nx·x + ny·y ≥ level
+func clipHalfPlane(polygon []Point, nx, ny, level float64) []Point {
+	var out []Point
+	n := len(polygon)
+	for i := 0; i < n; i++ {
+		curr, next := polygon[i], polygon[(i+1)%n]
+		dc := nx*curr.X + ny*curr.Y - level
+		dn := nx*next.X + ny*next.Y - level
+		if dc >= 0 {
+			out = append(out, curr)
+		}
+		if (dc >= 0) != (dn >= 0) {
+			t := dc / (dc - dn)
+			out = append(out, Point{X: curr.X + t*(next.X-curr.X), Y: curr.Y + t*(next.Y-curr.Y)})
+		}
+	}
+	return out
+}
@@ -0,0 +1,475 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Default confinement parameters
+const (
+	DefaultConfinementKe   = 0.75  // Confinement effectiveness for rectangular hoops (Mander et al.)
+	DefaultSpallingStrain  = 0.004 // Strain at which unconfined cover concrete has fully spalled
+	DefaultHoopStrainAtFsu = 0.09  // Strain of the hoop steel at maximum stress, εsu
+	DefaultBarFracture     = 0.10  // Longitudinal bar fracture strain when the steel model sets none
+)
+
+// Confinement describes the transverse reinforcement confining the concrete core
+// The core is the region inside the centerline of the outermost hoops; the
+// concrete outside it is unconfined cover that spalls beyond SpallingStrain.
+type Confinement struct {
+	Cover          float64 `json:"cover,omitempty"`           // Face to hoop centerline (mm), defaults to stirrup_cover
+	RhoS           float64 `json:"rho_s"`                     // Volumetric ratio of transverse reinforcement
+	Fyh            float64 `json:"fyh"`                       // Yield strength of hoops (MPa)
+	Ke             float64 `json:"ke,omitempty"`              // Confinement effectiveness coefficient
+	SpallingStrain float64 `json:"spalling_strain,omitempty"` // Cover spalling strain (0.003-0.004)
+	EpsilonSU      float64 `json:"epsilon_su,omitempty"`      // Hoop steel strain at maximum stress
+}
+
+// Validate checks the confinement parameters
+func (cf *Confinement) Validate() error {
+	if cf == nil {
+		return nil
+	}
+	if cf.RhoS <= 0 {
+		return &ValidationError{msg: "confinement rho_s must be positive", Field: "confinement.rho_s"}
+	}
+	if cf.Fyh <= 0 {
+		return &ValidationError{msg: "confinement fyh must be positive", Field: "confinement.fyh"}
+	}
+	if cf.Cover < 0 || cf.Ke < 0 || cf.SpallingStrain < 0 || cf.EpsilonSU < 0 {
+		return &ValidationError{msg: "confinement parameters must not be negative", Field: "confinement"}
+	}
+	return nil
+}
+
+// MomentCurvaturePoint is one point of the moment-curvature response
+type MomentCurvaturePoint struct {
+	TopStrain float64 // Extreme compression fiber strain
+	C         float64 // Neutral axis depth from top (mm)
+	Curvature float64 // 1/m
+	Moment    float64 // kN-m
+}
+
+// ConfinedResult holds the results of the confined section analysis
+type ConfinedResult struct {
+	// Concrete models (Mander et al. 1988)
+	Fco            float64 // Unconfined strength (MPa)
+	Fcc            float64 // Confined strength (MPa)
+	EpsilonCC      float64 // Strain at confined peak stress
+	EpsilonCU      float64 // Ultimate confined strain (Priestley energy balance)
+	SpallingStrain float64 // Cover spalling strain
+	CoreCover      float64 // Face to hoop centerline (mm)
+
+	CoreArea  float64 // mm²
+	CoverArea float64 // mm²
+
+	// Key points of the moment-curvature response
+	YieldCurvature    float64 // First yield of tension steel (1/m)
+	YieldMoment       float64 // kN-m
+	PeakMoment        float64 // Maximum moment reached (kN-m)
+	UltimateCurvature float64 // 1/m
+	UltimateMoment    float64 // kN-m
+	UltimateC         float64 // Neutral axis depth at ultimate (mm)
+
+	// Curvature ductility μφ = φu/φy' with the idealized yield curvature
+	// φy' = φy·Mpeak/My
+	CurvatureDuctility float64
+
+	CoverSpalled bool
+	BarFracture  bool
+	Limit        string // The limit state that ended the analysis
+
+	Points []MomentCurvaturePoint
+}
+
+// FiberSegment is a stretch of a strip of concrete across the section, in
+// the confined core or in the cover
+type FiberSegment struct {
+	XMin, XMax float64 // mm
+	Core       bool
+	Stress     float64 // MPa, compression positive; zero in tension and in spalled cover
+}
+
+// FiberStrip is a horizontal strip of the concrete of a confined section
+type FiberStrip struct {
+	Y        float64 // Center (mm)
+	Height   float64 // mm
+	Strain   float64 // Compression positive
+	Segments []FiberSegment
+}
+
+// FiberLayer is a steel layer of a confined section
+type FiberLayer struct {
+	Y      float64 // mm
+	Strain float64 // Compression positive
+	Stress float64 // MPa, compression positive
+}
+
+// FiberState is the strain and stress over the fibers of a confined section
+// at a point of its moment-curvature response
+type FiberState struct {
+	MomentCurvaturePoint
+	Core   []Point // Hoop centerline bounding the confined core
+	Strips []FiberStrip
+	Layers []FiberLayer
+}
+
+// numFibers is the number of horizontal strips the concrete of a confined
+// section is discretized into
+const numFibers = 200
+
+// concreteFiber is a horizontal strip of the section
+type concreteFiber struct {
+	depth     float64 // from top (mm)
+	coreArea  float64 // mm²
+	coverArea float64 // mm²
+}
+
+// confinedFibers discretizes the concrete of a section into horizontal
+// strips, each split into the area inside the confined core and the cover
+func (s *Section) confinedFibers(props *SectionProperties, core []Point) []concreteFiber {
+	dy := props.Height / numFibers
+	fibers := make([]concreteFiber, numFibers)
+	for i := range fibers {
+		y := props.MaxY - (float64(i)+0.5)*dy
+		total := s.widthAtY(y)
+		coreWidth := intervalWidth(polygonIntersectionsAtY(core, y))
+		for _, hole := range s.Holes {
+			coreWidth -= intervalWidth(polygonIntersectionsAtY(hole, y))
+		}
+		coreWidth = math.Max(0, math.Min(coreWidth, total))
+
+		fibers[i] = concreteFiber{
+			depth:     props.MaxY - y,
+			coreArea:  coreWidth * dy,
+			coverArea: (total - coreWidth) * dy,
+		}
+	}
+	return fibers
+}
+
+// AnalyzeConfined performs a fiber moment-curvature analysis that separates
+// the confined core from the unconfined cover concrete
+// Tension in the concrete is neglected.
+func (s *Section) AnalyzeConfined() (*ConfinedResult, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	cf := s.Confinement
+	if cf == nil {
+		return nil, fmt.Errorf("section has no confinement defined")
+	}
+
+	props := s.CalculateProperties()
+	result := &ConfinedResult{Fco: s.Fc}
+
+	result.CoreCover = cf.Cover
+	if result.CoreCover <= 0 {
+		result.CoreCover = s.StirrupCover
+	}
+	if result.CoreCover <= 0 {
+		result.CoreCover = DefaultStirrupCover
+	}
+	ke := cf.Ke
+	if ke <= 0 {
+		ke = DefaultConfinementKe
+	}
+	result.SpallingStrain = cf.SpallingStrain
+	if result.SpallingStrain <= 0 {
+		result.SpallingStrain = DefaultSpallingStrain
+	}
+	epsSU := cf.EpsilonSU
+	if epsSU <= 0 {
+		epsSU = DefaultHoopStrainAtFsu
+	}
+
+	// Confined strength from the effective lateral pressure,
+	// assuming equal confinement in both directions
+	fl := 0.5 * ke * cf.RhoS * cf.Fyh
+	result.Fcc = s.Fc * (-1.254 + 2.254*math.Sqrt(1+7.94*fl/s.Fc) - 2*fl/s.Fc)
+	result.EpsilonCC = 0.002 * (1 + 5*(result.Fcc/s.Fc-1))
+	result.EpsilonCU = 0.004 + 1.4*cf.RhoS*cf.Fyh*epsSU/result.Fcc
+
+	core := offsetPolygon(s.Vertices, result.CoreCover)
+	if len(core) < 3 {
+		return nil, fmt.Errorf("hoop cover %.1f mm leaves no confined core", result.CoreCover)
+	}
+
+	// Discretize the concrete into horizontal fibers
+	fibers := s.confinedFibers(props, core)
+	for _, f := range fibers {
+		result.CoreArea += f.coreArea
+		result.CoverArea += f.coverArea
+	}
+
+	ec := 4700 * math.Sqrt(s.Fc) // NSCP 2015 Section 419.2.2.1, normal-weight concrete
+	epsilonY := s.Fy / nscp.Es
+	fractureStrain := DefaultBarFracture
+	if s.SteelModel != nil && s.SteelModel.EpsilonU > 0 {
+		fractureStrain = s.SteelModel.EpsilonU
+	}
+	coreTopDepth := result.CoreCover
+
+	// Section response for a given top strain and neutral axis depth
+	// Returns net axial force (N, compression positive), moment about the
+	// top fiber (N-mm) and the state flags
+	respond := func(topStrain, c float64) (force, moment float64, spalled, fractured bool, minSteelStrain float64) {
+		for _, f := range fibers {
+			strain := topStrain * (c - f.depth) / c
+			if strain <= 0 {
+				continue
+			}
+			fcore := manderStress(strain, result.Fcc, result.EpsilonCC, ec)
+			fcover := coverStress(strain, s.Fc, ec, result.SpallingStrain)
+			if strain >= result.SpallingStrain {
+				spalled = spalled || f.coverArea > 0
+			}
+			dF := fcore*f.coreArea + fcover*f.coverArea
+			force += dF
+			moment += dF * f.depth
+		}
+		for _, layer := range s.Reinforcement {
+			depth := props.MaxY - layer.Y
+			strain := topStrain * (c - depth) / c
+			stress, _ := s.SteelModel.Stress(strain, s.Fy)
+			fractured = fractured || math.Abs(strain) > fractureStrain
+			if strain > 0 {
+				// Remove the concrete displaced by compression bars
+				stress -= coverStress(strain, s.Fc, ec, result.SpallingStrain)
+			}
+			dF := layer.Area * stress
+			force += dF
+			moment += dF * depth
+			minSteelStrain = math.Min(minSteelStrain, strain)
+		}
+		return
+	}
+
+	// Solve the neutral axis depth for zero axial force by bisection
+	solve := func(topStrain float64) (c, moment float64, spalled, fractured bool, minSteelStrain float64) {
+		lo, hi := 1e-3*props.Height, 10*props.Height
+		for iter := 0; iter < 80; iter++ {
+			c = (lo + hi) / 2
+			force, _, _, _, _ := respond(topStrain, c)
+			if force > 0 {
+				hi = c
+			} else {
+				lo = c
+			}
+		}
+		// With zero net force the internal couple is -Σ F·depth
+		// (compression near the top, tension below)
+		_, m, sp, fr, minStrain := respond(topStrain, c)
+		return c, -m, sp, fr, minStrain
+	}
+
+	// Increment the extreme fiber strain until the core crushes,
+	// a bar fractures or the moment drops below 80% of the peak
+	const stepsPerEpsilonCU = 150
+	const maxSteps = 10 * stepsPerEpsilonCU
+	strainStep := result.EpsilonCU / stepsPerEpsilonCU
+	var prev *MomentCurvaturePoint
+	var prevMinSteelStrain float64
+	for step := 1; step <= maxSteps; step++ {
+		topStrain := float64(step) * strainStep
+		c, moment, spalled, fractured, minSteelStrain := solve(topStrain)
+
+		point := MomentCurvaturePoint{
+			TopStrain: topStrain,
+			C:         c,
+			Curvature: topStrain / c * 1000,
+			Moment:    moment / 1e6,
+		}
+
+		// First yield of tension steel, interpolated between steps
+		if result.YieldCurvature == 0 && minSteelStrain <= -epsilonY {
+			if prev != nil && prevMinSteelStrain > -epsilonY {
+				t := (-epsilonY - prevMinSteelStrain) / (minSteelStrain - prevMinSteelStrain)
+				result.YieldCurvature = prev.Curvature + t*(point.Curvature-prev.Curvature)
+				result.YieldMoment = prev.Moment + t*(point.Moment-prev.Moment)
+			} else {
+				result.YieldCurvature = point.Curvature
+				result.YieldMoment = point.Moment
+			}
+		}
+
+		coreStrain := topStrain * (c - coreTopDepth) / c
+		switch {
+		case fractured:
+			result.BarFracture = true
+			result.Limit = "Tension bar fracture"
+		case coreStrain >= result.EpsilonCU:
+			result.Limit = "Confined core crushing (εc = εcu)"
+		case result.PeakMoment > 0 && point.Moment < 0.8*result.PeakMoment:
+			result.Limit = "Moment dropped below 80% of peak"
+		}
+
+		if result.Limit != "" && prev != nil {
+			// Ultimate is the last point before the limit was exceeded
+			result.UltimateCurvature = prev.Curvature
+			result.UltimateMoment = prev.Moment
+			result.UltimateC = prev.C
+			break
+		}
+
+		result.CoverSpalled = result.CoverSpalled || spalled
+		result.PeakMoment = math.Max(result.PeakMoment, point.Moment)
+		result.Points = append(result.Points, point)
+		prev = &result.Points[len(result.Points)-1]
+		prevMinSteelStrain = minSteelStrain
+	}
+
+	if result.Limit == "" && prev != nil {
+		result.Limit = "Strain range exhausted"
+		result.UltimateCurvature = prev.Curvature
+		result.UltimateMoment = prev.Moment
+		result.UltimateC = prev.C
+	}
+
+	if result.YieldCurvature > 0 && result.YieldMoment > 0 {
+		idealYield := result.YieldCurvature * result.PeakMoment / result.YieldMoment
+		result.CurvatureDuctility = result.UltimateCurvature / idealYield
+	}
+
+	return result, nil
+}
+
+// Fibers returns the strain and stress over the fibers of a section at a
+// point of the moment-curvature response r of its confined analysis, the
+// strips of concrete split across the section into core and cover
+func (s *Section) Fibers(r *ConfinedResult, point MomentCurvaturePoint) (*FiberState, error) {
+	if point.C <= 0 {
+		return nil, fmt.Errorf("invalid neutral axis depth: %.2f mm", point.C)
+	}
+	core := offsetPolygon(s.Vertices, r.CoreCover)
+	if len(core) < 3 {
+		return nil, fmt.Errorf("hoop cover %.1f mm leaves no confined core", r.CoreCover)
+	}
+	props := s.CalculateProperties()
+	ec := nscp.ModulusOfElasticity(s.Fc)
+	strainAt := func(y float64) float64 {
+		return point.TopStrain * (point.C - (props.MaxY - y)) / point.C
+	}
+
+	state := &FiberState{MomentCurvaturePoint: point, Core: core}
+	dy := props.Height / numFibers
+	for i := 0; i < numFibers; i++ {
+		y := props.MaxY - (float64(i)+0.5)*dy
+		strip := FiberStrip{Y: y, Height: dy, Strain: strainAt(y)}
+		confined := manderStress(strip.Strain, r.Fcc, r.EpsilonCC, ec)
+		unconfined := coverStress(strip.Strain, s.Fc, ec, r.SpallingStrain)
+		for _, seg := range s.stripSegments(core, y) {
+			seg.Stress = unconfined
+			if seg.Core {
+				seg.Stress = confined
+			}
+			strip.Segments = append(strip.Segments, seg)
+		}
+		state.Strips = append(state.Strips, strip)
+	}
+
+	for _, layer := range s.Reinforcement {
+		strain := strainAt(layer.Y)
+		stress, _ := s.SteelModel.Stress(strain, s.Fy)
+		state.Layers = append(state.Layers, FiberLayer{Y: layer.Y, Strain: strain, Stress: stress})
+	}
+	return state, nil
+}
+
+// stripSegments splits the concrete of a section across a horizontal line
+// into the stretches inside and outside the confined core
+func (s *Section) stripSegments(core []Point, y float64) []FiberSegment {
+	outline := polygonIntersectionsAtY(s.Vertices, y)
+	holes := make([][]float64, len(s.Holes))
+	for i, hole := range s.Holes {
+		holes[i] = polygonIntersectionsAtY(hole, y)
+	}
+	inner := polygonIntersectionsAtY(core, y)
+
+	// A point is inside a polygon when an odd number of its crossings lie
+	// to its left
+	inside := func(crossings []float64, x float64) bool {
+		n := 0
+		for _, c := range crossings {
+			if c < x {
+				n++
+			}
+		}
+		return n%2 == 1
+	}
+	cuts := append(append([]float64{}, outline...), inner...)
+	for _, h := range holes {
+		cuts = append(cuts, h...)
+	}
+	sort.Float64s(cuts)
+
+	var segments []FiberSegment
+	for i := 0; i+1 < len(cuts); i++ {
+		x0, x1 := cuts[i], cuts[i+1]
+		mid := (x0 + x1) / 2
+		if x1 <= x0 || !inside(outline, mid) {
+			continue
+		}
+		void := false
+		for _, h := range holes {
+			void = void || inside(h, mid)
+		}
+		if void {
+			continue
+		}
+		inCore := inside(inner, mid)
+		if n := len(segments); n > 0 && segments[n-1].XMax == x0 && segments[n-1].Core == inCore {
+			segments[n-1].XMax = x1
+			continue
+		}
+		segments = append(segments, FiberSegment{XMin: x0, XMax: x1, Core: inCore})
+	}
+	return segments
+}
+
+// manderStress returns the concrete stress for a compressive strain using
+// the Mander et al. (1988) model with peak stress fpeak at strain epsPeak
+func manderStress(strain, fpeak, epsPeak, ec float64) float64 {
+	if strain <= 0 {
+		return 0
+	}
+	esec := fpeak / epsPeak
+	if ec <= esec {
+		// Degenerate case: fall back to a linear-elastic, capped response
+		return math.Min(ec*strain, fpeak)
+	}
+	r := ec / (ec - esec)
+	x := strain / epsPeak
+	return fpeak * x * r / (r - 1 + math.Pow(x, r))
+}
+
+// coverStress returns the stress of unconfined cover concrete: the Mander
+// curve up to 2εco, then a linear descent to zero at the spalling strain
+func coverStress(strain, fco, ec, spallingStrain float64) float64 {
+	const epsCO = 0.002
+	switch {
+	case strain <= 0 || strain >= spallingStrain:
+		return 0
+	case strain <= 2*epsCO:
+		return manderStress(strain, fco, epsCO, ec)
+	default:
+		f2 := manderStress(2*epsCO, fco, epsCO, ec)
+		return f2 * (spallingStrain - strain) / (spallingStrain - 2*epsCO)
+	}
+}
+
+// intervalWidth sums the lengths of paired intersections along a line
+func intervalWidth(intersections []float64) float64 {
+	if len(intersections) < 2 {
+		return 0
+	}
+	sort.Float64s(intersections)
+	var width float64
+	for i := 0; i+1 < len(intersections); i += 2 {
+		width += intersections[i+1] - intersections[i]
+	}
+	return width
+}
@@ -0,0 +1,65 @@
+package section
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Ductility computes the ductility and over-strength metrics of the section.
+// The yield curvature uses the cracked transformed section with linear
+// concrete; Mpr uses elastic-perfectly plastic steel at 1.25fy.
+func (s *Section) Ductility() (*nscp.DuctilityResult, error) {
+	nominal, err := s.Analyze()
+	if err != nil {
+		return nil, err
+	}
+	probable := *s
+	probable.Fy = s.Fy * nscp.ProbableStrengthFactor
+	probable.SteelModel = nil
+	pr, err := probable.Analyze()
+	if err != nil {
+		return nil, err
+	}
+
+	code := codes.OrDefault(s.Code)
+	n := nscp.Es / code.ModulusOfElasticity(s.Fc, 0)
+	kd := s.crackedNeutralAxis(nominal.Properties, n)
+	return nscp.NewDuctilityResult(code.EpsilonCU(s.Fc), code.DesignYieldStrength(s.Fy)/nscp.Es,
+		nominal.C, kd, nominal.Properties.EffectiveDepth, pr.Mn, nominal.PhiMn), nil
+}
+
+// crackedNeutralAxis returns the depth from the top of the elastic neutral
+// axis of the cracked transformed section with modular ratio n, where the
+// first moment of the compressed concrete and steel balances that of the
+// tension steel. Found by bisection.
+func (s *Section) crackedNeutralAxis(props *SectionProperties, n float64) float64 {
+	firstMoment := func(c float64) float64 {
+		const numSteps = 100
+		dy := c / numSteps
+		var q float64
+		for i := 0; i < numSteps; i++ {
+			depth := (float64(i) + 0.5) * dy
+			q += s.widthAtY(props.MaxY-depth) * dy * (c - depth)
+		}
+		for _, layer := range s.Reinforcement {
+			depth := props.MaxY - layer.Y
+			if depth < c {
+				q += (n - 1) * layer.Area * (c - depth)
+			} else {
+				q -= n * layer.Area * (depth - c)
+			}
+		}
+		return q
+	}
+
+	lo, hi := 0.0, props.Height
+	for iter := 0; iter < 60; iter++ {
+		mid := (lo + hi) / 2
+		if firstMoment(mid) > 0 {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2
+}
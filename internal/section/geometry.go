@@ -37,8 +37,35 @@ func (s *Section) CalculateProperties() *SectionProperties {
 }
 
 // calculateAreaAndCentroid uses the shoelace formula
+// Hole areas are subtracted from the outer boundary
 func (s *Section) calculateAreaAndCentroid() (area, cx, cy float64) {
-	n := len(s.Vertices)
+	if len(s.Vertices) < 3 {
+		return 0, 0, 0
+	}
+
+	outerArea, outerX, outerY := polygonAreaAndCentroid(s.Vertices)
+	area = outerArea
+	momentX := outerArea * outerX
+	momentY := outerArea * outerY
+
+	for _, hole := range s.Holes {
+		holeArea, holeX, holeY := polygonAreaAndCentroid(hole)
+		area -= holeArea
+		momentX -= holeArea * holeX
+		momentY -= holeArea * holeY
+	}
+
+	if area > 0 {
+		cx = momentX / area
+		cy = momentY / area
+	}
+
+	return area, cx, cy
+}
+
+// polygonAreaAndCentroid returns the unsigned area and centroid of a simple polygon
+func polygonAreaAndCentroid(vertices []Point) (area, cx, cy float64) {
+	n := len(vertices)
 	if n < 3 {
 		return 0, 0, 0
 	}
@@ -48,10 +75,10 @@ func (s *Section) calculateAreaAndCentroid() (area, cx, cy float64) {
 
 	for i := 0; i < n; i++ {
 		j := (i + 1) % n
-		cross := s.Vertices[i].X*s.Vertices[j].Y - s.Vertices[j].X*s.Vertices[i].Y
+		cross := vertices[i].X*vertices[j].Y - vertices[j].X*vertices[i].Y
 		signedArea += cross
-		sumX += (s.Vertices[i].X + s.Vertices[j].X) * cross
-		sumY += (s.Vertices[i].Y + s.Vertices[j].Y) * cross
+		sumX += (vertices[i].X + vertices[j].X) * cross
+		sumY += (vertices[i].Y + vertices[j].Y) * cross
 	}
 
 	signedArea /= 2
@@ -142,13 +169,23 @@ func (s *Section) widthAtY(y float64) float64 {
 }
 
 // findIntersectionsAtY finds all X coordinates where a horizontal line at Y intersects the polygon
+// Hole boundaries are included so that paired intersections exclude the voids
 func (s *Section) findIntersectionsAtY(y float64) []float64 {
+	intersections := polygonIntersectionsAtY(s.Vertices, y)
+	for _, hole := range s.Holes {
+		intersections = append(intersections, polygonIntersectionsAtY(hole, y)...)
+	}
+	return intersections
+}
+
+// polygonIntersectionsAtY finds the X coordinates where a horizontal line at Y crosses a polygon
+func polygonIntersectionsAtY(vertices []Point, y float64) []float64 {
 	var intersections []float64
-	n := len(s.Vertices)
+	n := len(vertices)
 
 	for i := 0; i < n; i++ {
 		j := (i + 1) % n
-		v1, v2 := s.Vertices[i], s.Vertices[j]
+		v1, v2 := vertices[i], vertices[j]
 
 		// Check if the edge crosses the Y level
 		if (v1.Y <= y && v2.Y > y) || (v2.Y <= y && v1.Y > y) {
@@ -0,0 +1,212 @@
+package section
+
+import (
+	"math"
+	"slices"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Limits of the design axial strength φPn,max as a fraction of φP0,
+// Section 422.4.2.1
+const (
+	maxAxialTied   = 0.80
+	maxAxialSpiral = 0.85
+)
+
+// InteractionPoint is a point of the axial force-moment interaction diagram
+type InteractionPoint struct {
+	C        float64 // Neutral axis depth from the compression face (mm), zero for pure tension and compression
+	EpsilonT float64 // Net tensile strain of the extreme tension steel, zero for pure tension and compression
+	Pn       float64 // Nominal axial strength, compression positive (kN)
+	Mn       float64 // Nominal moment about the gross centroid, positive with the top in compression (kN-m)
+	Phi      float64 // Strength reduction factor
+	PhiPn    float64 // Design axial strength, limited to φPn,max (kN)
+	PhiMn    float64 // Design moment strength (kN-m)
+}
+
+// Interaction is the axial force-moment interaction diagram of a section,
+// by strain compatibility with the neutral axis swept over the depth
+type Interaction struct {
+	P0       float64 // Nominal axial strength at zero eccentricity (kN)
+	PhiPnMax float64 // Maximum design axial strength (kN)
+
+	// Points with the top in compression (positive moment) and with the
+	// bottom in compression (negative moment), from pure tension to pure
+	// compression
+	Positive []InteractionPoint
+	Negative []InteractionPoint
+}
+
+// Interaction calculates the axial force-moment interaction diagram of the
+// section with the stress block of the design code and the steel model of
+// the section
+func (s *Section) Interaction() (*Interaction, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	code := codes.OrDefault(s.Code)
+	transverse, _ := nscp.ParseTransverse(s.Transverse)
+
+	result := &Interaction{}
+	result.Positive, result.P0 = s.interactionBranch(code, transverse)
+	result.Negative, _ = s.mirrored().interactionBranch(code, transverse)
+	for i := range result.Negative {
+		result.Negative[i].Mn = -result.Negative[i].Mn
+		result.Negative[i].PhiMn = -result.Negative[i].PhiMn
+	}
+
+	limit := maxAxialTied
+	if transverse == nscp.TransverseSpiral {
+		limit = maxAxialSpiral
+	}
+	result.PhiPnMax = limit * code.Phi(0, s.Fy, transverse) * result.P0
+	for _, branch := range [][]InteractionPoint{result.Positive, result.Negative} {
+		for i := range branch {
+			branch[i].PhiPn = math.Min(branch[i].PhiPn, result.PhiPnMax)
+		}
+	}
+	return result, nil
+}
+
+// interactionBranch returns the points of the diagram with the top in
+// compression, from pure tension to pure compression, and P0
+func (s *Section) interactionBranch(code codes.DesignCode, transverse nscp.Transverse) ([]InteractionPoint, float64) {
+	props := s.CalculateProperties()
+	fy := code.DesignYieldStrength(s.Fy)
+	fcd := code.Alpha1(s.Fc) * s.Fc
+	epsCU := code.EpsilonCU(s.Fc)
+	beta1 := code.Beta1(s.Fc)
+	height := props.MaxY - props.MinY
+	centroid := props.MaxY - props.CentroidY // Depth of the gross centroid
+
+	// Depth of the extreme tension steel
+	var dt float64
+	for _, layer := range s.Reinforcement {
+		dt = math.Max(dt, props.MaxY-layer.Y)
+	}
+
+	point := func(pn, mn, epsilonT, c float64) InteractionPoint {
+		phi := code.Phi(math.Max(epsilonT, 0), s.Fy, transverse)
+		return InteractionPoint{C: c, EpsilonT: epsilonT, Pn: pn / 1000, Mn: mn / 1e6, Phi: phi,
+			PhiPn: phi * pn / 1000, PhiMn: phi * mn / 1e6}
+	}
+
+	// Pure tension, every bar yielded
+	var pn, mn, ast float64
+	for _, layer := range s.Reinforcement {
+		stress, _ := s.SteelModel.Stress(-1, fy)
+		pn += layer.Area * stress
+		mn += layer.Area * stress * (centroid - (props.MaxY - layer.Y))
+		ast += layer.Area
+	}
+	tension := point(pn, mn, 0, 0)
+	tension.Phi = code.Phi(1, s.Fy, transverse)
+	tension.PhiPn, tension.PhiMn = tension.Phi*tension.Pn, tension.Phi*tension.Mn
+	points := []InteractionPoint{tension}
+
+	// Neutral axis from near the top to well below the section
+	var depths []float64
+	for i := 0; i <= 40; i++ {
+		depths = append(depths, 0.01*height*math.Pow(100/beta1, float64(i)/40))
+	}
+	for _, f := range []float64{1.25, 1.5, 2, 3, 5, 10} {
+		depths = append(depths, f*height/beta1)
+	}
+	for _, c := range depths {
+		a := math.Min(beta1*c, height)
+		area := s.CompressionBlockArea(a)
+		pn = fcd * area
+		mn = fcd * area * (centroid - s.CompressionBlockCentroid(a))
+		for _, layer := range s.Reinforcement {
+			depth := props.MaxY - layer.Y
+			strain := epsCU * (c - depth) / c
+			stress, _ := s.SteelModel.Stress(strain, fy)
+			if strain > 0 && depth <= a {
+				stress -= fcd // Displaced concrete
+			}
+			pn += layer.Area * stress
+			mn += layer.Area * stress * (centroid - depth)
+		}
+		points = append(points, point(pn, mn, epsCU*(dt-c)/c, c))
+	}
+
+	// Pure compression over the whole section
+	p0 := fcd * (props.Area - ast)
+	mn = 0
+	for _, layer := range s.Reinforcement {
+		stress, _ := s.SteelModel.Stress(epsCU, fy)
+		p0 += layer.Area * stress
+		mn += layer.Area * (stress - fcd) * (centroid - (props.MaxY - layer.Y))
+	}
+	compression := point(p0, mn, 0, 0)
+	compression.Phi = code.Phi(0, s.Fy, transverse)
+	compression.PhiPn, compression.PhiMn = compression.Phi*compression.Pn, compression.Phi*compression.Mn
+	points = append(points, compression)
+
+	slices.SortStableFunc(points, func(a, b InteractionPoint) int {
+		switch {
+		case a.Pn < b.Pn:
+			return -1
+		case a.Pn > b.Pn:
+			return 1
+		}
+		return 0
+	})
+	return points, p0 / 1000
+}
+
+// mirrored returns a copy of the section turned upside down, to find the
+// strength with the bottom in compression
+func (s *Section) mirrored() *Section {
+	props := s.CalculateProperties()
+	flip := func(points []Point) []Point {
+		out := make([]Point, len(points))
+		for i, p := range points {
+			// Reversed to keep the winding of the outline
+			out[len(points)-1-i] = Point{X: p.X, Y: props.MaxY + props.MinY - p.Y}
+		}
+		return out
+	}
+	m := *s
+	m.Vertices = flip(s.Vertices)
+	m.Holes = nil
+	for _, hole := range s.Holes {
+		m.Holes = append(m.Holes, flip(hole))
+	}
+	m.Reinforcement = slices.Clone(s.Reinforcement)
+	for i := range m.Reinforcement {
+		m.Reinforcement[i].Y = props.MaxY + props.MinY - s.Reinforcement[i].Y
+	}
+	return &m
+}
+
+// MomentCapacity returns the design moment strength φMn at a factored axial
+// force Pu (kN, compression positive) on the side of the diagram of the
+// moment, negative for the bottom in compression, and false when Pu is
+// beyond the axial strength of the section
+func (d *Interaction) MomentCapacity(pu float64, negative bool) (float64, bool) {
+	branch := d.Positive
+	if negative {
+		branch = d.Negative
+	}
+	capacity, found := 0.0, false
+	for i := 1; i < len(branch); i++ {
+		p1, p2 := branch[i-1], branch[i]
+		if pu < math.Min(p1.PhiPn, p2.PhiPn) || pu > math.Max(p1.PhiPn, p2.PhiPn) {
+			continue
+		}
+		m := p1.PhiMn
+		if p2.PhiPn != p1.PhiPn {
+			m += (p2.PhiMn - p1.PhiMn) * (pu - p1.PhiPn) / (p2.PhiPn - p1.PhiPn)
+		} else {
+			m = math.Max(math.Abs(p1.PhiMn), math.Abs(p2.PhiMn))
+		}
+		if math.Abs(m) > capacity {
+			capacity = math.Abs(m)
+		}
+		found = true
+	}
+	return capacity, found
+}
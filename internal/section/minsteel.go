@@ -0,0 +1,24 @@
+package section
+
+import "github.com/alexiusacademia/gorcb/internal/codes"
+
+// minimumSteelWidth sets the web width, the tension face width and the width
+// used with ρmin for the minimum reinforcement of the section. The web width
+// is used unless the member is statically determinate with a flange in
+// tension, where the code's flange rule applies (e.g. the smaller of bf and
+// 2bw in NSCP 2015 Section 409.6.1.2).
+func (s *Section) minimumSteelWidth(code codes.DesignCode, result *DesignResult) {
+	props := result.Properties
+	bw := s.TorsionProperties().Bw
+
+	// Tension face width, sampled just inside the bottom fiber
+	bt := s.widthAtY(props.MinY + props.Height*1e-3)
+
+	result.WebWidth = bw
+	result.TensionWidth = bt
+	result.FlangeInTension = bt > bw*1.01
+	result.AsMinWidth = bw
+	if result.FlangeInTension && s.StaticallyDeterminate {
+		result.AsMinWidth = code.TensionFlangeMinWidth(bw, bt)
+	}
+}
@@ -0,0 +1,74 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// StarterShapes names the shapes of the sections returned by Starter
+var StarterShapes = []string{"rectangular", "t-beam", "inverted-t", "l-beam", "i-beam", "box"}
+
+// starterNote is appended to the description of every starter section
+const starterNote = "Values in mm and MPa; vertices counter-clockwise with y up from the bottom. " +
+	"Replace the dimensions and the placeholder reinforcement with your own."
+
+// Starter returns a valid section of a common shape with typical dimensions
+// and placeholder reinforcement, as a starting point for a section file
+func Starter(shape string) (*Section, error) {
+	s := &Section{Fc: 28, Fy: 415}
+	switch shape {
+	case "rectangular":
+		s.Name = "Rectangular Section"
+		s.Description = "300 mm wide, 500 mm deep"
+		s.Vertices = []Point{{0, 0}, {300, 0}, {300, 500}, {0, 500}}
+		s.Reinforcement = []RebarLayer{
+			barLayer(65, 3, 20, "tension"),
+			barLayer(435, 2, 16, "compression"),
+		}
+	case "t-beam":
+		s.Name = "T-Beam Section"
+		s.Description = "600 x 100 mm flange on a 300 mm web, 500 mm deep"
+		s.Vertices = []Point{{150, 0}, {450, 0}, {450, 400}, {600, 400}, {600, 500}, {0, 500}, {0, 400}, {150, 400}}
+		s.Reinforcement = []RebarLayer{barLayer(65, 4, 20, "tension")}
+	case "inverted-t":
+		s.Name = "Inverted T-Beam Section"
+		s.Description = "600 x 100 mm bottom flange under a 300 mm web, 500 mm deep. " +
+			"Set statically_determinate for a simply supported or cantilever member"
+		s.Vertices = []Point{{0, 0}, {600, 0}, {600, 100}, {450, 100}, {450, 500}, {150, 500}, {150, 100}, {0, 100}}
+		s.Reinforcement = []RebarLayer{barLayer(65, 4, 20, "tension")}
+	case "l-beam":
+		s.Name = "L-Beam Section"
+		s.Description = "300 mm web with a 200 x 100 mm flange on one side, 500 mm deep"
+		s.Vertices = []Point{{0, 0}, {300, 0}, {300, 400}, {500, 400}, {500, 500}, {0, 500}}
+		s.Reinforcement = []RebarLayer{barLayer(65, 3, 20, "tension")}
+	case "i-beam":
+		s.Name = "I-Beam Section"
+		s.Description = "400 x 120 mm flanges on a 150 mm web, 700 mm deep"
+		s.Vertices = []Point{{0, 0}, {400, 0}, {400, 120}, {275, 120}, {275, 580}, {400, 580},
+			{400, 700}, {0, 700}, {0, 580}, {125, 580}, {125, 120}, {0, 120}}
+		s.Reinforcement = []RebarLayer{barLayer(60, 6, 20, "tension")}
+	case "box":
+		s.Name = "Box Girder Section"
+		s.Description = "Single-cell 800 x 900 mm box with 200 mm webs and flanges"
+		s.Vertices = []Point{{0, 0}, {800, 0}, {800, 900}, {0, 900}}
+		s.Holes = [][]Point{{{200, 200}, {600, 200}, {600, 700}, {200, 700}}}
+		s.StirrupCover = 50
+		s.Reinforcement = []RebarLayer{barLayer(70, 8, 25, "tension")}
+	default:
+		return nil, fmt.Errorf("unknown shape %q (use %s)", shape, strings.Join(StarterShapes, ", "))
+	}
+	s.Description += ". " + starterNote
+	return s, s.Validate()
+}
+
+// barLayer returns a placeholder layer of n bars of a diameter (mm)
+func barLayer(y float64, n int, dia float64, typ string) RebarLayer {
+	area := math.Round(float64(n)*math.Pi*dia*dia/4*100) / 100
+	return RebarLayer{
+		Y:           y,
+		Area:        area,
+		Description: fmt.Sprintf("%d-%gmm %s steel (placeholder)", n, dia, typ),
+		Type:        typ,
+	}
+}
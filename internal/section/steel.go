@@ -0,0 +1,98 @@
+package section
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
+
+// Steel model types
+const (
+	SteelElasticPlastic = "elastic-plastic" // Code model: stress capped at fy
+	SteelBilinear       = "bilinear"        // Strain hardening with post-yield modulus
+)
+
+// SteelModel describes the stress-strain relationship of the reinforcement
+// The default elastic-perfectly-plastic model is the one assumed by the code
+// (NSCP 2015 Section 420.2.2.1). The bilinear model adds a post-yield modulus
+// and is intended for capacity design, e.g. probable moment strength Mpr.
+type SteelModel struct {
+	Type string `json:"type"` // "elastic-plastic" (default) or "bilinear"
+
+	Esh      float64 `json:"esh,omitempty"`       // Post-yield (hardening) modulus (MPa)
+	Fu       float64 `json:"fu,omitempty"`        // Ultimate tensile strength cap (MPa), 0 = no cap
+	EpsilonU float64 `json:"epsilon_u,omitempty"` // Bar fracture strain, 0 = no fracture check
+}
+
+// IsBilinear reports whether the model includes strain hardening
+func (m *SteelModel) IsBilinear() bool {
+	return m != nil && strings.EqualFold(m.Type, SteelBilinear)
+}
+
+// Validate checks the steel model parameters
+func (m *SteelModel) Validate(fy float64) error {
+	if m == nil {
+		return nil
+	}
+	switch strings.ToLower(m.Type) {
+	case "", SteelElasticPlastic:
+	case SteelBilinear:
+		if m.Esh < 0 {
+			return &ValidationError{msg: "post-yield modulus Esh must not be negative", Field: "steel_model.esh"}
+		}
+		if m.Fu != 0 && m.Fu < fy {
+			return &ValidationError{msg: fmt.Sprintf("ultimate strength fu=%.1f must not be less than fy=%.1f", m.Fu, fy), Field: "steel_model.fu"}
+		}
+	default:
+		return &ValidationError{msg: fmt.Sprintf("unknown steel model %q (use %s or %s)", m.Type, SteelElasticPlastic, SteelBilinear), Field: "steel_model.type"}
+	}
+	if m.EpsilonU < 0 {
+		return &ValidationError{msg: "fracture strain must not be negative", Field: "steel_model.epsilon_u"}
+	}
+	return nil
+}
+
+// Stress returns the steel stress for a given strain (compression positive)
+// and whether the strain exceeds the fracture strain. A fractured bar keeps
+// its capped stress so that the result can still be reported; the flag tells
+// the caller that bar fracture, not concrete crushing, governs.
+func (m *SteelModel) Stress(strain, fy float64) (stress float64, fractured bool) {
+	magnitude := math.Abs(strain)
+	fractured = m != nil && m.EpsilonU > 0 && magnitude > m.EpsilonU
+
+	epsilonY := fy / nscp.Es
+	switch {
+	case magnitude <= epsilonY:
+		stress = magnitude * nscp.Es
+	case m.IsBilinear():
+		stress = fy + m.Esh*(magnitude-epsilonY)
+		if m.Fu > 0 {
+			stress = math.Min(stress, m.Fu)
+		}
+	default:
+		stress = fy
+	}
+
+	return math.Copysign(stress, strain), fractured
+}
+
+// Describe returns a one-line summary of the model
+func (m *SteelModel) Describe() string {
+	if !m.IsBilinear() {
+		desc := "Elastic-perfectly plastic"
+		if m != nil && m.EpsilonU > 0 {
+			desc += fmt.Sprintf(", fracture at εsu = %.4f", m.EpsilonU)
+		}
+		return desc
+	}
+	desc := fmt.Sprintf("Bilinear, Esh = %.0f MPa", m.Esh)
+	if m.Fu > 0 {
+		desc += fmt.Sprintf(", fu = %.1f MPa", m.Fu)
+	}
+	if m.EpsilonU > 0 {
+		desc += fmt.Sprintf(", fracture at εsu = %.4f", m.EpsilonU)
+	}
+	return desc
+}
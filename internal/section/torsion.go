@@ -0,0 +1,263 @@
+package section
+
+import "math"
+
+// DefaultStirrupCover is the assumed distance from the concrete face to the
+// centerline of the outermost closed stirrup (40 mm clear cover + 10 mm stirrup / 2)
+const DefaultStirrupCover = 45.0
+
+// TorsionProperties holds the geometric properties used for torsion and shear design
+// NSCP 2015 Section 422.7 - Torsion
+type TorsionProperties struct {
+	// Outside concrete boundary
+	Acp float64 // Area enclosed by outside perimeter of concrete section (mm²)
+	Pcp float64 // Outside perimeter of concrete section (mm)
+	Ag  float64 // Net concrete area, excluding voids (mm²)
+
+	// Closed transverse reinforcement
+	StirrupCover float64 // Face to stirrup centerline distance used (mm)
+	Aoh          float64 // Area enclosed by centerline of outermost closed stirrup (mm²)
+	Ph           float64 // Perimeter of centerline of outermost closed stirrup (mm)
+	Ao           float64 // Gross area enclosed by shear flow path, 0.85Aoh (mm²)
+
+	// Threshold torsion geometry term, Acp²/pcp (Ag²/pcp for hollow sections)
+	// NSCP 2015 Section 422.7.4
+	ThresholdRatio float64 // mm³
+
+	// Elastic properties about the centroid
+	Ix float64 // Moment of inertia about horizontal centroidal axis (mm⁴)
+	Iy float64 // Moment of inertia about vertical centroidal axis (mm⁴)
+	Ip float64 // Polar moment of inertia (mm⁴)
+	J  float64 // Saint-Venant torsional constant (mm⁴)
+
+	// Shear properties
+	Bw        float64 // Web width, minimum solid width over the section depth (mm)
+	ShearArea float64 // bw·d (mm²)
+
+	IsHollow bool
+}
+
+// TorsionProperties computes the torsion and shear properties of the section
+// For solid sections J uses Saint-Venant's approximation J ≈ A⁴/(40·Ip).
+// For hollow sections J uses Bredt's thin-walled formula J = 4·Am²·t/pm
+// with the wall midline taken halfway between the outer boundary and the voids
+// and the wall thickness averaged as Ag/pm.
+func (s *Section) TorsionProperties() *TorsionProperties {
+	tp := &TorsionProperties{}
+	if len(s.Vertices) < 3 {
+		return tp
+	}
+
+	props := s.CalculateProperties()
+
+	tp.Acp, _, _ = polygonAreaAndCentroid(s.Vertices)
+	tp.Pcp = polygonPerimeter(s.Vertices)
+	tp.Ag = props.Area
+	tp.IsHollow = len(s.Holes) > 0
+
+	// Closed stirrup centerline
+	tp.StirrupCover = s.StirrupCover
+	if tp.StirrupCover <= 0 {
+		tp.StirrupCover = DefaultStirrupCover
+	}
+	hoop := offsetPolygon(s.Vertices, tp.StirrupCover)
+	if len(hoop) >= 3 {
+		tp.Aoh, _, _ = polygonAreaAndCentroid(hoop)
+		tp.Ph = polygonPerimeter(hoop)
+	}
+	tp.Ao = 0.85 * tp.Aoh
+
+	if tp.Pcp > 0 {
+		if tp.IsHollow {
+			tp.ThresholdRatio = tp.Ag * tp.Ag / tp.Pcp
+		} else {
+			tp.ThresholdRatio = tp.Acp * tp.Acp / tp.Pcp
+		}
+	}
+
+	// Second moments of area about the centroid (voids subtracted)
+	tp.Ix, tp.Iy = s.grossMomentsOfInertia(props)
+	tp.Ip = tp.Ix + tp.Iy
+
+	// Torsional constant
+	if tp.IsHollow {
+		var holeArea, holePerimeter float64
+		for _, hole := range s.Holes {
+			a, _, _ := polygonAreaAndCentroid(hole)
+			holeArea += a
+			holePerimeter += polygonPerimeter(hole)
+		}
+		// Midline area, exact for voids geometrically similar to the outline
+		am := math.Pow((math.Sqrt(tp.Acp)+math.Sqrt(holeArea))/2, 2)
+		pm := (tp.Pcp + holePerimeter) / 2
+		if pm > 0 {
+			t := tp.Ag / pm
+			tp.J = 4 * am * am * t / pm
+		}
+	} else if tp.Ip > 0 {
+		tp.J = math.Pow(tp.Ag, 4) / (40 * tp.Ip)
+	}
+
+	// Web width for shear: narrowest solid width over the depth
+	const numSteps = 200
+	dy := props.Height / numSteps
+	tp.Bw = props.Width
+	for i := 1; i < numSteps; i++ {
+		w := s.widthAtY(props.MinY + float64(i)*dy)
+		if w > 0 && w < tp.Bw {
+			tp.Bw = w
+		}
+	}
+	tp.ShearArea = tp.Bw * props.EffectiveDepth
+
+	return tp
+}
+
+// grossMomentsOfInertia returns the second moments of area of the concrete
+// section (voids subtracted) about its centroidal X and Y axes
+func (s *Section) grossMomentsOfInertia(props *SectionProperties) (ix, iy float64) {
+	ixo, iyo := polygonSecondMoments(s.Vertices)
+	for _, hole := range s.Holes {
+		hx, hy := polygonSecondMoments(hole)
+		ixo -= hx
+		iyo -= hy
+	}
+	ix = ixo - props.Area*props.CentroidY*props.CentroidY
+	iy = iyo - props.Area*props.CentroidX*props.CentroidX
+	return ix, iy
+}
+
+// CrackingProperties returns the gross moment of inertia Ig about the
+// horizontal centroidal axis (mm⁴) and the distance yt from the centroid to
+// the bottom (tension) fiber (mm) used for the cracking moment
+func (s *Section) CrackingProperties() (ig, yt float64) {
+	props := s.CalculateProperties()
+	ig, _ = s.grossMomentsOfInertia(props)
+	return ig, props.CentroidY - props.MinY
+}
+
+// polygonPerimeter returns the perimeter of a closed polygon
+func polygonPerimeter(vertices []Point) float64 {
+	var perimeter float64
+	n := len(vertices)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		perimeter += math.Hypot(vertices[j].X-vertices[i].X, vertices[j].Y-vertices[i].Y)
+	}
+	return perimeter
+}
+
+// FormedPerimeter returns the length of the faces of the section cast against
+// formwork (mm): the outer perimeter without its horizontal top edges, which
+// are left open or cast against a slab, plus the perimeters of the voids
+func (s *Section) FormedPerimeter() float64 {
+	if len(s.Vertices) < 3 {
+		return 0
+	}
+	perimeter := polygonPerimeter(s.Vertices)
+	top := s.Vertices[0].Y
+	for _, v := range s.Vertices {
+		top = math.Max(top, v.Y)
+	}
+	n := len(s.Vertices)
+	for i := 0; i < n; i++ {
+		a, b := s.Vertices[i], s.Vertices[(i+1)%n]
+		if math.Abs(a.Y-top) < 1e-6 && math.Abs(b.Y-top) < 1e-6 {
+			perimeter -= math.Abs(b.X - a.X)
+		}
+	}
+	for _, hole := range s.Holes {
+		perimeter += polygonPerimeter(hole)
+	}
+	return perimeter
+}
+
+// polygonSecondMoments returns the unsigned second moments of area of a
+// polygon about the global X and Y axes (Ix about X-axis, Iy about Y-axis)
+func polygonSecondMoments(vertices []Point) (ix, iy float64) {
+	n := len(vertices)
+	var signedArea float64
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		xi, yi := vertices[i].X, vertices[i].Y
+		xj, yj := vertices[j].X, vertices[j].Y
+		cross := xi*yj - xj*yi
+		signedArea += cross
+		ix += cross * (yi*yi + yi*yj + yj*yj)
+		iy += cross * (xi*xi + xi*xj + xj*xj)
+	}
+	ix /= 12
+	iy /= 12
+	if signedArea < 0 {
+		ix, iy = -ix, -iy
+	}
+	return ix, iy
+}
+
+// offsetPolygon moves every edge of a simple polygon inward by distance
+// and returns the polygon formed by the shifted edges.
+// Returns nil if the offset collapses the polygon.
+func offsetPolygon(vertices []Point, distance float64) []Point {
+	n := len(vertices)
+	if n < 3 {
+		return nil
+	}
+
+	// Orientation: inward normal is to the left for counter-clockwise polygons
+	var signedArea float64
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		signedArea += vertices[i].X*vertices[j].Y - vertices[j].X*vertices[i].Y
+	}
+	orient := 1.0
+	if signedArea < 0 {
+		orient = -1.0
+	}
+
+	// Shifted edge lines as point + direction
+	type line struct{ px, py, dx, dy float64 }
+	lines := make([]line, 0, n)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		dx := vertices[j].X - vertices[i].X
+		dy := vertices[j].Y - vertices[i].Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		nx, ny := -dy/length*orient, dx/length*orient
+		lines = append(lines, line{
+			px: vertices[i].X + nx*distance,
+			py: vertices[i].Y + ny*distance,
+			dx: dx,
+			dy: dy,
+		})
+	}
+
+	m := len(lines)
+	result := make([]Point, 0, m)
+	for i := 0; i < m; i++ {
+		prev := lines[(i+m-1)%m]
+		curr := lines[i]
+		denom := prev.dx*curr.dy - prev.dy*curr.dx
+		if math.Abs(denom) < 1e-9 {
+			// Collinear edges: the shifted start point is the vertex
+			result = append(result, Point{X: curr.px, Y: curr.py})
+			continue
+		}
+		t := ((curr.px-prev.px)*curr.dy - (curr.py-prev.py)*curr.dx) / denom
+		result = append(result, Point{X: prev.px + t*prev.dx, Y: prev.py + t*prev.dy})
+	}
+
+	// An inverted orientation means the offset passed through itself
+	var offsetArea float64
+	for i := 0; i < len(result); i++ {
+		j := (i + 1) % len(result)
+		offsetArea += result[i].X*result[j].Y - result[j].X*result[i].Y
+	}
+	if offsetArea*signedArea <= 0 {
+		return nil
+	}
+
+	return result
+}
@@ -1,6 +1,11 @@
 package section
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/nscp"
+)
 
 // Section represents a non-rectangular concrete section defined by vertices
 // The section is defined in a local coordinate system where:
@@ -10,21 +15,58 @@ import "fmt"
 type Section struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description,omitempty"`
-	
+
+	// Units of the values in the file (optional, defaults to mm and MPa).
+	// Values are converted to mm and MPa when the file is loaded.
+	Units *Units `json:"units,omitempty"`
+
 	// Material properties
 	Fc float64 `json:"fc"` // Concrete compressive strength (MPa)
 	Fy float64 `json:"fy"` // Steel yield strength (MPa)
 
+	// Lightweight concrete modification factor λ (optional, defaults to 1.0)
+	Lambda float64 `json:"lambda,omitempty"`
+
+	// Steel stress-strain model (optional, defaults to elastic-perfectly plastic)
+	SteelModel *SteelModel `json:"steel_model,omitempty"`
+
+	// Transverse confinement of the core (optional, enables confined analysis)
+	Confinement *Confinement `json:"confinement,omitempty"`
+
 	// Section geometry defined by vertices (in mm)
 	// Vertices should be defined counter-clockwise for the outer boundary
-	// The section is assumed to be a simple polygon (no holes)
 	Vertices []Point `json:"vertices"`
 
+	// Optional interior voids (e.g., box girder cells), each a simple polygon
+	// lying entirely inside the outer boundary
+	Holes [][]Point `json:"holes,omitempty"`
+
 	// Reinforcement layers
 	Reinforcement []RebarLayer `json:"reinforcement"`
 
 	// Effective depth override (optional, calculated from reinforcement if not provided)
 	EffectiveDepth float64 `json:"effective_depth,omitempty"`
+
+	// Distance from the concrete face to the centerline of the outermost
+	// closed stirrup (mm), used for Aoh and ph. Defaults to DefaultStirrupCover.
+	StirrupCover float64 `json:"stirrup_cover,omitempty"`
+
+	// Transverse reinforcement: "tied" (default) or "spiral", which sets φ
+	// for compression-controlled sections
+	Transverse string `json:"transverse,omitempty"`
+
+	// Statically determinate member (e.g. simply supported or cantilever);
+	// a flange in tension then raises the minimum reinforcement
+	StaticallyDeterminate bool `json:"statically_determinate,omitempty"`
+
+	// Design code used for β1, φ and reinforcement limits (defaults to NSCP 2015)
+	Code codes.DesignCode `json:"-"`
+}
+
+// Units names the length and stress units used in a section file
+type Units struct {
+	Length string `json:"length,omitempty"` // "mm" (default), "cm", "m", "in" or "ft"
+	Stress string `json:"stress,omitempty"` // "MPa" (default), "psi" or "ksi"
 }
 
 // Point represents a 2D coordinate
@@ -75,28 +117,49 @@ type SectionProperties struct {
 // Validate checks if the section definition is valid
 func (s *Section) Validate() error {
 	if len(s.Vertices) < 3 {
-		return &ValidationError{"section must have at least 3 vertices"}
+		return &ValidationError{msg: "section must have at least 3 vertices", Field: "vertices"}
 	}
 	if s.Fc <= 0 {
-		return &ValidationError{"f'c must be positive"}
+		return &ValidationError{msg: "f'c must be positive", Field: "fc"}
 	}
 	if s.Fy <= 0 {
-		return &ValidationError{"fy must be positive"}
+		return &ValidationError{msg: "fy must be positive", Field: "fy"}
 	}
 	if len(s.Reinforcement) == 0 {
-		return &ValidationError{"section must have at least one reinforcement layer"}
+		return &ValidationError{msg: "section must have at least one reinforcement layer", Field: "reinforcement"}
 	}
 	for i, layer := range s.Reinforcement {
 		if layer.Area <= 0 {
-			return &ValidationError{msg: fmt.Sprintf("reinforcement layer %d must have positive area", i+1)}
+			return &ValidationError{msg: fmt.Sprintf("reinforcement layer %d must have positive area", i+1), Field: fmt.Sprintf("reinforcement[%d].area", i)}
+		}
+	}
+	for i, hole := range s.Holes {
+		if len(hole) < 3 {
+			return &ValidationError{msg: fmt.Sprintf("hole %d must have at least 3 vertices", i+1), Field: fmt.Sprintf("holes[%d]", i)}
 		}
 	}
+	if s.Lambda < 0 || s.Lambda > 1 {
+		return &ValidationError{msg: "lambda must be between 0 and 1", Field: "lambda"}
+	}
+	if s.StirrupCover < 0 {
+		return &ValidationError{msg: "stirrup cover must not be negative", Field: "stirrup_cover"}
+	}
+	if err := s.SteelModel.Validate(s.Fy); err != nil {
+		return err
+	}
+	if err := s.Confinement.Validate(); err != nil {
+		return err
+	}
+	if _, err := nscp.ParseTransverse(s.Transverse); err != nil {
+		return &ValidationError{msg: err.Error(), Field: "transverse"}
+	}
 	return nil
 }
 
 // ValidationError represents a section validation error
 type ValidationError struct {
-	msg string
+	msg   string
+	Field string // JSON name of the value at fault, e.g. reinforcement[0].area
 }
 
 func (e *ValidationError) Error() string {
@@ -0,0 +1,94 @@
+package section
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/alexiusacademia/gorcb/internal/units"
+)
+
+// NormalizeUnits converts all values of the section to mm and MPa
+// according to its Units field, then clears the Units field
+func (s *Section) NormalizeUnits() error {
+	if s.Units == nil {
+		return nil
+	}
+
+	lf, err := units.LengthFactor(s.Units.Length)
+	if err != nil {
+		return &ValidationError{msg: err.Error(), Field: "units.length"}
+	}
+	sf, err := units.StressFactor(s.Units.Stress)
+	if err != nil {
+		return &ValidationError{msg: err.Error(), Field: "units.stress"}
+	}
+	s.scale(lf, sf, func(v float64) float64 { return v })
+	s.Units = nil
+	return nil
+}
+
+// ConvertUnits converts all values of a section in mm and MPa to the units
+// u and sets its Units field, the reverse of NormalizeUnits. Values are
+// rounded to 6 significant digits so that the file stays readable.
+func (s *Section) ConvertUnits(u Units) error {
+	if s.Units != nil {
+		if err := s.NormalizeUnits(); err != nil {
+			return err
+		}
+	}
+	lf, err := units.LengthFactor(u.Length)
+	if err != nil {
+		return &ValidationError{msg: err.Error(), Field: "units.length"}
+	}
+	sf, err := units.StressFactor(u.Stress)
+	if err != nil {
+		return &ValidationError{msg: err.Error(), Field: "units.stress"}
+	}
+	s.scale(1/lf, 1/sf, significant)
+	if lf != 1 || sf != 1 {
+		s.Units = &u
+	}
+	return nil
+}
+
+// significant rounds a value to 6 significant digits
+func significant(v float64) float64 {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return v
+	}
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 6, 64), 64)
+	return r
+}
+
+// scale multiplies the lengths of a section by lf, its areas by lf² and its
+// stresses by sf, passing every result through round
+func (s *Section) scale(lf, sf float64, round func(float64) float64) {
+	s.Fc = round(s.Fc * sf)
+	s.Fy = round(s.Fy * sf)
+
+	for i := range s.Vertices {
+		s.Vertices[i].X = round(s.Vertices[i].X * lf)
+		s.Vertices[i].Y = round(s.Vertices[i].Y * lf)
+	}
+	for _, hole := range s.Holes {
+		for i := range hole {
+			hole[i].X = round(hole[i].X * lf)
+			hole[i].Y = round(hole[i].Y * lf)
+		}
+	}
+	for i := range s.Reinforcement {
+		s.Reinforcement[i].Y = round(s.Reinforcement[i].Y * lf)
+		s.Reinforcement[i].Area = round(s.Reinforcement[i].Area * lf * lf)
+	}
+	s.EffectiveDepth = round(s.EffectiveDepth * lf)
+	s.StirrupCover = round(s.StirrupCover * lf)
+
+	if m := s.SteelModel; m != nil {
+		m.Esh = round(m.Esh * sf)
+		m.Fu = round(m.Fu * sf)
+	}
+	if cf := s.Confinement; cf != nil {
+		cf.Cover = round(cf.Cover * lf)
+		cf.Fyh = round(cf.Fyh * sf)
+	}
+}
@@ -0,0 +1,129 @@
+// Package server exposes the beam, batch and section engines as a JSON HTTP
+// API, described by the OpenAPI 3 specification served at /openapi.yaml.
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/alexiusacademia/gorcb/internal/api"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/version"
+)
+
+// Spec is the OpenAPI 3 specification of the API
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+// MaxRequestSize is the largest request body accepted (bytes)
+const MaxRequestSize = 1 << 20
+
+// Server answers the API requests with a design code
+type Server struct {
+	engine *api.Engine
+}
+
+// New returns a server of the design code
+func New(code codes.DesignCode) *Server {
+	return &Server{engine: api.New(code)}
+}
+
+// statuses are the HTTP statuses of the error codes
+var statuses = map[string]int{
+	api.CodeInvalidJSON:          http.StatusBadRequest,
+	api.CodeInvalidInput:         http.StatusUnprocessableEntity,
+	api.CodeNoConvergence:        http.StatusUnprocessableEntity,
+	api.CodeNotFound:             http.StatusNotFound,
+	api.CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	api.CodeTooLarge:             http.StatusRequestEntityTooLarge,
+}
+
+// Handler returns the routes of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	s.Register(mux)
+	mux.HandleFunc("/", NotFound)
+	return mux
+}
+
+// Register adds the routes of the API to a mux
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /openapi.yaml", serveSpec)
+	mux.HandleFunc("GET /v1/version", serveVersion)
+	mux.Handle("POST /v1/beam", handle(s.engine.Beam))
+	mux.Handle("POST /v1/batch", handle(s.engine.Batch))
+	mux.Handle("POST /v1/section/analyze", handle(s.engine.AnalyzeSection))
+}
+
+// NotFound answers a request no route matches
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, &api.Error{Code: api.CodeNotFound,
+		Message: fmt.Sprintf("no route %s %s (see /openapi.yaml)", r.Method, r.URL.Path)})
+}
+
+func serveSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(Spec)
+}
+
+func serveVersion(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, map[string]string{"version": version.Version})
+}
+
+// handle returns the handler of an engine request
+func handle(run func(data []byte) (*api.Response, *api.Error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := ReadJSON(w, r)
+		if !ok {
+			return
+		}
+		resp, err := run(data)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, resp)
+	})
+}
+
+// ReadJSON returns the JSON body of a request, or writes the error of other
+// content types and oversized bodies and reports false
+func ReadJSON(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); t != "application/json" {
+		WriteError(w, &api.Error{Code: api.CodeUnsupportedMediaType, Message: "request body must be application/json"})
+		return nil, false
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		WriteError(w, &api.Error{Code: api.CodeTooLarge,
+			Message: fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+		return nil, false
+	}
+	if err != nil {
+		WriteError(w, &api.Error{Code: api.CodeInvalidJSON, Message: err.Error()})
+		return nil, false
+	}
+	return data, true
+}
+
+// WriteError writes the error payload of a failed request
+func WriteError(w http.ResponseWriter, e *api.Error) {
+	WriteJSON(w, statuses[e.Code], api.ErrorResponse{Error: e})
+}
+
+// WriteJSON writes v as the indented JSON body of a response
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
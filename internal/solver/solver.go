@@ -0,0 +1,29 @@
+// Package solver holds the iteration limit and tolerance of the neutral axis
+// solvers of the beam and section engines
+package solver
+
+// Settings bound the neutral axis iterations of a solver. Zero values keep
+// the defaults of each solver.
+type Settings struct {
+	MaxIterations int     // Iterations of each method before giving up
+	Tolerance     float64 // Force imbalance T − (Cc + Cs) accepted at equilibrium (kN)
+}
+
+// Default are the settings of every solver, set by --max-iter and --tolerance
+var Default Settings
+
+// Iterations returns the iteration limit, or def when none is set
+func (s Settings) Iterations(def int) int {
+	if s.MaxIterations > 0 {
+		return s.MaxIterations
+	}
+	return def
+}
+
+// Imbalance returns the force tolerance in kN, or def when none is set
+func (s Settings) Imbalance(def float64) float64 {
+	if s.Tolerance > 0 {
+		return s.Tolerance
+	}
+	return def
+}
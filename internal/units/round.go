@@ -0,0 +1,150 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantities a rounding policy applies to. Spacing is a length rounded on
+// its own, e.g. stirrup and bar spacings down to 5 mm.
+const (
+	QuantityLength  = "length"
+	QuantitySpacing = "spacing"
+	QuantityArea    = "area"
+	QuantityStress  = "stress"
+	QuantityForce   = "force"
+	QuantityMoment  = "moment"
+)
+
+// Quantities lists the quantities of a rounding policy
+var Quantities = []string{QuantityLength, QuantitySpacing, QuantityArea, QuantityStress, QuantityForce, QuantityMoment}
+
+// Rounding directions
+const (
+	RoundNearest = "nearest"
+	RoundUp      = "up"
+	RoundDown    = "down"
+)
+
+// defaultDirections are the conservative directions of the quantities a
+// rule is given without one: steel areas up, spacings down
+var defaultDirections = map[string]string{
+	QuantityArea:    RoundUp,
+	QuantitySpacing: RoundDown,
+}
+
+// quantityAliases are the other names accepted for quantities
+var quantityAliases = map[string]string{
+	"as": QuantityArea,
+}
+
+// Rule rounds printed values of a quantity to multiples of a step
+type Rule struct {
+	Step      float64 // In the printed unit, e.g. 10 for 10 mm²
+	Direction string  // nearest, up or down
+}
+
+// Apply rounds v to a multiple of the step in the direction of the rule
+func (r Rule) Apply(v float64) float64 {
+	if r.Step <= 0 {
+		return v
+	}
+	// Tolerate the representation error of values already on a step
+	n := v / r.Step
+	switch r.Direction {
+	case RoundUp:
+		n = math.Ceil(n - 1e-9)
+	case RoundDown:
+		n = math.Floor(n + 1e-9)
+	default:
+		n = math.Round(n)
+	}
+	return n * r.Step
+}
+
+// Decimals returns the decimals needed to print multiples of the step,
+// e.g. 0 for 10 and 1 for 0.5
+func (r Rule) Decimals() int {
+	for d := 0; d < 6; d++ {
+		scaled := r.Step * math.Pow10(d)
+		if math.Abs(scaled-math.Round(scaled)) < 1e-9 {
+			return d
+		}
+	}
+	return 6
+}
+
+// Policy is the rounding rule of each quantity with one
+type Policy map[string]Rule
+
+// ParsePolicy parses rounding rules as quantity=step[:direction] pairs,
+// e.g. "area=10,spacing=5" or "moment=0.5:nearest". Areas are rounded up and
+// spacings down unless a direction is given, other quantities to the nearest
+// step.
+func ParsePolicy(s string) (Policy, error) {
+	policy := Policy{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("rounding rule %q: use quantity=step[:direction]", pair)
+		}
+		quantity := strings.ToLower(strings.TrimSpace(name))
+		if alias, ok := quantityAliases[quantity]; ok {
+			quantity = alias
+		}
+		if !isQuantity(quantity) {
+			return nil, fmt.Errorf("rounding rule %q: unknown quantity %q (use %s)", pair, name, strings.Join(Quantities, ", "))
+		}
+
+		stepText, direction, _ := strings.Cut(value, ":")
+		step, err := strconv.ParseFloat(strings.TrimSpace(stepText), 64)
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf("rounding rule %q: step must be a positive number", pair)
+		}
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		switch direction {
+		case "":
+			direction = defaultDirections[quantity]
+			if direction == "" {
+				direction = RoundNearest
+			}
+		case RoundNearest, RoundUp, RoundDown:
+		default:
+			return nil, fmt.Errorf("rounding rule %q: unknown direction %q (use %s, %s or %s)", pair, direction, RoundNearest, RoundUp, RoundDown)
+		}
+		policy[quantity] = Rule{Step: step, Direction: direction}
+	}
+	return policy, nil
+}
+
+// Quantity returns the quantity of an SI unit label, e.g. area for "mm²"
+func Quantity(siLabel string) (string, bool) {
+	switch siLabel {
+	case "mm":
+		return QuantityLength, true
+	case "mm²":
+		return QuantityArea, true
+	case "MPa":
+		return QuantityStress, true
+	case "kN":
+		return QuantityForce, true
+	case "kN-m":
+		return QuantityMoment, true
+	}
+	return "", false
+}
+
+func isQuantity(name string) bool {
+	for _, q := range Quantities {
+		if q == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Conversion constants to the canonical SI units used by the engines
+// (lengths in mm, stresses in MPa)
+const (
+	MMPerInch = 25.4
+	MMPerFoot = 304.8
+	MPaPerPsi = 0.00689475729
+	MPaPerKsi = 6.89475729
+)
+
+// LengthFactor returns the factor that converts a length in the named unit to mm
+func LengthFactor(unit string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "mm":
+		return 1, nil
+	case "cm":
+		return 10, nil
+	case "m":
+		return 1000, nil
+	case "in", "inch", "inches":
+		return MMPerInch, nil
+	case "ft", "feet":
+		return MMPerFoot, nil
+	}
+	return 0, fmt.Errorf("unknown length unit %q (use mm, cm, m, in or ft)", unit)
+}
+
+// StressFactor returns the factor that converts a stress in the named unit to MPa
+func StressFactor(unit string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "mpa", "n/mm2", "n/mm²":
+		return 1, nil
+	case "psi":
+		return MPaPerPsi, nil
+	case "ksi":
+		return MPaPerKsi, nil
+	}
+	return 0, fmt.Errorf("unknown stress unit %q (use MPa, psi or ksi)", unit)
+}
+
+// Conversion constants of the US customary result units to the engine units
+// (areas in mm², forces in kN, moments in kN-m, bar masses in kg/m)
+const (
+	MM2PerSqInch  = 645.16
+	KNPerKip      = 4.4482216152605
+	KNmPerKipFoot = KNPerKip * MMPerFoot / 1000
+	KgmPerLbFoot  = 0.45359237 / (MMPerFoot / 1000)
+)
+
+// Systems of units selected with --units
+const (
+	SystemSI = "si"
+	SystemUS = "us"
+)
+
+// Unit is the unit a quantity is given and printed in
+type Unit struct {
+	Label  string  // e.g. "mm" or "in"
+	Factor float64 // Engine units (mm, mm², MPa, kN or kN-m; m along a member) per unit
+	Digits int     // Decimals added to the SI precision when printing
+}
+
+// ToSI converts a value in the unit to the engine unit
+func (u Unit) ToSI(v float64) float64 {
+	return v * u.Factor
+}
+
+// FromSI converts a value in the engine unit to the unit
+func (u Unit) FromSI(v float64) float64 {
+	return v / u.Factor
+}
+
+// Format prints an engine value in the unit with its label, e.g. "11.81 in"
+// for 300 mm. prec is the number of decimals printed in SI units.
+func (u Unit) Format(v float64, prec int) string {
+	return fmt.Sprintf("%.*f %s", prec+u.Digits, u.FromSI(v), u.Label)
+}
+
+// System is the set of units inputs are given and results printed in.
+// Calculations always run in SI units.
+type System struct {
+	Name   string
+	Length Unit
+	Area   Unit
+	Stress Unit
+	Force  Unit
+	Moment Unit
+	Span   Unit // Positions and lengths along a member, in m
+	Mass   Unit // Steel mass per length of member, in kg/m
+}
+
+// SI returns the engine units: mm, mm², MPa, kN and kN-m, with positions
+// along a member in m and steel masses in kg/m
+func SI() System {
+	return System{
+		Name:   SystemSI,
+		Length: Unit{Label: "mm", Factor: 1},
+		Area:   Unit{Label: "mm²", Factor: 1},
+		Stress: Unit{Label: "MPa", Factor: 1},
+		Force:  Unit{Label: "kN", Factor: 1},
+		Moment: Unit{Label: "kN-m", Factor: 1},
+		Span:   Unit{Label: "m", Factor: 1},
+		Mass:   Unit{Label: "kg/m", Factor: 1},
+	}
+}
+
+// US returns the US customary units: in, in², ksi, kip and kip-ft, with
+// positions along a member in ft and steel masses in lb/ft
+func US() System {
+	return System{
+		Name:   SystemUS,
+		Length: Unit{Label: "in", Factor: MMPerInch, Digits: 1},
+		Area:   Unit{Label: "in²", Factor: MM2PerSqInch, Digits: 1},
+		Stress: Unit{Label: "ksi", Factor: MPaPerKsi, Digits: 1},
+		Force:  Unit{Label: "kip", Factor: KNPerKip},
+		Moment: Unit{Label: "kip-ft", Factor: KNmPerKipFoot},
+		Span:   Unit{Label: "ft", Factor: MMPerFoot / 1000},
+		Mass:   Unit{Label: "lb/ft", Factor: KgmPerLbFoot},
+	}
+}
+
+// GetSystem returns the system of units with the given name
+func GetSystem(name string) (System, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", SystemSI, "metric":
+		return SI(), nil
+	case SystemUS, "imperial":
+		return US(), nil
+	}
+	return System{}, fmt.Errorf("unknown units %q (use %s or %s)", name, SystemSI, SystemUS)
+}
+
+// IsSI reports whether the system is the engine's SI units
+func (s System) IsSI() bool {
+	return s.Name == "" || s.Name == SystemSI
+}
+
+// Unit returns the unit of the system for the quantity of an SI unit label,
+// e.g. the length unit for "mm"
+func (s System) Unit(siLabel string) (Unit, bool) {
+	switch siLabel {
+	case "mm":
+		return s.Length, true
+	case "mm²":
+		return s.Area, true
+	case "MPa":
+		return s.Stress, true
+	case "kN":
+		return s.Force, true
+	case "kN-m":
+		return s.Moment, true
+	}
+	return Unit{}, false
+}
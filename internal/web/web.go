@@ -0,0 +1,19 @@
+// Package web holds the single-page UI of gorcb web, which calls the JSON API
+// of gorcb serve from forms for beam and section inputs.
+package web
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// page is the UI, with its styles and script inline so it needs no other files
+//
+//go:embed index.html
+var page []byte
+
+// Page serves the UI
+func Page(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
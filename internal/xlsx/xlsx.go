@@ -0,0 +1,246 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Workbook is an Excel workbook of sheets of text and number cells
+type Workbook struct {
+	Sheets []*Sheet
+}
+
+// Sheet is a worksheet of tables, one below the other
+type Sheet struct {
+	Name string
+	rows []row
+}
+
+// row is one row of cells, bold for table headers
+type row struct {
+	cells []string
+	bold  bool
+}
+
+// maxSheetName is the longest sheet name Excel accepts
+const maxSheetName = 31
+
+// New returns an empty workbook
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet adds a sheet. Characters Excel does not allow in sheet names are
+// replaced, long names are shortened and repeated names are numbered.
+func (b *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", len(b.Sheets)+1)
+	}
+	unique := truncate(name, maxSheetName)
+	for n := 2; b.has(unique); n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		unique = truncate(name, maxSheetName-len(suffix)) + suffix
+	}
+	s := &Sheet{Name: unique}
+	b.Sheets = append(b.Sheets, s)
+	return s
+}
+
+// has reports whether the workbook has a sheet of a name, ignoring case as
+// Excel does
+func (b *Workbook) has(name string) bool {
+	for _, s := range b.Sheets {
+		if strings.EqualFold(s.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate shortens a name to at most n characters
+func truncate(name string, n int) string {
+	if utf8.RuneCountInString(name) <= n {
+		return name
+	}
+	return string([]rune(name)[:n])
+}
+
+// Table adds a table with a bold header row below the tables already on the
+// sheet, separated by an empty row
+func (s *Sheet) Table(header []string, rows [][]string) {
+	if len(s.rows) > 0 {
+		s.rows = append(s.rows, row{})
+	}
+	s.rows = append(s.rows, row{cells: header, bold: true})
+	for _, r := range rows {
+		s.rows = append(s.rows, row{cells: r})
+	}
+}
+
+// WriteFile writes the workbook to an .xlsx file, creating its directory
+// when missing
+func (b *Workbook) WriteFile(path string) error {
+	if len(b.Sheets) == 0 {
+		b.AddSheet("Results")
+	}
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", b.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", b.workbook()},
+		{"xl/_rels/workbook.xml.rels", b.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+	for i, s := range b.Sheets {
+		parts = append(parts, struct{ name, content string }{
+			fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), s.xml()})
+	}
+	modified := time.Now()
+	for _, part := range parts {
+		w, err := z.CreateHeader(&zip.FileHeader{Name: part.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return err
+		}
+	}
+	if err := z.Close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const rootRels = xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles defines the default cell format and a bold one for table headers
+const styles = xmlHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+func (b *Workbook) contentTypes() string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range b.Sheets {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+func (b *Workbook) workbook() string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, s := range b.Sheets {
+		fmt.Fprintf(&sb, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(s.Name), i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+	return sb.String()
+}
+
+func (b *Workbook) workbookRels() string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range b.Sheets {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(b.Sheets)+1)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// xml returns the worksheet with its columns sized to their widest cells.
+// Cells holding a number written without rounding are stored as numbers.
+func (s *Sheet) xml() string {
+	var widths []int
+	for _, r := range s.rows {
+		for i, c := range r.cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xmlHeader + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(widths) > 0 {
+		sb.WriteString(`<cols>`)
+		for i, w := range widths {
+			fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, min(max(w, 6), 60)+2)
+		}
+		sb.WriteString(`</cols>`)
+	}
+	sb.WriteString(`<sheetData>`)
+	for i, r := range s.rows {
+		fmt.Fprintf(&sb, `<row r="%d">`, i+1)
+		for j, c := range r.cells {
+			if c == "" {
+				continue
+			}
+			ref := column(j) + strconv.Itoa(i+1)
+			style := ""
+			if r.bold {
+				style = ` s="1"`
+			}
+			if v, err := strconv.ParseFloat(c, 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) &&
+				strconv.FormatFloat(v, 'f', -1, 64) == c {
+				fmt.Fprintf(&sb, `<c r="%s"%s><v>%s</v></c>`, ref, style, c)
+			} else {
+				fmt.Fprintf(&sb, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(c))
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// column returns the letters of a zero-based column index, e.g. "AB" for 27
+func column(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// escape escapes text for an XML attribute or element
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,253 @@
+// Package plots builds the diagrams of gorcb as gonum plots, for Go programs
+// that compose them into documents of their own instead of exporting each to
+// a file:
+//
+//	section := plots.SectionDiagramData{
+//		Width: 300, Height: 500,
+//		NeutralAxisDepth: 92.4, StressBlockDepth: 78.5,
+//		TensionSteelY: 62.5, TensionSteelArea: 1256.6,
+//		EpsilonCU: 0.003, EpsilonT: 0.0155, EpsilonY: 0.002075,
+//		Fc: 23.8, FsTension: 415, TensionYields: true,
+//	}
+//	p, err := plots.Section(section)
+//	if err != nil {
+//		return err
+//	}
+//	img := vgimg.New(8*vg.Inch, 6*vg.Inch)
+//	p.Draw(draw.New(img))
+//
+// Diagrams of several plots sharing a scale, e.g. the section beside its
+// strain and stress, are drawn on a canvas with the Draw functions instead,
+// such as a tile of a page:
+//
+//	page := draw.New(vgimg.New(8*vg.Inch, 10*vg.Inch))
+//	tiles := draw.Tiles{Rows: 2, Cols: 1}
+//	if err := plots.DrawCombined(tiles.At(page, 0, 0), section); err != nil {
+//		return err
+//	}
+//	moments.Draw(tiles.At(page, 0, 1)) // e.g. a plot of plots.Forces
+//
+// Lengths are in mm, stresses in MPa, forces in kN, moments in kN-m and
+// positions along beams in m, as in the fields of the data types; the Units
+// of a diagram only set the units its values are printed in.
+package plots
+
+import (
+	"github.com/alexiusacademia/gorcb/internal/diagram"
+	"github.com/alexiusacademia/gorcb/internal/i18n"
+	"github.com/alexiusacademia/gorcb/internal/units"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Data of the diagrams, as described in the fields of each type
+type (
+	Point = diagram.Point
+	Bar   = diagram.Bar
+
+	SectionDiagramData     = diagram.SectionDiagramData
+	InteractionDiagramData = diagram.InteractionDiagramData
+	BiaxialContour         = diagram.BiaxialContour
+	BiaxialDiagramData     = diagram.BiaxialDiagramData
+	InclinedAxisData       = diagram.InclinedAxisData
+	ComparisonResult       = diagram.ComparisonResult
+	ComparedSection        = diagram.ComparedSection
+	ComparisonData         = diagram.ComparisonData
+	ConvergenceStep        = diagram.ConvergenceStep
+	ConvergenceData        = diagram.ConvergenceData
+	FiberField             = diagram.FiberField
+	FiberCell              = diagram.FiberCell
+	FiberStrip             = diagram.FiberStrip
+	FiberLayer             = diagram.FiberLayer
+	FiberDiagramData       = diagram.FiberDiagramData
+
+	ForceDiagramKind      = diagram.ForceDiagramKind
+	ForceCurve            = diagram.ForceCurve
+	ForceDiagramData      = diagram.ForceDiagramData
+	EnvelopeValue         = diagram.EnvelopeValue
+	EnvelopeDiagramData   = diagram.EnvelopeDiagramData
+	ElevationLoad         = diagram.ElevationLoad
+	ElevationDiagramData  = diagram.ElevationDiagramData
+	ShearZone             = diagram.ShearZone
+	ShearCapacityData     = diagram.ShearCapacityData
+	StirrupZone           = diagram.StirrupZone
+	StirrupDiagramData    = diagram.StirrupDiagramData
+	BarExtent             = diagram.BarExtent
+	BarLap                = diagram.BarLap
+	CutoffDiagramData     = diagram.CutoffDiagramData
+	DeflectionCurve       = diagram.DeflectionCurve
+	DeflectionLimit       = diagram.DeflectionLimit
+	DeflectionDiagramData = diagram.DeflectionDiagramData
+
+	ChartCurve  = diagram.ChartCurve
+	ChartMark   = diagram.ChartMark
+	ChartRegion = diagram.ChartRegion
+	ChartData   = diagram.ChartData
+
+	// Units are the units the values of a diagram are printed in
+	Units = units.System
+)
+
+// Kinds of force diagrams
+const (
+	MomentDiagram = diagram.MomentDiagram
+	ShearDiagram  = diagram.ShearDiagram
+)
+
+// Quantities the fibers of a fiber diagram are colored by
+const (
+	FiberStress = diagram.FiberStress
+	FiberStrain = diagram.FiberStrain
+)
+
+// End supports of an elevation
+const (
+	SupportPinned = diagram.SupportPinned
+	SupportFixed  = diagram.SupportFixed
+	SupportFree   = diagram.SupportFree
+)
+
+// SI returns the SI units: mm, MPa, kN, kN-m and spans in m
+func SI() Units {
+	return units.SI()
+}
+
+// US returns the US customary units: in, ksi, kip, kip-ft and spans in ft
+func US() Units {
+	return units.US()
+}
+
+// SetTheme selects the theme the diagrams are drawn in by name, one of
+// ThemeNames
+func SetTheme(name string) error {
+	return diagram.SetTheme(name)
+}
+
+// ThemeNames returns the names of the themes
+func ThemeNames() []string {
+	return diagram.ThemeNames()
+}
+
+// SetLanguage selects the language of the titles, axes and legends of the
+// diagrams by code: en, fil or es
+func SetLanguage(code string) error {
+	return i18n.Set(code)
+}
+
+// Section returns a beam section with its stress block, neutral axis and
+// bars. The section is drawn to scale in an 8 by 6 inch figure; EqualScale
+// keeps it to scale on a canvas of another size.
+func Section(data SectionDiagramData) (*plot.Plot, error) {
+	return diagram.SectionPlot(data)
+}
+
+// SectionSketch returns a beam section dimensioned as an engineering sketch,
+// as Section
+func SectionSketch(data SectionDiagramData) (*plot.Plot, error) {
+	return diagram.SectionSketchPlot(data)
+}
+
+// EqualScale widens the range of one axis of a Section or SectionSketch
+// plot so that it is drawn to scale on a canvas
+func EqualScale(p *plot.Plot, c draw.Canvas) {
+	diagram.EqualScale(p, c)
+}
+
+// Strain returns the strain distribution over the depth of a section
+func Strain(data SectionDiagramData) (*plot.Plot, error) {
+	return diagram.StrainPlot(data)
+}
+
+// Interaction returns the nominal and design P-M interaction curves of a
+// section with the applied load
+func Interaction(data InteractionDiagramData) (*plot.Plot, error) {
+	return diagram.InteractionPlot(data)
+}
+
+// Biaxial returns the φMnx-φMny contours of a biaxial interaction surface
+func Biaxial(data BiaxialDiagramData) (*plot.Plot, error) {
+	return diagram.BiaxialPlot(data)
+}
+
+// InclinedAxis returns a section bent about both axes with its inclined
+// neutral axis, the compression zone turned toward the compression face and
+// the angle of the axis from the X axis
+func InclinedAxis(data InclinedAxisData) (*plot.Plot, error) {
+	return diagram.InclinedAxisPlot(data)
+}
+
+// Forces returns the bending moment or shear force diagram of a beam
+func Forces(data ForceDiagramData) (*plot.Plot, error) {
+	return diagram.ForcePlot(data)
+}
+
+// Envelope returns the moment envelope of a beam over the moments of its
+// combinations, with the design values annotated
+func Envelope(data EnvelopeDiagramData) (*plot.Plot, error) {
+	return diagram.EnvelopePlot(data)
+}
+
+// Elevation returns the elevation of a beam with its supports, spans and
+// loads, without the bending moment diagram DrawElevation draws below it
+func Elevation(data ElevationDiagramData) (*plot.Plot, error) {
+	return diagram.ElevationPlot(data)
+}
+
+// ShearCapacity returns the factored shear along a beam against the shear
+// strength of the concrete and of its stirrup zones
+func ShearCapacity(data ShearCapacityData) (*plot.Plot, error) {
+	return diagram.ShearCapacityPlot(data)
+}
+
+// Stirrups returns the elevation of the stirrup zones of a beam
+func Stirrups(data StirrupDiagramData) (*plot.Plot, error) {
+	return diagram.StirrupPlot(data)
+}
+
+// Cutoffs returns the elevation of the bars of a beam with their cutoff
+// points and laps
+func Cutoffs(data CutoffDiagramData) (*plot.Plot, error) {
+	return diagram.CutoffPlot(data)
+}
+
+// Deflection returns the deflected shapes of a span with their limits
+func Deflection(data DeflectionDiagramData) (*plot.Plot, error) {
+	return diagram.DeflectionPlot(data)
+}
+
+// Chart returns a design aid chart of curves against a common axis
+func Chart(data ChartData) (*plot.Plot, error) {
+	return diagram.ChartPlot(data)
+}
+
+// DrawCombined draws a section, its strain profile and its stress block
+// with their forces side by side on a canvas, a height of the section level
+// across them
+func DrawCombined(c draw.Canvas, data SectionDiagramData) error {
+	return diagram.DrawCombinedDiagram(c, data)
+}
+
+// DrawComparison draws two sections side by side on a canvas, to the same
+// scale with their key results under them
+func DrawComparison(c draw.Canvas, data ComparisonData) error {
+	return diagram.DrawComparisonDiagram(c, data)
+}
+
+// DrawElevation draws the elevation of a beam on a canvas, above its
+// bending moment diagram when the data has one
+func DrawElevation(c draw.Canvas, data ElevationDiagramData) error {
+	return diagram.DrawElevationDiagram(c, data)
+}
+
+// DrawFibers draws a section with each fiber colored by its stress or
+// strain on a canvas, beside the color scale
+func DrawFibers(c draw.Canvas, data FiberDiagramData) error {
+	return diagram.DrawFiberDiagram(c, data)
+}
+
+// DrawConvergence draws step i of the equilibrium iteration of a section on
+// a canvas, the section at the depth of the step beside the force imbalance
+// of the steps up to it
+func DrawConvergence(c draw.Canvas, data ConvergenceData, i int) error {
+	return diagram.DrawConvergenceFrame(c, data, i)
+}
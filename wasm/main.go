@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+// Command wasm compiles the beam, batch and section engines to WebAssembly for
+// a browser calculator without a backend. It sets a global gorcb object with
+//
+//	gorcb.version                      the gorcb version
+//	gorcb.codes                        keys of the design codes, e.g. "aci318-19"
+//	gorcb.beam(json[, code])           as POST /v1/beam of gorcb serve
+//	gorcb.batch(json[, code])          as POST /v1/batch
+//	gorcb.analyzeSection(json[, code]) as POST /v1/section/analyze
+//
+// The functions take and return JSON strings: the response of the request,
+// or {"error": {"code": ..., "message": ..., "field": ...}} as described by
+// the OpenAPI specification of gorcb serve. The design code defaults to
+// NSCP 2015.
+//
+// Build it and copy the Go loader next to it:
+//
+//	GOOS=js GOARCH=wasm go build -o gorcb.wasm ./wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// then load it in a page:
+//
+//	<script src="wasm_exec.js"></script>
+//	<script>
+//	  const go = new Go();
+//	  WebAssembly.instantiateStreaming(fetch("gorcb.wasm"), go.importObject)
+//	    .then(r => { go.run(r.instance); console.log(gorcb.beam('{"width":300,"height":500,"mu":180}')); });
+//	</script>
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/alexiusacademia/gorcb/internal/api"
+	"github.com/alexiusacademia/gorcb/internal/codes"
+	"github.com/alexiusacademia/gorcb/internal/version"
+)
+
+func main() {
+	names := make([]any, 0, len(codes.Names()))
+	for _, name := range codes.Names() {
+		names = append(names, name)
+	}
+	js.Global().Set("gorcb", js.ValueOf(map[string]any{
+		"version":        version.Version,
+		"codes":          names,
+		"beam":           export((*api.Engine).Beam),
+		"batch":          export((*api.Engine).Batch),
+		"analyzeSection": export((*api.Engine).AnalyzeSection),
+	}))
+
+	// Keep the exported functions alive
+	select {}
+}
+
+// export returns a JavaScript function of an engine request, taking the
+// request JSON and an optional design code key and returning the JSON of the
+// response or of the error
+func export(run func(e *api.Engine, data []byte) (*api.Response, *api.Error)) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) == 0 || args[0].Type() != js.TypeString {
+			return encode(api.ErrorResponse{Error: &api.Error{Code: api.CodeInvalidJSON,
+				Message: "the request must be a JSON string"}})
+		}
+		code := codes.Default()
+		if len(args) > 1 && args[1].Type() == js.TypeString {
+			c, err := codes.Get(args[1].String())
+			if err != nil {
+				return encode(api.ErrorResponse{Error: &api.Error{Code: api.CodeInvalidInput,
+					Message: err.Error(), Field: "code"}})
+			}
+			code = c
+		}
+		resp, apiErr := run(api.New(code), []byte(args[0].String()))
+		if apiErr != nil {
+			return encode(api.ErrorResponse{Error: apiErr})
+		}
+		return encode(resp)
+	})
+}
+
+// encode returns v as a JSON string
+func encode(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(api.ErrorResponse{Error: &api.Error{Code: api.CodeInvalidInput, Message: err.Error()}})
+	}
+	return string(data)
+}